@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	limitLogic "backend/app/internal/logic/limit"
+	"backend/app/internal/repo"
+	baseRepo "backend/app/internal/repo/base"
+	"backend/app/plugins"
+	"backend/utils/buildinfo"
+	"backend/utils/readonly"
+	"backend/utils/secret"
+
+	"go.uber.org/fx"
+)
+
+// 管理员账户的用户名和密码长度限制（与登录接口的参数校验一致）
+const (
+	maxUsernameLength = 16
+	minPasswordLength = 8
+	maxPasswordLength = 16
+)
+
+// command 命令行子命令
+type command struct {
+	name    string                    // 子命令名称
+	usage   string                    // 参数说明
+	summary string                    // 功能说明
+	loadEnv bool                      // 是否需要加载环境变量和配置文件
+	run     func(args []string) error // 执行函数
+}
+
+// commands 返回所有子命令
+func commands() []command {
+	return []command{
+		{name: "serve", summary: "启动 HTTP 服务（默认）", loadEnv: true, run: runServe},
+		{name: "migrate", usage: "up|down --force|status", summary: "创建/删除数据表或查看表结构版本", loadEnv: true, run: runMigrate},
+		{name: "seed", summary: "初始化系统配置和默认管理员账户", loadEnv: true, run: runSeed},
+		{name: "create-admin", usage: "--username <name> [--password <password>]", summary: "创建管理员账户，未指定密码时从标准输入读取", loadEnv: true, run: runCreateAdmin},
+		{name: "set-rate-limit-tier", usage: "--username <name> --tier <tier>", summary: "设置用户的限流档位，档位为空时恢复默认档位", loadEnv: true, run: runSetRateLimitTier},
+		{name: "hash-password", usage: "[password]", summary: "生成密码哈希，未指定密码时从标准输入读取", run: runHashPassword},
+		{name: "reindex", summary: "重建项目搜索索引", loadEnv: true, run: runReindexItems},
+		{name: "check-config", summary: "校验配置后退出", loadEnv: true, run: runCheckConfig},
+		{name: "version", summary: "输出版本信息", run: runVersion},
+	}
+}
+
+// findCommand 按名称查找子命令
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// usage 输出命令行帮助
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "用法: %s [选项] [子命令] [参数]\n\n子命令:\n", os.Args[0])
+	for _, cmd := range commands() {
+		fmt.Fprintf(out, "  %-15s %s\n", cmd.name, cmd.summary)
+		if cmd.usage != "" {
+			fmt.Fprintf(out, "  %-15s   %s %s\n", "", cmd.name, cmd.usage)
+		}
+	}
+	fmt.Fprintln(out, "\n选项:")
+	flag.PrintDefaults()
+}
+
+// runWithBaseRepo 初始化数据库相关模块（不启动 HTTP 服务、不自动迁移）后执行 fn
+func runWithBaseRepo(fn func(ctx context.Context, r *baseRepo.BaseRepo) error) error {
+	var r *baseRepo.BaseRepo
+
+	app := fx.New(
+		fx.NopLogger,
+		plugins.PluginsModule,
+		repo.RepoModule,
+		fx.Provide(baseRepo.NewBaseRepo),
+		fx.Populate(&r),
+	)
+	if err := app.Err(); err != nil {
+		return fmt.Errorf("初始化失败: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		return fmt.Errorf("启动失败: %w", err)
+	}
+	defer app.Stop(ctx)
+
+	return fn(ctx, r)
+}
+
+// checkWritable 只读模式下拒绝执行写入数据库的子命令
+func checkWritable() error {
+	if readonly.Enabled() {
+		return fmt.Errorf("只读模式下不能执行该命令: %s", readonly.Reason())
+	}
+	return nil
+}
+
+// runMigrate 执行表结构迁移
+func runMigrate(args []string) error {
+	if len(args) == 0 {
+		return errors.New("用法: migrate up|down --force|status")
+	}
+	action := args[0]
+
+	fs := flag.NewFlagSet("migrate "+action, flag.ContinueOnError)
+	force := fs.Bool("force", false, "确认删除所有数据表（down）")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch action {
+	case "up":
+		return runWithBaseRepo(func(ctx context.Context, r *baseRepo.BaseRepo) error {
+			if err := checkWritable(); err != nil {
+				return err
+			}
+			if err := r.MigrateUp(ctx); err != nil {
+				return fmt.Errorf("迁移失败: %w", err)
+			}
+			fmt.Printf("迁移完成，表结构版本 %d\n", baseRepo.SchemaVersion)
+			return nil
+		})
+	case "down":
+		if !*force {
+			return errors.New("migrate down 会删除所有数据表和数据，确认执行请加 --force")
+		}
+		return runWithBaseRepo(func(ctx context.Context, r *baseRepo.BaseRepo) error {
+			if err := checkWritable(); err != nil {
+				return err
+			}
+			if err := r.MigrateDown(ctx); err != nil {
+				return fmt.Errorf("删除数据表失败: %w", err)
+			}
+			fmt.Println("已删除所有数据表")
+			return nil
+		})
+	case "status":
+		return runWithBaseRepo(func(ctx context.Context, r *baseRepo.BaseRepo) error {
+			status, err := r.MigrationStatus(ctx)
+			if err != nil {
+				return fmt.Errorf("查询迁移状态失败: %w", err)
+			}
+			current := status.CurrentVersion
+			if current == "" {
+				current = "未迁移"
+			}
+			fmt.Printf("表结构版本: %s（最新 %d）\n", current, status.LatestVersion)
+			for _, table := range status.Tables {
+				state := "存在"
+				if !table.Exists {
+					state = "缺失"
+				}
+				fmt.Printf("  %-16s %s\n", table.Name, state)
+			}
+			if !status.UpToDate() {
+				fmt.Println("需要执行 migrate up")
+			}
+			return nil
+		})
+	default:
+		return fmt.Errorf("未知的迁移操作: %s，可选值: up, down, status", action)
+	}
+}
+
+// runSeed 初始化系统配置和默认管理员账户
+func runSeed(args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return runWithBaseRepo(func(ctx context.Context, r *baseRepo.BaseRepo) error {
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		status, err := r.MigrationStatus(ctx)
+		if err != nil {
+			return fmt.Errorf("查询迁移状态失败: %w", err)
+		}
+		if !status.UpToDate() {
+			return errors.New("表结构不是最新版本，请先执行 migrate up")
+		}
+		if err := r.Seed(ctx); err != nil {
+			return fmt.Errorf("初始化数据失败: %w", err)
+		}
+		fmt.Println("初始化数据完成")
+		return nil
+	})
+}
+
+// runCreateAdmin 创建管理员账户
+func runCreateAdmin(args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	username := fs.String("username", "", "用户名")
+	password := fs.String("password", "", "密码，未指定时从标准输入读取（避免出现在 shell 历史中）")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" || utf8.RuneCountInString(*username) > maxUsernameLength {
+		return fmt.Errorf("用户名不能为空且不能超过 %d 个字符", maxUsernameLength)
+	}
+	if *password == "" {
+		line, err := readPassword()
+		if err != nil {
+			return err
+		}
+		*password = line
+	}
+	if n := utf8.RuneCountInString(*password); n < minPasswordLength || n > maxPasswordLength {
+		return fmt.Errorf("密码长度需要在 %d 到 %d 个字符之间", minPasswordLength, maxPasswordLength)
+	}
+
+	return runWithBaseRepo(func(ctx context.Context, r *baseRepo.BaseRepo) error {
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		if err := r.CreateAdmin(ctx, *username, *password); err != nil {
+			return fmt.Errorf("创建管理员失败: %w", err)
+		}
+		fmt.Printf("管理员 %s 创建完成\n", *username)
+		return nil
+	})
+}
+
+// runSetRateLimitTier 设置用户的限流档位，档位需要在 RATE_LIMIT_TIERS 中配置
+func runSetRateLimitTier(args []string) error {
+	fs := flag.NewFlagSet("set-rate-limit-tier", flag.ContinueOnError)
+	username := fs.String("username", "", "用户名")
+	tier := fs.String("tier", "", "限流档位，为空时恢复默认档位")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *username == "" {
+		return errors.New("用户名不能为空")
+	}
+	if *tier != "" {
+		tiers, err := limitLogic.LoadTiers()
+		if err != nil {
+			return err
+		}
+		if !tiers.Has(*tier) {
+			return fmt.Errorf("未配置的限流档位: %s", *tier)
+		}
+	}
+
+	return runWithBaseRepo(func(ctx context.Context, r *baseRepo.BaseRepo) error {
+		if err := checkWritable(); err != nil {
+			return err
+		}
+		if err := r.SetRateLimitTier(ctx, *username, *tier); err != nil {
+			return fmt.Errorf("设置限流档位失败: %w", err)
+		}
+		if *tier == "" {
+			fmt.Printf("用户 %s 已恢复默认限流档位\n", *username)
+		} else {
+			fmt.Printf("用户 %s 的限流档位已设置为 %s\n", *username, *tier)
+		}
+		return nil
+	})
+}
+
+// runHashPassword 生成密码哈希，可用于手动修改数据库中的密码
+func runHashPassword(args []string) error {
+	fs := flag.NewFlagSet("hash-password", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	password := fs.Arg(0)
+	if password == "" {
+		line, err := readPassword()
+		if err != nil {
+			return err
+		}
+		password = line
+	}
+
+	hash, err := secret.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("生成密码哈希失败: %w", err)
+	}
+	fmt.Println(hash)
+	return nil
+}
+
+// runVersion 输出版本信息
+func runVersion(args []string) error {
+	info := buildinfo.Get()
+	fmt.Printf("version:    %s\n", info.Version)
+	fmt.Printf("git commit: %s\n", info.GitCommit)
+	fmt.Printf("build time: %s\n", info.BuildTime)
+	fmt.Printf("go version: %s\n", info.GoVersion)
+	return nil
+}
+
+// readPassword 从标准输入读取一行密码
+func readPassword() (string, error) {
+	fmt.Fprint(os.Stderr, "请输入密码: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("读取密码失败: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("密码不能为空")
+	}
+	return password, nil
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"backend/app/plugins/db"
+	"backend/app/plugins/sentry"
+	"backend/app/plugins/sse"
+	"backend/app/plugins/startup"
+	"backend/app/plugins/telemetry"
+	"backend/app/server/middleware"
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/lofile"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// minJWTSecretLength JWT 密钥的最小长度（HS256 密钥建议不少于 32 字节）
+const minJWTSecretLength = 32
+
+// serverEnv 启动前校验的 HTTP、JWT、存储和管理员账户配置（变量说明见 consts）
+type serverEnv struct {
+	HTTPPort            int           `env:"HTTP_PORT" default:"8080" min:"1"`
+	GINMode             string        `env:"GIN_MODE" default:"release"`
+	JWTSecret           string        `env:"JWT_SECRET" required:"true"`
+	AccessTokenExpire   time.Duration `env:"ACCESS_TOKEN_EXPIRE" required:"true" min:"1"`
+	RefreshTokenExpire  time.Duration `env:"REFRESH_TOKEN_EXPIRE" required:"true" min:"1"`
+	EmailVerifyExpire   time.Duration `env:"EMAIL_VERIFY_EXPIRE" default:"24h"`
+	PasswordResetExpire time.Duration `env:"PASSWORD_RESET_EXPIRE" default:"1h"`
+	StorageLocalPath    string        `env:"STORAGE_LOCAL_PATH" required:"true"`
+	StorageLocalBaseURL string        `env:"STORAGE_LOCAL_BASE_URL" required:"true"`
+	// 管理员账户只在数据库没有用户时使用，配置了才校验
+	AdminUsername string `env:"ADMIN_USERNAME"`
+	AdminPassword string `env:"ADMIN_PASSWORD"`
+}
+
+// checkConfig 启动前校验配置，所有问题汇总为一份报告返回
+// 避免启动到一半（或处理请求时）才因为配置缺失或无效而失败
+func checkConfig() error {
+	var agg errorx.Aggregate
+
+	var env serverEnv
+	agg.Add(envx.Bind(&env))
+	if env.HTTPPort > 65535 {
+		agg.Add(fmt.Errorf("环境变量 %s 必须在 1-65535 之间，当前值: %d", consts.HTTPPort, env.HTTPPort))
+	}
+	switch env.GINMode {
+	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+	default:
+		agg.Add(fmt.Errorf("环境变量 %s 无效: %s，可选值: debug, release, test", consts.GINMode, env.GINMode))
+	}
+	if env.JWTSecret != "" && len(env.JWTSecret) < minJWTSecretLength {
+		agg.Add(fmt.Errorf("环境变量 %s 长度不能少于 %d 个字符，当前 %d 个", consts.JWTSecret, minJWTSecretLength, len(env.JWTSecret)))
+	}
+	if env.AccessTokenExpire > 0 && env.RefreshTokenExpire > 0 && env.RefreshTokenExpire < env.AccessTokenExpire {
+		agg.Add(fmt.Errorf("环境变量 %s 不能小于 %s", consts.RefreshTokenExpire, consts.AccessTokenExpire))
+	}
+	if env.StorageLocalPath != "" {
+		if err := lofile.CheckWritable(env.StorageLocalPath); err != nil {
+			agg.Add(fmt.Errorf("环境变量 %s 配置错误: %w", consts.StorageLocalPath, err))
+		}
+	}
+	if n := utf8.RuneCountInString(env.AdminUsername); n > maxUsernameLength {
+		agg.Add(fmt.Errorf("环境变量 %s 长度不能超过 %d 个字符", consts.AdminUsername, maxUsernameLength))
+	}
+	if n := utf8.RuneCountInString(env.AdminPassword); n > 0 && (n < minPasswordLength || n > maxPasswordLength) {
+		agg.Add(fmt.Errorf("环境变量 %s 长度需要 %d-%d 个字符", consts.AdminPassword, minPasswordLength, maxPasswordLength))
+	}
+
+	if _, err := middleware.AdminUserIDs(); err != nil {
+		agg.Add(err)
+	}
+
+	if _, err := startup.Timeout(); err != nil {
+		agg.Add(err)
+	}
+	if _, err := startup.Backoff(); err != nil {
+		agg.Add(err)
+	}
+	agg.Add(db.CheckConfig())
+	agg.Add(sse.CheckConfig())
+	agg.Add(telemetry.CheckConfig())
+	agg.Add(sentry.CheckConfig())
+	agg.Add(logs.CheckLokiConfig())
+
+	if err := agg.Err(); err != nil {
+		return configReport(err)
+	}
+	return nil
+}
+
+// configReport 将配置问题汇总为一份报告，每个问题一行
+func configReport(err error) error {
+	errs := []error{err}
+	var multi errorx.MultiError
+	if errors.As(err, &multi) {
+		errs = multi.Errors()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "配置校验失败，共 %d 个问题:", len(errs))
+	for _, e := range errs {
+		fmt.Fprintf(&b, "\n  - %s", errorx.ErrorWithoutStack(e))
+	}
+	return errors.New(b.String())
+}
+
+// runCheckConfig 校验配置后退出，用于部署前检查
+func runCheckConfig(args []string) error {
+	fs := flag.NewFlagSet("check-config", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := checkConfig(); err != nil {
+		return err
+	}
+	fmt.Println("配置校验通过")
+	return nil
+}
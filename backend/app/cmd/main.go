@@ -1,16 +1,23 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"os"
 
+	"backend/app/extensions"
 	"backend/app/internal/handler"
 	"backend/app/internal/logic"
 	"backend/app/internal/repo"
 	"backend/app/plugins"
+	"backend/app/plugins/startup"
 	"backend/app/server"
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/handle"
 
-	"github.com/joho/godotenv"
 	"go.uber.org/fx"
 )
 
@@ -30,21 +37,119 @@ import (
 // @schemes http https
 func main() {
 	envFile := flag.String("env", ".env", "环境变量文件")
+	configFile := flag.String("config", "", "配置文件（YAML/TOML），未指定时读取环境变量 CONFIG_FILE")
+	reindexItems := flag.Bool("reindex-items", false, "重建项目搜索索引后退出（等同于 reindex 子命令）")
+	flag.Usage = usage
 	flag.Parse()
 
-	if err := godotenv.Load(*envFile); err != nil {
-		fmt.Println("加载环境变量失败", err.Error())
-		return
+	// 未指定子命令时启动 HTTP 服务
+	name, args := "serve", []string(nil)
+	if flag.NArg() > 0 {
+		name, args = flag.Arg(0), flag.Args()[1:]
+	}
+	if *reindexItems {
+		name = "reindex"
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "未知的子命令", name)
+		usage()
+		os.Exit(2)
+	}
+
+	if cmd.loadEnv {
+		if err := loadEnv(*envFile, *configFile); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+	}
+
+	if err := cmd.run(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+// loadEnv 加载环境变量、配置文件和敏感配置，并应用全局配置
+func loadEnv(envFile, configFile string) error {
+	if err := envx.LoadEnvFile(envFile); err != nil {
+		return fmt.Errorf("加载环境变量失败: %w", err)
+	}
+
+	// 配置文件只填充未设置的环境变量
+	if configFile == "" {
+		configFile = envx.GetStringOptional(consts.ConfigFile)
+	}
+	if configFile != "" {
+		if _, err := envx.LoadFile(configFile); err != nil {
+			return fmt.Errorf("加载配置文件失败: %w", err)
+		}
+	}
+
+	// 从文件读取敏感配置（*_FILE）
+	if err := envx.ResolveFiles(consts.SecretFileKeys...); err != nil {
+		return fmt.Errorf("读取敏感配置文件失败: %w", err)
+	}
+
+	// 错误堆栈捕获开关和深度
+	errorx.SetStackEnabled(envx.GetBool(consts.EnvLogErrorStack, true))
+	stackDepth, err := envx.GetIntWithDefaultAndMin(consts.EnvLogErrorStackDepth, errorx.DefaultStackDepth, 1)
+	if err != nil {
+		return err
+	}
+	errorx.SetStackDepth(stackDepth)
+
+	// 错误响应格式
+	switch format := envx.GetStringOptional(consts.ErrorResponseFormat); format {
+	case "", "legacy":
+	case "problem":
+		handle.SetProblemDetails(true)
+	default:
+		return fmt.Errorf("未知的错误响应格式: %s", format)
+	}
+
+	// 响应格式协商
+	formats, err := handle.ParseResponseFormats(envx.GetStringOptional(consts.ResponseFormats))
+	if err != nil {
+		return err
+	}
+	handle.SetResponseFormats(formats...)
+	return nil
+}
+
+// runServe 启动 HTTP 服务
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// 创建模块前校验配置，一次输出所有问题
+	if err := checkConfig(); err != nil {
+		return err
+	}
+
+	startupTimeout, err := startup.Timeout()
+	if err != nil {
+		return fmt.Errorf("读取启动配置失败: %w", err)
 	}
 
 	app := fx.New(
 		// fx.NopLogger,
+		fx.StartTimeout(startupTimeout),
 		// 基础设施模块
 		plugins.PluginsModule,
 
 		// 应用层模块
 		repo.RepoModule,
+		repo.InitBaseDataModule,
 		logic.LogicModule,
+		logic.JobsModule,
+		extensions.ExtensionsModule,
 		handler.HandlerModule,
 
 		// 服务器模块
@@ -52,4 +157,5 @@ func main() {
 	)
 
 	app.Run()
+	return nil
 }
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"backend/app/internal/logic"
+	itemLogic "backend/app/internal/logic/item"
+	"backend/app/internal/repo"
+	"backend/app/plugins"
+
+	"go.uber.org/fx"
+)
+
+// runReindexItems 全量重建项目搜索索引
+// 只启动基础设施、数据层和业务逻辑层，不启动 HTTP 服务
+func runReindexItems(args []string) error {
+	fs := flag.NewFlagSet("reindex", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var l *itemLogic.ItemLogic
+
+	app := fx.New(
+		fx.NopLogger,
+		plugins.PluginsModule,
+		repo.RepoModule,
+		repo.InitBaseDataModule,
+		logic.LogicModule,
+		fx.Populate(&l),
+	)
+	if err := app.Err(); err != nil {
+		return fmt.Errorf("初始化失败: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		return fmt.Errorf("启动失败: %w", err)
+	}
+	defer app.Stop(ctx)
+
+	count, err := l.ReindexItems(ctx)
+	if err != nil {
+		return fmt.Errorf("重建项目索引失败: %w", err)
+	}
+	fmt.Printf("重建项目索引完成，共 %d 条\n", count)
+	return nil
+}
@@ -24,25 +24,74 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/file/upload": {
-            "post": {
-                "description": "上传文件到服务器，支持多种文件类型",
+        "/api/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按时间倒序分页查询登录、刷新令牌、修改邮箱、重置密码、删除项目和标签、修改 webhook 等操作的审计日志\n日期支持 YYYY-MM-DD 和 today、last-7-days 等相对日期，date_end 包含当天",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "文件管理"
+                    "审计日志"
                 ],
-                "summary": "上传文件",
+                "summary": "查询审计日志",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "要上传的文件",
-                        "name": "file",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "操作人ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "item",
+                            "tag",
+                            "webhook",
+                            "system"
+                        ],
+                        "type": "string",
+                        "description": "数据类型",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作，如 login、login_failed、deleted",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "date_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "date_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -58,7 +107,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_file.UploadFileResp"
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_AuditLogDTO"
                                         }
                                     }
                                 }
@@ -71,6 +120,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -80,35 +141,112 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/item": {
-            "post": {
+        "/api/admin/audit/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "创建一个新项目",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "按查询条件导出审计日志 CSV（UTF-8 BOM），按时间倒序，超过 AUDIT_EXPORT_MAX_ROWS 行时返回错误",
                 "produces": [
-                    "application/json"
+                    "text/csv"
                 ],
                 "tags": [
-                    "项目管理"
+                    "审计日志"
                 ],
-                "summary": "创建项目",
+                "summary": "导出审计日志",
                 "parameters": [
                     {
-                        "description": "创建项目请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "操作人ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "item",
+                            "tag",
+                            "webhook",
+                            "system"
+                        ],
+                        "type": "string",
+                        "description": "数据类型",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "操作，如 login、login_failed、deleted",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "开始日期",
+                        "name": "date_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期",
+                        "name": "date_end",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV 文件",
                         "schema": {
-                            "$ref": "#/definitions/app_internal_handler_item.CreateItemReq"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或超过导出上限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     }
+                }
+            }
+        },
+        "/api/admin/log-level": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前实例的日志级别",
+                "consumes": [
+                    "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取日志级别",
                 "responses": {
                     "200": {
                         "description": "成功",
@@ -121,36 +259,34 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
+                                            "$ref": "#/definitions/backend_app_types_dto.LogLevelDTO"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
+                    "401": {
+                        "description": "未认证",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     }
                 }
-            }
-        },
-        "/api/item/daily-count": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取每日项目数量",
+                "description": "运行时修改当前实例的日志级别，不需要重启；修改只保存在内存中，LOG_LEVEL 配置重新加载或重启后以配置为准",
                 "consumes": [
                     "application/json"
                 ],
@@ -158,23 +294,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
+                    "系统"
                 ],
-                "summary": "获取每日项目数量",
+                "summary": "修改日志级别",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "开始日期",
-                        "name": "date_start",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束日期",
-                        "name": "date_end",
-                        "in": "query",
-                        "required": true
+                        "description": "日志级别",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_system.SetLogLevelReq"
+                        }
                     }
                 ],
                 "responses": {
@@ -189,7 +320,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_item.GetDailyItemCountResp"
+                                            "$ref": "#/definitions/backend_app_types_dto.LogLevelDTO"
                                         }
                                     }
                                 }
@@ -202,8 +333,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -211,14 +348,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/item/list": {
+        "/api/admin/stats": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取项目列表，支持分页和筛选",
+                "description": "获取用户数、按状态统计的项目数、文件数和存储用量、正在运行的 SSE 任务数，以及统计窗口内的请求数、平均每分钟请求数和错误率\n请求数和 SSE 任务数只统计当前实例（进程重启后清零），请求只统计 /api 下的路由",
                 "consumes": [
                     "application/json"
                 ],
@@ -226,38 +363,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
+                    "管理"
                 ],
-                "summary": "获取项目列表",
+                "summary": "获取管理后台统计",
                 "parameters": [
                     {
+                        "enum": [
+                            "5m",
+                            "15m",
+                            "1h",
+                            "6h",
+                            "24h"
+                        ],
                         "type": "string",
-                        "description": "开始日期",
-                        "name": "date_start",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "结束日期",
-                        "name": "date_end",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "状态",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "每页条数",
-                        "name": "page_size",
+                        "description": "请求统计窗口，默认 1h",
+                        "name": "window",
                         "in": "query"
                     }
                 ],
@@ -273,7 +393,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_item.GetItemListResp"
+                                            "$ref": "#/definitions/backend_app_types_dto.AdminStatsDTO"
                                         }
                                     }
                                 }
@@ -286,6 +406,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -295,30 +427,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/item/{item_id}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "获取指定项目的详细信息",
+        "/api/file/upload": {
+            "post": {
+                "description": "上传文件到服务器，支持多种文件类型",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
+                    "文件管理"
                 ],
-                "summary": "获取项目",
+                "summary": "上传文件",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "项目ID",
-                        "name": "item_id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "要上传的文件",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -334,7 +461,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
+                                            "$ref": "#/definitions/app_internal_handler_file.UploadFileResp"
                                         }
                                     }
                                 }
@@ -347,12 +474,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "404": {
-                        "description": "项目不存在",
-                        "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -360,14 +481,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/graphql": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新指定项目的信息",
+                "description": "通过 GraphQL 查询项目、标签、用户信息和统计，项目的 tags 字段在同一次请求中解析；变更操作调用与 REST 接口相同的业务逻辑。业务错误的 extensions 中包含 code（错误码）和 status（对应的 HTTP 状态码），schema 见 app/internal/handler/graph/schema.graphqls",
                 "consumes": [
                     "application/json"
                 ],
@@ -375,113 +498,97 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "项目管理"
+                    "GraphQL"
                 ],
-                "summary": "更新项目",
+                "summary": "GraphQL 查询",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "项目ID",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "更新项目请求",
+                        "description": "GraphQL 请求（query、variables、operationName）",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/app_internal_handler_item.UpdateItemReq"
+                            "type": "object"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/backend_utils_handle.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
-                        }
-                    },
-                    "404": {
-                        "description": "项目不存在",
+                        "description": "GraphQL 响应（data、errors）",
                         "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
-                        "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
+                            "type": "object"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "删除指定项目",
+                "description": "解析导入文件后通过后台任务队列创建标签和项目，通过 SSE 推送进度（事件名 progress），stage 依次为 queued、tags、items，最后为 completed 或 failed。\n导入任务只执行一次，失败时已创建的项目保留；客户端断开不影响导入\n带 dry_run=true 查询参数或 X-Dry-Run: true 请求头时不导入，以 JSON 返回与预览导入相同的结果",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "项目管理"
+                    "数据导入"
                 ],
-                "summary": "删除项目",
+                "summary": "导入数据",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "项目ID",
-                        "name": "item_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "导入格式",
+                        "name": "format",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "导出文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
+                        "description": "progress 事件",
                         "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
+                            "$ref": "#/definitions/backend_app_types_dto.ImportProgressDTO"
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "请求参数错误或解析失败",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "404": {
-                        "description": "项目不存在",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "413": {
+                        "description": "文件过大",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "启动导入任务失败",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -489,35 +596,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tag": {
-            "post": {
+        "/api/import/formats": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "创建一个新标签",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "返回可用于 format 参数的导入格式：todoist（项目 CSV 或备份 zip）、notion（Markdown \u0026 CSV 导出的 zip）、markdown（单个文件或 zip）",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "标签管理"
-                ],
-                "summary": "创建标签",
-                "parameters": [
-                    {
-                        "description": "创建标签请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app_internal_handler_tag.CreateTagReq"
-                        }
-                    }
+                    "数据导入"
                 ],
+                "summary": "获取支持的导入格式",
                 "responses": {
                     "200": {
                         "description": "成功",
@@ -530,21 +623,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                                            "$ref": "#/definitions/app_internal_handler_importer.FormatsResp"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "请求参数错误",
-                        "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -552,36 +639,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tag/list": {
-            "get": {
+        "/api/import/preview": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取标签列表，支持分页",
+                "description": "解析导入文件，返回将创建的项目数、新建和复用的标签、跳过的内容及原因和前 20 个项目，不写入数据",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "标签管理"
+                    "数据导入"
                 ],
-                "summary": "获取标签列表",
+                "summary": "预览导入",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "页码",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "导入格式",
+                        "name": "format",
+                        "in": "formData",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "每页条数",
-                        "name": "page_size",
-                        "in": "query"
+                        "type": "file",
+                        "description": "导出文件",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -596,7 +685,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_tag.GetTagListResp"
+                                            "$ref": "#/definitions/backend_app_types_dto.ImportPreviewDTO"
                                         }
                                     }
                                 }
@@ -604,13 +693,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "请求参数错误",
+                        "description": "请求参数错误或解析失败",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "413": {
+                        "description": "文件过大",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -618,14 +713,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/tag/{tag_id}": {
-            "get": {
+        "/api/item": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取指定标签的详细信息",
+                "description": "创建一个新项目",
                 "consumes": [
                     "application/json"
                 ],
@@ -633,16 +728,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "标签管理"
+                    "项目管理"
                 ],
-                "summary": "获取标签",
+                "summary": "创建项目",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "标签ID",
-                        "name": "tag_id",
-                        "in": "path",
-                        "required": true
+                        "description": "创建项目请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_item.CreateItemReq"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行，写操作执行后回滚",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -657,7 +760,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                                            "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
                                         }
                                     }
                                 }
@@ -670,27 +773,74 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "404": {
-                        "description": "标签不存在",
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/item/calendar.ics": {
+            "get": {
+                "description": "以 iCalendar 格式返回订阅令牌对应用户创建的、设置了截止时间的项目，供 Google 日历、Apple 日历等订阅，不需要登录。\ncomponent 为 event（默认）时每个项目输出为日程，为 todo 时输出为带完成状态的待办",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "日历订阅",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "订阅令牌",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "event",
+                            "todo"
+                        ],
+                        "type": "string",
+                        "description": "日历组件",
+                        "name": "component",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar 日历",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "403": {
+                        "description": "订阅链接无效或已失效",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/item/daily-count": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新指定标签的信息",
+                "description": "获取每日项目数量",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,25 +848,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "标签管理"
+                    "项目管理"
                 ],
-                "summary": "更新标签",
+                "summary": "获取每日项目数量",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "标签ID",
-                        "name": "tag_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "开始日期（YYYY-MM-DD，或 today、yesterday、last-7-days、this-month 等相对日期）",
+                        "name": "date_start",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "更新标签请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app_internal_handler_tag.UpdateTagReq"
-                        }
+                        "type": "string",
+                        "description": "结束日期（同 date_start，相对日期按 X-Timezone 请求头的时区计算）",
+                        "name": "date_end",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -731,7 +879,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                                            "$ref": "#/definitions/app_internal_handler_item.GetDailyItemCountResp"
                                         }
                                     }
                                 }
@@ -744,12 +892,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "404": {
-                        "description": "标签不存在",
-                        "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
-                        }
-                    },
                     "500": {
                         "description": "服务器内部错误",
                         "schema": {
@@ -757,38 +899,38 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "删除指定标签",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/item/feed.atom": {
+            "get": {
+                "description": "以 Atom 格式返回订阅令牌对应用户最近创建或完成的项目，供订阅阅读器和自动化工具读取，不需要登录。需要启用 FEED_ATOM_ENABLED",
                 "produces": [
-                    "application/json"
+                    "application/atom+xml"
                 ],
                 "tags": [
-                    "标签管理"
+                    "项目管理"
                 ],
-                "summary": "删除标签",
+                "summary": "最近项目订阅源",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "标签ID",
-                        "name": "tag_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "订阅令牌",
+                        "name": "token",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "标签值，只返回带该标签的项目",
+                        "name": "tag",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "成功",
+                        "description": "Atom 订阅源",
                         "schema": {
-                            "$ref": "#/definitions/backend_utils_handle.Response"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -797,14 +939,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "404": {
-                        "description": "标签不存在",
+                    "403": {
+                        "description": "订阅链接无效或已失效",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "500": {
-                        "description": "服务器内部错误",
+                    "404": {
+                        "description": "订阅源未启用或标签不存在",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -812,14 +954,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/info": {
+        "/api/item/list": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "获取当前登录用户的基本信息和菜单列表",
+                "description": "获取项目列表，支持分页和筛选",
                 "consumes": [
                     "application/json"
                 ],
@@ -827,9 +969,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户认证"
+                    "项目管理"
+                ],
+                "summary": "获取项目列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "开始日期（YYYY-MM-DD，或 today、yesterday、last-7-days、this-month 等相对日期）",
+                        "name": "date_start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "结束日期（同 date_start，相对日期按 X-Timezone 请求头的时区计算）",
+                        "name": "date_end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "获取用户信息",
                 "responses": {
                     "200": {
                         "description": "成功",
@@ -842,15 +1016,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_user.GetUserInfoResp"
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_ItemDTO"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -862,14 +1036,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/item/search": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "更新当前登录用户的基本信息和菜单列表",
+                "description": "按关键词搜索项目内容和标签，配置 ElasticSearch 时使用全文检索",
                 "consumes": [
                     "application/json"
                 ],
@@ -877,18 +1053,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户认证"
+                    "项目管理"
                 ],
-                "summary": "更新用户信息",
+                "summary": "搜索项目",
                 "parameters": [
                     {
-                        "description": "更新用户信息请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app_internal_handler_user.UpateUserInfoReq"
-                        }
+                        "type": "string",
+                        "description": "关键词",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -903,15 +1095,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_user.UpateUserInfoResp"
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_ItemDTO"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "未授权",
+                    "400": {
+                        "description": "请求参数错误",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -925,9 +1117,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/user/login": {
-            "post": {
-                "description": "使用用户名、密码和验证码进行登录，返回访问令牌和刷新令牌",
+        "/api/item/{item_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取指定项目的详细信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -935,18 +1132,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户认证"
+                    "项目管理"
                 ],
-                "summary": "用户登录",
+                "summary": "获取项目",
                 "parameters": [
                     {
-                        "description": "登录请求",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/app_internal_handler_user.LoginReq"
-                        }
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -961,7 +1156,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_user.LoginResp"
+                                            "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
                                         }
                                     }
                                 }
@@ -974,8 +1169,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "401": {
-                        "description": "用户名或密码错误",
+                    "404": {
+                        "description": "项目不存在",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -987,11 +1182,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/user/refresh-token": {
-            "post": {
-                "description": "使用刷新令牌获取新的访问令牌和刷新令牌",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新指定项目的信息",
                 "consumes": [
                     "application/json"
                 ],
@@ -999,18 +1197,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "用户认证"
+                    "项目管理"
                 ],
-                "summary": "刷新访问令牌",
+                "summary": "更新项目",
                 "parameters": [
                     {
-                        "description": "刷新令牌请求",
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新项目请求",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/app_internal_handler_user.RefreshTokenReq"
+                            "$ref": "#/definitions/app_internal_handler_item.UpdateItemReq"
                         }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行，写操作执行后回滚",
+                        "name": "dry_run",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1025,7 +1236,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/app_internal_handler_user.RefreshTokenResp"
+                                            "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
                                         }
                                     }
                                 }
@@ -1038,8 +1249,73 @@ const docTemplate = `{
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
                     },
-                    "401": {
-                        "description": "刷新令牌无效或已过期",
+                    "404": {
+                        "description": "项目不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "项目已被修改（版本冲突）",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除指定项目",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "项目管理"
+                ],
+                "summary": "删除项目",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "项目ID",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行，写操作执行后回滚",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "项目不存在",
                         "schema": {
                             "$ref": "#/definitions/backend_utils_handle.Response"
                         }
@@ -1052,401 +1328,4088 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "app_internal_handler_file.UploadFileResp": {
-            "type": "object",
-            "properties": {
-                "file_id": {
-                    "description": "文件ID",
-                    "type": "integer"
-                },
-                "file_name": {
+        },
+        "/api/meta/errors": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取所有已注册的错误码、各语言的消息模板和默认 HTTP 状态码，按错误码升序排列，用于前端生成错误处理",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取错误码目录",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/backend_app_types_dto.ErrorCodeDTO"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notify/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前登录用户的邮件通知偏好，未设置时返回默认值",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通知"
+                ],
+                "summary": "获取通知偏好",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.NotificationPreferenceDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新当前登录用户的邮件通知偏好，每日摘要只发送给邮箱已验证的用户",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "通知"
+                ],
+                "summary": "更新通知偏好",
+                "parameters": [
+                    {
+                        "description": "更新通知偏好请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_notify.UpdatePreferenceReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.NotificationPreferenceDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sync/changes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按序号升序返回序号大于 since 的项目、标签变更；has_more 为 true 时以 latest 作为 since 继续请求",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "同步"
+                ],
+                "summary": "增量同步",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "起始序号，不包含",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 500,
+                        "description": "数量，最大 500",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.ChangeListDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sync/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "SSE 推送项目、标签的新增、修改和删除（事件名 change，id 为同步序号）。\n传 since 或 Last-Event-ID 请求头时先补发之后的历史变更；收到 done 事件后应带上 Last-Event-ID 重连",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "同步"
+                ],
+                "summary": "订阅变更",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "起始序号，不包含",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "最后收到的同步序号，优先于 since",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "change 事件",
+                        "schema": {
+                            "$ref": "#/definitions/backend_app_types_dto.ChangeDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "订阅失败",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/email/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "不经过任务队列直接发送一封测试邮件，用于检查 SMTP 配置，需要管理员权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "发送测试邮件",
+                "parameters": [
+                    {
+                        "description": "发送测试邮件请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_notify.SendTestEmailReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "502": {
+                        "description": "SMTP 服务器拒绝或无法连接",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取应用版本、Git 提交、表结构版本、数据库驱动和运行时长，用于确认当前部署",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取系统信息",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.SystemInfoDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取所有定时任务的调度表达式、下一次执行时间和最近一次执行结果",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取定时任务列表",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/backend_app_types_dto.JobStatusDTO"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/jobs/{name}/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在后台立即执行一次定时任务，执行结果通过定时任务列表查看",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "手动触发定时任务",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "任务名称",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "任务不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "任务正在执行",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/queue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取已注册的任务类型和各状态的任务数量",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取后台任务队列统计",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.QueueStatsDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/queue/jobs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按状态和类型分页查询后台任务，按创建时间倒序",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取后台任务列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "状态（pending、running、succeeded、dead）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "任务类型",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_QueueJobDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "手动添加不需要参数的管理任务（目前只有 item.reindex），任务由工作协程异步执行，需要管理员权限",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "添加后台任务",
+                "parameters": [
+                    {
+                        "description": "任务类型",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_system.EnqueueQueueJobReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.QueueJobDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或任务类型不允许手动添加",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/queue/jobs/{job_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取后台任务的参数、状态、执行次数和最近一次错误",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取后台任务详情",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.QueueJobDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "任务不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/queue/jobs/{job_id}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "将死信状态的后台任务重新放入队列，执行次数清零",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "重试死信任务",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "任务ID",
+                        "name": "job_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.QueueJobDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "任务不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "任务不是死信状态",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/system/sse/tasks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按状态和类型分页查询本实例上的 SSE 任务（导出、导入、变更订阅），按创建时间倒序；结束的任务保留到过期",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "系统"
+                ],
+                "summary": "获取 SSE 任务列表",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "状态（queued、running、paused、completed、failed、cancelled）",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "任务类型（export、import、sync）",
+                        "name": "kind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_SSETaskDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未认证",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "没有管理员权限",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tag": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "创建一个新标签",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签管理"
+                ],
+                "summary": "创建标签",
+                "parameters": [
+                    {
+                        "description": "创建标签请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_tag.CreateTagReq"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行，写操作执行后回滚",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tag/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取标签列表，支持分页",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签管理"
+                ],
+                "summary": "获取标签列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_TagDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tag/{tag_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取指定标签的详细信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签管理"
+                ],
+                "summary": "获取标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "标签不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新指定标签的信息",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签管理"
+                ],
+                "summary": "更新标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新标签请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_tag.UpdateTagReq"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行，写操作执行后回滚",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "标签不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除指定标签",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "标签管理"
+                ],
+                "summary": "删除标签",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "标签ID",
+                        "name": "tag_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "试运行，写操作执行后回滚",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "标签不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/telegram/webhook": {
+            "post": {
+                "description": "TELEGRAM_MODE=webhook 时由 Telegram 调用，使用 X-Telegram-Bot-Api-Secret-Token 请求头校验，不需要登录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "接收 Telegram 推送的消息",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "webhook 密钥",
+                        "name": "X-Telegram-Bot-Api-Secret-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "webhook 密钥错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "未启用 webhook 模式",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/email": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "修改当前登录用户的邮箱并发送验证邮件，邮箱未变化且未验证时重新发送验证邮件",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "修改邮箱",
+                "parameters": [
+                    {
+                        "description": "修改邮箱请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.UpdateEmailReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app_internal_handler_user.GetUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "邮箱已被使用",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/email/verify": {
+            "post": {
+                "description": "使用验证邮件中的令牌验证邮箱",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "验证邮箱",
+                "parameters": [
+                    {
+                        "description": "验证邮箱请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.VerifyEmailReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "链接无效或已过期",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/export": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "在后台把当前用户的资料、项目、标签和上传的文件打包为 zip（JSON 和 CSV 两种格式），通过 SSE 推送进度（事件名 progress）。\nstage 为 completed 时返回有时效的下载地址，为 failed 时返回失败原因；客户端断开不影响导出，导出进行中再次请求会加入同一个导出\n每条进度带有事件 ID，重连时带上 Last-Event-ID 请求头只补发之后的进度",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "导出账户数据",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "最后收到的事件 ID",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "progress 事件",
+                        "schema": {
+                            "$ref": "#/definitions/backend_app_types_dto.ExportProgressDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "503": {
+                        "description": "启动导出任务失败",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/export/download": {
+            "get": {
+                "description": "使用导出完成时返回的下载地址下载 zip 文件，链接在有效期内可重复下载，不需要登录",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "下载导出文件",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "下载令牌",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "导出文件",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "链接无效或已过期",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "410": {
+                        "description": "导出文件已被清理",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/export/pause": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "暂停当前用户进行中的导出，导出在当前阶段结束时停下，已推送的进度和 SSE 连接保持不变，恢复后继续；暂停期间导出超时仍然计时",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "暂停导出",
+                "responses": {
+                    "200": {
+                        "description": "暂停成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有运行中的导出",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/export/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "恢复当前用户已暂停的导出，进度继续通过原来的 SSE 连接推送",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "恢复导出",
+                "responses": {
+                    "200": {
+                        "description": "恢复成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "没有运行中的导出",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "导出没有暂停",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前用户的日历订阅地址和最近项目订阅源地址（启用时），首次获取时生成订阅密钥。订阅地址长期有效，重新生成后旧地址失效",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "获取订阅链接",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.FeedDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/feed/rotate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "重新生成当前用户的订阅密钥，之前的订阅地址全部失效，订阅地址泄露时使用",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "重新生成订阅链接",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.FeedDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/info": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取当前登录用户的基本信息和菜单列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "获取用户信息",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app_internal_handler_user.GetUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新当前登录用户的基本信息和菜单列表",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "更新用户信息",
+                "parameters": [
+                    {
+                        "description": "更新用户信息请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.UpateUserInfoReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app_internal_handler_user.UpateUserInfoResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/login": {
+            "post": {
+                "description": "使用用户名、密码和验证码进行登录，返回访问令牌和刷新令牌",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "用户登录",
+                "parameters": [
+                    {
+                        "description": "登录请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.LoginReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app_internal_handler_user.LoginResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "用户名或密码错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/password/forgot": {
+            "post": {
+                "description": "向已验证的邮箱发送重置密码邮件，邮箱不存在时同样返回成功",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "忘记密码",
+                "parameters": [
+                    {
+                        "description": "忘记密码请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.ForgotPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/password/reset": {
+            "post": {
+                "description": "使用重置密码邮件中的令牌设置新密码，每个链接只能使用一次",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "重置密码",
+                "parameters": [
+                    {
+                        "description": "重置密码请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.ResetPasswordReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误或链接无效",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/refresh-token": {
+            "post": {
+                "description": "使用刷新令牌获取新的访问令牌和刷新令牌",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "刷新访问令牌",
+                "parameters": [
+                    {
+                        "description": "刷新令牌请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_user.RefreshTokenReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/app_internal_handler_user.RefreshTokenResp"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "刷新令牌无效或已过期",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/telegram": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取 Telegram 机器人是否启用以及当前用户是否已绑定",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "获取 Telegram 绑定状态",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.TelegramStatusDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "解除当前用户与 Telegram 的绑定，未绑定时直接返回成功",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "解除 Telegram 绑定",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/telegram/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "生成一次性绑定码，在 TELEGRAM_LINK_CODE_TTL 内发送 /link \u003c绑定码\u003e 给机器人完成绑定，之前未使用的绑定码失效",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "用户认证"
+                ],
+                "summary": "生成 Telegram 绑定码",
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.TelegramLinkDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "未授权",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Telegram 机器人未启用",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "注册事件回调地址，secret 为空时自动生成；完整的签名密钥只在创建时返回",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "创建 webhook",
+                "parameters": [
+                    {
+                        "description": "创建 webhook 请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_webhook.CreateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.WebhookDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取 webhook 列表，支持分页",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取 webhook 列表",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_WebhookDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/{webhook_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "获取 webhook 详情，签名密钥只返回掩码",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取 webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.WebhookDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "webhook 不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "更新回调地址、订阅事件、说明或启用状态，rotate_secret 为 true 时重新生成签名密钥并返回",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "更新 webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "更新 webhook 请求",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/app_internal_handler_webhook.UpdateWebhookReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_app_types_dto.WebhookDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "webhook 不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "删除 webhook 及其投递记录",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "删除 webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "webhook 不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhook/{webhook_id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "按时间倒序获取 webhook 的投递记录，支持按状态过滤和分页",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhook"
+                ],
+                "summary": "获取 webhook 投递记录",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "WebhookID",
+                        "name": "webhook_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "retrying",
+                            "succeeded",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "投递状态",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "页码",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "每页条数",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "成功",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/backend_utils_handle.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/backend_utils_handle.Page-backend_app_types_dto_WebhookDeliveryDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "请求参数错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "webhook 不存在",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "服务器内部错误",
+                        "schema": {
+                            "$ref": "#/definitions/backend_utils_handle.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "app_internal_handler_file.UploadFileResp": {
+            "type": "object",
+            "properties": {
+                "file_id": {
+                    "description": "文件ID",
+                    "type": "integer"
+                },
+                "file_name": {
                     "description": "文件名",
                     "type": "string"
                 },
-                "file_url": {
-                    "description": "文件访问URL",
+                "file_url": {
+                    "description": "文件访问URL",
+                    "type": "string"
+                }
+            }
+        },
+        "app_internal_handler_importer.FormatsResp": {
+            "type": "object",
+            "properties": {
+                "formats": {
+                    "description": "导入格式",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "app_internal_handler_item.CreateItemReq": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 3,
+                    "example": "这是一个项目"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2025-01-01T18:00:00+08:00"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backend_app_types_meta.ItemStatus"
+                        }
+                    ],
+                    "example": "normal"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                }
+            }
+        },
+        "app_internal_handler_item.GetDailyItemCountResp": {
+            "type": "object",
+            "properties": {
+                "daily_item_counts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.DailyItemCountDTO"
+                    }
+                }
+            }
+        },
+        "app_internal_handler_item.UpdateItemReq": {
+            "type": "object",
+            "properties": {
+                "clear_due_at": {
+                    "description": "ClearDueAt 为 true 时清除截止时间，忽略 due_at",
+                    "type": "boolean",
+                    "example": false
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 3,
+                    "example": "这是一个项目"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2025-01-01T18:00:00+08:00"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backend_app_types_meta.ItemStatus"
+                        }
+                    ],
+                    "example": "normal"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        3
+                    ]
+                },
+                "version": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "app_internal_handler_notify.SendTestEmailReq": {
+            "type": "object",
+            "required": [
+                "to"
+            ],
+            "properties": {
+                "to": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "ops@example.com"
+                }
+            }
+        },
+        "app_internal_handler_notify.UpdatePreferenceReq": {
+            "type": "object",
+            "properties": {
+                "daily_digest": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "email_enabled": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "app_internal_handler_system.EnqueueQueueJobReq": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "example": "item.reindex"
+                }
+            }
+        },
+        "app_internal_handler_system.SetLogLevelReq": {
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "level": {
+                    "type": "string",
+                    "enum": [
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ],
+                    "example": "debug"
+                }
+            }
+        },
+        "app_internal_handler_tag.CreateTagReq": {
+            "type": "object",
+            "required": [
+                "tag_name",
+                "tag_value"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#1E90FF"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "tag_name": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "minLength": 1,
+                    "example": "工作"
+                },
+                "tag_value": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1,
+                    "example": "work"
+                }
+            }
+        },
+        "app_internal_handler_tag.UpdateTagReq": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string",
+                    "example": "#1E90FF"
+                },
+                "icon": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1
+                },
+                "tag_name": {
+                    "type": "string",
+                    "maxLength": 12,
+                    "minLength": 1
+                },
+                "tag_value": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 1
+                }
+            }
+        },
+        "app_internal_handler_user.ForgotPasswordReq": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "alice@example.com"
+                }
+            }
+        },
+        "app_internal_handler_user.GetUserInfoResp": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string",
+                    "example": "https://example.com/avatar.jpg"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "alice@example.com"
+                },
+                "email_verified": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "locale": {
+                    "description": "语言偏好，为空时按 Accept-Language 选择",
+                    "type": "string",
+                    "example": "en"
+                },
+                "nick_name": {
+                    "type": "string",
+                    "example": "爱丽丝"
+                },
+                "user_id": {
+                    "description": "用户基本信息",
+                    "type": "integer",
+                    "example": 1
+                },
+                "username": {
+                    "type": "string",
+                    "example": "alice123"
+                }
+            }
+        },
+        "app_internal_handler_user.LoginReq": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 16,
+                    "minLength": 8,
+                    "example": "password123"
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 3,
+                    "example": "alice123"
+                }
+            }
+        },
+        "app_internal_handler_user.LoginResp": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "app_internal_handler_user.RefreshTokenReq": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "app_internal_handler_user.RefreshTokenResp": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "app_internal_handler_user.ResetPasswordReq": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 16,
+                    "minLength": 8,
+                    "example": "password123"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "app_internal_handler_user.UpateUserInfoReq": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string",
+                    "example": "https://example.com/avatar.jpg"
+                },
+                "locale": {
+                    "description": "空字符串表示清除语言偏好",
+                    "type": "string",
+                    "maxLength": 16,
+                    "example": "en"
+                },
+                "nick_name": {
+                    "type": "string",
+                    "example": "爱丽丝"
+                }
+            }
+        },
+        "app_internal_handler_user.UpateUserInfoResp": {
+            "type": "object",
+            "properties": {
+                "avatar": {
+                    "type": "string",
+                    "example": "https://example.com/avatar.jpg"
+                },
+                "locale": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "nick_name": {
+                    "type": "string",
+                    "example": "爱丽丝"
+                },
+                "user_id": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "app_internal_handler_user.UpdateEmailReq": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "example": "alice@example.com"
+                }
+            }
+        },
+        "app_internal_handler_user.VerifyEmailReq": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "app_internal_handler_webhook.CreateWebhookReq": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "item.created",
+                        "item.completed"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 512,
+                    "example": "https://example.com/hooks/peano"
+                }
+            }
+        },
+        "app_internal_handler_webhook.UpdateWebhookReq": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rotate_secret": {
+                    "type": "boolean"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 512
+                }
+            }
+        },
+        "backend_app_types_dto.AdminStatsDTO": {
+            "type": "object",
+            "properties": {
+                "files": {
+                    "$ref": "#/definitions/backend_app_types_dto.FileStatsDTO"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "items": {
+                    "$ref": "#/definitions/backend_app_types_dto.ItemStatsDTO"
+                },
+                "requests": {
+                    "$ref": "#/definitions/backend_app_types_dto.RequestStatsDTO"
+                },
+                "sse_tasks": {
+                    "$ref": "#/definitions/backend_app_types_dto.SSETaskStatsDTO"
+                },
+                "users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.AuditLogDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "login、login_failed、token_refreshed、email_changed、password_reset、created、updated、deleted、config_changed",
+                    "type": "string"
+                },
+                "audit_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "操作详情，没有时为 null",
+                    "type": "object"
+                },
+                "entity": {
+                    "description": "user、item、tag、webhook、system",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "description": "数据ID",
+                    "type": "integer"
+                },
+                "ip": {
+                    "description": "客户端IP",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "操作人ID，未登录（如登录失败）时为 0",
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.ChangeDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created、updated、deleted",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "description": "变更后的数据，删除时为 null",
+                    "type": "object"
+                },
+                "entity": {
+                    "description": "item、tag",
+                    "type": "string"
+                },
+                "entity_id": {
+                    "description": "数据ID",
+                    "type": "integer"
+                },
+                "seq": {
+                    "description": "同步序号，只增不减，不同租户共用序号空间，因此不连续",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "description": "操作人ID",
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.ChangeListDTO": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.ChangeDTO"
+                    }
+                },
+                "has_more": {
+                    "description": "是否还有更多变更",
+                    "type": "boolean"
+                },
+                "latest": {
+                    "description": "本次返回的最后一个序号，下次请求作为 since",
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.DailyItemCountDTO": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.ErrorCodeDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "错误码",
+                    "type": "integer",
+                    "example": 4000000
+                },
+                "http_status": {
+                    "description": "默认 HTTP 状态码，未注册时不返回",
+                    "type": "integer",
+                    "example": 404
+                },
+                "messages": {
+                    "description": "各语言的消息模板，支持 {key} 占位符",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "retryable": {
+                    "description": "是否可重试（如依赖服务暂时不可用），为 false 时不返回",
+                    "type": "boolean"
+                }
+            }
+        },
+        "backend_app_types_dto.ExportProgressDTO": {
+            "type": "object",
+            "properties": {
+                "download_url": {
+                    "description": "下载地址，completed 时返回",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "失败原因，failed 时返回",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "下载地址过期时间，completed 时返回",
+                    "type": "string"
+                },
+                "files": {
+                    "description": "已导出的文件数",
+                    "type": "integer"
+                },
+                "items": {
+                    "description": "已导出的项目数",
+                    "type": "integer"
+                },
+                "stage": {
+                    "description": "started、profile、items、tags、files、completed、failed",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "已导出的标签数",
+                    "type": "integer"
+                },
+                "task_id": {
+                    "description": "导出任务ID",
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.FeedDTO": {
+            "type": "object",
+            "properties": {
+                "atom_url": {
+                    "description": "最近项目订阅地址（Atom），未启用时为空",
+                    "type": "string"
+                },
+                "calendar_url": {
+                    "description": "日历订阅地址（iCalendar）",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "订阅令牌，长期有效，重新生成后失效",
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.FileStatsDTO": {
+            "type": "object",
+            "properties": {
+                "storage_bytes": {
+                    "description": "文件记录的总字节数",
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.ImportItemDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "内容",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "来源位置，如 tasks.csv:3",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "状态",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "标签值",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "backend_app_types_dto.ImportPreviewDTO": {
+            "type": "object",
+            "properties": {
+                "done": {
+                    "description": "其中已完成的项目数",
+                    "type": "integer"
+                },
+                "existing_tags": {
+                    "description": "已存在、直接使用的标签（标签值）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "format": {
+                    "description": "导入格式",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "将创建的项目数",
+                    "type": "integer"
+                },
+                "new_tags": {
+                    "description": "将创建的标签（标签值）",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "samples": {
+                    "description": "前 20 个将创建的项目",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.ImportItemDTO"
+                    }
+                },
+                "skipped": {
+                    "description": "跳过的内容及原因（最多返回 100 条）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.ImportSkipDTO"
+                    }
+                },
+                "skipped_total": {
+                    "description": "跳过的内容数",
+                    "type": "integer"
+                },
+                "truncated": {
+                    "description": "内容超长被截断的项目数",
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.ImportProgressDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "失败原因，failed 时返回",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "已创建的项目数",
+                    "type": "integer"
+                },
+                "job_id": {
+                    "description": "后台任务ID",
+                    "type": "integer"
+                },
+                "stage": {
+                    "description": "queued、tags、items、completed、failed",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "新创建的标签数",
+                    "type": "integer"
+                },
+                "task_id": {
+                    "description": "导入任务ID",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "项目总数",
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.ImportSkipDTO": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "跳过原因",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "来源位置",
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.ItemDTO": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.ItemStatsDTO": {
+            "type": "object",
+            "properties": {
+                "by_status": {
+                    "description": "key: 项目状态",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.JobStatusDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "failures": {
+                    "type": "integer"
+                },
+                "last_duration_ms": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "last_run_at": {
+                    "type": "string"
+                },
+                "last_trigger": {
+                    "description": "schedule、manual",
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "next_run_at": {
+                    "type": "string"
+                },
+                "running": {
+                    "description": "是否正在执行",
+                    "type": "boolean"
+                },
+                "runs": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "因上一次执行未结束而跳过的次数",
+                    "type": "integer"
+                },
+                "spec": {
+                    "description": "生效的调度表达式，为空表示只能手动触发",
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.LogLevelDTO": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "description": "debug、info、warn、error",
+                    "type": "string",
+                    "example": "info"
+                }
+            }
+        },
+        "backend_app_types_dto.NotificationPreferenceDTO": {
+            "type": "object",
+            "properties": {
+                "daily_digest": {
+                    "description": "是否接收每日待办摘要",
+                    "type": "boolean"
+                },
+                "email_enabled": {
+                    "description": "是否接收通知邮件（不影响验证邮箱、重置密码等事务邮件）",
+                    "type": "boolean"
+                }
+            }
+        },
+        "backend_app_types_dto.QueueJobDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "已执行次数",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "max_attempts": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "run_at": {
+                    "description": "最早执行时间（等待重试时为下一次执行时间）",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending、running、succeeded、dead",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "app_internal_handler_item.CreateItemReq": {
+        "backend_app_types_dto.QueueStatsDTO": {
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 3,
-                    "example": "这是一个项目"
+                "dead": {
+                    "type": "integer"
                 },
-                "status": {
-                    "enum": [
-                        "normal",
-                        "done",
-                        "marked"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/backend_app_types_meta.ItemStatus"
-                        }
-                    ],
-                    "example": "normal"
+                "pending": {
+                    "type": "integer"
                 },
-                "tags": {
+                "running": {
+                    "type": "integer"
+                },
+                "succeeded": {
+                    "type": "integer"
+                },
+                "types": {
+                    "description": "已注册的任务类型",
                     "type": "array",
-                    "maxItems": 10,
-                    "minItems": 1,
                     "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        1,
-                        2,
-                        3
-                    ]
+                        "type": "string"
+                    }
                 }
             }
         },
-        "app_internal_handler_item.GetDailyItemCountResp": {
+        "backend_app_types_dto.RequestStatsDTO": {
             "type": "object",
             "properties": {
-                "daily_item_counts": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backend_app_types_dto.DailyItemCountDTO"
-                    }
+                "client_error_rate": {
+                    "description": "4xx 响应占比（0-1）",
+                    "type": "number"
+                },
+                "client_errors": {
+                    "description": "4xx 响应数",
+                    "type": "integer"
+                },
+                "error_rate": {
+                    "description": "5xx 响应占比（0-1）",
+                    "type": "number"
+                },
+                "requests_per_minute": {
+                    "description": "平均每分钟请求数",
+                    "type": "number"
+                },
+                "server_errors": {
+                    "description": "5xx 响应数",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "请求数",
+                    "type": "integer"
+                },
+                "window": {
+                    "description": "统计窗口，如 1h",
+                    "type": "string"
+                },
+                "window_seconds": {
+                    "description": "实际统计时长，进程启动不足一个窗口时为运行时长",
+                    "type": "integer"
                 }
             }
         },
-        "app_internal_handler_item.GetItemListResp": {
+        "backend_app_types_dto.SSETaskDTO": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
-                    }
+                "created_at": {
+                    "type": "string"
                 },
-                "page": {
+                "dropped_events": {
+                    "description": "超出保存上限、重连后无法补发的数据条数",
                     "type": "integer"
                 },
-                "page_size": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "export、import、sync",
+                    "type": "string"
+                },
+                "last_event_id": {
+                    "description": "已推送的数据条数",
                     "type": "integer"
                 },
-                "total": {
+                "status": {
+                    "description": "running、completed、failed、cancelled",
+                    "type": "string"
+                },
+                "subscribers": {
+                    "description": "本实例上的订阅者数量",
                     "type": "integer"
                 },
-                "total_pages": {
+                "task_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.SSETaskStatsDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "本实例正在运行的 SSE 任务数",
                     "type": "integer"
+                },
+                "by_kind": {
+                    "description": "key: 任务类型（export、import、sync）",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
                 }
             }
         },
-        "app_internal_handler_item.UpdateItemReq": {
+        "backend_app_types_dto.SystemInfoDTO": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 3,
-                    "example": "这是一个项目"
+                "build_time": {
+                    "type": "string"
                 },
-                "status": {
-                    "enum": [
-                        "normal",
-                        "done",
-                        "marked"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/backend_app_types_meta.ItemStatus"
-                        }
-                    ],
-                    "example": "normal"
+                "db_driver": {
+                    "type": "string"
                 },
-                "tags": {
-                    "type": "array",
-                    "maxItems": 10,
-                    "minItems": 1,
-                    "items": {
-                        "type": "integer"
-                    },
-                    "example": [
-                        1,
-                        2,
-                        3
-                    ]
+                "db_version": {
+                    "type": "string"
+                },
+                "git_commit": {
+                    "type": "string"
+                },
+                "go_version": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string"
+                },
+                "schema_version": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "uptime_seconds": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "string"
                 }
             }
         },
-        "app_internal_handler_tag.CreateTagReq": {
+        "backend_app_types_dto.TagDTO": {
             "type": "object",
-            "required": [
-                "tag_name",
-                "tag_value"
-            ],
             "properties": {
                 "color": {
-                    "type": "string",
-                    "maxLength": 12,
-                    "minLength": 3
+                    "type": "string"
                 },
                 "icon": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                    "type": "string"
+                },
+                "tag_id": {
+                    "type": "integer"
                 },
                 "tag_name": {
-                    "type": "string",
-                    "maxLength": 12,
-                    "minLength": 1,
-                    "example": "工作"
+                    "type": "string"
                 },
                 "tag_value": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 1,
-                    "example": "work"
+                    "type": "string"
                 }
             }
         },
-        "app_internal_handler_tag.GetTagListResp": {
+        "backend_app_types_dto.TelegramLinkDTO": {
             "type": "object",
             "properties": {
-                "page": {
-                    "type": "integer"
+                "code": {
+                    "description": "绑定码，发送 /link \u003c绑定码\u003e 给机器人完成绑定",
+                    "type": "string"
                 },
-                "page_size": {
-                    "type": "integer"
+                "expires_at": {
+                    "description": "绑定码过期时间",
+                    "type": "string"
                 },
-                "tags": {
+                "link_url": {
+                    "description": "打开后自动发送绑定码的 t.me 链接，配置了机器人用户名时返回",
+                    "type": "string"
+                }
+            }
+        },
+        "backend_app_types_dto.TelegramStatusDTO": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "description": "是否启用了 Telegram 机器人",
+                    "type": "boolean"
+                },
+                "linked": {
+                    "description": "当前用户是否已绑定",
+                    "type": "boolean"
+                }
+            }
+        },
+        "backend_app_types_dto.WebhookDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled": {
+                    "type": "boolean"
+                },
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                        "type": "string"
                     }
                 },
-                "total": {
+                "secret": {
+                    "description": "只在创建和更换密钥时返回完整密钥，其余情况为掩码",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_dto.WebhookDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "已请求次数",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "response_body": {
+                    "type": "string"
+                },
+                "response_status": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending、retrying、succeeded、failed",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backend_app_types_meta.ItemStatus": {
+            "type": "string",
+            "enum": [
+                "normal",
+                "done",
+                "marked"
+            ],
+            "x-enum-varnames": [
+                "ItemStatusNormal",
+                "ItemStatusDone",
+                "ItemStatusMarked"
+            ]
+        },
+        "backend_utils_errorx.Detail": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "错误码，非 StatusError 时为 0",
                     "type": "integer"
                 },
-                "total_pages": {
+                "index": {
+                    "description": "序号（如批量导入的行号）",
                     "type": "integer"
+                },
+                "message": {
+                    "description": "按语言翻译后的错误消息",
+                    "type": "string"
                 }
             }
         },
-        "app_internal_handler_tag.UpdateTagReq": {
+        "backend_utils_handle.Page-backend_app_types_dto_AuditLogDTO": {
             "type": "object",
             "properties": {
-                "color": {
-                    "type": "string",
-                    "maxLength": 12,
-                    "minLength": 3
+                "items": {
+                    "description": "当前页数据",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.AuditLogDTO"
+                    }
                 },
-                "icon": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3
+                "page": {
+                    "description": "当前页码",
+                    "type": "integer",
+                    "example": 1
                 },
-                "tag_name": {
-                    "type": "string",
-                    "maxLength": 12,
-                    "minLength": 1
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
                 },
-                "tag_value": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 1
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "app_internal_handler_user.GetUserInfoResp": {
+        "backend_utils_handle.Page-backend_app_types_dto_ItemDTO": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string",
-                    "example": "https://example.com/avatar.jpg"
-                },
-                "nick_name": {
-                    "type": "string",
-                    "example": "爱丽丝"
+                "items": {
+                    "description": "当前页数据",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.ItemDTO"
+                    }
                 },
-                "user_id": {
-                    "description": "用户基本信息",
+                "page": {
+                    "description": "当前页码",
                     "type": "integer",
                     "example": 1
                 },
-                "username": {
-                    "type": "string",
-                    "example": "alice123"
-                }
-            }
-        },
-        "app_internal_handler_user.LoginReq": {
-            "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
-            "properties": {
-                "password": {
-                    "type": "string",
-                    "maxLength": 16,
-                    "minLength": 8,
-                    "example": "password123"
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
                 },
-                "username": {
-                    "type": "string",
-                    "maxLength": 32,
-                    "minLength": 3,
-                    "example": "alice123"
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "app_internal_handler_user.LoginResp": {
+        "backend_utils_handle.Page-backend_app_types_dto_QueueJobDTO": {
             "type": "object",
             "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                },
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "items": {
+                    "description": "当前页数据",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.QueueJobDTO"
+                    }
                 },
-                "user_id": {
+                "page": {
+                    "description": "当前页码",
                     "type": "integer",
                     "example": 1
-                }
-            }
-        },
-        "app_internal_handler_user.RefreshTokenReq": {
-            "type": "object",
-            "required": [
-                "refresh_token"
-            ],
-            "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                }
-            }
-        },
-        "app_internal_handler_user.RefreshTokenResp": {
-            "type": "object",
-            "properties": {
-                "access_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
                 },
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                }
-            }
-        },
-        "app_internal_handler_user.UpateUserInfoReq": {
-            "type": "object",
-            "properties": {
-                "avatar": {
-                    "type": "string",
-                    "example": "https://example.com/avatar.jpg"
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
                 },
-                "nick_name": {
-                    "type": "string",
-                    "example": "爱丽丝"
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "app_internal_handler_user.UpateUserInfoResp": {
+        "backend_utils_handle.Page-backend_app_types_dto_SSETaskDTO": {
             "type": "object",
             "properties": {
-                "avatar": {
-                    "type": "string",
-                    "example": "https://example.com/avatar.jpg"
-                },
-                "nick_name": {
-                    "type": "string",
-                    "example": "爱丽丝"
+                "items": {
+                    "description": "当前页数据",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.SSETaskDTO"
+                    }
                 },
-                "user_id": {
+                "page": {
+                    "description": "当前页码",
                     "type": "integer",
                     "example": 1
+                },
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "backend_app_types_dto.DailyItemCountDTO": {
+        "backend_utils_handle.Page-backend_app_types_dto_TagDTO": {
             "type": "object",
             "properties": {
-                "count": {
-                    "type": "integer"
+                "items": {
+                    "description": "当前页数据",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                    }
                 },
-                "date": {
-                    "type": "string"
+                "page": {
+                    "description": "当前页码",
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "backend_app_types_dto.ItemDTO": {
+        "backend_utils_handle.Page-backend_app_types_dto_WebhookDTO": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "item_id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "type": "string"
-                },
-                "tags": {
+                "items": {
+                    "description": "当前页数据",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backend_app_types_dto.TagDTO"
+                        "$ref": "#/definitions/backend_app_types_dto.WebhookDTO"
                     }
                 },
-                "updated_at": {
-                    "type": "string"
+                "page": {
+                    "description": "当前页码",
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "backend_app_types_dto.TagDTO": {
+        "backend_utils_handle.Page-backend_app_types_dto_WebhookDeliveryDTO": {
             "type": "object",
             "properties": {
-                "color": {
-                    "type": "string"
+                "items": {
+                    "description": "当前页数据",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_app_types_dto.WebhookDeliveryDTO"
+                    }
                 },
-                "icon": {
-                    "type": "string"
+                "page": {
+                    "description": "当前页码",
+                    "type": "integer",
+                    "example": 1
                 },
-                "tag_id": {
-                    "type": "integer"
+                "page_size": {
+                    "description": "每页条数",
+                    "type": "integer",
+                    "example": 20
                 },
-                "tag_name": {
-                    "type": "string"
+                "total": {
+                    "description": "总条数",
+                    "type": "integer",
+                    "example": 42
                 },
-                "tag_value": {
-                    "type": "string"
+                "total_pages": {
+                    "description": "总页数",
+                    "type": "integer",
+                    "example": 3
                 }
             }
         },
-        "backend_app_types_meta.ItemStatus": {
-            "type": "string",
-            "enum": [
-                "normal",
-                "done",
-                "marked"
-            ],
-            "x-enum-varnames": [
-                "ItemStatusNormal",
-                "ItemStatusDone",
-                "ItemStatusMarked"
-            ]
-        },
         "backend_utils_handle.Response": {
             "type": "object",
             "properties": {
@@ -1458,10 +5421,27 @@ const docTemplate = `{
                 "data": {
                     "description": "响应数据（可选）"
                 },
+                "errors": {
+                    "description": "聚合错误详情（可选，批量操作部分失败时返回）",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backend_utils_errorx.Detail"
+                    }
+                },
                 "message": {
                     "description": "响应消息（可选）",
                     "type": "string",
                     "example": "操作成功"
+                },
+                "request_id": {
+                    "description": "请求 ID（仅错误响应）",
+                    "type": "string",
+                    "example": "req_P8oBUcz6utMujQ"
+                },
+                "trace_id": {
+                    "description": "链路追踪 ID（仅错误响应）",
+                    "type": "string",
+                    "example": "4bf92f3577b34da6a3ce929d0e0e4736"
                 }
             }
         }
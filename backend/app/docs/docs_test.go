@@ -0,0 +1,96 @@
+package docs
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// routerAnnotation 匹配接口注释中的 @Router，如 // @Router /api/item/{id} [get]
+var routerAnnotation = regexp.MustCompile(`^//\s*@Router\s+(\S+)\s+\[(\w+)\]`)
+
+// TestDocsMatchAnnotations 测试生成的接口定义与接口注释一致，修改注释后需要重新生成 app/docs
+func TestDocsMatchAnnotations(t *testing.T) {
+	annotated := annotatedRoutes(t, "../internal/handler")
+	if len(annotated) == 0 {
+		t.Fatal("没有找到 @Router 注释")
+	}
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(SwaggerInfo.ReadDoc()), &doc); err != nil {
+		t.Fatalf("解析接口定义失败: %v", err)
+	}
+	documented := make(map[string]bool)
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	var missing, stale []string
+	for route, file := range annotated {
+		if !documented[route] {
+			missing = append(missing, route+"（"+file+"）")
+		}
+	}
+	for route := range documented {
+		if _, ok := annotated[route]; !ok {
+			stale = append(stale, route)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(stale)
+	for _, route := range missing {
+		t.Errorf("接口定义缺少路由 %s，需要重新生成 app/docs", route)
+	}
+	for _, route := range stale {
+		t.Errorf("接口定义中的路由 %s 没有对应的 @Router 注释，需要重新生成 app/docs", route)
+	}
+}
+
+// annotatedRoutes 收集目录下所有 @Router 注释，返回 "方法 路径" 到所在文件的映射
+func annotatedRoutes(t *testing.T, root string) map[string]string {
+	t.Helper()
+
+	routes := make(map[string]string)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			m := routerAnnotation.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+			if m == nil {
+				continue
+			}
+			route := strings.ToUpper(m[2]) + " " + m[1]
+			if prev, ok := routes[route]; ok {
+				t.Errorf("路由 %s 重复注释: %s, %s", route, prev, path)
+			}
+			routes[route] = path
+		}
+		return scanner.Err()
+	})
+	if err != nil {
+		t.Fatalf("读取接口注释失败: %v", err)
+	}
+	return routes
+}
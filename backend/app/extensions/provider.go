@@ -0,0 +1,21 @@
+// Package extensions 部署相关的扩展（自动打标签、同步外部系统、自定义校验等）
+// 扩展通过注册业务逻辑钩子实现，不需要修改 ItemLogic、UserLogic
+package extensions
+
+import (
+	"go.uber.org/fx"
+)
+
+// ExtensionsModule 启动时注册扩展钩子，在 fx.Invoke 中加入注册函数，例如：
+//
+//	func RegisterAutoTag(registry *hooks.Registry) {
+//		registry.BeforeItemCreate.Register("auto_tag", func(ctx context.Context, item *hooks.ItemCreate) error {
+//			if strings.Contains(item.Content, "#work") {
+//				item.TagIDs = append(item.TagIDs, workTagID)
+//			}
+//			return nil
+//		})
+//	}
+var ExtensionsModule = fx.Module("extensions",
+	fx.Invoke(),
+)
@@ -0,0 +1,16 @@
+package audit
+
+// AuditFilterReq 审计日志查询条件
+type AuditFilterReq struct {
+	UserID    *uint   `form:"user_id" binding:"omitempty,min=0" label:"操作人ID" example:"1"`
+	Entity    string  `form:"entity" binding:"omitempty,oneof=user item tag webhook system" label:"数据类型" example:"item"`
+	Action    string  `form:"action" binding:"omitempty,max=32" label:"操作" example:"deleted"`
+	DateStart *string `form:"date_start" binding:"omitempty,dateformat" label:"开始日期" example:"2025-01-01"`
+	DateEnd   *string `form:"date_end" binding:"omitempty,dateformat" label:"结束日期" example:"2025-01-31"`
+}
+
+type GetAuditLogListReq struct {
+	AuditFilterReq
+	Page     int `form:"page" binding:"required,min=1" label:"页码"`
+	PageSize int `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
+}
@@ -0,0 +1,125 @@
+package bot
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	botError "backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/handle"
+	"backend/utils/telegram"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type BotLogic interface {
+	GetStatus(ctx context.Context) (*dto.TelegramStatusDTO, error)
+	CreateLinkCode(ctx context.Context) (*dto.TelegramLinkDTO, error)
+	Unlink(ctx context.Context) error
+	HandleWebhook(ctx context.Context, secretToken string, update *telegram.Update) error
+}
+
+type BotHandlerParams struct {
+	fx.In
+
+	BotLogic BotLogic
+}
+
+type BotHandler struct {
+	botLogic BotLogic
+}
+
+func NewBotHandler(params BotHandlerParams) *BotHandler {
+	return &BotHandler{
+		botLogic: params.BotLogic,
+	}
+}
+
+var botBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: botError.BotErrInvalidParam,
+	RequiredCode:     botError.BotErrParamRequired,
+}
+
+// GetTelegramStatus 获取 Telegram 绑定状态
+// @Summary 获取 Telegram 绑定状态
+// @Description 获取 Telegram 机器人是否启用以及当前用户是否已绑定
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.TelegramStatusDTO} "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Router /api/user/telegram [get]
+func (h *BotHandler) GetTelegramStatus(c *gin.Context) {
+	result, err := h.botLogic.GetStatus(c.Request.Context())
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 Telegram 绑定状态", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// CreateTelegramLink 生成 Telegram 绑定码
+// @Summary 生成 Telegram 绑定码
+// @Description 生成一次性绑定码，在 TELEGRAM_LINK_CODE_TTL 内发送 /link <绑定码> 给机器人完成绑定，之前未使用的绑定码失效
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.TelegramLinkDTO} "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 404 {object} handle.Response "Telegram 机器人未启用"
+// @Router /api/user/telegram/link [post]
+func (h *BotHandler) CreateTelegramLink(c *gin.Context) {
+	result, err := h.botLogic.CreateLinkCode(c.Request.Context())
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "生成 Telegram 绑定码", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// UnlinkTelegram 解除 Telegram 绑定
+// @Summary 解除 Telegram 绑定
+// @Description 解除当前用户与 Telegram 的绑定，未绑定时直接返回成功
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Router /api/user/telegram [delete]
+func (h *BotHandler) UnlinkTelegram(c *gin.Context) {
+	if err := h.botLogic.Unlink(c.Request.Context()); err != nil {
+		handle.HandleErrorWithContext(c, err, "解除 Telegram 绑定", nil)
+		return
+	}
+
+	handle.Success(c, nil)
+}
+
+// Webhook 接收 Telegram 推送的消息
+// @Summary 接收 Telegram 推送的消息
+// @Description TELEGRAM_MODE=webhook 时由 Telegram 调用，使用 X-Telegram-Bot-Api-Secret-Token 请求头校验，不需要登录
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param X-Telegram-Bot-Api-Secret-Token header string true "webhook 密钥"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 403 {object} handle.Response "webhook 密钥错误"
+// @Failure 404 {object} handle.Response "未启用 webhook 模式"
+// @Router /api/telegram/webhook [post]
+func (h *BotHandler) Webhook(c *gin.Context) {
+	var update telegram.Update
+	if err := bind.ShouldBindJSON(c, &update, botBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "接收 Telegram 消息", nil)
+		return
+	}
+
+	if err := h.botLogic.HandleWebhook(c.Request.Context(), c.GetHeader(telegram.SecretTokenHeader), &update); err != nil {
+		handle.HandleErrorWithContext(c, err, "接收 Telegram 消息", nil)
+		return
+	}
+
+	handle.Success(c, nil)
+}
@@ -0,0 +1,126 @@
+package change
+
+import (
+	"context"
+	"strconv"
+
+	"backend/app/types/dto"
+	syncError "backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type ChangeLogic interface {
+	GetChanges(ctx context.Context, since uint, limit int) (*dto.ChangeListDTO, error)
+	Stream(ctx context.Context, since *uint) (<-chan *dto.ChangeDTO, error)
+}
+
+type ChangeHandlerParams struct {
+	fx.In
+
+	ChangeLogic ChangeLogic
+}
+
+type ChangeHandler struct {
+	changeLogic ChangeLogic
+}
+
+func NewChangeHandler(params ChangeHandlerParams) *ChangeHandler {
+	return &ChangeHandler{
+		changeLogic: params.ChangeLogic,
+	}
+}
+
+var syncBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: syncError.SyncErrInvalidParam,
+	RequiredCode:     syncError.SyncErrParamRequired,
+}
+
+// GetChanges 增量同步
+// @Summary 增量同步
+// @Description 按序号升序返回序号大于 since 的项目、标签变更；has_more 为 true 时以 latest 作为 since 继续请求
+// @Tags 同步
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param since query int false "起始序号，不包含" default(0)
+// @Param limit query int false "数量，最大 500" default(500)
+// @Success 200 {object} handle.Response{data=dto.ChangeListDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/sync/changes [get]
+func (h *ChangeHandler) GetChanges(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req GetChangesReq
+	if err := bind.ShouldBindQuery(c, &req, syncBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "增量同步", nil)
+		return
+	}
+
+	result, err := h.changeLogic.GetChanges(ctx, req.Since, req.Limit)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "增量同步", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// Stream 订阅变更
+// @Summary 订阅变更
+// @Description SSE 推送项目、标签的新增、修改和删除（事件名 change，id 为同步序号）。
+// @Description 传 since 或 Last-Event-ID 请求头时先补发之后的历史变更；收到 done 事件后应带上 Last-Event-ID 重连
+// @Tags 同步
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param since query int false "起始序号，不包含"
+// @Param Last-Event-ID header string false "最后收到的同步序号，优先于 since"
+// @Success 200 {object} dto.ChangeDTO "change 事件"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 503 {object} handle.Response "订阅失败"
+// @Router /api/sync/stream [get]
+func (h *ChangeHandler) Stream(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req StreamReq
+	if err := bind.ShouldBindQuery(c, &req, syncBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "订阅变更", nil)
+		return
+	}
+
+	// 断线重连时浏览器自动带上 Last-Event-ID
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		seq, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			handle.HandleErrorWithContext(c, errorx.New(syncError.SyncErrInvalidParam, errorx.K("reason", "Last-Event-ID 必须是同步序号")), "订阅变更", nil)
+			return
+		}
+		since := uint(seq)
+		req.Since = &since
+	}
+
+	changes, err := h.changeLogic.Stream(ctx, req.Since)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "订阅变更", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "开始推送变更")
+	cfg := handle.DefaultSSEConfig()
+	cfg.EventName = "change"
+	cfg.EventID = func(data any) string {
+		if change, ok := data.(*dto.ChangeDTO); ok {
+			return strconv.FormatUint(uint64(change.Seq), 10)
+		}
+		return ""
+	}
+	handle.StreamSSE(c, changes, cfg)
+}
@@ -0,0 +1,12 @@
+package change
+
+// GetChangesReq 增量同步请求
+type GetChangesReq struct {
+	Since uint `form:"since" binding:"omitempty,min=0" label:"起始序号" example:"0"`
+	Limit int  `form:"limit" binding:"omitempty,min=1,max=500" label:"数量" example:"100"`
+}
+
+// StreamReq 订阅变更请求
+type StreamReq struct {
+	Since *uint `form:"since" binding:"omitempty,min=0" label:"起始序号" example:"0"`
+}
@@ -0,0 +1,164 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strconv"
+
+	_ "backend/app/types/dto" // 接口文档中的 dto.ExportProgressDTO
+	exportError "backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+	"backend/utils/sse"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type ExportLogic interface {
+	Export(ctx context.Context, lastEventID uint64) (<-chan sse.Event, error)
+	OpenDownload(ctx context.Context, token string) (*os.File, string, error)
+	Pause(ctx context.Context) error
+	Resume(ctx context.Context) error
+}
+
+type ExportHandlerParams struct {
+	fx.In
+
+	ExportLogic ExportLogic
+}
+
+type ExportHandler struct {
+	exportLogic ExportLogic
+}
+
+func NewExportHandler(params ExportHandlerParams) *ExportHandler {
+	return &ExportHandler{
+		exportLogic: params.ExportLogic,
+	}
+}
+
+var exportBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: exportError.ExportErrInvalidParam,
+	RequiredCode:     exportError.ExportErrParamRequired,
+}
+
+// Export 导出账户数据
+// @Summary 导出账户数据
+// @Description 在后台把当前用户的资料、项目、标签和上传的文件打包为 zip（JSON 和 CSV 两种格式），通过 SSE 推送进度（事件名 progress）。
+// @Description stage 为 completed 时返回有时效的下载地址，为 failed 时返回失败原因；客户端断开不影响导出，导出进行中再次请求会加入同一个导出
+// @Description 每条进度带有事件 ID，重连时带上 Last-Event-ID 请求头只补发之后的进度
+// @Tags 用户认证
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param Last-Event-ID header string false "最后收到的事件 ID"
+// @Success 200 {object} dto.ExportProgressDTO "progress 事件"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 503 {object} handle.Response "启动导出任务失败"
+// @Router /api/user/export [post]
+func (h *ExportHandler) Export(c *gin.Context) {
+	ctx := errorx.WithLocale(c.Request.Context(), handle.Locale(c))
+
+	// 断线重连时浏览器自动带上 Last-Event-ID
+	var lastEventID uint64
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			handle.HandleErrorWithContext(c, errorx.New(exportError.ExportErrInvalidParam, errorx.K("reason", "Last-Event-ID 必须是事件 ID")), "导出账户数据", nil)
+			return
+		}
+		lastEventID = id
+	}
+
+	progress, err := h.exportLogic.Export(ctx, lastEventID)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "导出账户数据", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "开始推送导出进度")
+	cfg := handle.DefaultSSEConfig()
+	cfg.EventName = "progress"
+	handle.StreamSSE(c, progress, cfg)
+}
+
+// Pause 暂停导出
+// @Summary 暂停导出
+// @Description 暂停当前用户进行中的导出，导出在当前阶段结束时停下，已推送的进度和 SSE 连接保持不变，恢复后继续；暂停期间导出超时仍然计时
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response "暂停成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 404 {object} handle.Response "没有运行中的导出"
+// @Router /api/user/export/pause [post]
+func (h *ExportHandler) Pause(c *gin.Context) {
+	ctx := errorx.WithLocale(c.Request.Context(), handle.Locale(c))
+
+	if err := h.exportLogic.Pause(ctx); err != nil {
+		handle.HandleErrorWithContext(c, err, "暂停导出", nil)
+		return
+	}
+	handle.Success(c, nil)
+}
+
+// Resume 恢复导出
+// @Summary 恢复导出
+// @Description 恢复当前用户已暂停的导出，进度继续通过原来的 SSE 连接推送
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response "恢复成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 404 {object} handle.Response "没有运行中的导出"
+// @Failure 409 {object} handle.Response "导出没有暂停"
+// @Router /api/user/export/resume [post]
+func (h *ExportHandler) Resume(c *gin.Context) {
+	ctx := errorx.WithLocale(c.Request.Context(), handle.Locale(c))
+
+	if err := h.exportLogic.Resume(ctx); err != nil {
+		handle.HandleErrorWithContext(c, err, "恢复导出", nil)
+		return
+	}
+	handle.Success(c, nil)
+}
+
+// Download 下载导出文件
+// @Summary 下载导出文件
+// @Description 使用导出完成时返回的下载地址下载 zip 文件，链接在有效期内可重复下载，不需要登录
+// @Tags 用户认证
+// @Produce application/zip
+// @Param token query string true "下载令牌"
+// @Success 200 {file} file "导出文件"
+// @Failure 400 {object} handle.Response "链接无效或已过期"
+// @Failure 410 {object} handle.Response "导出文件已被清理"
+// @Router /api/user/export/download [get]
+func (h *ExportHandler) Download(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req DownloadReq
+	if err := bind.ShouldBindQuery(c, &req, exportBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "下载导出文件", nil)
+		return
+	}
+
+	file, name, err := h.exportLogic.OpenDownload(ctx, req.Token)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "下载导出文件", nil)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		handle.HandleErrorWithContext(c, errorx.Wrap(err, exportError.ExportErrFileNotFound), "下载导出文件", nil)
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(http.StatusOK, info.Size(), "application/zip", file, nil)
+}
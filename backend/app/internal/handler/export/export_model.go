@@ -0,0 +1,6 @@
+package export
+
+// DownloadReq 下载导出文件请求
+type DownloadReq struct {
+	Token string `form:"token" binding:"required" label:"下载令牌" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
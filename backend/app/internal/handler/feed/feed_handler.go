@@ -0,0 +1,159 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+
+	"backend/app/types/dto"
+	feedError "backend/app/types/errorn"
+	"backend/utils/atom"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/ical"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type FeedLogic interface {
+	GetFeed(ctx context.Context) (*dto.FeedDTO, error)
+	RotateFeed(ctx context.Context) (*dto.FeedDTO, error)
+	Calendar(ctx context.Context, token string, component string) (*ical.Calendar, error)
+	Atom(ctx context.Context, token string, tagValue string) (*atom.Feed, error)
+}
+
+type FeedHandlerParams struct {
+	fx.In
+
+	FeedLogic FeedLogic
+}
+
+type FeedHandler struct {
+	feedLogic FeedLogic
+}
+
+func NewFeedHandler(params FeedHandlerParams) *FeedHandler {
+	return &FeedHandler{
+		feedLogic: params.FeedLogic,
+	}
+}
+
+var feedBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: feedError.FeedErrInvalidParam,
+	RequiredCode:     feedError.FeedErrParamRequired,
+}
+
+// GetFeed 获取订阅链接
+// @Summary 获取订阅链接
+// @Description 获取当前用户的日历订阅地址和最近项目订阅源地址（启用时），首次获取时生成订阅密钥。订阅地址长期有效，重新生成后旧地址失效
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.FeedDTO} "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Router /api/user/feed [get]
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	result, err := h.feedLogic.GetFeed(c.Request.Context())
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取订阅链接", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// RotateFeed 重新生成订阅链接
+// @Summary 重新生成订阅链接
+// @Description 重新生成当前用户的订阅密钥，之前的订阅地址全部失效，订阅地址泄露时使用
+// @Tags 用户认证
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.FeedDTO} "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Router /api/user/feed/rotate [post]
+func (h *FeedHandler) RotateFeed(c *gin.Context) {
+	result, err := h.feedLogic.RotateFeed(c.Request.Context())
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "重新生成订阅链接", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// Calendar 日历订阅
+// @Summary 日历订阅
+// @Description 以 iCalendar 格式返回订阅令牌对应用户创建的、设置了截止时间的项目，供 Google 日历、Apple 日历等订阅，不需要登录。
+// @Description component 为 event（默认）时每个项目输出为日程，为 todo 时输出为带完成状态的待办
+// @Tags 项目管理
+// @Produce text/calendar
+// @Param token query string true "订阅令牌"
+// @Param component query string false "日历组件" Enums(event, todo)
+// @Success 200 {string} string "iCalendar 日历"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 403 {object} handle.Response "订阅链接无效或已失效"
+// @Router /api/item/calendar.ics [get]
+func (h *FeedHandler) Calendar(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req CalendarReq
+	if err := bind.ShouldBindQuery(c, &req, feedBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "日历订阅", nil)
+		return
+	}
+
+	cal, err := h.feedLogic.Calendar(ctx, req.Token, req.Component)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "日历订阅", nil)
+		return
+	}
+
+	var buf bytes.Buffer
+	if _, err := cal.WriteTo(&buf); err != nil {
+		handle.HandleErrorWithContext(c, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error())), "日历订阅", nil)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="calendar.ics"`)
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
+
+// Atom 最近项目订阅源
+// @Summary 最近项目订阅源
+// @Description 以 Atom 格式返回订阅令牌对应用户最近创建或完成的项目，供订阅阅读器和自动化工具读取，不需要登录。需要启用 FEED_ATOM_ENABLED
+// @Tags 项目管理
+// @Produce application/atom+xml
+// @Param token query string true "订阅令牌"
+// @Param tag query string false "标签值，只返回带该标签的项目"
+// @Success 200 {string} string "Atom 订阅源"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 403 {object} handle.Response "订阅链接无效或已失效"
+// @Failure 404 {object} handle.Response "订阅源未启用或标签不存在"
+// @Router /api/item/feed.atom [get]
+func (h *FeedHandler) Atom(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req AtomReq
+	if err := bind.ShouldBindQuery(c, &req, feedBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "订阅源", nil)
+		return
+	}
+
+	feed, err := h.feedLogic.Atom(ctx, req.Token, req.Tag)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "订阅源", nil)
+		return
+	}
+
+	var buf bytes.Buffer
+	if _, err := feed.WriteTo(&buf); err != nil {
+		handle.HandleErrorWithContext(c, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error())), "订阅源", nil)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, atom.ContentType+"; charset=utf-8", buf.Bytes())
+}
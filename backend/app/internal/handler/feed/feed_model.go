@@ -0,0 +1,13 @@
+package feed
+
+// CalendarReq 日历订阅请求
+type CalendarReq struct {
+	Token     string `form:"token" binding:"required" label:"订阅令牌" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Component string `form:"component" binding:"omitempty,oneof=event todo" label:"日历组件" example:"event"`
+}
+
+// AtomReq 最近项目订阅源请求
+type AtomReq struct {
+	Token string `form:"token" binding:"required" label:"订阅令牌" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Tag   string `form:"tag" binding:"omitempty,max=64" label:"标签值" example:"work"`
+}
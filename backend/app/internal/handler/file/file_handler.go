@@ -37,9 +37,6 @@ func NewFileHandler(params FileHandlerParams) *FileHandler {
 var fileBindConfig = bind.FieldErrorConfig{
 	InvalidParamCode: fileErr.FileErrInvalidFile,
 	RequiredCode:     fileErr.FileErrInvalidFile,
-	FieldLabels: map[string]string{
-		"file": "文件",
-	},
 }
 
 // UploadFile 上传文件
@@ -0,0 +1,136 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+	"backend/utils/readonly"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/fx"
+)
+
+const (
+	// complexityLimit 单次查询的复杂度上限（每个字段计 1，列表字段不放大）
+	complexityLimit = 500
+	// queryCacheSize 解析后的查询缓存数量
+	queryCacheSize = 1000
+)
+
+type GraphHandlerParams struct {
+	fx.In
+
+	ItemLogic ItemLogic
+	TagLogic  TagLogic
+	UserLogic UserLogic
+}
+
+type GraphHandler struct {
+	server *handler.Server
+}
+
+func NewGraphHandler(params GraphHandlerParams) *GraphHandler {
+	resolver := &Resolver{
+		itemLogic: params.ItemLogic,
+		tagLogic:  params.TagLogic,
+		userLogic: params.UserLogic,
+	}
+
+	server := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+	server.AddTransport(transport.POST{})
+	server.SetQueryCache(lru.New[*ast.QueryDocument](queryCacheSize))
+	server.Use(extension.Introspection{})
+	server.Use(extension.FixedComplexityLimit(complexityLimit))
+	server.AroundOperations(rejectMutationInReadOnly)
+	server.SetErrorPresenter(presentError)
+	server.SetRecoverFunc(recoverPanic)
+
+	return &GraphHandler{
+		server: server,
+	}
+}
+
+var itemBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: errorn.ItemErrInvalidParam,
+	RequiredCode:     errorn.ItemErrParamRequired,
+}
+
+var tagBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: errorn.TagErrInvalidParam,
+	RequiredCode:     errorn.TagErrParamRequired,
+}
+
+// Serve 处理 GraphQL 请求
+// @Summary GraphQL 查询
+// @Description 通过 GraphQL 查询项目、标签、用户信息和统计，项目的 tags 字段在同一次请求中解析；变更操作调用与 REST 接口相同的业务逻辑。业务错误的 extensions 中包含 code（错误码）和 status（对应的 HTTP 状态码），schema 见 app/internal/handler/graph/schema.graphqls
+// @Tags GraphQL
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body object true "GraphQL 请求（query、variables、operationName）"
+// @Success 200 {object} object "GraphQL 响应（data、errors）"
+// @Router /api/graphql [post]
+func (h *GraphHandler) Serve(c *gin.Context) {
+	// 语言和时区在进入解析器前确定，解析器中不再访问 gin.Context
+	ctx := errorx.WithLocale(c.Request.Context(), handle.Locale(c))
+	ctx = withLocation(ctx, handle.Location(c))
+
+	h.server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+}
+
+// rejectMutationInReadOnly 只读模式下拒绝变更操作，查询不受影响
+// ReadOnly 中间件放行了 /api/graphql 的 POST 请求，需要在这里按操作类型判断
+func rejectMutationInReadOnly(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	op := graphql.GetOperationContext(ctx).Operation
+	if op == nil || op.Operation != ast.Mutation || !readonly.Enabled() {
+		return next(ctx)
+	}
+
+	// 操作阶段还没有响应上下文，不能使用 graphql.AddError
+	err := errorx.New(errorn.SystemErrReadOnly, errorx.K("reason", readonly.Reason()))
+	return graphql.OneShot(&graphql.Response{Errors: gqlerror.List{presentError(ctx, err)}})
+}
+
+// presentError 将业务错误转换为 GraphQL 错误
+// 消息按请求语言翻译，extensions 中输出错误码和对应的 HTTP 状态码
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) {
+		return gqlErr
+	}
+
+	status, ok := errorx.HTTPStatus(statusErr.Code())
+	if !ok {
+		status = http.StatusBadRequest
+	}
+
+	logs.CtxWarnf(ctx, "GraphQL 请求失败: path=%s, error_code=%d, error_msg=%s", gqlErr.Path.String(), statusErr.Code(), statusErr.Msg())
+
+	gqlErr.Message = errorx.LocalizedMsg(err, localeFromContext(ctx))
+	gqlErr.Extensions = map[string]interface{}{
+		"code":   statusErr.Code(),
+		"status": status,
+	}
+	return gqlErr
+}
+
+// recoverPanic 解析器 panic 时记录日志并返回内部错误，不向客户端暴露 panic 内容
+func recoverPanic(ctx context.Context, p interface{}) error {
+	logs.CtxErrorf(ctx, "GraphQL 解析器 panic: %v", p)
+	return errorx.New(errorn.SystemErrInternal)
+}
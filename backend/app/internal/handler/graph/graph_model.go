@@ -0,0 +1,26 @@
+package graph
+
+// 查询参数的验证规则与 REST 接口的请求参数一致，输入对象的验证规则通过 schema 中的 @goTag 生成
+
+type itemListArgs struct {
+	DateStart *string `json:"dateStart" binding:"omitempty,dateformat" label:"开始日期"`
+	DateEnd   *string `json:"dateEnd" binding:"omitempty,dateformat" label:"结束日期"`
+	Page      int     `json:"page" binding:"min=1" label:"页码"`
+	PageSize  int     `json:"pageSize" binding:"min=1,max=100" label:"每页条数"`
+}
+
+type searchItemsArgs struct {
+	Keyword  string `json:"keyword" binding:"required,min=1,max=100" label:"关键词"`
+	Page     int    `json:"page" binding:"min=1" label:"页码"`
+	PageSize int    `json:"pageSize" binding:"min=1,max=100" label:"每页条数"`
+}
+
+type itemStatsArgs struct {
+	DateStart string `json:"dateStart" binding:"required,dateformat" label:"开始日期"`
+	DateEnd   string `json:"dateEnd" binding:"required,dateformat" label:"结束日期"`
+}
+
+type tagListArgs struct {
+	Page     int `json:"page" binding:"min=1" label:"页码"`
+	PageSize int `json:"pageSize" binding:"min=1,max=100" label:"每页条数"`
+}
@@ -0,0 +1,125 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graph
+
+import (
+	"backend/app/types/dto"
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type CreateItemInput struct {
+	Content string      `json:"content" binding:"required,min=3,max=1000" label:"内容"`
+	Status  *ItemStatus `json:"status,omitempty"`
+	Tags    []uint      `json:"tags,omitempty" binding:"omitempty,min=1,max=10" label:"标签ID"`
+}
+
+type CreateTagInput struct {
+	Name  string  `json:"name" binding:"required,min=1,max=12" label:"标签名"`
+	Value string  `json:"value" binding:"required,min=1,max=32" label:"标签值"`
+	Icon  *string `json:"icon,omitempty" binding:"omitempty,min=1,max=255" label:"图标"`
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor" label:"颜色"`
+}
+
+type ItemPage struct {
+	Items    []*dto.ItemDTO `json:"items"`
+	Total    int            `json:"total"`
+	Page     int            `json:"page"`
+	PageSize int            `json:"pageSize"`
+}
+
+type ItemStats struct {
+	Total int                      `json:"total"`
+	Daily []*dto.DailyItemCountDTO `json:"daily"`
+}
+
+type Mutation struct {
+}
+
+type Query struct {
+}
+
+type TagPage struct {
+	Tags     []*dto.TagDTO `json:"tags"`
+	Total    int           `json:"total"`
+	Page     int           `json:"page"`
+	PageSize int           `json:"pageSize"`
+}
+
+type UpdateItemInput struct {
+	Content *string     `json:"content,omitempty" binding:"omitempty,min=3,max=1000" label:"内容"`
+	Status  *ItemStatus `json:"status,omitempty"`
+	Tags    []uint      `json:"tags,omitempty" binding:"omitempty,min=1,max=10" label:"标签ID"`
+	Version *int        `json:"version,omitempty" binding:"omitempty,min=1" label:"版本号"`
+}
+
+type UpdateTagInput struct {
+	Name  *string `json:"name,omitempty" binding:"omitempty,min=1,max=12" label:"标签名"`
+	Value *string `json:"value,omitempty" binding:"omitempty,min=1,max=32" label:"标签值"`
+	Icon  *string `json:"icon,omitempty" binding:"omitempty,min=1,max=255" label:"图标"`
+	Color *string `json:"color,omitempty" binding:"omitempty,hexcolor" label:"颜色"`
+}
+
+type UpdateUserInput struct {
+	NickName *string `json:"nickName,omitempty" label:"昵称"`
+	Avatar   *string `json:"avatar,omitempty" label:"头像"`
+}
+
+type ItemStatus string
+
+const (
+	ItemStatusNormal ItemStatus = "NORMAL"
+	ItemStatusDone   ItemStatus = "DONE"
+	ItemStatusMarked ItemStatus = "MARKED"
+)
+
+var AllItemStatus = []ItemStatus{
+	ItemStatusNormal,
+	ItemStatusDone,
+	ItemStatusMarked,
+}
+
+func (e ItemStatus) IsValid() bool {
+	switch e {
+	case ItemStatusNormal, ItemStatusDone, ItemStatusMarked:
+		return true
+	}
+	return false
+}
+
+func (e ItemStatus) String() string {
+	return string(e)
+}
+
+func (e *ItemStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ItemStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ItemStatus", str)
+	}
+	return nil
+}
+
+func (e ItemStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ItemStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ItemStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
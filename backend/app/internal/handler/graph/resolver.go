@@ -0,0 +1,110 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"backend/app/types/dto"
+	itemError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/errorx"
+	"backend/utils/timex"
+)
+
+type ItemLogic interface {
+	CreateItem(ctx context.Context, content string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint) (*dto.ItemDTO, error)
+	UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint, version *uint) (*dto.ItemDTO, error)
+	DeleteItem(ctx context.Context, itemID uint) error
+	GetItem(ctx context.Context, itemID uint) (*dto.ItemDTO, error)
+	GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
+	GetDailyItemCount(ctx context.Context, dateStart time.Time, dateEnd time.Time) ([]dto.DailyItemCountDTO, error)
+	SearchItems(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
+}
+
+type TagLogic interface {
+	CreateTag(ctx context.Context, tagName string, tagValue string, icon *string, color *string) (*dto.TagDTO, error)
+	UpdateTag(ctx context.Context, tagID uint, tagName *string, tagValue *string, icon *string, color *string) (*dto.TagDTO, error)
+	DeleteTag(ctx context.Context, tagID uint) error
+	GetTag(ctx context.Context, tagID uint) (*dto.TagDTO, error)
+	GetTagList(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, error)
+}
+
+type UserLogic interface {
+	GetUserInfo(ctx context.Context) (*dto.UserDTO, error)
+	UpdateUserInfo(ctx context.Context, nickName *string, avatar *string, locale *string) (*dto.UserDTO, error)
+}
+
+// Resolver GraphQL 解析器，字段解析直接调用 logic 层方法，与 REST 接口共享业务逻辑
+type Resolver struct {
+	itemLogic ItemLogic
+	tagLogic  TagLogic
+	userLogic UserLogic
+}
+
+// locationContextKey 请求时区在 context 中的键
+type locationContextKey struct{}
+
+// withLocation 将请求时区写入 context，用于解析相对日期
+func withLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, locationContextKey{}, loc)
+}
+
+// locationFromContext 从 context 中读取请求时区，未设置时返回 UTC
+func locationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(locationContextKey{}).(*time.Location); ok {
+		return loc
+	}
+	return time.UTC
+}
+
+// localeFromContext 从 context 中读取请求语言，未设置时返回默认语言
+func localeFromContext(ctx context.Context) string {
+	if locale := errorx.LocaleFromContext(ctx); locale != "" {
+		return locale
+	}
+	return errorx.DefaultLocale
+}
+
+// toMetaStatus 将 GraphQL 枚举转换为项目状态
+func toMetaStatus(status *ItemStatus) *meta.ItemStatus {
+	if status == nil {
+		return nil
+	}
+	s := meta.ItemStatus(strings.ToLower(string(*status)))
+	return &s
+}
+
+// parseDateRange 解析日期参数（相对日期按请求时区计算），空值返回 nil
+// 参数已经过 dateformat 规则验证，解析失败时按项目参数错误返回
+func parseDateRange(ctx context.Context, dateStart, dateEnd *string) (*time.Time, *time.Time, error) {
+	loc := locationFromContext(ctx)
+
+	var start *time.Time
+	if dateStart != nil && *dateStart != "" {
+		parsed, err := timex.ParseDateStart(*dateStart, loc)
+		if err != nil {
+			return nil, nil, errorx.Wrap(err, itemError.ItemErrInvalidParam, errorx.K("reason", err.Error()))
+		}
+		start = &parsed
+	}
+
+	var end *time.Time
+	if dateEnd != nil && *dateEnd != "" {
+		parsed, err := timex.ParseDateEnd(*dateEnd, loc)
+		if err != nil {
+			return nil, nil, errorx.Wrap(err, itemError.ItemErrInvalidParam, errorx.K("reason", err.Error()))
+		}
+		end = &parsed
+	}
+	return start, end, nil
+}
+
+// toPointers 将切片转换为指针切片
+func toPointers[T any](items []T) []*T {
+	result := make([]*T, len(items))
+	for i := range items {
+		result[i] = &items[i]
+	}
+	return result
+}
@@ -0,0 +1,176 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver
+// implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen
+
+import (
+	"backend/app/types/dto"
+	"backend/utils/bind"
+	"context"
+	"strings"
+)
+
+// Status is the resolver for the status field.
+func (r *itemResolver) Status(ctx context.Context, obj *dto.ItemDTO) (ItemStatus, error) {
+	return ItemStatus(strings.ToUpper(obj.Status)), nil
+}
+
+// CreateItem is the resolver for the createItem field.
+func (r *mutationResolver) CreateItem(ctx context.Context, input CreateItemInput) (*dto.ItemDTO, error) {
+	if err := bind.Validate(&input, localeFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+	return r.itemLogic.CreateItem(ctx, input.Content, toMetaStatus(input.Status), nil, input.Tags)
+}
+
+// UpdateItem is the resolver for the updateItem field.
+func (r *mutationResolver) UpdateItem(ctx context.Context, id uint, input UpdateItemInput) (*dto.ItemDTO, error) {
+	if err := bind.Validate(&input, localeFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	var version *uint
+	if input.Version != nil {
+		v := uint(*input.Version)
+		version = &v
+	}
+	return r.itemLogic.UpdateItem(ctx, id, input.Content, toMetaStatus(input.Status), nil, input.Tags, version)
+}
+
+// DeleteItem is the resolver for the deleteItem field.
+func (r *mutationResolver) DeleteItem(ctx context.Context, id uint) (bool, error) {
+	if err := r.itemLogic.DeleteItem(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateTag is the resolver for the createTag field.
+func (r *mutationResolver) CreateTag(ctx context.Context, input CreateTagInput) (*dto.TagDTO, error) {
+	if err := bind.Validate(&input, localeFromContext(ctx), tagBindConfig); err != nil {
+		return nil, err
+	}
+	return r.tagLogic.CreateTag(ctx, input.Name, input.Value, input.Icon, input.Color)
+}
+
+// UpdateTag is the resolver for the updateTag field.
+func (r *mutationResolver) UpdateTag(ctx context.Context, id uint, input UpdateTagInput) (*dto.TagDTO, error) {
+	if err := bind.Validate(&input, localeFromContext(ctx), tagBindConfig); err != nil {
+		return nil, err
+	}
+	return r.tagLogic.UpdateTag(ctx, id, input.Name, input.Value, input.Icon, input.Color)
+}
+
+// DeleteTag is the resolver for the deleteTag field.
+func (r *mutationResolver) DeleteTag(ctx context.Context, id uint) (bool, error) {
+	if err := r.tagLogic.DeleteTag(ctx, id); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// UpdateMe is the resolver for the updateMe field.
+func (r *mutationResolver) UpdateMe(ctx context.Context, input UpdateUserInput) (*dto.UserDTO, error) {
+	return r.userLogic.UpdateUserInfo(ctx, input.NickName, input.Avatar, nil)
+}
+
+// Me is the resolver for the me field.
+func (r *queryResolver) Me(ctx context.Context) (*dto.UserDTO, error) {
+	return r.userLogic.GetUserInfo(ctx)
+}
+
+// Item is the resolver for the item field.
+func (r *queryResolver) Item(ctx context.Context, id uint) (*dto.ItemDTO, error) {
+	return r.itemLogic.GetItem(ctx, id)
+}
+
+// Items is the resolver for the items field.
+func (r *queryResolver) Items(ctx context.Context, status *ItemStatus, dateStart *string, dateEnd *string, page int, pageSize int) (*ItemPage, error) {
+	args := itemListArgs{DateStart: dateStart, DateEnd: dateEnd, Page: page, PageSize: pageSize}
+	if err := bind.Validate(&args, localeFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseDateRange(ctx, dateStart, dateEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	items, total, err := r.itemLogic.GetItemList(ctx, start, end, toMetaStatus(status), page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ItemPage{Items: toPointers(items), Total: int(total), Page: page, PageSize: pageSize}, nil
+}
+
+// SearchItems is the resolver for the searchItems field.
+func (r *queryResolver) SearchItems(ctx context.Context, keyword string, status *ItemStatus, page int, pageSize int) (*ItemPage, error) {
+	args := searchItemsArgs{Keyword: keyword, Page: page, PageSize: pageSize}
+	if err := bind.Validate(&args, localeFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	items, total, err := r.itemLogic.SearchItems(ctx, keyword, toMetaStatus(status), page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &ItemPage{Items: toPointers(items), Total: int(total), Page: page, PageSize: pageSize}, nil
+}
+
+// Tag is the resolver for the tag field.
+func (r *queryResolver) Tag(ctx context.Context, id uint) (*dto.TagDTO, error) {
+	return r.tagLogic.GetTag(ctx, id)
+}
+
+// Tags is the resolver for the tags field.
+func (r *queryResolver) Tags(ctx context.Context, page int, pageSize int) (*TagPage, error) {
+	args := tagListArgs{Page: page, PageSize: pageSize}
+	if err := bind.Validate(&args, localeFromContext(ctx), tagBindConfig); err != nil {
+		return nil, err
+	}
+
+	tags, total, err := r.tagLogic.GetTagList(ctx, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &TagPage{Tags: toPointers(tags), Total: int(total), Page: page, PageSize: pageSize}, nil
+}
+
+// ItemStats is the resolver for the itemStats field.
+func (r *queryResolver) ItemStats(ctx context.Context, dateStart string, dateEnd string) (*ItemStats, error) {
+	args := itemStatsArgs{DateStart: dateStart, DateEnd: dateEnd}
+	if err := bind.Validate(&args, localeFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseDateRange(ctx, &dateStart, &dateEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := r.itemLogic.GetDailyItemCount(ctx, *start, *end)
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count.Count
+	}
+	return &ItemStats{Total: total, Daily: toPointers(counts)}, nil
+}
+
+// Item returns ItemResolver implementation.
+func (r *Resolver) Item() ItemResolver { return &itemResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+type itemResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
@@ -0,0 +1,138 @@
+package importer
+
+import (
+	"context"
+	"mime/multipart"
+
+	"backend/app/types/dto"
+	importError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type ImportLogic interface {
+	Formats() []string
+	Preview(ctx context.Context, format string, file *multipart.FileHeader) (*dto.ImportPreviewDTO, error)
+	Import(ctx context.Context, format string, file *multipart.FileHeader) (<-chan *dto.ImportProgressDTO, error)
+}
+
+type ImportHandlerParams struct {
+	fx.In
+
+	ImportLogic ImportLogic
+}
+
+type ImportHandler struct {
+	importLogic ImportLogic
+}
+
+func NewImportHandler(params ImportHandlerParams) *ImportHandler {
+	return &ImportHandler{
+		importLogic: params.ImportLogic,
+	}
+}
+
+var importBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: importError.ImportErrInvalidParam,
+	RequiredCode:     importError.ImportErrParamRequired,
+}
+
+// GetFormats 获取支持的导入格式
+// @Summary 获取支持的导入格式
+// @Description 返回可用于 format 参数的导入格式：todoist（项目 CSV 或备份 zip）、notion（Markdown & CSV 导出的 zip）、markdown（单个文件或 zip）
+// @Tags 数据导入
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=FormatsResp} "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Router /api/import/formats [get]
+func (h *ImportHandler) GetFormats(c *gin.Context) {
+	handle.Success(c, FormatsResp{Formats: h.importLogic.Formats()})
+}
+
+// Preview 预览导入
+// @Summary 预览导入
+// @Description 解析导入文件，返回将创建的项目数、新建和复用的标签、跳过的内容及原因和前 20 个项目，不写入数据
+// @Tags 数据导入
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param format formData string true "导入格式"
+// @Param file formData file true "导出文件"
+// @Success 200 {object} handle.Response{data=dto.ImportPreviewDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误或解析失败"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 413 {object} handle.Response "文件过大"
+// @Router /api/import/preview [post]
+func (h *ImportHandler) Preview(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req ImportReq
+	if err := bind.ShouldBind(c, &req, importBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "预览导入", nil)
+		return
+	}
+
+	preview, err := h.importLogic.Preview(ctx, req.Format, req.File)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "预览导入", nil)
+		return
+	}
+	handle.Success(c, preview)
+}
+
+// Import 导入数据
+// @Summary 导入数据
+// @Description 解析导入文件后通过后台任务队列创建标签和项目，通过 SSE 推送进度（事件名 progress），stage 依次为 queued、tags、items，最后为 completed 或 failed。
+// @Description 导入任务只执行一次，失败时已创建的项目保留；客户端断开不影响导入
+// @Description 带 dry_run=true 查询参数或 X-Dry-Run: true 请求头时不导入，以 JSON 返回与预览导入相同的结果
+// @Tags 数据导入
+// @Accept multipart/form-data
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param format formData string true "导入格式"
+// @Param file formData file true "导出文件"
+// @Param dry_run query bool false "试运行"
+// @Success 200 {object} dto.ImportProgressDTO "progress 事件"
+// @Failure 400 {object} handle.Response "请求参数错误或解析失败"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 413 {object} handle.Response "文件过大"
+// @Failure 503 {object} handle.Response "启动导入任务失败"
+// @Router /api/import [post]
+func (h *ImportHandler) Import(c *gin.Context) {
+	ctx := errorx.WithLocale(c.Request.Context(), handle.Locale(c))
+
+	var req ImportReq
+	if err := bind.ShouldBind(c, &req, importBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "导入数据", nil)
+		return
+	}
+
+	// 导入在后台任务中执行，无法回滚，试运行时返回预览结果
+	if meta.IsDryRun(ctx) {
+		preview, err := h.importLogic.Preview(ctx, req.Format, req.File)
+		if err != nil {
+			handle.HandleErrorWithContext(c, err, "导入数据", nil)
+			return
+		}
+		handle.Success(c, preview)
+		return
+	}
+
+	progress, err := h.importLogic.Import(ctx, req.Format, req.File)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "导入数据", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "开始推送导入进度")
+	cfg := handle.DefaultSSEConfig()
+	cfg.EventName = "progress"
+	handle.StreamSSE(c, progress, cfg)
+}
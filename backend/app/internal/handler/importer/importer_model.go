@@ -0,0 +1,13 @@
+package importer
+
+import "mime/multipart"
+
+type ImportReq struct {
+	Format string                `form:"format" binding:"required" label:"导入格式" example:"todoist"`
+	File   *multipart.FileHeader `form:"file" binding:"required" label:"导入文件"`
+}
+
+// FormatsResp 支持的导入格式
+type FormatsResp struct {
+	Formats []string `json:"formats"` // 导入格式
+}
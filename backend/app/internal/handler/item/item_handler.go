@@ -17,12 +17,13 @@ import (
 )
 
 type ItemLogic interface {
-	CreateItem(ctx context.Context, content string, status *meta.ItemStatus, tagIDs []uint) (*dto.ItemDTO, error)
-	UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, tagIDs []uint) (*dto.ItemDTO, error)
+	CreateItem(ctx context.Context, content string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint) (*dto.ItemDTO, error)
+	UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint, version *uint) (*dto.ItemDTO, error)
 	DeleteItem(ctx context.Context, itemID uint) error
 	GetItem(ctx context.Context, itemID uint) (*dto.ItemDTO, error)
-	GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, int, error)
+	GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
 	GetDailyItemCount(ctx context.Context, dateStart time.Time, dateEnd time.Time) ([]dto.DailyItemCountDTO, error)
+	SearchItems(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
 }
 
 type ItemHandlerParams struct {
@@ -42,18 +43,8 @@ func NewItemHandler(params ItemHandlerParams) *ItemHandler {
 }
 
 var itemBindConfig = bind.FieldErrorConfig{
-	InvalidParamCode: itemError.ItemErrDatabaseError,
-	RequiredCode:     itemError.ItemErrDatabaseError,
-	FieldLabels: map[string]string{
-		"item_id":    "项目ID",
-		"content":    "内容",
-		"status":     "状态",
-		"tags":       "标签",
-		"date_start": "开始日期",
-		"date_end":   "结束日期",
-		"page":       "页码",
-		"page_size":  "每页条数",
-	},
+	InvalidParamCode: itemError.ItemErrInvalidParam,
+	RequiredCode:     itemError.ItemErrParamRequired,
 }
 
 // CreateItem 创建项目
@@ -64,6 +55,7 @@ var itemBindConfig = bind.FieldErrorConfig{
 // @Produce json
 // @Security BearerAuth
 // @Param request body CreateItemReq true "创建项目请求"
+// @Param dry_run query bool false "试运行，写操作执行后回滚"
 // @Success 200 {object} handle.Response{data=dto.ItemDTO} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 500 {object} handle.Response "服务器内部错误"
@@ -77,7 +69,7 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		return
 	}
 
-	result, err := h.itemLogic.CreateItem(ctx, req.Content, req.Status, req.Tags)
+	result, err := h.itemLogic.CreateItem(ctx, req.Content, req.Status, req.DueAt, req.Tags)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "创建项目", nil)
 		return
@@ -96,9 +88,11 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 // @Security BearerAuth
 // @Param item_id path int true "项目ID"
 // @Param request body UpdateItemReq true "更新项目请求"
+// @Param dry_run query bool false "试运行，写操作执行后回滚"
 // @Success 200 {object} handle.Response{data=dto.ItemDTO} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 404 {object} handle.Response "项目不存在"
+// @Failure 409 {object} handle.Response "项目已被修改（版本冲突）"
 // @Failure 500 {object} handle.Response "服务器内部错误"
 // @Router /api/item/{item_id} [put]
 func (h *ItemHandler) UpdateItem(c *gin.Context) {
@@ -116,7 +110,12 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	result, err := h.itemLogic.UpdateItem(ctx, uri.ItemID, req.Content, req.Status, req.Tags)
+	dueAt := req.DueAt
+	if req.ClearDueAt {
+		dueAt = &time.Time{}
+	}
+
+	result, err := h.itemLogic.UpdateItem(ctx, uri.ItemID, req.Content, req.Status, dueAt, req.Tags, req.Version)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "更新项目", nil)
 		return
@@ -134,6 +133,7 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param item_id path int true "项目ID"
+// @Param dry_run query bool false "试运行，写操作执行后回滚"
 // @Success 200 {object} handle.Response "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 404 {object} handle.Response "项目不存在"
@@ -196,12 +196,12 @@ func (h *ItemHandler) GetItem(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param date_start query string false "开始日期"
-// @Param date_end query string false "结束日期"
+// @Param date_start query string false "开始日期（YYYY-MM-DD，或 today、yesterday、last-7-days、this-month 等相对日期）"
+// @Param date_end query string false "结束日期（同 date_start，相对日期按 X-Timezone 请求头的时区计算）"
 // @Param status query string false "状态"
 // @Param page query int false "页码"
 // @Param page_size query int false "每页条数"
-// @Success 200 {object} handle.Response{data=GetItemListResp} "成功"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.ItemDTO]} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 500 {object} handle.Response "服务器内部错误"
 // @Router /api/item/list [get]
@@ -214,10 +214,11 @@ func (h *ItemHandler) GetItemList(c *gin.Context) {
 		return
 	}
 
-	// 解析时间字符串
+	// 解析时间字符串（相对日期按请求时区计算）
+	loc := handle.Location(c)
 	var dateStart *time.Time
 	if req.DateStart != nil && *req.DateStart != "" {
-		parsed, err := timex.ParseDateString(*req.DateStart)
+		parsed, err := timex.ParseDateStart(*req.DateStart, loc)
 		if err != nil {
 			handle.HandleErrorWithContext(c, err, "获取项目列表", nil)
 			return
@@ -227,7 +228,7 @@ func (h *ItemHandler) GetItemList(c *gin.Context) {
 
 	var dateEnd *time.Time
 	if req.DateEnd != nil && *req.DateEnd != "" {
-		parsed, err := timex.ParseDateString(*req.DateEnd)
+		parsed, err := timex.ParseDateEnd(*req.DateEnd, loc)
 		if err != nil {
 			handle.HandleErrorWithContext(c, err, "获取项目列表", nil)
 			return
@@ -235,20 +236,48 @@ func (h *ItemHandler) GetItemList(c *gin.Context) {
 		dateEnd = &parsed
 	}
 
-	items, total, totalPages, err := h.itemLogic.GetItemList(ctx, dateStart, dateEnd, req.Status, req.Page, req.PageSize)
+	items, total, err := h.itemLogic.GetItemList(ctx, dateStart, dateEnd, req.Status, req.Page, req.PageSize)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "获取项目列表", nil)
 		return
 	}
 
 	logs.CtxInfof(ctx, "获取项目列表成功: page=%d, page_size=%d, total=%d", req.Page, req.PageSize, total)
-	handle.Success(c, GetItemListResp{
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		Total:      int(total),
-		TotalPages: totalPages,
-		Items:      items,
-	})
+	handle.Paginated(c, items, req.Page, req.PageSize, total)
+}
+
+// SearchItems 搜索项目
+// @Summary 搜索项目
+// @Description 按关键词搜索项目内容和标签，配置 ElasticSearch 时使用全文检索
+// @Tags 项目管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param keyword query string true "关键词"
+// @Param status query string false "状态"
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.ItemDTO]} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/item/search [get]
+func (h *ItemHandler) SearchItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req SearchItemsReq
+	if err := bind.ShouldBindQuery(c, &req, itemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "搜索项目", nil)
+		return
+	}
+
+	items, total, err := h.itemLogic.SearchItems(ctx, req.Keyword, req.Status, req.Page, req.PageSize)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "搜索项目", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "搜索项目成功: keyword=%s, page=%d, page_size=%d, total=%d", req.Keyword, req.Page, req.PageSize, total)
+	handle.Paginated(c, items, req.Page, req.PageSize, total)
 }
 
 // GetDailyItemCount 获取每日项目数量
@@ -258,8 +287,8 @@ func (h *ItemHandler) GetItemList(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param date_start query string true "开始日期"
-// @Param date_end query string true "结束日期"
+// @Param date_start query string true "开始日期（YYYY-MM-DD，或 today、yesterday、last-7-days、this-month 等相对日期）"
+// @Param date_end query string true "结束日期（同 date_start，相对日期按 X-Timezone 请求头的时区计算）"
 // @Success 200 {object} handle.Response{data=GetDailyItemCountResp} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 500 {object} handle.Response "服务器内部错误"
@@ -273,14 +302,15 @@ func (h *ItemHandler) GetDailyItemCount(c *gin.Context) {
 		return
 	}
 
-	// 解析时间字符串
-	dateStart, err := timex.ParseDateString(req.DateStart)
+	// 解析时间字符串（相对日期按请求时区计算）
+	loc := handle.Location(c)
+	dateStart, err := timex.ParseDateStart(req.DateStart, loc)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "获取每日项目数量", nil)
 		return
 	}
 
-	dateEnd, err := timex.ParseDateString(req.DateEnd)
+	dateEnd, err := timex.ParseDateEnd(req.DateEnd, loc)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "获取每日项目数量", nil)
 		return
@@ -1,6 +1,8 @@
 package item
 
 import (
+	"time"
+
 	"backend/app/types/dto"
 	"backend/app/types/meta"
 )
@@ -11,35 +13,39 @@ type ItemURI struct {
 
 type CreateItemReq struct {
 	Content string           `json:"content" binding:"required,min=3,max=1000" label:"内容" example:"这是一个项目"`
-	Status  *meta.ItemStatus `json:"status" binding:"omitempty,oneof=normal done marked" label:"状态" example:"normal"`
+	Status  *meta.ItemStatus `json:"status" binding:"omitempty,itemstatus" label:"状态" example:"normal"`
+	DueAt   *time.Time       `json:"due_at" label:"截止时间" example:"2025-01-01T18:00:00+08:00"`
 	Tags    []uint           `json:"tags" binding:"omitempty,min=1,max=10" label:"标签ID" example:"1,2,3"`
 }
 
 type UpdateItemReq struct {
 	Content *string          `json:"content" binding:"omitempty,min=3,max=1000" label:"内容" example:"这是一个项目"`
-	Status  *meta.ItemStatus `json:"status" binding:"omitempty,oneof=normal done marked" label:"状态" example:"normal"`
-	Tags    []uint           `json:"tags" binding:"omitempty,min=1,max=10" label:"标签ID" example:"1,2,3"`
+	Status  *meta.ItemStatus `json:"status" binding:"omitempty,itemstatus" label:"状态" example:"normal"`
+	DueAt   *time.Time       `json:"due_at" label:"截止时间" example:"2025-01-01T18:00:00+08:00"`
+	// ClearDueAt 为 true 时清除截止时间，忽略 due_at
+	ClearDueAt bool   `json:"clear_due_at" label:"清除截止时间" example:"false"`
+	Tags       []uint `json:"tags" binding:"omitempty,min=1,max=10" label:"标签ID" example:"1,2,3"`
+	Version    *uint  `json:"version" binding:"omitempty,min=1" label:"版本号" example:"1"`
 }
 
 type GetItemListReq struct {
-	DateStart *string          `form:"date_start" binding:"omitempty" label:"开始日期" example:"2025-01-01"`
-	DateEnd   *string          `form:"date_end" binding:"omitempty" label:"结束日期" example:"2025-01-02"`
-	Status    *meta.ItemStatus `form:"status" binding:"omitempty,oneof=normal done marked" label:"状态" example:"normal"`
+	DateStart *string          `form:"date_start" binding:"omitempty,dateformat" label:"开始日期" example:"2025-01-01"`
+	DateEnd   *string          `form:"date_end" binding:"omitempty,dateformat" label:"结束日期" example:"2025-01-02"`
+	Status    *meta.ItemStatus `form:"status" binding:"omitempty,itemstatus" label:"状态" example:"normal"`
 	Page      int              `form:"page" binding:"required,min=1" label:"页码"`
 	PageSize  int              `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
 }
 
-type GetItemListResp struct {
-	Page       int           `json:"page"`
-	PageSize   int           `json:"page_size"`
-	Total      int           `json:"total"`
-	TotalPages int           `json:"total_pages"`
-	Items      []dto.ItemDTO `json:"items"`
+type SearchItemsReq struct {
+	Keyword  string           `form:"keyword" binding:"required,min=1,max=100" label:"关键词" example:"项目"`
+	Status   *meta.ItemStatus `form:"status" binding:"omitempty,itemstatus" label:"状态" example:"normal"`
+	Page     int              `form:"page" binding:"required,min=1" label:"页码"`
+	PageSize int              `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
 }
 
 type GetDailyItemCountReq struct {
-	DateStart string `form:"date_start" binding:"required" label:"开始日期" example:"2025-01-01"`
-	DateEnd   string `form:"date_end" binding:"required" label:"结束日期" example:"2025-01-02"`
+	DateStart string `form:"date_start" binding:"required,dateformat" label:"开始日期" example:"2025-01-01"`
+	DateEnd   string `form:"date_end" binding:"required,dateformat" label:"结束日期" example:"2025-01-02"`
 }
 
 type GetDailyItemCountResp struct {
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	notifyError "backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/handle"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type NotifyLogic interface {
+	GetPreference(ctx context.Context) (*dto.NotificationPreferenceDTO, error)
+	UpdatePreference(ctx context.Context, emailEnabled *bool, dailyDigest *bool) (*dto.NotificationPreferenceDTO, error)
+	SendTestEmail(ctx context.Context, to string) error
+}
+
+type NotifyHandlerParams struct {
+	fx.In
+
+	NotifyLogic NotifyLogic
+}
+
+type NotifyHandler struct {
+	notifyLogic NotifyLogic
+}
+
+func NewNotifyHandler(params NotifyHandlerParams) *NotifyHandler {
+	return &NotifyHandler{
+		notifyLogic: params.NotifyLogic,
+	}
+}
+
+var notifyBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: notifyError.NotifyErrInvalidParam,
+	RequiredCode:     notifyError.NotifyErrParamRequired,
+}
+
+// GetPreference 获取通知偏好
+// @Summary 获取通知偏好
+// @Description 获取当前登录用户的邮件通知偏好，未设置时返回默认值
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.NotificationPreferenceDTO} "成功"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/notify/preferences [get]
+func (h *NotifyHandler) GetPreference(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.notifyLogic.GetPreference(ctx)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取通知偏好", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// UpdatePreference 更新通知偏好
+// @Summary 更新通知偏好
+// @Description 更新当前登录用户的邮件通知偏好，每日摘要只发送给邮箱已验证的用户
+// @Tags 通知
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdatePreferenceReq true "更新通知偏好请求"
+// @Success 200 {object} handle.Response{data=dto.NotificationPreferenceDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/notify/preferences [put]
+func (h *NotifyHandler) UpdatePreference(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req UpdatePreferenceReq
+	if err := bind.ShouldBindJSON(c, &req, notifyBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "更新通知偏好", nil)
+		return
+	}
+
+	result, err := h.notifyLogic.UpdatePreference(ctx, req.EmailEnabled, req.DailyDigest)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "更新通知偏好", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "更新通知偏好成功: email_enabled=%t, daily_digest=%t", result.EmailEnabled, result.DailyDigest)
+	handle.Success(c, result)
+}
+
+// SendTestEmail 发送测试邮件
+// @Summary 发送测试邮件
+// @Description 不经过任务队列直接发送一封测试邮件，用于检查 SMTP 配置，需要管理员权限
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SendTestEmailReq true "发送测试邮件请求"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 403 {object} handle.Response "没有管理员权限"
+// @Failure 502 {object} handle.Response "SMTP 服务器拒绝或无法连接"
+// @Router /api/system/email/test [post]
+func (h *NotifyHandler) SendTestEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req SendTestEmailReq
+	if err := bind.ShouldBindJSON(c, &req, notifyBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "发送测试邮件", nil)
+		return
+	}
+
+	if err := h.notifyLogic.SendTestEmail(ctx, req.To); err != nil {
+		handle.HandleErrorWithContext(c, err, "发送测试邮件", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "发送测试邮件成功: to=%s", req.To)
+	handle.Success(c, nil)
+}
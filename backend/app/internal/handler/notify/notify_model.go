@@ -0,0 +1,12 @@
+package notify
+
+// UpdatePreferenceReq 更新通知偏好请求，未传的字段保持不变
+type UpdatePreferenceReq struct {
+	EmailEnabled *bool `json:"email_enabled" binding:"omitempty" label:"接收通知邮件" example:"true"`
+	DailyDigest  *bool `json:"daily_digest" binding:"omitempty" label:"接收每日摘要" example:"true"`
+}
+
+// SendTestEmailReq 发送测试邮件请求
+type SendTestEmailReq struct {
+	To string `json:"to" binding:"required,email,max=128" label:"收件人" example:"ops@example.com"`
+}
@@ -1,10 +1,22 @@
 package handler
 
 import (
+	auditHandler "backend/app/internal/handler/audit"
+	botHandler "backend/app/internal/handler/bot"
+	changeHandler "backend/app/internal/handler/change"
+	exportHandler "backend/app/internal/handler/export"
+	feedHandler "backend/app/internal/handler/feed"
 	fileHandler "backend/app/internal/handler/file"
+	graphHandler "backend/app/internal/handler/graph"
+	importerHandler "backend/app/internal/handler/importer"
 	itemHandler "backend/app/internal/handler/item"
+	notifyHandler "backend/app/internal/handler/notify"
+	rpcHandler "backend/app/internal/handler/rpc"
+	statsHandler "backend/app/internal/handler/stats"
+	systemHandler "backend/app/internal/handler/system"
 	tagHandler "backend/app/internal/handler/tag"
 	userHandler "backend/app/internal/handler/user"
+	webhookHandler "backend/app/internal/handler/webhook"
 
 	"go.uber.org/fx"
 )
@@ -20,5 +32,31 @@ var HandlerModule = fx.Module("handler",
 		itemHandler.NewItemHandler,
 		// Tag Handler
 		tagHandler.NewTagHandler,
+		// System Handler
+		systemHandler.NewSystemHandler,
+		// Webhook Handler
+		webhookHandler.NewWebhookHandler,
+		// Notify Handler
+		notifyHandler.NewNotifyHandler,
+		// Change Handler
+		changeHandler.NewChangeHandler,
+		// Export Handler
+		exportHandler.NewExportHandler,
+		// Import Handler
+		importerHandler.NewImportHandler,
+		// Audit Handler
+		auditHandler.NewAuditHandler,
+		// Feed Handler
+		feedHandler.NewFeedHandler,
+		// Stats Handler
+		statsHandler.NewStatsHandler,
+		// Bot Handler
+		botHandler.NewBotHandler,
+		// GraphQL Handler
+		graphHandler.NewGraphHandler,
+		// gRPC Services
+		rpcHandler.NewItemService,
+		rpcHandler.NewTagService,
+		rpcHandler.NewUserService,
 	),
 )
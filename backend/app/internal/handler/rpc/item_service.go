@@ -0,0 +1,186 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"backend/app/types/dto"
+	itemError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	peanov1 "backend/proto/peano/v1"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/timex"
+
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ItemLogic interface {
+	CreateItem(ctx context.Context, content string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint) (*dto.ItemDTO, error)
+	UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint, version *uint) (*dto.ItemDTO, error)
+	DeleteItem(ctx context.Context, itemID uint) error
+	GetItem(ctx context.Context, itemID uint) (*dto.ItemDTO, error)
+	GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
+	GetDailyItemCount(ctx context.Context, dateStart time.Time, dateEnd time.Time) ([]dto.DailyItemCountDTO, error)
+	SearchItems(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
+}
+
+type ItemServiceParams struct {
+	fx.In
+
+	ItemLogic ItemLogic
+}
+
+// ItemService gRPC 项目服务
+type ItemService struct {
+	peanov1.UnimplementedItemServiceServer
+
+	itemLogic ItemLogic
+}
+
+func NewItemService(params ItemServiceParams) *ItemService {
+	return &ItemService{
+		itemLogic: params.ItemLogic,
+	}
+}
+
+var itemBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: itemError.ItemErrInvalidParam,
+	RequiredCode:     itemError.ItemErrParamRequired,
+}
+
+// CreateItem 创建项目
+func (s *ItemService) CreateItem(ctx context.Context, req *peanov1.CreateItemRequest) (*peanov1.Item, error) {
+	args := createItemArgs{Content: req.GetContent(), TagIDs: toUints(req.GetTagIds())}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	result, err := s.itemLogic.CreateItem(ctx, args.Content, toMetaStatus(req.GetStatus()), nil, args.TagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "创建项目成功: item_id=%d", result.ItemID)
+	return toItemPB(result), nil
+}
+
+// GetItem 获取项目
+func (s *ItemService) GetItem(ctx context.Context, req *peanov1.GetItemRequest) (*peanov1.Item, error) {
+	result, err := s.itemLogic.GetItem(ctx, uint(req.GetItemId()))
+	if err != nil {
+		return nil, err
+	}
+	return toItemPB(result), nil
+}
+
+// UpdateItem 更新项目
+func (s *ItemService) UpdateItem(ctx context.Context, req *peanov1.UpdateItemRequest) (*peanov1.Item, error) {
+	args := updateItemArgs{Content: req.Content, TagIDs: toUints(req.GetTagIds())}
+	if req.Version != nil {
+		version := uint(req.GetVersion())
+		args.Version = &version
+	}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	result, err := s.itemLogic.UpdateItem(ctx, uint(req.GetItemId()), args.Content, toMetaStatus(req.GetStatus()), nil, args.TagIDs, args.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "更新项目成功: item_id=%d", result.ItemID)
+	return toItemPB(result), nil
+}
+
+// DeleteItem 删除项目
+func (s *ItemService) DeleteItem(ctx context.Context, req *peanov1.DeleteItemRequest) (*emptypb.Empty, error) {
+	if err := s.itemLogic.DeleteItem(ctx, uint(req.GetItemId())); err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "删除项目成功: item_id=%d", req.GetItemId())
+	return &emptypb.Empty{}, nil
+}
+
+// ListItems 获取项目列表
+func (s *ItemService) ListItems(ctx context.Context, req *peanov1.ListItemsRequest) (*peanov1.ListItemsResponse, error) {
+	args := listItemsArgs{DateStart: req.DateStart, DateEnd: req.DateEnd, pageArgs: newPageArgs(req.GetPage(), req.GetPageSize())}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	// 解析时间字符串（相对日期按请求时区计算）
+	loc := locationFromContext(ctx)
+	var dateStart *time.Time
+	if args.DateStart != nil && *args.DateStart != "" {
+		parsed, err := timex.ParseDateStart(*args.DateStart, loc)
+		if err != nil {
+			return nil, errorx.Wrap(err, itemError.ItemErrInvalidParam, errorx.K("reason", err.Error()))
+		}
+		dateStart = &parsed
+	}
+
+	var dateEnd *time.Time
+	if args.DateEnd != nil && *args.DateEnd != "" {
+		parsed, err := timex.ParseDateEnd(*args.DateEnd, loc)
+		if err != nil {
+			return nil, errorx.Wrap(err, itemError.ItemErrInvalidParam, errorx.K("reason", err.Error()))
+		}
+		dateEnd = &parsed
+	}
+
+	items, total, err := s.itemLogic.GetItemList(ctx, dateStart, dateEnd, toMetaStatus(req.GetStatus()), args.Page, args.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return toItemListPB(items, total, args.pageArgs), nil
+}
+
+// SearchItems 搜索项目
+func (s *ItemService) SearchItems(ctx context.Context, req *peanov1.SearchItemsRequest) (*peanov1.ListItemsResponse, error) {
+	args := searchItemsArgs{Keyword: req.GetKeyword(), pageArgs: newPageArgs(req.GetPage(), req.GetPageSize())}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	items, total, err := s.itemLogic.SearchItems(ctx, args.Keyword, toMetaStatus(req.GetStatus()), args.Page, args.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	return toItemListPB(items, total, args.pageArgs), nil
+}
+
+// GetDailyItemCount 获取每日项目数量
+func (s *ItemService) GetDailyItemCount(ctx context.Context, req *peanov1.GetDailyItemCountRequest) (*peanov1.GetDailyItemCountResponse, error) {
+	args := dailyItemCountArgs{DateStart: req.GetDateStart(), DateEnd: req.GetDateEnd()}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), itemBindConfig); err != nil {
+		return nil, err
+	}
+
+	// 解析时间字符串（相对日期按请求时区计算）
+	loc := locationFromContext(ctx)
+	dateStart, err := timex.ParseDateStart(args.DateStart, loc)
+	if err != nil {
+		return nil, errorx.Wrap(err, itemError.ItemErrInvalidParam, errorx.K("reason", err.Error()))
+	}
+	dateEnd, err := timex.ParseDateEnd(args.DateEnd, loc)
+	if err != nil {
+		return nil, errorx.Wrap(err, itemError.ItemErrInvalidParam, errorx.K("reason", err.Error()))
+	}
+
+	counts, err := s.itemLogic.GetDailyItemCount(ctx, dateStart, dateEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &peanov1.GetDailyItemCountResponse{DailyItemCounts: make([]*peanov1.DailyItemCount, len(counts))}
+	for i, count := range counts {
+		resp.DailyItemCounts[i] = &peanov1.DailyItemCount{Date: timestamppb.New(count.Date), Count: int32(count.Count)}
+	}
+	return resp, nil
+}
@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"backend/app/types/dto"
+	"backend/app/types/meta"
+	peanov1 "backend/proto/peano/v1"
+	"backend/utils/handle"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// 请求参数的验证规则与 REST 接口的请求参数一致，proto 消息没有 binding 标签，验证前先转换为以下结构体
+
+const (
+	// defaultPage 未设置页码时的默认值
+	defaultPage = 1
+	// defaultPageSize 未设置每页条数时的默认值
+	defaultPageSize = 20
+)
+
+type pageArgs struct {
+	Page     int `json:"page" binding:"min=1" label:"页码"`
+	PageSize int `json:"page_size" binding:"min=1,max=100" label:"每页条数"`
+}
+
+// newPageArgs 创建分页参数，proto3 中未设置的页码、每页条数为 0，使用默认值
+func newPageArgs(page, pageSize int32) pageArgs {
+	args := pageArgs{Page: int(page), PageSize: int(pageSize)}
+	if args.Page == 0 {
+		args.Page = defaultPage
+	}
+	if args.PageSize == 0 {
+		args.PageSize = defaultPageSize
+	}
+	return args
+}
+
+type createItemArgs struct {
+	Content string `json:"content" binding:"required,min=3,max=1000" label:"内容"`
+	TagIDs  []uint `json:"tag_ids" binding:"omitempty,min=1,max=10" label:"标签ID"`
+}
+
+type updateItemArgs struct {
+	Content *string `json:"content" binding:"omitempty,min=3,max=1000" label:"内容"`
+	TagIDs  []uint  `json:"tag_ids" binding:"omitempty,min=1,max=10" label:"标签ID"`
+	Version *uint   `json:"version" binding:"omitempty,min=1" label:"版本号"`
+}
+
+type listItemsArgs struct {
+	pageArgs
+	DateStart *string `json:"date_start" binding:"omitempty,dateformat" label:"开始日期"`
+	DateEnd   *string `json:"date_end" binding:"omitempty,dateformat" label:"结束日期"`
+}
+
+type searchItemsArgs struct {
+	pageArgs
+	Keyword string `json:"keyword" binding:"required,min=1,max=100" label:"关键词"`
+}
+
+type dailyItemCountArgs struct {
+	DateStart string `json:"date_start" binding:"required,dateformat" label:"开始日期"`
+	DateEnd   string `json:"date_end" binding:"required,dateformat" label:"结束日期"`
+}
+
+type createTagArgs struct {
+	TagName  string  `json:"tag_name" binding:"required,min=1,max=12" label:"标签名"`
+	TagValue string  `json:"tag_value" binding:"required,min=1,max=32" label:"标签值"`
+	Icon     *string `json:"icon" binding:"omitempty,min=1,max=255" label:"图标"`
+	Color    *string `json:"color" binding:"omitempty,hexcolor" label:"颜色"`
+}
+
+type updateTagArgs struct {
+	TagName  *string `json:"tag_name" binding:"omitempty,min=1,max=12" label:"标签名"`
+	TagValue *string `json:"tag_value" binding:"omitempty,min=1,max=32" label:"标签值"`
+	Icon     *string `json:"icon" binding:"omitempty,min=1,max=255" label:"图标"`
+	Color    *string `json:"color" binding:"omitempty,hexcolor" label:"颜色"`
+}
+
+type loginArgs struct {
+	Username string `json:"username" binding:"required,min=3,max=32" label:"用户名"`
+	Password string `json:"password" binding:"required,min=8,max=16" label:"密码"`
+}
+
+type refreshTokenArgs struct {
+	RefreshToken string `json:"refresh_token" binding:"required" label:"刷新令牌"`
+}
+
+// itemStatuses 项目状态与 proto 枚举的对应关系
+var itemStatuses = map[meta.ItemStatus]peanov1.ItemStatus{
+	meta.ItemStatusNormal: peanov1.ItemStatus_ITEM_STATUS_NORMAL,
+	meta.ItemStatusDone:   peanov1.ItemStatus_ITEM_STATUS_DONE,
+	meta.ItemStatusMarked: peanov1.ItemStatus_ITEM_STATUS_MARKED,
+}
+
+// toMetaStatus 将 proto 枚举转换为项目状态，未设置时返回 nil
+func toMetaStatus(status peanov1.ItemStatus) *meta.ItemStatus {
+	for s, pb := range itemStatuses {
+		if pb == status {
+			return &s
+		}
+	}
+	return nil
+}
+
+// locationFromContext 返回请求使用的时区（x-timezone 元数据），用于解析相对日期
+// 没有设置或无法识别时使用 UTC
+func locationFromContext(ctx context.Context) *time.Location {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(handle.TimezoneHeader)
+	if len(values) == 0 || values[0] == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(values[0])
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// toUints 将 proto 的 uint64 ID 列表转换为 uint，空列表返回 nil（更新时不修改）
+func toUints(ids []uint64) []uint {
+	if len(ids) == 0 {
+		return nil
+	}
+	result := make([]uint, len(ids))
+	for i, id := range ids {
+		result[i] = uint(id)
+	}
+	return result
+}
+
+func toTagPB(tag *dto.TagDTO) *peanov1.Tag {
+	return &peanov1.Tag{
+		TagId:    uint64(tag.TagID),
+		TagName:  tag.TagName,
+		TagValue: tag.TagValue,
+		Icon:     tag.Icon,
+		Color:    tag.Color,
+	}
+}
+
+func toItemPB(item *dto.ItemDTO) *peanov1.Item {
+	result := &peanov1.Item{
+		ItemId:    uint64(item.ItemID),
+		Content:   item.Content,
+		Status:    itemStatuses[meta.ItemStatus(item.Status)],
+		Version:   uint64(item.Version),
+		Tags:      make([]*peanov1.Tag, len(item.Tags)),
+		CreatedAt: timestamppb.New(item.CreatedAt),
+		UpdatedAt: timestamppb.New(item.UpdatedAt),
+	}
+	for i := range item.Tags {
+		result.Tags[i] = toTagPB(&item.Tags[i])
+	}
+	return result
+}
+
+func toItemListPB(items []dto.ItemDTO, total int64, page pageArgs) *peanov1.ListItemsResponse {
+	resp := &peanov1.ListItemsResponse{
+		Items:    make([]*peanov1.Item, len(items)),
+		Total:    total,
+		Page:     int32(page.Page),
+		PageSize: int32(page.PageSize),
+	}
+	for i := range items {
+		resp.Items[i] = toItemPB(&items[i])
+	}
+	return resp
+}
+
+func toUserPB(user *dto.UserDTO) *peanov1.User {
+	return &peanov1.User{
+		UserId:        uint64(user.UserID),
+		Username:      user.Username,
+		NickName:      user.NickName,
+		Avatar:        user.Avatar,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+	}
+}
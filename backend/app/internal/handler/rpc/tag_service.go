@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	tagError "backend/app/types/errorn"
+	peanov1 "backend/proto/peano/v1"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type TagLogic interface {
+	CreateTag(ctx context.Context, tagName string, tagValue string, icon *string, color *string) (*dto.TagDTO, error)
+	UpdateTag(ctx context.Context, tagID uint, tagName *string, tagValue *string, icon *string, color *string) (*dto.TagDTO, error)
+	DeleteTag(ctx context.Context, tagID uint) error
+	GetTag(ctx context.Context, tagID uint) (*dto.TagDTO, error)
+	GetTagList(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, error)
+}
+
+type TagServiceParams struct {
+	fx.In
+
+	TagLogic TagLogic
+}
+
+// TagService gRPC 标签服务
+type TagService struct {
+	peanov1.UnimplementedTagServiceServer
+
+	tagLogic TagLogic
+}
+
+func NewTagService(params TagServiceParams) *TagService {
+	return &TagService{
+		tagLogic: params.TagLogic,
+	}
+}
+
+var tagBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: tagError.TagErrInvalidParam,
+	RequiredCode:     tagError.TagErrParamRequired,
+}
+
+// CreateTag 创建标签
+func (s *TagService) CreateTag(ctx context.Context, req *peanov1.CreateTagRequest) (*peanov1.Tag, error) {
+	args := createTagArgs{TagName: req.GetTagName(), TagValue: req.GetTagValue(), Icon: req.Icon, Color: req.Color}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), tagBindConfig); err != nil {
+		return nil, err
+	}
+
+	result, err := s.tagLogic.CreateTag(ctx, args.TagName, args.TagValue, args.Icon, args.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "创建标签成功: tag_id=%d", result.TagID)
+	return toTagPB(result), nil
+}
+
+// GetTag 获取标签
+func (s *TagService) GetTag(ctx context.Context, req *peanov1.GetTagRequest) (*peanov1.Tag, error) {
+	result, err := s.tagLogic.GetTag(ctx, uint(req.GetTagId()))
+	if err != nil {
+		return nil, err
+	}
+	return toTagPB(result), nil
+}
+
+// UpdateTag 更新标签
+func (s *TagService) UpdateTag(ctx context.Context, req *peanov1.UpdateTagRequest) (*peanov1.Tag, error) {
+	args := updateTagArgs{TagName: req.TagName, TagValue: req.TagValue, Icon: req.Icon, Color: req.Color}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), tagBindConfig); err != nil {
+		return nil, err
+	}
+
+	result, err := s.tagLogic.UpdateTag(ctx, uint(req.GetTagId()), args.TagName, args.TagValue, args.Icon, args.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "更新标签成功: tag_id=%d", result.TagID)
+	return toTagPB(result), nil
+}
+
+// DeleteTag 删除标签
+func (s *TagService) DeleteTag(ctx context.Context, req *peanov1.DeleteTagRequest) (*emptypb.Empty, error) {
+	if err := s.tagLogic.DeleteTag(ctx, uint(req.GetTagId())); err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "删除标签成功: tag_id=%d", req.GetTagId())
+	return &emptypb.Empty{}, nil
+}
+
+// ListTags 获取标签列表
+func (s *TagService) ListTags(ctx context.Context, req *peanov1.ListTagsRequest) (*peanov1.ListTagsResponse, error) {
+	args := newPageArgs(req.GetPage(), req.GetPageSize())
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), tagBindConfig); err != nil {
+		return nil, err
+	}
+
+	tags, total, err := s.tagLogic.GetTagList(ctx, args.Page, args.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &peanov1.ListTagsResponse{
+		Tags:     make([]*peanov1.Tag, len(tags)),
+		Total:    total,
+		Page:     int32(args.Page),
+		PageSize: int32(args.PageSize),
+	}
+	for i := range tags {
+		resp.Tags[i] = toTagPB(&tags[i])
+	}
+	return resp, nil
+}
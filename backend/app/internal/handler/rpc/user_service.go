@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	authError "backend/app/types/errorn"
+	peanov1 "backend/proto/peano/v1"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+
+	"go.uber.org/fx"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type UserLogic interface {
+	Login(ctx context.Context, username string, password string) (*dto.UserDTO, *dto.TokenDTO, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenDTO, error)
+	GetUserInfo(ctx context.Context) (*dto.UserDTO, error)
+	UpdateUserInfo(ctx context.Context, nickName *string, avatar *string, locale *string) (*dto.UserDTO, error)
+}
+
+type UserServiceParams struct {
+	fx.In
+
+	UserLogic UserLogic
+}
+
+// UserService gRPC 用户服务
+type UserService struct {
+	peanov1.UnimplementedUserServiceServer
+
+	userLogic UserLogic
+}
+
+func NewUserService(params UserServiceParams) *UserService {
+	return &UserService{
+		userLogic: params.UserLogic,
+	}
+}
+
+var userBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: authError.AuthErrInvalidParam,
+	RequiredCode:     authError.AuthErrParamRequired,
+}
+
+// Login 用户名密码登录
+func (s *UserService) Login(ctx context.Context, req *peanov1.LoginRequest) (*peanov1.LoginResponse, error) {
+	args := loginArgs{Username: req.GetUsername(), Password: req.GetPassword()}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), userBindConfig); err != nil {
+		return nil, err
+	}
+
+	user, token, err := s.userLogic.Login(ctx, args.Username, args.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "用户登录成功: user_id=%d", user.UserID)
+	return &peanov1.LoginResponse{
+		UserId:       uint64(user.UserID),
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	}, nil
+}
+
+// RefreshToken 刷新令牌
+func (s *UserService) RefreshToken(ctx context.Context, req *peanov1.RefreshTokenRequest) (*peanov1.RefreshTokenResponse, error) {
+	args := refreshTokenArgs{RefreshToken: req.GetRefreshToken()}
+	if err := bind.Validate(&args, errorx.LocaleFromContext(ctx), userBindConfig); err != nil {
+		return nil, err
+	}
+
+	token, err := s.userLogic.RefreshToken(ctx, args.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return &peanov1.RefreshTokenResponse{
+		AccessToken:  token.AccessToken,
+		RefreshToken: token.RefreshToken,
+	}, nil
+}
+
+// GetUserInfo 获取当前用户信息
+func (s *UserService) GetUserInfo(ctx context.Context, _ *emptypb.Empty) (*peanov1.User, error) {
+	user, err := s.userLogic.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toUserPB(user), nil
+}
+
+// UpdateUserInfo 更新当前用户信息
+func (s *UserService) UpdateUserInfo(ctx context.Context, req *peanov1.UpdateUserInfoRequest) (*peanov1.User, error) {
+	user, err := s.userLogic.UpdateUserInfo(ctx, req.NickName, req.Avatar, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	logs.CtxInfof(ctx, "更新用户信息成功: user_id=%d", user.UserID)
+	return toUserPB(user), nil
+}
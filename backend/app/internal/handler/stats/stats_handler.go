@@ -0,0 +1,71 @@
+package stats
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	statsError "backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/handle"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type StatsLogic interface {
+	GetStats(ctx context.Context, window string) (*dto.AdminStatsDTO, error)
+}
+
+type StatsHandlerParams struct {
+	fx.In
+
+	StatsLogic StatsLogic
+}
+
+type StatsHandler struct {
+	statsLogic StatsLogic
+}
+
+func NewStatsHandler(params StatsHandlerParams) *StatsHandler {
+	return &StatsHandler{
+		statsLogic: params.StatsLogic,
+	}
+}
+
+var statsBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: statsError.StatsErrInvalidParam,
+	RequiredCode:     statsError.StatsErrParamRequired,
+}
+
+// GetStats 获取管理后台统计
+// @Summary 获取管理后台统计
+// @Description 获取用户数、按状态统计的项目数、文件数和存储用量、正在运行的 SSE 任务数，以及统计窗口内的请求数、平均每分钟请求数和错误率
+// @Description 请求数和 SSE 任务数只统计当前实例（进程重启后清零），请求只统计 /api 下的路由
+// @Tags 管理
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param window query string false "请求统计窗口，默认 1h" Enums(5m, 15m, 1h, 6h, 24h)
+// @Success 200 {object} handle.Response{data=dto.AdminStatsDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 403 {object} handle.Response "没有管理员权限"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/admin/stats [get]
+func (h *StatsHandler) GetStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req GetStatsReq
+	if err := bind.ShouldBindQuery(c, &req, statsBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取管理后台统计", nil)
+		return
+	}
+
+	result, err := h.statsLogic.GetStats(ctx, req.Window)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取管理后台统计", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
@@ -0,0 +1,5 @@
+package stats
+
+type GetStatsReq struct {
+	Window string `form:"window" binding:"omitempty,oneof=5m 15m 1h 6h 24h" label:"统计窗口" example:"1h"`
+}
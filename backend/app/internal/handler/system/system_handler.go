@@ -0,0 +1,391 @@
+package system
+
+import (
+	"context"
+	"net/http"
+
+	"backend/app/types/dto"
+	systemError "backend/app/types/errorn"
+	"backend/app/types/i18n"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type SystemLogic interface {
+	GetSystemInfo(ctx context.Context) (*dto.SystemInfoDTO, error)
+	ListJobs(ctx context.Context) ([]*dto.JobStatusDTO, error)
+	RunJob(ctx context.Context, name string) error
+	GetQueueStats(ctx context.Context) (*dto.QueueStatsDTO, error)
+	ListQueueJobs(ctx context.Context, status string, jobType string, page, pageSize int) ([]*dto.QueueJobDTO, int64, error)
+	GetQueueJob(ctx context.Context, jobID uint) (*dto.QueueJobDTO, error)
+	EnqueueQueueJob(ctx context.Context, jobType string) (*dto.QueueJobDTO, error)
+	RetryQueueJob(ctx context.Context, jobID uint) (*dto.QueueJobDTO, error)
+	ListSSETasks(ctx context.Context, status string, kind string, page, pageSize int) ([]*dto.SSETaskDTO, int64, error)
+	GetLogLevel(ctx context.Context) (*dto.LogLevelDTO, error)
+	SetLogLevel(ctx context.Context, level string) (*dto.LogLevelDTO, error)
+	ListErrorCodes(ctx context.Context) ([]*dto.ErrorCodeDTO, error)
+}
+
+var systemBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: systemError.SystemErrInvalidParam,
+	RequiredCode:     systemError.SystemErrParamRequired,
+}
+
+type SystemHandlerParams struct {
+	fx.In
+
+	SystemLogic SystemLogic
+}
+
+type SystemHandler struct {
+	systemLogic SystemLogic
+}
+
+func NewSystemHandler(params SystemHandlerParams) *SystemHandler {
+	return &SystemHandler{
+		systemLogic: params.SystemLogic,
+	}
+}
+
+// GetSystemInfo 获取系统信息
+// @Summary 获取系统信息
+// @Description 获取应用版本、Git 提交、表结构版本、数据库驱动和运行时长，用于确认当前部署
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.SystemInfoDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/system/info [get]
+func (h *SystemHandler) GetSystemInfo(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.systemLogic.GetSystemInfo(ctx)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取系统信息", &handle.ErrorConfig{
+			DefaultStatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// ListJobs 获取定时任务列表
+// @Summary 获取定时任务列表
+// @Description 获取所有定时任务的调度表达式、下一次执行时间和最近一次执行结果
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=[]dto.JobStatusDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Router /api/system/jobs [get]
+func (h *SystemHandler) ListJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.systemLogic.ListJobs(ctx)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取定时任务列表", &handle.ErrorConfig{
+			DefaultStatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// RunJob 手动触发定时任务
+// @Summary 手动触发定时任务
+// @Description 在后台立即执行一次定时任务，执行结果通过定时任务列表查看
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "任务名称"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 404 {object} handle.Response "任务不存在"
+// @Failure 409 {object} handle.Response "任务正在执行"
+// @Router /api/system/jobs/{name}/run [post]
+func (h *SystemHandler) RunJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	if err := h.systemLogic.RunJob(ctx, c.Param("name")); err != nil {
+		handle.HandleErrorWithContext(c, err, "触发定时任务", &handle.ErrorConfig{
+			DefaultStatusCode: http.StatusInternalServerError,
+		})
+		return
+	}
+
+	handle.SuccessWithMessage(c, i18n.MsgJobTriggered, nil)
+}
+
+// GetQueueStats 获取后台任务队列统计
+// @Summary 获取后台任务队列统计
+// @Description 获取已注册的任务类型和各状态的任务数量
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.QueueStatsDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/system/queue [get]
+func (h *SystemHandler) GetQueueStats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.systemLogic.GetQueueStats(ctx)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取后台任务队列统计", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// ListQueueJobs 获取后台任务列表
+// @Summary 获取后台任务列表
+// @Description 按状态和类型分页查询后台任务，按创建时间倒序
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "状态（pending、running、succeeded、dead）"
+// @Param type query string false "任务类型"
+// @Param page query int true "页码"
+// @Param page_size query int true "每页条数"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.QueueJobDTO]} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/system/queue/jobs [get]
+func (h *SystemHandler) ListQueueJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req ListQueueJobsReq
+	if err := bind.ShouldBindQuery(c, &req, systemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取后台任务列表", nil)
+		return
+	}
+
+	jobs, total, err := h.systemLogic.ListQueueJobs(ctx, req.Status, req.Type, req.Page, req.PageSize)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取后台任务列表", nil)
+		return
+	}
+
+	handle.Paginated(c, jobs, req.Page, req.PageSize, total)
+}
+
+// GetQueueJob 获取后台任务详情
+// @Summary 获取后台任务详情
+// @Description 获取后台任务的参数、状态、执行次数和最近一次错误
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job_id path int true "任务ID"
+// @Success 200 {object} handle.Response{data=dto.QueueJobDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 404 {object} handle.Response "任务不存在"
+// @Router /api/system/queue/jobs/{job_id} [get]
+func (h *SystemHandler) GetQueueJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri QueueJobURI
+	if err := bind.ShouldBindURI(c, &uri, systemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取后台任务详情", nil)
+		return
+	}
+
+	result, err := h.systemLogic.GetQueueJob(ctx, uri.JobID)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取后台任务详情", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// EnqueueQueueJob 添加后台任务
+// @Summary 添加后台任务
+// @Description 手动添加不需要参数的管理任务（目前只有 item.reindex），任务由工作协程异步执行，需要管理员权限
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body EnqueueQueueJobReq true "任务类型"
+// @Success 200 {object} handle.Response{data=dto.QueueJobDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误或任务类型不允许手动添加"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 403 {object} handle.Response "没有管理员权限"
+// @Router /api/system/queue/jobs [post]
+func (h *SystemHandler) EnqueueQueueJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req EnqueueQueueJobReq
+	if err := bind.ShouldBindJSON(c, &req, systemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "添加后台任务", nil)
+		return
+	}
+	if len(req.Payload) > 0 {
+		err := errorx.New(systemError.SystemErrInvalidParam, errorx.K("reason", "手动添加的后台任务不接受参数"))
+		handle.HandleErrorWithContext(c, err, "添加后台任务", nil)
+		return
+	}
+
+	result, err := h.systemLogic.EnqueueQueueJob(ctx, req.Type)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "添加后台任务", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// RetryQueueJob 重试死信任务
+// @Summary 重试死信任务
+// @Description 将死信状态的后台任务重新放入队列，执行次数清零
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param job_id path int true "任务ID"
+// @Success 200 {object} handle.Response{data=dto.QueueJobDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 404 {object} handle.Response "任务不存在"
+// @Failure 409 {object} handle.Response "任务不是死信状态"
+// @Router /api/system/queue/jobs/{job_id}/retry [post]
+func (h *SystemHandler) RetryQueueJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri QueueJobURI
+	if err := bind.ShouldBindURI(c, &uri, systemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "重试后台任务", nil)
+		return
+	}
+
+	result, err := h.systemLogic.RetryQueueJob(ctx, uri.JobID)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "重试后台任务", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// ListSSETasks 获取 SSE 任务列表
+// @Summary 获取 SSE 任务列表
+// @Description 按状态和类型分页查询本实例上的 SSE 任务（导出、导入、变更订阅），按创建时间倒序；结束的任务保留到过期
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "状态（queued、running、paused、completed、failed、cancelled）"
+// @Param kind query string false "任务类型（export、import、sync）"
+// @Param page query int true "页码"
+// @Param page_size query int true "每页条数"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.SSETaskDTO]} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 403 {object} handle.Response "没有管理员权限"
+// @Router /api/system/sse/tasks [get]
+func (h *SystemHandler) ListSSETasks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req ListSSETasksReq
+	if err := bind.ShouldBindQuery(c, &req, systemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 SSE 任务列表", nil)
+		return
+	}
+
+	tasks, total, err := h.systemLogic.ListSSETasks(ctx, req.Status, req.Kind, req.Page, req.PageSize)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 SSE 任务列表", nil)
+		return
+	}
+
+	handle.Paginated(c, tasks, req.Page, req.PageSize, total)
+}
+
+// GetLogLevel 获取日志级别
+// @Summary 获取日志级别
+// @Description 获取当前实例的日志级别
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=dto.LogLevelDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 403 {object} handle.Response "没有管理员权限"
+// @Router /api/admin/log-level [get]
+func (h *SystemHandler) GetLogLevel(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.systemLogic.GetLogLevel(ctx)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取日志级别", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// SetLogLevel 修改日志级别
+// @Summary 修改日志级别
+// @Description 运行时修改当前实例的日志级别，不需要重启；修改只保存在内存中，LOG_LEVEL 配置重新加载或重启后以配置为准
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body SetLogLevelReq true "日志级别"
+// @Success 200 {object} handle.Response{data=dto.LogLevelDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未认证"
+// @Failure 403 {object} handle.Response "没有管理员权限"
+// @Router /api/admin/log-level [put]
+func (h *SystemHandler) SetLogLevel(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req SetLogLevelReq
+	if err := bind.ShouldBindJSON(c, &req, systemBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "修改日志级别", nil)
+		return
+	}
+
+	result, err := h.systemLogic.SetLogLevel(ctx, req.Level)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "修改日志级别", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
+
+// ListErrorCodes 获取错误码目录
+// @Summary 获取错误码目录
+// @Description 获取所有已注册的错误码、各语言的消息模板和默认 HTTP 状态码，按错误码升序排列，用于前端生成错误处理
+// @Tags 系统
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} handle.Response{data=[]dto.ErrorCodeDTO} "成功"
+// @Failure 401 {object} handle.Response "未认证"
+// @Router /api/meta/errors [get]
+func (h *SystemHandler) ListErrorCodes(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.systemLogic.ListErrorCodes(ctx)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取错误码目录", nil)
+		return
+	}
+
+	handle.Success(c, result)
+}
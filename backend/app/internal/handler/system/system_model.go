@@ -0,0 +1,31 @@
+package system
+
+import "encoding/json"
+
+type QueueJobURI struct {
+	JobID uint `uri:"job_id" binding:"required" label:"任务ID" example:"1"`
+}
+
+type ListQueueJobsReq struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending running succeeded dead" label:"状态" example:"dead"`
+	Type     string `form:"type" binding:"omitempty,max=64" label:"任务类型" example:"item.reindex"`
+	Page     int    `form:"page" binding:"required,min=1" label:"页码"`
+	PageSize int    `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
+}
+
+type ListSSETasksReq struct {
+	Status   string `form:"status" binding:"omitempty,oneof=queued running paused completed failed cancelled" label:"状态" example:"running"`
+	Kind     string `form:"kind" binding:"omitempty,max=64" label:"任务类型" example:"export"`
+	Page     int    `form:"page" binding:"required,min=1" label:"页码"`
+	PageSize int    `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
+}
+
+type EnqueueQueueJobReq struct {
+	Type string `json:"type" binding:"required,max=64" label:"任务类型" example:"item.reindex"`
+	// Payload 手动添加的任务不接受参数，传入时返回参数错误
+	Payload json.RawMessage `json:"payload,omitempty" label:"任务参数" swaggerignore:"true"`
+}
+
+type SetLogLevelReq struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn error" label:"日志级别" example:"debug"`
+}
@@ -18,7 +18,7 @@ type TagLogic interface {
 	UpdateTag(ctx context.Context, tagID uint, tagName *string, tagValue *string, icon *string, color *string) (*dto.TagDTO, error)
 	DeleteTag(ctx context.Context, tagID uint) error
 	GetTag(ctx context.Context, tagID uint) (*dto.TagDTO, error)
-	GetTagList(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, int, error)
+	GetTagList(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, error)
 }
 
 type TagHandlerParams struct {
@@ -38,17 +38,8 @@ func NewTagHandler(params TagHandlerParams) *TagHandler {
 }
 
 var tagBindConfig = bind.FieldErrorConfig{
-	InvalidParamCode: tagError.TagErrDatabaseError,
-	RequiredCode:     tagError.TagErrDatabaseError,
-	FieldLabels: map[string]string{
-		"tag_id":    "标签ID",
-		"tag_name":  "标签名",
-		"tag_value": "标签值",
-		"icon":      "图标",
-		"color":     "颜色",
-		"page":      "页码",
-		"page_size": "每页条数",
-	},
+	InvalidParamCode: tagError.TagErrInvalidParam,
+	RequiredCode:     tagError.TagErrParamRequired,
 }
 
 // CreateTag 创建标签
@@ -59,6 +50,7 @@ var tagBindConfig = bind.FieldErrorConfig{
 // @Produce json
 // @Security BearerAuth
 // @Param request body CreateTagReq true "创建标签请求"
+// @Param dry_run query bool false "试运行，写操作执行后回滚"
 // @Success 200 {object} handle.Response{data=dto.TagDTO} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 500 {object} handle.Response "服务器内部错误"
@@ -91,6 +83,7 @@ func (h *TagHandler) CreateTag(c *gin.Context) {
 // @Security BearerAuth
 // @Param tag_id path int true "标签ID"
 // @Param request body UpdateTagReq true "更新标签请求"
+// @Param dry_run query bool false "试运行，写操作执行后回滚"
 // @Success 200 {object} handle.Response{data=dto.TagDTO} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 404 {object} handle.Response "标签不存在"
@@ -129,6 +122,7 @@ func (h *TagHandler) UpdateTag(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param tag_id path int true "标签ID"
+// @Param dry_run query bool false "试运行，写操作执行后回滚"
 // @Success 200 {object} handle.Response "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 404 {object} handle.Response "标签不存在"
@@ -193,7 +187,7 @@ func (h *TagHandler) GetTag(c *gin.Context) {
 // @Security BearerAuth
 // @Param page query int false "页码"
 // @Param page_size query int false "每页条数"
-// @Success 200 {object} handle.Response{data=GetTagListResp} "成功"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.TagDTO]} "成功"
 // @Failure 400 {object} handle.Response "请求参数错误"
 // @Failure 500 {object} handle.Response "服务器内部错误"
 // @Router /api/tag/list [get]
@@ -206,18 +200,12 @@ func (h *TagHandler) GetTagList(c *gin.Context) {
 		return
 	}
 
-	tags, total, totalPages, err := h.tagLogic.GetTagList(ctx, req.Page, req.PageSize)
+	tags, total, err := h.tagLogic.GetTagList(ctx, req.Page, req.PageSize)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "获取标签列表", nil)
 		return
 	}
 
 	logs.CtxInfof(ctx, "获取标签列表成功: page=%d, page_size=%d, total=%d", req.Page, req.PageSize, total)
-	handle.Success(c, GetTagListResp{
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		Total:      int(total),
-		TotalPages: totalPages,
-		Tags:       tags,
-	})
+	handle.Paginated(c, tags, req.Page, req.PageSize, total)
 }
@@ -17,7 +17,11 @@ type UserLogic interface {
 	Login(ctx context.Context, username string, password string) (*dto.UserDTO, *dto.TokenDTO, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*dto.TokenDTO, error)
 	GetUserInfo(ctx context.Context) (*dto.UserDTO, error)
-	UpdateUserInfo(ctx context.Context, nickName *string, avatar *string) (*dto.UserDTO, error)
+	UpdateUserInfo(ctx context.Context, nickName *string, avatar *string, locale *string) (*dto.UserDTO, error)
+	UpdateEmail(ctx context.Context, email string) (*dto.UserDTO, error)
+	VerifyEmail(ctx context.Context, token string) error
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token string, password string) error
 }
 
 type UserHandlerParams struct {
@@ -37,13 +41,8 @@ func NewUserHandler(params UserHandlerParams) *UserHandler {
 }
 
 var userBindConfig = bind.FieldErrorConfig{
-	InvalidParamCode: authError.AuthErrTokenInvalid,
-	RequiredCode:     authError.AuthErrTokenRequired,
-	FieldLabels: map[string]string{
-		"username":      "用户名",
-		"password":      "密码",
-		"refresh_token": "刷新令牌",
-	},
+	InvalidParamCode: authError.AuthErrInvalidParam,
+	RequiredCode:     authError.AuthErrParamRequired,
 }
 
 // Login 用户登录
@@ -137,10 +136,13 @@ func (h *UserHandler) GetUserInfo(c *gin.Context) {
 
 	logs.CtxInfof(ctx, "获取用户信息成功: user_id=%d", u.UserID)
 	handle.Success(c, GetUserInfoResp{
-		UserID:   u.UserID,
-		Username: u.Username,
-		NickName: u.NickName,
-		Avatar:   u.Avatar,
+		UserID:        u.UserID,
+		Username:      u.Username,
+		NickName:      u.NickName,
+		Avatar:        u.Avatar,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Locale:        u.Locale,
 	})
 }
 
@@ -165,7 +167,7 @@ func (h *UserHandler) UpateUserInfo(c *gin.Context) {
 		return
 	}
 
-	result, err := h.userLogic.UpdateUserInfo(ctx, req.NickName, req.Avatar)
+	result, err := h.userLogic.UpdateUserInfo(ctx, req.NickName, req.Avatar, req.Locale)
 	if err != nil {
 		handle.HandleErrorWithContext(c, err, "更新用户信息", nil)
 		return
@@ -176,5 +178,131 @@ func (h *UserHandler) UpateUserInfo(c *gin.Context) {
 		UserID:   result.UserID,
 		NickName: result.NickName,
 		Avatar:   result.Avatar,
+		Locale:   result.Locale,
 	})
 }
+
+// UpdateEmail 修改邮箱
+// @Summary 修改邮箱
+// @Description 修改当前登录用户的邮箱并发送验证邮件，邮箱未变化且未验证时重新发送验证邮件
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body UpdateEmailReq true "修改邮箱请求"
+// @Success 200 {object} handle.Response{data=GetUserInfoResp} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 401 {object} handle.Response "未授权"
+// @Failure 409 {object} handle.Response "邮箱已被使用"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/user/email [put]
+func (h *UserHandler) UpdateEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req UpdateEmailReq
+	if err := bind.ShouldBindJSON(c, &req, userBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "修改邮箱", nil)
+		return
+	}
+
+	u, err := h.userLogic.UpdateEmail(ctx, req.Email)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "修改邮箱", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "修改邮箱成功: user_id=%d", u.UserID)
+	handle.Success(c, GetUserInfoResp{
+		UserID:        u.UserID,
+		Username:      u.Username,
+		NickName:      u.NickName,
+		Avatar:        u.Avatar,
+		Email:         u.Email,
+		EmailVerified: u.EmailVerified,
+		Locale:        u.Locale,
+	})
+}
+
+// VerifyEmail 验证邮箱
+// @Summary 验证邮箱
+// @Description 使用验证邮件中的令牌验证邮箱
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body VerifyEmailReq true "验证邮箱请求"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 400 {object} handle.Response "链接无效或已过期"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/user/email/verify [post]
+func (h *UserHandler) VerifyEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req VerifyEmailReq
+	if err := bind.ShouldBindJSON(c, &req, userBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "验证邮箱", nil)
+		return
+	}
+
+	if err := h.userLogic.VerifyEmail(ctx, req.Token); err != nil {
+		handle.HandleErrorWithContext(c, err, "验证邮箱", nil)
+		return
+	}
+
+	handle.Success(c, nil)
+}
+
+// ForgotPassword 忘记密码
+// @Summary 忘记密码
+// @Description 向已验证的邮箱发送重置密码邮件，邮箱不存在时同样返回成功
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordReq true "忘记密码请求"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/user/password/forgot [post]
+func (h *UserHandler) ForgotPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req ForgotPasswordReq
+	if err := bind.ShouldBindJSON(c, &req, userBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "忘记密码", nil)
+		return
+	}
+
+	if err := h.userLogic.ForgotPassword(ctx, req.Email); err != nil {
+		handle.HandleErrorWithContext(c, err, "忘记密码", nil)
+		return
+	}
+
+	handle.Success(c, nil)
+}
+
+// ResetPassword 重置密码
+// @Summary 重置密码
+// @Description 使用重置密码邮件中的令牌设置新密码，每个链接只能使用一次
+// @Tags 用户认证
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordReq true "重置密码请求"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 400 {object} handle.Response "请求参数错误或链接无效"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/user/password/reset [post]
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req ResetPasswordReq
+	if err := bind.ShouldBindJSON(c, &req, userBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "重置密码", nil)
+		return
+	}
+
+	if err := h.userLogic.ResetPassword(ctx, req.Token, req.Password); err != nil {
+		handle.HandleErrorWithContext(c, err, "重置密码", nil)
+		return
+	}
+
+	handle.Success(c, nil)
+}
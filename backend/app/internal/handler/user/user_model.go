@@ -31,12 +31,18 @@ type GetUserInfoResp struct {
 	Username string `json:"username" example:"alice123"`
 	NickName string `json:"nick_name" example:"爱丽丝"`
 	Avatar   string `json:"avatar" example:"https://example.com/avatar.jpg"`
+
+	Email         string `json:"email" example:"alice@example.com"`
+	EmailVerified bool   `json:"email_verified" example:"true"`
+
+	Locale string `json:"locale" example:"en"` // 语言偏好，为空时按 Accept-Language 选择
 }
 
 // UpateUserInfoReq 更新用户信息请求
 type UpateUserInfoReq struct {
 	NickName *string `json:"nick_name" binding:"omitempty" label:"昵称" example:"爱丽丝"`
 	Avatar   *string `json:"avatar" binding:"omitempty" label:"头像" example:"https://example.com/avatar.jpg"`
+	Locale   *string `json:"locale" binding:"omitempty,max=16" label:"语言偏好" example:"en"` // 空字符串表示清除语言偏好
 }
 
 // UpateUserInfoResp 更新用户信息响应
@@ -44,4 +50,26 @@ type UpateUserInfoResp struct {
 	UserID   uint   `json:"user_id" example:"1"`
 	NickName string `json:"nick_name" example:"爱丽丝"`
 	Avatar   string `json:"avatar" example:"https://example.com/avatar.jpg"`
+	Locale   string `json:"locale" example:"en"`
+}
+
+// UpdateEmailReq 修改邮箱请求
+type UpdateEmailReq struct {
+	Email string `json:"email" binding:"required,email,max=128" label:"邮箱" example:"alice@example.com"`
+}
+
+// VerifyEmailReq 验证邮箱请求
+type VerifyEmailReq struct {
+	Token string `json:"token" binding:"required" label:"验证令牌" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+}
+
+// ForgotPasswordReq 忘记密码请求
+type ForgotPasswordReq struct {
+	Email string `json:"email" binding:"required,email,max=128" label:"邮箱" example:"alice@example.com"`
+}
+
+// ResetPasswordReq 重置密码请求
+type ResetPasswordReq struct {
+	Token    string `json:"token" binding:"required" label:"重置令牌" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
+	Password string `json:"password" binding:"required,min=8,max=16" label:"密码" example:"password123"`
 }
@@ -0,0 +1,250 @@
+package webhook
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	webhookError "backend/app/types/errorn"
+	"backend/utils/bind"
+	"backend/utils/handle"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/fx"
+)
+
+type WebhookLogic interface {
+	CreateWebhook(ctx context.Context, rawURL string, secret string, events []string, description string) (*dto.WebhookDTO, error)
+	UpdateWebhook(ctx context.Context, webhookID uint, rawURL *string, events []string, description *string, enabled *bool, rotateSecret bool) (*dto.WebhookDTO, error)
+	DeleteWebhook(ctx context.Context, webhookID uint) error
+	GetWebhook(ctx context.Context, webhookID uint) (*dto.WebhookDTO, error)
+	GetWebhookList(ctx context.Context, page, pageSize int) ([]*dto.WebhookDTO, int64, error)
+	GetDeliveryList(ctx context.Context, webhookID uint, status string, page, pageSize int) ([]*dto.WebhookDeliveryDTO, int64, error)
+}
+
+type WebhookHandlerParams struct {
+	fx.In
+
+	WebhookLogic WebhookLogic
+}
+
+type WebhookHandler struct {
+	webhookLogic WebhookLogic
+}
+
+func NewWebhookHandler(params WebhookHandlerParams) *WebhookHandler {
+	return &WebhookHandler{
+		webhookLogic: params.WebhookLogic,
+	}
+}
+
+var webhookBindConfig = bind.FieldErrorConfig{
+	InvalidParamCode: webhookError.WebhookErrInvalidParam,
+	RequiredCode:     webhookError.WebhookErrParamRequired,
+}
+
+// CreateWebhook 创建 webhook
+// @Summary 创建 webhook
+// @Description 注册事件回调地址，secret 为空时自动生成；完整的签名密钥只在创建时返回
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateWebhookReq true "创建 webhook 请求"
+// @Success 200 {object} handle.Response{data=dto.WebhookDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/webhook [post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req CreateWebhookReq
+	if err := bind.ShouldBindJSON(c, &req, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "创建 webhook", nil)
+		return
+	}
+
+	result, err := h.webhookLogic.CreateWebhook(ctx, req.URL, req.Secret, req.Events, req.Description)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "创建 webhook", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "创建 webhook 成功: webhook_id=%d", result.WebhookID)
+	handle.Success(c, result)
+}
+
+// UpdateWebhook 更新 webhook
+// @Summary 更新 webhook
+// @Description 更新回调地址、订阅事件、说明或启用状态，rotate_secret 为 true 时重新生成签名密钥并返回
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path int true "WebhookID"
+// @Param request body UpdateWebhookReq true "更新 webhook 请求"
+// @Success 200 {object} handle.Response{data=dto.WebhookDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 404 {object} handle.Response "webhook 不存在"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/webhook/{webhook_id} [put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri WebhookURI
+	if err := bind.ShouldBindURI(c, &uri, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "更新 webhook", nil)
+		return
+	}
+
+	var req UpdateWebhookReq
+	if err := bind.ShouldBindJSON(c, &req, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "更新 webhook", nil)
+		return
+	}
+
+	result, err := h.webhookLogic.UpdateWebhook(ctx, uri.WebhookID, req.URL, req.Events, req.Description, req.Enabled, req.RotateSecret)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "更新 webhook", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "更新 webhook 成功: webhook_id=%d", result.WebhookID)
+	handle.Success(c, result)
+}
+
+// DeleteWebhook 删除 webhook
+// @Summary 删除 webhook
+// @Description 删除 webhook 及其投递记录
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path int true "WebhookID"
+// @Success 200 {object} handle.Response "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 404 {object} handle.Response "webhook 不存在"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/webhook/{webhook_id} [delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri WebhookURI
+	if err := bind.ShouldBindURI(c, &uri, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "删除 webhook", nil)
+		return
+	}
+
+	if err := h.webhookLogic.DeleteWebhook(ctx, uri.WebhookID); err != nil {
+		handle.HandleErrorWithContext(c, err, "删除 webhook", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "删除 webhook 成功: webhook_id=%d", uri.WebhookID)
+	handle.Success(c, nil)
+}
+
+// GetWebhook 获取 webhook
+// @Summary 获取 webhook
+// @Description 获取 webhook 详情，签名密钥只返回掩码
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path int true "WebhookID"
+// @Success 200 {object} handle.Response{data=dto.WebhookDTO} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 404 {object} handle.Response "webhook 不存在"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/webhook/{webhook_id} [get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri WebhookURI
+	if err := bind.ShouldBindURI(c, &uri, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook", nil)
+		return
+	}
+
+	result, err := h.webhookLogic.GetWebhook(ctx, uri.WebhookID)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "获取 webhook 成功: webhook_id=%d", result.WebhookID)
+	handle.Success(c, result)
+}
+
+// GetWebhookList 获取 webhook 列表
+// @Summary 获取 webhook 列表
+// @Description 获取 webhook 列表，支持分页
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.WebhookDTO]} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/webhook/list [get]
+func (h *WebhookHandler) GetWebhookList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req GetWebhookListReq
+	if err := bind.ShouldBindQuery(c, &req, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook 列表", nil)
+		return
+	}
+
+	webhooks, total, err := h.webhookLogic.GetWebhookList(ctx, req.Page, req.PageSize)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook 列表", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "获取 webhook 列表成功: page=%d, page_size=%d, total=%d", req.Page, req.PageSize, total)
+	handle.Paginated(c, webhooks, req.Page, req.PageSize, total)
+}
+
+// GetDeliveryList 获取 webhook 投递记录
+// @Summary 获取 webhook 投递记录
+// @Description 按时间倒序获取 webhook 的投递记录，支持按状态过滤和分页
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param webhook_id path int true "WebhookID"
+// @Param status query string false "投递状态" Enums(pending, retrying, succeeded, failed)
+// @Param page query int false "页码"
+// @Param page_size query int false "每页条数"
+// @Success 200 {object} handle.Response{data=handle.Page[dto.WebhookDeliveryDTO]} "成功"
+// @Failure 400 {object} handle.Response "请求参数错误"
+// @Failure 404 {object} handle.Response "webhook 不存在"
+// @Failure 500 {object} handle.Response "服务器内部错误"
+// @Router /api/webhook/{webhook_id}/deliveries [get]
+func (h *WebhookHandler) GetDeliveryList(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var uri WebhookURI
+	if err := bind.ShouldBindURI(c, &uri, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook 投递记录", nil)
+		return
+	}
+
+	var req GetDeliveryListReq
+	if err := bind.ShouldBindQuery(c, &req, webhookBindConfig); err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook 投递记录", nil)
+		return
+	}
+
+	deliveries, total, err := h.webhookLogic.GetDeliveryList(ctx, uri.WebhookID, req.Status, req.Page, req.PageSize)
+	if err != nil {
+		handle.HandleErrorWithContext(c, err, "获取 webhook 投递记录", nil)
+		return
+	}
+
+	logs.CtxInfof(ctx, "获取 webhook 投递记录成功: webhook_id=%d, page=%d, page_size=%d, total=%d", uri.WebhookID, req.Page, req.PageSize, total)
+	handle.Paginated(c, deliveries, req.Page, req.PageSize, total)
+}
@@ -0,0 +1,31 @@
+package webhook
+
+type WebhookURI struct {
+	WebhookID uint `uri:"webhook_id" binding:"required" label:"WebhookID" example:"1"`
+}
+
+type CreateWebhookReq struct {
+	URL         string   `json:"url" binding:"required,max=512" label:"回调地址" example:"https://example.com/hooks/peano"`
+	Secret      string   `json:"secret" binding:"omitempty,min=16,max=128" label:"签名密钥"`
+	Events      []string `json:"events" binding:"required,min=1" label:"订阅事件" example:"item.created,item.completed"`
+	Description string   `json:"description" binding:"omitempty,max=255" label:"说明"`
+}
+
+type UpdateWebhookReq struct {
+	URL          *string  `json:"url" binding:"omitempty,max=512" label:"回调地址"`
+	Events       []string `json:"events" binding:"omitempty,min=1" label:"订阅事件"`
+	Description  *string  `json:"description" binding:"omitempty,max=255" label:"说明"`
+	Enabled      *bool    `json:"enabled" label:"是否启用"`
+	RotateSecret bool     `json:"rotate_secret" label:"重新生成签名密钥"`
+}
+
+type GetWebhookListReq struct {
+	Page     int `form:"page" binding:"required,min=1" label:"页码"`
+	PageSize int `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
+}
+
+type GetDeliveryListReq struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending retrying succeeded failed" label:"投递状态"`
+	Page     int    `form:"page" binding:"required,min=1" label:"页码"`
+	PageSize int    `form:"page_size" binding:"required,min=1,max=100" label:"每页条数"`
+}
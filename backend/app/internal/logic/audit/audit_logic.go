@@ -0,0 +1,225 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	auditModel "backend/app/model/audit"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	auditError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+
+	"go.uber.org/fx"
+)
+
+// exportBatchSize 导出 CSV 时每批查询的审计日志数
+const exportBatchSize = 500
+
+// csvHeader 导出 CSV 的表头
+var csvHeader = []string{"audit_id", "created_at", "user_id", "entity", "entity_id", "action", "ip", "detail"}
+
+type AuditRepo interface {
+	CreateAuditLog(ctx context.Context, log *auditModel.AuditLog) error
+	CountAuditLogs(ctx context.Context, filter auditModel.Filter) (int64, error)
+	GetAuditLogList(ctx context.Context, filter auditModel.Filter, page, pageSize int) ([]*auditModel.AuditLog, int64, error)
+	GetAuditLogsBefore(ctx context.Context, filter auditModel.Filter, beforeID uint, limit int) ([]*auditModel.AuditLog, error)
+}
+
+type AuditLogicParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	AuditRepo AuditRepo
+}
+
+type AuditLogic struct {
+	auditRepo     AuditRepo
+	exportMaxRows int
+	// file 配置 AUDIT_LOG_FILE 时同时写入的审计日志文件，每行一条 JSON
+	file   io.Writer
+	fileMu sync.Mutex
+}
+
+func NewAuditLogic(params AuditLogicParams) (*AuditLogic, error) {
+	exportMaxRows, err := envx.GetIntWithDefaultAndMin(consts.AuditExportMaxRows, 100000, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &AuditLogic{
+		auditRepo:     params.AuditRepo,
+		exportMaxRows: exportMaxRows,
+	}
+	if path := envx.GetStringOptional(consts.AuditLogFile); path != "" {
+		file, err := logs.OpenRotatingFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("打开审计日志文件失败: %w", err)
+		}
+		l.file = file
+		params.Lifecycle.Append(fx.Hook{
+			OnStop: func(ctx context.Context) error {
+				l.fileMu.Lock()
+				defer l.fileMu.Unlock()
+				return file.Close()
+			},
+		})
+	}
+	return l, nil
+}
+
+// Audit 记录审计日志，操作人和客户端IP从 ctx 中读取，失败只记录日志，不影响调用方
+// detail 为操作详情，没有时传 nil；试运行时不记录
+func (l *AuditLogic) Audit(ctx context.Context, entity string, action string, entityID uint, detail any) {
+	if meta.IsDryRun(ctx) {
+		return
+	}
+	log := &auditModel.AuditLog{
+		Entity:   entity,
+		EntityID: entityID,
+		Action:   action,
+	}
+	log.UserID, _ = ctx.Value(meta.ContextKeyUserID).(uint)
+	log.IP, _ = ctx.Value(meta.ContextKeyClientIP).(string)
+	if detail != nil {
+		body, err := json.Marshal(detail)
+		if err != nil {
+			logs.CtxErrorf(ctx, "序列化审计详情失败: entity=%s, entity_id=%d, action=%s, error=%s", entity, entityID, action, err.Error())
+			return
+		}
+		log.Detail = string(body)
+	}
+
+	if err := l.auditRepo.CreateAuditLog(ctx, log); err != nil {
+		logs.CtxErrorf(ctx, "记录审计日志失败: entity=%s, entity_id=%d, action=%s, error=%s", entity, entityID, action, err.Error())
+	}
+	// 写入数据库失败时仍写入文件，audit_id 为 0
+	l.writeFile(ctx, log)
+}
+
+// writeFile 配置了 AUDIT_LOG_FILE 时把审计日志写入文件，与应用日志分开存放，便于单独采集和长期归档
+func (l *AuditLogic) writeFile(ctx context.Context, log *auditModel.AuditLog) {
+	if l.file == nil {
+		return
+	}
+	if log.CreatedAt.IsZero() {
+		log.CreatedAt = time.Now()
+	}
+	line, err := json.Marshal(toAuditLogDTO(log))
+	if err != nil {
+		logs.CtxErrorf(ctx, "序列化审计日志失败: entity=%s, action=%s, error=%s", log.Entity, log.Action, err.Error())
+		return
+	}
+
+	l.fileMu.Lock()
+	defer l.fileMu.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		logs.CtxErrorf(ctx, "写入审计日志文件失败: entity=%s, action=%s, error=%s", log.Entity, log.Action, err.Error())
+	}
+}
+
+// GetAuditLogList 分页查询审计日志，按时间倒序
+func (l *AuditLogic) GetAuditLogList(ctx context.Context, filter auditModel.Filter, page, pageSize int) ([]*dto.AuditLogDTO, int64, error) {
+	auditLogs, total, err := l.auditRepo.GetAuditLogList(ctx, filter, page, pageSize)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询审计日志失败: error=%s", err.Error())
+		return nil, 0, errorx.Wrap(err, auditError.AuditErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	result := make([]*dto.AuditLogDTO, 0, len(auditLogs))
+	for _, log := range auditLogs {
+		result = append(result, toAuditLogDTO(log))
+	}
+	return result, total, nil
+}
+
+// CheckExport 检查符合条件的审计日志数是否超过导出上限，在写入响应前调用
+func (l *AuditLogic) CheckExport(ctx context.Context, filter auditModel.Filter) error {
+	total, err := l.auditRepo.CountAuditLogs(ctx, filter)
+	if err != nil {
+		logs.CtxErrorf(ctx, "统计审计日志失败: error=%s", err.Error())
+		return errorx.Wrap(err, auditError.AuditErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	if total > int64(l.exportMaxRows) {
+		return errorx.New(auditError.AuditErrTooManyRows, errorx.Kf("count", "%d", total), errorx.Kf("max", "%d", l.exportMaxRows))
+	}
+	return nil
+}
+
+// ExportAuditLogs 按时间倒序将符合条件的审计日志写为 CSV，开头写入 UTF-8 BOM，Excel 打开时中文不乱码
+// 最多写入 AUDIT_EXPORT_MAX_ROWS 行，调用前先用 CheckExport 检查
+func (l *AuditLogic) ExportAuditLogs(ctx context.Context, filter auditModel.Filter, w io.Writer) error {
+	if _, err := io.WriteString(w, "\xEF\xBB\xBF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	var beforeID uint
+	written := 0
+	for written < l.exportMaxRows {
+		auditLogs, err := l.auditRepo.GetAuditLogsBefore(ctx, filter, beforeID, min(exportBatchSize, l.exportMaxRows-written))
+		if err != nil {
+			logs.CtxErrorf(ctx, "查询审计日志失败: before_id=%d, error=%s", beforeID, err.Error())
+			return errorx.Wrap(err, auditError.AuditErrDatabaseError, errorx.K("reason", err.Error()))
+		}
+		for _, log := range auditLogs {
+			if err := cw.Write(toCSVRow(log)); err != nil {
+				return err
+			}
+			beforeID = log.ID
+		}
+		written += len(auditLogs)
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if len(auditLogs) < exportBatchSize {
+			break
+		}
+	}
+
+	logs.CtxInfof(ctx, "导出审计日志完成: rows=%d", written)
+	return nil
+}
+
+func toAuditLogDTO(log *auditModel.AuditLog) *dto.AuditLogDTO {
+	result := &dto.AuditLogDTO{
+		AuditID:   log.ID,
+		UserID:    log.UserID,
+		Entity:    log.Entity,
+		EntityID:  log.EntityID,
+		Action:    log.Action,
+		IP:        log.IP,
+		CreatedAt: log.CreatedAt,
+	}
+	if log.Detail != "" {
+		result.Detail = json.RawMessage(log.Detail)
+	}
+	return result
+}
+
+func toCSVRow(log *auditModel.AuditLog) []string {
+	return []string{
+		strconv.FormatUint(uint64(log.ID), 10),
+		log.CreatedAt.Format(time.RFC3339),
+		strconv.FormatUint(uint64(log.UserID), 10),
+		log.Entity,
+		strconv.FormatUint(uint64(log.EntityID), 10),
+		log.Action,
+		log.IP,
+		log.Detail,
+	}
+}
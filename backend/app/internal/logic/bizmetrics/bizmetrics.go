@@ -0,0 +1,93 @@
+// Package bizmetrics 业务指标，由 logic 层在领域事件发生时调用，通过 /metrics 输出
+// 计数器只统计本进程启动以来的次数，按天统计请在 Prometheus 中使用 increase(...[1d])
+package bizmetrics
+
+import (
+	"backend/utils/metrics"
+)
+
+// 指标名称
+const (
+	ItemsCreatedMetricName      = "items_created_total"
+	ItemsCompletedMetricName    = "items_completed_total"
+	LoginsMetricName            = "logins_total"
+	FilesUploadedMetricName     = "files_uploaded_total"
+	FileUploadBytesMetricName   = "file_upload_bytes_total"
+	SSETasksMetricName          = "sse_tasks_started_total"
+	SSETasksActiveMetricName    = "sse_tasks_active"
+	WebhookDeliveriesMetricName = "webhook_deliveries_total"
+)
+
+// 登录结果
+const (
+	LoginSucceeded = "succeeded"
+	LoginFailed    = "failed"
+)
+
+// SSE 任务类型
+const (
+	SSEKindExport = "export"
+	SSEKindImport = "import"
+	SSEKindSync   = "sync"
+)
+
+var (
+	itemsCreated      = metrics.NewCounterVec(ItemsCreatedMetricName, "创建的项目数", "status")
+	itemsCompleted    = metrics.NewCounterVec(ItemsCompletedMetricName, "完成的项目数（创建时即为已完成或更新为已完成）")
+	logins            = metrics.NewCounterVec(LoginsMetricName, "登录次数", "result")
+	filesUploaded     = metrics.NewCounterVec(FilesUploadedMetricName, "上传的文件数，内容重复的文件按 deduplicated 统计", "deduplicated")
+	fileUploadBytes   = metrics.NewCounterVec(FileUploadBytesMetricName, "新上传文件的总字节数（不含内容重复的文件）")
+	sseTasks          = metrics.NewCounterVec(SSETasksMetricName, "启动的 SSE 任务数", "kind")
+	sseTasksActive    = metrics.NewGaugeVec(SSETasksActiveMetricName, "正在运行的 SSE 任务数", "kind")
+	webhookDeliveries = metrics.NewCounterVec(WebhookDeliveriesMetricName, "webhook 投递次数（每次请求计一次）", "event", "status")
+)
+
+// ItemCreated 记录创建项目，status 为项目状态
+func ItemCreated(status string) {
+	itemsCreated.Inc(status)
+}
+
+// ItemCompleted 记录项目完成
+func ItemCompleted() {
+	itemsCompleted.Inc()
+}
+
+// Login 记录登录，result 为 LoginSucceeded 或 LoginFailed
+func Login(result string) {
+	logins.Inc(result)
+}
+
+// FileUploaded 记录上传文件，内容重复的文件不重复保存，不计入字节数
+func FileUploaded(size int64, deduplicated bool) {
+	if deduplicated {
+		filesUploaded.Inc("true")
+		return
+	}
+	filesUploaded.Inc("false")
+	fileUploadBytes.Add(size)
+}
+
+// SSETaskStarted 记录启动 SSE 任务，任务结束时调用返回的函数
+//
+//	defer bizmetrics.SSETaskStarted(bizmetrics.SSEKindExport)()
+func SSETaskStarted(kind string) func() {
+	sseTasks.Inc(kind)
+	sseTasksActive.Inc(kind)
+	return func() {
+		sseTasksActive.Dec(kind)
+	}
+}
+
+// WebhookDelivered 记录一次 webhook 投递请求，status 为投递后的状态（succeeded、retrying、failed）
+func WebhookDelivered(event string, status string) {
+	webhookDeliveries.Inc(event, status)
+}
+
+// ActiveSSETasks 返回正在运行的 SSE 任务数（key: 任务类型）
+func ActiveSSETasks() map[string]int64 {
+	result := make(map[string]int64)
+	for _, sample := range sseTasksActive.Collect() {
+		result[sample.Labels["kind"]] = sample.Value
+	}
+	return result
+}
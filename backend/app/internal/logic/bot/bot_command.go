@@ -0,0 +1,249 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	userModel "backend/app/model/user"
+	"backend/app/types/dto"
+	"backend/app/types/meta"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/telegram"
+	"backend/utils/timex"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// todayMaxItems /today 最多列出的项目数
+	todayMaxItems = 30
+	// 项目内容的字符数范围，与创建项目接口一致
+	contentMinRunes = 3
+	contentMaxRunes = 1000
+)
+
+// 回复消息
+const (
+	replyHelp = "直接发送文字即可记录为新项目\n" +
+		"/today 查看今天创建的项目\n" +
+		"/done <项目ID> 标记项目为已完成\n" +
+		"/unlink 解除绑定\n" +
+		"/help 查看帮助"
+	replyNotLinked    = "尚未绑定账号，请在应用中生成绑定码，然后发送 /link <绑定码>"
+	replyLinkInvalid  = "绑定码无效或已过期，请在应用中重新生成"
+	replyPrivateOnly  = "请在与机器人的私聊中使用"
+	replyInternal     = "处理失败，请稍后重试"
+	replyUnknown      = "未知的命令，发送 /help 查看帮助"
+	replyContentLimit = "内容需要 %d-%d 个字符"
+)
+
+// ItemLogic 项目业务逻辑，机器人的项目读写与 HTTP 接口使用相同的逻辑（记录变更、发布事件、更新索引）
+type ItemLogic interface {
+	CreateItem(ctx context.Context, content string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint) (*dto.ItemDTO, error)
+	UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint, version *uint) (*dto.ItemDTO, error)
+	GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
+}
+
+// HandleUpdate 处理一条消息并回复，长轮询和 webhook 共用
+func (l *BotLogic) HandleUpdate(ctx context.Context, update *telegram.Update) {
+	msg := update.Message
+	if msg == nil || strings.TrimSpace(msg.Text) == "" {
+		return
+	}
+	chatID := msg.Chat.ID
+
+	reply := l.handleMessage(ctx, msg)
+	if reply == "" {
+		return
+	}
+	if err := l.client.SendMessage(ctx, chatID, reply); err != nil {
+		logs.CtxErrorf(ctx, "回复 Telegram 消息失败: chat_id=%d, error=%s", chatID, err.Error())
+	}
+}
+
+// handleMessage 处理消息，返回回复内容
+func (l *BotLogic) handleMessage(ctx context.Context, msg *telegram.Message) string {
+	if msg.Chat.Type != "private" {
+		return replyPrivateOnly
+	}
+
+	// 按会话ID查询所有租户的用户，webhook 请求的 ctx 中可能带有默认租户
+	ctx = context.WithValue(ctx, meta.ContextKeyTenantID, nil)
+
+	command, args := parseCommand(msg.Text)
+	switch command {
+	case "start", "link":
+		if args != "" {
+			return l.link(ctx, msg.Chat.ID, args)
+		}
+	}
+
+	user, err := l.userRepo.GetUserByTelegramChatID(ctx, msg.Chat.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return replyNotLinked
+		}
+		logs.CtxErrorf(ctx, "查询 Telegram 绑定用户失败: chat_id=%d, error=%s", msg.Chat.ID, err.Error())
+		return replyInternal
+	}
+	ctx = userContext(ctx, user)
+
+	switch command {
+	case "":
+		return l.capture(ctx, msg.Text)
+	case "start", "help":
+		return replyHelp
+	case "today":
+		return l.today(ctx)
+	case "done":
+		return l.done(ctx, args)
+	case "unlink":
+		return l.unlink(ctx, user)
+	default:
+		return replyUnknown
+	}
+}
+
+// parseCommand 解析命令，不是命令时 command 为空
+// 群组中的命令可能带有机器人用户名（/today@peano_bot）
+func parseCommand(text string) (command, args string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") {
+		return "", text
+	}
+	command, args, _ = strings.Cut(text[1:], " ")
+	command, _, _ = strings.Cut(command, "@")
+	return strings.ToLower(command), strings.TrimSpace(args)
+}
+
+// userContext 以绑定的用户身份访问数据，与 HTTP 请求一样按用户所在租户隔离
+func userContext(ctx context.Context, user *userModel.User) context.Context {
+	if user.TenantID != "" {
+		ctx = context.WithValue(ctx, meta.ContextKeyTenantID, user.TenantID)
+	}
+	return context.WithValue(ctx, meta.ContextKeyUserID, user.ID)
+}
+
+// link 使用绑定码绑定当前会话，绑定码只能使用一次，会话之前绑定的用户自动解除绑定
+func (l *BotLogic) link(ctx context.Context, chatID int64, code string) string {
+	user, err := l.userRepo.GetUserByTelegramLinkCode(ctx, hashLinkCode(strings.ToUpper(code)))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.CtxWarnf(ctx, "Telegram 绑定码无效: chat_id=%d", chatID)
+			return replyLinkInvalid
+		}
+		logs.CtxErrorf(ctx, "查询 Telegram 绑定码失败: chat_id=%d, error=%s", chatID, err.Error())
+		return replyInternal
+	}
+	if user.TelegramLinkExpiresAt == nil || time.Now().After(*user.TelegramLinkExpiresAt) {
+		logs.CtxWarnf(ctx, "Telegram 绑定码已过期: chat_id=%d, user_id=%d", chatID, user.ID)
+		return replyLinkInvalid
+	}
+
+	if err := l.userRepo.ClearTelegramChatID(ctx, chatID); err != nil {
+		logs.CtxErrorf(ctx, "解除 Telegram 会话之前的绑定失败: chat_id=%d, error=%s", chatID, err.Error())
+		return replyInternal
+	}
+	if err := l.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{
+		"telegram_chat_id":         chatID,
+		"telegram_link_code":       "",
+		"telegram_link_expires_at": nil,
+	}); err != nil {
+		logs.CtxErrorf(ctx, "保存 Telegram 绑定失败: chat_id=%d, user_id=%d, error=%s", chatID, user.ID, err.Error())
+		return replyInternal
+	}
+
+	logs.CtxInfof(ctx, "Telegram 绑定成功: chat_id=%d, user_id=%d", chatID, user.ID)
+	return fmt.Sprintf("绑定成功，当前账号: %s\n\n%s", user.Username, replyHelp)
+}
+
+// unlink 解除当前会话的绑定
+func (l *BotLogic) unlink(ctx context.Context, user *userModel.User) string {
+	if err := l.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{"telegram_chat_id": 0}); err != nil {
+		logs.CtxErrorf(ctx, "解除 Telegram 绑定失败: user_id=%d, error=%s", user.ID, err.Error())
+		return replyInternal
+	}
+	logs.CtxInfof(ctx, "已解除 Telegram 绑定: user_id=%d", user.ID)
+	return "已解除绑定"
+}
+
+// capture 将消息内容记录为新项目
+func (l *BotLogic) capture(ctx context.Context, text string) string {
+	content := strings.TrimSpace(text)
+	if n := utf8.RuneCountInString(content); n < contentMinRunes || n > contentMaxRunes {
+		return fmt.Sprintf(replyContentLimit, contentMinRunes, contentMaxRunes)
+	}
+	item, err := l.itemLogic.CreateItem(ctx, content, nil, nil, nil)
+	if err != nil {
+		return errorReply(err)
+	}
+	return fmt.Sprintf("已记录 #%d", item.ItemID)
+}
+
+// today 列出今天创建的项目
+func (l *BotLogic) today(ctx context.Context) string {
+	start, end, _ := timex.RelativeRange("today", time.Now().In(l.location))
+	// 创建时间上限包含在内，取今天的最后时刻
+	end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	items, total, err := l.itemLogic.GetItemList(ctx, &start, &end, nil, 1, todayMaxItems)
+	if err != nil {
+		return errorReply(err)
+	}
+	if total == 0 {
+		return "今天还没有项目"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "今天的项目（共 %d 个）:", total)
+	for _, item := range items {
+		fmt.Fprintf(&b, "\n#%d %s%s", item.ItemID, item.Content, statusSuffix(item.Status))
+	}
+	if total > int64(len(items)) {
+		fmt.Fprintf(&b, "\n……只显示最新的 %d 个", len(items))
+	}
+	return b.String()
+}
+
+// statusSuffix 项目状态后缀，普通状态的项目不显示
+func statusSuffix(status string) string {
+	switch meta.ItemStatus(status) {
+	case meta.ItemStatusDone:
+		return "（已完成）"
+	case meta.ItemStatusMarked:
+		return "（已标记）"
+	default:
+		return ""
+	}
+}
+
+// done 将项目标记为已完成
+func (l *BotLogic) done(ctx context.Context, args string) string {
+	itemID, err := strconv.ParseUint(strings.TrimPrefix(args, "#"), 10, 64)
+	if err != nil || itemID == 0 {
+		return "用法: /done <项目ID>"
+	}
+	status := meta.ItemStatusDone
+	item, err := l.itemLogic.UpdateItem(ctx, uint(itemID), nil, &status, nil, nil, nil)
+	if err != nil {
+		return errorReply(err)
+	}
+	return fmt.Sprintf("已完成 #%d %s", item.ItemID, item.Content)
+}
+
+// errorReply 将业务错误转换为回复内容，服务端错误不回复具体原因
+func errorReply(err error) string {
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		if status, ok := errorx.HTTPStatus(statusErr.Code()); !ok || status < http.StatusInternalServerError {
+			return errorx.LocalizedMsg(err, errorx.DefaultLocale)
+		}
+	}
+	return replyInternal
+}
@@ -0,0 +1,100 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/safego"
+	"backend/utils/telegram"
+	"backend/utils/trace"
+
+	"go.uber.org/fx"
+)
+
+// pollRetryInterval 获取消息失败后的重试间隔
+const pollRetryInterval = 5 * time.Second
+
+type BotJobParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	BotLogic  *BotLogic
+}
+
+// RegisterBot 启动 Telegram 机器人，未启用时不做任何处理
+// polling 模式启动时删除已设置的 webhook 并开始长轮询；webhook 模式配置了 TELEGRAM_WEBHOOK_URL 时启动时设置 webhook
+func RegisterBot(params BotJobParams) {
+	l := params.BotLogic
+	if !l.enabled {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			safego.Go(ctx, func() {
+				defer close(done)
+				if l.mode == ModePolling {
+					l.poll(ctx)
+					return
+				}
+				if l.webhookURL != "" {
+					if err := l.client.SetWebhook(ctx, l.webhookURL, l.webhookSecret); err != nil {
+						logs.Error("设置 Telegram webhook 失败", "url", l.webhookURL, "error", err.Error())
+						return
+					}
+					logs.Info("已设置 Telegram webhook", "url", l.webhookURL)
+				}
+			})
+			logs.Info("Telegram 机器人已启用", "mode", l.mode)
+			return nil
+		},
+		OnStop: func(stopCtx context.Context) error {
+			cancel()
+			select {
+			case <-done:
+			case <-stopCtx.Done():
+			}
+			return nil
+		},
+	})
+}
+
+// poll 长轮询获取消息并逐条处理，直到 ctx 取消
+func (l *BotLogic) poll(ctx context.Context) {
+	if err := l.client.DeleteWebhook(ctx); err != nil {
+		logs.Warn("删除 Telegram webhook 失败", "error", err.Error())
+	}
+
+	var offset int64
+	for ctx.Err() == nil {
+		updates, err := l.client.GetUpdates(ctx, offset, pollTimeout)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			logs.Error("获取 Telegram 消息失败", "error", err.Error())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(pollRetryInterval):
+			}
+			continue
+		}
+
+		for i := range updates {
+			offset = updates[i].UpdateID + 1
+			l.handlePolled(ctx, &updates[i])
+		}
+	}
+}
+
+// handlePolled 处理长轮询获取的一条消息，panic 不影响后续消息
+func (l *BotLogic) handlePolled(ctx context.Context, update *telegram.Update) {
+	ctx = trace.InjectTraceID(ctx)
+	defer safego.Recovery(ctx)
+	l.HandleUpdate(ctx, update)
+}
@@ -0,0 +1,213 @@
+package bot
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	userModel "backend/app/model/user"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	authError "backend/app/types/errorn"
+	botError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/rand"
+	"backend/utils/telegram"
+
+	"go.uber.org/fx"
+)
+
+// 接收消息的方式
+const (
+	ModePolling = "polling" // 长轮询
+	ModeWebhook = "webhook" // Telegram 推送
+)
+
+const (
+	// linkCodeLength 绑定码长度
+	linkCodeLength = 8
+	// pollTimeout 长轮询的等待时间
+	pollTimeout = 30 * time.Second
+)
+
+type UserRepo interface {
+	GetUserByID(ctx context.Context, userID uint) (*userModel.User, error)
+	GetUserByTelegramChatID(ctx context.Context, chatID int64) (*userModel.User, error)
+	GetUserByTelegramLinkCode(ctx context.Context, codeHash string) (*userModel.User, error)
+	ClearTelegramChatID(ctx context.Context, chatID int64) error
+	UpdateUserInfo(ctx context.Context, userID uint, updates map[string]interface{}) error
+}
+
+type BotLogicParams struct {
+	fx.In
+
+	UserRepo  UserRepo
+	ItemLogic ItemLogic
+}
+
+// BotLogic Telegram 机器人，已绑定的用户通过机器人记录项目、查看今天的项目和标记完成
+// 项目的读写都通过 ItemLogic，与 HTTP 接口使用相同的业务逻辑
+type BotLogic struct {
+	userRepo  UserRepo
+	itemLogic ItemLogic
+
+	enabled       bool
+	client        *telegram.Client
+	mode          string
+	botUsername   string
+	webhookURL    string
+	webhookSecret string
+	linkCodeTTL   time.Duration
+	location      *time.Location
+}
+
+func NewBotLogic(params BotLogicParams) (*BotLogic, error) {
+	l := &BotLogic{
+		userRepo:  params.UserRepo,
+		itemLogic: params.ItemLogic,
+		enabled:   envx.GetBool(consts.TelegramEnabled, false),
+	}
+	if !l.enabled {
+		return l, nil
+	}
+
+	token, err := envx.GetString(consts.TelegramBotToken)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mode = envx.GetStringOptional(consts.TelegramMode)
+	switch l.mode {
+	case "":
+		l.mode = ModePolling
+	case ModePolling, ModeWebhook:
+	default:
+		return nil, fmt.Errorf("%s 配置错误: 不支持的接收方式 %s", consts.TelegramMode, l.mode)
+	}
+	if l.mode == ModeWebhook {
+		if l.webhookSecret, err = envx.GetString(consts.TelegramWebhookSecret); err != nil {
+			return nil, err
+		}
+		l.webhookURL = envx.GetStringOptional(consts.TelegramWebhookURL)
+	}
+
+	if l.linkCodeTTL, err = envx.GetDurationWithDefault(consts.TelegramLinkCodeTTL, 10*time.Minute); err != nil {
+		return nil, err
+	}
+	l.location = time.Local
+	if tz := envx.GetStringOptional(consts.TelegramTimezone); tz != "" {
+		if l.location, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("%s 配置错误: %w", consts.TelegramTimezone, err)
+		}
+	}
+
+	// 长轮询的 HTTP 超时需要大于等待时间
+	httpClient := &http.Client{Timeout: pollTimeout + 10*time.Second}
+	l.client = telegram.NewClient(token, envx.GetStringOptional(consts.TelegramAPIURL), httpClient)
+	l.botUsername = envx.GetStringOptional(consts.TelegramBotUsername)
+	return l, nil
+}
+
+// hashLinkCode 计算绑定码哈希，数据库中只保存哈希
+func hashLinkCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetStatus 获取机器人是否启用以及当前用户是否已绑定
+func (l *BotLogic) GetStatus(ctx context.Context) (*dto.TelegramStatusDTO, error) {
+	user, err := l.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.TelegramStatusDTO{
+		Enabled: l.enabled,
+		Linked:  user.TelegramChatID != 0,
+	}, nil
+}
+
+// CreateLinkCode 为当前用户生成一次性绑定码，之前未使用的绑定码失效
+func (l *BotLogic) CreateLinkCode(ctx context.Context) (*dto.TelegramLinkDTO, error) {
+	if !l.enabled {
+		return nil, errorx.New(botError.BotErrDisabled)
+	}
+	user, err := l.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := rand.GenerateCode(linkCodeLength)
+	if err != nil {
+		logs.CtxErrorf(ctx, "生成绑定码失败: user_id=%d, error=%s", user.ID, err.Error())
+		return nil, errorx.Wrap(err, botError.BotErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	expiresAt := time.Now().Add(l.linkCodeTTL)
+	if err := l.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{
+		"telegram_link_code":       hashLinkCode(code),
+		"telegram_link_expires_at": expiresAt,
+	}); err != nil {
+		logs.CtxErrorf(ctx, "保存绑定码失败: user_id=%d, error=%s", user.ID, err.Error())
+		return nil, errorx.Wrap(err, botError.BotErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	logs.CtxInfof(ctx, "已生成 Telegram 绑定码: user_id=%d, expires_at=%s", user.ID, expiresAt.Format(time.RFC3339))
+	link := &dto.TelegramLinkDTO{Code: code, ExpiresAt: expiresAt}
+	if l.botUsername != "" {
+		link.LinkURL = "https://t.me/" + url.PathEscape(l.botUsername) + "?start=" + code
+	}
+	return link, nil
+}
+
+// Unlink 解除当前用户与 Telegram 的绑定
+func (l *BotLogic) Unlink(ctx context.Context) error {
+	user, err := l.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if user.TelegramChatID == 0 {
+		return nil
+	}
+	if err := l.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{"telegram_chat_id": 0}); err != nil {
+		logs.CtxErrorf(ctx, "解除 Telegram 绑定失败: user_id=%d, error=%s", user.ID, err.Error())
+		return errorx.Wrap(err, botError.BotErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	logs.CtxInfof(ctx, "已解除 Telegram 绑定: user_id=%d", user.ID)
+	return nil
+}
+
+// HandleWebhook 处理 Telegram 推送的消息，secretToken 为请求头中的 webhook 密钥
+// 消息处理失败时只回复用户并记录日志，不返回错误，避免 Telegram 重复推送
+func (l *BotLogic) HandleWebhook(ctx context.Context, secretToken string, update *telegram.Update) error {
+	if !l.enabled || l.mode != ModeWebhook {
+		return errorx.New(botError.BotErrDisabled)
+	}
+	if subtle.ConstantTimeCompare([]byte(secretToken), []byte(l.webhookSecret)) != 1 {
+		logs.CtxWarnf(ctx, "Telegram webhook 密钥错误")
+		return errorx.New(botError.BotErrWebhookForbidden)
+	}
+	l.HandleUpdate(ctx, update)
+	return nil
+}
+
+// currentUser 查询 ctx 中的当前用户
+func (l *BotLogic) currentUser(ctx context.Context) (*userModel.User, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return nil, errorx.New(authError.AuthErrTokenRequired)
+	}
+	user, err := l.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询用户失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, botError.BotErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	return user, nil
+}
@@ -0,0 +1,118 @@
+package change
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"backend/app/internal/logic/bizmetrics"
+	"backend/utils/logs"
+	"backend/utils/safego"
+	"backend/utils/sse"
+)
+
+// feedCacheLimit 订阅者只接收实时变更，广播任务只保留最后一条数据，避免长期运行的任务数据无限增长
+var feedCacheLimit = sse.CacheLimit{MaxEvents: 1}
+
+// feedOptions 广播任务的配置，常驻的广播任务不占用 SSE 并发名额
+var feedOptions = []sse.TaskOption{
+	sse.WithTaskCacheLimit(feedCacheLimit),
+	sse.WithTaskKind(bizmetrics.SSEKindSync),
+	sse.WithoutConcurrencyLimit(),
+}
+
+// feed 一个租户的变更广播任务
+type feed struct {
+	taskID      string
+	resumeKey   string
+	subscribers int
+}
+
+// changeFeed 按租户广播变更
+// 每个租户对应 SSEManager 中一个长期运行的任务，订阅者通过 resumeKey 加入同一个任务，
+// 变更通过 UpdateProgress 转发给所有订阅者。任务运行 lifetime 后结束，订阅者收到 done 事件后重连
+type changeFeed struct {
+	manager  SSEManager
+	lifetime time.Duration
+
+	mu    sync.Mutex
+	feeds map[string]*feed
+	seq   uint64
+}
+
+func newChangeFeed(manager SSEManager, lifetime time.Duration) *changeFeed {
+	return &changeFeed{
+		manager:  manager,
+		lifetime: lifetime,
+		feeds:    make(map[string]*feed),
+	}
+}
+
+// subscribe 订阅租户的变更，ctx 结束时取消订阅
+func (f *changeFeed) subscribe(ctx context.Context, tenantID string) (<-chan sse.Event, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	subscriberID := fmt.Sprintf("sync_%d", f.seq)
+
+	// 加入已有任务，只接收实时变更（历史变更由 Stream 按 since 补发），任务已结束或过期时创建新任务
+	resumeKey := ""
+	current := f.feeds[tenantID]
+	if current != nil {
+		resumeKey = current.resumeKey
+	}
+	ch, taskID, err := f.manager.ExecuteWithSSE(ctx, resumeKey, sse.LiveOnly, subscriberID, f.run, f.lifetime, feedOptions...)
+	if err != nil && resumeKey != "" {
+		logs.CtxInfof(ctx, "变更广播任务已结束，重新创建: tenant_id=%s, task_id=%s, error=%s", tenantID, current.taskID, err.Error())
+		current = nil
+		ch, taskID, err = f.manager.ExecuteWithSSE(ctx, "", 0, subscriberID, f.run, f.lifetime, feedOptions...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil || current.taskID != taskID {
+		info, err := f.manager.GetTaskInfo(taskID)
+		if err != nil {
+			return nil, err
+		}
+		current = &feed{taskID: taskID, resumeKey: info.ResumeKey}
+		f.feeds[tenantID] = current
+	}
+	current.subscribers++
+
+	safego.Go(ctx, func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		current.subscribers--
+		f.mu.Unlock()
+	})
+
+	return ch, nil
+}
+
+// publish 向租户的订阅者广播变更
+// 没有订阅者时不发送，避免 SSEManager 在无订阅者期间缓存数据
+func (f *changeFeed) publish(ctx context.Context, tenantID string, data interface{}) {
+	f.mu.Lock()
+	current := f.feeds[tenantID]
+	if current == nil || current.subscribers == 0 {
+		f.mu.Unlock()
+		return
+	}
+	taskID := current.taskID
+	f.mu.Unlock()
+
+	if err := f.manager.UpdateProgress(ctx, taskID, data); err != nil {
+		logs.CtxWarnf(ctx, "广播变更失败: tenant_id=%s, task_id=%s, error=%s", tenantID, taskID, err.Error())
+	}
+}
+
+// run 广播任务的执行函数，只等待任务到期，数据由 publish 推送
+func (f *changeFeed) run(ctx context.Context, taskID string, _ func(data interface{}) error) error {
+	defer bizmetrics.SSETaskStarted(bizmetrics.SSEKindSync)()
+	<-ctx.Done()
+	return nil
+}
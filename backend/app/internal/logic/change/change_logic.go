@@ -0,0 +1,199 @@
+package change
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	changeModel "backend/app/model/change"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	syncError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/safego"
+	"backend/utils/sse"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// backlogPageSize 订阅时补发历史变更的每页条数
+	backlogPageSize = 500
+	// maxChangesPageSize 增量同步接口每次最多返回的变更数
+	maxChangesPageSize = 500
+)
+
+type ChangeRepo interface {
+	CreateChange(ctx context.Context, change *changeModel.Change) error
+	GetChangesSince(ctx context.Context, since uint, limit int) ([]*changeModel.Change, error)
+}
+
+type SSEManager interface {
+	ExecuteWithSSE(ctx context.Context, resumeKey string, lastEventID uint64, subscriberID string, asyncFunc sse.AsyncTaskFunc, asyncTimeout time.Duration, opts ...sse.TaskOption) (<-chan sse.Event, string, error)
+	UpdateProgress(ctx context.Context, taskID string, data interface{}) error
+	GetTaskInfo(taskID string) (*sse.TaskInfo, error)
+}
+
+type ChangeLogicParams struct {
+	fx.In
+
+	ChangeRepo ChangeRepo
+	SSEManager SSEManager
+}
+
+type ChangeLogic struct {
+	changeRepo ChangeRepo
+	feed       *changeFeed
+}
+
+func NewChangeLogic(params ChangeLogicParams) (*ChangeLogic, error) {
+	lifetime, err := envx.GetDurationWithDefault(consts.SSETaskTTL, time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeLogic{
+		changeRepo: params.ChangeRepo,
+		feed:       newChangeFeed(params.SSEManager, lifetime),
+	}, nil
+}
+
+// Record 记录数据变更并广播给同一租户的订阅者，失败只记录日志，不影响调用方
+// data 为变更后的数据，删除时传 nil；试运行时不记录
+func (l *ChangeLogic) Record(ctx context.Context, entity string, action string, entityID uint, data any) {
+	if meta.IsDryRun(ctx) {
+		return
+	}
+	change := &changeModel.Change{
+		Entity:   entity,
+		EntityID: entityID,
+		Action:   action,
+	}
+	change.UserID, _ = ctx.Value(meta.ContextKeyUserID).(uint)
+	if data != nil {
+		body, err := json.Marshal(data)
+		if err != nil {
+			logs.CtxErrorf(ctx, "序列化变更数据失败: entity=%s, entity_id=%d, error=%s", entity, entityID, err.Error())
+			return
+		}
+		change.Data = string(body)
+	}
+
+	if err := l.changeRepo.CreateChange(ctx, change); err != nil {
+		logs.CtxErrorf(ctx, "记录变更失败: entity=%s, entity_id=%d, action=%s, error=%s", entity, entityID, action, err.Error())
+		return
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	l.feed.publish(ctx, tenantID, toChangeDTO(change))
+}
+
+// GetChanges 获取序号大于 since 的变更，用于客户端补齐断线期间的变更
+func (l *ChangeLogic) GetChanges(ctx context.Context, since uint, limit int) (*dto.ChangeListDTO, error) {
+	if limit <= 0 || limit > maxChangesPageSize {
+		limit = maxChangesPageSize
+	}
+
+	// 多查一条判断是否还有更多
+	changes, err := l.changeRepo.GetChangesSince(ctx, since, limit+1)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询变更失败: since=%d, error=%s", since, err.Error())
+		return nil, errorx.Wrap(err, syncError.SyncErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	result := &dto.ChangeListDTO{
+		Changes: make([]*dto.ChangeDTO, 0, len(changes)),
+		Latest:  since,
+	}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		result.HasMore = true
+	}
+	for _, change := range changes {
+		result.Changes = append(result.Changes, toChangeDTO(change))
+		result.Latest = change.ID
+	}
+	return result, nil
+}
+
+// Stream 订阅当前租户的变更
+// since 不为空时先补发序号大于 since 的历史变更，再推送实时变更，按序号去重，ctx 结束时通道关闭
+func (l *ChangeLogic) Stream(ctx context.Context, since *uint) (<-chan *dto.ChangeDTO, error) {
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+
+	// 先订阅再查询历史，避免两者之间的变更丢失
+	live, err := l.feed.subscribe(ctx, tenantID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "订阅变更失败: tenant_id=%s, error=%s", tenantID, err.Error())
+		return nil, errorx.Wrap(err, syncError.SyncErrStreamFailed, errorx.K("reason", err.Error()))
+	}
+
+	out := make(chan *dto.ChangeDTO, 100)
+	safego.Go(ctx, func() {
+		defer close(out)
+
+		var last uint
+		send := func(change *dto.ChangeDTO) bool {
+			if change.Seq <= last {
+				return true
+			}
+			select {
+			case out <- change:
+				last = change.Seq
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		if since != nil {
+			last = *since
+			for {
+				changes, err := l.changeRepo.GetChangesSince(ctx, last, backlogPageSize)
+				if err != nil {
+					logs.CtxErrorf(ctx, "查询历史变更失败: since=%d, error=%s", last, err.Error())
+					return
+				}
+				for _, change := range changes {
+					if !send(toChangeDTO(change)) {
+						return
+					}
+				}
+				if len(changes) < backlogPageSize {
+					break
+				}
+			}
+		}
+
+		for event := range live {
+			change, ok := event.Data.(*dto.ChangeDTO)
+			if !ok {
+				continue
+			}
+			if !send(change) {
+				return
+			}
+		}
+	})
+
+	return out, nil
+}
+
+func toChangeDTO(change *changeModel.Change) *dto.ChangeDTO {
+	var data json.RawMessage
+	if change.Data != "" {
+		data = json.RawMessage(change.Data)
+	}
+	return &dto.ChangeDTO{
+		Seq:       change.ID,
+		Entity:    change.Entity,
+		EntityID:  change.EntityID,
+		Action:    change.Action,
+		Data:      data,
+		UserID:    change.UserID,
+		CreatedAt: change.CreatedAt,
+	}
+}
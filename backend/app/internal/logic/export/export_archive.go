@@ -0,0 +1,217 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	fileModel "backend/app/model/file"
+	tagModel "backend/app/model/tag"
+	userModel "backend/app/model/user"
+	"backend/app/types/dto"
+)
+
+// exportManifest 导出文件说明（manifest.json）
+type exportManifest struct {
+	UserID     uint      `json:"user_id"`
+	ExportedAt time.Time `json:"exported_at"`
+	Items      int       `json:"items"`
+	Tags       int       `json:"tags"`
+	Files      int       `json:"files"`
+}
+
+// exportProfile 用户资料（profile.json），不包含密码哈希等敏感字段
+type exportProfile struct {
+	UserID        uint      `json:"user_id"`
+	Username      string    `json:"username"`
+	NickName      string    `json:"nick_name"`
+	Avatar        string    `json:"avatar"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// exportFile 文件记录（files.json），Path 为文件内容在压缩包中的路径，存储中找不到文件时为空
+type exportFile struct {
+	FileID    uint      `json:"file_id"`
+	FileName  string    `json:"file_name"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+	Path      string    `json:"path"`
+}
+
+// archive 导出压缩包写入器，每个条目写完后才能创建下一个
+type archive struct {
+	zw         *zip.Writer
+	exportedAt time.Time
+}
+
+func newArchive(w io.Writer, exportedAt time.Time) *archive {
+	return &archive{
+		zw:         zip.NewWriter(w),
+		exportedAt: exportedAt,
+	}
+}
+
+// create 创建压缩包条目
+func (a *archive) create(name string) (io.Writer, error) {
+	return a.zw.CreateHeader(&zip.FileHeader{
+		Name:     name,
+		Method:   zip.Deflate,
+		Modified: a.exportedAt,
+	})
+}
+
+// writeJSON 以缩进格式写入 JSON 条目
+func (a *archive) writeJSON(name string, v any) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeCSV 写入 CSV 条目，开头写入 UTF-8 BOM，Excel 打开时中文不乱码
+func (a *archive) writeCSV(name string, header []string, rows [][]string) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, "\xEF\xBB\xBF"); err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	return cw.WriteAll(rows)
+}
+
+// writeFile 写入文件内容条目
+func (a *archive) writeFile(name string, r io.Reader) error {
+	w, err := a.create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// Close 写入压缩包目录
+func (a *archive) Close() error {
+	return a.zw.Close()
+}
+
+func toExportProfile(user *userModel.User) *exportProfile {
+	return &exportProfile{
+		UserID:        user.ID,
+		Username:      user.Username,
+		NickName:      user.NickName,
+		Avatar:        user.Avatar,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
+
+func toExportTags(tags []*tagModel.Tag) []dto.TagDTO {
+	result := make([]dto.TagDTO, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, dto.TagDTO{
+			TagID:    tag.ID,
+			TagName:  tag.TagName,
+			TagValue: tag.TagValue,
+			Icon:     tag.Icon,
+			Color:    tag.Color,
+		})
+	}
+	return result
+}
+
+func toExportFile(file *fileModel.File) exportFile {
+	return exportFile{
+		FileID:    file.ID,
+		FileName:  file.FileName,
+		MimeType:  file.FileMimeType,
+		Size:      file.FileSize,
+		Hash:      file.FileHash,
+		CreatedAt: file.CreatedAt,
+	}
+}
+
+// filePath 文件内容在压缩包中的路径，文件名只保留最后一段，避免出现目录穿越
+func filePath(file *fileModel.File) string {
+	name := path.Base(strings.ReplaceAll(file.FileName, "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		name = "file"
+	}
+	return fmt.Sprintf("files/%d_%s", file.ID, name)
+}
+
+func itemRows(items []dto.ItemDTO) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		tagNames := make([]string, 0, len(item.Tags))
+		for _, tag := range item.Tags {
+			tagNames = append(tagNames, tag.TagName)
+		}
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(item.ItemID), 10),
+			item.Content,
+			item.Status,
+			strings.Join(tagNames, ";"),
+			strconv.FormatUint(uint64(item.Version), 10),
+			item.CreatedAt.Format(time.RFC3339),
+			item.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return rows
+}
+
+func tagRows(tags []dto.TagDTO) [][]string {
+	rows := make([][]string, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(tag.TagID), 10),
+			tag.TagName,
+			tag.TagValue,
+			tag.Icon,
+			tag.Color,
+		})
+	}
+	return rows
+}
+
+func fileRows(files []exportFile) [][]string {
+	rows := make([][]string, 0, len(files))
+	for _, file := range files {
+		rows = append(rows, []string{
+			strconv.FormatUint(uint64(file.FileID), 10),
+			file.FileName,
+			file.MimeType,
+			strconv.FormatInt(file.Size, 10),
+			file.Hash,
+			file.CreatedAt.Format(time.RFC3339),
+			file.Path,
+		})
+	}
+	return rows
+}
+
+var (
+	itemCSVHeader = []string{"item_id", "content", "status", "tags", "version", "created_at", "updated_at"}
+	tagCSVHeader  = []string{"tag_id", "tag_name", "tag_value", "icon", "color"}
+	fileCSVHeader = []string{"file_id", "file_name", "mime_type", "size", "hash", "created_at", "path"}
+)
@@ -0,0 +1,630 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/app/internal/logic/bizmetrics"
+	fileModel "backend/app/model/file"
+	tagModel "backend/app/model/tag"
+	userModel "backend/app/model/user"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	authError "backend/app/types/errorn"
+	exportError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/rand"
+	"backend/utils/safego"
+	"backend/utils/secret"
+	"backend/utils/sse"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// purposeDownload 下载链接令牌用途
+	purposeDownload = "export_download"
+	// downloadPath 下载接口路径
+	downloadPath = "/api/user/export/download"
+	// itemBatchSize 每批导出的项目数
+	itemBatchSize = 200
+	// fileSuffix 导出文件后缀
+	fileSuffix = ".zip"
+)
+
+// 导出阶段
+const (
+	StageStarted   = "started"
+	StageProfile   = "profile"
+	StageItems     = "items"
+	StageTags      = "tags"
+	StageFiles     = "files"
+	StageCompleted = "completed"
+	StageFailed    = "failed"
+)
+
+type ItemRepo interface {
+	ListItemsWithTagsByCreator(ctx context.Context, userID uint, afterID uint, limit int) ([]dto.ItemDTO, error)
+}
+
+type TagRepo interface {
+	ListTagsByCreator(ctx context.Context, userID uint) ([]*tagModel.Tag, error)
+}
+
+type FileRepo interface {
+	ListUserFiles(ctx context.Context, userID uint, storagePaths []string) ([]*fileModel.File, error)
+}
+
+type UserRepo interface {
+	GetUserByID(ctx context.Context, userID uint) (*userModel.User, error)
+}
+
+// FileStorage 读取已上传文件的内容，查找内容中引用的文件
+type FileStorage interface {
+	OpenFile(ctx context.Context, file *fileModel.File) (io.ReadCloser, error)
+	ReferencedPaths(content string) []string
+}
+
+type SSEManager interface {
+	ExecuteWithSSE(ctx context.Context, resumeKey string, lastEventID uint64, subscriberID string, asyncFunc sse.AsyncTaskFunc, asyncTimeout time.Duration, opts ...sse.TaskOption) (<-chan sse.Event, string, error)
+	GetTaskInfo(taskID string) (*sse.TaskInfo, error)
+	GetTaskResult(taskID string) (*sse.TaskResult, error)
+	PauseTask(ctx context.Context, taskID string) error
+	ResumeTask(ctx context.Context, taskID string) error
+}
+
+type ExportLogicParams struct {
+	fx.In
+
+	ItemRepo    ItemRepo
+	TagRepo     TagRepo
+	FileRepo    FileRepo
+	UserRepo    UserRepo
+	FileStorage FileStorage
+	SSEManager  SSEManager
+}
+
+type ExportLogic struct {
+	itemRepo   ItemRepo
+	tagRepo    TagRepo
+	fileRepo   FileRepo
+	userRepo   UserRepo
+	storage    FileStorage
+	manager    SSEManager
+	jwt        *secret.JWT
+	dir        string
+	linkExpire time.Duration
+	timeout    time.Duration
+
+	mu      sync.Mutex
+	running map[string]runningExport // 租户和用户 -> 进行中的导出任务
+	seq     uint64
+}
+
+// runningExport 进行中的导出任务
+type runningExport struct {
+	taskID    string
+	resumeKey string
+}
+
+func NewExportLogic(params ExportLogicParams) (*ExportLogic, error) {
+	jwtSecret, err := envx.GetString(consts.JWTSecret)
+	if err != nil {
+		return nil, err
+	}
+	linkExpire, err := envx.GetDurationWithDefault(consts.ExportLinkExpire, 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := envx.GetDurationWithDefault(consts.ExportTimeout, 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	dir := envx.GetStringOptional(consts.ExportDir)
+	if dir == "" {
+		dir = "./exports"
+	}
+
+	return &ExportLogic{
+		itemRepo:   params.ItemRepo,
+		tagRepo:    params.TagRepo,
+		fileRepo:   params.FileRepo,
+		userRepo:   params.UserRepo,
+		storage:    params.FileStorage,
+		manager:    params.SSEManager,
+		jwt:        secret.NewJWT(secret.TokenConfig{Secret: jwtSecret}),
+		dir:        dir,
+		linkExpire: linkExpire,
+		timeout:    timeout,
+		running:    make(map[string]runningExport),
+	}, nil
+}
+
+// Export 导出当前用户的数据，返回导出进度（sse.Event，Data 为 *dto.ExportProgressDTO），ctx 结束时通道关闭
+// 导出在后台执行，客户端断开不影响导出；同一用户已有导出在进行时加入该导出，不重复导出，
+// 从 lastEventID 之后补发进度（0 表示补发全部进度）
+func (l *ExportLogic) Export(ctx context.Context, lastEventID uint64) (<-chan sse.Event, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return nil, errorx.New(authError.AuthErrTokenRequired)
+	}
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	key := fmt.Sprintf("%s:%d", tenantID, userID)
+
+	// 导出在独立的 context 中执行，需要带上租户、用户和链路信息
+	values := detachValues(ctx)
+	run := func(asyncCtx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		defer bizmetrics.SSETaskStarted(bizmetrics.SSEKindExport)()
+		err := l.run(values(asyncCtx), taskID, userID, updateProgress)
+		l.finish(key, taskID)
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	subscriberID := fmt.Sprintf("export_%d", l.seq)
+	resumeKey := l.running[key].resumeKey
+	ch, taskID, err := l.manager.ExecuteWithSSE(ctx, resumeKey, lastEventID, subscriberID, run, l.timeout, sse.WithTaskKind(bizmetrics.SSEKindExport))
+	if err != nil && resumeKey != "" {
+		logs.CtxInfof(ctx, "导出任务已结束，重新导出: user_id=%d, error=%s", userID, err.Error())
+		ch, taskID, err = l.manager.ExecuteWithSSE(ctx, "", 0, subscriberID, run, l.timeout, sse.WithTaskKind(bizmetrics.SSEKindExport))
+	}
+	if err != nil {
+		logs.CtxErrorf(ctx, "启动导出任务失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+
+	info, err := l.manager.GetTaskInfo(taskID)
+	if err == nil && !info.Status.Finished() {
+		if info.ResumeKey != resumeKey {
+			logs.CtxInfof(ctx, "开始导出账户数据: user_id=%d, task_id=%s", userID, taskID)
+		}
+		l.running[key] = runningExport{taskID: taskID, resumeKey: info.ResumeKey}
+	}
+
+	out := make(chan sse.Event, 16)
+	safego.Go(ctx, func() {
+		defer close(out)
+
+		send := func(event sse.Event) bool {
+			select {
+			case out <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var last *dto.ExportProgressDTO
+		for event := range ch {
+			// 订阅被中止（如服务关闭），转发后结束，客户端收到 server_shutdown 等事件
+			if event.Err != nil {
+				send(event)
+				return
+			}
+			// 保活、排队数据直接转发，客户端据此展示导出仍在进行或排队位置
+			if event.Keepalive != nil || event.Queued != nil {
+				if !send(event) {
+					return
+				}
+				continue
+			}
+			progress, ok := event.Data.(*dto.ExportProgressDTO)
+			if !ok {
+				continue
+			}
+			if !send(event) {
+				return
+			}
+			last = progress
+		}
+
+		// 任务结束时最后一条进度可能未转发，补发最终进度
+		if last != nil && isFinal(last.Stage) {
+			return
+		}
+		if result, err := l.manager.GetTaskResult(taskID); err == nil && result.Result != nil {
+			send(sse.Event{ID: result.LastEventID, Data: result.Result})
+		}
+	})
+	return out, nil
+}
+
+// finish 导出结束后移除进行中的记录，之后的导出请求开始新的导出
+// 最终进度由 SSEManager 保留到任务过期，通过 GetTaskResult 获取
+func (l *ExportLogic) finish(key string, taskID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.running[key].taskID == taskID {
+		delete(l.running, key)
+	}
+}
+
+// Pause 暂停当前用户进行中的导出，导出在当前阶段结束时停下，已推送的进度保持不变
+// 订阅者仍然连接，Resume 后从停下的位置继续；暂停期间 EXPORT_TIMEOUT 仍然计时
+func (l *ExportLogic) Pause(ctx context.Context) error {
+	taskID, err := l.runningTask(ctx)
+	if err != nil {
+		return err
+	}
+	if err := l.manager.PauseTask(ctx, taskID); err != nil {
+		if errors.Is(err, sse.ErrTaskNotRunning) {
+			return errorx.New(exportError.ExportErrNotRunning)
+		}
+		return errorx.Wrap(err, exportError.ExportErrNotRunning)
+	}
+	logs.CtxInfof(ctx, "暂停导出: task_id=%s", taskID)
+	return nil
+}
+
+// Resume 恢复当前用户已暂停的导出
+func (l *ExportLogic) Resume(ctx context.Context) error {
+	taskID, err := l.runningTask(ctx)
+	if err != nil {
+		return err
+	}
+	if err := l.manager.ResumeTask(ctx, taskID); err != nil {
+		if errors.Is(err, sse.ErrTaskNotPaused) {
+			return errorx.New(exportError.ExportErrNotPaused)
+		}
+		return errorx.Wrap(err, exportError.ExportErrNotRunning)
+	}
+	logs.CtxInfof(ctx, "恢复导出: task_id=%s", taskID)
+	return nil
+}
+
+// runningTask 当前用户进行中的导出任务ID
+func (l *ExportLogic) runningTask(ctx context.Context) (string, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return "", errorx.New(authError.AuthErrTokenRequired)
+	}
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	key := fmt.Sprintf("%s:%d", tenantID, userID)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	running, ok := l.running[key]
+	if !ok {
+		return "", errorx.New(exportError.ExportErrNotRunning)
+	}
+	return running.taskID, nil
+}
+
+// isFinal 是否为结束阶段
+func isFinal(stage string) bool {
+	return stage == StageCompleted || stage == StageFailed
+}
+
+// run 执行导出，每个阶段结束时推送进度，失败时推送 failed 阶段和失败原因
+// 最后推送的进度（completed 或 failed）即任务的最终结果
+func (l *ExportLogic) run(ctx context.Context, taskID string, userID uint, updateProgress func(data interface{}) error) error {
+	progress := &dto.ExportProgressDTO{TaskID: taskID}
+	report := func(stage string) {
+		progress.Stage = stage
+		snapshot := new(dto.ExportProgressDTO)
+		*snapshot = *progress
+		// 推送失败（如首个订阅者已断开）不影响导出，重新请求即可获取最新进度
+		_ = updateProgress(snapshot)
+	}
+	report(StageStarted)
+
+	l.removeExpired(ctx)
+
+	name, err := l.writeArchive(ctx, userID, progress, report)
+	if err != nil {
+		logs.CtxErrorf(ctx, "导出账户数据失败: user_id=%d, task_id=%s, error=%s", userID, taskID, err.Error())
+		progress.Error = errorx.LocalizedMsg(err, localeFromContext(ctx))
+		report(StageFailed)
+		return err
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	token, err := l.jwt.GenerateActionToken(userID, tenantID, purposeDownload, name, l.linkExpire)
+	if err != nil {
+		logs.CtxErrorf(ctx, "生成下载链接失败: user_id=%d, error=%s", userID, err.Error())
+		progress.Error = err.Error()
+		report(StageFailed)
+		return err
+	}
+	expiresAt := time.Now().Add(l.linkExpire)
+	progress.DownloadURL = downloadPath + "?token=" + url.QueryEscape(token)
+	progress.ExpiresAt = &expiresAt
+	report(StageCompleted)
+
+	logs.CtxInfof(ctx, "账户数据导出完成: user_id=%d, task_id=%s, file=%s, items=%d, tags=%d, files=%d",
+		userID, taskID, name, progress.Items, progress.Tags, progress.Files)
+	return nil
+}
+
+// writeArchive 将用户数据写入导出文件，返回导出文件名
+// 先写入临时文件，全部写完后再重命名，下载时不会读到不完整的文件
+func (l *ExportLogic) writeArchive(ctx context.Context, userID uint, progress *dto.ExportProgressDTO, report func(stage string)) (string, error) {
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
+		return "", errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("export_%d_%s_%s%s", userID, now.Format("20060102150405"), rand.MustGenerateUID(), fileSuffix)
+	fullPath := filepath.Join(l.dir, name)
+	tmpPath := fullPath + ".tmp"
+
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return "", errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+	defer os.Remove(tmpPath)
+
+	a := newArchive(f, now)
+	if err := l.writeEntries(ctx, a, userID, now, progress, report); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := a.Close(); err != nil {
+		f.Close()
+		return "", errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+	if err := f.Close(); err != nil {
+		return "", errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+	if err := os.Rename(tmpPath, fullPath); err != nil {
+		return "", errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+	return name, nil
+}
+
+// writeEntries 依次写入用户资料、项目、标签和文件
+func (l *ExportLogic) writeEntries(ctx context.Context, a *archive, userID uint, exportedAt time.Time, progress *dto.ExportProgressDTO, report func(stage string)) error {
+	archiveErr := func(err error) error {
+		return errorx.Wrap(err, exportError.ExportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+	dbErr := func(err error) error {
+		return errorx.Wrap(err, exportError.ExportErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	// 用户资料
+	user, err := l.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return dbErr(err)
+	}
+	if err := a.writeJSON("profile.json", toExportProfile(user)); err != nil {
+		return archiveErr(err)
+	}
+	report(StageProfile)
+
+	// 头像和项目内容中引用的文件，上传时未登录的文件没有创建人，通过引用关联到用户
+	refs := newPathSet(l.storage.ReferencedPaths(user.Avatar))
+
+	// 项目（分批查询）
+	items := make([]dto.ItemDTO, 0)
+	var afterID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return archiveErr(err)
+		}
+		batch, err := l.itemRepo.ListItemsWithTagsByCreator(ctx, userID, afterID, itemBatchSize)
+		if err != nil {
+			return dbErr(err)
+		}
+		items = append(items, batch...)
+		for _, item := range batch {
+			refs.add(l.storage.ReferencedPaths(item.Content))
+		}
+		progress.Items = len(items)
+		report(StageItems)
+		if len(batch) < itemBatchSize {
+			break
+		}
+		afterID = batch[len(batch)-1].ItemID
+	}
+	if err := a.writeJSON("items.json", items); err != nil {
+		return archiveErr(err)
+	}
+	if err := a.writeCSV("items.csv", itemCSVHeader, itemRows(items)); err != nil {
+		return archiveErr(err)
+	}
+
+	// 标签
+	tagRecords, err := l.tagRepo.ListTagsByCreator(ctx, userID)
+	if err != nil {
+		return dbErr(err)
+	}
+	tags := toExportTags(tagRecords)
+	if err := a.writeJSON("tags.json", tags); err != nil {
+		return archiveErr(err)
+	}
+	if err := a.writeCSV("tags.csv", tagCSVHeader, tagRows(tags)); err != nil {
+		return archiveErr(err)
+	}
+	progress.Tags = len(tags)
+	report(StageTags)
+
+	// 文件（记录和内容）
+	fileRecords, err := l.fileRepo.ListUserFiles(ctx, userID, refs.paths)
+	if err != nil {
+		return dbErr(err)
+	}
+	files := make([]exportFile, 0, len(fileRecords))
+	for _, record := range fileRecords {
+		if err := ctx.Err(); err != nil {
+			return archiveErr(err)
+		}
+		file := toExportFile(record)
+		included, err := l.writeFileContent(ctx, a, record)
+		if err != nil {
+			return archiveErr(err)
+		}
+		if included {
+			file.Path = filePath(record)
+		}
+		files = append(files, file)
+		progress.Files = len(files)
+		if len(files)%itemBatchSize == 0 {
+			report(StageFiles)
+		}
+	}
+	if err := a.writeJSON("files.json", files); err != nil {
+		return archiveErr(err)
+	}
+	if err := a.writeCSV("files.csv", fileCSVHeader, fileRows(files)); err != nil {
+		return archiveErr(err)
+	}
+	report(StageFiles)
+
+	return a.writeJSON("manifest.json", &exportManifest{
+		UserID:     userID,
+		ExportedAt: exportedAt,
+		Items:      len(items),
+		Tags:       len(tags),
+		Files:      len(files),
+	})
+}
+
+// writeFileContent 写入文件内容，存储中读取不到的文件只记录日志并跳过
+func (l *ExportLogic) writeFileContent(ctx context.Context, a *archive, file *fileModel.File) (bool, error) {
+	reader, err := l.storage.OpenFile(ctx, file)
+	if err != nil {
+		logs.CtxWarnf(ctx, "读取文件失败，跳过文件内容: file_id=%d, error=%s", file.ID, err.Error())
+		return false, nil
+	}
+	defer reader.Close()
+
+	if err := a.writeFile(filePath(file), reader); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// OpenDownload 校验下载链接令牌并打开导出文件，返回文件和下载时使用的文件名
+func (l *ExportLogic) OpenDownload(ctx context.Context, token string) (*os.File, string, error) {
+	claims, err := l.jwt.ParseActionToken(token, purposeDownload)
+	if err != nil {
+		logs.CtxWarnf(ctx, "解析下载链接令牌失败: error=%s", err.Error())
+		return nil, "", errorx.New(exportError.ExportErrLinkInvalid)
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	if claims.TenantID != tenantID {
+		logs.CtxWarnf(ctx, "下载链接令牌租户不匹配: token_tenant=%s, tenant_id=%s", claims.TenantID, tenantID)
+		return nil, "", errorx.New(exportError.ExportErrLinkInvalid)
+	}
+
+	// 文件名由服务端生成并签名在令牌中，这里仍校验不含路径
+	name := claims.Fingerprint
+	if name == "" || filepath.Base(name) != name || !strings.HasSuffix(name, fileSuffix) {
+		logs.CtxWarnf(ctx, "下载链接令牌文件名无效: name=%s", name)
+		return nil, "", errorx.New(exportError.ExportErrLinkInvalid)
+	}
+
+	f, err := os.Open(filepath.Join(l.dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logs.CtxWarnf(ctx, "导出文件不存在: name=%s", name)
+			return nil, "", errorx.New(exportError.ExportErrFileNotFound)
+		}
+		logs.CtxErrorf(ctx, "打开导出文件失败: name=%s, error=%s", name, err.Error())
+		return nil, "", errorx.Wrap(err, exportError.ExportErrFileNotFound)
+	}
+
+	logs.CtxInfof(ctx, "下载导出文件: user_id=%d, name=%s", claims.UserID, name)
+	return f, name, nil
+}
+
+// removeExpired 删除下载链接已过期的导出文件和中断的临时文件
+func (l *ExportLogic) removeExpired(ctx context.Context) {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logs.CtxWarnf(ctx, "读取导出目录失败: dir=%s, error=%s", l.dir, err.Error())
+		}
+		return
+	}
+
+	deadline := time.Now().Add(-l.linkExpire)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), "export_") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().After(deadline) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(l.dir, entry.Name())); err != nil {
+			logs.CtxWarnf(ctx, "删除过期导出文件失败: name=%s, error=%s", entry.Name(), err.Error())
+			continue
+		}
+		logs.CtxInfof(ctx, "已删除过期导出文件: name=%s", entry.Name())
+	}
+}
+
+// pathSet 去重的存储路径列表
+type pathSet struct {
+	paths []string
+	seen  map[string]bool
+}
+
+func newPathSet(paths []string) *pathSet {
+	s := &pathSet{seen: make(map[string]bool)}
+	s.add(paths)
+	return s
+}
+
+func (s *pathSet) add(paths []string) {
+	for _, path := range paths {
+		if !s.seen[path] {
+			s.seen[path] = true
+			s.paths = append(s.paths, path)
+		}
+	}
+}
+
+// detachValues 返回把请求中的租户、用户、语言和链路信息复制到新 context 的函数
+func detachValues(ctx context.Context) func(context.Context) context.Context {
+	keys := []any{
+		meta.ContextKeyTenantID,
+		meta.ContextKeyUserID,
+		logs.TraceIDContextKey,
+		logs.RequestIDContextKey,
+	}
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = ctx.Value(key)
+	}
+	locale := errorx.LocaleFromContext(ctx)
+
+	return func(target context.Context) context.Context {
+		for i, key := range keys {
+			if values[i] != nil {
+				target = context.WithValue(target, key, values[i])
+			}
+		}
+		if locale != "" {
+			target = errorx.WithLocale(target, locale)
+		}
+		return target
+	}
+}
+
+// localeFromContext 从 context 中读取请求语言，未设置时返回默认语言
+func localeFromContext(ctx context.Context) string {
+	if locale := errorx.LocaleFromContext(ctx); locale != "" {
+		return locale
+	}
+	return errorx.DefaultLocale
+}
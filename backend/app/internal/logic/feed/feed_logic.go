@@ -0,0 +1,383 @@
+package feed
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	tagModel "backend/app/model/tag"
+	userModel "backend/app/model/user"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	authError "backend/app/types/errorn"
+	feedError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/atom"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/ical"
+	"backend/utils/logs"
+	"backend/utils/rand"
+	"backend/utils/secret"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// purposeFeed 订阅链接令牌用途
+	purposeFeed = "item_feed"
+	// calendarPath 日历订阅接口路径
+	calendarPath = "/api/item/calendar.ics"
+	// atomPath 最近项目订阅源接口路径
+	atomPath = "/api/item/feed.atom"
+	// calendarProdID 日历的产品标识
+	calendarProdID = "-//Peano//Items//ZH"
+	// summaryMaxRunes 日程标题的最大字符数，超出部分放在描述中
+	summaryMaxRunes = 80
+)
+
+// 日历组件类型
+const (
+	ComponentEvent = "event" // 日程（VEVENT），日历应用都支持
+	ComponentTodo  = "todo"  // 待办（VTODO），带完成状态，部分日历应用不显示
+)
+
+type UserRepo interface {
+	GetUserByID(ctx context.Context, userID uint) (*userModel.User, error)
+	UpdateUserInfo(ctx context.Context, userID uint, updates map[string]interface{}) error
+}
+
+type ItemRepo interface {
+	ListDueItemsWithTagsByCreator(ctx context.Context, userID uint, limit int) ([]dto.ItemDTO, error)
+	ListRecentItemsWithTagsByCreator(ctx context.Context, userID uint, since time.Time, tagID *uint, limit int) ([]dto.ItemDTO, error)
+}
+
+type TagRepo interface {
+	GetTagByValue(ctx context.Context, tagValue string) (*tagModel.Tag, error)
+}
+
+type FeedLogicParams struct {
+	fx.In
+
+	UserRepo UserRepo
+	ItemRepo ItemRepo
+	TagRepo  TagRepo
+}
+
+type FeedLogic struct {
+	userRepo         UserRepo
+	itemRepo         ItemRepo
+	tagRepo          TagRepo
+	jwt              *secret.JWT
+	calendarMaxItems int
+	atomEnabled      bool
+	atomDays         int
+	atomMaxItems     int
+}
+
+func NewFeedLogic(params FeedLogicParams) (*FeedLogic, error) {
+	jwtSecret, err := envx.GetString(consts.JWTSecret)
+	if err != nil {
+		return nil, err
+	}
+	calendarMaxItems, err := envx.GetIntWithDefaultAndMin(consts.FeedCalendarMaxItems, 500, 1)
+	if err != nil {
+		return nil, err
+	}
+	atomDays, err := envx.GetIntWithDefaultAndMin(consts.FeedAtomDays, 7, 1)
+	if err != nil {
+		return nil, err
+	}
+	atomMaxItems, err := envx.GetIntWithDefaultAndMin(consts.FeedAtomMaxItems, 50, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FeedLogic{
+		userRepo:         params.UserRepo,
+		itemRepo:         params.ItemRepo,
+		tagRepo:          params.TagRepo,
+		jwt:              secret.NewJWT(secret.TokenConfig{Secret: jwtSecret}),
+		calendarMaxItems: calendarMaxItems,
+		atomEnabled:      envx.GetBool(consts.FeedAtomEnabled, false),
+		atomDays:         atomDays,
+		atomMaxItems:     atomMaxItems,
+	}, nil
+}
+
+// fingerprint 计算订阅令牌绑定的订阅密钥指纹，重新生成密钥后旧令牌失效
+func fingerprint(feedKey string) string {
+	sum := sha256.Sum256([]byte(feedKey))
+	return hex.EncodeToString(sum[:8])
+}
+
+// GetFeed 获取当前用户的订阅链接，首次获取时生成订阅密钥
+func (l *FeedLogic) GetFeed(ctx context.Context) (*dto.FeedDTO, error) {
+	return l.feed(ctx, false)
+}
+
+// RotateFeed 重新生成当前用户的订阅密钥，之前的订阅链接全部失效
+func (l *FeedLogic) RotateFeed(ctx context.Context) (*dto.FeedDTO, error) {
+	return l.feed(ctx, true)
+}
+
+func (l *FeedLogic) feed(ctx context.Context, rotate bool) (*dto.FeedDTO, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return nil, errorx.New(authError.AuthErrTokenRequired)
+	}
+
+	user, err := l.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询用户失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	feedKey := user.FeedKey
+	if feedKey == "" || rotate {
+		if feedKey, err = rand.GenerateUID(); err != nil {
+			logs.CtxErrorf(ctx, "生成订阅密钥失败: user_id=%d, error=%s", userID, err.Error())
+			return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+		}
+		if err := l.userRepo.UpdateUserInfo(ctx, userID, map[string]interface{}{"feed_key": feedKey}); err != nil {
+			logs.CtxErrorf(ctx, "保存订阅密钥失败: user_id=%d, error=%s", userID, err.Error())
+			return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+		}
+		logs.CtxInfof(ctx, "已生成订阅密钥: user_id=%d, rotate=%t", userID, rotate)
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	token, err := l.jwt.GenerateActionToken(userID, tenantID, purposeFeed, fingerprint(feedKey), 0)
+	if err != nil {
+		logs.CtxErrorf(ctx, "生成订阅令牌失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	query := "?token=" + url.QueryEscape(token)
+	feed := &dto.FeedDTO{
+		Token:       token,
+		CalendarURL: calendarPath + query,
+	}
+	if l.atomEnabled {
+		feed.AtomURL = atomPath + query
+	}
+	return feed, nil
+}
+
+// authorize 校验订阅令牌，返回令牌对应的用户
+func (l *FeedLogic) authorize(ctx context.Context, token string) (*userModel.User, error) {
+	claims, err := l.jwt.ParseActionToken(token, purposeFeed)
+	if err != nil {
+		logs.CtxWarnf(ctx, "解析订阅令牌失败: error=%s", err.Error())
+		return nil, errorx.New(feedError.FeedErrLinkInvalid)
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	if claims.TenantID != tenantID {
+		logs.CtxWarnf(ctx, "订阅令牌租户不匹配: token_tenant=%s, tenant_id=%s", claims.TenantID, tenantID)
+		return nil, errorx.New(feedError.FeedErrLinkInvalid)
+	}
+
+	user, err := l.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.CtxWarnf(ctx, "用户不存在: user_id=%d", claims.UserID)
+			return nil, errorx.New(feedError.FeedErrLinkInvalid)
+		}
+		logs.CtxErrorf(ctx, "查询用户失败: user_id=%d, error=%s", claims.UserID, err.Error())
+		return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	// 订阅密钥已重新生成，旧链接失效
+	if user.FeedKey == "" || fingerprint(user.FeedKey) != claims.Fingerprint {
+		logs.CtxWarnf(ctx, "订阅令牌已失效: user_id=%d", user.ID)
+		return nil, errorx.New(feedError.FeedErrLinkInvalid)
+	}
+	return user, nil
+}
+
+// Calendar 校验订阅令牌并生成令牌对应用户的项目日历，只包含设置了截止时间的项目
+// component 为 ComponentEvent 或 ComponentTodo，为空时使用 ComponentEvent
+func (l *FeedLogic) Calendar(ctx context.Context, token string, component string) (*ical.Calendar, error) {
+	if component == "" {
+		component = ComponentEvent
+	}
+	user, err := l.authorize(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.itemRepo.ListDueItemsWithTagsByCreator(ctx, user.ID, l.calendarMaxItems)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询项目失败: user_id=%d, error=%s", user.ID, err.Error())
+		return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	cal := &ical.Calendar{ProdID: calendarProdID, Name: "Peano"}
+	for i := range items {
+		if component == ComponentTodo {
+			cal.Add(todoComponent(tenantID, &items[i]))
+		} else {
+			cal.Add(eventComponent(tenantID, &items[i]))
+		}
+	}
+
+	logs.CtxInfof(ctx, "生成日历订阅: user_id=%d, component=%s, items=%d", user.ID, component, len(items))
+	return cal, nil
+}
+
+// Atom 校验订阅令牌并生成令牌对应用户最近创建或完成的项目订阅源
+// tagValue 不为空时只包含带该标签的项目
+func (l *FeedLogic) Atom(ctx context.Context, token string, tagValue string) (*atom.Feed, error) {
+	if !l.atomEnabled {
+		return nil, errorx.New(feedError.FeedErrDisabled)
+	}
+	user, err := l.authorize(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagID *uint
+	if tagValue != "" {
+		tag, err := l.tagRepo.GetTagByValue(ctx, tagValue)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logs.CtxWarnf(ctx, "标签不存在: tag_value=%s", tagValue)
+				return nil, errorx.New(feedError.FeedErrTagNotFound, errorx.K("tag", tagValue))
+			}
+			logs.CtxErrorf(ctx, "查询标签失败: tag_value=%s, error=%s", tagValue, err.Error())
+			return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+		}
+		tagID = &tag.ID
+	}
+
+	now := time.Now()
+	since := now.AddDate(0, 0, -l.atomDays)
+	items, err := l.itemRepo.ListRecentItemsWithTagsByCreator(ctx, user.ID, since, tagID, l.atomMaxItems)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询项目失败: user_id=%d, error=%s", user.ID, err.Error())
+		return nil, errorx.Wrap(err, feedError.FeedErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	title := "Peano"
+	if tagValue != "" {
+		title += " - " + tagValue
+	}
+	feed := &atom.Feed{
+		ID:      urn(tenantID, "feed", user.ID),
+		Title:   title,
+		Updated: atom.Time(now),
+		Author:  &atom.Person{Name: displayName(user)},
+		Links:   []atom.Link{{Rel: "self", Type: atom.ContentType, Href: atomPath}},
+	}
+	// 订阅源的更新时间取最近更新的项目，没有项目时为当前时间
+	if len(items) > 0 {
+		feed.Updated = atom.Time(items[0].UpdatedAt)
+	}
+	for i := range items {
+		feed.Entries = append(feed.Entries, atomEntry(tenantID, &items[i]))
+	}
+
+	logs.CtxInfof(ctx, "生成订阅源: user_id=%d, tag=%s, items=%d", user.ID, tagValue, len(items))
+	return feed, nil
+}
+
+// atomEntry 将项目转换为订阅条目，状态和标签作为分类
+func atomEntry(tenantID string, item *dto.ItemDTO) *atom.Entry {
+	categories := []atom.Category{{Term: "status:" + item.Status}}
+	for _, tag := range item.Tags {
+		categories = append(categories, atom.Category{Term: tag.TagValue, Label: tag.TagName})
+	}
+	return &atom.Entry{
+		ID:         urn(tenantID, "item", item.ItemID),
+		Title:      summary(item.Content),
+		Updated:    atom.Time(item.UpdatedAt),
+		Published:  atom.NewTime(item.CreatedAt),
+		Categories: categories,
+		Content:    &atom.Text{Type: "text", Body: item.Content},
+	}
+}
+
+// urn 生成订阅源和条目的唯一标识
+func urn(tenantID string, kind string, id uint) string {
+	if tenantID == "" {
+		return fmt.Sprintf("urn:peano:%s:%d", kind, id)
+	}
+	return fmt.Sprintf("urn:peano:%s:%s:%d", tenantID, kind, id)
+}
+
+// displayName 订阅源中对用户的称呼
+func displayName(user *userModel.User) string {
+	if user.NickName != "" {
+		return user.NickName
+	}
+	return user.Username
+}
+
+// eventComponent 将项目转换为日程，截止时间作为开始时间
+func eventComponent(tenantID string, item *dto.ItemDTO) *ical.Component {
+	c := ical.NewComponent(ical.KindEvent)
+	writeCommon(c, tenantID, item)
+	c.Time("DTSTART", *item.DueAt)
+	return c
+}
+
+// todoComponent 将项目转换为待办，已完成的项目标记为完成，标记的项目设为最高优先级
+func todoComponent(tenantID string, item *dto.ItemDTO) *ical.Component {
+	c := ical.NewComponent(ical.KindTodo)
+	writeCommon(c, tenantID, item)
+	c.Time("DUE", *item.DueAt)
+	switch meta.ItemStatus(item.Status) {
+	case meta.ItemStatusDone:
+		c.Raw("STATUS", "COMPLETED")
+		c.Time("COMPLETED", item.UpdatedAt)
+	case meta.ItemStatusMarked:
+		c.Raw("STATUS", "NEEDS-ACTION")
+		c.Raw("PRIORITY", "1")
+	default:
+		c.Raw("STATUS", "NEEDS-ACTION")
+	}
+	return c
+}
+
+// writeCommon 写入日程和待办共有的属性
+func writeCommon(c *ical.Component, tenantID string, item *dto.ItemDTO) {
+	uid := fmt.Sprintf("item-%d@peano", item.ItemID)
+	if tenantID != "" {
+		uid = fmt.Sprintf("item-%d.%s@peano", item.ItemID, tenantID)
+	}
+
+	tags := make([]string, 0, len(item.Tags))
+	for _, tag := range item.Tags {
+		tags = append(tags, tag.TagName)
+	}
+
+	c.Text("UID", uid).
+		Time("DTSTAMP", item.UpdatedAt).
+		Time("CREATED", item.CreatedAt).
+		Time("LAST-MODIFIED", item.UpdatedAt).
+		Text("SUMMARY", summary(item.Content)).
+		Text("DESCRIPTION", item.Content).
+		List("CATEGORIES", tags)
+}
+
+// summary 取内容的第一行作为标题，超长时截断
+func summary(content string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(content), "\n")
+	line = strings.TrimSpace(line)
+	if utf8.RuneCountInString(line) <= summaryMaxRunes {
+		return line
+	}
+	runes := []rune(line)
+	return string(runes[:summaryMaxRunes]) + "…"
+}
@@ -0,0 +1,94 @@
+package file
+
+import (
+	"context"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/readonly"
+	"backend/utils/scheduler"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// GCJobName 文件清理定时任务名称
+	GCJobName = "file_gc"
+	// gcDefaultSpec 文件清理默认每天 04:00 执行
+	gcDefaultSpec = "0 4 * * *"
+)
+
+// GCResult 文件清理结果
+type GCResult struct {
+	Scanned int `json:"scanned"` // 扫描的存储文件数
+	Deleted int `json:"deleted"` // 删除的孤立文件数
+	Failed  int `json:"failed"`  // 删除失败的文件数
+}
+
+// CollectGarbage 删除本地存储中没有数据库记录的孤立文件（如上传后写库失败、记录被彻底删除）
+// 只删除修改时间早于 grace 的文件，避免误删正在上传、尚未写入数据库的文件；只读模式下跳过
+func (l *FileLogic) CollectGarbage(ctx context.Context, grace time.Duration) (*GCResult, error) {
+	result := &GCResult{}
+	if readonly.Enabled() {
+		logs.CtxInfof(ctx, "只读模式，跳过文件清理: reason=%s", readonly.Reason())
+		return result, nil
+	}
+
+	// 先记录截止时间再查询数据库，之后上传的文件不会被当作孤立文件
+	before := time.Now().Add(-grace)
+	paths, err := l.fileRepo.ListStoragePaths(ctx, l.storage.GetType())
+	if err != nil {
+		return nil, err
+	}
+	referenced := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		referenced[path] = struct{}{}
+	}
+
+	err = l.storage.Walk(ctx, func(path string, modTime time.Time) error {
+		result.Scanned++
+		if _, ok := referenced[path]; ok || !modTime.Before(before) {
+			return nil
+		}
+		if err := l.storage.Delete(ctx, path); err != nil {
+			result.Failed++
+			logs.CtxWarnf(ctx, "删除孤立文件失败: path=%s, error=%s", path, err.Error())
+			return nil
+		}
+		result.Deleted++
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	logs.CtxInfof(ctx, "文件清理完成: scanned=%d, deleted=%d, failed=%d", result.Scanned, result.Deleted, result.Failed)
+	return result, nil
+}
+
+type FileGCJobParams struct {
+	fx.In
+
+	Scheduler *scheduler.Scheduler
+	FileLogic *FileLogic
+}
+
+// RegisterFileGCJob 注册文件清理定时任务（任务名 file_gc），默认每天 04:00 执行
+func RegisterFileGCJob(params FileGCJobParams) error {
+	grace, err := envx.GetDurationWithDefault(consts.FileGCGrace, 24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	return params.Scheduler.Register(scheduler.Job{
+		Name:        GCJobName,
+		Description: "删除本地存储中没有数据库记录的孤立文件",
+		Spec:        gcDefaultSpec,
+		Run: func(ctx context.Context) error {
+			_, err := params.FileLogic.CollectGarbage(ctx, grace)
+			return err
+		},
+	})
+}
@@ -9,7 +9,9 @@ import (
 	"mime"
 	"mime/multipart"
 	"path/filepath"
+	"time"
 
+	"backend/app/internal/logic/bizmetrics"
 	fileModel "backend/app/model/file"
 	"backend/app/types/consts"
 	"backend/app/types/dto"
@@ -18,6 +20,7 @@ import (
 	"backend/utils/errorx"
 	"backend/utils/lofile"
 	"backend/utils/logs"
+	"backend/utils/retry"
 
 	"go.uber.org/fx"
 )
@@ -27,6 +30,7 @@ type FileRepo interface {
 	GetFileByID(ctx context.Context, fileID uint) (*fileModel.File, error)
 	GetFileByHash(ctx context.Context, hash string) (*fileModel.File, error)
 	DeleteFile(ctx context.Context, fileID uint) error
+	ListStoragePaths(ctx context.Context, storageType string) ([]string, error)
 }
 
 type FileLogicParams struct {
@@ -61,6 +65,14 @@ func NewFileLogic(params FileLogicParams) *FileLogic {
 	}
 }
 
+// uploadRetryBackoff 上传到存储遇到可重试错误时的重试间隔
+var uploadRetryBackoff = retry.Backoff{
+	InitialInterval: 200 * time.Millisecond,
+	MaxInterval:     time.Second,
+	Multiplier:      2,
+	MaxElapsed:      3 * time.Second,
+}
+
 // UploadFile 上传文件
 func (l *FileLogic) UploadFile(ctx context.Context, fileHeader *multipart.FileHeader) (*dto.FileDTO, error) {
 	// 打开文件并读取内容用于计算哈希
@@ -84,17 +96,23 @@ func (l *FileLogic) UploadFile(ctx context.Context, fileHeader *multipart.FileHe
 	if err == nil && existingFile != nil {
 		// 文件已存在，返回已存在的文件信息
 		logs.Info("文件已存在，返回已存在的文件", "file_id", existingFile.ID, "hash", hashStr)
+		bizmetrics.FileUploaded(fileHeader.Size, true)
 		return l.buildFileDTO(ctx, existingFile)
 	}
 
 	// 获取文件MIME类型
 	mimeType := l.getMimeType(fileHeader)
 
-	// 使用已读取的文件内容创建新的 Reader 用于上传
-	fileReader := bytes.NewReader(fileContent)
-
-	// 上传文件到存储
-	storagePath, err := l.storage.Upload(ctx, fileReader, fileHeader.Filename, mimeType)
+	// 上传文件到存储，可重试的错误（如超时）短暂重试，每次使用已读取的文件内容创建新的 Reader
+	var storagePath string
+	err = retry.Do(ctx, uploadRetryBackoff, func(ctx context.Context, attempt int) error {
+		var err error
+		storagePath, err = l.storage.Upload(ctx, bytes.NewReader(fileContent), fileHeader.Filename, mimeType)
+		if err != nil && !errorx.IsRetryable(err) {
+			return retry.Permanent(err)
+		}
+		return err
+	})
 	if err != nil {
 		return nil, errorx.Wrap(err, fileErr.FileErrStorageError, errorx.K("reason", err.Error()))
 	}
@@ -118,9 +136,27 @@ func (l *FileLogic) UploadFile(ctx context.Context, fileHeader *multipart.FileHe
 	}
 
 	logs.Info("文件上传成功", "file_id", fileRecord.ID, "filename", fileHeader.Filename, "size", fileHeader.Size)
+	bizmetrics.FileUploaded(fileHeader.Size, false)
 	return l.buildFileDTO(ctx, fileRecord)
 }
 
+// OpenFile 打开已上传文件的内容，调用方负责关闭
+func (l *FileLogic) OpenFile(ctx context.Context, file *fileModel.File) (io.ReadCloser, error) {
+	if file.FileStorageType != l.storage.GetType() {
+		return nil, errorx.New(fileErr.FileErrStorageError, errorx.K("reason", fmt.Sprintf("不支持的存储类型: %s", file.FileStorageType)))
+	}
+	reader, err := l.storage.Open(ctx, file.FileStoragePath)
+	if err != nil {
+		return nil, errorx.Wrap(err, fileErr.FileErrStorageError, errorx.K("reason", err.Error()))
+	}
+	return reader, nil
+}
+
+// ReferencedPaths 返回内容中引用的已上传文件的存储路径
+func (l *FileLogic) ReferencedPaths(content string) []string {
+	return l.storage.PathsInText(content)
+}
+
 // calculateFileHash 计算文件内容的 SHA256 哈希值
 func (l *FileLogic) calculateFileHash(content []byte) string {
 	hash := sha256.Sum256(content)
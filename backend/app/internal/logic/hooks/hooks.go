@@ -0,0 +1,80 @@
+// Package hooks 业务逻辑层的扩展钩子
+// 部署时需要的定制行为（自动打标签、同步外部系统、自定义校验等）在启动时注册钩子实现，不需要修改 ItemLogic、UserLogic
+package hooks
+
+import (
+	"errors"
+	"time"
+
+	"backend/app/types/dto"
+	systemError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/errorx"
+	"backend/utils/hook"
+)
+
+// ItemCreate 创建项目的参数，BeforeItemCreate 钩子可以修改
+type ItemCreate struct {
+	Content string
+	Status  meta.ItemStatus
+	DueAt   *time.Time // 没有截止时间时为 nil
+	TagIDs  []uint
+}
+
+// ItemUpdate 更新项目的参数，BeforeItemUpdate 钩子可以修改，字段为 nil 表示不修改
+type ItemUpdate struct {
+	ItemID  uint
+	Current *dto.ItemDTO // 更新前的项目（不含标签）
+	Content *string
+	Status  *meta.ItemStatus
+	DueAt   *time.Time // 零值表示清除截止时间
+	TagIDs  []uint
+}
+
+// ItemUpdated 更新后的项目
+type ItemUpdated struct {
+	Item           *dto.ItemDTO
+	PreviousStatus string // 更新前的状态
+}
+
+// ItemDelete 删除的项目（不含标签）
+type ItemDelete struct {
+	Item *dto.ItemDTO
+}
+
+// UserLogin 登录成功的用户
+type UserLogin struct {
+	User *dto.UserDTO
+}
+
+// Registry 业务逻辑钩子注册表
+// Before 钩子在写入前调用，可以修改参数，返回错误时拒绝操作（试运行时也会调用）；
+// After 钩子在写入后调用，错误只记录日志（试运行时不调用）
+type Registry struct {
+	BeforeItemCreate hook.Hook[*ItemCreate]
+	AfterItemCreate  hook.Hook[*dto.ItemDTO]
+	BeforeItemUpdate hook.Hook[*ItemUpdate]
+	AfterItemUpdate  hook.Hook[*ItemUpdated]
+	BeforeItemDelete hook.Hook[*ItemDelete]
+	AfterItemDelete  hook.Hook[*ItemDelete]
+	AfterUserLogin   hook.Hook[*UserLogin]
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Rejected 将 Before 钩子返回的错误转换为业务错误
+// 钩子返回带错误码的错误（errorx.New）时原样返回，否则使用 SystemErrHookRejected
+func Rejected(err error) error {
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr
+	}
+	reason := err.Error()
+	var hookErr *hook.Error
+	if errors.As(err, &hookErr) {
+		reason = hookErr.Err.Error()
+	}
+	return errorx.Wrap(err, systemError.SystemErrHookRejected, errorx.K("reason", reason))
+}
@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"context"
+	"errors"
+
+	"backend/app/types/dto"
+	importError "backend/app/types/errorn"
+	tagError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/errorx"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/retry"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// RunJobType 执行导入的后台任务类型
+	RunJobType = "import.run"
+	// reportInterval 每创建多少个项目推送一次进度
+	reportInterval = 20
+)
+
+// runPayload 导入任务参数，包含解析后的导入计划，执行时不再读取上传的文件
+type runPayload struct {
+	ImportID string     `json:"import_id"`
+	Locale   string     `json:"locale"`
+	Format   string     `json:"format"`
+	Items    []planItem `json:"items"`
+	Tags     []planTag  `json:"tags"`
+}
+
+type QueueHandlersParams struct {
+	fx.In
+
+	Queue       *jobqueue.Queue
+	ImportLogic *ImportLogic
+}
+
+// RegisterQueueHandlers 注册导入相关的后台任务处理函数
+func RegisterQueueHandlers(params QueueHandlersParams) error {
+	return params.Queue.Handle(RunJobType, params.ImportLogic.Run)
+}
+
+// Run 执行导入任务：创建缺少的标签，再按顺序创建项目
+// 导入不是幂等的，失败时返回不可重试的错误，已创建的项目保留
+func (l *ImportLogic) Run(ctx context.Context, job *jobqueue.Job) error {
+	var payload runPayload
+	if err := job.Decode(&payload); err != nil {
+		return retry.Permanent(err)
+	}
+
+	// 项目和标签的创建人为发起导入的用户，取自入队时服务端记录的创建人，不信任任务参数
+	if job.CreatedBy == 0 {
+		return retry.Permanent(errorx.New(importError.ImportErrParamRequired, errorx.K("param", "created_by")))
+	}
+	ctx = context.WithValue(ctx, meta.ContextKeyUserID, job.CreatedBy)
+	ctx = errorx.WithLocale(ctx, payload.Locale)
+
+	progress := dto.ImportProgressDTO{Total: len(payload.Items)}
+	report := func(stage string) {
+		progress.Stage = stage
+		l.publish(payload.ImportID, progress)
+	}
+
+	if err := l.execute(ctx, &payload, &progress, report); err != nil {
+		logs.CtxErrorf(ctx, "导入失败: job_id=%d, format=%s, items=%d, error=%s", job.ID, payload.Format, progress.Items, err.Error())
+		progress.Stage = StageFailed
+		progress.Error = errorx.LocalizedMsg(err, payload.Locale)
+		l.complete(payload.ImportID, progress)
+		return retry.Permanent(err)
+	}
+
+	logs.CtxInfof(ctx, "导入完成: job_id=%d, format=%s, items=%d, tags=%d", job.ID, payload.Format, progress.Items, progress.Tags)
+	progress.Stage = StageCompleted
+	l.complete(payload.ImportID, progress)
+	return nil
+}
+
+// execute 创建标签和项目
+func (l *ImportLogic) execute(ctx context.Context, payload *runPayload, progress *dto.ImportProgressDTO, report func(stage string)) error {
+	tagIDs, err := l.ensureTags(ctx, payload.Tags, progress)
+	if err != nil {
+		return err
+	}
+	report(StageTags)
+
+	for _, item := range payload.Items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ids := make([]uint, 0, len(item.Tags))
+		for _, value := range item.Tags {
+			if id, ok := tagIDs[value]; ok {
+				ids = append(ids, id)
+			}
+		}
+		status := meta.ItemStatus(item.Status)
+		if _, err := l.itemCreator.CreateItem(ctx, item.Content, &status, nil, ids); err != nil {
+			logs.CtxWarnf(ctx, "导入项目失败: source=%s", item.Source)
+			return err
+		}
+
+		progress.Items++
+		if progress.Items%reportInterval == 0 {
+			report(StageItems)
+		}
+	}
+	report(StageItems)
+	return nil
+}
+
+// ensureTags 返回导入用到的标签值到标签ID的映射，不存在的标签先创建
+func (l *ImportLogic) ensureTags(ctx context.Context, tags []planTag, progress *dto.ImportProgressDTO) (map[string]uint, error) {
+	values := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, tag.Value)
+	}
+	ids, err := l.tagIDs(ctx, values)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		if _, ok := ids[tag.Value]; ok {
+			continue
+		}
+		created, err := l.tagCreator.CreateTag(ctx, tag.Name, tag.Value, nil, nil)
+		if err == nil {
+			ids[tag.Value] = created.TagID
+			progress.Tags++
+			continue
+		}
+
+		// 其他请求同时创建了同一个标签
+		var statusErr errorx.StatusError
+		if !errors.As(err, &statusErr) || statusErr.Code() != tagError.TagErrAlreadyExists {
+			return nil, err
+		}
+		existing, err := l.tagIDs(ctx, []string{tag.Value})
+		if err != nil {
+			return nil, err
+		}
+		if id, ok := existing[tag.Value]; ok {
+			ids[tag.Value] = id
+		}
+	}
+	return ids, nil
+}
+
+// tagIDs 查询已存在的标签
+func (l *ImportLogic) tagIDs(ctx context.Context, values []string) (map[string]uint, error) {
+	tags, err := l.tagRepo.GetTagsByValues(ctx, values)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询标签失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	ids := make(map[string]uint, len(values))
+	for _, tag := range tags {
+		ids[tag.TagValue] = tag.ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,345 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/app/internal/logic/bizmetrics"
+	tagModel "backend/app/model/tag"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	authError "backend/app/types/errorn"
+	importError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/importer"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/rand"
+	"backend/utils/safego"
+	"backend/utils/sse"
+
+	"go.uber.org/fx"
+)
+
+// 导入阶段
+const (
+	StageQueued    = "queued"
+	StageTags      = "tags"
+	StageItems     = "items"
+	StageCompleted = "completed"
+	StageFailed    = "failed"
+)
+
+type TagRepo interface {
+	GetTagsByValues(ctx context.Context, tagValues []string) ([]*tagModel.Tag, error)
+}
+
+// ItemCreator 创建项目，与创建项目接口使用相同的业务逻辑（记录变更、发布事件、更新索引）
+type ItemCreator interface {
+	CreateItem(ctx context.Context, content string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint) (*dto.ItemDTO, error)
+}
+
+// TagCreator 创建标签
+type TagCreator interface {
+	CreateTag(ctx context.Context, tagName string, tagValue string, icon *string, color *string) (*dto.TagDTO, error)
+}
+
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload any, opts ...jobqueue.EnqueueOption) (*jobqueue.Job, error)
+}
+
+type SSEManager interface {
+	ExecuteWithSSE(ctx context.Context, resumeKey string, lastEventID uint64, subscriberID string, asyncFunc sse.AsyncTaskFunc, asyncTimeout time.Duration, opts ...sse.TaskOption) (<-chan sse.Event, string, error)
+}
+
+type ImportLogicParams struct {
+	fx.In
+
+	TagRepo     TagRepo
+	ItemCreator ItemCreator
+	TagCreator  TagCreator
+	JobQueue    JobQueue
+	SSEManager  SSEManager
+}
+
+type ImportLogic struct {
+	tagRepo     TagRepo
+	itemCreator ItemCreator
+	tagCreator  TagCreator
+	jobQueue    JobQueue
+	manager     SSEManager
+	maxSize     int64
+	maxItems    int
+	timeout     time.Duration
+
+	mu       sync.Mutex
+	watchers map[string]*watcher // 导入ID -> 等待导入进度的请求
+	seq      uint64
+}
+
+// watcher 在发起导入的实例上接收后台任务推送的进度
+type watcher struct {
+	progress chan *dto.ImportProgressDTO
+	done     chan struct{}
+	final    *dto.ImportProgressDTO // done 关闭前写入
+}
+
+func NewImportLogic(params ImportLogicParams) (*ImportLogic, error) {
+	maxSize, err := envx.GetIntWithDefaultAndMin(consts.ImportMaxSize, 10, 1)
+	if err != nil {
+		return nil, err
+	}
+	maxItems, err := envx.GetIntWithDefaultAndMin(consts.ImportMaxItems, 5000, 1)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := envx.GetDurationWithDefault(consts.ImportTimeout, 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportLogic{
+		tagRepo:     params.TagRepo,
+		itemCreator: params.ItemCreator,
+		tagCreator:  params.TagCreator,
+		jobQueue:    params.JobQueue,
+		manager:     params.SSEManager,
+		maxSize:     int64(maxSize) << 20,
+		maxItems:    maxItems,
+		timeout:     timeout,
+		watchers:    make(map[string]*watcher),
+	}, nil
+}
+
+// Formats 返回支持的导入格式
+func (l *ImportLogic) Formats() []string {
+	return importer.Formats()
+}
+
+// Preview 解析导入文件，返回将创建的项目和标签，不写入数据
+func (l *ImportLogic) Preview(ctx context.Context, format string, file *multipart.FileHeader) (*dto.ImportPreviewDTO, error) {
+	p, err := l.load(ctx, format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	tags, err := l.tagRepo.GetTagsByValues(ctx, p.tagValues())
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询标签失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	for _, tag := range tags {
+		existing[tag.TagValue] = true
+	}
+
+	logs.CtxInfof(ctx, "导入预览: format=%s, filename=%s, items=%d, tags=%d, skipped=%d", format, file.Filename, len(p.items), len(p.tags), len(p.skipped))
+	return p.preview(format, existing), nil
+}
+
+// Import 解析导入文件并通过后台任务队列执行导入，返回导入进度，ctx 结束时通道关闭
+// 导入任务只执行一次，失败时不重试，已创建的项目保留；客户端断开不影响导入
+func (l *ImportLogic) Import(ctx context.Context, format string, file *multipart.FileHeader) (<-chan *dto.ImportProgressDTO, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return nil, errorx.New(authError.AuthErrTokenRequired)
+	}
+
+	p, err := l.load(ctx, format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先登记再入队，任务可能在入队后立即执行完成
+	importID := rand.MustGenerateUID()
+	w := l.watch(importID)
+	payload := &runPayload{
+		ImportID: importID,
+		Locale:   errorx.LocaleFromContext(ctx),
+		Format:   format,
+		Items:    p.items,
+		Tags:     p.tags,
+	}
+	job, err := l.jobQueue.Enqueue(ctx, RunJobType, payload, jobqueue.MaxAttempts(1))
+	if err != nil {
+		l.unwatch(importID)
+		logs.CtxErrorf(ctx, "添加导入任务失败: format=%s, error=%s", format, err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+	logs.CtxInfof(ctx, "已添加导入任务: job_id=%d, format=%s, filename=%s, items=%d, tags=%d", job.ID, format, file.Filename, len(p.items), len(p.tags))
+
+	total := len(p.items)
+	run := func(asyncCtx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		defer l.unwatch(importID)
+		defer bizmetrics.SSETaskStarted(bizmetrics.SSEKindImport)()
+		return l.forward(asyncCtx, w, taskID, job.ID, total, updateProgress)
+	}
+
+	l.mu.Lock()
+	l.seq++
+	subscriberID := fmt.Sprintf("import_%d", l.seq)
+	l.mu.Unlock()
+
+	// 导入本身由任务队列限流，进度推送任务不占用 SSE 并发名额
+	ch, _, err := l.manager.ExecuteWithSSE(ctx, "", 0, subscriberID, run, l.timeout, sse.WithTaskKind(bizmetrics.SSEKindImport), sse.WithoutConcurrencyLimit())
+	if err != nil {
+		// 任务已入队，仍会执行，只是无法推送进度
+		l.unwatch(importID)
+		logs.CtxErrorf(ctx, "启动导入进度推送失败: job_id=%d, error=%s", job.ID, err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrStartFailed, errorx.K("reason", err.Error()))
+	}
+
+	out := make(chan *dto.ImportProgressDTO, 16)
+	safego.Go(ctx, func() {
+		defer close(out)
+		for event := range ch {
+			progress, ok := event.Data.(*dto.ImportProgressDTO)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- progress:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return out, nil
+}
+
+// forward 把后台任务推送的进度转发给 SSE 任务，导入结束或等待超时后返回
+// 任务由其他实例执行时收不到进度，等待 IMPORT_TIMEOUT 后结束
+func (l *ImportLogic) forward(ctx context.Context, w *watcher, taskID string, jobID uint, total int, updateProgress func(data interface{}) error) error {
+	send := func(progress *dto.ImportProgressDTO) {
+		progress.TaskID = taskID
+		progress.JobID = jobID
+		if err := updateProgress(progress); err != nil {
+			logs.CtxWarnf(ctx, "推送导入进度失败: task_id=%s, error=%s", taskID, err.Error())
+		}
+	}
+
+	send(&dto.ImportProgressDTO{Stage: StageQueued, Total: total})
+	for {
+		select {
+		case progress := <-w.progress:
+			send(progress)
+		case <-w.done:
+			// 先转发通道中剩余的进度
+		drain:
+			for {
+				select {
+				case progress := <-w.progress:
+					send(progress)
+				default:
+					break drain
+				}
+			}
+			send(w.final)
+			if w.final.Stage == StageFailed {
+				return errors.New(w.final.Error)
+			}
+			return nil
+		case <-ctx.Done():
+			logs.CtxWarnf(ctx, "等待导入任务超时，停止推送进度: job_id=%d, task_id=%s", jobID, taskID)
+			return ctx.Err()
+		}
+	}
+}
+
+// watch 登记等待导入进度
+func (l *ImportLogic) watch(importID string) *watcher {
+	w := &watcher{
+		progress: make(chan *dto.ImportProgressDTO, 64),
+		done:     make(chan struct{}),
+	}
+	l.mu.Lock()
+	l.watchers[importID] = w
+	l.mu.Unlock()
+	return w
+}
+
+// unwatch 取消等待
+func (l *ImportLogic) unwatch(importID string) {
+	l.mu.Lock()
+	delete(l.watchers, importID)
+	l.mu.Unlock()
+}
+
+// publish 推送导入进度，没有等待的请求（任务在其他实例上发起）或通道已满时丢弃
+func (l *ImportLogic) publish(importID string, progress dto.ImportProgressDTO) {
+	l.mu.Lock()
+	w := l.watchers[importID]
+	l.mu.Unlock()
+	if w == nil {
+		return
+	}
+	select {
+	case w.progress <- &progress:
+	default:
+	}
+}
+
+// complete 推送最终进度
+func (l *ImportLogic) complete(importID string, progress dto.ImportProgressDTO) {
+	l.mu.Lock()
+	w := l.watchers[importID]
+	delete(l.watchers, importID)
+	l.mu.Unlock()
+	if w == nil {
+		return
+	}
+	w.final = &progress
+	close(w.done)
+}
+
+// load 读取并解析导入文件，生成导入计划
+func (l *ImportLogic) load(ctx context.Context, format string, file *multipart.FileHeader) (*plan, error) {
+	parser, ok := importer.Lookup(format)
+	if !ok {
+		logs.CtxWarnf(ctx, "不支持的导入格式: format=%s", format)
+		return nil, errorx.New(importError.ImportErrUnsupportedFormat, errorx.K("format", format), errorx.K("formats", strings.Join(importer.Formats(), ", ")))
+	}
+
+	if file.Size > l.maxSize {
+		logs.CtxWarnf(ctx, "导入文件过大: filename=%s, size=%d", file.Filename, file.Size)
+		return nil, errorx.New(importError.ImportErrFileTooLarge, errorx.Kf("max_size", "%dMB", l.maxSize>>20))
+	}
+	f, err := file.Open()
+	if err != nil {
+		logs.CtxErrorf(ctx, "打开导入文件失败: filename=%s, error=%s", file.Filename, err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrParseFailed, errorx.K("reason", err.Error()))
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, l.maxSize+1))
+	if err != nil {
+		logs.CtxErrorf(ctx, "读取导入文件失败: filename=%s, error=%s", file.Filename, err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrParseFailed, errorx.K("reason", err.Error()))
+	}
+	if int64(len(data)) > l.maxSize {
+		return nil, errorx.New(importError.ImportErrFileTooLarge, errorx.Kf("max_size", "%dMB", l.maxSize>>20))
+	}
+
+	result, err := parser.Parse(file.Filename, data)
+	if err != nil {
+		logs.CtxWarnf(ctx, "解析导入文件失败: format=%s, filename=%s, error=%s", format, file.Filename, err.Error())
+		return nil, errorx.Wrap(err, importError.ImportErrParseFailed, errorx.K("reason", err.Error()))
+	}
+
+	p := newPlan(result)
+	if len(p.items) == 0 {
+		return nil, errorx.New(importError.ImportErrNoRecords)
+	}
+	if len(p.items) > l.maxItems {
+		return nil, errorx.New(importError.ImportErrTooManyItems, errorx.Kf("count", "%d", len(p.items)), errorx.Kf("max", "%d", l.maxItems))
+	}
+	return p, nil
+}
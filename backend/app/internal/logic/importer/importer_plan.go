@@ -0,0 +1,176 @@
+package importer
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"backend/app/types/dto"
+	"backend/app/types/meta"
+	"backend/utils/importer"
+)
+
+const (
+	// 项目内容长度限制，与创建项目接口的参数验证一致
+	minContentLength = 3
+	maxContentLength = 1000
+	// maxItemTags 每个项目最多的标签数，与创建项目接口的参数验证一致
+	maxItemTags = 10
+	// 标签名和标签值的最大长度，与 tag 表列宽一致
+	maxTagNameLength  = 12
+	maxTagValueLength = 32
+
+	// maxPreviewSkipped 预览中最多返回的跳过记录数
+	maxPreviewSkipped = 100
+	// maxPreviewSamples 预览中返回的项目数
+	maxPreviewSamples = 20
+)
+
+// planItem 将创建的项目
+type planItem struct {
+	Source  string   `json:"source"`
+	Content string   `json:"content"`
+	Status  string   `json:"status"`
+	Tags    []string `json:"tags"` // 标签值
+}
+
+// planTag 导入用到的标签
+type planTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// plan 根据解析结果生成的导入计划，预览和执行使用同一份计划
+type plan struct {
+	items     []planItem
+	tags      []planTag // 按首次出现的顺序
+	skipped   []importer.Skip
+	truncated int
+}
+
+// newPlan 按项目和标签的限制整理解析结果
+// 内容过短的记录跳过，过长的截断；标签按标签值去重，每个项目最多保留 maxItemTags 个
+func newPlan(result *importer.Result) *plan {
+	p := &plan{skipped: result.Skipped}
+	seen := make(map[string]bool)
+
+	for _, record := range result.Records {
+		content := record.Content
+		if utf8.RuneCountInString(content) < minContentLength {
+			p.skipped = append(p.skipped, importer.Skip{Source: record.Source, Reason: "内容少于 3 个字"})
+			continue
+		}
+		if utf8.RuneCountInString(content) > maxContentLength {
+			content = truncate(content, maxContentLength)
+			p.truncated++
+		}
+
+		item := planItem{
+			Source:  record.Source,
+			Content: content,
+			Status:  string(recordStatus(record)),
+			Tags:    make([]string, 0, len(record.Tags)),
+		}
+		itemTags := make(map[string]bool)
+		for _, name := range record.Tags {
+			tag, ok := normalizeTag(name)
+			if !ok || itemTags[tag.Value] || len(item.Tags) >= maxItemTags {
+				continue
+			}
+			itemTags[tag.Value] = true
+			item.Tags = append(item.Tags, tag.Value)
+			if !seen[tag.Value] {
+				seen[tag.Value] = true
+				p.tags = append(p.tags, tag)
+			}
+		}
+		p.items = append(p.items, item)
+	}
+	return p
+}
+
+// preview 生成预览，existing 为已存在的标签值
+func (p *plan) preview(format string, existing map[string]bool) *dto.ImportPreviewDTO {
+	preview := &dto.ImportPreviewDTO{
+		Format:       format,
+		Items:        len(p.items),
+		Truncated:    p.truncated,
+		NewTags:      make([]string, 0),
+		ExistingTags: make([]string, 0),
+		SkippedTotal: len(p.skipped),
+		Skipped:      make([]dto.ImportSkipDTO, 0, min(len(p.skipped), maxPreviewSkipped)),
+		Samples:      make([]dto.ImportItemDTO, 0, min(len(p.items), maxPreviewSamples)),
+	}
+	for _, item := range p.items {
+		if item.Status == string(meta.ItemStatusDone) {
+			preview.Done++
+		}
+		if len(preview.Samples) < maxPreviewSamples {
+			preview.Samples = append(preview.Samples, dto.ImportItemDTO{
+				Source:  item.Source,
+				Content: item.Content,
+				Status:  item.Status,
+				Tags:    item.Tags,
+			})
+		}
+	}
+	for _, tag := range p.tags {
+		if existing[tag.Value] {
+			preview.ExistingTags = append(preview.ExistingTags, tag.Value)
+		} else {
+			preview.NewTags = append(preview.NewTags, tag.Value)
+		}
+	}
+	for _, skip := range p.skipped {
+		if len(preview.Skipped) >= maxPreviewSkipped {
+			break
+		}
+		preview.Skipped = append(preview.Skipped, dto.ImportSkipDTO{Source: skip.Source, Reason: skip.Reason})
+	}
+	return preview
+}
+
+// tagValues 导入用到的所有标签值
+func (p *plan) tagValues() []string {
+	values := make([]string, 0, len(p.tags))
+	for _, tag := range p.tags {
+		values = append(values, tag.Value)
+	}
+	return values
+}
+
+// recordStatus 记录对应的项目状态，已完成优先于重要
+func recordStatus(record importer.Record) meta.ItemStatus {
+	switch {
+	case record.Done:
+		return meta.ItemStatusDone
+	case record.Marked:
+		return meta.ItemStatusMarked
+	default:
+		return meta.ItemStatusNormal
+	}
+}
+
+// normalizeTag 由标签名生成标签，标签值为小写、空白替换为 "-" 的标签名
+func normalizeTag(name string) (planTag, bool) {
+	name = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(name), "#@"))
+	if name == "" {
+		return planTag{}, false
+	}
+	value := strings.Join(strings.Fields(strings.ToLower(name)), "-")
+	return planTag{
+		Name:  strings.TrimSpace(truncate(name, maxTagNameLength)),
+		Value: truncate(value, maxTagValueLength),
+	}, true
+}
+
+// truncate 截取前 n 个字符
+func truncate(s string, n int) string {
+	count := 0
+	for i := range s {
+		if count == n {
+			return s[:i]
+		}
+		count++
+	}
+	return s
+}
@@ -0,0 +1,27 @@
+package item
+
+import (
+	"context"
+
+	"backend/utils/jobqueue"
+
+	"go.uber.org/fx"
+)
+
+// ReindexJobType 重建项目搜索索引的后台任务类型
+const ReindexJobType = "item.reindex"
+
+type QueueHandlersParams struct {
+	fx.In
+
+	Queue     *jobqueue.Queue
+	ItemLogic *ItemLogic
+}
+
+// RegisterQueueHandlers 注册项目相关的后台任务处理函数
+func RegisterQueueHandlers(params QueueHandlersParams) error {
+	return params.Queue.Handle(ReindexJobType, func(ctx context.Context, job *jobqueue.Job) error {
+		_, err := params.ItemLogic.ReindexItems(ctx)
+		return err
+	})
+}
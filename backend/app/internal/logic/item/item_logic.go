@@ -5,6 +5,11 @@ import (
 	"errors"
 	"time"
 
+	"backend/app/internal/logic/bizmetrics"
+	"backend/app/internal/logic/hooks"
+	"backend/app/internal/repo/base"
+	auditModel "backend/app/model/audit"
+	changeModel "backend/app/model/change"
 	itemModel "backend/app/model/item"
 	tagModel "backend/app/model/tag"
 	"backend/app/types/dto"
@@ -13,6 +18,7 @@ import (
 	"backend/app/types/meta"
 	"backend/utils/errorx"
 	"backend/utils/logs"
+	"backend/utils/retry"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
@@ -20,51 +26,103 @@ import (
 
 type ItemRepo interface {
 	CreateItem(ctx context.Context, item *itemModel.Item) error
-	UpdateItem(ctx context.Context, itemID uint, updates map[string]interface{}) error
+	UpdateItem(ctx context.Context, itemID uint, version *uint, updates map[string]interface{}, tagIDs []uint) error
 	DeleteItem(ctx context.Context, itemID uint) error
 	GetItemByID(ctx context.Context, itemID uint) (*itemModel.Item, error)
 	GetItemListWithTags(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
 	GetItemWithTags(ctx context.Context, itemID uint) (*itemModel.Item, []*tagModel.Tag, error)
 	SetItemTags(ctx context.Context, itemID uint, tagIDs []uint) error
 	GetDailyItemCount(ctx context.Context, dateStart time.Time, dateEnd time.Time) ([]dto.DailyItemCountDTO, error)
+	SearchItemListWithTags(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error)
+	GetItemListWithTagsByIDs(ctx context.Context, itemIDs []uint) ([]dto.ItemDTO, error)
+}
+
+type ItemSearchRepo interface {
+	Enabled() bool
+	IndexItem(ctx context.Context, item *dto.ItemDTO) error
+	DeleteItem(ctx context.Context, itemID uint) error
+	SearchItemIDs(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]uint, int64, error)
+	Reindex(ctx context.Context) (int, error)
 }
 
 type ItemTagRepo interface {
 	GetTagByID(ctx context.Context, tagID uint) (*tagModel.Tag, error)
 }
 
+// EventPublisher 发布领域事件（webhook）
+type EventPublisher interface {
+	Publish(ctx context.Context, event meta.WebhookEvent, data any)
+}
+
+// ChangeRecorder 记录数据变更，用于客户端实时同步
+type ChangeRecorder interface {
+	Record(ctx context.Context, entity string, action string, entityID uint, data any)
+}
+
+// AuditRecorder 记录审计日志
+type AuditRecorder interface {
+	Audit(ctx context.Context, entity string, action string, entityID uint, detail any)
+}
+
 type ItemLogicParams struct {
 	fx.In
 
-	ItemRepo ItemRepo
-	TagRepo  ItemTagRepo
+	ItemRepo   ItemRepo
+	TagRepo    ItemTagRepo
+	SearchRepo ItemSearchRepo
+	Publisher  EventPublisher
+	Changes    ChangeRecorder
+	Audit      AuditRecorder
+	Hooks      *hooks.Registry
 }
 
 type ItemLogic struct {
-	itemRepo ItemRepo
-	tagRepo  ItemTagRepo
+	itemRepo   ItemRepo
+	tagRepo    ItemTagRepo
+	searchRepo ItemSearchRepo
+	publisher  EventPublisher
+	changes    ChangeRecorder
+	audit      AuditRecorder
+	hooks      *hooks.Registry
 }
 
 func NewItemLogic(params ItemLogicParams) *ItemLogic {
 	return &ItemLogic{
-		itemRepo: params.ItemRepo,
-		tagRepo:  params.TagRepo,
+		itemRepo:   params.ItemRepo,
+		tagRepo:    params.TagRepo,
+		searchRepo: params.SearchRepo,
+		publisher:  params.Publisher,
+		changes:    params.Changes,
+		audit:      params.Audit,
+		hooks:      params.Hooks,
 	}
 }
 
 // CreateItem 创建项目
-func (l *ItemLogic) CreateItem(ctx context.Context, content string, status *meta.ItemStatus, tagIDs []uint) (*dto.ItemDTO, error) {
+// dueAt 为截止时间，没有时传 nil
+func (l *ItemLogic) CreateItem(ctx context.Context, content string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint) (*dto.ItemDTO, error) {
 	// 设置默认状态
 	itemStatus := string(meta.ItemStatusNormal)
 	if status != nil {
 		itemStatus = string(*status)
 	}
 
+	// 创建前钩子，可以修改参数或拒绝创建
+	input := &hooks.ItemCreate{Content: content, Status: meta.ItemStatus(itemStatus), DueAt: dueAt, TagIDs: tagIDs}
+	if err := l.hooks.BeforeItemCreate.Run(ctx, input); err != nil {
+		logs.CtxWarnf(ctx, "创建项目被钩子拒绝: error=%s", err.Error())
+		return nil, hooks.Rejected(err)
+	}
+	content, itemStatus, dueAt, tagIDs = input.Content, string(input.Status), input.DueAt, input.TagIDs
+
 	// 创建项目
 	item := &itemModel.Item{
 		Content: content,
 		Status:  itemStatus,
 	}
+	if dueAt != nil && !dueAt.IsZero() {
+		item.DueAt = dueAt
+	}
 
 	if err := l.itemRepo.CreateItem(ctx, item); err != nil {
 		logs.CtxErrorf(ctx, "创建项目失败: error=%s", err.Error())
@@ -111,20 +169,42 @@ func (l *ItemLogic) CreateItem(ctx context.Context, content string, status *meta
 		})
 	}
 
-	return &dto.ItemDTO{
+	itemDTO := &dto.ItemDTO{
 		ItemID:    itemModel.ID,
 		CreatedAt: itemModel.CreatedAt,
 		UpdatedAt: itemModel.UpdatedAt,
 		Content:   itemModel.Content,
 		Status:    itemModel.Status,
+		DueAt:     itemModel.DueAt,
+		Version:   itemModel.Version,
 		Tags:      tagDTOs,
-	}, nil
+	}
+
+	// 试运行不同步搜索索引、不发布事件
+	if meta.IsDryRun(ctx) {
+		return itemDTO, nil
+	}
+
+	// 同步搜索索引
+	l.syncItemIndex(ctx, itemDTO)
+
+	l.publisher.Publish(ctx, meta.WebhookEventItemCreated, itemDTO)
+	bizmetrics.ItemCreated(itemDTO.Status)
+	if itemDTO.Status == string(meta.ItemStatusDone) {
+		bizmetrics.ItemCompleted()
+	}
+	l.changes.Record(ctx, changeModel.EntityItem, changeModel.ActionCreated, itemDTO.ItemID, itemDTO)
+	l.hooks.AfterItemCreate.Notify(ctx, itemDTO)
+
+	return itemDTO, nil
 }
 
 // UpdateItem 更新项目
-func (l *ItemLogic) UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, tagIDs []uint) (*dto.ItemDTO, error) {
+// dueAt 不为空时修改截止时间，为零值时清除截止时间
+// version 不为空时启用乐观锁，版本号不一致返回 ItemErrVersionConflict
+func (l *ItemLogic) UpdateItem(ctx context.Context, itemID uint, content *string, status *meta.ItemStatus, dueAt *time.Time, tagIDs []uint, version *uint) (*dto.ItemDTO, error) {
 	// 检查项目是否存在
-	_, err := l.itemRepo.GetItemByID(ctx, itemID)
+	existing, err := l.itemRepo.GetItemByID(ctx, itemID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logs.CtxWarnf(ctx, "项目不存在: item_id=%d", itemID)
@@ -134,6 +214,14 @@ func (l *ItemLogic) UpdateItem(ctx context.Context, itemID uint, content *string
 		return nil, errorx.Wrap(err, itemError.ItemErrUpdateFailed, errorx.K("reason", err.Error()))
 	}
 
+	// 更新前钩子，可以修改参数或拒绝更新
+	input := &hooks.ItemUpdate{ItemID: itemID, Current: toItemDTO(existing), Content: content, Status: status, DueAt: dueAt, TagIDs: tagIDs}
+	if err := l.hooks.BeforeItemUpdate.Run(ctx, input); err != nil {
+		logs.CtxWarnf(ctx, "更新项目被钩子拒绝: item_id=%d, error=%s", itemID, err.Error())
+		return nil, hooks.Rejected(err)
+	}
+	content, status, dueAt, tagIDs = input.Content, input.Status, input.DueAt, input.TagIDs
+
 	// 构建更新字段
 	updates := make(map[string]interface{})
 	if content != nil {
@@ -142,32 +230,35 @@ func (l *ItemLogic) UpdateItem(ctx context.Context, itemID uint, content *string
 	if status != nil {
 		updates["status"] = string(*status)
 	}
-
-	// 更新项目
-	if len(updates) > 0 {
-		if err := l.itemRepo.UpdateItem(ctx, itemID, updates); err != nil {
-			logs.CtxErrorf(ctx, "更新项目失败: item_id=%d, error=%s", itemID, err.Error())
-			return nil, errorx.Wrap(err, itemError.ItemErrUpdateFailed, errorx.K("reason", err.Error()))
+	if dueAt != nil {
+		if dueAt.IsZero() {
+			updates["due_at"] = nil
+		} else {
+			updates["due_at"] = *dueAt
 		}
 	}
 
-	// 更新标签
-	if tagIDs != nil {
-		// 验证标签是否存在
-		for _, tagID := range tagIDs {
-			_, err := l.tagRepo.GetTagByID(ctx, tagID)
-			if err != nil {
-				if errors.Is(err, gorm.ErrRecordNotFound) {
-					logs.CtxWarnf(ctx, "标签不存在: tag_id=%d", tagID)
-					return nil, errorx.New(tagError.TagErrNotFound, errorx.Kf("tag_id", "%d", tagID))
-				}
-				logs.CtxErrorf(ctx, "查询标签失败: tag_id=%d, error=%s", tagID, err.Error())
-				return nil, errorx.Wrap(err, itemError.ItemErrUpdateFailed, errorx.K("reason", err.Error()))
+	// 写入前验证标签是否存在
+	for _, tagID := range tagIDs {
+		_, err := l.tagRepo.GetTagByID(ctx, tagID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				logs.CtxWarnf(ctx, "标签不存在: tag_id=%d", tagID)
+				return nil, errorx.New(tagError.TagErrNotFound, errorx.Kf("tag_id", "%d", tagID))
 			}
+			logs.CtxErrorf(ctx, "查询标签失败: tag_id=%d, error=%s", tagID, err.Error())
+			return nil, errorx.Wrap(err, itemError.ItemErrUpdateFailed, errorx.K("reason", err.Error()))
 		}
+	}
 
-		if err := l.itemRepo.SetItemTags(ctx, itemID, tagIDs); err != nil {
-			logs.CtxErrorf(ctx, "设置项目标签失败: item_id=%d, error=%s", itemID, err.Error())
+	// 在同一事务中更新项目字段和标签，版本号只在两者都成功时递增
+	if version != nil || len(updates) > 0 || tagIDs != nil {
+		if err := l.itemRepo.UpdateItem(ctx, itemID, version, updates, tagIDs); err != nil {
+			if errors.Is(err, base.ErrVersionConflict) {
+				logs.CtxWarnf(ctx, "项目版本冲突: item_id=%d, version=%d", itemID, *version)
+				return nil, errorx.New(itemError.ItemErrVersionConflict, errorx.Kf("item_id", "%d", itemID))
+			}
+			logs.CtxErrorf(ctx, "更新项目失败: item_id=%d, error=%s", itemID, err.Error())
 			return nil, errorx.Wrap(err, itemError.ItemErrUpdateFailed, errorx.K("reason", err.Error()))
 		}
 	}
@@ -191,20 +282,40 @@ func (l *ItemLogic) UpdateItem(ctx context.Context, itemID uint, content *string
 		})
 	}
 
-	return &dto.ItemDTO{
+	itemDTO := &dto.ItemDTO{
 		ItemID:    itemModel.ID,
 		CreatedAt: itemModel.CreatedAt,
 		UpdatedAt: itemModel.UpdatedAt,
 		Content:   itemModel.Content,
 		Status:    itemModel.Status,
+		DueAt:     itemModel.DueAt,
+		Version:   itemModel.Version,
 		Tags:      tagDTOs,
-	}, nil
+	}
+
+	// 试运行不同步搜索索引、不发布事件
+	if meta.IsDryRun(ctx) {
+		return itemDTO, nil
+	}
+
+	// 同步搜索索引
+	l.syncItemIndex(ctx, itemDTO)
+
+	// 状态变为已完成时发布事件
+	if existing.Status != string(meta.ItemStatusDone) && itemDTO.Status == string(meta.ItemStatusDone) {
+		l.publisher.Publish(ctx, meta.WebhookEventItemCompleted, itemDTO)
+		bizmetrics.ItemCompleted()
+	}
+	l.changes.Record(ctx, changeModel.EntityItem, changeModel.ActionUpdated, itemDTO.ItemID, itemDTO)
+	l.hooks.AfterItemUpdate.Notify(ctx, &hooks.ItemUpdated{Item: itemDTO, PreviousStatus: existing.Status})
+
+	return itemDTO, nil
 }
 
 // DeleteItem 删除项目
 func (l *ItemLogic) DeleteItem(ctx context.Context, itemID uint) error {
 	// 检查项目是否存在
-	_, err := l.itemRepo.GetItemByID(ctx, itemID)
+	item, err := l.itemRepo.GetItemByID(ctx, itemID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logs.CtxWarnf(ctx, "项目不存在: item_id=%d", itemID)
@@ -214,12 +325,33 @@ func (l *ItemLogic) DeleteItem(ctx context.Context, itemID uint) error {
 		return errorx.Wrap(err, itemError.ItemErrDeleteFailed, errorx.K("reason", err.Error()))
 	}
 
+	// 删除前钩子，可以拒绝删除
+	deleted := &hooks.ItemDelete{Item: toItemDTO(item)}
+	if err := l.hooks.BeforeItemDelete.Run(ctx, deleted); err != nil {
+		logs.CtxWarnf(ctx, "删除项目被钩子拒绝: item_id=%d, error=%s", itemID, err.Error())
+		return hooks.Rejected(err)
+	}
+
 	// 删除项目
 	if err := l.itemRepo.DeleteItem(ctx, itemID); err != nil {
 		logs.CtxErrorf(ctx, "删除项目失败: item_id=%d, error=%s", itemID, err.Error())
 		return errorx.Wrap(err, itemError.ItemErrDeleteFailed, errorx.K("reason", err.Error()))
 	}
 
+	// 试运行不同步搜索索引、不记录变更
+	if meta.IsDryRun(ctx) {
+		return nil
+	}
+
+	// 同步搜索索引
+	if err := l.searchRepo.DeleteItem(ctx, itemID); err != nil {
+		logs.CtxWarnf(ctx, "删除项目索引失败: item_id=%d, error=%s", itemID, err.Error())
+	}
+
+	l.changes.Record(ctx, changeModel.EntityItem, changeModel.ActionDeleted, itemID, nil)
+	l.audit.Audit(ctx, auditModel.EntityItem, auditModel.ActionDeleted, itemID, map[string]string{"content": item.Content, "status": item.Status})
+	l.hooks.AfterItemDelete.Notify(ctx, deleted)
+
 	return nil
 }
 
@@ -253,22 +385,21 @@ func (l *ItemLogic) GetItem(ctx context.Context, itemID uint) (*dto.ItemDTO, err
 		UpdatedAt: itemModel.UpdatedAt,
 		Content:   itemModel.Content,
 		Status:    itemModel.Status,
+		DueAt:     itemModel.DueAt,
+		Version:   itemModel.Version,
 		Tags:      tagDTOs,
 	}, nil
 }
 
 // GetItemList 获取项目列表
-func (l *ItemLogic) GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, int, error) {
+func (l *ItemLogic) GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error) {
 	items, total, err := l.itemRepo.GetItemListWithTags(ctx, dateStart, dateEnd, status, page, pageSize)
 	if err != nil {
 		logs.CtxErrorf(ctx, "获取项目列表失败: error=%s", err.Error())
-		return nil, 0, 0, errorx.Wrap(err, itemError.ItemErrDatabaseError, errorx.K("reason", err.Error()))
+		return nil, 0, errorx.Wrap(err, itemError.ItemErrDatabaseError, errorx.K("reason", err.Error()))
 	}
 
-	// 计算总页数
-	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
-
-	return items, total, totalPages, nil
+	return items, total, nil
 }
 
 // GetDailyItemCount 获取每日项目数量
@@ -281,3 +412,88 @@ func (l *ItemLogic) GetDailyItemCount(ctx context.Context, dateStart time.Time,
 
 	return items, nil
 }
+
+// SearchItems 搜索项目
+// 配置了 ElasticSearch 时使用全文检索，否则退化为数据库模糊匹配
+func (l *ItemLogic) SearchItems(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error) {
+	var items []dto.ItemDTO
+	var total int64
+
+	if l.searchRepo.Enabled() {
+		itemIDs, hits, err := l.searchRepo.SearchItemIDs(ctx, keyword, status, page, pageSize)
+		if err != nil {
+			logs.CtxErrorf(ctx, "搜索项目失败: keyword=%s, error=%s", keyword, err.Error())
+			return nil, 0, errorx.Wrap(err, itemError.ItemErrSearchFailed, errorx.K("reason", err.Error()))
+		}
+
+		items, err = l.itemRepo.GetItemListWithTagsByIDs(ctx, itemIDs)
+		if err != nil {
+			logs.CtxErrorf(ctx, "获取项目列表失败: error=%s", err.Error())
+			return nil, 0, errorx.Wrap(err, itemError.ItemErrDatabaseError, errorx.K("reason", err.Error()))
+		}
+		total = hits
+	} else {
+		var err error
+		items, total, err = l.itemRepo.SearchItemListWithTags(ctx, keyword, status, page, pageSize)
+		if err != nil {
+			logs.CtxErrorf(ctx, "搜索项目失败: keyword=%s, error=%s", keyword, err.Error())
+			return nil, 0, errorx.Wrap(err, itemError.ItemErrSearchFailed, errorx.K("reason", err.Error()))
+		}
+	}
+
+	return items, total, nil
+}
+
+// ReindexItems 全量重建项目搜索索引，返回写入的项目数量
+func (l *ItemLogic) ReindexItems(ctx context.Context) (int, error) {
+	if !l.searchRepo.Enabled() {
+		logs.CtxWarnf(ctx, "未配置 ElasticSearch，跳过项目索引重建")
+		return 0, nil
+	}
+
+	count, err := l.searchRepo.Reindex(ctx)
+	if err != nil {
+		logs.CtxErrorf(ctx, "重建项目索引失败: indexed=%d, error=%s", count, err.Error())
+		return count, errorx.Wrap(err, itemError.ItemErrSearchFailed, errorx.K("reason", err.Error()))
+	}
+
+	logs.CtxInfof(ctx, "重建项目索引完成: indexed=%d", count)
+	return count, nil
+}
+
+// indexRetryBackoff 同步搜索索引遇到可重试错误（超时、ES 限流等）时的重试间隔
+var indexRetryBackoff = retry.Backoff{
+	InitialInterval: 100 * time.Millisecond,
+	MaxInterval:     500 * time.Millisecond,
+	Multiplier:      2,
+	MaxElapsed:      time.Second,
+}
+
+// syncItemIndex 同步项目到搜索索引
+// 可重试的错误短暂重试；索引失败不影响主流程，可通过重建索引修复
+func (l *ItemLogic) syncItemIndex(ctx context.Context, item *dto.ItemDTO) {
+	err := retry.Do(ctx, indexRetryBackoff, func(ctx context.Context, attempt int) error {
+		err := l.searchRepo.IndexItem(ctx, item)
+		if err != nil && !errorx.IsRetryable(err) {
+			return retry.Permanent(err)
+		}
+		return err
+	})
+	if err != nil {
+		logs.CtxWarnf(ctx, "同步项目索引失败: item_id=%d, error=%s", item.ItemID, err.Error())
+	}
+}
+
+// toItemDTO 转换项目（不含标签）
+func toItemDTO(item *itemModel.Item) *dto.ItemDTO {
+	return &dto.ItemDTO{
+		ItemID:    item.ID,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+		Content:   item.Content,
+		Status:    item.Status,
+		DueAt:     item.DueAt,
+		Version:   item.Version,
+		Tags:      []dto.TagDTO{},
+	}
+}
@@ -0,0 +1,138 @@
+package limit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	userModel "backend/app/model/user"
+	"backend/app/types/consts"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/ratelimit"
+
+	"go.uber.org/fx"
+)
+
+// DefaultTiers 默认的限流档位配置
+const DefaultTiers = "default:general=600/1m,search=60/1m,export=5/1h,email=10/1h;api-heavy:general=6000/1m,search=600/1m,export=20/1h,email=10/1h"
+
+// tierCacheTTL 用户档位的缓存时间，修改档位后最多经过该时间生效
+const tierCacheTTL = time.Minute
+
+// LoadTiers 读取限流档位配置
+func LoadTiers() (ratelimit.Tiers, error) {
+	spec := envx.GetStringOptional(consts.RateLimitTiers)
+	if spec == "" {
+		spec = DefaultTiers
+	}
+	tiers, err := ratelimit.ParseTiers(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s 配置错误: %w", consts.RateLimitTiers, err)
+	}
+	return tiers, nil
+}
+
+type UserRepo interface {
+	GetUserByID(ctx context.Context, userID uint) (*userModel.User, error)
+}
+
+type LimitLogicParams struct {
+	fx.In
+
+	UserRepo UserRepo
+}
+
+type cachedTier struct {
+	tier    string
+	expires time.Time
+}
+
+type LimitLogic struct {
+	userRepo UserRepo
+	enabled  bool
+	tiers    ratelimit.Tiers
+	limiter  *ratelimit.Limiter
+
+	mu        sync.Mutex
+	tierCache map[string]cachedTier
+}
+
+func NewLimitLogic(params LimitLogicParams) (*LimitLogic, error) {
+	tiers, err := LoadTiers()
+	if err != nil {
+		return nil, err
+	}
+
+	return &LimitLogic{
+		userRepo:  params.UserRepo,
+		enabled:   envx.GetBool(consts.RateLimitEnabled, false),
+		tiers:     tiers,
+		limiter:   ratelimit.NewLimiter(),
+		tierCache: make(map[string]cachedTier),
+	}, nil
+}
+
+// Allow 对当前调用方在 class 类接口上计数一次
+// 调用方为 ctx 中的用户，未登录时为客户端IP；未启用限流或 class 未配置规则时返回 nil
+func (l *LimitLogic) Allow(ctx context.Context, class string) (*ratelimit.Result, error) {
+	if !l.enabled {
+		return nil, nil
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	userID, _ := ctx.Value(meta.ContextKeyUserID).(uint)
+
+	var caller, tier string
+	if userID != 0 {
+		caller = fmt.Sprintf("%s:user:%d", tenantID, userID)
+		var err error
+		if tier, err = l.userTier(ctx, caller, userID); err != nil {
+			return nil, err
+		}
+	} else {
+		ip, _ := ctx.Value(meta.ContextKeyClientIP).(string)
+		caller = fmt.Sprintf("%s:ip:%s", tenantID, ip)
+	}
+
+	rule, ok := l.tiers.Rule(tier, class)
+	if !ok {
+		return nil, nil
+	}
+	result := l.limiter.Allow(caller+":"+class, rule, time.Now())
+	if !result.Allowed {
+		logs.CtxInfof(ctx, "请求被限流: caller=%s, tier=%s, class=%s, rule=%s", caller, tier, class, rule)
+	}
+	return &result, nil
+}
+
+// userTier 查询用户的限流档位，结果缓存 tierCacheTTL
+func (l *LimitLogic) userTier(ctx context.Context, caller string, userID uint) (string, error) {
+	now := time.Now()
+	l.mu.Lock()
+	cached, ok := l.tierCache[caller]
+	l.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.tier, nil
+	}
+
+	user, err := l.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询用户限流档位失败: user_id=%d, error=%s", userID, err.Error())
+		return "", err
+	}
+
+	l.mu.Lock()
+	// 顺带清理过期的缓存，避免长时间运行后缓存无限增长
+	for key, c := range l.tierCache {
+		if !now.Before(c.expires) {
+			delete(l.tierCache, key)
+		}
+	}
+	l.tierCache[caller] = cachedTier{tier: user.RateLimitTier, expires: now.Add(tierCacheTTL)}
+	l.mu.Unlock()
+
+	return user.RateLimitTier, nil
+}
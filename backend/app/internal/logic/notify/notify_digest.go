@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"backend/app/types/dto"
+	"backend/utils/logs"
+)
+
+const (
+	// digestMaxItems 摘要中列出的最多项目数
+	digestMaxItems = 20
+	// digestMaxContentLength 摘要中项目内容的最大长度（字符）
+	digestMaxContentLength = 80
+)
+
+type digestItem struct {
+	Content   string
+	CreatedAt string
+}
+
+// SendDailyDigests 向开启了每日摘要的用户发送未完成项目的摘要
+// 项目没有截止日期，摘要列出用户创建的、状态不是 done 的项目，没有待办项目的用户不发送
+func (l *NotifyLogic) SendDailyDigests(ctx context.Context) (*dto.DigestResultDTO, error) {
+	users, err := l.notifyRepo.GetDigestRecipients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询摘要收件人失败: %w", err)
+	}
+
+	result := &dto.DigestResultDTO{Recipients: len(users)}
+	date := time.Now().Format("2006-01-02")
+	var errs []error
+	for _, user := range users {
+		items, total, err := l.itemRepo.GetOpenItemsByCreator(ctx, user.ID, digestMaxItems)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", user.ID, err))
+			continue
+		}
+		if total == 0 {
+			result.Skipped++
+			continue
+		}
+
+		list := make([]digestItem, 0, len(items))
+		for _, item := range items {
+			list = append(list, digestItem{
+				Content:   truncate(plainText(item.Content), digestMaxContentLength),
+				CreatedAt: item.CreatedAt.Format("01-02"),
+			})
+		}
+		name := user.NickName
+		if name == "" {
+			name = user.Username
+		}
+
+		err = l.enqueueEmail(ctx, user.Email, TemplateDailyDigest, map[string]any{
+			"Name":  name,
+			"Date":  date,
+			"Total": total,
+			"Items": list,
+			"More":  total - int64(len(list)),
+			"Link":  l.publicURL + "/",
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", user.ID, err))
+			continue
+		}
+		result.Sent++
+	}
+
+	logs.CtxInfof(ctx, "每日摘要发送完成: recipients=%d, sent=%d, skipped=%d, failed=%d",
+		result.Recipients, result.Sent, result.Skipped, len(errs))
+	return result, errors.Join(errs...)
+}
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// plainText 去掉富文本内容中的 HTML 标签，合并空白
+func plainText(s string) string {
+	s = html.UnescapeString(htmlTagPattern.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// truncate 按字符截断字符串
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n]) + "…"
+}
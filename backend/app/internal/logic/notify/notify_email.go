@@ -0,0 +1,74 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/mailer"
+	"backend/utils/retry"
+)
+
+// SendVerificationEmail 发送邮箱验证邮件
+func (l *NotifyLogic) SendVerificationEmail(ctx context.Context, to string, name string, token string, expire time.Duration) error {
+	return l.enqueueEmail(ctx, to, TemplateVerifyEmail, map[string]string{
+		"Name":   name,
+		"Link":   l.publicURL + "/verify-email?token=" + url.QueryEscape(token),
+		"Expire": formatDuration(expire),
+	})
+}
+
+// SendPasswordResetEmail 发送重置密码邮件
+func (l *NotifyLogic) SendPasswordResetEmail(ctx context.Context, to string, name string, token string, expire time.Duration) error {
+	return l.enqueueEmail(ctx, to, TemplateResetPassword, map[string]string{
+		"Name":   name,
+		"Link":   l.publicURL + "/reset-password?token=" + url.QueryEscape(token),
+		"Expire": formatDuration(expire),
+	})
+}
+
+// enqueueEmail 渲染模板并加入发送队列
+func (l *NotifyLogic) enqueueEmail(ctx context.Context, to string, template string, data any) error {
+	msg, err := l.templates.Render(template, data)
+	if err != nil {
+		return err
+	}
+	msg.To = []string{to}
+
+	if _, err := l.jobQueue.Enqueue(ctx, SendEmailJobType, msg); err != nil {
+		return fmt.Errorf("邮件加入发送队列失败: %w", err)
+	}
+	return nil
+}
+
+// DeliverEmail 发送队列中的邮件，由后台任务队列调用，SMTP 5xx 响应不再重试
+func (l *NotifyLogic) DeliverEmail(ctx context.Context, job *jobqueue.Job) error {
+	var msg mailer.Message
+	if err := job.Decode(&msg); err != nil {
+		return retry.Permanent(err)
+	}
+
+	if err := l.mailer.Send(ctx, &msg); err != nil {
+		logs.CtxWarnf(ctx, "发送邮件失败: job_id=%d, subject=%s, attempt=%d, error=%s", job.ID, msg.Subject, job.Attempts, err.Error())
+		if mailer.IsPermanent(err) {
+			return retry.Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// formatDuration 将有效期格式化为邮件中显示的文字
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour && d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%d 天", d/(24*time.Hour))
+	case d >= time.Hour && d%time.Hour == 0:
+		return fmt.Sprintf("%d 小时", d/time.Hour)
+	default:
+		return fmt.Sprintf("%d 分钟", (d+time.Minute-1)/time.Minute)
+	}
+}
@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"context"
+
+	"backend/utils/jobqueue"
+	"backend/utils/scheduler"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// SendEmailJobType 发送邮件的后台任务类型
+	SendEmailJobType = "email.send"
+	// DigestJobName 每日摘要定时任务名称
+	DigestJobName = "email_digest"
+	// digestDefaultSpec 每日摘要默认每天 08:00 发送
+	digestDefaultSpec = "0 8 * * *"
+)
+
+type QueueHandlersParams struct {
+	fx.In
+
+	Queue       *jobqueue.Queue
+	NotifyLogic *NotifyLogic
+}
+
+// RegisterQueueHandlers 注册邮件相关的后台任务处理函数
+func RegisterQueueHandlers(params QueueHandlersParams) error {
+	return params.Queue.Handle(SendEmailJobType, params.NotifyLogic.DeliverEmail)
+}
+
+type DigestJobParams struct {
+	fx.In
+
+	Scheduler   *scheduler.Scheduler
+	NotifyLogic *NotifyLogic
+}
+
+// RegisterDigestJob 注册每日摘要定时任务（任务名 email_digest），默认每天 08:00 执行
+func RegisterDigestJob(params DigestJobParams) error {
+	return params.Scheduler.Register(scheduler.Job{
+		Name:        DigestJobName,
+		Description: "向开启了每日摘要的用户发送未完成项目的摘要邮件",
+		Spec:        digestDefaultSpec,
+		Run: func(ctx context.Context) error {
+			_, err := params.NotifyLogic.SendDailyDigests(ctx)
+			return err
+		},
+	})
+}
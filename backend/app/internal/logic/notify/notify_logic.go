@@ -0,0 +1,178 @@
+package notify
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"strings"
+	"time"
+
+	itemModel "backend/app/model/item"
+	notifyModel "backend/app/model/notify"
+	userModel "backend/app/model/user"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	notifyError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/mailer"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// 邮件模板名称，对应 templates 目录下的文件名
+const (
+	TemplateVerifyEmail   = "verify_email"
+	TemplateResetPassword = "reset_password"
+	TemplateDailyDigest   = "daily_digest"
+	TemplateTest          = "test"
+)
+
+type NotifyRepo interface {
+	GetPreference(ctx context.Context, userID uint) (*notifyModel.NotificationPreference, error)
+	SavePreference(ctx context.Context, pref *notifyModel.NotificationPreference) error
+	GetDigestRecipients(ctx context.Context) ([]*userModel.User, error)
+}
+
+type NotifyItemRepo interface {
+	GetOpenItemsByCreator(ctx context.Context, userID uint, limit int) ([]*itemModel.Item, int64, error)
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg *mailer.Message) error
+}
+
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload any, opts ...jobqueue.EnqueueOption) (*jobqueue.Job, error)
+}
+
+type NotifyLogicParams struct {
+	fx.In
+
+	NotifyRepo NotifyRepo
+	ItemRepo   NotifyItemRepo
+	Mailer     Mailer
+	JobQueue   JobQueue
+}
+
+type NotifyLogic struct {
+	notifyRepo NotifyRepo
+	itemRepo   NotifyItemRepo
+	mailer     Mailer
+	jobQueue   JobQueue
+	templates  *mailer.Templates
+	publicURL  string
+}
+
+func NewNotifyLogic(params NotifyLogicParams) (*NotifyLogic, error) {
+	templates, err := mailer.ParseTemplates(templateFS, "templates/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+
+	publicURL := envx.GetStringOptional(consts.AppPublicURL)
+	if publicURL == "" {
+		publicURL = "http://localhost:5174"
+	}
+
+	return &NotifyLogic{
+		notifyRepo: params.NotifyRepo,
+		itemRepo:   params.ItemRepo,
+		mailer:     params.Mailer,
+		jobQueue:   params.JobQueue,
+		templates:  templates,
+		publicURL:  strings.TrimRight(publicURL, "/"),
+	}, nil
+}
+
+// defaultPreference 没有保存偏好时的默认值：接收通知邮件，不接收每日摘要
+func defaultPreference(userID uint) *notifyModel.NotificationPreference {
+	return &notifyModel.NotificationPreference{UserID: userID, EmailEnabled: true}
+}
+
+// GetPreference 获取当前用户的通知偏好
+func (l *NotifyLogic) GetPreference(ctx context.Context) (*dto.NotificationPreferenceDTO, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := l.getPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.NotificationPreferenceDTO{EmailEnabled: pref.EmailEnabled, DailyDigest: pref.DailyDigest}, nil
+}
+
+// UpdatePreference 更新当前用户的通知偏好，参数为 nil 时不修改对应字段
+func (l *NotifyLogic) UpdatePreference(ctx context.Context, emailEnabled *bool, dailyDigest *bool) (*dto.NotificationPreferenceDTO, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pref, err := l.getPreference(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if emailEnabled != nil {
+		pref.EmailEnabled = *emailEnabled
+	}
+	if dailyDigest != nil {
+		pref.DailyDigest = *dailyDigest
+	}
+
+	if err := l.notifyRepo.SavePreference(ctx, pref); err != nil {
+		logs.CtxErrorf(ctx, "保存通知偏好失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, notifyError.NotifyErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	return &dto.NotificationPreferenceDTO{EmailEnabled: pref.EmailEnabled, DailyDigest: pref.DailyDigest}, nil
+}
+
+// getPreference 查询用户的通知偏好，没有记录时返回默认值
+func (l *NotifyLogic) getPreference(ctx context.Context, userID uint) (*notifyModel.NotificationPreference, error) {
+	pref, err := l.notifyRepo.GetPreference(ctx, userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return defaultPreference(userID), nil
+		}
+		logs.CtxErrorf(ctx, "查询通知偏好失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, notifyError.NotifyErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	return pref, nil
+}
+
+// SendTestEmail 直接发送测试邮件（不经过任务队列），用于检查 SMTP 配置
+func (l *NotifyLogic) SendTestEmail(ctx context.Context, to string) error {
+	msg, err := l.templates.Render(TemplateTest, map[string]string{
+		"SentAt": time.Now().Format("2006-01-02 15:04:05 MST"),
+	})
+	if err != nil {
+		logs.CtxErrorf(ctx, "渲染测试邮件失败: error=%s", err.Error())
+		return errorx.Wrap(err, notifyError.NotifyErrTemplateFailed, errorx.K("reason", err.Error()))
+	}
+	msg.To = []string{to}
+
+	if err := l.mailer.Send(ctx, msg); err != nil {
+		logs.CtxWarnf(ctx, "发送测试邮件失败: to=%s, error=%s", to, err.Error())
+		return errorx.Wrap(err, notifyError.NotifyErrSendFailed, errorx.K("reason", err.Error()))
+	}
+	return nil
+}
+
+// currentUserID 从 context 中获取当前用户ID
+func currentUserID(ctx context.Context) (uint, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return 0, errorx.New(notifyError.AuthErrTokenRequired)
+	}
+	return userID, nil
+}
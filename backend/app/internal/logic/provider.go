@@ -1,14 +1,40 @@
 package logic
 
 import (
+	auditHandler "backend/app/internal/handler/audit"
+	botHandler "backend/app/internal/handler/bot"
+	changeHandler "backend/app/internal/handler/change"
+	exportHandler "backend/app/internal/handler/export"
+	feedHandler "backend/app/internal/handler/feed"
 	fileHandler "backend/app/internal/handler/file"
+	graphHandler "backend/app/internal/handler/graph"
+	importerHandler "backend/app/internal/handler/importer"
 	itemHandler "backend/app/internal/handler/item"
+	notifyHandler "backend/app/internal/handler/notify"
+	rpcHandler "backend/app/internal/handler/rpc"
+	statsHandler "backend/app/internal/handler/stats"
+	systemHandler "backend/app/internal/handler/system"
 	tagHandler "backend/app/internal/handler/tag"
 	userHandler "backend/app/internal/handler/user"
+	webhookHandler "backend/app/internal/handler/webhook"
+	auditLogic "backend/app/internal/logic/audit"
+	botLogic "backend/app/internal/logic/bot"
+	changeLogic "backend/app/internal/logic/change"
+	exportLogic "backend/app/internal/logic/export"
+	feedLogic "backend/app/internal/logic/feed"
 	fileLogic "backend/app/internal/logic/file"
+	"backend/app/internal/logic/hooks"
+	importerLogic "backend/app/internal/logic/importer"
 	itemLogic "backend/app/internal/logic/item"
+	limitLogic "backend/app/internal/logic/limit"
+	notifyLogic "backend/app/internal/logic/notify"
+	retentionLogic "backend/app/internal/logic/retention"
+	statsLogic "backend/app/internal/logic/stats"
+	systemLogic "backend/app/internal/logic/system"
 	tagLogic "backend/app/internal/logic/tag"
 	userLogic "backend/app/internal/logic/user"
+	webhookLogic "backend/app/internal/logic/webhook"
+	"backend/app/server/middleware"
 
 	"go.uber.org/fx"
 )
@@ -16,25 +42,129 @@ import (
 // LogicModule fx 业务逻辑层模块
 var LogicModule = fx.Module("logic",
 	fx.Provide(
+		// Hooks
+		hooks.NewRegistry,
 		// User Logic
 		fx.Annotate(
 			userLogic.NewUserLogic,
 			fx.As(new(userHandler.UserLogic)),
+			fx.As(new(graphHandler.UserLogic)),
+			fx.As(new(rpcHandler.UserLogic)),
+			fx.As(new(middleware.UserLocaleResolver)),
 		),
 		// File Logic
 		fx.Annotate(
 			fileLogic.NewFileLogic,
+			fx.As(fx.Self()),
 			fx.As(new(fileHandler.FileLogic)),
+			fx.As(new(exportLogic.FileStorage)),
 		),
 		// Item Logic
 		fx.Annotate(
 			itemLogic.NewItemLogic,
+			fx.As(fx.Self()),
 			fx.As(new(itemHandler.ItemLogic)),
+			fx.As(new(graphHandler.ItemLogic)),
+			fx.As(new(rpcHandler.ItemLogic)),
+			fx.As(new(importerLogic.ItemCreator)),
+			fx.As(new(botLogic.ItemLogic)),
 		),
 		// Tag Logic
 		fx.Annotate(
 			tagLogic.NewTagLogic,
 			fx.As(new(tagHandler.TagLogic)),
+			fx.As(new(graphHandler.TagLogic)),
+			fx.As(new(rpcHandler.TagLogic)),
+			fx.As(new(importerLogic.TagCreator)),
 		),
+		// System Logic
+		fx.Annotate(
+			systemLogic.NewSystemLogic,
+			fx.As(fx.Self()),
+			fx.As(new(systemHandler.SystemLogic)),
+		),
+		// Retention Logic
+		retentionLogic.NewRetentionLogic,
+		// Webhook Logic
+		fx.Annotate(
+			webhookLogic.NewWebhookLogic,
+			fx.As(fx.Self()),
+			fx.As(new(webhookHandler.WebhookLogic)),
+			fx.As(new(itemLogic.EventPublisher)),
+			fx.As(new(tagLogic.EventPublisher)),
+		),
+		// Notify Logic
+		fx.Annotate(
+			notifyLogic.NewNotifyLogic,
+			fx.As(fx.Self()),
+			fx.As(new(notifyHandler.NotifyLogic)),
+			fx.As(new(userLogic.EmailNotifier)),
+		),
+		// Change Logic
+		fx.Annotate(
+			changeLogic.NewChangeLogic,
+			fx.As(new(changeHandler.ChangeLogic)),
+			fx.As(new(itemLogic.ChangeRecorder)),
+			fx.As(new(tagLogic.ChangeRecorder)),
+		),
+		// Export Logic
+		fx.Annotate(
+			exportLogic.NewExportLogic,
+			fx.As(new(exportHandler.ExportLogic)),
+		),
+		// Import Logic
+		fx.Annotate(
+			importerLogic.NewImportLogic,
+			fx.As(fx.Self()),
+			fx.As(new(importerHandler.ImportLogic)),
+		),
+		// Audit Logic
+		fx.Annotate(
+			auditLogic.NewAuditLogic,
+			fx.As(new(auditHandler.AuditLogic)),
+			fx.As(new(userLogic.AuditRecorder)),
+			fx.As(new(itemLogic.AuditRecorder)),
+			fx.As(new(tagLogic.AuditRecorder)),
+			fx.As(new(webhookLogic.AuditRecorder)),
+			fx.As(new(systemLogic.AuditRecorder)),
+		),
+		// Feed Logic
+		fx.Annotate(
+			feedLogic.NewFeedLogic,
+			fx.As(new(feedHandler.FeedLogic)),
+		),
+		// Stats Logic
+		fx.Annotate(
+			statsLogic.NewStatsLogic,
+			fx.As(new(statsHandler.StatsLogic)),
+			fx.As(new(middleware.RequestRecorder)),
+		),
+		// Bot Logic
+		fx.Annotate(
+			botLogic.NewBotLogic,
+			fx.As(fx.Self()),
+			fx.As(new(botHandler.BotLogic)),
+		),
+		// Limit Logic
+		fx.Annotate(
+			limitLogic.NewLimitLogic,
+			fx.As(new(middleware.RateLimiter)),
+		),
+	),
+)
+
+// JobsModule 注册定时任务（数据保留、数据库备份、文件清理、每日摘要）、后台任务处理函数（重建索引、webhook 投递、发送邮件、数据导入）和 Telegram 机器人
+// 与 LogicModule 分开，命令行子命令只使用 LogicModule，不会启动调度器和任务队列
+var JobsModule = fx.Module("jobs",
+	fx.Invoke(
+		retentionLogic.RegisterRetentionJob,
+		systemLogic.RegisterBackupJob,
+		fileLogic.RegisterFileGCJob,
+		itemLogic.RegisterQueueHandlers,
+		webhookLogic.RegisterQueueHandlers,
+		notifyLogic.RegisterQueueHandlers,
+		importerLogic.RegisterQueueHandlers,
+		notifyLogic.RegisterDigestJob,
+		botLogic.RegisterBot,
 	),
 )
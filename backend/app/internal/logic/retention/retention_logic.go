@@ -0,0 +1,351 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	auditModel "backend/app/model/audit"
+	changeModel "backend/app/model/change"
+	fileModel "backend/app/model/file"
+	itemModel "backend/app/model/item"
+	queueModel "backend/app/model/queue"
+	tagModel "backend/app/model/tag"
+	userModel "backend/app/model/user"
+	webhookModel "backend/app/model/webhook"
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/readonly"
+	"backend/utils/scheduler"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// MetricName 数据保留统计在 metrics 端点中的名称
+	MetricName = "retention"
+	// JobName 数据保留定时任务名称
+	JobName = "retention_purge"
+
+	// purgeBatchSize 每批删除的记录数
+	purgeBatchSize = 500
+)
+
+type RetentionRepo interface {
+	HasColumn(ctx context.Context, table string, column string) bool
+	CountExpired(ctx context.Context, table string, column string, before time.Time) (int64, error)
+	PurgeExpired(ctx context.Context, table string, column string, before time.Time, batchSize int) (int64, error)
+}
+
+// Policy 数据保留策略
+// Column 早于 当前时间-MaxAge 的记录会被清理；表或列不存在时跳过该策略
+type Policy struct {
+	Name   string        // 策略名称
+	Table  string        // 表名
+	Column string        // 判断过期的时间列
+	MaxAge time.Duration // 保留时长
+}
+
+// PolicyResult 单个策略的执行结果
+type PolicyResult struct {
+	Policy     string `json:"policy"`
+	Table      string `json:"table"`
+	Affected   int64  `json:"affected"` // 已清理（dry-run 时为待清理）的记录数
+	DryRun     bool   `json:"dry_run"`
+	Skipped    bool   `json:"skipped"` // 表或列不存在
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PolicyStats 单个策略的累计统计
+type PolicyStats struct {
+	Runs         int64     `json:"runs"`
+	Failures     int64     `json:"failures"`
+	TotalPurged  int64     `json:"total_purged"`
+	LastAffected int64     `json:"last_affected"`
+	LastRunAt    time.Time `json:"last_run_at"`
+	LastError    string    `json:"last_error,omitempty"`
+	DryRun       bool      `json:"dry_run"`
+}
+
+type RetentionLogicParams struct {
+	fx.In
+
+	RetentionRepo RetentionRepo
+}
+
+type RetentionLogic struct {
+	retentionRepo RetentionRepo
+	policies      []Policy
+	dryRun        bool
+
+	statsMu sync.RWMutex
+	stats   map[string]*PolicyStats
+}
+
+func NewRetentionLogic(params RetentionLogicParams) (*RetentionLogic, error) {
+	policies, err := loadPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	l := &RetentionLogic{
+		retentionRepo: params.RetentionRepo,
+		policies:      policies,
+		dryRun:        envx.GetBool(consts.RetentionDryRun, false),
+		stats:         make(map[string]*PolicyStats),
+	}
+	return l, nil
+}
+
+// loadPolicies 从环境变量构建数据保留策略
+// 登录会话是无状态的 JWT，不保存在数据库中，没有需要清理的会话数据
+func loadPolicies() ([]Policy, error) {
+	softDeletedDays, err := envx.GetIntWithDefaultAndMin(consts.RetentionSoftDeletedDays, 30, 0)
+	if err != nil {
+		return nil, err
+	}
+	auditLogDays, err := envx.GetIntWithDefaultAndMin(consts.RetentionAuditLogDays, 90, 0)
+	if err != nil {
+		return nil, err
+	}
+	queueJobDays, err := envx.GetIntWithDefaultAndMin(consts.RetentionQueueJobDays, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+	webhookDeliveryDays, err := envx.GetIntWithDefaultAndMin(consts.RetentionWebhookDeliveryDays, 30, 0)
+	if err != nil {
+		return nil, err
+	}
+	syncChangeDays, err := envx.GetIntWithDefaultAndMin(consts.RetentionSyncChangeDays, 7, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []Policy
+
+	// 软删除数据：只对包含 deleted_at 列的表生效
+	if softDeletedDays > 0 {
+		for _, table := range []string{userModel.UserTableName, itemModel.ItemTableName, tagModel.TagTableName, fileModel.FileTableName} {
+			policies = append(policies, Policy{
+				Name:   "soft_deleted_" + table,
+				Table:  table,
+				Column: "deleted_at",
+				MaxAge: time.Duration(softDeletedDays) * 24 * time.Hour,
+			})
+		}
+	}
+
+	// 审计日志
+	if auditLogDays > 0 {
+		policies = append(policies, Policy{
+			Name:   "audit_log",
+			Table:  auditModel.AuditLogTableName,
+			Column: "created_at",
+			MaxAge: time.Duration(auditLogDays) * 24 * time.Hour,
+		})
+	}
+
+	// 已完成的后台任务（等待执行的任务 finished_at 为空，不会被清理）
+	if queueJobDays > 0 {
+		policies = append(policies, Policy{
+			Name:   "finished_queue_job",
+			Table:  queueModel.QueueJobTableName,
+			Column: "finished_at",
+			MaxAge: time.Duration(queueJobDays) * 24 * time.Hour,
+		})
+	}
+
+	// webhook 投递记录
+	if webhookDeliveryDays > 0 {
+		policies = append(policies, Policy{
+			Name:   "webhook_delivery",
+			Table:  webhookModel.WebhookDeliveryTableName,
+			Column: "created_at",
+			MaxAge: time.Duration(webhookDeliveryDays) * 24 * time.Hour,
+		})
+	}
+
+	// 同步变更记录
+	if syncChangeDays > 0 {
+		policies = append(policies, Policy{
+			Name:   "sync_change",
+			Table:  changeModel.ChangeTableName,
+			Column: "created_at",
+			MaxAge: time.Duration(syncChangeDays) * 24 * time.Hour,
+		})
+	}
+
+	return policies, nil
+}
+
+// Policies 返回当前生效的数据保留策略
+func (l *RetentionLogic) Policies() []Policy {
+	return l.policies
+}
+
+// RunOnce 执行一次所有数据保留策略
+// dry-run 模式下只统计待清理的记录数，不删除数据
+// 只读模式下跳过清理（dry-run 不写入数据，仍会执行）
+func (l *RetentionLogic) RunOnce(ctx context.Context) []PolicyResult {
+	if readonly.Enabled() && !l.dryRun {
+		logs.CtxInfof(ctx, "只读模式，跳过数据保留清理: reason=%s", readonly.Reason())
+		return nil
+	}
+
+	now := time.Now()
+	results := make([]PolicyResult, 0, len(l.policies))
+
+	for _, policy := range l.policies {
+		result := l.runPolicy(ctx, policy, now)
+		results = append(results, result)
+		l.recordStats(result, now)
+
+		switch {
+		case result.Error != "":
+			logs.CtxErrorf(ctx, "数据保留策略执行失败: policy=%s, table=%s, error=%s", result.Policy, result.Table, result.Error)
+		case result.Skipped:
+			logs.CtxDebugf(ctx, "数据保留策略跳过（表或列不存在）: policy=%s, table=%s", result.Policy, result.Table)
+		case result.DryRun:
+			logs.CtxInfof(ctx, "数据保留策略（dry-run）: policy=%s, table=%s, would_purge=%d", result.Policy, result.Table, result.Affected)
+		case result.Affected > 0:
+			logs.CtxInfof(ctx, "数据保留策略执行完成: policy=%s, table=%s, purged=%d, duration_ms=%d", result.Policy, result.Table, result.Affected, result.DurationMs)
+		}
+	}
+
+	return results
+}
+
+// runPolicy 执行单个数据保留策略
+func (l *RetentionLogic) runPolicy(ctx context.Context, policy Policy, now time.Time) PolicyResult {
+	start := time.Now()
+	result := PolicyResult{
+		Policy: policy.Name,
+		Table:  policy.Table,
+		DryRun: l.dryRun,
+	}
+
+	if !l.retentionRepo.HasColumn(ctx, policy.Table, policy.Column) {
+		result.Skipped = true
+		return result
+	}
+
+	before := now.Add(-policy.MaxAge)
+
+	var err error
+	if l.dryRun {
+		result.Affected, err = l.retentionRepo.CountExpired(ctx, policy.Table, policy.Column, before)
+	} else {
+		result.Affected, err = l.retentionRepo.PurgeExpired(ctx, policy.Table, policy.Column, before, purgeBatchSize)
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
+
+// recordStats 记录策略执行统计
+func (l *RetentionLogic) recordStats(result PolicyResult, runAt time.Time) {
+	if result.Skipped {
+		return
+	}
+
+	l.statsMu.Lock()
+	defer l.statsMu.Unlock()
+
+	stats, ok := l.stats[result.Policy]
+	if !ok {
+		stats = &PolicyStats{}
+		l.stats[result.Policy] = stats
+	}
+
+	stats.Runs++
+	stats.LastRunAt = runAt
+	stats.LastAffected = result.Affected
+	stats.DryRun = result.DryRun
+	stats.LastError = result.Error
+	if result.Error != "" {
+		stats.Failures++
+	} else if !result.DryRun {
+		stats.TotalPurged += result.Affected
+	}
+}
+
+// Stats 返回各策略的累计统计快照
+func (l *RetentionLogic) Stats() map[string]PolicyStats {
+	l.statsMu.RLock()
+	defer l.statsMu.RUnlock()
+
+	snapshot := make(map[string]PolicyStats, len(l.stats))
+	for name, stats := range l.stats {
+		snapshot[name] = *stats
+	}
+	return snapshot
+}
+
+type RetentionJobParams struct {
+	fx.In
+
+	Lifecycle      fx.Lifecycle
+	Scheduler      *scheduler.Scheduler
+	RetentionLogic *RetentionLogic
+}
+
+// RegisterRetentionJob 注册数据保留定时任务（任务名 retention_purge）
+// 未启用时不做任何处理；启用后调度器启动时立即执行一次，之后默认按 RETENTION_INTERVAL 定期执行，
+// 也可以通过 SCHEDULER_SCHEDULES 或数据库改为 cron 表达式
+func RegisterRetentionJob(params RetentionJobParams) error {
+	if !envx.GetBool(consts.RetentionEnabled, false) {
+		return nil
+	}
+
+	interval, err := envx.GetDurationWithDefault(consts.RetentionInterval, 24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	l := params.RetentionLogic
+	err = params.Scheduler.Register(scheduler.Job{
+		Name:        JobName,
+		Description: "清理超过保留期限的数据",
+		Spec:        "@every " + interval.String(),
+		RunOnStart:  true,
+		Run: func(ctx context.Context) error {
+			var failed []string
+			for _, result := range l.RunOnce(ctx) {
+				if result.Error != "" {
+					failed = append(failed, result.Policy+": "+result.Error)
+				}
+			}
+			if len(failed) > 0 {
+				return fmt.Errorf("%d 个策略执行失败: %s", len(failed), strings.Join(failed, "; "))
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			metrics.Register(MetricName, func() interface{} {
+				return l.Stats()
+			})
+			logs.Info("数据保留任务已启用", "interval", interval.String(), "dry_run", l.dryRun, "policies", len(l.policies))
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			metrics.Unregister(MetricName)
+			return nil
+		},
+	})
+
+	return nil
+}
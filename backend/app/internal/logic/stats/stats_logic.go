@@ -0,0 +1,157 @@
+package stats
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"backend/app/internal/logic/bizmetrics"
+	"backend/app/types/dto"
+	statsError "backend/app/types/errorn"
+	"backend/utils/buildinfo"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/metrics"
+
+	"go.uber.org/fx"
+)
+
+// DefaultWindow 未指定统计窗口时使用的窗口
+const DefaultWindow = "1h"
+
+// windows 可选的统计窗口
+var windows = map[string]time.Duration{
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+}
+
+// windowSpan 请求计数保留的最长时长，与最大的统计窗口一致
+const windowSpan = 24 * time.Hour
+
+type UserRepo interface {
+	CountUsers(ctx context.Context) (int64, error)
+}
+
+type ItemRepo interface {
+	CountItemsByStatus(ctx context.Context) (map[string]int64, error)
+}
+
+type FileRepo interface {
+	GetStorageUsage(ctx context.Context) (count int64, bytes int64, err error)
+}
+
+type StatsLogicParams struct {
+	fx.In
+
+	UserRepo UserRepo
+	ItemRepo ItemRepo
+	FileRepo FileRepo
+}
+
+// StatsLogic 管理后台统计，数据量来自数据库，请求数和 SSE 任务数来自本实例的内存计数
+type StatsLogic struct {
+	userRepo UserRepo
+	itemRepo ItemRepo
+	fileRepo FileRepo
+
+	// 按分钟统计的请求数、4xx 和 5xx 响应数
+	requests     *metrics.Window
+	clientErrors *metrics.Window
+	serverErrors *metrics.Window
+}
+
+func NewStatsLogic(params StatsLogicParams) *StatsLogic {
+	return &StatsLogic{
+		userRepo:     params.UserRepo,
+		itemRepo:     params.ItemRepo,
+		fileRepo:     params.FileRepo,
+		requests:     metrics.NewWindow(windowSpan, time.Minute),
+		clientErrors: metrics.NewWindow(windowSpan, time.Minute),
+		serverErrors: metrics.NewWindow(windowSpan, time.Minute),
+	}
+}
+
+// RecordRequest 记录一次请求，由请求统计中间件在请求结束时调用
+func (l *StatsLogic) RecordRequest(statusCode int) {
+	now := time.Now()
+	l.requests.Inc(now)
+	switch {
+	case statusCode >= http.StatusInternalServerError:
+		l.serverErrors.Inc(now)
+	case statusCode >= http.StatusBadRequest:
+		l.clientErrors.Inc(now)
+	}
+}
+
+// GetStats 获取管理后台统计，window 为请求统计窗口（5m、15m、1h、6h、24h），为空时使用 1h
+// 多租户模式下数据量只统计当前租户，请求数和 SSE 任务数统计本实例的所有请求
+func (l *StatsLogic) GetStats(ctx context.Context, window string) (*dto.AdminStatsDTO, error) {
+	if window == "" {
+		window = DefaultWindow
+	}
+	d, ok := windows[window]
+	if !ok {
+		return nil, errorx.New(statsError.StatsErrInvalidParam, errorx.Kf("reason", "不支持的统计窗口: %s", window))
+	}
+
+	users, err := l.userRepo.CountUsers(ctx)
+	if err != nil {
+		logs.CtxErrorf(ctx, "统计用户数失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, statsError.StatsErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	itemsByStatus, err := l.itemRepo.CountItemsByStatus(ctx)
+	if err != nil {
+		logs.CtxErrorf(ctx, "统计项目数失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, statsError.StatsErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	files, storageBytes, err := l.fileRepo.GetStorageUsage(ctx)
+	if err != nil {
+		logs.CtxErrorf(ctx, "统计文件存储用量失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, statsError.StatsErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	now := time.Now()
+	result := &dto.AdminStatsDTO{
+		GeneratedAt: now,
+		Users:       users,
+		Items:       dto.ItemStatsDTO{ByStatus: itemsByStatus},
+		Files:       dto.FileStatsDTO{Total: files, StorageBytes: storageBytes},
+		SSETasks:    dto.SSETaskStatsDTO{ByKind: bizmetrics.ActiveSSETasks()},
+		Requests:    l.requestStats(now, window, d),
+	}
+	for _, count := range itemsByStatus {
+		result.Items.Total += count
+	}
+	for _, count := range result.SSETasks.ByKind {
+		result.SSETasks.Active += count
+	}
+	return result, nil
+}
+
+// requestStats 统计窗口内的请求数和错误率，进程启动不足一个窗口时按运行时长计算平均速率
+func (l *StatsLogic) requestStats(now time.Time, window string, d time.Duration) dto.RequestStatsDTO {
+	stats := dto.RequestStatsDTO{
+		Window:       window,
+		Total:        l.requests.Sum(now, d),
+		ClientErrors: l.clientErrors.Sum(now, d),
+		ServerErrors: l.serverErrors.Sum(now, d),
+	}
+
+	elapsed := d
+	if uptime := buildinfo.Uptime(); uptime < elapsed {
+		elapsed = uptime
+	}
+	if elapsed < time.Second {
+		elapsed = time.Second
+	}
+	stats.WindowSeconds = int64(elapsed / time.Second)
+	stats.RequestsPerMinute = float64(stats.Total) / elapsed.Minutes()
+	if stats.Total > 0 {
+		stats.ErrorRate = float64(stats.ServerErrors) / float64(stats.Total)
+		stats.ClientErrorRate = float64(stats.ClientErrors) / float64(stats.Total)
+	}
+	return stats
+}
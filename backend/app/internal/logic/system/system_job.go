@@ -0,0 +1,161 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	systemError "backend/app/types/errorn"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/scheduler"
+
+	"go.uber.org/fx"
+)
+
+const (
+	// BackupJobName 数据库备份定时任务名称
+	BackupJobName = "db_backup"
+
+	// backupFilePrefix 备份文件名前缀，清理旧备份时只处理该前缀的文件
+	backupFilePrefix = "peano-"
+	// backupFileSuffix 备份文件扩展名
+	backupFileSuffix = ".db"
+)
+
+// ListJobs 获取所有定时任务的状态
+func (l *SystemLogic) ListJobs(ctx context.Context) ([]*dto.JobStatusDTO, error) {
+	statuses := l.jobScheduler.Statuses()
+	jobs := make([]*dto.JobStatusDTO, 0, len(statuses))
+	for _, status := range statuses {
+		jobs = append(jobs, &dto.JobStatusDTO{
+			Name:           status.Name,
+			Description:    status.Description,
+			Spec:           status.Spec,
+			Running:        status.Running,
+			NextRunAt:      timePtr(status.NextRunAt),
+			LastRunAt:      timePtr(status.LastRunAt),
+			LastDurationMs: status.LastDurationMs,
+			LastTrigger:    status.LastTrigger,
+			LastError:      status.LastError,
+			Runs:           status.Runs,
+			Failures:       status.Failures,
+			Skipped:        status.Skipped,
+		})
+	}
+	return jobs, nil
+}
+
+// RunJob 手动触发定时任务，任务在后台执行，结果通过 ListJobs 查看
+func (l *SystemLogic) RunJob(ctx context.Context, name string) error {
+	err := l.jobScheduler.Trigger(name)
+	switch {
+	case err == nil:
+		logs.CtxInfof(ctx, "手动触发定时任务: name=%s", name)
+		return nil
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		return errorx.New(systemError.SystemErrJobNotFound, errorx.K("name", name))
+	case errors.Is(err, scheduler.ErrJobRunning):
+		return errorx.New(systemError.SystemErrJobRunning, errorx.K("name", name))
+	default:
+		return errorx.Wrap(err, systemError.SystemErrNotReady, errorx.K("reason", err.Error()))
+	}
+}
+
+// BackupDatabase 备份数据库到 dir 目录，并只保留最新的 keep 个备份（keep 小于 1 时不清理）
+func (l *SystemLogic) BackupDatabase(ctx context.Context, dir string, keep int) (*dto.BackupDTO, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("创建备份目录失败: %w", err)
+	}
+
+	now := time.Now()
+	path := filepath.Join(dir, backupFilePrefix+now.Format("20060102-150405")+backupFileSuffix)
+	if err := l.sysRepo.BackupDatabase(ctx, path); err != nil {
+		return nil, fmt.Errorf("备份数据库失败: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	logs.CtxInfof(ctx, "数据库备份完成: path=%s, size=%d", path, info.Size())
+
+	if keep > 0 {
+		if err := pruneBackups(dir, keep); err != nil {
+			logs.CtxWarnf(ctx, "清理旧备份失败: error=%s", err.Error())
+		}
+	}
+	return &dto.BackupDTO{Path: path, Size: info.Size(), CreatedAt: now}, nil
+}
+
+// pruneBackups 删除最早的备份，只保留 keep 个（备份文件名包含时间，按名称排序即按时间排序）
+func pruneBackups(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() && strings.HasPrefix(name, backupFilePrefix) && strings.HasSuffix(name, backupFileSuffix) {
+			names = append(names, name)
+		}
+	}
+	if len(names) <= keep {
+		return nil
+	}
+
+	sort.Strings(names)
+	var errs []error
+	for _, name := range names[:len(names)-keep] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// timePtr 零值时间返回 nil
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+type BackupJobParams struct {
+	fx.In
+
+	Scheduler   *scheduler.Scheduler
+	SystemLogic *SystemLogic
+}
+
+// RegisterBackupJob 注册数据库备份定时任务（任务名 db_backup）
+// 默认只能手动触发，需要定期备份时通过 SCHEDULER_SCHEDULES 配置调度表达式
+func RegisterBackupJob(params BackupJobParams) error {
+	dir := envx.GetStringOptional(consts.BackupDir)
+	if dir == "" {
+		dir = "./backups"
+	}
+	keep, err := envx.GetIntWithDefaultAndMin(consts.BackupKeep, 7, 0)
+	if err != nil {
+		return err
+	}
+
+	return params.Scheduler.Register(scheduler.Job{
+		Name:        BackupJobName,
+		Description: "备份数据库（仅支持 SQLite）",
+		Run: func(ctx context.Context) error {
+			_, err := params.SystemLogic.BackupDatabase(ctx, dir, keep)
+			return err
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package system
+
+import (
+	"context"
+
+	auditModel "backend/app/model/audit"
+	"backend/app/types/dto"
+	systemError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+)
+
+// GetLogLevel 获取当前的日志级别
+func (l *SystemLogic) GetLogLevel(ctx context.Context) (*dto.LogLevelDTO, error) {
+	return &dto.LogLevelDTO{Level: logs.GetLevel()}, nil
+}
+
+// SetLogLevel 运行时修改当前实例的日志级别，不需要重启
+// 修改只保存在内存中，LOG_LEVEL 配置变更（文件修改或 SIGHUP 重新加载）或重启后以配置为准
+func (l *SystemLogic) SetLogLevel(ctx context.Context, level string) (*dto.LogLevelDTO, error) {
+	previous := logs.GetLevel()
+	if !logs.SetLevel(level) {
+		return nil, errorx.New(systemError.SystemErrNotReady, errorx.K("reason", "日志组件不支持运行时修改级别"))
+	}
+	current := logs.GetLevel()
+	// 使用 warn 级别记录，调高级别时也能看到
+	logs.CtxWarnf(ctx, "日志级别已修改: %s -> %s", previous, current)
+	l.audit.Audit(ctx, auditModel.EntitySystem, auditModel.ActionConfigChanged, 0, map[string]any{
+		"key":    "log_level",
+		"before": previous,
+		"after":  current,
+	})
+	return &dto.LogLevelDTO{Level: current}, nil
+}
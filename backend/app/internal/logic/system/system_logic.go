@@ -0,0 +1,115 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	baseRepo "backend/app/internal/repo/base"
+	"backend/app/types/dto"
+	systemError "backend/app/types/errorn"
+	"backend/utils/buildinfo"
+	"backend/utils/errorx"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/readonly"
+	"backend/utils/scheduler"
+	"backend/utils/sse"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type SysRepo interface {
+	GetSystemConfig(ctx context.Context, key string) (string, error)
+	GetDatabaseInfo(ctx context.Context) (string, string, error)
+	BackupDatabase(ctx context.Context, path string) error
+}
+
+type JobScheduler interface {
+	Statuses() []scheduler.JobStatus
+	Trigger(name string) error
+}
+
+type QueueRepo interface {
+	ListJobs(ctx context.Context, status string, jobType string, page, pageSize int) ([]*jobqueue.Job, int64, error)
+	GetJob(ctx context.Context, id uint) (*jobqueue.Job, error)
+	RequeueJob(ctx context.Context, id uint, runAt time.Time) (bool, error)
+	CountByStatus(ctx context.Context) (map[string]int64, error)
+}
+
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload any, opts ...jobqueue.EnqueueOption) (*jobqueue.Job, error)
+	Types() []string
+}
+
+type SSEManager interface {
+	ListTasks(filter sse.TaskFilter) sse.TaskList
+}
+
+// AuditRecorder 记录审计日志
+type AuditRecorder interface {
+	Audit(ctx context.Context, entity string, action string, entityID uint, detail any)
+}
+
+type SystemLogicParams struct {
+	fx.In
+
+	SysRepo      SysRepo
+	JobScheduler JobScheduler
+	QueueRepo    QueueRepo
+	JobQueue     JobQueue
+	SSEManager   SSEManager
+	Audit        AuditRecorder
+}
+
+type SystemLogic struct {
+	sysRepo      SysRepo
+	jobScheduler JobScheduler
+	queueRepo    QueueRepo
+	jobQueue     JobQueue
+	sseManager   SSEManager
+	audit        AuditRecorder
+}
+
+func NewSystemLogic(params SystemLogicParams) *SystemLogic {
+	return &SystemLogic{
+		sysRepo:      params.SysRepo,
+		jobScheduler: params.JobScheduler,
+		queueRepo:    params.QueueRepo,
+		jobQueue:     params.JobQueue,
+		sseManager:   params.SSEManager,
+		audit:        params.Audit,
+	}
+}
+
+// GetSystemInfo 获取系统信息
+// 包括构建信息、表结构版本、数据库驱动和运行时长
+func (l *SystemLogic) GetSystemInfo(ctx context.Context) (*dto.SystemInfoDTO, error) {
+	// 只读模式下首次启动不会写入版本号，此时返回空
+	schemaVersion, err := l.sysRepo.GetSystemConfig(ctx, baseRepo.SchemaVersionKey)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logs.CtxErrorf(ctx, "查询表结构版本失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, systemError.SystemErrDatabase, errorx.K("reason", err.Error()))
+	}
+
+	// 数据库版本查询失败不影响其他信息的返回
+	driver, dbVersion, err := l.sysRepo.GetDatabaseInfo(ctx)
+	if err != nil {
+		logs.CtxWarnf(ctx, "查询数据库版本失败: error=%s", err.Error())
+	}
+
+	build := buildinfo.Get()
+	return &dto.SystemInfoDTO{
+		Version:       build.Version,
+		GitCommit:     build.GitCommit,
+		BuildTime:     build.BuildTime,
+		GoVersion:     build.GoVersion,
+		SchemaVersion: schemaVersion,
+		DBDriver:      driver,
+		DBVersion:     dbVersion,
+		Mode:          readonly.Mode(),
+		StartedAt:     buildinfo.StartTime(),
+		UptimeSeconds: int64(buildinfo.Uptime() / time.Second),
+	}, nil
+}
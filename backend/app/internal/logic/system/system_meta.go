@@ -0,0 +1,23 @@
+package system
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	"backend/utils/errorx"
+)
+
+// ListErrorCodes 获取所有已注册的错误码、各语言的消息模板、默认 HTTP 状态码和是否可重试
+func (l *SystemLogic) ListErrorCodes(ctx context.Context) ([]*dto.ErrorCodeDTO, error) {
+	catalog := errorx.Catalog()
+	result := make([]*dto.ErrorCodeDTO, 0, len(catalog))
+	for _, entry := range catalog {
+		result = append(result, &dto.ErrorCodeDTO{
+			Code:       entry.Code,
+			Messages:   entry.Messages,
+			HTTPStatus: entry.HTTPStatus,
+			Retryable:  entry.Retryable,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"backend/app/types/dto"
+	systemError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+
+	"gorm.io/gorm"
+)
+
+// GetQueueStats 获取后台任务队列的任务类型和各状态的任务数量
+func (l *SystemLogic) GetQueueStats(ctx context.Context) (*dto.QueueStatsDTO, error) {
+	counts, err := l.queueRepo.CountByStatus(ctx)
+	if err != nil {
+		logs.CtxErrorf(ctx, "统计后台任务失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, systemError.SystemErrDatabase, errorx.K("reason", err.Error()))
+	}
+
+	return &dto.QueueStatsDTO{
+		Types:     l.jobQueue.Types(),
+		Pending:   counts[string(jobqueue.StatusPending)],
+		Running:   counts[string(jobqueue.StatusRunning)],
+		Succeeded: counts[string(jobqueue.StatusSucceeded)],
+		Dead:      counts[string(jobqueue.StatusDead)],
+	}, nil
+}
+
+// ListQueueJobs 分页查询后台任务，status、jobType 为空时不过滤
+func (l *SystemLogic) ListQueueJobs(ctx context.Context, status string, jobType string, page, pageSize int) ([]*dto.QueueJobDTO, int64, error) {
+	jobs, total, err := l.queueRepo.ListJobs(ctx, status, jobType, page, pageSize)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询后台任务失败: error=%s", err.Error())
+		return nil, 0, errorx.Wrap(err, systemError.SystemErrDatabase, errorx.K("reason", err.Error()))
+	}
+
+	result := make([]*dto.QueueJobDTO, 0, len(jobs))
+	for _, job := range jobs {
+		result = append(result, toQueueJobDTO(job))
+	}
+	return result, total, nil
+}
+
+// GetQueueJob 获取后台任务详情
+func (l *SystemLogic) GetQueueJob(ctx context.Context, jobID uint) (*dto.QueueJobDTO, error) {
+	job, err := l.queueRepo.GetJob(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errorx.New(systemError.SystemErrQueueJobNotFound, errorx.Kf("job_id", "%d", jobID))
+		}
+		logs.CtxErrorf(ctx, "查询后台任务失败: job_id=%d, error=%s", jobID, err.Error())
+		return nil, errorx.Wrap(err, systemError.SystemErrDatabase, errorx.K("reason", err.Error()))
+	}
+	return toQueueJobDTO(job), nil
+}
+
+// manualJobTypes 允许通过接口手动添加的后台任务类型
+// 只包括不需要参数的管理任务；发送邮件、导入等任务的参数决定了发件内容和执行用户，只能由服务端创建
+var manualJobTypes = []string{"item.reindex"}
+
+// EnqueueQueueJob 手动添加后台任务，只允许 manualJobTypes 中的任务类型，不接受参数
+func (l *SystemLogic) EnqueueQueueJob(ctx context.Context, jobType string) (*dto.QueueJobDTO, error) {
+	if !slices.Contains(manualJobTypes, jobType) {
+		return nil, errorx.New(systemError.SystemErrQueueTypeDenied, errorx.K("type", jobType), errorx.K("types", strings.Join(manualJobTypes, ", ")))
+	}
+
+	job, err := l.jobQueue.Enqueue(ctx, jobType, nil)
+	if err != nil {
+		if errors.Is(err, jobqueue.ErrUnknownType) {
+			return nil, errorx.New(systemError.SystemErrQueueUnknownType, errorx.K("type", jobType))
+		}
+		logs.CtxErrorf(ctx, "添加后台任务失败: type=%s, error=%s", jobType, err.Error())
+		return nil, errorx.Wrap(err, systemError.SystemErrDatabase, errorx.K("reason", err.Error()))
+	}
+
+	logs.CtxInfof(ctx, "添加后台任务: job_id=%d, type=%s", job.ID, jobType)
+	return toQueueJobDTO(job), nil
+}
+
+// RetryQueueJob 将死信任务重新放入队列，执行次数清零
+func (l *SystemLogic) RetryQueueJob(ctx context.Context, jobID uint) (*dto.QueueJobDTO, error) {
+	// 先确认任务存在，区分不存在和状态不符
+	if _, err := l.GetQueueJob(ctx, jobID); err != nil {
+		return nil, err
+	}
+
+	ok, err := l.queueRepo.RequeueJob(ctx, jobID, time.Now())
+	if err != nil {
+		logs.CtxErrorf(ctx, "重试后台任务失败: job_id=%d, error=%s", jobID, err.Error())
+		return nil, errorx.Wrap(err, systemError.SystemErrDatabase, errorx.K("reason", err.Error()))
+	}
+	if !ok {
+		return nil, errorx.New(systemError.SystemErrQueueJobNotDead, errorx.Kf("job_id", "%d", jobID))
+	}
+
+	logs.CtxInfof(ctx, "重试后台任务: job_id=%d", jobID)
+	return l.GetQueueJob(ctx, jobID)
+}
+
+// toQueueJobDTO 队列任务转换为 DTO
+func toQueueJobDTO(job *jobqueue.Job) *dto.QueueJobDTO {
+	return &dto.QueueJobDTO{
+		ID:          job.ID,
+		Type:        job.Type,
+		Payload:     job.Payload,
+		Status:      string(job.Status),
+		Attempts:    job.Attempts,
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+		LastError:   job.LastError,
+		CreatedAt:   job.CreatedAt,
+		UpdatedAt:   job.UpdatedAt,
+		FinishedAt:  job.FinishedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package system
+
+import (
+	"context"
+
+	"backend/app/types/dto"
+	"backend/utils/sse"
+)
+
+// ListSSETasks 分页查询本实例上的 SSE 任务，按创建时间倒序，status、kind 为空时不过滤
+func (l *SystemLogic) ListSSETasks(ctx context.Context, status string, kind string, page, pageSize int) ([]*dto.SSETaskDTO, int64, error) {
+	list := l.sseManager.ListTasks(sse.TaskFilter{
+		Status: sse.TaskStatus(status),
+		Kind:   kind,
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	})
+
+	result := make([]*dto.SSETaskDTO, 0, len(list.Tasks))
+	for _, task := range list.Tasks {
+		result = append(result, &dto.SSETaskDTO{
+			TaskID:        task.TaskID,
+			Kind:          task.Kind,
+			Status:        string(task.Status),
+			LastEventID:   task.LastEventID,
+			DroppedEvents: task.DroppedEvents,
+			Subscribers:   task.Subscribers,
+			CreatedAt:     task.CreatedAt,
+			UpdatedAt:     task.UpdatedAt,
+			ExpiresAt:     task.ExpiresAt,
+		})
+	}
+	return result, int64(list.Total), nil
+}
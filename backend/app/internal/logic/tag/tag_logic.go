@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 
+	auditModel "backend/app/model/audit"
+	changeModel "backend/app/model/change"
 	tagModel "backend/app/model/tag"
 	"backend/app/types/dto"
 	tagError "backend/app/types/errorn"
+	"backend/app/types/meta"
 	"backend/utils/errorx"
 	"backend/utils/logs"
 
@@ -23,19 +26,43 @@ type TagRepo interface {
 	GetTagListDTO(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, error)
 }
 
+// EventPublisher 发布领域事件（webhook）
+type EventPublisher interface {
+	Publish(ctx context.Context, event meta.WebhookEvent, data any)
+}
+
+// ChangeRecorder 记录数据变更，用于客户端实时同步
+type ChangeRecorder interface {
+	Record(ctx context.Context, entity string, action string, entityID uint, data any)
+}
+
+// AuditRecorder 记录审计日志
+type AuditRecorder interface {
+	Audit(ctx context.Context, entity string, action string, entityID uint, detail any)
+}
+
 type TagLogicParams struct {
 	fx.In
 
-	TagRepo TagRepo
+	TagRepo   TagRepo
+	Publisher EventPublisher
+	Changes   ChangeRecorder
+	Audit     AuditRecorder
 }
 
 type TagLogic struct {
-	tagRepo TagRepo
+	tagRepo   TagRepo
+	publisher EventPublisher
+	changes   ChangeRecorder
+	audit     AuditRecorder
 }
 
 func NewTagLogic(params TagLogicParams) *TagLogic {
 	return &TagLogic{
-		tagRepo: params.TagRepo,
+		tagRepo:   params.TagRepo,
+		publisher: params.Publisher,
+		changes:   params.Changes,
+		audit:     params.Audit,
 	}
 }
 
@@ -75,13 +102,16 @@ func (l *TagLogic) CreateTag(ctx context.Context, tagName string, tagValue strin
 		return nil, errorx.Wrap(err, tagError.TagErrCreateFailed, errorx.K("reason", err.Error()))
 	}
 
-	return &dto.TagDTO{
+	tagDTO := &dto.TagDTO{
 		TagID:    tag.ID,
 		TagName:  tag.TagName,
 		TagValue: tag.TagValue,
 		Icon:     tag.Icon,
 		Color:    tag.Color,
-	}, nil
+	}
+	l.changes.Record(ctx, changeModel.EntityTag, changeModel.ActionCreated, tag.ID, tagDTO)
+
+	return tagDTO, nil
 }
 
 // UpdateTag 更新标签
@@ -143,19 +173,22 @@ func (l *TagLogic) UpdateTag(ctx context.Context, tagID uint, tagName *string, t
 		return nil, errorx.Wrap(err, tagError.TagErrUpdateFailed, errorx.K("reason", err.Error()))
 	}
 
-	return &dto.TagDTO{
+	tagDTO := &dto.TagDTO{
 		TagID:    tag.ID,
 		TagName:  tag.TagName,
 		TagValue: tag.TagValue,
 		Icon:     tag.Icon,
 		Color:    tag.Color,
-	}, nil
+	}
+	l.changes.Record(ctx, changeModel.EntityTag, changeModel.ActionUpdated, tag.ID, tagDTO)
+
+	return tagDTO, nil
 }
 
 // DeleteTag 删除标签
 func (l *TagLogic) DeleteTag(ctx context.Context, tagID uint) error {
 	// 检查标签是否存在
-	_, err := l.tagRepo.GetTagByID(ctx, tagID)
+	tag, err := l.tagRepo.GetTagByID(ctx, tagID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logs.CtxWarnf(ctx, "标签不存在: tag_id=%d", tagID)
@@ -171,6 +204,16 @@ func (l *TagLogic) DeleteTag(ctx context.Context, tagID uint) error {
 		return errorx.Wrap(err, tagError.TagErrDeleteFailed, errorx.K("reason", err.Error()))
 	}
 
+	l.publisher.Publish(ctx, meta.WebhookEventTagDeleted, &dto.TagDTO{
+		TagID:    tag.ID,
+		TagName:  tag.TagName,
+		TagValue: tag.TagValue,
+		Icon:     tag.Icon,
+		Color:    tag.Color,
+	})
+	l.changes.Record(ctx, changeModel.EntityTag, changeModel.ActionDeleted, tag.ID, nil)
+	l.audit.Audit(ctx, auditModel.EntityTag, auditModel.ActionDeleted, tag.ID, map[string]string{"tag_name": tag.TagName, "tag_value": tag.TagValue})
+
 	return nil
 }
 
@@ -196,15 +239,12 @@ func (l *TagLogic) GetTag(ctx context.Context, tagID uint) (*dto.TagDTO, error)
 }
 
 // GetTagList 获取标签列表
-func (l *TagLogic) GetTagList(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, int, error) {
+func (l *TagLogic) GetTagList(ctx context.Context, page, pageSize int) ([]dto.TagDTO, int64, error) {
 	tags, total, err := l.tagRepo.GetTagListDTO(ctx, page, pageSize)
 	if err != nil {
 		logs.CtxErrorf(ctx, "获取标签列表失败: error=%s", err.Error())
-		return nil, 0, 0, errorx.Wrap(err, tagError.TagErrDatabaseError, errorx.K("reason", err.Error()))
+		return nil, 0, errorx.Wrap(err, tagError.TagErrDatabaseError, errorx.K("reason", err.Error()))
 	}
 
-	// 计算总页数
-	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
-
-	return tags, total, totalPages, nil
+	return tags, total, nil
 }
@@ -0,0 +1,221 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	auditModel "backend/app/model/audit"
+	"backend/app/types/dto"
+	authError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/secret"
+
+	"gorm.io/gorm"
+)
+
+// 操作令牌用途
+const (
+	purposeVerifyEmail   = "verify_email"
+	purposeResetPassword = "reset_password"
+)
+
+// fingerprint 计算操作令牌绑定的数据指纹
+// 邮箱验证绑定邮箱地址，重置密码绑定密码哈希，数据变化后旧链接失效
+func fingerprint(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// normalizeEmail 统一邮箱地址格式
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// displayName 邮件中对用户的称呼
+func displayName(nickName, username string) string {
+	if nickName != "" {
+		return nickName
+	}
+	return username
+}
+
+// UpdateEmail 修改当前用户的邮箱并发送验证邮件，邮箱未变化且未验证时重新发送验证邮件
+func (l *UserLogic) UpdateEmail(ctx context.Context, email string) (*dto.UserDTO, error) {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		logs.CtxWarnf(ctx, "context 中未找到 user_id")
+		return nil, errorx.New(authError.AuthErrTokenRequired)
+	}
+	email = normalizeEmail(email)
+
+	// 检查邮箱是否已被其他用户使用
+	existing, err := l.userRepo.GetUserByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logs.CtxErrorf(ctx, "查询用户失败: email=%s, error=%s", email, err.Error())
+		return nil, errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+	}
+	if existing != nil && existing.ID != userID {
+		logs.CtxWarnf(ctx, "邮箱已被使用: email=%s, user_id=%d", email, existing.ID)
+		return nil, errorx.New(authError.AuthErrEmailAlreadyUsed, errorx.K("email", email))
+	}
+	if existing != nil && existing.EmailVerified {
+		return l.GetUserInfo(ctx)
+	}
+
+	if existing == nil {
+		err = l.userRepo.UpdateUserInfo(ctx, userID, map[string]interface{}{
+			"email":          email,
+			"email_verified": false,
+		})
+		if err != nil {
+			logs.CtxErrorf(ctx, "更新邮箱失败: user_id=%d, error=%s", userID, err.Error())
+			return nil, errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+		}
+		l.audit.Audit(ctx, auditModel.EntityUser, auditModel.ActionEmailChanged, userID, map[string]string{"email": email})
+	}
+
+	user, err := l.GetUserInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 发送验证邮件
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	token, err := l.jwt.GenerateActionToken(userID, tenantID, purposeVerifyEmail, fingerprint(email), l.verifyExpire)
+	if err != nil {
+		logs.CtxErrorf(ctx, "生成邮箱验证令牌失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, authError.AuthErrEmailSendFailed, errorx.K("reason", err.Error()))
+	}
+	if err := l.notifier.SendVerificationEmail(ctx, email, displayName(user.NickName, user.Username), token, l.verifyExpire); err != nil {
+		logs.CtxErrorf(ctx, "发送邮箱验证邮件失败: user_id=%d, error=%s", userID, err.Error())
+		return nil, errorx.Wrap(err, authError.AuthErrEmailSendFailed, errorx.K("reason", err.Error()))
+	}
+
+	logs.CtxInfof(ctx, "已发送邮箱验证邮件: user_id=%d", userID)
+	return user, nil
+}
+
+// VerifyEmail 使用验证邮件中的令牌验证邮箱
+func (l *UserLogic) VerifyEmail(ctx context.Context, token string) error {
+	claims, err := l.jwt.ParseActionToken(token, purposeVerifyEmail)
+	if err != nil {
+		logs.CtxWarnf(ctx, "解析邮箱验证令牌失败: error=%s", err.Error())
+		return errorx.New(authError.AuthErrLinkInvalid)
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	if claims.TenantID != tenantID {
+		logs.CtxWarnf(ctx, "邮箱验证令牌租户不匹配: token_tenant=%s, tenant_id=%s", claims.TenantID, tenantID)
+		return errorx.New(authError.AuthErrLinkInvalid)
+	}
+
+	user, err := l.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.CtxWarnf(ctx, "用户不存在: user_id=%d", claims.UserID)
+			return errorx.New(authError.AuthErrLinkInvalid)
+		}
+		logs.CtxErrorf(ctx, "查询用户失败: user_id=%d, error=%s", claims.UserID, err.Error())
+		return errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+	}
+
+	// 邮箱已修改，旧的验证链接失效
+	if user.Email == "" || fingerprint(user.Email) != claims.Fingerprint {
+		logs.CtxWarnf(ctx, "邮箱验证令牌与当前邮箱不匹配: user_id=%d", user.ID)
+		return errorx.New(authError.AuthErrLinkInvalid)
+	}
+	if user.EmailVerified {
+		return nil
+	}
+
+	if err := l.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{"email_verified": true}); err != nil {
+		logs.CtxErrorf(ctx, "更新邮箱验证状态失败: user_id=%d, error=%s", user.ID, err.Error())
+		return errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+	}
+
+	logs.CtxInfof(ctx, "邮箱验证成功: user_id=%d", user.ID)
+	return nil
+}
+
+// ForgotPassword 向已验证的邮箱发送重置密码邮件
+// 邮箱不存在或未验证时同样返回成功，避免通过接口探测邮箱是否注册
+func (l *UserLogic) ForgotPassword(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+
+	user, err := l.userRepo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.CtxInfof(ctx, "重置密码邮箱不存在: email=%s", email)
+			return nil
+		}
+		logs.CtxErrorf(ctx, "查询用户失败: email=%s, error=%s", email, err.Error())
+		return errorx.Wrap(err, authError.AuthErrUserNotFound, errorx.K("user_uid", email))
+	}
+	if !user.EmailVerified {
+		logs.CtxInfof(ctx, "重置密码邮箱未验证: user_id=%d", user.ID)
+		return nil
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	token, err := l.jwt.GenerateActionToken(user.ID, tenantID, purposeResetPassword, fingerprint(user.PasswordHash), l.resetExpire)
+	if err != nil {
+		logs.CtxErrorf(ctx, "生成重置密码令牌失败: user_id=%d, error=%s", user.ID, err.Error())
+		return nil
+	}
+	if err := l.notifier.SendPasswordResetEmail(ctx, user.Email, displayName(user.NickName, user.Username), token, l.resetExpire); err != nil {
+		logs.CtxErrorf(ctx, "发送重置密码邮件失败: user_id=%d, error=%s", user.ID, err.Error())
+		return nil
+	}
+
+	logs.CtxInfof(ctx, "已发送重置密码邮件: user_id=%d", user.ID)
+	return nil
+}
+
+// ResetPassword 使用重置密码邮件中的令牌设置新密码，密码修改后同一链接不能再次使用
+func (l *UserLogic) ResetPassword(ctx context.Context, token string, password string) error {
+	claims, err := l.jwt.ParseActionToken(token, purposeResetPassword)
+	if err != nil {
+		logs.CtxWarnf(ctx, "解析重置密码令牌失败: error=%s", err.Error())
+		return errorx.New(authError.AuthErrLinkInvalid)
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	if claims.TenantID != tenantID {
+		logs.CtxWarnf(ctx, "重置密码令牌租户不匹配: token_tenant=%s, tenant_id=%s", claims.TenantID, tenantID)
+		return errorx.New(authError.AuthErrLinkInvalid)
+	}
+
+	user, err := l.userRepo.GetUserByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.CtxWarnf(ctx, "用户不存在: user_id=%d", claims.UserID)
+			return errorx.New(authError.AuthErrLinkInvalid)
+		}
+		logs.CtxErrorf(ctx, "查询用户失败: user_id=%d, error=%s", claims.UserID, err.Error())
+		return errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+	}
+	if fingerprint(user.PasswordHash) != claims.Fingerprint {
+		logs.CtxWarnf(ctx, "重置密码令牌已使用: user_id=%d", user.ID)
+		return errorx.New(authError.AuthErrLinkInvalid)
+	}
+
+	passwordHash, err := secret.HashPassword(password)
+	if err != nil {
+		logs.CtxErrorf(ctx, "生成密码哈希失败: user_id=%d, error=%s", user.ID, err.Error())
+		return errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+	}
+	if err := l.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{"password_hash": passwordHash}); err != nil {
+		logs.CtxErrorf(ctx, "更新密码失败: user_id=%d, error=%s", user.ID, err.Error())
+		return errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
+	}
+
+	// 重置密码请求未经过认证，操作人为令牌对应的用户
+	l.audit.Audit(context.WithValue(ctx, meta.ContextKeyUserID, user.ID), auditModel.EntityUser, auditModel.ActionPasswordReset, user.ID, nil)
+	logs.CtxInfof(ctx, "重置密码成功: user_id=%d", user.ID)
+	return nil
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	authError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+)
+
+// localeCacheTTL 用户语言偏好的缓存时间，其他实例修改后最多经过该时间生效
+const localeCacheTTL = time.Minute
+
+type cachedLocale struct {
+	locale  string
+	expires time.Time
+}
+
+// normalizeLocale 将语言偏好转换为已注册语言的写法，空字符串表示清除偏好
+func normalizeLocale(locale string) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return "", nil
+	}
+	registered, ok := errorx.LookupLocale(locale)
+	if !ok {
+		return "", errorx.New(authError.AuthErrLocaleUnsupported,
+			errorx.K("locale", locale), errorx.K("locales", strings.Join(errorx.Locales(), ", ")))
+	}
+	return registered, nil
+}
+
+// UserLocale 查询用户的语言偏好，未设置时返回空字符串，结果缓存 localeCacheTTL
+func (l *UserLogic) UserLocale(ctx context.Context, userID uint) (string, error) {
+	now := time.Now()
+	l.localeMu.Lock()
+	cached, ok := l.localeCache[userID]
+	l.localeMu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.locale, nil
+	}
+
+	user, err := l.userRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询用户语言偏好失败: user_id=%d, error=%s", userID, err.Error())
+		return "", err
+	}
+
+	l.localeMu.Lock()
+	// 顺带清理过期的缓存，避免长时间运行后缓存无限增长
+	for key, c := range l.localeCache {
+		if !now.Before(c.expires) {
+			delete(l.localeCache, key)
+		}
+	}
+	l.localeCache[userID] = cachedLocale{locale: user.Locale, expires: now.Add(localeCacheTTL)}
+	l.localeMu.Unlock()
+
+	return user.Locale, nil
+}
+
+// forgetLocale 修改语言偏好后清除缓存，当前实例立即生效
+func (l *UserLogic) forgetLocale(userID uint) {
+	l.localeMu.Lock()
+	delete(l.localeCache, userID)
+	l.localeMu.Unlock()
+}
@@ -4,7 +4,12 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
+	"time"
 
+	"backend/app/internal/logic/bizmetrics"
+	"backend/app/internal/logic/hooks"
+	auditModel "backend/app/model/audit"
 	userModel "backend/app/model/user"
 	"backend/app/types/consts"
 	"backend/app/types/dto"
@@ -23,17 +28,40 @@ type UserRepo interface {
 	GetUserByUsername(ctx context.Context, username string) (*userModel.User, error)
 	GetUserByID(ctx context.Context, userID uint) (*userModel.User, error)
 	UpdateUserInfo(ctx context.Context, userID uint, updates map[string]interface{}) error
+	GetUserByEmail(ctx context.Context, email string) (*userModel.User, error)
+}
+
+// EmailNotifier 发送账户相关的邮件（邮箱验证、重置密码）
+type EmailNotifier interface {
+	SendVerificationEmail(ctx context.Context, to string, name string, token string, expire time.Duration) error
+	SendPasswordResetEmail(ctx context.Context, to string, name string, token string, expire time.Duration) error
+}
+
+// AuditRecorder 记录审计日志
+type AuditRecorder interface {
+	Audit(ctx context.Context, entity string, action string, entityID uint, detail any)
 }
 
 type UserLogicParams struct {
 	fx.In
 
 	UserRepo UserRepo
+	Notifier EmailNotifier
+	Audit    AuditRecorder
+	Hooks    *hooks.Registry
 }
 
 type UserLogic struct {
-	userRepo UserRepo
-	jwt      *secret.JWT
+	userRepo     UserRepo
+	notifier     EmailNotifier
+	audit        AuditRecorder
+	hooks        *hooks.Registry
+	jwt          *secret.JWT
+	verifyExpire time.Duration
+	resetExpire  time.Duration
+
+	localeMu    sync.Mutex
+	localeCache map[uint]cachedLocale
 }
 
 func NewUserLogic(params UserLogicParams) *UserLogic {
@@ -53,6 +81,16 @@ func NewUserLogic(params UserLogicParams) *UserLogic {
 		logs.Error("获取 JWT_SECRET 配置失败", "error", err.Error())
 		panic(err)
 	}
+	verifyExpire, err := envx.GetDurationWithDefault(consts.EmailVerifyExpire, 24*time.Hour)
+	if err != nil {
+		logs.Error("获取 EMAIL_VERIFY_EXPIRE 配置失败", "error", err.Error())
+		panic(err)
+	}
+	resetExpire, err := envx.GetDurationWithDefault(consts.PasswordResetExpire, time.Hour)
+	if err != nil {
+		logs.Error("获取 PASSWORD_RESET_EXPIRE 配置失败", "error", err.Error())
+		panic(err)
+	}
 
 	jwt := secret.NewJWT(secret.TokenConfig{
 		AccessTokenExpire:  accessTokenExpire,
@@ -61,8 +99,14 @@ func NewUserLogic(params UserLogicParams) *UserLogic {
 	})
 
 	return &UserLogic{
-		userRepo: params.UserRepo,
-		jwt:      jwt,
+		userRepo:     params.UserRepo,
+		notifier:     params.Notifier,
+		audit:        params.Audit,
+		hooks:        params.Hooks,
+		jwt:          jwt,
+		verifyExpire: verifyExpire,
+		resetExpire:  resetExpire,
+		localeCache:  make(map[uint]cachedLocale),
 	}
 }
 
@@ -72,6 +116,8 @@ func (l *UserLogic) Login(ctx context.Context, username string, password string)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logs.CtxWarnf(ctx, "用户不存在: username=%s", username)
+			bizmetrics.Login(bizmetrics.LoginFailed)
+			l.audit.Audit(ctx, auditModel.EntityUser, auditModel.ActionLoginFailed, 0, map[string]string{"username": username, "reason": "user_not_found"})
 			return nil, nil, errorx.New(authError.AuthErrUserNotFound, errorx.K("user_uid", username))
 		}
 		logs.CtxErrorf(ctx, "查询用户失败: username=%s, error=%s", username, err.Error())
@@ -81,18 +127,23 @@ func (l *UserLogic) Login(ctx context.Context, username string, password string)
 	// 验证密码
 	if !secret.VerifyPassword(password, user.PasswordHash) {
 		logs.CtxWarnf(ctx, "密码错误: username=%s, user_id=%d", username, user.ID)
+		bizmetrics.Login(bizmetrics.LoginFailed)
+		l.audit.Audit(ctx, auditModel.EntityUser, auditModel.ActionLoginFailed, user.ID, map[string]string{"username": username, "reason": "password_incorrect"})
 		return nil, nil, errorx.New(authError.AuthErrPasswordIncorrect)
 	}
 
+	// 当前租户（未启用多租户时为空）
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+
 	// 生成 access token
-	accessToken, _, err := l.jwt.GenerateAccessToken(user.ID)
+	accessToken, _, err := l.jwt.GenerateAccessTokenForTenant(user.ID, tenantID)
 	if err != nil {
 		logs.CtxErrorf(ctx, "生成 access token 失败: user_id=%d, error=%s", user.ID, err.Error())
 		return nil, nil, errorx.Wrap(err, authError.AuthErrTokenInvalid)
 	}
 
 	// 生成 refresh token
-	refreshToken, _, err := l.jwt.GenerateRefreshToken(user.ID)
+	refreshToken, _, err := l.jwt.GenerateRefreshTokenForTenant(user.ID, tenantID)
 	if err != nil {
 		logs.CtxErrorf(ctx, "生成 refresh token 失败: user_id=%d, error=%s", user.ID, err.Error())
 		return nil, nil, errorx.Wrap(err, authError.AuthErrTokenInvalid)
@@ -111,6 +162,12 @@ func (l *UserLogic) Login(ctx context.Context, username string, password string)
 		RefreshToken: refreshToken,
 	}
 
+	bizmetrics.Login(bizmetrics.LoginSucceeded)
+	// 登录请求未经过认证，操作人为登录的用户
+	loginCtx := context.WithValue(ctx, meta.ContextKeyUserID, user.ID)
+	l.audit.Audit(loginCtx, auditModel.EntityUser, auditModel.ActionLogin, user.ID, nil)
+	l.hooks.AfterUserLogin.Notify(loginCtx, &hooks.UserLogin{User: userDTO})
+
 	return userDTO, tokenDTO, nil
 }
 
@@ -132,6 +189,13 @@ func (l *UserLogic) RefreshToken(ctx context.Context, refreshToken string) (*dto
 		}
 	}
 
+	// 验证 refresh token 与当前租户一致
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	if claims.TenantID != tenantID {
+		logs.CtxWarnf(ctx, "refresh token 租户不匹配: token_tenant=%s, tenant_id=%s", claims.TenantID, tenantID)
+		return nil, errorx.New(authError.TenantErrMismatch)
+	}
+
 	// 验证用户是否存在
 	user, err := l.userRepo.GetUserByID(ctx, claims.UserID)
 	if err != nil {
@@ -144,14 +208,14 @@ func (l *UserLogic) RefreshToken(ctx context.Context, refreshToken string) (*dto
 	}
 
 	// 生成新的 access token
-	accessToken, _, err := l.jwt.GenerateAccessToken(user.ID)
+	accessToken, _, err := l.jwt.GenerateAccessTokenForTenant(user.ID, tenantID)
 	if err != nil {
 		logs.CtxErrorf(ctx, "生成 access token 失败: user_id=%d, error=%s", user.ID, err.Error())
 		return nil, errorx.Wrap(err, authError.AuthErrTokenInvalid)
 	}
 
 	// 生成新的 refresh token
-	newRefreshToken, _, err := l.jwt.GenerateRefreshToken(user.ID)
+	newRefreshToken, _, err := l.jwt.GenerateRefreshTokenForTenant(user.ID, tenantID)
 	if err != nil {
 		logs.CtxErrorf(ctx, "生成 refresh token 失败: user_id=%d, error=%s", user.ID, err.Error())
 		return nil, errorx.Wrap(err, authError.AuthErrTokenInvalid)
@@ -162,6 +226,8 @@ func (l *UserLogic) RefreshToken(ctx context.Context, refreshToken string) (*dto
 		RefreshToken: newRefreshToken,
 	}
 
+	l.audit.Audit(context.WithValue(ctx, meta.ContextKeyUserID, user.ID), auditModel.EntityUser, auditModel.ActionTokenRefreshed, user.ID, nil)
+
 	return tokenDTO, nil
 }
 
@@ -192,16 +258,20 @@ func (l *UserLogic) GetUserInfo(ctx context.Context) (*dto.UserDTO, error) {
 
 	// 构建返回数据
 	userDTO := &dto.UserDTO{
-		UserID:   user.ID,
-		Username: user.Username,
-		NickName: user.NickName,
-		Avatar:   user.Avatar,
+		UserID:        user.ID,
+		Username:      user.Username,
+		NickName:      user.NickName,
+		Avatar:        user.Avatar,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Locale:        user.Locale,
 	}
 
 	return userDTO, nil
 }
 
-func (l *UserLogic) UpdateUserInfo(ctx context.Context, nickName *string, avatar *string) (*dto.UserDTO, error) {
+// UpdateUserInfo 更新当前用户的信息，参数为 nil 时不修改；locale 为空字符串时清除语言偏好
+func (l *UserLogic) UpdateUserInfo(ctx context.Context, nickName *string, avatar *string, locale *string) (*dto.UserDTO, error) {
 	// 从 context 中获取用户ID
 	userIDValue := ctx.Value(meta.ContextKeyUserID)
 	if userIDValue == nil {
@@ -223,6 +293,13 @@ func (l *UserLogic) UpdateUserInfo(ctx context.Context, nickName *string, avatar
 	if avatar != nil {
 		updates["avatar"] = *avatar
 	}
+	if locale != nil {
+		normalized, err := normalizeLocale(*locale)
+		if err != nil {
+			return nil, err
+		}
+		updates["locale"] = normalized
+	}
 
 	// 如果没有需要更新的字段，直接返回当前用户信息
 	if len(updates) == 0 {
@@ -236,6 +313,10 @@ func (l *UserLogic) UpdateUserInfo(ctx context.Context, nickName *string, avatar
 		return nil, errorx.Wrap(err, authError.AuthErrUserUpdateFailed)
 	}
 
+	if locale != nil {
+		l.forgetLocale(userID)
+	}
+
 	// 重新查询用户信息
 	user, err := l.userRepo.GetUserByID(ctx, userID)
 	if err != nil {
@@ -249,10 +330,13 @@ func (l *UserLogic) UpdateUserInfo(ctx context.Context, nickName *string, avatar
 
 	// 构建返回数据
 	userDTO := &dto.UserDTO{
-		UserID:   user.ID,
-		Username: user.Username,
-		NickName: user.NickName,
-		Avatar:   user.Avatar,
+		UserID:        user.ID,
+		Username:      user.Username,
+		NickName:      user.NickName,
+		Avatar:        user.Avatar,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		Locale:        user.Locale,
 	}
 
 	return userDTO, nil
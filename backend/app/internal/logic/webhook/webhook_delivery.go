@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"backend/app/internal/logic/bizmetrics"
+	webhookModel "backend/app/model/webhook"
+	"backend/app/types/dto"
+	"backend/app/types/meta"
+	"backend/utils/buildinfo"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/retry"
+	"backend/utils/webhook"
+
+	"gorm.io/gorm"
+)
+
+// maxResponseBodyLength 投递记录中保存的响应内容最大字节数
+const maxResponseBodyLength = 1024
+
+// deliverPayload 投递任务参数
+type deliverPayload struct {
+	DeliveryID uint `json:"delivery_id"`
+}
+
+// Publish 向订阅了事件的 webhook 发布事件
+// 每个 webhook 创建一条投递记录并通过后台任务队列异步投递，失败只记录日志，不影响调用方；试运行时不发布
+func (l *WebhookLogic) Publish(ctx context.Context, event meta.WebhookEvent, data any) {
+	if meta.IsDryRun(ctx) {
+		return
+	}
+	hooks, err := l.webhookRepo.GetEnabledWebhooks(ctx)
+	if err != nil {
+		logs.CtxErrorf(ctx, "查询 webhook 失败: event=%s, error=%s", event, err.Error())
+		return
+	}
+
+	var body []byte
+	for _, hook := range hooks {
+		if !subscribed(hook, event) {
+			continue
+		}
+		if body == nil {
+			if body, err = json.Marshal(dto.WebhookEventDTO{Event: string(event), CreatedAt: time.Now(), Data: data}); err != nil {
+				logs.CtxErrorf(ctx, "序列化 webhook 事件失败: event=%s, error=%s", event, err.Error())
+				return
+			}
+		}
+
+		delivery := &webhookModel.WebhookDelivery{
+			WebhookID: hook.ID,
+			Event:     string(event),
+			Payload:   string(body),
+			Status:    webhookModel.DeliveryStatusPending,
+		}
+		if err := l.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			logs.CtxErrorf(ctx, "创建 webhook 投递记录失败: webhook_id=%d, event=%s, error=%s", hook.ID, event, err.Error())
+			continue
+		}
+		if _, err := l.jobQueue.Enqueue(ctx, DeliverJobType, deliverPayload{DeliveryID: delivery.ID}, jobqueue.MaxAttempts(l.maxAttempts)); err != nil {
+			logs.CtxErrorf(ctx, "webhook 投递任务入队失败: delivery_id=%d, error=%s", delivery.ID, err.Error())
+			l.updateDelivery(ctx, delivery.ID, map[string]interface{}{
+				"status":     webhookModel.DeliveryStatusFailed,
+				"last_error": err.Error(),
+			})
+		}
+	}
+}
+
+// Deliver 执行一次投递，由后台任务队列调用
+// 2xx 响应视为成功；除 408、429 外的 4xx 响应、webhook 已删除或停用、目标为内网地址时不再重试
+func (l *WebhookLogic) Deliver(ctx context.Context, job *jobqueue.Job) error {
+	var payload deliverPayload
+	if err := job.Decode(&payload); err != nil {
+		return retry.Permanent(err)
+	}
+
+	delivery, err := l.webhookRepo.GetDeliveryByID(ctx, payload.DeliveryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return retry.Permanent(fmt.Errorf("投递记录不存在: %d", payload.DeliveryID))
+		}
+		return err
+	}
+	// 上次执行已成功但任务状态未更新（如进程退出），不重复投递
+	if delivery.Status == webhookModel.DeliveryStatusSucceeded {
+		return nil
+	}
+
+	hook, err := l.webhookRepo.GetWebhookByID(ctx, delivery.WebhookID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if hook == nil || !hook.Enabled {
+		reason := "webhook 已删除或停用"
+		l.updateDelivery(ctx, delivery.ID, map[string]interface{}{
+			"status":     webhookModel.DeliveryStatusFailed,
+			"last_error": reason,
+		})
+		return retry.Permanent(errors.New(reason))
+	}
+
+	start := time.Now()
+	statusCode, responseBody, deliverErr := l.send(ctx, hook, delivery)
+	updates := map[string]interface{}{
+		"attempts":        delivery.Attempts + 1,
+		"response_status": statusCode,
+		"response_body":   responseBody,
+		"duration_ms":     time.Since(start).Milliseconds(),
+		"last_error":      "",
+	}
+
+	switch {
+	case deliverErr == nil:
+		now := time.Now()
+		updates["status"] = webhookModel.DeliveryStatusSucceeded
+		updates["delivered_at"] = &now
+	case retry.IsPermanent(deliverErr) || job.Attempts >= job.MaxAttempts:
+		updates["status"] = webhookModel.DeliveryStatusFailed
+		updates["last_error"] = deliverErr.Error()
+	default:
+		updates["status"] = webhookModel.DeliveryStatusRetrying
+		updates["last_error"] = deliverErr.Error()
+	}
+	l.updateDelivery(ctx, delivery.ID, updates)
+	bizmetrics.WebhookDelivered(delivery.Event, updates["status"].(string))
+
+	if deliverErr != nil {
+		logs.CtxWarnf(ctx, "webhook 投递失败: delivery_id=%d, webhook_id=%d, attempt=%d, error=%s",
+			delivery.ID, hook.ID, job.Attempts, deliverErr.Error())
+	}
+	return deliverErr
+}
+
+// send 发送签名后的请求，返回响应状态码和截断后的响应内容
+func (l *WebhookLogic) send(ctx context.Context, hook *webhookModel.Webhook, delivery *webhookModel.WebhookDelivery) (int, string, error) {
+	body := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", retry.Permanent(err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Peano-Webhook/"+buildinfo.Version)
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(hook.Secret, timestamp, body))
+	req.Header.Set(webhook.TimestampHeader, fmt.Sprintf("%d", timestamp))
+	req.Header.Set(webhook.EventHeader, delivery.Event)
+	req.Header.Set(webhook.DeliveryHeader, fmt.Sprintf("%d", delivery.ID))
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		if errors.Is(err, webhook.ErrPrivateAddress) {
+			return 0, "", retry.Permanent(err)
+		}
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodyLength))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, string(respBody), nil
+	}
+
+	err = fmt.Errorf("响应状态码 %d", resp.StatusCode)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		err = retry.Permanent(err)
+	}
+	return resp.StatusCode, string(respBody), err
+}
+
+// updateDelivery 更新投递记录，失败只记录日志
+func (l *WebhookLogic) updateDelivery(ctx context.Context, deliveryID uint, updates map[string]interface{}) {
+	if err := l.webhookRepo.UpdateDelivery(ctx, deliveryID, updates); err != nil {
+		logs.CtxErrorf(ctx, "更新 webhook 投递记录失败: delivery_id=%d, error=%s", deliveryID, err.Error())
+	}
+}
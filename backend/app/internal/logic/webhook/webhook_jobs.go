@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"backend/utils/jobqueue"
+
+	"go.uber.org/fx"
+)
+
+// DeliverJobType 投递 webhook 的后台任务类型
+const DeliverJobType = "webhook.deliver"
+
+type QueueHandlersParams struct {
+	fx.In
+
+	Queue        *jobqueue.Queue
+	WebhookLogic *WebhookLogic
+}
+
+// RegisterQueueHandlers 注册 webhook 相关的后台任务处理函数
+func RegisterQueueHandlers(params QueueHandlersParams) error {
+	return params.Queue.Handle(DeliverJobType, params.WebhookLogic.Deliver)
+}
@@ -0,0 +1,335 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	auditModel "backend/app/model/audit"
+	webhookModel "backend/app/model/webhook"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	webhookError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/webhook"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// maxURLLength 回调地址最大长度，与 webhook.url 列宽一致
+const maxURLLength = 512
+
+type WebhookRepo interface {
+	CreateWebhook(ctx context.Context, webhook *webhookModel.Webhook) error
+	UpdateWebhook(ctx context.Context, webhook *webhookModel.Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID uint) error
+	GetWebhookByID(ctx context.Context, webhookID uint) (*webhookModel.Webhook, error)
+	GetWebhookList(ctx context.Context, page, pageSize int) ([]*webhookModel.Webhook, int64, error)
+	GetEnabledWebhooks(ctx context.Context) ([]*webhookModel.Webhook, error)
+	CreateDelivery(ctx context.Context, delivery *webhookModel.WebhookDelivery) error
+	UpdateDelivery(ctx context.Context, deliveryID uint, updates map[string]interface{}) error
+	GetDeliveryByID(ctx context.Context, deliveryID uint) (*webhookModel.WebhookDelivery, error)
+	GetDeliveryList(ctx context.Context, webhookID uint, status string, page, pageSize int) ([]*webhookModel.WebhookDelivery, int64, error)
+}
+
+type JobQueue interface {
+	Enqueue(ctx context.Context, jobType string, payload any, opts ...jobqueue.EnqueueOption) (*jobqueue.Job, error)
+}
+
+// AuditRecorder 记录审计日志
+type AuditRecorder interface {
+	Audit(ctx context.Context, entity string, action string, entityID uint, detail any)
+}
+
+type WebhookLogicParams struct {
+	fx.In
+
+	WebhookRepo WebhookRepo
+	JobQueue    JobQueue
+	Audit       AuditRecorder
+}
+
+type WebhookLogic struct {
+	webhookRepo WebhookRepo
+	jobQueue    JobQueue
+	audit       AuditRecorder
+	client      *http.Client
+	maxAttempts int
+}
+
+func NewWebhookLogic(params WebhookLogicParams) (*WebhookLogic, error) {
+	timeout, err := envx.GetDurationWithDefault(consts.WebhookTimeout, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	maxAttempts, err := envx.GetIntWithDefaultAndMin(consts.WebhookMaxAttempts, 8, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookLogic{
+		webhookRepo: params.WebhookRepo,
+		jobQueue:    params.JobQueue,
+		audit:       params.Audit,
+		client:      webhook.NewClient(timeout, envx.GetBool(consts.WebhookAllowPrivateNetwork, false)),
+		maxAttempts: maxAttempts,
+	}, nil
+}
+
+// CreateWebhook 创建 webhook，secret 为空时自动生成
+// 返回结果中包含完整的签名密钥，之后只能查询到掩码
+func (l *WebhookLogic) CreateWebhook(ctx context.Context, rawURL string, secret string, events []string, description string) (*dto.WebhookDTO, error) {
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+	eventList, err := normalizeEvents(events)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		if secret, err = webhook.GenerateSecret(); err != nil {
+			logs.CtxErrorf(ctx, "生成 webhook 签名密钥失败: error=%s", err.Error())
+			return nil, errorx.Wrap(err, webhookError.WebhookErrSecretFailed, errorx.K("reason", err.Error()))
+		}
+	}
+
+	hook := &webhookModel.Webhook{
+		URL:         rawURL,
+		Secret:      secret,
+		Events:      eventList,
+		Description: description,
+		Enabled:     true,
+	}
+	if err := l.webhookRepo.CreateWebhook(ctx, hook); err != nil {
+		logs.CtxErrorf(ctx, "创建 webhook 失败: error=%s", err.Error())
+		return nil, errorx.Wrap(err, webhookError.WebhookErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	// 审计详情中的签名密钥为掩码
+	l.audit.Audit(ctx, auditModel.EntityWebhook, auditModel.ActionCreated, hook.ID, toWebhookDTO(hook))
+
+	result := toWebhookDTO(hook)
+	result.Secret = hook.Secret
+	return result, nil
+}
+
+// UpdateWebhook 更新 webhook，参数为 nil 时不修改对应字段
+// rotateSecret 为 true 时重新生成签名密钥，并在返回结果中包含完整的新密钥
+func (l *WebhookLogic) UpdateWebhook(ctx context.Context, webhookID uint, rawURL *string, events []string, description *string, enabled *bool, rotateSecret bool) (*dto.WebhookDTO, error) {
+	hook, err := l.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	before := toWebhookDTO(hook)
+
+	if rawURL != nil {
+		if err := validateURL(*rawURL); err != nil {
+			return nil, err
+		}
+		hook.URL = *rawURL
+	}
+	if events != nil {
+		if hook.Events, err = normalizeEvents(events); err != nil {
+			return nil, err
+		}
+	}
+	if description != nil {
+		hook.Description = *description
+	}
+	if enabled != nil {
+		hook.Enabled = *enabled
+	}
+	if rotateSecret {
+		if hook.Secret, err = webhook.GenerateSecret(); err != nil {
+			logs.CtxErrorf(ctx, "生成 webhook 签名密钥失败: error=%s", err.Error())
+			return nil, errorx.Wrap(err, webhookError.WebhookErrSecretFailed, errorx.K("reason", err.Error()))
+		}
+	}
+
+	if err := l.webhookRepo.UpdateWebhook(ctx, hook); err != nil {
+		logs.CtxErrorf(ctx, "更新 webhook 失败: webhook_id=%d, error=%s", webhookID, err.Error())
+		return nil, errorx.Wrap(err, webhookError.WebhookErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	l.audit.Audit(ctx, auditModel.EntityWebhook, auditModel.ActionUpdated, hook.ID, map[string]any{
+		"before":        before,
+		"after":         toWebhookDTO(hook),
+		"rotate_secret": rotateSecret,
+	})
+
+	result := toWebhookDTO(hook)
+	if rotateSecret {
+		result.Secret = hook.Secret
+	}
+	return result, nil
+}
+
+// DeleteWebhook 删除 webhook 及其投递记录，尚未执行的投递任务会因 webhook 不存在而放弃
+func (l *WebhookLogic) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	hook, err := l.getWebhook(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := l.webhookRepo.DeleteWebhook(ctx, webhookID); err != nil {
+		logs.CtxErrorf(ctx, "删除 webhook 失败: webhook_id=%d, error=%s", webhookID, err.Error())
+		return errorx.Wrap(err, webhookError.WebhookErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	l.audit.Audit(ctx, auditModel.EntityWebhook, auditModel.ActionDeleted, webhookID, toWebhookDTO(hook))
+	return nil
+}
+
+// GetWebhook 获取 webhook
+func (l *WebhookLogic) GetWebhook(ctx context.Context, webhookID uint) (*dto.WebhookDTO, error) {
+	hook, err := l.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return toWebhookDTO(hook), nil
+}
+
+// GetWebhookList 获取 webhook 列表
+func (l *WebhookLogic) GetWebhookList(ctx context.Context, page, pageSize int) ([]*dto.WebhookDTO, int64, error) {
+	hooks, total, err := l.webhookRepo.GetWebhookList(ctx, page, pageSize)
+	if err != nil {
+		logs.CtxErrorf(ctx, "获取 webhook 列表失败: error=%s", err.Error())
+		return nil, 0, errorx.Wrap(err, webhookError.WebhookErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	result := make([]*dto.WebhookDTO, 0, len(hooks))
+	for _, hook := range hooks {
+		result = append(result, toWebhookDTO(hook))
+	}
+	return result, total, nil
+}
+
+// GetDeliveryList 获取 webhook 的投递记录，status 为空时不过滤
+func (l *WebhookLogic) GetDeliveryList(ctx context.Context, webhookID uint, status string, page, pageSize int) ([]*dto.WebhookDeliveryDTO, int64, error) {
+	if _, err := l.getWebhook(ctx, webhookID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := l.webhookRepo.GetDeliveryList(ctx, webhookID, status, page, pageSize)
+	if err != nil {
+		logs.CtxErrorf(ctx, "获取 webhook 投递记录失败: webhook_id=%d, error=%s", webhookID, err.Error())
+		return nil, 0, errorx.Wrap(err, webhookError.WebhookErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+
+	result := make([]*dto.WebhookDeliveryDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, toDeliveryDTO(delivery))
+	}
+	return result, total, nil
+}
+
+// getWebhook 查询 webhook，不存在时返回 WebhookErrNotFound
+func (l *WebhookLogic) getWebhook(ctx context.Context, webhookID uint) (*webhookModel.Webhook, error) {
+	hook, err := l.webhookRepo.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logs.CtxWarnf(ctx, "webhook 不存在: webhook_id=%d", webhookID)
+			return nil, errorx.New(webhookError.WebhookErrNotFound, errorx.Kf("webhook_id", "%d", webhookID))
+		}
+		logs.CtxErrorf(ctx, "查询 webhook 失败: webhook_id=%d, error=%s", webhookID, err.Error())
+		return nil, errorx.Wrap(err, webhookError.WebhookErrDatabaseError, errorx.K("reason", err.Error()))
+	}
+	return hook, nil
+}
+
+// validateURL 校验回调地址，只支持 http 和 https
+// 是否为内网地址在投递时按解析后的 IP 检查
+func validateURL(rawURL string) error {
+	if len(rawURL) > maxURLLength {
+		return errorx.New(webhookError.WebhookErrInvalidURL, errorx.K("url", rawURL))
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return errorx.New(webhookError.WebhookErrInvalidURL, errorx.K("url", rawURL))
+	}
+	return nil
+}
+
+// normalizeEvents 校验订阅的事件并去重，按 meta.WebhookEvents 的顺序以逗号拼接
+func normalizeEvents(events []string) (string, error) {
+	if len(events) == 0 {
+		return "", errorx.New(webhookError.WebhookErrParamRequired, errorx.K("param", "events"))
+	}
+	selected := make(map[meta.WebhookEvent]bool, len(events))
+	for _, event := range events {
+		e := meta.WebhookEvent(strings.TrimSpace(event))
+		if !e.Valid() {
+			return "", errorx.New(webhookError.WebhookErrInvalidEvent, errorx.K("event", event))
+		}
+		selected[e] = true
+	}
+
+	list := make([]string, 0, len(selected))
+	for _, e := range meta.WebhookEvents {
+		if selected[e] {
+			list = append(list, string(e))
+		}
+	}
+	return strings.Join(list, ","), nil
+}
+
+// subscribed webhook 是否订阅了事件
+func subscribed(hook *webhookModel.Webhook, event meta.WebhookEvent) bool {
+	for _, e := range strings.Split(hook.Events, ",") {
+		if e == string(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecret 只保留密钥前缀和前 4 位
+func maskSecret(secret string) string {
+	visible := len("whsec_") + 4
+	if len(secret) <= visible {
+		return "****"
+	}
+	return secret[:visible] + "****"
+}
+
+func toWebhookDTO(hook *webhookModel.Webhook) *dto.WebhookDTO {
+	events := []string{}
+	if hook.Events != "" {
+		events = strings.Split(hook.Events, ",")
+	}
+	return &dto.WebhookDTO{
+		WebhookID:   hook.ID,
+		URL:         hook.URL,
+		Secret:      maskSecret(hook.Secret),
+		Events:      events,
+		Description: hook.Description,
+		Enabled:     hook.Enabled,
+		CreatedAt:   hook.CreatedAt,
+		UpdatedAt:   hook.UpdatedAt,
+	}
+}
+
+func toDeliveryDTO(delivery *webhookModel.WebhookDelivery) *dto.WebhookDeliveryDTO {
+	return &dto.WebhookDeliveryDTO{
+		DeliveryID:     delivery.ID,
+		WebhookID:      delivery.WebhookID,
+		Event:          delivery.Event,
+		Payload:        []byte(delivery.Payload),
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		LastError:      delivery.LastError,
+		DurationMs:     delivery.DurationMs,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
@@ -0,0 +1,82 @@
+package audit
+
+import (
+	"context"
+
+	"backend/app/internal/repo/base"
+	auditModel "backend/app/model/audit"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type AuditRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+type AuditRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditRepo(params AuditRepoParams) *AuditRepo {
+	return &AuditRepo{
+		db: params.DB,
+	}
+}
+
+// CreateAuditLog 记录审计日志
+func (r *AuditRepo) CreateAuditLog(ctx context.Context, log *auditModel.AuditLog) error {
+	return base.Conn(ctx, r.db).Create(log).Error
+}
+
+// buildFilter 将查询条件转换为查询条件构建器
+func buildFilter(filter auditModel.Filter) *base.Filter {
+	return base.NewFilter().
+		Eq("user_id", filter.UserID).
+		Eq("entity", filter.Entity).
+		Eq("action", filter.Action).
+		Gte("created_at", filter.DateStart).
+		Lt("created_at", filter.DateEnd)
+}
+
+// CountAuditLogs 统计符合条件的审计日志数
+func (r *AuditRepo) CountAuditLogs(ctx context.Context, filter auditModel.Filter) (int64, error) {
+	var total int64
+	err := base.Conn(ctx, r.db).
+		Model(&auditModel.AuditLog{}).
+		Scopes(buildFilter(filter).Scope()).
+		Count(&total).Error
+	return total, err
+}
+
+// GetAuditLogList 分页获取审计日志，按ID倒序
+func (r *AuditRepo) GetAuditLogList(ctx context.Context, filter auditModel.Filter, page, pageSize int) ([]*auditModel.AuditLog, int64, error) {
+	var auditLogs []*auditModel.AuditLog
+	var total int64
+
+	query := base.Conn(ctx, r.db).Model(&auditModel.AuditLog{}).Scopes(buildFilter(filter).Scope())
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&auditLogs).Error; err != nil {
+		return nil, 0, err
+	}
+	return auditLogs, total, nil
+}
+
+// GetAuditLogsBefore 按ID倒序获取ID小于 beforeID 的审计日志，最多 limit 条，beforeID 为 0 时从最新开始
+func (r *AuditRepo) GetAuditLogsBefore(ctx context.Context, filter auditModel.Filter, beforeID uint, limit int) ([]*auditModel.AuditLog, error) {
+	var auditLogs []*auditModel.AuditLog
+	query := base.Conn(ctx, r.db).Scopes(buildFilter(filter).Scope())
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if err := query.Order("id DESC").Limit(limit).Find(&auditLogs).Error; err != nil {
+		return nil, err
+	}
+	return auditLogs, nil
+}
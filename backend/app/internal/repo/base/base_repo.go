@@ -3,24 +3,40 @@ package base
 import (
 	"context"
 	"errors"
+	"strconv"
 
+	auditModel "backend/app/model/audit"
+	changeModel "backend/app/model/change"
 	fileModel "backend/app/model/file"
 	itemModel "backend/app/model/item"
+	notifyModel "backend/app/model/notify"
+	queueModel "backend/app/model/queue"
 	relationModel "backend/app/model/relation"
 	systemModel "backend/app/model/system"
 	tagModel "backend/app/model/tag"
 	userModel "backend/app/model/user"
+	webhookModel "backend/app/model/webhook"
 	"backend/app/types/consts"
 	"backend/utils/envx"
 	"backend/utils/logs"
+	"backend/utils/readonly"
 	"backend/utils/secret"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
+const (
+	// SchemaVersion 表结构版本，修改模型结构（新增表、字段或索引）时递增
+	SchemaVersion = 11
+	// SchemaVersionKey 表结构版本在系统配置中的键
+	SchemaVersionKey = "schema_version"
+)
+
 type UserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*userModel.User, error)
 	CreateUser(ctx context.Context, user *userModel.User) error
+	UpdateUserInfo(ctx context.Context, userID uint, updates map[string]interface{}) error
 }
 
 type SysRepo interface {
@@ -43,21 +59,37 @@ type BaseRepo struct {
 	db       *gorm.DB
 }
 
-// InitBaseData 初始化基础数据
-// 包括：数据库表迁移、系统配置初始化、用户数据初始化
-func InitBaseData(params BaseRepoParams) error {
-	r := &BaseRepo{
+func NewBaseRepo(params BaseRepoParams) *BaseRepo {
+	return &BaseRepo{
 		userRepo: params.UserRepo,
 		sysRepo:  params.SysRepo,
 		db:       params.DB,
 	}
+}
+
+// InitBaseData 初始化基础数据
+// 包括：数据库表迁移、表结构版本记录、系统配置初始化、用户数据初始化
+func InitBaseData(params BaseRepoParams) error {
+	r := NewBaseRepo(params)
 
 	// 1. 初始化数据库表
 	if err := r.InitTables(); err != nil {
 		return err
 	}
 
-	// 2. 初始化系统配置
+	// 只读模式下不写入基础数据
+	if readonly.Enabled() {
+		logs.Warn("只读模式，跳过基础数据初始化", "reason", readonly.Reason())
+		return nil
+	}
+
+	// 2. 记录表结构版本
+	if err := r.sysRepo.CreateOrUpdateSystemConfig(context.Background(), SchemaVersionKey, strconv.Itoa(SchemaVersion)); err != nil {
+		logs.Error("记录表结构版本失败", "error", err.Error())
+		return err
+	}
+
+	// 3. 初始化系统配置
 	// 如果系统已初始化（配置存在且值为"ok"），则跳过用户数据初始化
 	alreadyInitialized, err := r.InitSystemConfig()
 	if err != nil {
@@ -69,7 +101,7 @@ func InitBaseData(params BaseRepoParams) error {
 		return nil
 	}
 
-	// 3. 初始化用户数据（仅在首次启动时执行）
+	// 4. 初始化用户数据（仅在首次启动时执行）
 	if err := r.InitUsers(); err != nil {
 		return err
 	}
@@ -81,20 +113,31 @@ func InitBaseData(params BaseRepoParams) error {
 // 使用 AutoMigrate 自动创建或更新表结构
 func (r *BaseRepo) InitTables() error {
 	logs.Info("初始化数据库表")
-	err := r.db.AutoMigrate(
+	err := r.db.AutoMigrate(models()...)
+	if err != nil {
+		logs.Error("初始化数据库表失败", "error", err.Error())
+		return err
+	}
+	logs.Info("数据库表初始化完成")
+	return nil
+}
+
+// models 需要迁移的数据模型
+func models() []interface{} {
+	return []interface{}{
 		&userModel.User{},
 		&systemModel.SystemConfig{},
 		&fileModel.File{},
 		&itemModel.Item{},
 		&tagModel.Tag{},
 		&relationModel.ItemTag{},
-	)
-	if err != nil {
-		logs.Error("初始化数据库表失败", "error", err.Error())
-		return err
+		&queueModel.QueueJob{},
+		&webhookModel.Webhook{},
+		&webhookModel.WebhookDelivery{},
+		&notifyModel.NotificationPreference{},
+		&changeModel.Change{},
+		&auditModel.AuditLog{},
 	}
-	logs.Info("数据库表初始化完成")
-	return nil
 }
 
 // InitSystemConfig 初始化系统配置
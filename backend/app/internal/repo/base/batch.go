@@ -0,0 +1,52 @@
+package base
+
+import (
+	"context"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+
+	"gorm.io/gorm"
+)
+
+// DefaultBatchSize 默认每批插入的记录数
+// SQLite 单条语句的参数个数有上限（旧版本为 999），按每条记录十余个字段估算取 100
+const DefaultBatchSize = 100
+
+// BatchSize 返回批量插入的分批大小
+// 读取 DB_BATCH_INSERT_SIZE，未配置或配置非法时使用 DefaultBatchSize
+func BatchSize() int {
+	size, err := envx.GetIntWithDefaultAndMin(consts.DBBatchInsertSize, DefaultBatchSize, 1)
+	if err != nil {
+		logs.Warn("批量插入分批大小配置无效，使用默认值", "error", err.Error(), "default", DefaultBatchSize)
+		return DefaultBatchSize
+	}
+	return size
+}
+
+// BatchInsert 在事务中分批插入记录
+// chunkSize <= 0 时使用 BatchSize()，任意一批失败时整体回滚
+// db 本身处于事务中时会使用保存点，不影响外层事务
+func BatchInsert[T any](ctx context.Context, db *gorm.DB, rows []T, chunkSize int) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = BatchSize()
+	}
+
+	return Conn(ctx, db).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(rows); start += chunkSize {
+			end := start + chunkSize
+			if end > len(rows) {
+				end = len(rows)
+			}
+			chunk := rows[start:end]
+			if err := tx.Create(&chunk).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
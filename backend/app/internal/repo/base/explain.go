@@ -0,0 +1,15 @@
+package base
+
+import (
+	"backend/app/types/meta"
+
+	"gorm.io/gorm"
+)
+
+// Explain 标记查询在超过慢查询阈值时输出执行计划
+// 仅在启用 SQLITE_EXPLAIN_ENABLED 时生效，用于列表、搜索等随数据量增长可能变慢的查询
+//
+//	query := base.Conn(ctx, r.db).Model(&itemModel.Item{}).Scopes(base.Explain)
+func Explain(db *gorm.DB) *gorm.DB {
+	return db.Set(meta.GormKeyExplain, true)
+}
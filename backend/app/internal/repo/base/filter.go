@@ -0,0 +1,128 @@
+package base
+
+import (
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Condition 单个查询条件
+type Condition struct {
+	Query string
+	Args  []interface{}
+}
+
+// Filter 查询条件构建器
+// 值为 nil、空指针、空字符串或空切片的条件会被忽略，调用方无需逐个判断
+//
+//	filter := base.NewFilter().
+//		Eq("status", status).
+//		Gte("created_at", dateStart).
+//		Like("content", keyword)
+//	db.Scopes(filter.Scope()).Find(&items)
+type Filter struct {
+	conds []Condition
+}
+
+// NewFilter 创建查询条件构建器
+func NewFilter() *Filter {
+	return &Filter{}
+}
+
+// Where 添加自定义条件，query 为空时忽略
+func (f *Filter) Where(query string, args ...interface{}) *Filter {
+	if query == "" {
+		return f
+	}
+	f.conds = append(f.conds, Condition{Query: query, Args: args})
+	return f
+}
+
+// Eq 添加 column = value 条件
+func (f *Filter) Eq(column string, value interface{}) *Filter {
+	return f.compare(column, "=", value)
+}
+
+// Gte 添加 column >= value 条件
+func (f *Filter) Gte(column string, value interface{}) *Filter {
+	return f.compare(column, ">=", value)
+}
+
+// Lte 添加 column <= value 条件
+func (f *Filter) Lte(column string, value interface{}) *Filter {
+	return f.compare(column, "<=", value)
+}
+
+// Lt 添加 column < value 条件
+func (f *Filter) Lt(column string, value interface{}) *Filter {
+	return f.compare(column, "<", value)
+}
+
+// In 添加 column IN (values) 条件，values 必须是切片
+func (f *Filter) In(column string, values interface{}) *Filter {
+	v, ok := deref(values)
+	if !ok || v.Kind() != reflect.Slice || v.Len() == 0 {
+		return f
+	}
+	return f.Where(column+" IN ?", v.Interface())
+}
+
+// Like 添加 column LIKE %keyword% 条件
+// keyword 中的 %、_ 会被转义，按字面匹配
+func (f *Filter) Like(column string, keyword string) *Filter {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return f
+	}
+	return f.Where(column+` LIKE ? ESCAPE '\'`, "%"+escapeLike(keyword)+"%")
+}
+
+// Empty 是否没有任何条件
+func (f *Filter) Empty() bool {
+	return len(f.conds) == 0
+}
+
+// Conditions 返回已添加的条件
+func (f *Filter) Conditions() []Condition {
+	return f.conds
+}
+
+// Scope 转换为 gorm scope
+func (f *Filter) Scope() func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		for _, cond := range f.conds {
+			db = db.Where(cond.Query, cond.Args...)
+		}
+		return db
+	}
+}
+
+// compare 添加比较条件，值为空时忽略
+func (f *Filter) compare(column string, op string, value interface{}) *Filter {
+	v, ok := deref(value)
+	if !ok || (v.Kind() == reflect.String && v.Len() == 0) {
+		return f
+	}
+	return f.Where(column+" "+op+" ?", v.Interface())
+}
+
+// deref 解引用指针，值为 nil 或空指针时返回 false
+func deref(value interface{}) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// escapeLike 转义 LIKE 通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
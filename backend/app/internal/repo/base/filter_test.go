@@ -0,0 +1,56 @@
+package base
+
+import (
+	"testing"
+	"time"
+)
+
+// TestFilterSkipEmpty 测试空值条件被忽略
+func TestFilterSkipEmpty(t *testing.T) {
+	var nilTime *time.Time
+	var nilSlice []uint
+	filter := NewFilter().
+		Eq("status", nil).
+		Gte("created_at", nilTime).
+		Eq("name", "").
+		Like("content", "   ").
+		In("id", nilSlice).
+		Where("")
+
+	if !filter.Empty() {
+		t.Fatalf("期望没有条件，实际: %+v", filter.Conditions())
+	}
+}
+
+// TestFilterDeref 测试指针值被解引用
+func TestFilterDeref(t *testing.T) {
+	status := "done"
+	filter := NewFilter().Eq("status", &status)
+
+	conds := filter.Conditions()
+	if len(conds) != 1 || conds[0].Query != "status = ?" {
+		t.Fatalf("条件不符合预期: %+v", conds)
+	}
+	if v, ok := conds[0].Args[0].(string); !ok || v != "done" {
+		t.Fatalf("期望参数为解引用后的值，实际: %#v", conds[0].Args[0])
+	}
+}
+
+// TestFilterLikeEscape 测试 LIKE 通配符转义
+func TestFilterLikeEscape(t *testing.T) {
+	conds := NewFilter().Like("content", `50%_off\`).Conditions()
+	if len(conds) != 1 {
+		t.Fatalf("期望 1 个条件，实际 %d 个", len(conds))
+	}
+	if got, want := conds[0].Args[0], `%50\%\_off\\%`; got != want {
+		t.Fatalf("期望 %q，实际 %q", want, got)
+	}
+}
+
+// TestFilterIn 测试 IN 条件
+func TestFilterIn(t *testing.T) {
+	conds := NewFilter().In("id", []uint{1, 2}).In("tag_id", "not a slice").Conditions()
+	if len(conds) != 1 || conds[0].Query != "id IN ?" {
+		t.Fatalf("条件不符合预期: %+v", conds)
+	}
+}
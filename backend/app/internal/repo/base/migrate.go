@@ -0,0 +1,156 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	systemModel "backend/app/model/system"
+	userModel "backend/app/model/user"
+	"backend/utils/logs"
+	"backend/utils/secret"
+
+	"gorm.io/gorm"
+)
+
+// ErrUserExists 用户名已存在
+var ErrUserExists = errors.New("用户名已存在")
+
+// ErrUserNotFound 用户不存在
+var ErrUserNotFound = errors.New("用户不存在")
+
+// TableStatus 数据表状态
+type TableStatus struct {
+	Name   string // 表名
+	Exists bool   // 表是否存在
+}
+
+// MigrationStatus 表结构迁移状态
+type MigrationStatus struct {
+	CurrentVersion string        // 数据库中记录的表结构版本，未迁移时为空
+	LatestVersion  int           // 代码中的表结构版本
+	Tables         []TableStatus // 各数据表的状态
+}
+
+// UpToDate 表结构是否为最新版本且所有表都存在
+func (s *MigrationStatus) UpToDate() bool {
+	if s.CurrentVersion != strconv.Itoa(s.LatestVersion) {
+		return false
+	}
+	for _, table := range s.Tables {
+		if !table.Exists {
+			return false
+		}
+	}
+	return true
+}
+
+// MigrationStatus 查询表结构迁移状态
+func (r *BaseRepo) MigrationStatus(ctx context.Context) (*MigrationStatus, error) {
+	status := &MigrationStatus{LatestVersion: SchemaVersion}
+
+	migrator := r.db.WithContext(ctx).Migrator()
+	for _, model := range models() {
+		name, err := r.tableName(model)
+		if err != nil {
+			return nil, err
+		}
+		status.Tables = append(status.Tables, TableStatus{Name: name, Exists: migrator.HasTable(model)})
+	}
+
+	// 系统配置表不存在时没有版本记录
+	if migrator.HasTable(&systemModel.SystemConfig{}) {
+		version, err := r.sysRepo.GetSystemConfig(ctx, SchemaVersionKey)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		status.CurrentVersion = version
+	}
+	return status, nil
+}
+
+// MigrateUp 创建或更新数据表，并记录表结构版本
+func (r *BaseRepo) MigrateUp(ctx context.Context) error {
+	if err := r.InitTables(); err != nil {
+		return err
+	}
+	if err := r.sysRepo.CreateOrUpdateSystemConfig(ctx, SchemaVersionKey, strconv.Itoa(SchemaVersion)); err != nil {
+		logs.Error("记录表结构版本失败", "error", err.Error())
+		return err
+	}
+	return nil
+}
+
+// MigrateDown 删除所有数据表（包括数据），用于开发环境重置数据库
+func (r *BaseRepo) MigrateDown(ctx context.Context) error {
+	list := models()
+	migrator := r.db.WithContext(ctx).Migrator()
+	// 逆序删除，先删除关联表
+	for i := len(list) - 1; i >= 0; i-- {
+		name, err := r.tableName(list[i])
+		if err != nil {
+			return err
+		}
+		if err := migrator.DropTable(list[i]); err != nil {
+			logs.Error("删除数据表失败", "table", name, "error", err.Error())
+			return err
+		}
+		logs.Info("删除数据表", "table", name)
+	}
+	return nil
+}
+
+// Seed 初始化系统配置和默认管理员账户（已初始化时跳过）
+func (r *BaseRepo) Seed(ctx context.Context) error {
+	alreadyInitialized, err := r.InitSystemConfig()
+	if err != nil {
+		return err
+	}
+	if alreadyInitialized {
+		return nil
+	}
+	return r.InitUsers()
+}
+
+// CreateAdmin 创建管理员账户
+// 用户名已存在时返回 ErrUserExists
+func (r *BaseRepo) CreateAdmin(ctx context.Context, username, password string) error {
+	if _, err := r.userRepo.GetUserByUsername(ctx, username); err == nil {
+		return ErrUserExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	passwordHash, err := secret.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return r.userRepo.CreateUser(ctx, &userModel.User{
+		Username:     username,
+		PasswordHash: passwordHash,
+		NickName:     username,
+	})
+}
+
+// SetRateLimitTier 设置用户的限流档位，tier 为空时恢复默认档位
+// 用户不存在时返回 ErrUserNotFound
+func (r *BaseRepo) SetRateLimitTier(ctx context.Context, username, tier string) error {
+	user, err := r.userRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+	return r.userRepo.UpdateUserInfo(ctx, user.ID, map[string]interface{}{"rate_limit_tier": tier})
+}
+
+// tableName 解析模型对应的表名
+func (r *BaseRepo) tableName(model interface{}) (string, error) {
+	stmt := &gorm.Statement{DB: r.db}
+	if err := stmt.Parse(model); err != nil {
+		return "", fmt.Errorf("解析模型失败: %w", err)
+	}
+	return stmt.Schema.Table, nil
+}
@@ -0,0 +1,15 @@
+package base
+
+import (
+	"backend/app/types/meta"
+
+	"gorm.io/gorm"
+)
+
+// CrossTenant 标记原始 SQL（Raw、Exec）与租户无关，如查询数据库版本、备份数据库
+// 多租户模式下没有标记的原始 SQL 会被拒绝，按租户隔离的数据不能使用
+//
+//	base.Conn(ctx, r.db).Scopes(base.CrossTenant).Raw("SELECT sqlite_version()").Scan(&version)
+func CrossTenant(db *gorm.DB) *gorm.DB {
+	return db.Set(meta.GormKeyCrossTenant, true)
+}
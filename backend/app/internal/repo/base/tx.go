@@ -0,0 +1,63 @@
+package base
+
+import (
+	"context"
+	"errors"
+
+	"backend/app/types/meta"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+// errDryRunRollback 试运行结束时返回，用于回滚事务
+var errDryRunRollback = errors.New("dry run rollback")
+
+// txKey 上下文中事务的键
+type txKey struct{}
+
+// Conn 返回绑定 ctx 的数据库连接
+// ctx 中有事务（如试运行）时使用该事务，db 本身已是事务时优先使用 db；
+// 仓储的读写都应通过 Conn 获取连接，保证同一请求内的读写落在同一事务中
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if committer, ok := db.Statement.ConnPool.(gorm.TxCommitter); ok && committer != nil {
+		return db.WithContext(ctx)
+	}
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+type TxRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+// TxRepo 跨仓储的事务
+type TxRepo struct {
+	db *gorm.DB
+}
+
+func NewTxRepo(params TxRepoParams) *TxRepo {
+	return &TxRepo{
+		db: params.DB,
+	}
+}
+
+// DryRun 试运行：在事务中执行 fn，结束后总是回滚
+// 传给 fn 的 ctx 带有事务和试运行标记，fn 返回的错误原样返回；fn 中不经过 Conn 的写操作（如文件、外部服务）不会回滚
+func (r *TxRepo) DryRun(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		ctx := context.WithValue(ctx, meta.ContextKeyDryRun, true)
+		if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+			return err
+		}
+		return errDryRunRollback
+	})
+	if errors.Is(err, errDryRunRollback) {
+		return nil
+	}
+	return err
+}
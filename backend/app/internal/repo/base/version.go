@@ -0,0 +1,42 @@
+package base
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict 版本冲突（记录已被其他请求修改）
+var ErrVersionConflict = errors.New("version conflict")
+
+// VersionColumn 版本号列名
+const VersionColumn = "version"
+
+// BumpVersion 在更新字段中加入版本号自增
+// 所有带版本号的模型在更新时都应调用，保证版本号随每次修改递增
+func BumpVersion(updates map[string]interface{}) map[string]interface{} {
+	if updates == nil {
+		updates = make(map[string]interface{})
+	}
+	updates[VersionColumn] = gorm.Expr(VersionColumn + " + 1")
+	return updates
+}
+
+// UpdateWithVersion 基于版本号的乐观锁更新
+// 仅当记录当前版本号等于 version 时才执行更新，并将版本号加 1
+// 如果记录不存在或版本号不匹配，返回 ErrVersionConflict
+// 需要与其他写操作（如替换关联的标签）同时生效时，在 db.Transaction 中传入事务调用
+func UpdateWithVersion(ctx context.Context, db *gorm.DB, model interface{}, id uint, version uint, updates map[string]interface{}) error {
+	result := Conn(ctx, db).
+		Model(model).
+		Where("id = ? AND "+VersionColumn+" = ?", id, version).
+		Updates(BumpVersion(updates))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrVersionConflict
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package change
+
+import (
+	"context"
+
+	"backend/app/internal/repo/base"
+	changeModel "backend/app/model/change"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type ChangeRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+type ChangeRepo struct {
+	db *gorm.DB
+}
+
+func NewChangeRepo(params ChangeRepoParams) *ChangeRepo {
+	return &ChangeRepo{
+		db: params.DB,
+	}
+}
+
+// CreateChange 记录数据变更
+func (r *ChangeRepo) CreateChange(ctx context.Context, change *changeModel.Change) error {
+	return base.Conn(ctx, r.db).Create(change).Error
+}
+
+// GetChangesSince 按序号升序获取序号大于 since 的变更，最多 limit 条
+func (r *ChangeRepo) GetChangesSince(ctx context.Context, since uint, limit int) ([]*changeModel.Change, error) {
+	var changes []*changeModel.Change
+	err := base.Conn(ctx, r.db).
+		Where("id > ?", since).
+		Order("id ASC").
+		Limit(limit).
+		Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -3,6 +3,7 @@ package file
 import (
 	"context"
 
+	"backend/app/internal/repo/base"
 	fileModel "backend/app/model/file"
 
 	"go.uber.org/fx"
@@ -26,12 +27,12 @@ func NewFileRepo(params FileRepoParams) *FileRepo {
 }
 
 func (r *FileRepo) CreateFile(ctx context.Context, file *fileModel.File) error {
-	return r.db.WithContext(ctx).Create(file).Error
+	return base.Conn(ctx, r.db).Create(file).Error
 }
 
 func (r *FileRepo) GetFileByID(ctx context.Context, fileID uint) (*fileModel.File, error) {
 	var file fileModel.File
-	if err := r.db.WithContext(ctx).Where("id = ?", fileID).First(&file).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("id = ?", fileID).First(&file).Error; err != nil {
 		return nil, err
 	}
 	return &file, nil
@@ -39,12 +40,53 @@ func (r *FileRepo) GetFileByID(ctx context.Context, fileID uint) (*fileModel.Fil
 
 func (r *FileRepo) GetFileByHash(ctx context.Context, hash string) (*fileModel.File, error) {
 	var file fileModel.File
-	if err := r.db.WithContext(ctx).Where("file_hash = ?", hash).First(&file).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("file_hash = ?", hash).First(&file).Error; err != nil {
 		return nil, err
 	}
 	return &file, nil
 }
 
 func (r *FileRepo) DeleteFile(ctx context.Context, fileID uint) error {
-	return r.db.WithContext(ctx).Delete(&fileModel.File{}, fileID).Error
+	return base.Conn(ctx, r.db).Delete(&fileModel.File{}, fileID).Error
+}
+
+// ListUserFiles 获取用户上传的文件和 storagePaths 中的文件记录，按 ID 升序返回（用于数据导出）
+func (r *FileRepo) ListUserFiles(ctx context.Context, userID uint, storagePaths []string) ([]*fileModel.File, error) {
+	query := base.Conn(ctx, r.db)
+	if len(storagePaths) > 0 {
+		query = query.Where("created_by = ? OR file_storage_path IN ?", userID, storagePaths)
+	} else {
+		query = query.Where("created_by = ?", userID)
+	}
+
+	var files []*fileModel.File
+	if err := query.Order("id ASC").Find(&files).Error; err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// ListStoragePaths 获取指定存储类型下所有文件记录的存储路径
+// context 不带租户ID时（如定时任务）查询所有租户的记录
+func (r *FileRepo) ListStoragePaths(ctx context.Context, storageType string) ([]string, error) {
+	var paths []string
+	if err := base.Conn(ctx, r.db).Model(&fileModel.File{}).
+		Where("file_storage_type = ?", storageType).
+		Pluck("file_storage_path", &paths).Error; err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+// GetStorageUsage 统计文件记录数和文件总字节数
+func (r *FileRepo) GetStorageUsage(ctx context.Context) (count int64, bytes int64, err error) {
+	var result struct {
+		Count int64 `gorm:"column:count"`
+		Bytes int64 `gorm:"column:bytes"`
+	}
+	err = base.Conn(ctx, r.db).
+		Model(&fileModel.File{}).
+		Select("COUNT(*) as count, COALESCE(SUM(file_size), 0) as bytes").
+		Scan(&result).Error
+	return result.Count, result.Bytes, err
 }
@@ -0,0 +1,94 @@
+package item
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	itemModel "backend/app/model/item"
+	"backend/app/types/meta"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// buildSQL 在 DryRun 模式下生成过滤条件对应的 SQL
+func buildSQL(t *testing.T, filter ItemFilter) (string, []interface{}) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+
+	var items []*itemModel.Item
+	stmt := db.Model(&itemModel.Item{}).Scopes(filter.build(db).Scope()).Find(&items).Statement
+	return stmt.SQL.String(), stmt.Vars
+}
+
+// TestItemFilterCombinations 测试各过滤条件组合生成的 SQL
+func TestItemFilterCombinations(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	status := meta.ItemStatus("done")
+
+	tests := []struct {
+		name     string
+		filter   ItemFilter
+		contains []string
+		vars     int
+	}{
+		{
+			name:   "无条件",
+			filter: ItemFilter{},
+			vars:   0,
+		},
+		{
+			name:     "日期范围",
+			filter:   ItemFilter{DateStart: &start, DateEnd: &end},
+			contains: []string{"created_at >= ?", "created_at <= ?"},
+			vars:     2,
+		},
+		{
+			name:     "状态",
+			filter:   ItemFilter{Status: &status},
+			contains: []string{"status = ?"},
+			vars:     1,
+		},
+		{
+			name:     "关键词",
+			filter:   ItemFilter{Keyword: "hello"},
+			contains: []string{"content LIKE ?"},
+			vars:     1,
+		},
+		{
+			name:     "标签",
+			filter:   ItemFilter{TagIDs: []uint{1, 2}},
+			contains: []string{"id IN (SELECT `item_id` FROM `item_tag` WHERE tag_id IN (?,?))"},
+			vars:     2,
+		},
+		{
+			name:     "全部条件",
+			filter:   ItemFilter{DateStart: &start, DateEnd: &end, Status: &status, Keyword: "hello", TagIDs: []uint{3}},
+			contains: []string{"created_at >= ?", "created_at <= ?", "status = ?", "content LIKE ?", "tag_id IN (?)"},
+			vars:     5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, vars := buildSQL(t, tt.filter)
+			for _, want := range tt.contains {
+				if !strings.Contains(sql, want) {
+					t.Errorf("SQL 中缺少 %q: %s", want, sql)
+				}
+			}
+			if len(tt.contains) == 0 && strings.Contains(sql, "WHERE") {
+				t.Errorf("期望没有 WHERE 条件: %s", sql)
+			}
+			if len(vars) != tt.vars {
+				t.Errorf("期望 %d 个参数，实际 %d 个: %v", tt.vars, len(vars), vars)
+			}
+		})
+	}
+}
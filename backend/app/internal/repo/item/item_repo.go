@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"backend/app/internal/repo/base"
 	itemModel "backend/app/model/item"
 	relationModel "backend/app/model/relation"
 	tagModel "backend/app/model/tag"
@@ -32,18 +33,38 @@ func NewItemRepo(params ItemRepoParams) *ItemRepo {
 
 // CreateItem 创建项目
 func (r *ItemRepo) CreateItem(ctx context.Context, item *itemModel.Item) error {
-	return r.db.WithContext(ctx).Create(item).Error
+	return base.Conn(ctx, r.db).Create(item).Error
 }
 
-// UpdateItem 更新项目
-func (r *ItemRepo) UpdateItem(ctx context.Context, itemID uint, updates map[string]interface{}) error {
-	return r.db.WithContext(ctx).Model(&itemModel.Item{}).Where("id = ?", itemID).Updates(updates).Error
+// CreateItems 批量创建项目（用于导入）
+func (r *ItemRepo) CreateItems(ctx context.Context, items []*itemModel.Item) error {
+	return base.BatchInsert(ctx, r.db, items, 0)
+}
+
+// UpdateItem 在同一事务中更新项目字段和标签，版本号加 1，任一步骤失败时整体回滚
+// version 不为 nil 时启用乐观锁，项目当前版本号与 version 不一致时返回 base.ErrVersionConflict；
+// tagIDs 为 nil 时不修改标签
+func (r *ItemRepo) UpdateItem(ctx context.Context, itemID uint, version *uint, updates map[string]interface{}, tagIDs []uint) error {
+	return base.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if version != nil {
+			if err := base.UpdateWithVersion(ctx, tx, &itemModel.Item{}, itemID, *version, updates); err != nil {
+				return err
+			}
+		} else if err := tx.Model(&itemModel.Item{}).Where("id = ?", itemID).Updates(base.BumpVersion(updates)).Error; err != nil {
+			return err
+		}
+
+		if tagIDs == nil {
+			return nil
+		}
+		return replaceItemTags(ctx, tx, itemID, tagIDs)
+	})
 }
 
 // DeleteItem 删除项目
 func (r *ItemRepo) DeleteItem(ctx context.Context, itemID uint) error {
 	// 开启事务
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return base.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// 删除项目标签关系
 		if err := tx.Where("item_id = ?", itemID).Delete(&relationModel.ItemTag{}).Error; err != nil {
 			return err
@@ -56,32 +77,101 @@ func (r *ItemRepo) DeleteItem(ctx context.Context, itemID uint) error {
 // GetItemByID 根据ID获取项目
 func (r *ItemRepo) GetItemByID(ctx context.Context, itemID uint) (*itemModel.Item, error) {
 	var item itemModel.Item
-	if err := r.db.WithContext(ctx).Where("id = ?", itemID).First(&item).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("id = ?", itemID).First(&item).Error; err != nil {
 		return nil, err
 	}
 	return &item, nil
 }
 
-// GetItemList 获取项目列表
-func (r *ItemRepo) GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]*itemModel.Item, int64, error) {
+// GetOpenItemsByCreator 获取用户创建的未完成项目，按创建时间升序返回最早的 limit 个及总数
+func (r *ItemRepo) GetOpenItemsByCreator(ctx context.Context, userID uint, limit int) ([]*itemModel.Item, int64, error) {
 	var items []*itemModel.Item
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&itemModel.Item{})
+	query := base.Conn(ctx, r.db).Model(&itemModel.Item{}).
+		Where("created_by = ? AND status <> ?", userID, string(meta.ItemStatusDone))
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Order("created_at ASC").Limit(limit).Find(&items).Error; err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
 
-	// 日期范围过滤
-	if dateStart != nil {
-		query = query.Where("created_at >= ?", *dateStart)
+// ListItemsWithTagsByCreator 按 ID 升序获取用户创建的 ID 大于 afterID 的项目及其标签，每次最多 limit 个（用于数据导出）
+func (r *ItemRepo) ListItemsWithTagsByCreator(ctx context.Context, userID uint, afterID uint, limit int) ([]dto.ItemDTO, error) {
+	var items []*itemModel.Item
+	if err := base.Conn(ctx, r.db).
+		Where("created_by = ? AND id > ?", userID, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
 	}
-	if dateEnd != nil {
-		query = query.Where("created_at <= ?", *dateEnd)
+	return r.buildItemDTOs(ctx, items)
+}
+
+// ListDueItemsWithTagsByCreator 按截止时间倒序获取用户创建的、设置了截止时间的项目及其标签，最多 limit 个（用于日历订阅）
+func (r *ItemRepo) ListDueItemsWithTagsByCreator(ctx context.Context, userID uint, limit int) ([]dto.ItemDTO, error) {
+	var items []*itemModel.Item
+	if err := base.Conn(ctx, r.db).
+		Where("created_by = ? AND due_at IS NOT NULL", userID).
+		Order("due_at DESC").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, err
 	}
+	return r.buildItemDTOs(ctx, items)
+}
 
-	// 状态过滤
-	if status != nil {
-		query = query.Where("status = ?", string(*status))
+// ListRecentItemsWithTagsByCreator 按更新时间倒序获取用户在 since 之后创建或完成的项目及其标签，最多 limit 个（用于订阅源）
+// tagID 不为空时只返回包含该标签的项目
+func (r *ItemRepo) ListRecentItemsWithTagsByCreator(ctx context.Context, userID uint, since time.Time, tagID *uint, limit int) ([]dto.ItemDTO, error) {
+	db := base.Conn(ctx, r.db)
+	query := db.Where("created_by = ?", userID).
+		Where(db.Where("created_at >= ?", since).Or("status = ? AND updated_at >= ?", meta.ItemStatusDone, since))
+	if tagID != nil {
+		query = query.Where("id IN (?)", db.Model(&relationModel.ItemTag{}).Select("item_id").Where("tag_id = ?", *tagID))
 	}
 
+	var items []*itemModel.Item
+	if err := query.Order("updated_at DESC").Limit(limit).Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return r.buildItemDTOs(ctx, items)
+}
+
+// ItemFilter 项目列表过滤条件，字段为空表示不过滤
+type ItemFilter struct {
+	DateStart *time.Time       // 创建时间下限（含）
+	DateEnd   *time.Time       // 创建时间上限（含）
+	Status    *meta.ItemStatus // 状态
+	Keyword   string           // 内容关键词（模糊匹配）
+	TagIDs    []uint           // 包含任一标签
+}
+
+// build 将过滤条件转换为查询条件
+func (f ItemFilter) build(db *gorm.DB) *base.Filter {
+	filter := base.NewFilter().
+		Gte("created_at", f.DateStart).
+		Lte("created_at", f.DateEnd).
+		Eq("status", f.Status).
+		Like("content", f.Keyword)
+	if len(f.TagIDs) > 0 {
+		filter.Where("id IN (?)", db.Model(&relationModel.ItemTag{}).Select("item_id").Where("tag_id IN ?", f.TagIDs))
+	}
+	return filter
+}
+
+// ListItems 按过滤条件分页获取项目列表
+func (r *ItemRepo) ListItems(ctx context.Context, filter ItemFilter, page, pageSize int) ([]*itemModel.Item, int64, error) {
+	var items []*itemModel.Item
+	var total int64
+
+	db := base.Conn(ctx, r.db)
+	query := db.Model(&itemModel.Item{}).Scopes(filter.build(db).Scope(), base.Explain)
+
 	// 获取总数
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -96,36 +186,58 @@ func (r *ItemRepo) GetItemList(ctx context.Context, dateStart *time.Time, dateEn
 	return items, total, nil
 }
 
+// ListItemsWithTags 按过滤条件分页获取项目列表及其标签
+func (r *ItemRepo) ListItemsWithTags(ctx context.Context, filter ItemFilter, page, pageSize int) ([]dto.ItemDTO, int64, error) {
+	items, total, err := r.ListItems(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	itemDTOs, err := r.buildItemDTOs(ctx, items)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return itemDTOs, total, nil
+}
+
+// GetItemList 获取项目列表
+func (r *ItemRepo) GetItemList(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]*itemModel.Item, int64, error) {
+	return r.ListItems(ctx, ItemFilter{DateStart: dateStart, DateEnd: dateEnd, Status: status}, page, pageSize)
+}
+
 // SetItemTags 设置项目的标签
 func (r *ItemRepo) SetItemTags(ctx context.Context, itemID uint, tagIDs []uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 删除旧的标签关系
-		if err := tx.Where("item_id = ?", itemID).Delete(&relationModel.ItemTag{}).Error; err != nil {
-			return err
-		}
+	return base.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return replaceItemTags(ctx, tx, itemID, tagIDs)
+	})
+}
 
-		// 创建新的标签关系
-		if len(tagIDs) > 0 {
-			relations := make([]relationModel.ItemTag, 0, len(tagIDs))
-			for _, tagID := range tagIDs {
-				relations = append(relations, relationModel.ItemTag{
-					ItemID: itemID,
-					TagID:  tagID,
-				})
-			}
-			if err := tx.Create(&relations).Error; err != nil {
-				return err
-			}
-		}
+// replaceItemTags 在事务中删除项目的旧标签关系并创建新的标签关系
+func replaceItemTags(ctx context.Context, tx *gorm.DB, itemID uint, tagIDs []uint) error {
+	// 删除旧的标签关系
+	if err := tx.Where("item_id = ?", itemID).Delete(&relationModel.ItemTag{}).Error; err != nil {
+		return err
+	}
 
+	// 创建新的标签关系
+	if len(tagIDs) == 0 {
 		return nil
-	})
+	}
+	relations := make([]relationModel.ItemTag, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		relations = append(relations, relationModel.ItemTag{
+			ItemID: itemID,
+			TagID:  tagID,
+		})
+	}
+	return base.BatchInsert(ctx, tx, relations, 0)
 }
 
 // GetItemTags 获取项目的标签
 func (r *ItemRepo) GetItemTags(ctx context.Context, itemID uint) ([]*tagModel.Tag, error) {
 	var tags []*tagModel.Tag
-	err := r.db.WithContext(ctx).
+	err := base.Conn(ctx, r.db).
 		Table("tag").
 		Joins("INNER JOIN item_tag ON tag.id = item_tag.tag_id").
 		Where("item_tag.item_id = ?", itemID).
@@ -150,16 +262,48 @@ func (r *ItemRepo) GetItemWithTags(ctx context.Context, itemID uint) (*itemModel
 
 // GetItemListWithTags 获取项目列表及其标签
 func (r *ItemRepo) GetItemListWithTags(ctx context.Context, dateStart *time.Time, dateEnd *time.Time, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error) {
-	items, total, err := r.GetItemList(ctx, dateStart, dateEnd, status, page, pageSize)
-	if err != nil {
-		return nil, 0, err
+	return r.ListItemsWithTags(ctx, ItemFilter{DateStart: dateStart, DateEnd: dateEnd, Status: status}, page, pageSize)
+}
+
+// SearchItemListWithTags 按关键词搜索项目列表及其标签（数据库模糊匹配）
+func (r *ItemRepo) SearchItemListWithTags(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]dto.ItemDTO, int64, error) {
+	return r.ListItemsWithTags(ctx, ItemFilter{Keyword: keyword, Status: status}, page, pageSize)
+}
+
+// GetItemListWithTagsByIDs 根据ID列表获取项目及其标签，结果按 itemIDs 的顺序返回
+// 不存在的项目会被忽略
+func (r *ItemRepo) GetItemListWithTagsByIDs(ctx context.Context, itemIDs []uint) ([]dto.ItemDTO, error) {
+	if len(itemIDs) == 0 {
+		return []dto.ItemDTO{}, nil
 	}
 
+	var items []*itemModel.Item
+	if err := base.Conn(ctx, r.db).Where("id IN ?", itemIDs).Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	// 按传入顺序排序
+	itemMap := make(map[uint]*itemModel.Item, len(items))
+	for _, item := range items {
+		itemMap[item.ID] = item
+	}
+	ordered := make([]*itemModel.Item, 0, len(items))
+	for _, itemID := range itemIDs {
+		if item, ok := itemMap[itemID]; ok {
+			ordered = append(ordered, item)
+		}
+	}
+
+	return r.buildItemDTOs(ctx, ordered)
+}
+
+// buildItemDTOs 查询项目标签并构建项目 DTO 列表
+func (r *ItemRepo) buildItemDTOs(ctx context.Context, items []*itemModel.Item) ([]dto.ItemDTO, error) {
 	itemDTOs := make([]dto.ItemDTO, 0, len(items))
 	for _, item := range items {
 		tags, err := r.GetItemTags(ctx, item.ID)
 		if err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 
 		tagDTOs := make([]dto.TagDTO, 0, len(tags))
@@ -179,11 +323,13 @@ func (r *ItemRepo) GetItemListWithTags(ctx context.Context, dateStart *time.Time
 			UpdatedAt: item.UpdatedAt,
 			Content:   item.Content,
 			Status:    item.Status,
+			DueAt:     item.DueAt,
+			Version:   item.Version,
 			Tags:      tagDTOs,
 		})
 	}
 
-	return itemDTOs, total, nil
+	return itemDTOs, nil
 }
 
 func (r *ItemRepo) GetDailyItemCount(ctx context.Context, dateStart time.Time, dateEnd time.Time) ([]dto.DailyItemCountDTO, error) {
@@ -195,7 +341,7 @@ func (r *ItemRepo) GetDailyItemCount(ctx context.Context, dateStart time.Time, d
 
 	// 查询时间范围内每天的 item 创建数量
 	// 使用 DATE() 函数提取日期，按日期分组统计
-	err := r.db.WithContext(ctx).
+	err := base.Conn(ctx, r.db).
 		Model(&itemModel.Item{}).
 		Select("DATE(created_at) as date, COUNT(*) as count").
 		Where("created_at >= ? AND created_at < ?", dateStart, dateEnd.AddDate(0, 0, 1)).
@@ -244,3 +390,25 @@ func (r *ItemRepo) GetDailyItemCount(ctx context.Context, dateStart time.Time, d
 
 	return dailyItemCounts, nil
 }
+
+// CountItemsByStatus 按状态统计项目数（key: 状态）
+func (r *ItemRepo) CountItemsByStatus(ctx context.Context) (map[string]int64, error) {
+	var results []struct {
+		Status string `gorm:"column:status"`
+		Count  int64  `gorm:"column:count"`
+	}
+	err := base.Conn(ctx, r.db).
+		Model(&itemModel.Item{}).
+		Select("status, COUNT(*) as count").
+		Group("status").
+		Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(results))
+	for _, result := range results {
+		counts[result.Status] = result.Count
+	}
+	return counts, nil
+}
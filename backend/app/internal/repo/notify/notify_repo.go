@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+
+	"backend/app/internal/repo/base"
+	notifyModel "backend/app/model/notify"
+	userModel "backend/app/model/user"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotifyRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+type NotifyRepo struct {
+	db *gorm.DB
+}
+
+func NewNotifyRepo(params NotifyRepoParams) *NotifyRepo {
+	return &NotifyRepo{
+		db: params.DB,
+	}
+}
+
+// GetPreference 获取用户的通知偏好
+func (r *NotifyRepo) GetPreference(ctx context.Context, userID uint) (*notifyModel.NotificationPreference, error) {
+	var pref notifyModel.NotificationPreference
+	if err := base.Conn(ctx, r.db).Where("user_id = ?", userID).First(&pref).Error; err != nil {
+		return nil, err
+	}
+	return &pref, nil
+}
+
+// SavePreference 保存用户的通知偏好，已存在时更新
+func (r *NotifyRepo) SavePreference(ctx context.Context, pref *notifyModel.NotificationPreference) error {
+	return base.Conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email_enabled", "daily_digest", "updated_at"}),
+	}).Create(pref).Error
+}
+
+// GetDigestRecipients 获取开启了每日摘要且邮箱已验证的用户
+func (r *NotifyRepo) GetDigestRecipients(ctx context.Context) ([]*userModel.User, error) {
+	var users []*userModel.User
+	db := base.Conn(ctx, r.db)
+	err := db.
+		Where("email <> '' AND email_verified = ?", true).
+		Where("id IN (?)", db.Model(&notifyModel.NotificationPreference{}).
+			Select("user_id").
+			Where("email_enabled = ? AND daily_digest = ?", true, true)).
+		Order("id").
+		Find(&users).Error
+	return users, err
+}
@@ -1,16 +1,38 @@
 package repo
 
 import (
+	auditLogic "backend/app/internal/logic/audit"
+	botLogic "backend/app/internal/logic/bot"
+	changeLogic "backend/app/internal/logic/change"
+	exportLogic "backend/app/internal/logic/export"
+	feedLogic "backend/app/internal/logic/feed"
 	fileLogic "backend/app/internal/logic/file"
+	importerLogic "backend/app/internal/logic/importer"
 	itemLogic "backend/app/internal/logic/item"
+	limitLogic "backend/app/internal/logic/limit"
+	notifyLogic "backend/app/internal/logic/notify"
+	retentionLogic "backend/app/internal/logic/retention"
+	statsLogic "backend/app/internal/logic/stats"
+	systemLogic "backend/app/internal/logic/system"
 	tagLogic "backend/app/internal/logic/tag"
 	userLogic "backend/app/internal/logic/user"
+	webhookLogic "backend/app/internal/logic/webhook"
+	auditRepo "backend/app/internal/repo/audit"
 	baseRepo "backend/app/internal/repo/base"
+	changeRepo "backend/app/internal/repo/change"
 	fileRepo "backend/app/internal/repo/file"
 	itemRepo "backend/app/internal/repo/item"
+	notifyRepo "backend/app/internal/repo/notify"
+	queueRepo "backend/app/internal/repo/queue"
+	retentionRepo "backend/app/internal/repo/retention"
+	searchRepo "backend/app/internal/repo/search"
 	sysRepo "backend/app/internal/repo/sys"
 	tagRepo "backend/app/internal/repo/tag"
 	userRepo "backend/app/internal/repo/user"
+	webhookRepo "backend/app/internal/repo/webhook"
+	schedulerPlugin "backend/app/plugins/scheduler"
+	"backend/app/server/middleware"
+	"backend/utils/jobqueue"
 
 	"go.uber.org/fx"
 )
@@ -22,29 +44,90 @@ var RepoModule = fx.Module("repo",
 			userRepo.NewUserRepo,
 			fx.As(new(userLogic.UserRepo)),
 			fx.As(new(baseRepo.UserRepo)),
+			fx.As(new(exportLogic.UserRepo)),
+			fx.As(new(limitLogic.UserRepo)),
+			fx.As(new(feedLogic.UserRepo)),
+			fx.As(new(statsLogic.UserRepo)),
+			fx.As(new(botLogic.UserRepo)),
 		),
 		// Sys Repo
 		fx.Annotate(
 			sysRepo.NewSysRepo,
 			fx.As(new(baseRepo.SysRepo)),
+			fx.As(new(systemLogic.SysRepo)),
+			fx.As(new(schedulerPlugin.SysRepo)),
 		),
 		// File Repo
 		fx.Annotate(
 			fileRepo.NewFileRepo,
 			fx.As(new(fileLogic.FileRepo)),
+			fx.As(new(exportLogic.FileRepo)),
+			fx.As(new(statsLogic.FileRepo)),
 		),
 		// Item Repo
 		fx.Annotate(
 			itemRepo.NewItemRepo,
 			fx.As(new(itemLogic.ItemRepo)),
+			fx.As(new(notifyLogic.NotifyItemRepo)),
+			fx.As(new(exportLogic.ItemRepo)),
+			fx.As(new(feedLogic.ItemRepo)),
+			fx.As(new(statsLogic.ItemRepo)),
+		),
+		// Item Search Repo
+		fx.Annotate(
+			searchRepo.NewItemSearchRepo,
+			fx.As(new(itemLogic.ItemSearchRepo)),
+		),
+		// Retention Repo
+		fx.Annotate(
+			retentionRepo.NewRetentionRepo,
+			fx.As(new(retentionLogic.RetentionRepo)),
+		),
+		// Queue Repo
+		fx.Annotate(
+			queueRepo.NewQueueRepo,
+			fx.As(new(jobqueue.Store)),
+			fx.As(new(systemLogic.QueueRepo)),
 		),
 		// Tag Repo
 		fx.Annotate(
 			tagRepo.NewTagRepo,
 			fx.As(new(tagLogic.TagRepo)),
 			fx.As(new(itemLogic.ItemTagRepo)),
+			fx.As(new(exportLogic.TagRepo)),
+			fx.As(new(importerLogic.TagRepo)),
+			fx.As(new(feedLogic.TagRepo)),
+		),
+		// Webhook Repo
+		fx.Annotate(
+			webhookRepo.NewWebhookRepo,
+			fx.As(new(webhookLogic.WebhookRepo)),
+		),
+		// Notify Repo
+		fx.Annotate(
+			notifyRepo.NewNotifyRepo,
+			fx.As(new(notifyLogic.NotifyRepo)),
+		),
+		// Change Repo
+		fx.Annotate(
+			changeRepo.NewChangeRepo,
+			fx.As(new(changeLogic.ChangeRepo)),
+		),
+		// Audit Repo
+		fx.Annotate(
+			auditRepo.NewAuditRepo,
+			fx.As(new(auditLogic.AuditRepo)),
+		),
+		// Tx Repo
+		fx.Annotate(
+			baseRepo.NewTxRepo,
+			fx.As(new(middleware.DryRunner)),
 		),
 	),
-	// 初始化基础数据
+)
+
+// InitBaseDataModule 启动时初始化基础数据（表迁移、版本记录、默认管理员）
+// 与 RepoModule 分开，命令行子命令只使用 RepoModule，由 migrate、seed 显式执行
+var InitBaseDataModule = fx.Module("repo-init",
 	fx.Invoke(baseRepo.InitBaseData),
 )
@@ -0,0 +1,212 @@
+package queue
+
+import (
+	"context"
+	"time"
+
+	"backend/app/internal/repo/base"
+	queueModel "backend/app/model/queue"
+	"backend/utils/jobqueue"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type QueueRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+// QueueRepo 基于数据库表的任务队列存储
+// 领取任务时使用带状态条件的更新抢占，多个实例同时领取时每个任务只会被一个实例领取
+type QueueRepo struct {
+	db *gorm.DB
+}
+
+func NewQueueRepo(params QueueRepoParams) *QueueRepo {
+	return &QueueRepo{
+		db: params.DB,
+	}
+}
+
+// Enqueue 保存新任务
+func (r *QueueRepo) Enqueue(ctx context.Context, job *jobqueue.Job) error {
+	record := &queueModel.QueueJob{
+		JobType:     job.Type,
+		Payload:     string(job.Payload),
+		Status:      string(job.Status),
+		MaxAttempts: job.MaxAttempts,
+		RunAt:       job.RunAt,
+	}
+	if err := base.Conn(ctx, r.db).Create(record).Error; err != nil {
+		return err
+	}
+	*job = *toJob(record)
+	return nil
+}
+
+// Claim 领取可执行的任务
+func (r *QueueRepo) Claim(ctx context.Context, types []string, now time.Time, leaseUntil time.Time, limit int) ([]*jobqueue.Job, error) {
+	if len(types) == 0 || limit <= 0 {
+		return nil, nil
+	}
+
+	var candidates []queueModel.QueueJob
+	if err := base.Conn(ctx, r.db).
+		Where("job_type IN ?", types).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_until < ?)",
+			jobqueue.StatusPending, now, jobqueue.StatusRunning, now).
+		Order("run_at, id").
+		Limit(limit).
+		Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	var claimed []*jobqueue.Job
+	for _, candidate := range candidates {
+		// 只有状态和执行次数未被其他实例修改时才能领取成功
+		result := base.Conn(ctx, r.db).Model(&queueModel.QueueJob{}).
+			Where("id = ? AND status = ? AND attempts = ?", candidate.ID, candidate.Status, candidate.Attempts).
+			Updates(map[string]interface{}{
+				"status":       jobqueue.StatusRunning,
+				"attempts":     candidate.Attempts + 1,
+				"locked_until": leaseUntil,
+			})
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		candidate.Status = string(jobqueue.StatusRunning)
+		candidate.Attempts++
+		candidate.LockedUntil = &leaseUntil
+		claimed = append(claimed, toJob(&candidate))
+	}
+	return claimed, nil
+}
+
+// Complete 标记任务执行成功
+func (r *QueueRepo) Complete(ctx context.Context, id uint, finishedAt time.Time) error {
+	return base.Conn(ctx, r.db).Model(&queueModel.QueueJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       jobqueue.StatusSucceeded,
+		"locked_until": nil,
+		"last_error":   "",
+		"finished_at":  finishedAt,
+	}).Error
+}
+
+// Retry 标记任务执行失败，等待重试
+func (r *QueueRepo) Retry(ctx context.Context, id uint, runAt time.Time, lastError string) error {
+	return base.Conn(ctx, r.db).Model(&queueModel.QueueJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       jobqueue.StatusPending,
+		"run_at":       runAt,
+		"locked_until": nil,
+		"last_error":   lastError,
+	}).Error
+}
+
+// Dead 标记任务为死信
+func (r *QueueRepo) Dead(ctx context.Context, id uint, finishedAt time.Time, lastError string) error {
+	return base.Conn(ctx, r.db).Model(&queueModel.QueueJob{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":       jobqueue.StatusDead,
+		"locked_until": nil,
+		"last_error":   lastError,
+		"finished_at":  finishedAt,
+	}).Error
+}
+
+// ListJobs 分页查询任务，status、jobType 为空时不过滤，按 ID 倒序
+func (r *QueueRepo) ListJobs(ctx context.Context, status string, jobType string, page, pageSize int) ([]*jobqueue.Job, int64, error) {
+	query := base.Conn(ctx, r.db).Model(&queueModel.QueueJob{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if jobType != "" {
+		query = query.Where("job_type = ?", jobType)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var records []queueModel.QueueJob
+	if err := query.Order("id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&records).Error; err != nil {
+		return nil, 0, err
+	}
+
+	jobs := make([]*jobqueue.Job, 0, len(records))
+	for i := range records {
+		jobs = append(jobs, toJob(&records[i]))
+	}
+	return jobs, total, nil
+}
+
+// GetJob 查询任务
+// 如果任务不存在，返回 gorm.ErrRecordNotFound 错误
+func (r *QueueRepo) GetJob(ctx context.Context, id uint) (*jobqueue.Job, error) {
+	var record queueModel.QueueJob
+	if err := base.Conn(ctx, r.db).Where("id = ?", id).First(&record).Error; err != nil {
+		return nil, err
+	}
+	return toJob(&record), nil
+}
+
+// RequeueJob 将死信任务重新放入队列，执行次数清零
+// 返回是否更新成功，任务不是死信状态时返回 false
+func (r *QueueRepo) RequeueJob(ctx context.Context, id uint, runAt time.Time) (bool, error) {
+	result := base.Conn(ctx, r.db).Model(&queueModel.QueueJob{}).
+		Where("id = ? AND status = ?", id, jobqueue.StatusDead).
+		Updates(map[string]interface{}{
+			"status":      jobqueue.StatusPending,
+			"attempts":    0,
+			"run_at":      runAt,
+			"finished_at": nil,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountByStatus 统计各状态的任务数量
+func (r *QueueRepo) CountByStatus(ctx context.Context) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	if err := base.Conn(ctx, r.db).Model(&queueModel.QueueJob{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// toJob 数据库记录转换为队列任务
+func toJob(record *queueModel.QueueJob) *jobqueue.Job {
+	job := &jobqueue.Job{
+		ID:          record.ID,
+		TenantID:    record.TenantID,
+		CreatedBy:   record.CreatedBy,
+		Type:        record.JobType,
+		Status:      jobqueue.Status(record.Status),
+		Attempts:    record.Attempts,
+		MaxAttempts: record.MaxAttempts,
+		RunAt:       record.RunAt,
+		LastError:   record.LastError,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+		FinishedAt:  record.FinishedAt,
+	}
+	if record.Payload != "" {
+		job.Payload = []byte(record.Payload)
+	}
+	return job
+}
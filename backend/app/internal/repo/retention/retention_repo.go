@@ -0,0 +1,71 @@
+package retention
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/app/internal/repo/base"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type RetentionRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+// RetentionRepo 数据保留仓库，按时间列清理过期数据
+// 直接按表名操作，不经过模型的软删除，清理后的数据无法恢复
+type RetentionRepo struct {
+	db *gorm.DB
+}
+
+func NewRetentionRepo(params RetentionRepoParams) *RetentionRepo {
+	return &RetentionRepo{
+		db: params.DB,
+	}
+}
+
+// HasColumn 检查表是否存在且包含指定列
+func (r *RetentionRepo) HasColumn(ctx context.Context, table string, column string) bool {
+	migrator := base.Conn(ctx, r.db).Migrator()
+	return migrator.HasTable(table) && migrator.HasColumn(table, column)
+}
+
+// CountExpired 统计 column 早于 before 的记录数
+func (r *RetentionRepo) CountExpired(ctx context.Context, table string, column string, before time.Time) (int64, error) {
+	var count int64
+	err := base.Conn(ctx, r.db).
+		Table(table).
+		Where(fmt.Sprintf("%s IS NOT NULL AND %s < ?", column, column), before).
+		Count(&count).Error
+	return count, err
+}
+
+// PurgeExpired 分批删除 column 早于 before 的记录，返回删除的记录数
+// 每批最多删除 batchSize 条，避免长时间持有写锁
+func (r *RetentionRepo) PurgeExpired(ctx context.Context, table string, column string, before time.Time, batchSize int) (int64, error) {
+	sql := fmt.Sprintf(
+		"DELETE FROM %s WHERE rowid IN (SELECT rowid FROM %s WHERE %s IS NOT NULL AND %s < ? LIMIT ?)",
+		table, table, column, column,
+	)
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		result := base.Conn(ctx, r.db).Exec(sql, before, batchSize)
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if result.RowsAffected < int64(batchSize) {
+			return total, nil
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/app/internal/repo/base"
+	itemModel "backend/app/model/item"
+	"backend/app/types/consts"
+	"backend/app/types/dto"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultItemIndex 默认项目索引名称
+	defaultItemIndex = "items"
+	// reindexBatchSize 重建索引时每批处理的项目数量
+	reindexBatchSize = 500
+)
+
+// itemIndexMapping 项目索引映射
+const itemIndexMapping = `{
+  "mappings": {
+    "properties": {
+      "item_id":    {"type": "long"},
+      "tenant_id":  {"type": "keyword"},
+      "content":    {"type": "text"},
+      "status":     {"type": "keyword"},
+      "tag_ids":    {"type": "long"},
+      "tag_names":  {"type": "text"},
+      "version":    {"type": "long"},
+      "created_at": {"type": "date"},
+      "updated_at": {"type": "date"}
+    }
+  }
+}`
+
+// itemDocument 项目索引文档
+type itemDocument struct {
+	ItemID    uint      `json:"item_id"`
+	TenantID  string    `json:"tenant_id"`
+	Content   string    `json:"content"`
+	Status    string    `json:"status"`
+	TagIDs    []uint    `json:"tag_ids"`
+	TagNames  []string  `json:"tag_names"`
+	Version   uint      `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ItemSearchRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+	// ES 客户端（可选），未配置 ElasticSearch 时为 nil
+	ES *elasticsearch.Client `optional:"true"`
+}
+
+// ItemSearchRepo 项目搜索索引仓库
+// 未配置 ElasticSearch 时 Enabled 返回 false，所有写操作均为空操作
+type ItemSearchRepo struct {
+	db    *gorm.DB
+	es    *elasticsearch.Client
+	index string
+}
+
+func NewItemSearchRepo(params ItemSearchRepoParams) *ItemSearchRepo {
+	index := envx.GetStringOptional(consts.ESItemIndex)
+	if index == "" {
+		index = defaultItemIndex
+	}
+
+	return &ItemSearchRepo{
+		db:    params.DB,
+		es:    params.ES,
+		index: index,
+	}
+}
+
+// Enabled 是否启用了 ElasticSearch 搜索
+func (r *ItemSearchRepo) Enabled() bool {
+	return r.es != nil
+}
+
+// EnsureIndex 确保项目索引存在，不存在时按映射创建
+func (r *ItemSearchRepo) EnsureIndex(ctx context.Context) error {
+	if !r.Enabled() {
+		return nil
+	}
+
+	res, err := r.es.Indices.Exists([]string{r.index}, r.es.Indices.Exists.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	res, err = r.es.Indices.Create(r.index,
+		r.es.Indices.Create.WithContext(ctx),
+		r.es.Indices.Create.WithBody(bytes.NewReader([]byte(itemIndexMapping))),
+	)
+	if err != nil {
+		return err
+	}
+	return checkResponse(res)
+}
+
+// IndexItem 索引单个项目（已存在时覆盖）
+// 租户ID从 context 中读取
+func (r *ItemSearchRepo) IndexItem(ctx context.Context, item *dto.ItemDTO) error {
+	if !r.Enabled() || item == nil {
+		return nil
+	}
+
+	tenantID, _ := ctx.Value(meta.ContextKeyTenantID).(string)
+	body, err := json.Marshal(newItemDocument(item, tenantID))
+	if err != nil {
+		return err
+	}
+
+	res, err := r.es.Index(r.index, bytes.NewReader(body),
+		r.es.Index.WithContext(ctx),
+		r.es.Index.WithDocumentID(strconv.FormatUint(uint64(item.ItemID), 10)),
+	)
+	if err != nil {
+		return err
+	}
+	return checkResponse(res)
+}
+
+// DeleteItem 从索引中删除项目，文档不存在时忽略
+func (r *ItemSearchRepo) DeleteItem(ctx context.Context, itemID uint) error {
+	if !r.Enabled() {
+		return nil
+	}
+
+	res, err := r.es.Delete(r.index, strconv.FormatUint(uint64(itemID), 10), r.es.Delete.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil
+	}
+	return checkResponse(res)
+}
+
+// SearchItemIDs 搜索项目，返回按相关度排序的项目ID和命中总数
+// 启用多租户时只搜索当前租户的项目
+func (r *ItemSearchRepo) SearchItemIDs(ctx context.Context, keyword string, status *meta.ItemStatus, page, pageSize int) ([]uint, int64, error) {
+	if !r.Enabled() {
+		return nil, 0, nil
+	}
+
+	filters := make([]map[string]interface{}, 0, 2)
+	if status != nil {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"status": string(*status)}})
+	}
+	if tenantID, ok := ctx.Value(meta.ContextKeyTenantID).(string); ok {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"tenant_id": tenantID}})
+	}
+
+	query := map[string]interface{}{
+		"from":    (page - 1) * pageSize,
+		"size":    pageSize,
+		"_source": []string{"item_id"},
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"must": map[string]interface{}{
+					"multi_match": map[string]interface{}{
+						"query":  keyword,
+						"fields": []string{"content", "tag_names"},
+					},
+				},
+				"filter": filters,
+			},
+		},
+		"sort": []interface{}{"_score", map[string]interface{}{"created_at": "desc"}},
+	}
+
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := r.es.Search(
+		r.es.Search.WithContext(ctx),
+		r.es.Search.WithIndex(r.index),
+		r.es.Search.WithBody(bytes.NewReader(body)),
+		r.es.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return nil, 0, fmt.Errorf("ElasticSearch 搜索失败: %s", res.String())
+	}
+
+	var result struct {
+		Hits struct {
+			Total struct {
+				Value int64 `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Source struct {
+					ItemID uint `json:"item_id"`
+				} `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, 0, err
+	}
+
+	itemIDs := make([]uint, 0, len(result.Hits.Hits))
+	for _, hit := range result.Hits.Hits {
+		itemIDs = append(itemIDs, hit.Source.ItemID)
+	}
+
+	return itemIDs, result.Hits.Total.Value, nil
+}
+
+// Reindex 全量重建项目索引
+// 按 ID 分批读取数据库中的全部项目并批量写入索引，返回写入的项目数量
+func (r *ItemSearchRepo) Reindex(ctx context.Context) (int, error) {
+	if !r.Enabled() {
+		return 0, nil
+	}
+
+	if err := r.EnsureIndex(ctx); err != nil {
+		return 0, err
+	}
+
+	var lastID uint
+	total := 0
+	for {
+		var items []*itemModel.Item
+		if err := base.Conn(ctx, r.db).Where("id > ?", lastID).Order("id ASC").Limit(reindexBatchSize).Find(&items).Error; err != nil {
+			return total, err
+		}
+		if len(items) == 0 {
+			break
+		}
+
+		docs, err := r.buildDocuments(ctx, items)
+		if err != nil {
+			return total, err
+		}
+		if err := r.bulkIndex(ctx, docs); err != nil {
+			return total, err
+		}
+
+		total += len(docs)
+		lastID = items[len(items)-1].ID
+		logs.CtxInfof(ctx, "项目索引重建中: indexed=%d, last_id=%d", total, lastID)
+
+		if len(items) < reindexBatchSize {
+			break
+		}
+	}
+
+	return total, nil
+}
+
+// buildDocuments 根据项目列表构建索引文档（批量查询标签）
+func (r *ItemSearchRepo) buildDocuments(ctx context.Context, items []*itemModel.Item) ([]itemDocument, error) {
+	itemIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	var rows []struct {
+		ItemID  uint   `gorm:"column:item_id"`
+		TagID   uint   `gorm:"column:tag_id"`
+		TagName string `gorm:"column:tag_name"`
+	}
+	err := base.Conn(ctx, r.db).
+		Table("item_tag").
+		Select("item_tag.item_id, item_tag.tag_id, tag.tag_name").
+		Joins("INNER JOIN tag ON tag.id = item_tag.tag_id").
+		Where("item_tag.item_id IN ?", itemIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	tagIDs := make(map[uint][]uint, len(items))
+	tagNames := make(map[uint][]string, len(items))
+	for _, row := range rows {
+		tagIDs[row.ItemID] = append(tagIDs[row.ItemID], row.TagID)
+		tagNames[row.ItemID] = append(tagNames[row.ItemID], row.TagName)
+	}
+
+	docs := make([]itemDocument, 0, len(items))
+	for _, item := range items {
+		docs = append(docs, itemDocument{
+			ItemID:    item.ID,
+			TenantID:  item.TenantID,
+			Content:   item.Content,
+			Status:    item.Status,
+			TagIDs:    tagIDs[item.ID],
+			TagNames:  tagNames[item.ID],
+			Version:   item.Version,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+	return docs, nil
+}
+
+// bulkIndex 批量写入索引文档
+func (r *ItemSearchRepo) bulkIndex(ctx context.Context, docs []itemDocument) error {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		action := map[string]interface{}{
+			"index": map[string]interface{}{"_id": strconv.FormatUint(uint64(doc.ItemID), 10)},
+		}
+		if err := json.NewEncoder(&buf).Encode(action); err != nil {
+			return err
+		}
+		if err := json.NewEncoder(&buf).Encode(doc); err != nil {
+			return err
+		}
+	}
+
+	res, err := r.es.Bulk(bytes.NewReader(buf.Bytes()),
+		r.es.Bulk.WithContext(ctx),
+		r.es.Bulk.WithIndex(r.index),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("ElasticSearch 批量写入失败: %s", res.String())
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	if result.Errors {
+		return fmt.Errorf("ElasticSearch 批量写入部分失败")
+	}
+	return nil
+}
+
+// newItemDocument 根据项目 DTO 构建索引文档
+func newItemDocument(item *dto.ItemDTO, tenantID string) itemDocument {
+	doc := itemDocument{
+		ItemID:    item.ItemID,
+		TenantID:  tenantID,
+		Content:   item.Content,
+		Status:    item.Status,
+		TagIDs:    make([]uint, 0, len(item.Tags)),
+		TagNames:  make([]string, 0, len(item.Tags)),
+		Version:   item.Version,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+	for _, tag := range item.Tags {
+		doc.TagIDs = append(doc.TagIDs, tag.TagID)
+		doc.TagNames = append(doc.TagNames, tag.TagName)
+	}
+	return doc
+}
+
+// checkResponse 检查 ES 响应状态并关闭响应体
+// 429 和 5xx 响应（限流、集群暂时不可用）标记为可重试
+func checkResponse(res *esapi.Response) error {
+	defer res.Body.Close()
+	if res.IsError() {
+		body, _ := io.ReadAll(res.Body)
+		err := fmt.Errorf("ElasticSearch 请求失败: status=%d, body=%s", res.StatusCode, string(body))
+		return errorx.MarkRetryable(err, res.StatusCode == http.StatusTooManyRequests || res.StatusCode >= http.StatusInternalServerError)
+	}
+	return nil
+}
@@ -2,7 +2,9 @@ package sys
 
 import (
 	"context"
+	"fmt"
 
+	"backend/app/internal/repo/base"
 	sysModel "backend/app/model/system"
 
 	"go.uber.org/fx"
@@ -29,7 +31,7 @@ func NewSysRepo(params SysRepoParams) *SysRepo {
 // 如果配置不存在，返回 gorm.ErrRecordNotFound 错误
 func (r *SysRepo) GetSystemConfig(ctx context.Context, key string) (string, error) {
 	var systemConfig sysModel.SystemConfig
-	if err := r.db.WithContext(ctx).Where("k = ?", key).First(&systemConfig).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("k = ?", key).First(&systemConfig).Error; err != nil {
 		return "", err
 	}
 	return systemConfig.V, nil
@@ -38,7 +40,7 @@ func (r *SysRepo) GetSystemConfig(ctx context.Context, key string) (string, erro
 // SetSystemConfig 设置系统配置（更新已存在的配置）
 // 如果配置不存在，不会创建新记录
 func (r *SysRepo) SetSystemConfig(ctx context.Context, key string, value string) error {
-	return r.db.WithContext(ctx).Model(&sysModel.SystemConfig{}).Where("k = ?", key).Update("v", value).Error
+	return base.Conn(ctx, r.db).Model(&sysModel.SystemConfig{}).Where("k = ?", key).Update("v", value).Error
 }
 
 // CreateOrUpdateSystemConfig 创建或更新系统配置
@@ -51,5 +53,35 @@ func (r *SysRepo) CreateOrUpdateSystemConfig(ctx context.Context, key string, va
 	// 使用 FirstOrCreate 或 Save 来实现创建或更新
 	// 这里使用 Save，它会根据主键或唯一索引来决定是创建还是更新
 	// 由于 SystemConfig 没有主键，我们使用 FirstOrCreate
-	return r.db.WithContext(ctx).Where("k = ?", key).Assign(sysModel.SystemConfig{V: value}).FirstOrCreate(&systemConfig).Error
+	return base.Conn(ctx, r.db).Where("k = ?", key).Assign(sysModel.SystemConfig{V: value}).FirstOrCreate(&systemConfig).Error
+}
+
+// GetDatabaseInfo 获取数据库驱动名称和版本
+func (r *SysRepo) GetDatabaseInfo(ctx context.Context) (string, string, error) {
+	driver := r.db.Dialector.Name()
+
+	var query string
+	switch driver {
+	case "sqlite":
+		query = "SELECT sqlite_version()"
+	case "mysql":
+		query = "SELECT VERSION()"
+	default:
+		return driver, "", nil
+	}
+
+	var version string
+	if err := base.Conn(ctx, r.db).Scopes(base.CrossTenant).Raw(query).Scan(&version).Error; err != nil {
+		return driver, "", err
+	}
+	return driver, version, nil
+}
+
+// BackupDatabase 将数据库备份到指定文件（仅支持 SQLite，使用 VACUUM INTO 生成一致的快照）
+// 目标文件已存在时返回错误
+func (r *SysRepo) BackupDatabase(ctx context.Context, path string) error {
+	if driver := r.db.Dialector.Name(); driver != "sqlite" {
+		return fmt.Errorf("不支持备份 %s 数据库", driver)
+	}
+	return base.Conn(ctx, r.db).Scopes(base.CrossTenant).Exec("VACUUM INTO ?", path).Error
 }
@@ -3,6 +3,7 @@ package tag
 import (
 	"context"
 
+	"backend/app/internal/repo/base"
 	tagModel "backend/app/model/tag"
 	"backend/app/types/dto"
 
@@ -28,23 +29,34 @@ func NewTagRepo(params TagRepoParams) *TagRepo {
 
 // CreateTag 创建标签
 func (r *TagRepo) CreateTag(ctx context.Context, tag *tagModel.Tag) error {
-	return r.db.WithContext(ctx).Create(tag).Error
+	return base.Conn(ctx, r.db).Create(tag).Error
+}
+
+// CreateTags 批量创建标签（用于导入）
+func (r *TagRepo) CreateTags(ctx context.Context, tags []*tagModel.Tag) error {
+	return base.BatchInsert(ctx, r.db, tags, 0)
 }
 
 // UpdateTag 更新标签
 func (r *TagRepo) UpdateTag(ctx context.Context, tagID uint, updates map[string]interface{}) error {
-	return r.db.WithContext(ctx).Model(&tagModel.Tag{}).Where("id = ?", tagID).Updates(updates).Error
+	return base.Conn(ctx, r.db).Model(&tagModel.Tag{}).Where("id = ?", tagID).Updates(base.BumpVersion(updates)).Error
+}
+
+// UpdateTagWithVersion 基于版本号更新标签
+// 如果标签当前版本号与 version 不一致，返回 base.ErrVersionConflict
+func (r *TagRepo) UpdateTagWithVersion(ctx context.Context, tagID uint, version uint, updates map[string]interface{}) error {
+	return base.UpdateWithVersion(ctx, r.db, &tagModel.Tag{}, tagID, version, updates)
 }
 
 // DeleteTag 删除标签
 func (r *TagRepo) DeleteTag(ctx context.Context, tagID uint) error {
-	return r.db.WithContext(ctx).Where("id = ?", tagID).Delete(&tagModel.Tag{}).Error
+	return base.Conn(ctx, r.db).Where("id = ?", tagID).Delete(&tagModel.Tag{}).Error
 }
 
 // GetTagByID 根据ID获取标签
 func (r *TagRepo) GetTagByID(ctx context.Context, tagID uint) (*tagModel.Tag, error) {
 	var tag tagModel.Tag
-	if err := r.db.WithContext(ctx).Where("id = ?", tagID).First(&tag).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("id = ?", tagID).First(&tag).Error; err != nil {
 		return nil, err
 	}
 	return &tag, nil
@@ -53,18 +65,39 @@ func (r *TagRepo) GetTagByID(ctx context.Context, tagID uint) (*tagModel.Tag, er
 // GetTagByValue 根据值获取标签
 func (r *TagRepo) GetTagByValue(ctx context.Context, tagValue string) (*tagModel.Tag, error) {
 	var tag tagModel.Tag
-	if err := r.db.WithContext(ctx).Where("tag_value = ?", tagValue).First(&tag).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("tag_value = ?", tagValue).First(&tag).Error; err != nil {
 		return nil, err
 	}
 	return &tag, nil
 }
 
+// GetTagsByValues 根据值批量获取标签
+func (r *TagRepo) GetTagsByValues(ctx context.Context, tagValues []string) ([]*tagModel.Tag, error) {
+	var tags []*tagModel.Tag
+	if len(tagValues) == 0 {
+		return tags, nil
+	}
+	if err := base.Conn(ctx, r.db).Where("tag_value IN ?", tagValues).Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// ListTagsByCreator 获取用户创建的所有标签，按 ID 升序返回（用于数据导出）
+func (r *TagRepo) ListTagsByCreator(ctx context.Context, userID uint) ([]*tagModel.Tag, error) {
+	var tags []*tagModel.Tag
+	if err := base.Conn(ctx, r.db).Where("created_by = ?", userID).Order("id ASC").Find(&tags).Error; err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
 // GetTagList 获取标签列表
 func (r *TagRepo) GetTagList(ctx context.Context, page, pageSize int) ([]*tagModel.Tag, int64, error) {
 	var tags []*tagModel.Tag
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&tagModel.Tag{})
+	query := base.Conn(ctx, r.db).Model(&tagModel.Tag{}).Scopes(base.Explain)
 
 	// 获取总数
 	if err := query.Count(&total).Error; err != nil {
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"backend/app/internal/repo/base"
 	userModel "backend/app/model/user"
 
 	"go.uber.org/fx"
@@ -27,7 +28,7 @@ func NewUserRepo(params UserRepoParams) *UserRepo {
 
 func (r *UserRepo) GetUserByUsername(ctx context.Context, username string) (*userModel.User, error) {
 	var user userModel.User
-	if err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("username = ?", username).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -35,16 +36,62 @@ func (r *UserRepo) GetUserByUsername(ctx context.Context, username string) (*use
 
 func (r *UserRepo) GetUserByID(ctx context.Context, userID uint) (*userModel.User, error) {
 	var user userModel.User
-	if err := r.db.WithContext(ctx).Where("id = ?", userID).First(&user).Error; err != nil {
+	if err := base.Conn(ctx, r.db).Where("id = ?", userID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *UserRepo) GetUserByEmail(ctx context.Context, email string) (*userModel.User, error) {
+	var user userModel.User
+	if err := base.Conn(ctx, r.db).Where("email = ?", email).First(&user).Error; err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
 func (r *UserRepo) CreateUser(ctx context.Context, user *userModel.User) error {
-	return r.db.WithContext(ctx).Create(user).Error
+	return base.Conn(ctx, r.db).Create(user).Error
 }
 
 func (r *UserRepo) UpdateUserInfo(ctx context.Context, userID uint, updates map[string]interface{}) error {
-	return r.db.WithContext(ctx).Model(&userModel.User{}).Where("id = ?", userID).Updates(updates).Error
+	return base.Conn(ctx, r.db).Model(&userModel.User{}).Where("id = ?", userID).Updates(base.BumpVersion(updates)).Error
+}
+
+// UpdateUserInfoWithVersion 基于版本号更新用户信息
+// 如果用户当前版本号与 version 不一致，返回 base.ErrVersionConflict
+func (r *UserRepo) UpdateUserInfoWithVersion(ctx context.Context, userID uint, version uint, updates map[string]interface{}) error {
+	return base.UpdateWithVersion(ctx, r.db, &userModel.User{}, userID, version, updates)
+}
+
+// CountUsers 统计用户数
+func (r *UserRepo) CountUsers(ctx context.Context) (int64, error) {
+	var total int64
+	err := base.Conn(ctx, r.db).Model(&userModel.User{}).Count(&total).Error
+	return total, err
+}
+
+// GetUserByTelegramChatID 根据绑定的 Telegram 会话ID查询用户
+func (r *UserRepo) GetUserByTelegramChatID(ctx context.Context, chatID int64) (*userModel.User, error) {
+	var user userModel.User
+	if err := base.Conn(ctx, r.db).Where("telegram_chat_id = ?", chatID).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByTelegramLinkCode 根据 Telegram 绑定码哈希查询用户，不检查是否过期
+func (r *UserRepo) GetUserByTelegramLinkCode(ctx context.Context, codeHash string) (*userModel.User, error) {
+	var user userModel.User
+	if err := base.Conn(ctx, r.db).Where("telegram_link_code = ?", codeHash).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ClearTelegramChatID 解除所有用户与指定 Telegram 会话的绑定，一个会话只能绑定一个用户
+func (r *UserRepo) ClearTelegramChatID(ctx context.Context, chatID int64) error {
+	return base.Conn(ctx, r.db).Model(&userModel.User{}).
+		Where("telegram_chat_id = ?", chatID).
+		Updates(base.BumpVersion(map[string]interface{}{"telegram_chat_id": 0})).Error
 }
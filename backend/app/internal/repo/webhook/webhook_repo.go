@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+
+	"backend/app/internal/repo/base"
+	webhookModel "backend/app/model/webhook"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+type WebhookRepoParams struct {
+	fx.In
+
+	DB *gorm.DB
+}
+
+type WebhookRepo struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepo(params WebhookRepoParams) *WebhookRepo {
+	return &WebhookRepo{
+		db: params.DB,
+	}
+}
+
+// CreateWebhook 创建 webhook
+func (r *WebhookRepo) CreateWebhook(ctx context.Context, webhook *webhookModel.Webhook) error {
+	return base.Conn(ctx, r.db).Create(webhook).Error
+}
+
+// UpdateWebhook 更新 webhook
+// 更新 secret 时需要通过 Select 保存整条记录，才会经过加密序列化器，因此这里接收完整的模型
+func (r *WebhookRepo) UpdateWebhook(ctx context.Context, webhook *webhookModel.Webhook) error {
+	return base.Conn(ctx, r.db).Select("url", "secret", "events", "description", "enabled", "updated_at", "updated_by").Save(webhook).Error
+}
+
+// DeleteWebhook 删除 webhook 及其投递记录
+func (r *WebhookRepo) DeleteWebhook(ctx context.Context, webhookID uint) error {
+	return base.Conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhookID).Delete(&webhookModel.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", webhookID).Delete(&webhookModel.Webhook{}).Error
+	})
+}
+
+// GetWebhookByID 根据ID获取 webhook
+func (r *WebhookRepo) GetWebhookByID(ctx context.Context, webhookID uint) (*webhookModel.Webhook, error) {
+	var webhook webhookModel.Webhook
+	if err := base.Conn(ctx, r.db).Where("id = ?", webhookID).First(&webhook).Error; err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetWebhookList 获取 webhook 列表
+func (r *WebhookRepo) GetWebhookList(ctx context.Context, page, pageSize int) ([]*webhookModel.Webhook, int64, error) {
+	var webhooks []*webhookModel.Webhook
+	var total int64
+
+	query := base.Conn(ctx, r.db).Model(&webhookModel.Webhook{})
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&webhooks).Error; err != nil {
+		return nil, 0, err
+	}
+	return webhooks, total, nil
+}
+
+// GetEnabledWebhooks 获取所有启用的 webhook（事件过滤由调用方处理）
+func (r *WebhookRepo) GetEnabledWebhooks(ctx context.Context) ([]*webhookModel.Webhook, error) {
+	var webhooks []*webhookModel.Webhook
+	if err := base.Conn(ctx, r.db).Where("enabled = ?", true).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// CreateDelivery 创建投递记录
+func (r *WebhookRepo) CreateDelivery(ctx context.Context, delivery *webhookModel.WebhookDelivery) error {
+	return base.Conn(ctx, r.db).Create(delivery).Error
+}
+
+// UpdateDelivery 更新投递记录
+func (r *WebhookRepo) UpdateDelivery(ctx context.Context, deliveryID uint, updates map[string]interface{}) error {
+	return base.Conn(ctx, r.db).Model(&webhookModel.WebhookDelivery{}).Where("id = ?", deliveryID).Updates(updates).Error
+}
+
+// GetDeliveryByID 根据ID获取投递记录
+func (r *WebhookRepo) GetDeliveryByID(ctx context.Context, deliveryID uint) (*webhookModel.WebhookDelivery, error) {
+	var delivery webhookModel.WebhookDelivery
+	if err := base.Conn(ctx, r.db).Where("id = ?", deliveryID).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// GetDeliveryList 获取 webhook 的投递记录，status 为空时不过滤，按ID倒序
+func (r *WebhookRepo) GetDeliveryList(ctx context.Context, webhookID uint, status string, page, pageSize int) ([]*webhookModel.WebhookDelivery, int64, error) {
+	var deliveries []*webhookModel.WebhookDelivery
+	var total int64
+
+	query := base.Conn(ctx, r.db).Model(&webhookModel.WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("id DESC").Offset(offset).Limit(pageSize).Find(&deliveries).Error; err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
@@ -0,0 +1,54 @@
+package audit
+
+import "time"
+
+var AuditLogTableName = "audit_log"
+
+// 审计的数据类型
+const (
+	EntityUser    = "user"
+	EntityItem    = "item"
+	EntityTag     = "tag"
+	EntityWebhook = "webhook"
+	EntitySystem  = "system"
+)
+
+// 审计操作
+const (
+	ActionLogin          = "login"
+	ActionLoginFailed    = "login_failed"
+	ActionTokenRefreshed = "token_refreshed"
+	ActionEmailChanged   = "email_changed"
+	ActionPasswordReset  = "password_reset"
+	ActionCreated        = "created"
+	ActionUpdated        = "updated"
+	ActionDeleted        = "deleted"
+	ActionConfigChanged  = "config_changed"
+)
+
+// AuditLog 审计日志，记录登录、删除数据、修改配置等安全相关的操作
+// 修改操作的详情中 before、after 为修改前后的快照，删除操作的详情为删除前的快照
+type AuditLog struct {
+	ID        uint      `gorm:"column:id;type:uint;primarykey;comment:ID"`
+	TenantID  string    `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_audit_log_tenant_id;comment:租户ID"`
+	CreatedAt time.Time `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_audit_log_created_at;comment:创建时间"`
+	UserID    uint      `gorm:"column:user_id;type:uint;not null;default:0;index:idx_audit_log_user_id;comment:操作人ID，未登录时为 0"`
+	Entity    string    `gorm:"column:entity;type:varchar(32);not null;comment:数据类型"`
+	EntityID  uint      `gorm:"column:entity_id;type:uint;not null;default:0;comment:数据ID"`
+	Action    string    `gorm:"column:action;type:varchar(32);not null;comment:操作"`
+	IP        string    `gorm:"column:ip;type:varchar(64);not null;default:'';comment:客户端IP"`
+	Detail    string    `gorm:"column:detail;type:text;comment:操作详情（JSON）"`
+}
+
+func (AuditLog) TableName() string {
+	return AuditLogTableName
+}
+
+// Filter 审计日志查询条件，字段为空表示不过滤
+type Filter struct {
+	UserID    *uint      // 操作人ID
+	Entity    string     // 数据类型
+	Action    string     // 操作
+	DateStart *time.Time // 创建时间下限（含）
+	DateEnd   *time.Time // 创建时间上限（不含）
+}
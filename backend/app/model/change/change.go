@@ -0,0 +1,34 @@
+package change
+
+import "time"
+
+var ChangeTableName = "sync_change"
+
+// 变更的数据类型
+const (
+	EntityItem = "item"
+	EntityTag  = "tag"
+)
+
+// 变更操作
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// Change 数据变更记录，ID 即同步序号，客户端按序号增量同步
+type Change struct {
+	ID        uint      `gorm:"column:id;type:uint;primarykey;comment:同步序号"`
+	TenantID  string    `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_sync_change_tenant_id;comment:租户ID"`
+	CreatedAt time.Time `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_sync_change_created_at;comment:创建时间"`
+	UserID    uint      `gorm:"column:user_id;type:uint;not null;default:0;comment:操作人ID"`
+	Entity    string    `gorm:"column:entity;type:varchar(32);not null;comment:数据类型"`
+	EntityID  uint      `gorm:"column:entity_id;type:uint;not null;comment:数据ID"`
+	Action    string    `gorm:"column:action;type:varchar(16);not null;comment:操作"`
+	Data      string    `gorm:"column:data;type:text;comment:变更后的数据（JSON），删除时为空"`
+}
+
+func (Change) TableName() string {
+	return ChangeTableName
+}
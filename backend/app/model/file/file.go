@@ -6,6 +6,9 @@ var FileTableName = "file"
 
 type File struct {
 	ID        uint      `gorm:"column:id;type:uint;primarykey;comment:文件ID"`
+	TenantID  string    `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_file_tenant_id;comment:租户ID"`
+	CreatedBy uint      `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	UpdatedBy uint      `gorm:"column:updated_by;type:uint;not null;default:0;comment:更新人ID"`
 	CreatedAt time.Time `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_file_created_at;comment:创建时间"`
 	UpdatedAt time.Time `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
 	// 文件名
@@ -7,11 +7,16 @@ import (
 var ItemTableName = "item"
 
 type Item struct {
-	ID        uint      `gorm:"column:id;type:uint;primarykey;comment:项目ID"`
-	CreatedAt time.Time `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_item_created_at;comment:创建时间"`
-	UpdatedAt time.Time `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
-	Content   string    `gorm:"column:content;type:text;not null;comment:内容"`
-	Status    string    `gorm:"column:status;type:varchar(12);not null;comment:状态"`
+	ID        uint       `gorm:"column:id;type:uint;primarykey;comment:项目ID"`
+	TenantID  string     `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_item_tenant_id;comment:租户ID"`
+	CreatedBy uint       `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	UpdatedBy uint       `gorm:"column:updated_by;type:uint;not null;default:0;comment:更新人ID"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_item_created_at;comment:创建时间"`
+	UpdatedAt time.Time  `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
+	Content   string     `gorm:"column:content;type:text;not null;comment:内容"`
+	Status    string     `gorm:"column:status;type:varchar(12);not null;comment:状态"`
+	DueAt     *time.Time `gorm:"column:due_at;type:datetime;index:idx_item_due_at;comment:截止时间"`
+	Version   uint       `gorm:"column:version;type:uint;not null;default:1;comment:版本号"`
 }
 
 func (Item) TableName() string {
@@ -0,0 +1,21 @@
+package notify
+
+import "time"
+
+var NotificationPreferenceTableName = "notification_preference"
+
+// NotificationPreference 用户通知偏好，没有记录时使用默认值
+// 验证邮箱、重置密码等事务邮件不受偏好影响
+type NotificationPreference struct {
+	ID           uint      `gorm:"column:id;type:uint;primarykey;comment:ID"`
+	TenantID     string    `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_notification_preference_tenant_id;comment:租户ID"`
+	CreatedAt    time.Time `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;comment:创建时间"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
+	UserID       uint      `gorm:"column:user_id;type:uint;not null;uniqueIndex:idx_notification_preference_user_id;comment:用户ID"`
+	EmailEnabled bool      `gorm:"column:email_enabled;type:boolean;not null;comment:是否接收通知邮件"`
+	DailyDigest  bool      `gorm:"column:daily_digest;type:boolean;not null;comment:是否接收每日待办摘要"`
+}
+
+func (NotificationPreference) TableName() string {
+	return NotificationPreferenceTableName
+}
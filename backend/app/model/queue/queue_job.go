@@ -0,0 +1,27 @@
+package queue
+
+import "time"
+
+var QueueJobTableName = "queue_job"
+
+// QueueJob 后台任务队列中的任务
+type QueueJob struct {
+	ID          uint       `gorm:"column:id;type:uint;primarykey;comment:任务ID"`
+	TenantID    string     `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_queue_job_tenant_id;comment:租户ID"`
+	CreatedBy   uint       `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;comment:创建时间"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
+	JobType     string     `gorm:"column:job_type;type:varchar(64);not null;comment:任务类型"`
+	Payload     string     `gorm:"column:payload;type:text;comment:任务参数（JSON）"`
+	Status      string     `gorm:"column:status;type:varchar(16);not null;index:idx_queue_job_status_run_at,priority:1;comment:任务状态"`
+	Attempts    int        `gorm:"column:attempts;type:int;not null;default:0;comment:已执行次数"`
+	MaxAttempts int        `gorm:"column:max_attempts;type:int;not null;default:0;comment:最大执行次数"`
+	RunAt       time.Time  `gorm:"column:run_at;type:datetime;not null;index:idx_queue_job_status_run_at,priority:2;comment:最早执行时间"`
+	LockedUntil *time.Time `gorm:"column:locked_until;type:datetime;comment:租约到期时间"`
+	LastError   string     `gorm:"column:last_error;type:text;comment:最近一次错误"`
+	FinishedAt  *time.Time `gorm:"column:finished_at;type:datetime;index:idx_queue_job_finished_at;comment:完成时间"`
+}
+
+func (QueueJob) TableName() string {
+	return QueueJobTableName
+}
@@ -4,9 +4,12 @@ var ItemTagTableName = "item_tag"
 
 // ItemTag 项目标签关系
 type ItemTag struct {
-	ID     uint `gorm:"column:id;type:uint;primarykey;comment:关系ID"`
-	ItemID uint `gorm:"column:item_id;type:uint;not null;comment:项目ID"`
-	TagID  uint `gorm:"column:tag_id;type:uint;not null;comment:标签ID"`
+	ID        uint   `gorm:"column:id;type:uint;primarykey;comment:关系ID"`
+	TenantID  string `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_item_tag_tenant_id;comment:租户ID"`
+	CreatedBy uint   `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	UpdatedBy uint   `gorm:"column:updated_by;type:uint;not null;default:0;comment:更新人ID"`
+	ItemID    uint   `gorm:"column:item_id;type:uint;not null;comment:项目ID"`
+	TagID     uint   `gorm:"column:tag_id;type:uint;not null;comment:标签ID"`
 }
 
 func (ItemTag) TableName() string {
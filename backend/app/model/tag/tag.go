@@ -6,11 +6,15 @@ var TagTableName = "tag"
 
 type Tag struct {
 	ID        uint           `gorm:"column:id;type:uint;primarykey;comment:标签ID"`
+	TenantID  string         `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_tag_tenant_id;comment:租户ID"`
+	CreatedBy uint           `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	UpdatedBy uint           `gorm:"column:updated_by;type:uint;not null;default:0;comment:更新人ID"`
 	TagName   string         `gorm:"column:tag_name;type:varchar(12);not null;comment:标签名"`
 	TagValue  string         `gorm:"column:tag_value;type:varchar(32);not null;comment:标签值"`
 	Icon      string         `gorm:"column:icon;type:varchar(255);not null;comment:图标"`
 	Color     string         `gorm:"column:color;type:varchar(12);not null;comment:颜色"`
 	ExtraData datatypes.JSON `gorm:"column:extra_data;type:json;comment:扩展数据"`
+	Version   uint           `gorm:"column:version;type:uint;not null;default:1;comment:版本号"`
 }
 
 func (Tag) TableName() string {
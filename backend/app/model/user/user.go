@@ -12,18 +12,42 @@ var UserTableName = "user"
 // User 用户
 type User struct {
 	ID           uint           `gorm:"column:id;type:uint;primarykey;comment:用户ID"`
+	TenantID     string         `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_user_tenant_id;comment:租户ID"`
+	CreatedBy    uint           `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	UpdatedBy    uint           `gorm:"column:updated_by;type:uint;not null;default:0;comment:更新人ID"`
 	CreatedAt    time.Time      `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_user_created_at;comment:创建时间"`
 	UpdatedAt    time.Time      `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
 	DeletedAt    gorm.DeletedAt `gorm:"column:deleted_at;type:datetime;uniqueIndex:idx_username_deleted,idx_email_deleted,idx_phone_deleted;index:idx_user_deleted_at,idx_user_status;comment:删除时间"`
 	Username     string         `gorm:"column:username;type:varchar(16);uniqueIndex:idx_username_deleted;comment:用户名"`
 	PasswordHash string         `gorm:"column:password_hash;type:varchar(512);comment:密码哈希"`
 
+	// 邮箱（用于通知邮件和重置密码）
+	Email         string `gorm:"column:email;type:varchar(128);not null;default:'';uniqueIndex:idx_email_deleted;comment:邮箱"`
+	EmailVerified bool   `gorm:"column:email_verified;type:boolean;not null;default:false;comment:邮箱是否已验证"`
+
 	// 系统内详细用户信息
 	NickName string `gorm:"column:nick_name;type:varchar(16);comment:昵称"`
 	Avatar   string `gorm:"column:avatar;type:varchar(255);comment:头像"`
 
+	// 语言偏好（如 en、zh-CN），为空时按 Accept-Language 选择
+	Locale string `gorm:"column:locale;type:varchar(16);not null;default:'';comment:语言偏好"`
+
+	// 限流档位，为空时使用默认档位
+	RateLimitTier string `gorm:"column:rate_limit_tier;type:varchar(32);not null;default:'';comment:限流档位"`
+
+	// 订阅密钥，订阅链接令牌绑定该密钥，重新生成后旧链接失效
+	FeedKey string `gorm:"column:feed_key;type:varchar(32);not null;default:'';comment:订阅密钥"`
+
+	// Telegram 机器人绑定：绑定的会话ID（0 表示未绑定），以及绑定码的哈希和过期时间（绑定成功后清空）
+	TelegramChatID        int64      `gorm:"column:telegram_chat_id;type:bigint;not null;default:0;index:idx_user_telegram_chat_id;comment:Telegram会话ID"`
+	TelegramLinkCode      string     `gorm:"column:telegram_link_code;type:varchar(64);not null;default:'';index:idx_user_telegram_link_code;comment:Telegram绑定码哈希"`
+	TelegramLinkExpiresAt *time.Time `gorm:"column:telegram_link_expires_at;type:datetime;comment:Telegram绑定码过期时间"`
+
 	// 扩展字段
 	ExtraData datatypes.JSON `gorm:"column:extra_data;type:json;comment:扩展字段"`
+
+	// 版本号（乐观锁）
+	Version uint `gorm:"column:version;type:uint;not null;default:1;comment:版本号"`
 }
 
 func (User) TableName() string {
@@ -0,0 +1,56 @@
+package webhook
+
+import "time"
+
+var WebhookTableName = "webhook"
+
+// Webhook 事件回调地址
+type Webhook struct {
+	ID          uint      `gorm:"column:id;type:uint;primarykey;comment:WebhookID"`
+	TenantID    string    `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_webhook_tenant_id;comment:租户ID"`
+	CreatedBy   uint      `gorm:"column:created_by;type:uint;not null;default:0;comment:创建人ID"`
+	UpdatedBy   uint      `gorm:"column:updated_by;type:uint;not null;default:0;comment:更新人ID"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;comment:创建时间"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
+	URL         string    `gorm:"column:url;type:varchar(512);not null;comment:回调地址"`
+	Secret      string    `gorm:"column:secret;type:text;not null;serializer:encrypt;comment:签名密钥（加密存储）"`
+	Events      string    `gorm:"column:events;type:varchar(255);not null;comment:订阅的事件，逗号分隔"`
+	Description string    `gorm:"column:description;type:varchar(255);not null;default:'';comment:说明"`
+	Enabled     bool      `gorm:"column:enabled;type:boolean;not null;default:true;comment:是否启用"`
+}
+
+func (Webhook) TableName() string {
+	return WebhookTableName
+}
+
+var WebhookDeliveryTableName = "webhook_delivery"
+
+// 投递状态
+const (
+	DeliveryStatusPending   = "pending"   // 等待投递
+	DeliveryStatusRetrying  = "retrying"  // 投递失败，等待重试
+	DeliveryStatusSucceeded = "succeeded" // 投递成功
+	DeliveryStatusFailed    = "failed"    // 投递失败，不再重试
+)
+
+// WebhookDelivery webhook 投递记录，每个事件对每个 webhook 一条，重试时更新
+type WebhookDelivery struct {
+	ID             uint       `gorm:"column:id;type:uint;primarykey;comment:投递ID"`
+	TenantID       string     `gorm:"column:tenant_id;type:varchar(64);not null;default:'';index:idx_webhook_delivery_tenant_id;comment:租户ID"`
+	CreatedAt      time.Time  `gorm:"column:created_at;type:datetime;default:current_timestamp;not null;index:idx_webhook_delivery_created_at;comment:创建时间"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;type:datetime;default:current_timestamp;on update:current_timestamp;not null;comment:更新时间"`
+	WebhookID      uint       `gorm:"column:webhook_id;type:uint;not null;index:idx_webhook_delivery_webhook_id;comment:WebhookID"`
+	Event          string     `gorm:"column:event;type:varchar(64);not null;comment:事件类型"`
+	Payload        string     `gorm:"column:payload;type:text;not null;comment:请求体"`
+	Status         string     `gorm:"column:status;type:varchar(16);not null;comment:投递状态"`
+	Attempts       int        `gorm:"column:attempts;type:int;not null;default:0;comment:已投递次数"`
+	ResponseStatus int        `gorm:"column:response_status;type:int;not null;default:0;comment:最近一次响应状态码"`
+	ResponseBody   string     `gorm:"column:response_body;type:text;comment:最近一次响应内容（截断）"`
+	LastError      string     `gorm:"column:last_error;type:text;comment:最近一次错误"`
+	DurationMs     int64      `gorm:"column:duration_ms;type:bigint;not null;default:0;comment:最近一次请求耗时"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at;type:datetime;comment:投递成功时间"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return WebhookDeliveryTableName
+}
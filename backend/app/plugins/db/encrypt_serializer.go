@@ -0,0 +1,187 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/schema"
+
+	"backend/utils/secret"
+)
+
+const (
+	// EncryptSerializerName 加密序列化器名称
+	// 使用方式: Field string `gorm:"column:xxx;type:text;serializer:encrypt"`
+	EncryptSerializerName = "encrypt"
+
+	// ColumnEncryptPurpose 字段加密的用途，参与密文认证
+	ColumnEncryptPurpose = "db.column"
+
+	// encryptedValuePrefix 密文前缀，用于区分密文和历史明文数据
+	// 格式: enc:v1:<secret.Keyring 密文>
+	encryptedValuePrefix = "enc:v1:"
+
+	// columnKeyLength 字段加密密钥解码后的长度（AES-256）
+	columnKeyLength = 32
+)
+
+var (
+	// ErrEncryptKeyNotSet 未配置加密密钥
+	ErrEncryptKeyNotSet = errors.New("未配置字段加密密钥")
+
+	// columnKeyring 字段加密使用的密钥环
+	columnKeyring *secret.Keyring
+	// columnCipherMu 保护 columnKeyring 的读写锁
+	columnCipherMu sync.RWMutex
+)
+
+func init() {
+	schema.RegisterSerializer(EncryptSerializerName, EncryptSerializer{})
+}
+
+// SetColumnEncryptKey 设置字段加密密钥
+// value: 密钥配置，格式见 secret.ParseKeys，支持多个密钥以便轮换；
+// 每个密钥必须是 base64 或十六进制编码的 32 字节密钥，否则返回错误
+func SetColumnEncryptKey(value string) error {
+	keyring, err := parseColumnEncryptKey(value)
+	if err != nil {
+		return err
+	}
+
+	columnCipherMu.Lock()
+	defer columnCipherMu.Unlock()
+	columnKeyring = keyring
+	return nil
+}
+
+// parseColumnEncryptKey 解析字段加密密钥配置，返回密钥环
+// 与 secret.ParseKeys 不同，无法解码为 32 字节的密钥直接返回错误，不按原始字符串使用
+func parseColumnEncryptKey(value string) (*secret.Keyring, error) {
+	currentID, keys, err := secret.ParseKeys(value)
+	if err != nil {
+		return nil, fmt.Errorf("解析字段加密密钥失败: %w", err)
+	}
+	for _, entry := range strings.Split(strings.TrimSpace(value), ",") {
+		id, material, found := strings.Cut(strings.TrimSpace(entry), ":")
+		if !found {
+			id, material = secret.DefaultKeyID, id
+		}
+		key, err := decodeColumnKey(material)
+		if err != nil {
+			return nil, fmt.Errorf("解析字段加密密钥 %q 失败: %w", id, err)
+		}
+		keys[id] = key
+	}
+
+	keyring, err := secret.NewKeyring(currentID, keys)
+	if err != nil {
+		return nil, fmt.Errorf("创建字段加密密钥环失败: %w", err)
+	}
+	return keyring, nil
+}
+
+// decodeColumnKey 按 base64 或十六进制解码密钥，解码结果必须为 32 字节
+func decodeColumnKey(value string) ([]byte, error) {
+	if key, err := base64.StdEncoding.DecodeString(value); err == nil && len(key) == columnKeyLength {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(value); err == nil && len(key) == columnKeyLength {
+		return key, nil
+	}
+	return nil, fmt.Errorf("密钥必须是 base64 或十六进制编码的 %d 字节密钥", columnKeyLength)
+}
+
+// getColumnKeyring 获取字段加密使用的密钥环
+func getColumnKeyring() (*secret.Keyring, error) {
+	columnCipherMu.RLock()
+	defer columnCipherMu.RUnlock()
+	if columnKeyring == nil {
+		return nil, ErrEncryptKeyNotSet
+	}
+	return columnKeyring, nil
+}
+
+// EncryptSerializer GORM 字段加密序列化器
+// 写入时使用 secret.Keyring（AES-GCM）加密字符串字段，读取时自动解密；
+// 读取到不带密文前缀的值时视为历史明文数据原样返回，便于平滑迁移
+type EncryptSerializer struct{}
+
+// Scan 实现 schema.SerializerInterface 接口，从数据库读取时解密
+func (EncryptSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+		return nil
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return fmt.Errorf("加密字段 %s 不支持的数据库类型: %T", field.Name, dbValue)
+	}
+
+	plaintext, err := decryptColumnValue(value)
+	if err != nil {
+		return fmt.Errorf("解密字段 %s 失败: %w", field.Name, err)
+	}
+	return field.Set(ctx, dst, plaintext)
+}
+
+// Value 实现 schema.SerializerValuerInterface 接口，写入数据库时加密
+func (EncryptSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	var value string
+	switch v := fieldValue.(type) {
+	case string:
+		value = v
+	case *string:
+		if v == nil {
+			return nil, nil
+		}
+		value = *v
+	default:
+		return nil, fmt.Errorf("加密字段 %s 只支持字符串类型: %T", field.Name, fieldValue)
+	}
+
+	ciphertext, err := encryptColumnValue(value)
+	if err != nil {
+		return nil, fmt.Errorf("加密字段 %s 失败: %w", field.Name, err)
+	}
+	return ciphertext, nil
+}
+
+// encryptColumnValue 加密字段值，空字符串不加密
+func encryptColumnValue(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	keyring, err := getColumnKeyring()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := keyring.EncryptString(plaintext, ColumnEncryptPurpose)
+	if err != nil {
+		return "", err
+	}
+	return encryptedValuePrefix + token, nil
+}
+
+// decryptColumnValue 解密字段值，不带密文前缀的值原样返回
+func decryptColumnValue(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return value, nil
+	}
+
+	keyring, err := getColumnKeyring()
+	if err != nil {
+		return "", err
+	}
+	return keyring.DecryptString(strings.TrimPrefix(value, encryptedValuePrefix), ColumnEncryptPurpose)
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"backend/app/types/meta"
+	"backend/utils/logs"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// explainPluginName 执行计划插件名称
+	explainPluginName = "query_explain"
+	// explainStartKey 记录查询开始时间的实例键
+	explainStartKey = "query_explain:start"
+	// explainTimeout 查询执行计划的超时时间
+	explainTimeout = 2 * time.Second
+)
+
+// ExplainPlugin GORM 执行计划插件
+// 被 base.Explain 标记的查询耗时超过阈值时，执行 EXPLAIN QUERY PLAN 并记录结果
+// 未标记的查询不受影响，避免对所有查询产生额外开销
+type ExplainPlugin struct {
+	threshold time.Duration // 输出执行计划的耗时阈值
+}
+
+// NewExplainPlugin 创建执行计划插件
+func NewExplainPlugin(threshold time.Duration) *ExplainPlugin {
+	return &ExplainPlugin{
+		threshold: threshold,
+	}
+}
+
+// Name 实现 gorm.Plugin 接口
+func (p *ExplainPlugin) Name() string {
+	return explainPluginName
+}
+
+// Initialize 实现 gorm.Plugin 接口，注册查询的前后回调
+// Count 和 Find 都走 Query 回调
+func (p *ExplainPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Query().Before("gorm:query").Register("query_explain:before_query", p.before); err != nil {
+		return err
+	}
+	return cb.Query().After("gorm:query").Register("query_explain:after_query", p.after)
+}
+
+// before 记录被标记查询的开始时间
+func (p *ExplainPlugin) before(db *gorm.DB) {
+	if _, ok := db.Get(meta.GormKeyExplain); !ok {
+		return
+	}
+	db.InstanceSet(explainStartKey, time.Now())
+}
+
+// after 查询超过阈值时输出执行计划
+func (p *ExplainPlugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(explainStartKey)
+	if !ok {
+		return
+	}
+	start, ok := value.(time.Time)
+	if !ok || db.Error != nil {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < p.threshold {
+		return
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sql := db.Statement.SQL.String()
+	plan, err := explainQueryPlan(ctx, db, sql, db.Statement.Vars)
+	if err != nil {
+		logs.CtxWarn(ctx, "获取执行计划失败", "table", db.Statement.Table, "sql", sanitizeSQL(sql), "error", err.Error())
+		return
+	}
+
+	logs.CtxWarn(ctx, "慢查询执行计划",
+		"table", db.Statement.Table,
+		"sql", sanitizeSQL(sql),
+		"duration_ms", float64(elapsed.Microseconds())/1000.0,
+		"plan", plan,
+	)
+}
+
+// explainQueryPlan 执行 EXPLAIN QUERY PLAN 并返回每个步骤的描述
+// 直接使用语句的连接池执行，不触发 GORM 回调；处于事务中时使用同一事务
+func explainQueryPlan(ctx context.Context, db *gorm.DB, sql string, vars []interface{}) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, explainTimeout)
+	defer cancel()
+
+	rows, err := db.Statement.ConnPool.QueryContext(ctx, "EXPLAIN QUERY PLAN "+sql, vars...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// 结果列: id, parent, notused, detail
+	var plan []string
+	depths := make(map[int]int)
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		// 按层级缩进，保留执行计划的树形结构
+		depth := 0
+		if d, ok := depths[parent]; ok {
+			depth = d + 1
+		}
+		depths[id] = depth
+		plan = append(plan, strings.Repeat("  ", depth)+detail)
+	}
+	return plan, rows.Err()
+}
@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/safego"
+
+	"gorm.io/gorm"
+)
+
+// PoolStatsMetricName 连接池统计在 metrics 端点中的名称
+const PoolStatsMetricName = "db_pool"
+
+// PoolStats 数据库连接池统计信息
+type PoolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"` // 最大打开连接数
+	OpenConnections    int   `json:"open_connections"`     // 当前打开连接数
+	InUse              int   `json:"in_use"`               // 使用中的连接数
+	Idle               int   `json:"idle"`                 // 空闲连接数
+	WaitCount          int64 `json:"wait_count"`           // 等待连接的总次数
+	WaitDurationMs     int64 `json:"wait_duration_ms"`     // 等待连接的总耗时（毫秒）
+	MaxIdleClosed      int64 `json:"max_idle_closed"`      // 因超过最大空闲数被关闭的连接数
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"` // 因超过最大空闲时间被关闭的连接数
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`  // 因超过最大生存时间被关闭的连接数
+}
+
+// GetPoolStats 获取数据库连接池统计信息
+func GetPoolStats(db *gorm.DB) (*PoolStats, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := sqlDB.Stats()
+	return &PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}, nil
+}
+
+// registerPoolStatsMetric 将连接池统计注册到 metrics 端点
+func registerPoolStatsMetric(db *gorm.DB) {
+	metrics.Register(PoolStatsMetricName, func() interface{} {
+		stats, err := GetPoolStats(db)
+		if err != nil {
+			return map[string]string{"error": err.Error()}
+		}
+		return stats
+	})
+}
+
+// poolStatsReporter 定期以 debug 级别输出连接池统计信息
+type poolStatsReporter struct {
+	db       *gorm.DB
+	interval time.Duration
+	stop     chan struct{}
+}
+
+// newPoolStatsReporter 创建连接池统计输出器
+func newPoolStatsReporter(db *gorm.DB, interval time.Duration) *poolStatsReporter {
+	return &poolStatsReporter{
+		db:       db,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start 启动定期输出
+func (r *poolStatsReporter) Start() {
+	safego.Go(context.Background(), func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.report()
+			case <-r.stop:
+				return
+			}
+		}
+	})
+}
+
+// Stop 停止定期输出
+func (r *poolStatsReporter) Stop() {
+	close(r.stop)
+}
+
+// report 输出一次连接池统计信息
+func (r *poolStatsReporter) report() {
+	stats, err := GetPoolStats(r.db)
+	if err != nil {
+		logs.Warn("获取连接池统计信息失败", "error", err.Error())
+		return
+	}
+
+	logs.Debug("数据库连接池统计",
+		"max_open", stats.MaxOpenConnections,
+		"open", stats.OpenConnections,
+		"in_use", stats.InUse,
+		"idle", stats.Idle,
+		"wait_count", stats.WaitCount,
+		"wait_duration_ms", stats.WaitDurationMs,
+	)
+}
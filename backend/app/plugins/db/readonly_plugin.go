@@ -0,0 +1,45 @@
+package db
+
+import (
+	"backend/utils/readonly"
+
+	"gorm.io/gorm"
+)
+
+// readOnlyPluginName 只读插件名称
+const readOnlyPluginName = "read_only"
+
+// ReadOnlyPlugin GORM 只读插件
+// 只读模式下拒绝 Create/Update/Delete，返回 readonly.ErrReadOnly；
+// Raw/Exec 不受限制，表结构迁移和 WAL 检查点等维护操作仍可执行
+type ReadOnlyPlugin struct{}
+
+// NewReadOnlyPlugin 创建只读插件
+func NewReadOnlyPlugin() *ReadOnlyPlugin {
+	return &ReadOnlyPlugin{}
+}
+
+// Name 实现 gorm.Plugin 接口
+func (p *ReadOnlyPlugin) Name() string {
+	return readOnlyPluginName
+}
+
+// Initialize 实现 gorm.Plugin 接口，在写操作执行前检查只读开关
+func (p *ReadOnlyPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("read_only:create", p.reject); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("read_only:update", p.reject); err != nil {
+		return err
+	}
+	return cb.Delete().Before("gorm:delete").Register("read_only:delete", p.reject)
+}
+
+// reject 只读模式下中止写操作
+func (p *ReadOnlyPlugin) reject(db *gorm.DB) {
+	if readonly.Enabled() {
+		_ = db.AddError(readonly.ErrReadOnly)
+	}
+}
@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/safego"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// CheckpointModePassive 不阻塞读写的检查点，Litestream 模式下只使用该模式
+	CheckpointModePassive = "PASSIVE"
+	// CheckpointModeTruncate 检查点完成后截断 WAL 文件
+	CheckpointModeTruncate = "TRUNCATE"
+
+	// restoreDBPlaceholder 恢复命令中数据库路径的占位符
+	restoreDBPlaceholder = "{db}"
+	// litestreamBusyTimeoutMs Litestream 模式下的 busy_timeout（毫秒）
+	litestreamBusyTimeoutMs = 5000
+)
+
+// replicationConfig SQLite 复制相关配置
+type replicationConfig struct {
+	litestream         bool          // 是否与 Litestream 协同
+	checkpointInterval time.Duration // WAL 检查点间隔，0 表示不主动检查点
+	restoreCommand     string        // 数据库为空时执行的恢复命令
+}
+
+// loadReplicationConfig 从环境变量读取复制相关配置
+func loadReplicationConfig() (*replicationConfig, error) {
+	checkpointInterval, err := envx.GetIntWithDefaultAndMin(consts.SQLiteWALCheckpointInterval, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &replicationConfig{
+		litestream:         envx.GetBool(consts.SQLiteLitestreamEnabled, false),
+		checkpointInterval: time.Duration(checkpointInterval) * time.Second,
+		restoreCommand:     envx.GetStringOptional(consts.SQLiteRestoreCommand),
+	}, nil
+}
+
+// checkpointMode 返回定期检查点使用的模式
+// Litestream 依赖 WAL 文件持续增长来读取变更，因此只能使用 PASSIVE，避免截断 WAL
+func (c *replicationConfig) checkpointMode() string {
+	if c.litestream {
+		return CheckpointModePassive
+	}
+	return CheckpointModeTruncate
+}
+
+// restoreIfEmpty 数据库文件不存在或为空时执行恢复命令
+// 命令通过 sh -c 执行，{db} 会被替换为数据库文件路径，例如:
+//
+//	litestream restore -if-replica-exists -o {db} s3://bucket/data.db
+func restoreIfEmpty(ctx context.Context, dbPath string, command string) error {
+	if command == "" {
+		return nil
+	}
+
+	info, err := os.Stat(dbPath)
+	if err == nil && info.Size() > 0 {
+		return nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("检查数据库文件失败: %w", err)
+	}
+
+	command = strings.ReplaceAll(command, restoreDBPlaceholder, dbPath)
+	logs.Info("数据库为空，开始执行恢复命令", "path", dbPath, "command", command)
+
+	start := time.Now()
+	output, err := exec.CommandContext(ctx, "sh", "-c", command).CombinedOutput()
+	if err != nil {
+		logs.Error("数据库恢复失败", "path", dbPath, "error", err.Error(), "output", strings.TrimSpace(string(output)))
+		return fmt.Errorf("数据库恢复失败: %w", err)
+	}
+
+	if info, err := os.Stat(dbPath); err == nil && info.Size() > 0 {
+		logs.Info("数据库恢复完成", "path", dbPath, "size", info.Size(), "duration_ms", time.Since(start).Milliseconds())
+	} else {
+		logs.Info("没有可用的副本，使用空数据库启动", "path", dbPath)
+	}
+	return nil
+}
+
+// Checkpoint 执行一次 WAL 检查点
+// mode: PASSIVE, FULL, RESTART, TRUNCATE
+func Checkpoint(ctx context.Context, db *gorm.DB, mode string) error {
+	var result struct {
+		Busy         int `gorm:"column:busy"`
+		Log          int `gorm:"column:log"`
+		Checkpointed int `gorm:"column:checkpointed"`
+	}
+	sql := fmt.Sprintf("PRAGMA wal_checkpoint(%s)", mode)
+	if err := db.WithContext(ctx).Raw(sql).Scan(&result).Error; err != nil {
+		return err
+	}
+
+	logs.Debug("WAL 检查点完成", "mode", mode, "busy", result.Busy, "log_frames", result.Log, "checkpointed_frames", result.Checkpointed)
+	return nil
+}
+
+// walCheckpointer 定期执行 WAL 检查点
+type walCheckpointer struct {
+	db       *gorm.DB
+	interval time.Duration
+	mode     string
+	stop     chan struct{}
+}
+
+// newWALCheckpointer 创建 WAL 检查点执行器
+func newWALCheckpointer(db *gorm.DB, interval time.Duration, mode string) *walCheckpointer {
+	return &walCheckpointer{
+		db:       db,
+		interval: interval,
+		mode:     mode,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start 启动定期检查点
+func (c *walCheckpointer) Start() {
+	safego.Go(context.Background(), func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := Checkpoint(context.Background(), c.db, c.mode); err != nil {
+					logs.Warn("WAL 检查点失败", "mode", c.mode, "error", err.Error())
+				}
+			case <-c.stop:
+				return
+			}
+		}
+	})
+}
+
+// Stop 停止定期检查点
+func (c *walCheckpointer) Stop() {
+	close(c.stop)
+}
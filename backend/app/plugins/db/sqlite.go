@@ -2,16 +2,27 @@ package db
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"time"
 
-	"backend/app/types/consts"
+	"backend/app/plugins/startup"
 	"backend/pkg/sqlite"
 	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/health"
+	"backend/utils/lofile"
 	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/readonly"
 
 	"go.uber.org/fx"
 	"gorm.io/gorm"
 )
 
+// HealthCheckName 数据库在就绪检查中的名称
+const HealthCheckName = "database"
+
 // ProvideDatabaseParams 定义 Database 的依赖
 type ProvideDatabaseParams struct {
 	fx.In
@@ -19,68 +30,195 @@ type ProvideDatabaseParams struct {
 	Lifecycle fx.Lifecycle
 }
 
+// databaseEnv 数据库环境变量配置（变量说明见 consts）
+type databaseEnv struct {
+	DBPath             string `env:"SQLITE_DB_PATH" default:"data.db"`
+	MaxIdleConns       int    `env:"SQLITE_MAX_IDLE_CONNS" default:"10"`
+	MaxOpenConns       int    `env:"SQLITE_MAX_OPEN_CONNS" default:"100"`
+	ConnMaxLifetimeMin int    `env:"SQLITE_CONN_MAX_LIFETIME_MIN" default:"60"`
+	ConnMaxIdleTimeMin int    `env:"SQLITE_CONN_MAX_IDLE_TIME_MIN" default:"10"`
+	EnableSlowQueryLog bool   `env:"SQLITE_ENABLE_SLOW_QUERY_LOG" default:"false"`
+	SlowQueryThreshold int    `env:"SQLITE_SLOW_QUERY_THRESHOLD" default:"200"`
+	EnableQueryTrace   bool   `env:"SQLITE_ENABLE_QUERY_TRACE" default:"false"`
+	EnableExplain      bool   `env:"SQLITE_EXPLAIN_ENABLED" default:"false"`
+	ExplainThreshold   int    `env:"SQLITE_EXPLAIN_THRESHOLD" default:"100" min:"0"`
+	PoolStatsInterval  int    `env:"SQLITE_POOL_STATS_INTERVAL" default:"60" min:"0"`
+	ReadOnly           bool   `env:"READ_ONLY" default:"false"`
+	ReadOnlyReason     string `env:"READ_ONLY_REASON" default:"maintenance"`
+	TenantEnabled      bool   `env:"MULTI_TENANT_ENABLED" default:"false"`
+	ColumnEncryptKey   string `env:"COLUMN_ENCRYPT_KEY"`
+}
+
+// CheckConfig 校验数据库配置（数值、复制配置、字段加密密钥和数据库目录是否可写），不连接数据库
+// 所有问题聚合后一起返回，启动前调用以便一次性发现
+func CheckConfig() error {
+	var agg errorx.Aggregate
+	var env databaseEnv
+	agg.Add(envx.Bind(&env))
+	if _, err := loadReplicationConfig(); err != nil {
+		agg.Add(err)
+	}
+	if env.ColumnEncryptKey != "" {
+		if _, err := parseColumnEncryptKey(env.ColumnEncryptKey); err != nil {
+			agg.Add(fmt.Errorf("环境变量 COLUMN_ENCRYPT_KEY 无效: %w", err))
+		}
+	}
+	if env.DBPath != "" {
+		if err := lofile.CheckWritable(filepath.Dir(env.DBPath)); err != nil {
+			agg.Add(fmt.Errorf("环境变量 SQLITE_DB_PATH 配置错误: %w", err))
+		}
+	}
+	return agg.Err()
+}
+
 // ProvideDatabase 提供数据库实例
 func ProvideDatabase(params ProvideDatabaseParams) (*gorm.DB, error) {
-	// 读取数据库文件路径（可选，带默认值）
-	dbPath := envx.GetStringOptional(consts.SQLiteDBPath)
-	if dbPath == "" {
-		dbPath = "data.db"
-		logs.Info("使用默认数据库路径", "path", dbPath)
+	var env databaseEnv
+	if err := envx.Bind(&env); err != nil {
+		return nil, err
 	}
+	dbPath := env.DBPath
 
-	// 读取最大空闲连接数（可选，带默认值）
-	maxIdleConns, err := envx.GetIntWithDefault(consts.SQLiteMaxIdleConns, 10)
+	// 读取复制相关配置（可选）
+	replication, err := loadReplicationConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	// 读取最大打开连接数（可选，带默认值）
-	maxOpenConns, err := envx.GetIntWithDefault(consts.SQLiteMaxOpenConns, 100)
+	// 读取字段加密密钥（可选）
+	if env.ColumnEncryptKey != "" {
+		if err := SetColumnEncryptKey(env.ColumnEncryptKey); err != nil {
+			return nil, err
+		}
+		logs.Info("字段加密已启用")
+	}
+
+	// 构建 SQLite 配置
+	config := &sqlite.SQLiteConfig{
+		DBPath:             dbPath,
+		MaxIdleConns:       env.MaxIdleConns,
+		MaxOpenConns:       env.MaxOpenConns,
+		ConnMaxLifetimeMin: env.ConnMaxLifetimeMin,
+		ConnMaxIdleTimeMin: env.ConnMaxIdleTimeMin,
+		EnableSlowQueryLog: env.EnableSlowQueryLog,
+		SlowQueryThreshold: env.SlowQueryThreshold,
+	}
+
+	// Litestream 要求数据库使用 WAL 模式，并在检查点与复制竞争时等待而不是报错
+	if replication.litestream {
+		config.WALMode = true
+		config.BusyTimeoutMs = litestreamBusyTimeoutMs
+		logs.Info("Litestream 协同已启用", "path", dbPath)
+	}
+
+	// 创建数据库连接（失败时按退避策略重试）
+	startupTimeout, err := startup.Timeout()
 	if err != nil {
 		return nil, err
 	}
+	connectCtx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
 
-	// 读取连接最大生存时间（可选，带默认值）
-	connMaxLifetimeMin, err := envx.GetIntWithDefault(consts.SQLiteConnMaxLifetimeMin, 60)
-	if err != nil {
+	// 数据库为空时从副本恢复
+	if err := restoreIfEmpty(connectCtx, dbPath, replication.restoreCommand); err != nil {
 		return nil, err
 	}
 
-	// 读取连接最大空闲时间（可选，带默认值）
-	connMaxIdleTimeMin, err := envx.GetIntWithDefault(consts.SQLiteConnMaxIdleTimeMin, 10)
+	var db *gorm.DB
+	err = startup.Connect(connectCtx, "database", func(ctx context.Context) error {
+		var connectErr error
+		db, connectErr = sqlite.NewSQLite(config)
+		return connectErr
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 读取是否启用慢查询日志（可选，带默认值）
-	enableSlowQueryLog := envx.GetBool(consts.SQLiteEnableSlowQueryLog, false)
+	// 注册查询追踪插件
+	if env.EnableQueryTrace {
+		var traceSlowThreshold time.Duration
+		if env.EnableSlowQueryLog {
+			traceSlowThreshold = time.Duration(env.SlowQueryThreshold) * time.Millisecond
+		}
+		if err := db.Use(NewQueryTracePlugin(traceSlowThreshold)); err != nil {
+			return nil, err
+		}
+		logs.Info("查询追踪已启用")
+	}
 
-	// 读取慢查询阈值（可选，带默认值）
-	slowQueryThreshold, err := envx.GetIntWithDefault(consts.SQLiteSlowQueryThreshold, 200)
-	if err != nil {
-		return nil, err
+	// 注册执行计划插件
+	if env.EnableExplain {
+		if err := db.Use(NewExplainPlugin(time.Duration(env.ExplainThreshold) * time.Millisecond)); err != nil {
+			return nil, err
+		}
+		logs.Info("慢查询执行计划已启用", "threshold_ms", env.ExplainThreshold)
 	}
 
-	// 构建 SQLite 配置
-	config := &sqlite.SQLiteConfig{
-		DBPath:             dbPath,
-		MaxIdleConns:       maxIdleConns,
-		MaxOpenConns:       maxOpenConns,
-		ConnMaxLifetimeMin: connMaxLifetimeMin,
-		ConnMaxIdleTimeMin: connMaxIdleTimeMin,
-		EnableSlowQueryLog: enableSlowQueryLog,
-		SlowQueryThreshold: slowQueryThreshold,
+	// 注册只读插件（只读开关可在运行时切换，因此始终注册）
+	if err := db.Use(NewReadOnlyPlugin()); err != nil {
+		return nil, err
+	}
+	if env.ReadOnly {
+		readonly.Enable(env.ReadOnlyReason)
+		logs.Warn("服务以只读模式运行", "reason", env.ReadOnlyReason)
 	}
 
-	// 创建数据库连接
-	db, err := sqlite.NewSQLite(config)
-	if err != nil {
+	// 注册操作人填充插件
+	if err := db.Use(NewStampPlugin()); err != nil {
 		return nil, err
 	}
 
-	// 注册生命周期钩子，在应用关闭时关闭数据库连接
+	// 启用多租户时注册租户隔离插件
+	if env.TenantEnabled {
+		if err := db.Use(NewTenantPlugin()); err != nil {
+			return nil, err
+		}
+	}
+
+	// 注册连接池统计指标
+	registerPoolStatsMetric(db)
+
+	// 注册就绪检查
+	health.Register(HealthCheckName, func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+
+	// 注册生命周期钩子，在应用启动时开始输出连接池统计，在应用关闭时关闭数据库连接
+	var reporter *poolStatsReporter
+	var checkpointer *walCheckpointer
 	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if env.PoolStatsInterval > 0 {
+				reporter = newPoolStatsReporter(db, time.Duration(env.PoolStatsInterval)*time.Second)
+				reporter.Start()
+			}
+			if replication.checkpointInterval > 0 {
+				checkpointer = newWALCheckpointer(db, replication.checkpointInterval, replication.checkpointMode())
+				checkpointer.Start()
+			}
+			return nil
+		},
 		OnStop: func(ctx context.Context) error {
+			if reporter != nil {
+				reporter.Stop()
+			}
+			if checkpointer != nil {
+				checkpointer.Stop()
+			}
+
+			// 关闭前执行一次检查点，减少遗留在 WAL 中的数据
+			if replication.litestream || replication.checkpointInterval > 0 {
+				if err := Checkpoint(ctx, db, replication.checkpointMode()); err != nil {
+					logs.Warn("关闭前 WAL 检查点失败", "error", err.Error())
+				}
+			}
+			metrics.Unregister(PoolStatsMetricName)
+			health.Unregister(HealthCheckName)
+
 			logs.Info("正在关闭数据库连接", "path", dbPath)
 			sqlDB, err := db.DB()
 			if err != nil {
@@ -0,0 +1,87 @@
+package db
+
+import (
+	"context"
+
+	"backend/app/types/meta"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// stampPluginName 操作人填充插件名称
+	stampPluginName = "operator_stamp"
+	// createdByColumn 创建人列名
+	createdByColumn = "created_by"
+	// updatedByColumn 更新人列名
+	updatedByColumn = "updated_by"
+)
+
+// StampPlugin GORM 操作人填充插件
+// 从 context 中读取当前用户ID：
+//   - 创建记录时填充 created_by 和 updated_by
+//   - 更新记录时填充 updated_by
+//
+// context 中没有用户ID（如未登录接口、启动时的数据初始化）或模型没有对应列时不做处理
+type StampPlugin struct{}
+
+// NewStampPlugin 创建操作人填充插件
+func NewStampPlugin() *StampPlugin {
+	return &StampPlugin{}
+}
+
+// Name 实现 gorm.Plugin 接口
+func (p *StampPlugin) Name() string {
+	return stampPluginName
+}
+
+// Initialize 实现 gorm.Plugin 接口，注册创建和更新回调
+func (p *StampPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("stamp:create", p.stampCreate); err != nil {
+		return err
+	}
+	return cb.Update().Before("gorm:update").Register("stamp:update", p.stampUpdate)
+}
+
+// stampCreate 创建记录时填充 created_by 和 updated_by
+func (p *StampPlugin) stampCreate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	userID, ok := operatorFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+
+	for _, column := range []string{createdByColumn, updatedByColumn} {
+		if _, exists := db.Statement.Schema.FieldsByDBName[column]; exists {
+			db.Statement.SetColumn(column, userID, true)
+		}
+	}
+}
+
+// stampUpdate 更新记录时填充 updated_by
+func (p *StampPlugin) stampUpdate(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	userID, ok := operatorFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+
+	if _, exists := db.Statement.Schema.FieldsByDBName[updatedByColumn]; exists {
+		db.Statement.SetColumn(updatedByColumn, userID, true)
+	}
+}
+
+// operatorFromContext 从 context 中获取当前用户ID
+func operatorFromContext(ctx context.Context) (uint, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	return userID, ok && userID != 0
+}
@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"backend/app/types/meta"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// tenantPluginName 多租户插件名称
+	tenantPluginName = "tenant"
+	// tenantColumn 租户列名
+	tenantColumn = "tenant_id"
+	// tenantField 租户字段名
+	tenantField = "TenantID"
+)
+
+// ErrRawSQLWithTenant context 中存在租户ID时执行了没有标记为与租户无关的原始 SQL
+var ErrRawSQLWithTenant = errors.New("多租户模式下不能执行原始 SQL，无法按租户隔离")
+
+// TenantPlugin GORM 多租户插件
+// 当 context 中存在租户ID时：
+//   - 创建记录时自动填充 tenant_id
+//   - 查询、更新、删除时自动追加 tenant_id 条件
+//   - 拒绝执行原始 SQL（Raw、Exec），返回 ErrRawSQLWithTenant；与租户无关的 SQL（如查询数据库版本、备份）
+//     需要设置 meta.GormKeyCrossTenant 显式标记
+//
+// context 中没有租户ID（如启动时的数据初始化、定时任务）或模型没有 tenant_id 列时不做处理
+type TenantPlugin struct{}
+
+// NewTenantPlugin 创建多租户插件
+func NewTenantPlugin() *TenantPlugin {
+	return &TenantPlugin{}
+}
+
+// Name 实现 gorm.Plugin 接口
+func (p *TenantPlugin) Name() string {
+	return tenantPluginName
+}
+
+// Initialize 实现 gorm.Plugin 接口，注册租户填充和过滤回调
+func (p *TenantPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("tenant:fill_create", p.fillTenant); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("tenant:scope_query", p.scopeTenant); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("tenant:scope_update", p.scopeTenant); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("tenant:scope_delete", p.scopeTenant); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("tenant:scope_row", p.scopeTenant); err != nil {
+		return err
+	}
+	return cb.Raw().Before("gorm:raw").Register("tenant:reject_raw", p.rejectRaw)
+}
+
+// fillTenant 创建记录时填充 tenant_id
+func (p *TenantPlugin) fillTenant(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	tenantID, ok := tenantFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+	field := db.Statement.Schema.LookUpField(tenantField)
+	if field == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(rv.Index(i)), tenantID); err != nil {
+				db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, rv, tenantID); err != nil {
+			db.AddError(err)
+		}
+	}
+}
+
+// scopeTenant 查询、更新、删除时追加 tenant_id 条件
+// Raw 构造的查询（Raw().Scan、Raw().Row 等）已经生成 SQL，无法追加条件，按原始 SQL 处理
+func (p *TenantPlugin) scopeTenant(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if db.Statement.SQL.Len() > 0 {
+		p.rejectRaw(db)
+		return
+	}
+	if db.Statement.Schema == nil {
+		return
+	}
+	tenantID, ok := tenantFromContext(db.Statement.Context)
+	if !ok {
+		return
+	}
+	if _, exists := db.Statement.Schema.FieldsByDBName[tenantColumn]; !exists {
+		return
+	}
+
+	// 使用当前表限定列名，避免 JOIN 时列名冲突
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: tenantColumn}, Value: tenantID},
+	}})
+}
+
+// rejectRaw context 中存在租户ID时拒绝执行原始 SQL，标记为与租户无关的除外
+func (p *TenantPlugin) rejectRaw(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+	if _, ok := tenantFromContext(db.Statement.Context); !ok {
+		return
+	}
+	if _, ok := db.Get(meta.GormKeyCrossTenant); ok {
+		return
+	}
+	_ = db.AddError(ErrRawSQLWithTenant)
+}
+
+// tenantFromContext 从 context 中获取租户ID
+func tenantFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	tenantID, ok := ctx.Value(meta.ContextKeyTenantID).(string)
+	return tenantID, ok
+}
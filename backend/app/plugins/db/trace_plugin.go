@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/trace"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// queryTracePluginName 查询追踪插件名称
+	queryTracePluginName = "query_trace"
+	// queryTraceStartKey 记录查询开始时间的实例键
+	queryTraceStartKey = "query_trace:start"
+	// maxTracedSQLLength 记录的 SQL 最大长度，超出部分截断
+	maxTracedSQLLength = 1024
+)
+
+// QueryTracePlugin GORM 查询追踪插件
+// 记录每条 SQL（仅包含占位符，不包含参数值）、耗时和影响行数，
+// 并为每次查询生成独立的 span，挂在请求的 trace_id 下
+type QueryTracePlugin struct {
+	slowThreshold time.Duration // 慢查询阈值，<= 0 表示不标记慢查询
+}
+
+// NewQueryTracePlugin 创建查询追踪插件
+// slowThreshold: 慢查询阈值，超过阈值的查询会带上 slow 标记
+func NewQueryTracePlugin(slowThreshold time.Duration) *QueryTracePlugin {
+	return &QueryTracePlugin{
+		slowThreshold: slowThreshold,
+	}
+}
+
+// Name 实现 gorm.Plugin 接口
+func (p *QueryTracePlugin) Name() string {
+	return queryTracePluginName
+}
+
+// Initialize 实现 gorm.Plugin 接口，注册各类操作的前后回调
+func (p *QueryTracePlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+
+	if err := cb.Create().Before("gorm:create").Register("query_trace:before_create", p.before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register("query_trace:after_create", p.after("create")); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register("query_trace:before_query", p.before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register("query_trace:after_query", p.after("query")); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("query_trace:before_update", p.before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("query_trace:after_update", p.after("update")); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("query_trace:before_delete", p.before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register("query_trace:after_delete", p.after("delete")); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register("query_trace:before_row", p.before); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register("query_trace:after_row", p.after("row")); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register("query_trace:before_raw", p.before); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register("query_trace:after_raw", p.after("raw"))
+}
+
+// before 记录查询开始时间
+func (p *QueryTracePlugin) before(db *gorm.DB) {
+	db.InstanceSet(queryTraceStartKey, time.Now())
+}
+
+// after 计算耗时并记录查询信息
+func (p *QueryTracePlugin) after(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(queryTraceStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		elapsed := time.Since(start)
+
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		// 每次查询作为请求 span 的子 span
+		ctx = trace.InjectSpan(ctx)
+
+		keyvals := []interface{}{
+			"operation", operation,
+			"table", db.Statement.Table,
+			"sql", sanitizeSQL(db.Statement.SQL.String()),
+			"duration_ms", float64(elapsed.Microseconds()) / 1000.0,
+			"rows", db.Statement.RowsAffected,
+		}
+
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			keyvals = append(keyvals, "error", db.Error.Error())
+			logs.CtxWarn(ctx, "SQL 执行失败", keyvals...)
+			return
+		}
+
+		// 慢查询告警由 GORM 日志适配器统一输出，这里只做标记，避免重复记录
+		if p.slowThreshold > 0 {
+			keyvals = append(keyvals, "slow", elapsed > p.slowThreshold)
+		}
+
+		logs.CtxDebug(ctx, "SQL 执行", keyvals...)
+	}
+}
+
+// sanitizeSQL 规范化 SQL 文本
+// Statement.SQL 中参数均为占位符，不包含实际值；这里压缩空白并截断过长的语句
+func sanitizeSQL(sql string) string {
+	sql = strings.Join(strings.Fields(sql), " ")
+	if len(sql) > maxTracedSQLLength {
+		sql = sql[:maxTracedSQLLength] + "..."
+	}
+	return sql
+}
@@ -0,0 +1,123 @@
+package es
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/app/plugins/startup"
+	esPkg "backend/pkg/es"
+	"backend/utils/envx"
+	"backend/utils/health"
+	"backend/utils/logs"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"go.uber.org/fx"
+)
+
+// HealthCheckName ElasticSearch 在就绪检查中的名称
+const HealthCheckName = "elasticsearch"
+
+// ProvideElasticSearchParams 定义 ElasticSearch 的依赖
+type ProvideElasticSearchParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+}
+
+// elasticSearchEnv ElasticSearch 环境变量配置（变量说明见 consts）
+type elasticSearchEnv struct {
+	Addresses            []string      `env:"ES_ADDRESSES"`
+	Username             string        `env:"ES_USERNAME"`
+	Password             string        `env:"ES_PASSWORD"`
+	CloudID              string        `env:"ES_CLOUD_ID"`
+	APIKey               string        `env:"ES_API_KEY"`
+	MaxRetries           int           `env:"ES_MAX_RETRIES" default:"3" min:"0"`
+	EnableRetryOnTimeout bool          `env:"ES_ENABLE_RETRY_ON_TIMEOUT" default:"false"`
+	EnableCompression    bool          `env:"ES_ENABLE_COMPRESSION" default:"false"`
+	RequestTimeout       time.Duration `env:"ES_REQUEST_TIMEOUT" default:"10s"`
+	PingTimeout          time.Duration `env:"ES_PING_TIMEOUT" default:"5s"`
+}
+
+// ProvideElasticSearch 提供 ElasticSearch 客户端实例
+// 未配置 ES_ADDRESSES 时返回 nil，依赖方需要自行判断是否启用 ElasticSearch
+func ProvideElasticSearch(params ProvideElasticSearchParams) (*elasticsearch.Client, error) {
+	var env elasticSearchEnv
+	if err := envx.Bind(&env); err != nil {
+		return nil, err
+	}
+
+	// 未配置服务器地址时不启用
+	if len(env.Addresses) == 0 {
+		logs.Info("未配置 ElasticSearch，跳过初始化")
+		return nil, nil
+	}
+
+	// 构建 ElasticSearch 配置
+	config := &esPkg.ElasticSearchConfig{
+		Addresses:            env.Addresses,
+		Username:             env.Username,
+		Password:             env.Password,
+		CloudID:              env.CloudID,
+		APIKey:               env.APIKey,
+		MaxRetries:           env.MaxRetries,
+		EnableRetryOnTimeout: env.EnableRetryOnTimeout,
+		EnableCompression:    env.EnableCompression,
+		RequestTimeout:       env.RequestTimeout,
+		PingTimeout:          env.PingTimeout,
+	}
+
+	// 创建客户端（会检查连接，失败时按退避策略重试）
+	startupTimeout, err := startup.Timeout()
+	if err != nil {
+		return nil, err
+	}
+	connectCtx, cancel := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancel()
+
+	var client *elasticsearch.Client
+	err = startup.Connect(connectCtx, HealthCheckName, func(ctx context.Context) error {
+		var connectErr error
+		client, connectErr = esPkg.NewElasticSearch(config)
+		return connectErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 注册就绪检查
+	health.Register(HealthCheckName, func(ctx context.Context) error {
+		return ping(ctx, client)
+	})
+
+	// 注册生命周期钩子，在应用关闭时注销就绪检查并关闭客户端
+	params.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			health.Unregister(HealthCheckName)
+
+			logs.Info("正在关闭 ElasticSearch 客户端")
+			if err := client.Close(ctx); err != nil {
+				logs.Error("ElasticSearch 客户端关闭失败", "error", err.Error())
+				return err
+			}
+			logs.Info("ElasticSearch 客户端已关闭")
+			return nil
+		},
+	})
+
+	return client, nil
+}
+
+// ping 检查 ElasticSearch 是否可用
+func ping(ctx context.Context, client *elasticsearch.Client) error {
+	res, err := client.Ping(client.Ping.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("ElasticSearch 状态异常: %s", res.Status())
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package jobqueue
+
+import (
+	"context"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/jobqueue"
+	"backend/utils/logs"
+	"backend/utils/retry"
+
+	"go.uber.org/fx"
+)
+
+// ProvideQueueParams 定义任务队列的依赖
+type ProvideQueueParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Store     jobqueue.Store
+}
+
+// ProvideQueue 提供后台任务队列
+// 各模块通过 Handle 注册任务类型；未启用时只能入队，任务由启用了工作协程的实例执行
+func ProvideQueue(params ProvideQueueParams) (*jobqueue.Queue, error) {
+	enabled := envx.GetBool(consts.JobQueueEnabled, true)
+
+	workers, err := envx.GetIntWithDefaultAndMin(consts.JobQueueWorkers, 4, 1)
+	if err != nil {
+		return nil, err
+	}
+	maxAttempts, err := envx.GetIntWithDefaultAndMin(consts.JobQueueMaxAttempts, 5, 1)
+	if err != nil {
+		return nil, err
+	}
+	pollInterval, err := envx.GetDurationWithDefault(consts.JobQueuePollInterval, time.Second)
+	if err != nil {
+		return nil, err
+	}
+	lease, err := envx.GetDurationWithDefault(consts.JobQueueLease, 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	retryInterval, err := envx.GetDurationWithDefault(consts.JobQueueRetryInterval, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	q := jobqueue.New(params.Store,
+		jobqueue.WithWorkers(workers),
+		jobqueue.WithMaxAttempts(maxAttempts),
+		jobqueue.WithPollInterval(pollInterval),
+		jobqueue.WithLease(lease),
+		jobqueue.WithBackoff(retry.Backoff{
+			InitialInterval: retryInterval,
+			MaxInterval:     time.Hour,
+			Multiplier:      2,
+		}),
+		jobqueue.WithContext(withTenant),
+	)
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if !enabled {
+				logs.Info("后台任务队列未启用工作协程，任务只入队不执行")
+				return nil
+			}
+			return q.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			return q.Stop(ctx)
+		},
+	})
+
+	return q, nil
+}
+
+// withTenant 恢复入队时的租户，任务中的数据库读写按该租户隔离
+func withTenant(ctx context.Context, job *jobqueue.Job) context.Context {
+	if job.TenantID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, meta.ContextKeyTenantID, job.TenantID)
+}
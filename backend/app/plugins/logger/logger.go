@@ -0,0 +1,21 @@
+package logger
+
+import (
+	"context"
+
+	"backend/utils/logs"
+
+	"go.uber.org/fx"
+)
+
+// Register 服务停止时写入缓冲的日志（异步写入的队列、推送到 Loki 的队列）
+// 需要最先注册，OnStop 按注册的相反顺序执行，其他组件停止时记录的日志也能写入
+func Register(lc fx.Lifecycle) {
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			// stdout 为终端时 Sync 会返回错误，不影响日志写入
+			_ = logs.Sync()
+			return nil
+		},
+	})
+}
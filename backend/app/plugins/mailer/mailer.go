@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/mailer"
+)
+
+// ProvideMailer 提供邮件发送器
+// 未配置 SMTP_HOST 时邮件只记录日志不发送，便于本地开发
+func ProvideMailer() (mailer.Sender, error) {
+	host := envx.GetStringOptional(consts.SMTPHost)
+	if host == "" {
+		logs.Warn("未配置 SMTP_HOST，邮件只记录日志不发送")
+		return logSender{}, nil
+	}
+
+	port, err := envx.GetIntWithDefaultAndMin(consts.SMTPPort, 587, 1)
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := envx.GetDurationWithDefault(consts.SMTPTimeout, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	tlsMode := mailer.TLSMode(strings.ToLower(envx.GetStringOptional(consts.SMTPTLS)))
+
+	sender, err := mailer.NewSMTPSender(mailer.SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: envx.GetStringOptional(consts.SMTPUsername),
+		Password: envx.GetStringOptional(consts.SMTPPassword),
+		From:     envx.GetStringOptional(consts.SMTPFrom),
+		TLS:      tlsMode,
+		Timeout:  timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logs.Info("邮件发送已启用", "host", host, "port", port, "tls", string(tlsMode))
+	return sender, nil
+}
+
+// logSender 只记录日志的发送器
+type logSender struct{}
+
+func (logSender) Send(ctx context.Context, msg *mailer.Message) error {
+	logs.CtxInfof(ctx, "未配置 SMTP，跳过发送邮件: to=%s, subject=%s", strings.Join(msg.To, ","), msg.Subject)
+	return nil
+}
@@ -1,7 +1,24 @@
 package plugins
 
 import (
+	changeLogic "backend/app/internal/logic/change"
+	exportLogic "backend/app/internal/logic/export"
+	importerLogic "backend/app/internal/logic/importer"
+	notifyLogic "backend/app/internal/logic/notify"
+	systemLogic "backend/app/internal/logic/system"
+	webhookLogic "backend/app/internal/logic/webhook"
 	"backend/app/plugins/db"
+	"backend/app/plugins/es"
+	"backend/app/plugins/jobqueue"
+	"backend/app/plugins/logger"
+	"backend/app/plugins/mailer"
+	"backend/app/plugins/redis"
+	"backend/app/plugins/scheduler"
+	"backend/app/plugins/sentry"
+	"backend/app/plugins/sse"
+	"backend/app/plugins/telemetry"
+	httpServer "backend/app/server/http"
+	"backend/app/server/ws"
 
 	"go.uber.org/fx"
 )
@@ -11,5 +28,46 @@ var PluginsModule = fx.Module("plugins",
 	fx.Provide(
 		// Database
 		db.ProvideDatabase,
+		// Redis
+		redis.ProvideRedis,
+		// ElasticSearch
+		es.ProvideElasticSearch,
+		// Scheduler
+		fx.Annotate(
+			scheduler.ProvideScheduler,
+			fx.As(fx.Self()),
+			fx.As(new(systemLogic.JobScheduler)),
+		),
+		// Job Queue
+		fx.Annotate(
+			jobqueue.ProvideQueue,
+			fx.As(fx.Self()),
+			fx.As(new(systemLogic.JobQueue)),
+			fx.As(new(webhookLogic.JobQueue)),
+			fx.As(new(notifyLogic.JobQueue)),
+			fx.As(new(importerLogic.JobQueue)),
+		),
+		// Mailer
+		fx.Annotate(
+			mailer.ProvideMailer,
+			fx.As(new(notifyLogic.Mailer)),
+		),
+		// SSE Manager
+		fx.Annotate(
+			sse.ProvideSSEManager,
+			fx.As(fx.Self()),
+			fx.As(new(changeLogic.SSEManager)),
+			fx.As(new(exportLogic.SSEManager)),
+			fx.As(new(importerLogic.SSEManager)),
+			fx.As(new(systemLogic.SSEManager)),
+			fx.As(new(httpServer.StreamCloser)),
+			fx.As(new(ws.TaskSubscriber)),
+		),
 	),
+	// 日志，服务停止时最后写入缓冲的日志
+	fx.Invoke(logger.Register),
+	// OpenTelemetry（配置 OTEL_EXPORTER_OTLP_ENDPOINT 时），在服务器启动前接入
+	fx.Invoke(telemetry.Register),
+	// Sentry（配置 SENTRY_DSN 时），上报 error 及以上级别的日志
+	fx.Invoke(sentry.Register),
 )
@@ -0,0 +1,116 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/app/plugins/startup"
+	redisPkg "backend/pkg/redis"
+	"backend/utils/envx"
+	"backend/utils/health"
+	"backend/utils/logs"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+const (
+	// HealthCheckName Redis 在就绪检查中的名称
+	HealthCheckName = "redis"
+	// pingTimeout 启动时检查 Redis 连接的超时时间
+	pingTimeout = 5 * time.Second
+)
+
+// ProvideRedisParams 定义 Redis 的依赖
+type ProvideRedisParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+}
+
+// redisEnv Redis 环境变量配置（变量说明见 consts）
+type redisEnv struct {
+	Host         string        `env:"REDIS_HOST"`
+	Port         string        `env:"REDIS_PORT" default:"6379"`
+	Password     string        `env:"REDIS_PASSWORD"`
+	DB           int           `env:"REDIS_DB" default:"0" min:"0"`
+	PoolSize     int           `env:"REDIS_POOL_SIZE" default:"10" min:"1"`
+	MinIdleConns int           `env:"REDIS_MIN_IDLE_CONNS" default:"0" min:"0"`
+	MaxIdleConns int           `env:"REDIS_MAX_IDLE_CONNS" default:"0" min:"0"`
+	MaxRetries   int           `env:"REDIS_MAX_RETRIES" default:"3" min:"0"`
+	DialTimeout  time.Duration `env:"REDIS_DIAL_TIMEOUT" default:"5s"`
+	ReadTimeout  time.Duration `env:"REDIS_READ_TIMEOUT" default:"3s"`
+	WriteTimeout time.Duration `env:"REDIS_WRITE_TIMEOUT" default:"3s"`
+	PoolTimeout  time.Duration `env:"REDIS_POOL_TIMEOUT" default:"4s"`
+}
+
+// ProvideRedis 提供 Redis 客户端实例
+// 未配置 REDIS_HOST 时返回 nil，依赖方需要自行判断是否启用 Redis
+func ProvideRedis(params ProvideRedisParams) (*goredis.Client, error) {
+	var env redisEnv
+	if err := envx.Bind(&env); err != nil {
+		return nil, err
+	}
+
+	// 未配置主机地址时不启用
+	if env.Host == "" {
+		logs.Info("未配置 Redis，跳过初始化")
+		return nil, nil
+	}
+
+	// 构建 Redis 配置
+	config := &redisPkg.RedisConfig{
+		Host:         env.Host,
+		Port:         env.Port,
+		Password:     env.Password,
+		DB:           env.DB,
+		PoolSize:     env.PoolSize,
+		MinIdleConns: env.MinIdleConns,
+		MaxIdleConns: env.MaxIdleConns,
+		MaxRetries:   env.MaxRetries,
+		DialTimeout:  env.DialTimeout,
+		ReadTimeout:  env.ReadTimeout,
+		WriteTimeout: env.WriteTimeout,
+		PoolTimeout:  env.PoolTimeout,
+	}
+
+	client := redisPkg.NewRedisClient(config)
+	addr := fmt.Sprintf("%s:%s", env.Host, env.Port)
+
+	// 注册生命周期钩子，在应用启动时检查连接，在应用关闭时关闭连接
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			// 检查连接（失败时按退避策略重试，受应用启动超时限制）
+			err := startup.Connect(ctx, HealthCheckName, func(ctx context.Context) error {
+				pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+				defer cancel()
+				return client.Ping(pingCtx).Err()
+			})
+			if err != nil {
+				logs.Error("Redis 连接失败", "addr", addr, "error", err.Error())
+				return fmt.Errorf("Redis 连接失败: %w", err)
+			}
+			logs.Info("Redis 连接成功", "addr", addr, "db", env.DB, "pool_size", env.PoolSize)
+
+			// 注册就绪检查
+			health.Register(HealthCheckName, func(ctx context.Context) error {
+				return client.Ping(ctx).Err()
+			})
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			health.Unregister(HealthCheckName)
+
+			logs.Info("正在关闭 Redis 连接", "addr", addr)
+			if err := client.Close(); err != nil {
+				logs.Error("Redis 连接关闭失败", "addr", addr, "error", err.Error())
+				return err
+			}
+			logs.Info("Redis 连接已关闭", "addr", addr)
+			return nil
+		},
+	})
+
+	return client, nil
+}
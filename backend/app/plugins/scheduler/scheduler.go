@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	sched "backend/utils/scheduler"
+
+	"go.uber.org/fx"
+	"gorm.io/gorm"
+)
+
+const (
+	// specKeyPrefix 数据库中任务调度表达式的系统配置键前缀，完整的键为 scheduler.<任务名>.spec
+	specKeyPrefix = "scheduler."
+	// specKeySuffix 调度表达式的系统配置键后缀
+	specKeySuffix = ".spec"
+	// statusKeySuffix 任务最近一次执行状态的系统配置键后缀
+	statusKeySuffix = ".status"
+	// maxSavedErrorLength 持久化的错误信息最大字符数
+	maxSavedErrorLength = 40
+)
+
+// SysRepo 系统配置存储，用于持久化任务状态和读取数据库中的调度表达式
+type SysRepo interface {
+	GetSystemConfig(ctx context.Context, key string) (string, error)
+	CreateOrUpdateSystemConfig(ctx context.Context, key string, value string) error
+}
+
+// ProvideSchedulerParams 定义调度器的依赖
+type ProvideSchedulerParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	SysRepo   SysRepo
+}
+
+// ProvideScheduler 提供定时任务调度器
+// 各模块通过 Register 注册任务；未启用时不会按计划执行任务，但仍可通过管理接口手动触发
+func ProvideScheduler(params ProvideSchedulerParams) (*sched.Scheduler, error) {
+	enabled := envx.GetBool(consts.SchedulerEnabled, true)
+
+	specs, err := sched.ParseSpecs(envx.GetStringOptional(consts.SchedulerSchedules))
+	if err != nil {
+		return nil, err
+	}
+	loc := time.Local
+	if tz := envx.GetStringOptional(consts.SchedulerTimezone); tz != "" {
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("无效的调度时区 %s: %w", tz, err)
+		}
+	}
+
+	s := sched.New(
+		sched.WithStore(&sysRepoStore{sysRepo: params.SysRepo}),
+		sched.WithSpecs(specs),
+		sched.WithLocation(loc),
+	)
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			if !enabled {
+				logs.Info("定时任务调度器未启用，任务只能手动触发")
+				return nil
+			}
+			return s.Start()
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.Stop(ctx)
+		},
+	})
+
+	return s, nil
+}
+
+// sysRepoStore 基于系统配置表的任务状态存储
+type sysRepoStore struct {
+	sysRepo SysRepo
+}
+
+// LoadStatus 读取任务状态
+func (s *sysRepoStore) LoadStatus(ctx context.Context, name string) (*sched.JobStatus, error) {
+	value, err := s.sysRepo.GetSystemConfig(ctx, specKeyPrefix+name+statusKeySuffix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var status sched.JobStatus
+	if err := json.Unmarshal([]byte(value), &status); err != nil {
+		return nil, fmt.Errorf("解析任务状态失败: %w", err)
+	}
+	return &status, nil
+}
+
+// SaveStatus 保存任务状态
+func (s *sysRepoStore) SaveStatus(ctx context.Context, status sched.JobStatus) error {
+	// 系统配置的值最长 255 字符，只持久化执行结果，错误信息截断保存
+	name := status.Name
+	status.Name, status.Description, status.Spec = "", "", ""
+	status.Running = false
+	status.NextRunAt = time.Time{}
+	if errRunes := []rune(status.LastError); len(errRunes) > maxSavedErrorLength {
+		status.LastError = string(errRunes[:maxSavedErrorLength]) + "..."
+	}
+
+	value, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+	return s.sysRepo.CreateOrUpdateSystemConfig(ctx, specKeyPrefix+name+statusKeySuffix, string(value))
+}
+
+// LoadSpec 读取数据库中配置的调度表达式
+func (s *sysRepoStore) LoadSpec(ctx context.Context, name string) (string, error) {
+	value, err := s.sysRepo.GetSystemConfig(ctx, specKeyPrefix+name+specKeySuffix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", err
+	}
+	return value, nil
+}
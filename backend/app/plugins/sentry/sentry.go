@@ -0,0 +1,80 @@
+package sentry
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"backend/utils/buildinfo"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/sentry"
+
+	"go.uber.org/fx"
+)
+
+// sentryEnv Sentry 环境变量配置（变量说明见 consts）
+type sentryEnv struct {
+	DSN         string `env:"SENTRY_DSN"`
+	Environment string `env:"SENTRY_ENVIRONMENT" default:"production"`
+	Release     string `env:"SENTRY_RELEASE"`
+	QueueSize   int    `env:"SENTRY_QUEUE_SIZE" default:"100"`
+}
+
+// CheckConfig 校验 Sentry 配置
+func CheckConfig() error {
+	env, err := loadEnv()
+	if err != nil || env.DSN == "" {
+		return err
+	}
+	return sentry.ValidateDSN(env.DSN)
+}
+
+func loadEnv() (sentryEnv, error) {
+	var env sentryEnv
+	if err := envx.Bind(&env); err != nil {
+		return env, err
+	}
+	if env.Release == "" {
+		env.Release = buildinfo.Get().Version
+	}
+	return env, nil
+}
+
+// Register 配置 SENTRY_DSN 时把 error 及以上级别的日志上报到 Sentry
+// 服务停止时取消注册并上报队列中剩余的事件
+func Register(lc fx.Lifecycle) error {
+	env, err := loadEnv()
+	if err != nil {
+		return err
+	}
+	if env.DSN == "" {
+		logs.Info("未配置 SENTRY_DSN，不上报错误日志")
+		return nil
+	}
+
+	serverName, _ := os.Hostname()
+	hook, err := sentry.NewHook(sentry.Config{
+		DSN:         env.DSN,
+		Environment: env.Environment,
+		Release:     env.Release,
+		ServerName:  serverName,
+		QueueSize:   env.QueueSize,
+	})
+	if err != nil {
+		return fmt.Errorf("接入 Sentry 失败: %w", err)
+	}
+	remove := logs.AddHook(hook)
+	lc.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			remove()
+			if dropped := hook.Dropped(); dropped > 0 {
+				logs.Warn("Sentry 上报队列已满，部分错误日志未上报", "dropped", dropped)
+			}
+			return hook.Close(ctx)
+		},
+	})
+
+	logs.Info("错误日志已接入 Sentry", "environment", env.Environment, "release", env.Release)
+	return nil
+}
@@ -0,0 +1,119 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/sse"
+
+	goredis "github.com/redis/go-redis/v9"
+	"go.uber.org/fx"
+)
+
+const (
+	// StoreMemory 进程内存储，只能在单实例内续传
+	StoreMemory = "memory"
+	// StoreRedis Redis 存储，多个实例共享任务
+	StoreRedis = "redis"
+)
+
+// ProvideSSEManagerParams 定义 SSE 管理器的依赖
+type ProvideSSEManagerParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+	Redis     *goredis.Client // 未配置 Redis 时为 nil
+}
+
+// sseEnv SSE 环境变量配置（变量说明见 consts）
+type sseEnv struct {
+	Store         string        `env:"SSE_STORE" default:"memory"`
+	MaxEvents     int           `env:"SSE_MAX_EVENTS" default:"0"`
+	MaxEventBytes int           `env:"SSE_MAX_EVENT_BYTES" default:"0"`
+	Eviction      string        `env:"SSE_EVICTION" default:"oldest"`
+	Keepalive     time.Duration `env:"SSE_KEEPALIVE" default:"0"`
+	MaxTasks      int           `env:"SSE_MAX_CONCURRENT_TASKS" default:"0"`
+}
+
+// cacheLimit 每个任务保存的数据上限
+func (e sseEnv) cacheLimit() sse.CacheLimit {
+	return sse.CacheLimit{
+		MaxEvents: e.MaxEvents,
+		MaxBytes:  e.MaxEventBytes,
+		Policy:    sse.EvictionPolicy(e.Eviction),
+	}
+}
+
+// CheckConfig 校验 SSE 任务存储配置
+func CheckConfig() error {
+	_, err := loadEnv()
+	return err
+}
+
+func loadEnv() (sseEnv, error) {
+	var env sseEnv
+	if err := envx.Bind(&env); err != nil {
+		return env, err
+	}
+	switch env.Store {
+	case StoreMemory:
+	case StoreRedis:
+		if envx.GetStringOptional(consts.RedisHost) == "" {
+			return env, fmt.Errorf("环境变量 %s 为 redis 时需要配置 %s", consts.SSEStore, consts.RedisHost)
+		}
+	default:
+		return env, fmt.Errorf("环境变量 %s 无效: %s，可选值: memory, redis", consts.SSEStore, env.Store)
+	}
+	if env.MaxEvents < 0 || env.MaxEventBytes < 0 {
+		return env, fmt.Errorf("环境变量 %s、%s 不能为负数", consts.SSEMaxEvents, consts.SSEMaxEventBytes)
+	}
+	if env.Eviction != string(sse.EvictOldest) && env.Eviction != string(sse.EvictNewest) {
+		return env, fmt.Errorf("环境变量 %s 无效: %s，可选值: oldest, newest", consts.SSEEviction, env.Eviction)
+	}
+	if env.Keepalive < 0 || env.MaxTasks < 0 {
+		return env, fmt.Errorf("环境变量 %s、%s 不能为负数", consts.SSEKeepalive, consts.SSEMaxConcurrentTasks)
+	}
+	return env, nil
+}
+
+// ProvideSSEManager 提供 SSE 管理器，进程内共享，停止时清理资源
+// SSE_STORE=redis 时任务存储在 Redis 中，负载均衡后重连请求落到其他实例时也能续传
+func ProvideSSEManager(params ProvideSSEManagerParams) (*sse.SSEManager, error) {
+	ttl, err := envx.GetDurationWithDefault(consts.SSETaskTTL, time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	env, err := loadEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sse.Option{
+		sse.WithCacheLimit(env.cacheLimit()),
+		sse.WithKeepalive(env.Keepalive),
+		sse.WithMaxConcurrentTasks(env.MaxTasks),
+	}
+	if env.Store == StoreRedis && params.Redis != nil {
+		opts = append(opts, sse.WithStore(sse.NewRedisStore(params.Redis, sse.DefaultRedisPrefix)))
+		logs.Info("SSE 任务使用 Redis 存储", "prefix", sse.DefaultRedisPrefix)
+	}
+
+	m := sse.NewSSEManager(ttl, opts...)
+	// 任务数、订阅者数、丢弃的数据条数和任务耗时通过 /metrics 输出
+	sse.NewCollector(m)
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStop: func(ctx context.Context) error {
+			metrics.Unregister(sse.CollectorName)
+			// 取消未结束的任务，订阅者收到 server_shutdown 事件，HTTP 服务器关闭时已先调用过一次
+			return m.Stop(ctx)
+		},
+	})
+
+	return m, nil
+}
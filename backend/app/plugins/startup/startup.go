@@ -0,0 +1,61 @@
+package startup
+
+import (
+	"context"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/retry"
+)
+
+// Backoff 从环境变量读取启动阶段连接依赖的重试配置
+func Backoff() (retry.Backoff, error) {
+	b := retry.DefaultBackoff()
+
+	maxElapsed, err := envx.GetDurationWithDefault(consts.StartupRetryMaxElapsed, b.MaxElapsed)
+	if err != nil {
+		return b, err
+	}
+	initialInterval, err := envx.GetDurationWithDefault(consts.StartupRetryInitialInterval, b.InitialInterval)
+	if err != nil {
+		return b, err
+	}
+	maxInterval, err := envx.GetDurationWithDefault(consts.StartupRetryMaxInterval, b.MaxInterval)
+	if err != nil {
+		return b, err
+	}
+
+	b.MaxElapsed = maxElapsed
+	b.InitialInterval = initialInterval
+	b.MaxInterval = maxInterval
+	return b, nil
+}
+
+// Timeout 从环境变量读取应用启动的最长时间
+func Timeout() (time.Duration, error) {
+	return envx.GetDurationWithDefault(consts.StartupTimeout, 60*time.Second)
+}
+
+// Connect 连接依赖服务，失败时按指数退避重试
+// name: 依赖名称（用于日志）
+// fn: 连接函数，返回 nil 表示连接成功
+func Connect(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	b, err := Backoff()
+	if err != nil {
+		return err
+	}
+
+	return retry.Do(ctx, b, func(ctx context.Context, attempt int) error {
+		err := fn(ctx)
+		if err != nil {
+			logs.Warn("依赖服务连接失败", "name", name, "attempt", attempt, "error", err.Error())
+			return err
+		}
+		if attempt > 1 {
+			logs.Info("依赖服务连接成功", "name", name, "attempt", attempt)
+		}
+		return nil
+	})
+}
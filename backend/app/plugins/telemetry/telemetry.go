@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/telemetry"
+
+	"go.uber.org/fx"
+)
+
+// telemetryEnv OpenTelemetry 环境变量配置（变量说明见 consts）
+type telemetryEnv struct {
+	Endpoint    string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	Headers     string  `env:"OTEL_EXPORTER_OTLP_HEADERS"`
+	ServiceName string  `env:"OTEL_SERVICE_NAME" default:"backend"`
+	SampleRatio float64 `env:"OTEL_TRACES_SAMPLER_ARG" default:"1"`
+}
+
+// CheckConfig 校验 OpenTelemetry 配置
+func CheckConfig() error {
+	_, _, err := loadEnv()
+	return err
+}
+
+func loadEnv() (telemetryEnv, map[string]string, error) {
+	var env telemetryEnv
+	if err := envx.Bind(&env); err != nil {
+		return env, nil, err
+	}
+	if env.SampleRatio < 0 || env.SampleRatio > 1 {
+		return env, nil, fmt.Errorf("环境变量 %s 必须在 0-1 之间，当前值: %v", consts.OTelSampleRatio, env.SampleRatio)
+	}
+	headers, err := parseHeaders(env.Headers)
+	return env, headers, err
+}
+
+// Register 配置 OTEL_EXPORTER_OTLP_ENDPOINT 时接入 OpenTelemetry，请求和 gRPC 调用创建 span 并通过 OTLP 导出
+// 服务停止时导出剩余的 span
+func Register(lc fx.Lifecycle) error {
+	env, headers, err := loadEnv()
+	if err != nil {
+		return err
+	}
+	if env.Endpoint == "" {
+		logs.Info("未配置 OTEL_EXPORTER_OTLP_ENDPOINT，不导出链路追踪数据")
+		return nil
+	}
+
+	shutdown, err := telemetry.Setup(context.Background(), telemetry.Config{
+		Endpoint:    env.Endpoint,
+		Headers:     headers,
+		ServiceName: env.ServiceName,
+		SampleRatio: env.SampleRatio,
+	})
+	if err != nil {
+		return fmt.Errorf("接入 OpenTelemetry 失败: %w", err)
+	}
+	lc.Append(fx.Hook{OnStop: shutdown})
+
+	logs.Info("链路追踪已接入 OpenTelemetry", "endpoint", env.Endpoint, "service", env.ServiceName, "sample_ratio", env.SampleRatio)
+	return nil
+}
+
+// parseHeaders 解析 key1=value1,key2=value2 格式的请求头
+func parseHeaders(value string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("环境变量 %s 格式错误: %s，格式: key1=value1,key2=value2", consts.OTelHeaders, pair)
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return headers, nil
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"backend/utils/grpcx"
+	"backend/utils/handle"
+	"backend/utils/trace"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/status"
+)
+
+// forwardedHeaders 网关原样转发给 gRPC 服务的请求头（元数据键为小写的请求头名称）
+// 其他请求头按 grpc-gateway 的默认规则转发
+var forwardedHeaders = map[string]bool{
+	"Accept-Language": true,
+	textproto.CanonicalMIMEHeaderKey(handle.TimezoneHeader):   true,
+	textproto.CanonicalMIMEHeaderKey(trace.TraceIDHeader):     true,
+	textproto.CanonicalMIMEHeaderKey(trace.RequestIDHeader):   true,
+	textproto.CanonicalMIMEHeaderKey(trace.TraceparentHeader): true,
+	textproto.CanonicalMIMEHeaderKey(trace.TracestateHeader):  true,
+}
+
+// newIncomingHeaderMatcher 创建网关的请求头匹配函数，决定哪些请求头转发为 gRPC 元数据
+// 除 forwardedHeaders 外，还会转发多租户请求头
+func newIncomingHeaderMatcher(tenantHeader string) runtime.HeaderMatcherFunc {
+	tenantHeader = textproto.CanonicalMIMEHeaderKey(tenantHeader)
+	return func(key string) (string, bool) {
+		key = textproto.CanonicalMIMEHeaderKey(key)
+		if forwardedHeaders[key] || key == tenantHeader {
+			return strings.ToLower(key), true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}
+}
+
+// outgoingHeaderMatcher 将 gRPC 响应头中的链路追踪 ID、请求 ID 原样写回 HTTP 响应头
+// gRPC 服务通过 ServeHTTP 处理时会把 Content-Type、Trailer 写入响应头元数据，这两项不转发
+// 其他响应头元数据按 grpc-gateway 的默认规则加上 Grpc-Metadata- 前缀
+func outgoingHeaderMatcher(key string) (string, bool) {
+	switch textproto.CanonicalMIMEHeaderKey(key) {
+	case textproto.CanonicalMIMEHeaderKey(trace.TraceIDHeader):
+		return trace.TraceIDHeader, true
+	case textproto.CanonicalMIMEHeaderKey(trace.RequestIDHeader):
+		return trace.RequestIDHeader, true
+	case "Content-Type", "Trailer":
+		return "", false
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
+
+// gatewayErrorResponse 网关错误响应，与 REST 接口的错误响应格式一致
+type gatewayErrorResponse struct {
+	Code    int32  `json:"code"`
+	Message string `json:"message"`
+}
+
+// gatewayErrorHandler 将 gRPC 错误转换为 HTTP 错误响应
+// HTTP 状态码由 gRPC 状态码转换，错误码从 ErrorInfo 详情中读取（非业务错误时为 0）
+func gatewayErrorHandler(ctx context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, _ *http.Request, err error) {
+	st := status.Convert(err)
+	code, _ := grpcx.ErrorCode(st)
+
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		for _, key := range []string{trace.TraceIDHeader, trace.RequestIDHeader} {
+			if values := md.HeaderMD.Get(key); len(values) > 0 {
+				w.Header().Set(key, values[0])
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(runtime.HTTPStatusFromCode(st.Code()))
+	_ = json.NewEncoder(w).Encode(gatewayErrorResponse{Code: code, Message: st.Message()})
+}
@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"backend/app/internal/handler/rpc"
+	"backend/app/server/middleware"
+	"backend/app/types/consts"
+	peanov1 "backend/proto/peano/v1"
+	"backend/utils/envx"
+	"backend/utils/logs"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.uber.org/fx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+)
+
+// defaultGRPCPort 默认 gRPC 端口
+const defaultGRPCPort = 9090
+
+// GRPCServerParams 定义 gRPC 服务器的依赖
+type GRPCServerParams struct {
+	fx.In
+	Lifecycle   fx.Lifecycle
+	ItemService *rpc.ItemService
+	TagService  *rpc.TagService
+	UserService *rpc.UserService
+}
+
+// GRPCServer 创建 gRPC 服务器
+// 未启用时不监听端口；启用后在 GRPC_PORT 上同时提供 gRPC（HTTP/2 明文）和 grpc-gateway 的 HTTP/JSON 接口，
+// 网关通过本地 gRPC 连接转发请求，与 gRPC 客户端经过相同的拦截器
+func GRPCServer(params GRPCServerParams) error {
+	if !envx.GetBool(consts.GRPCEnabled, false) {
+		return nil
+	}
+
+	port, err := envx.GetIntWithDefaultAndMin(consts.GRPCPort, defaultGRPCPort, 1)
+	if err != nil {
+		return fmt.Errorf("%s 配置错误: %w", consts.GRPCPort, err)
+	}
+	if port > 65535 {
+		return fmt.Errorf("%s 必须在 1-65535 之间，当前值: %d", consts.GRPCPort, port)
+	}
+	addr := fmt.Sprintf(":%d", port)
+
+	// 租户解析规则与 HTTP 服务相同
+	tenantResolver := middleware.NewTenantResolver()
+
+	// gRPC 服务
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(unaryInterceptors(tenantResolver)...))
+	peanov1.RegisterItemServiceServer(grpcServer, params.ItemService)
+	peanov1.RegisterTagServiceServer(grpcServer, params.TagService)
+	peanov1.RegisterUserServiceServer(grpcServer, params.UserService)
+	if envx.GetBool(consts.GRPCReflection, false) {
+		reflection.Register(grpcServer)
+	}
+
+	// grpc-gateway 通过本地连接调用 gRPC 服务
+	conn, err := grpc.NewClient(net.JoinHostPort("localhost", strconv.Itoa(port)), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("创建 grpc-gateway 连接失败: %w", err)
+	}
+	gateway := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(newIncomingHeaderMatcher(tenantResolver.Header())),
+		runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+		runtime.WithErrorHandler(gatewayErrorHandler),
+	)
+	ctx := context.Background()
+	for _, register := range []func(context.Context, *runtime.ServeMux, *grpc.ClientConn) error{
+		peanov1.RegisterItemServiceHandler,
+		peanov1.RegisterTagServiceHandler,
+		peanov1.RegisterUserServiceHandler,
+	} {
+		if err := register(ctx, gateway, conn); err != nil {
+			return fmt.Errorf("注册 grpc-gateway 失败: %w", err)
+		}
+	}
+
+	// 同一端口按请求类型分发：gRPC 请求交给 gRPC 服务，其他请求交给网关
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+	srv := &http.Server{
+		Addr:      addr,
+		Protocols: protocols,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+				grpcServer.ServeHTTP(w, r)
+				return
+			}
+			gateway.ServeHTTP(w, r)
+		}),
+	}
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("gRPC 服务器监听失败: %w", err)
+			}
+			go func() {
+				logs.Info("gRPC 服务器启动", "port", port, "addr", addr)
+				if err := srv.Serve(listener); err != nil && err != http.ErrServerClosed {
+					logs.Error("gRPC 服务器启动失败", "error", err.Error(), "port", port)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			logs.Info("正在关闭 gRPC 服务器", "port", port)
+			if err := conn.Close(); err != nil {
+				logs.Warn("关闭 grpc-gateway 连接失败", "error", err.Error())
+			}
+			if err := srv.Shutdown(ctx); err != nil {
+				logs.Error("gRPC 服务器关闭失败", "error", err.Error(), "port", port)
+				return err
+			}
+			logs.Info("gRPC 服务器已关闭", "port", port)
+			return nil
+		},
+	})
+
+	return nil
+}
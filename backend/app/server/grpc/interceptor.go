@@ -0,0 +1,169 @@
+package grpc
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"backend/app/server/middleware"
+	"backend/app/types/errorn"
+	"backend/app/types/meta"
+	peanov1 "backend/proto/peano/v1"
+	"backend/utils/errorx"
+	"backend/utils/grpcx"
+	"backend/utils/logs"
+	"backend/utils/readonly"
+	"backend/utils/trace"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// publicMethods 不需要认证的方法
+var publicMethods = map[string]bool{
+	peanov1.UserService_Login_FullMethodName:        true,
+	peanov1.UserService_RefreshToken_FullMethodName: true,
+}
+
+// readMethods 只读模式下仍允许调用的方法（不写入数据）
+var readMethods = map[string]bool{
+	peanov1.ItemService_GetItem_FullMethodName:           true,
+	peanov1.ItemService_ListItems_FullMethodName:         true,
+	peanov1.ItemService_SearchItems_FullMethodName:       true,
+	peanov1.ItemService_GetDailyItemCount_FullMethodName: true,
+	peanov1.TagService_GetTag_FullMethodName:             true,
+	peanov1.TagService_ListTags_FullMethodName:           true,
+	peanov1.UserService_Login_FullMethodName:             true,
+	peanov1.UserService_RefreshToken_FullMethodName:      true,
+	peanov1.UserService_GetUserInfo_FullMethodName:       true,
+}
+
+// unaryInterceptors 返回一元调用的拦截器（按顺序执行）
+// 与 HTTP 中间件对应：链路追踪/语言/租户 -> 日志和错误转换 -> panic 恢复 -> 只读模式 -> 认证
+func unaryInterceptors(tenantResolver *middleware.TenantResolver) []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		contextInterceptor(tenantResolver),
+		loggingInterceptor,
+		recoveryInterceptor,
+		readOnlyInterceptor,
+		authInterceptor,
+	}
+}
+
+// contextInterceptor 从元数据中读取链路追踪 ID、请求语言和租户写入 context
+// 链路追踪 ID 和请求 ID 通过响应头元数据返回；每次调用创建一个 span，接入 OpenTelemetry 后导出
+func contextInterceptor(resolver *middleware.TenantResolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		get := func(name string) string {
+			if values := md.Get(name); len(values) > 0 {
+				return values[0]
+			}
+			return ""
+		}
+
+		if remote, ok := trace.ContinueTrace(ctx, get(trace.TraceparentHeader), get(trace.TracestateHeader)); ok {
+			ctx = remote
+		} else if traceID := get(trace.TraceIDHeader); trace.ValidTraceID(traceID) {
+			ctx = context.WithValue(ctx, logs.TraceIDContextKey, traceID)
+		} else {
+			ctx = trace.InjectTraceID(ctx)
+		}
+		ctx, span := trace.StartSpan(ctx, info.FullMethod)
+		defer func() {
+			code := status.Code(err)
+			span.SetAttributes("rpc.method", info.FullMethod, "rpc.grpc.status_code", int(code))
+			// 只有服务端错误标记 span 失败，参数错误、未找到等是正常的业务结果
+			if code == codes.Unknown || code == codes.Internal || code == codes.DataLoss {
+				span.End(err)
+				return
+			}
+			span.End(nil)
+		}()
+		ctx = trace.InjectRequestID(ctx, get(trace.RequestIDHeader))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(
+			strings.ToLower(trace.TraceIDHeader), trace.TraceID(ctx),
+			strings.ToLower(trace.RequestIDHeader), trace.RequestID(ctx),
+		))
+
+		ctx = errorx.WithLocale(ctx, errorx.MatchLocale(get("accept-language")))
+
+		if resolver.Enabled() {
+			// 经过网关的请求使用原始 Host
+			host := get("x-forwarded-host")
+			if host == "" {
+				host = get(":authority")
+			}
+			tenantID, resolveErr := resolver.Resolve(get, host)
+			if resolveErr != nil {
+				logs.CtxWarnf(ctx, "无效的租户: tenant_id=%s, method=%s", tenantID, info.FullMethod)
+				return nil, grpcx.Status(resolveErr, errorx.LocaleFromContext(ctx)).Err()
+			}
+			ctx = context.WithValue(ctx, meta.ContextKeyTenantID, tenantID)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// loggingInterceptor 记录调用日志，并将业务错误转换为 gRPC 状态（状态码由错误码注册的 HTTP 状态码转换）
+func loggingInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	latency := time.Since(start)
+
+	if err == nil {
+		logs.CtxInfof(ctx, "gRPC 调用: method=%s, code=%s, latency=%s", info.FullMethod, codes.OK, latency)
+		return resp, nil
+	}
+
+	st := grpcx.Status(err, errorx.LocaleFromContext(ctx))
+	if code, ok := grpcx.ErrorCode(st); ok {
+		logs.CtxWarnf(ctx, "gRPC 调用失败: method=%s, code=%s, error_code=%d, error=%s, latency=%s", info.FullMethod, st.Code(), code, errorx.ErrorWithoutStack(err), latency)
+	} else {
+		logs.CtxWarnf(ctx, "gRPC 调用失败: method=%s, code=%s, error=%s, latency=%s", info.FullMethod, st.Code(), err.Error(), latency)
+	}
+	return nil, st.Err()
+}
+
+// recoveryInterceptor 恢复 panic，返回内部错误，不向客户端暴露 panic 内容
+func recoveryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logs.CtxErrorf(ctx, "gRPC 调用 panic: method=%s, panic=%v\n%s", info.FullMethod, p, debug.Stack())
+			resp, err = nil, errorx.New(errorn.SystemErrInternal)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// readOnlyInterceptor 只读模式下拒绝写数据的方法
+func readOnlyInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if readonly.Enabled() && !readMethods[info.FullMethod] {
+		return nil, errorx.New(errorn.SystemErrReadOnly, errorx.K("reason", readonly.Reason()))
+	}
+	return handler(ctx, req)
+}
+
+// authInterceptor 认证拦截器，校验 authorization 元数据中的 Bearer Token（与 HTTP 认证中间件规则相同）
+func authInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if publicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+
+	var authHeader string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			authHeader = values[0]
+		}
+	}
+
+	ctx, err := middleware.Authenticate(ctx, authHeader)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
@@ -10,12 +10,25 @@ import (
 	"strconv"
 	"strings"
 
+	"backend/app/docs"
+	"backend/app/internal/handler/audit"
+	"backend/app/internal/handler/bot"
+	"backend/app/internal/handler/change"
+	"backend/app/internal/handler/export"
+	"backend/app/internal/handler/feed"
 	"backend/app/internal/handler/file"
+	"backend/app/internal/handler/graph"
+	"backend/app/internal/handler/importer"
 	"backend/app/internal/handler/item"
+	"backend/app/internal/handler/notify"
+	"backend/app/internal/handler/stats"
+	"backend/app/internal/handler/system"
 	"backend/app/internal/handler/tag"
 	"backend/app/internal/handler/user"
+	"backend/app/internal/handler/webhook"
 	"backend/app/server/middleware"
 	"backend/app/server/router"
+	"backend/app/server/ws"
 	"backend/app/types/consts"
 	"backend/utils/envx"
 	"backend/utils/logs"
@@ -27,11 +40,33 @@ import (
 // HTTPServerParams 定义 HTTP 服务器的依赖
 type HTTPServerParams struct {
 	fx.In
-	Lifecycle   fx.Lifecycle
-	UserHandler *user.UserHandler
-	FileHandler *file.FileHandler
-	ItemHandler *item.ItemHandler
-	TagHandler  *tag.TagHandler
+	Lifecycle      fx.Lifecycle
+	UserHandler    *user.UserHandler
+	FileHandler    *file.FileHandler
+	ItemHandler    *item.ItemHandler
+	TagHandler     *tag.TagHandler
+	SystemHandler  *system.SystemHandler
+	WebhookHandler *webhook.WebhookHandler
+	NotifyHandler  *notify.NotifyHandler
+	ChangeHandler  *change.ChangeHandler
+	ExportHandler  *export.ExportHandler
+	ImportHandler  *importer.ImportHandler
+	AuditHandler   *audit.AuditHandler
+	FeedHandler    *feed.FeedHandler
+	StatsHandler   *stats.StatsHandler
+	BotHandler     *bot.BotHandler
+	GraphHandler   *graph.GraphHandler
+	RateLimiter    middleware.RateLimiter
+	UserLocales    middleware.UserLocaleResolver
+	DryRunner      middleware.DryRunner
+	RequestStats   middleware.RequestRecorder
+	Streams        StreamCloser
+	Tasks          ws.TaskSubscriber
+}
+
+// StreamCloser 中止进行中的长连接（如 SSE 订阅），由 SSE 管理器实现
+type StreamCloser interface {
+	Stop(ctx context.Context) error
 }
 
 // HTTPServer 创建 HTTP 服务器
@@ -53,10 +88,24 @@ func HTTPServer(params HTTPServerParams) *http.Server {
 	// 添加中间件（按顺序）
 	// 1. CORS 中间件：处理跨域
 	r.Use(middleware.CORSMiddleware())
-	// 2. API Logger 中间件：记录请求日志
-	r.Use(middleware.APILoggerMiddleware())
-	// 3. Recovery 中间件：恢复 panic
+	// 2. Trace 中间件：注入 trace_id 和客户端 IP
+	r.Use(middleware.TraceMiddleware())
+	// 3. Tenant 中间件：解析租户（未启用多租户时直接放行）
+	r.Use(middleware.TenantMiddleware())
+	// 4. API Logger 中间件：记录请求日志
+	apiLoggerConfig, err := middleware.LoadAPILoggerConfig()
+	if err != nil {
+		panic(fmt.Sprintf("API 日志配置错误: %v", err))
+	}
+	r.Use(middleware.APILoggerMiddleware(apiLoggerConfig))
+	// 5. RequestStats 中间件：统计请求数和错误率，用于管理后台统计
+	r.Use(middleware.RequestStatsMiddleware(params.RequestStats))
+	// 6. Recovery 中间件：恢复 panic
 	r.Use(gin.Recovery())
+	// 7. ReadOnly 中间件：只读模式下拒绝写请求
+	r.Use(middleware.ReadOnlyMiddleware())
+	// 8. OpenAPI 中间件：按接口定义校验请求和响应（未启用时直接放行）
+	r.Use(middleware.OpenAPIMiddleware([]byte(docs.SwaggerInfo.ReadDoc())))
 
 	// 设置路由
 
@@ -64,11 +113,24 @@ func HTTPServer(params HTTPServerParams) *http.Server {
 	setupStaticFileServer(r)
 
 	// API 路由
-	router.SetupAPIRouter(r, params.UserHandler, params.FileHandler, params.ItemHandler, params.TagHandler)
+	router.SetupAPIRouter(r, params.RateLimiter, params.UserLocales, params.DryRunner, params.UserHandler, params.FileHandler, params.ItemHandler, params.TagHandler, params.SystemHandler, params.WebhookHandler, params.NotifyHandler, params.ChangeHandler, params.ExportHandler, params.ImportHandler, params.AuditHandler, params.FeedHandler, params.StatsHandler, params.BotHandler, params.GraphHandler)
+
+	// WebSocket 路由：与 SSE 接口订阅同一个任务管理器
+	pingInterval, err := envx.GetDurationWithDefault(consts.WSPingInterval, ws.DefaultPingInterval)
+	if err != nil {
+		panic(fmt.Sprintf("%s 配置错误: %v", consts.WSPingInterval, err))
+	}
+	router.SetupWebSocketRouter(r, ws.NewHandler(params.Tasks, pingInterval))
 
 	// Swagger 路由
 	router.SetupSwaggerRouter(r)
 
+	// Metrics 路由
+	router.SetupMetricsRouter(r)
+
+	// 健康检查路由
+	router.SetupHealthRouter(r)
+
 	// 获取端口配置
 	port := envx.GetStringOptional(consts.HTTPPort)
 	if port == "" {
@@ -106,6 +168,12 @@ func HTTPServer(params HTTPServerParams) *http.Server {
 		},
 		OnStop: func(ctx context.Context) error {
 			logs.Info("正在关闭 HTTP 服务器", "port", port)
+			// Shutdown 等待进行中的请求结束，SSE 长连接需要先中止，否则要等到超时
+			srv.RegisterOnShutdown(func() {
+				if err := params.Streams.Stop(ctx); err != nil {
+					logs.Warn("中止 SSE 连接超时", "error", err.Error())
+				}
+			})
 			if err := srv.Shutdown(ctx); err != nil {
 				logs.Error("HTTP 服务器关闭失败", "error", err.Error(), "port", port)
 				return err
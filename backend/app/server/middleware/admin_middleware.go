@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"backend/app/types/consts"
+	authError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultAdminUserID 未配置 ADMIN_USER_IDS 时的管理员用户ID（空数据库启动时创建的管理员账户）
+const defaultAdminUserID = 1
+
+// AdminMiddleware 管理权限中间件，需要放在 AuthMiddleware 之后
+// 当前用户不在 ADMIN_USER_IDS 中时返回 403
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if IsAdmin(c.Request.Context()) {
+			c.Next()
+			return
+		}
+
+		err := errorx.New(authError.AuthErrPermissionDenied, errorx.K("reason", "需要管理员权限"))
+		handle.HandleErrorWithContext(c, err, "管理权限校验", &handle.ErrorConfig{LogLevel: "warn"})
+		c.Abort()
+	}
+}
+
+// IsAdmin 判断 context 中的当前用户是否拥有管理权限，未登录时返回 false
+// 每次调用时读取 ADMIN_USER_IDS，配置重新加载后立即生效
+func IsAdmin(ctx context.Context) bool {
+	userID, ok := ctx.Value(meta.ContextKeyUserID).(uint)
+	if !ok || userID == 0 {
+		return false
+	}
+	adminIDs, err := AdminUserIDs()
+	if err != nil {
+		// 配置无效时不授予管理权限
+		return false
+	}
+	for _, id := range adminIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// AdminUserIDs 读取 ADMIN_USER_IDS 配置的管理员用户ID，未设置时返回默认的管理员账户
+func AdminUserIDs() ([]uint, error) {
+	if _, ok := os.LookupEnv(consts.AdminUserIDs); !ok {
+		return []uint{defaultAdminUserID}, nil
+	}
+	values := envx.GetStringSlice(consts.AdminUserIDs)
+	ids := make([]uint, 0, len(values))
+	for _, value := range values {
+		id, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || id == 0 {
+			return nil, fmt.Errorf("环境变量 %s 无效: %q 不是用户ID", consts.AdminUserIDs, value)
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
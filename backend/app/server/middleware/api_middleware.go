@@ -2,8 +2,13 @@ package middleware
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -20,12 +25,46 @@ const (
 	bgCyan   = "\033[46m"
 )
 
+// apiLogMessage JSON 格式下请求日志的消息，便于按消息聚合和采样
+const apiLogMessage = "HTTP 请求"
+
+// APILogEntry 一次请求的日志信息
+type APILogEntry struct {
+	StatusCode int
+	Latency    time.Duration
+	ClientIP   string
+	Method     string
+	Path       string // 包含查询参数
+	Slow       bool   // 耗时超过 SlowThreshold
+}
+
+// APILogFormatter 生成请求日志的消息
+type APILogFormatter func(entry APILogEntry) string
+
 // APILoggerConfig API 日志中间件配置
 type APILoggerConfig struct {
-	SkipPaths []string // 跳过的路径
+	SkipPaths []string // 跳过的路径，返回 5xx 时仍然记录
+	// SlowThreshold 慢请求阈值，耗时超过时使用 warn 级别记录，为 0 时不区分
+	SlowThreshold time.Duration
+	// Formatter 生成日志消息，默认控制台格式下为带颜色的单行摘要（ConsoleAPILogFormatter），JSON 格式下为固定消息
+	Formatter APILogFormatter
+}
+
+// LoadAPILoggerConfig 从环境变量读取 API 日志配置（API_LOG_SKIP_PATHS、API_LOG_SLOW_THRESHOLD）
+func LoadAPILoggerConfig() (APILoggerConfig, error) {
+	slowThreshold, err := envx.GetDurationWithDefault(consts.APILogSlowThreshold, time.Second)
+	if err != nil {
+		return APILoggerConfig{}, err
+	}
+	return APILoggerConfig{
+		SkipPaths:     envx.GetStringSlice(consts.APILogSkipPaths),
+		SlowThreshold: slowThreshold,
+	}, nil
 }
 
-// APILoggerMiddleware 创建自定义 API 日志中间件
+// APILoggerMiddleware 创建 API 日志中间件，通过 logs 记录结构化的请求日志
+// 字段包括 status、latency_ms、ip、method、path，trace_id、user_id 等从 context 中读取；
+// 5xx 使用 error 级别，4xx 和慢请求使用 warn 级别，其他使用 info 级别
 func APILoggerMiddleware(config ...APILoggerConfig) gin.HandlerFunc {
 	var cfg APILoggerConfig
 	if len(config) > 0 {
@@ -38,12 +77,6 @@ func APILoggerMiddleware(config ...APILoggerConfig) gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
-		// 跳过指定路径
-		if skipPaths[c.Request.URL.Path] {
-			c.Next()
-			return
-		}
-
 		// 开始时间
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -52,42 +85,78 @@ func APILoggerMiddleware(config ...APILoggerConfig) gin.HandlerFunc {
 		// 处理请求
 		c.Next()
 
-		// 计算耗时
-		latency := time.Since(start)
-		latencyStr := formatLatency(latency)
-
-		// 获取状态码
 		statusCode := c.Writer.Status()
-
-		// 获取客户端 IP
-		clientIP := c.ClientIP()
-
-		// 获取请求方法
-		method := c.Request.Method
+		// 跳过指定路径（如健康检查），出错时仍然记录
+		if skipPaths[path] && statusCode < http.StatusInternalServerError {
+			return
+		}
 
 		// 如果有查询参数，添加到路径
 		if raw != "" {
 			path = path + "?" + raw
 		}
 
-		// 格式化时间
-		timestamp := time.Now().Format("2006/01/02 - 15:04:05")
-
-		// 构建日志输出
-		logLine := fmt.Sprintf("%s | %s | %s | %s | %s     \"%s\"",
-			timestamp,
-			colorizeStatusCode(statusCode),
-			latencyStr,
-			clientIP,
-			colorizeMethod(method),
-			path,
-		)
-
-		// 输出日志
-		fmt.Println(logLine)
+		latency := time.Since(start)
+		entry := APILogEntry{
+			StatusCode: statusCode,
+			Latency:    latency,
+			ClientIP:   c.ClientIP(),
+			Method:     c.Request.Method,
+			Path:       path,
+			Slow:       cfg.SlowThreshold > 0 && latency > cfg.SlowThreshold,
+		}
+
+		// 认证等中间件替换了请求的 context，处理完成后读取才能带上 user_id
+		ctx := c.Request.Context()
+		var keyvals []interface{}
+		msg := apiLogMessage
+		switch {
+		case cfg.Formatter != nil:
+			msg = cfg.Formatter(entry)
+			keyvals = apiLogFields(entry)
+		case logs.IsConsole():
+			// 控制台格式下摘要中已包含请求信息
+			msg = ConsoleAPILogFormatter(entry)
+		default:
+			keyvals = apiLogFields(entry)
+		}
+		if entry.Slow {
+			keyvals = append(keyvals, "slow", true)
+		}
+
+		switch {
+		case statusCode >= http.StatusInternalServerError:
+			logs.CtxError(ctx, msg, keyvals...)
+		case statusCode >= http.StatusBadRequest || entry.Slow:
+			logs.CtxWarn(ctx, msg, keyvals...)
+		default:
+			logs.CtxInfo(ctx, msg, keyvals...)
+		}
 	}
 }
 
+// apiLogFields 请求日志的结构化字段
+func apiLogFields(entry APILogEntry) []interface{} {
+	return []interface{}{
+		"status", entry.StatusCode,
+		"latency_ms", float64(entry.Latency.Microseconds()) / 1000,
+		"ip", entry.ClientIP,
+		"method", entry.Method,
+		"path", entry.Path,
+	}
+}
+
+// ConsoleAPILogFormatter 控制台格式的请求日志，带颜色的状态码和请求方法
+func ConsoleAPILogFormatter(entry APILogEntry) string {
+	return fmt.Sprintf("| %s | %s | %s | %s     \"%s\"",
+		colorizeStatusCode(entry.StatusCode),
+		formatLatency(entry.Latency),
+		entry.ClientIP,
+		colorizeMethod(entry.Method),
+		entry.Path,
+	)
+}
+
 // colorizeStatusCode 根据状态码返回带颜色的字符串
 func colorizeStatusCode(code int) string {
 	var bgColor string
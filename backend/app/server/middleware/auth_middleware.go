@@ -24,65 +24,61 @@ func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := trace.InjectSpan(c.Request.Context())
 
-		jwt := getJWT()
-
-		// 从 Authorization header 获取 token
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			logs.CtxWarnf(ctx, "Authorization header 为空: path=%s, method=%s", c.Request.URL.Path, c.Request.Method)
-			err := errorx.New(authError.AuthErrTokenRequired)
+		ctx, err := Authenticate(ctx, c.GetHeader("Authorization"))
+		if err != nil {
+			logs.CtxWarnf(ctx, "JWT 认证失败: error=%s, path=%s, method=%s", errorx.ErrorWithoutStack(err), c.Request.URL.Path, c.Request.Method)
 			handle.HandleErrorWithContext(c, err, "JWT 认证", &handle.ErrorConfig{
 				DefaultStatusCode: http.StatusUnauthorized,
 			})
 			c.Abort()
 			return
 		}
+		c.Request = c.Request.WithContext(ctx)
 
-		// 去除 "Bearer " 前缀
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		if tokenString == "" || tokenString == authHeader {
-			logs.CtxWarnf(ctx, "Token 格式错误，缺少 Bearer 前缀: path=%s, method=%s", c.Request.URL.Path, c.Request.Method)
-			err := errorx.New(authError.AuthErrTokenMalformed, errorx.K("reason", "缺少 Bearer 前缀"))
-			handle.HandleErrorWithContext(c, err, "JWT 认证", &handle.ErrorConfig{
-				DefaultStatusCode: http.StatusUnauthorized,
-			})
-			c.Abort()
-			return
-		}
+		// 继续执行下一个中间件或处理器
+		c.Next()
+	}
+}
 
-		// 验证并解析 JWT token
-		userInfo, err := jwt.ParseToken(tokenString)
-		if err != nil {
-			logs.CtxWarnf(ctx, "JWT Token 验证失败: error=%s, path=%s, method=%s", err.Error(), c.Request.URL.Path, c.Request.Method)
+// Authenticate 校验 Authorization 头（Bearer Token），成功时将用户信息写入 context
+// HTTP 认证中间件和 gRPC 认证拦截器共用，返回的错误为认证相关的 errorx 错误
+func Authenticate(ctx context.Context, authHeader string) (context.Context, error) {
+	if authHeader == "" {
+		return ctx, errorx.New(authError.AuthErrTokenRequired)
+	}
 
-			// 根据错误类型返回不同的错误码
-			var authErr error
-			errStr := err.Error()
-			if strings.Contains(errStr, "expired") || strings.Contains(errStr, "exp") {
-				authErr = errorx.New(authError.AuthErrTokenExpired)
-			} else if strings.Contains(errStr, "malformed") || strings.Contains(errStr, "invalid character") {
-				authErr = errorx.New(authError.AuthErrTokenMalformed, errorx.K("reason", err.Error()))
-			} else if strings.Contains(errStr, "signature") {
-				authErr = errorx.New(authError.AuthErrTokenSignature)
-			} else {
-				authErr = errorx.New(authError.AuthErrTokenInvalid, errorx.K("reason", err.Error()))
-			}
+	// 去除 "Bearer " 前缀
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+	if tokenString == "" || tokenString == authHeader {
+		return ctx, errorx.New(authError.AuthErrTokenMalformed, errorx.K("reason", "缺少 Bearer 前缀"))
+	}
 
-			handle.HandleErrorWithContext(c, authErr, "JWT 认证", &handle.ErrorConfig{
-				DefaultStatusCode: http.StatusUnauthorized,
-			})
-			c.Abort()
-			return
+	// 验证并解析 JWT token
+	userInfo, err := getJWT().ParseToken(tokenString)
+	if err != nil {
+		// 根据错误类型返回不同的错误码
+		errStr := err.Error()
+		if strings.Contains(errStr, "expired") || strings.Contains(errStr, "exp") {
+			return ctx, errorx.New(authError.AuthErrTokenExpired)
+		} else if strings.Contains(errStr, "malformed") || strings.Contains(errStr, "invalid character") {
+			return ctx, errorx.New(authError.AuthErrTokenMalformed, errorx.K("reason", err.Error()))
+		} else if strings.Contains(errStr, "signature") {
+			return ctx, errorx.New(authError.AuthErrTokenSignature)
 		}
+		return ctx, errorx.New(authError.AuthErrTokenInvalid, errorx.K("reason", err.Error()))
+	}
 
-		// 将用户信息存入上下文
-		ctx = context.WithValue(ctx, meta.ContextKeyUserID, userInfo.UserID)
-		ctx = context.WithValue(ctx, meta.ContextKeyAccessToken, tokenString)
-		c.Request = c.Request.WithContext(ctx)
-
-		// 继续执行下一个中间件或处理器
-		c.Next()
+	// 启用多租户时，校验 token 所属租户与当前请求租户一致
+	if tenantID, ok := TenantFromContext(ctx); ok && userInfo.TenantID != tenantID {
+		return ctx, errorx.New(authError.TenantErrMismatch)
 	}
+
+	// 将用户信息存入上下文
+	ctx = context.WithValue(ctx, meta.ContextKeyUserID, userInfo.UserID)
+	ctx = context.WithValue(ctx, meta.ContextKeyAccessToken, tokenString)
+	// 之后的 Ctx* 日志自动附带 user_id
+	ctx = logs.WithFields(ctx, "user_id", userInfo.UserID)
+	return ctx, nil
 }
 
 // getJWT 获取 JWT 实例
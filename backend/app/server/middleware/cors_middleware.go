@@ -1,35 +1,69 @@
 package middleware
 
 import (
+	"sync/atomic"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/handle"
 	"backend/utils/logs"
 
 	"github.com/gin-gonic/gin"
 )
 
+// corsAllowedOrigins 允许跨域的源（nil 表示允许所有源）
+var corsAllowedOrigins atomic.Pointer[map[string]bool]
+
+// SetCORSAllowedOrigins 设置允许跨域的源，支持运行时修改
+// 为空或包含 * 时允许所有源
+func SetCORSAllowedOrigins(origins []string) {
+	if len(origins) == 0 {
+		corsAllowedOrigins.Store(nil)
+		return
+	}
+	allowed := make(map[string]bool, len(origins))
+	for _, origin := range origins {
+		if origin == "*" {
+			corsAllowedOrigins.Store(nil)
+			return
+		}
+		allowed[origin] = true
+	}
+	corsAllowedOrigins.Store(&allowed)
+}
+
+// IsOriginAllowed 检查源是否允许跨域，WebSocket 握手时也用于校验 Origin
+func IsOriginAllowed(origin string) bool {
+	allowed := corsAllowedOrigins.Load()
+	return allowed == nil || (*allowed)[origin]
+}
+
 // CORSMiddleware 跨域中间件
+// 允许的源由 CORS_ALLOWED_ORIGINS 配置，未配置时允许所有源
 func CORSMiddleware() gin.HandlerFunc {
+	SetCORSAllowedOrigins(envx.GetStringSlice(consts.CORSAllowedOrigins))
+
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
 		// 获取请求的 Origin
 		origin := c.GetHeader("Origin")
 
-		// 设置允许的源（可以根据实际需求配置）
-		// 这里允许所有源，生产环境建议配置具体的域名
-		if origin != "" {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-		} else {
+		// 设置允许的源，不在允许列表中的源不返回 Allow-Origin，由浏览器拒绝
+		if origin == "" {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		} else if IsOriginAllowed(origin) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
 		}
 
 		// 设置允许的请求方法
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, PATCH, OPTIONS")
 
 		// 设置允许的请求头
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Accept, Origin")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, Accept, Origin, "+handle.TimezoneHeader+", "+handle.LastEventIDHeader+", "+handle.ResumeKeyHeader+", "+tenantHeaderName())
 
 		// 设置允许暴露的响应头
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type, "+TraceIDHeader+", "+RequestIDHeader)
 
 		// 设置是否允许携带凭证（Cookie等）
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
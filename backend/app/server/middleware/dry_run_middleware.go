@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	systemError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DryRunQuery 试运行查询参数
+	DryRunQuery = "dry_run"
+	// DryRunHeader 试运行请求头，响应中同名响应头为 true 表示本次写操作已回滚
+	DryRunHeader = "X-Dry-Run"
+)
+
+// DryRunner 在事务中执行写操作后回滚
+type DryRunner interface {
+	DryRun(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// DryRunMiddleware 试运行中间件，用于支持试运行的写接口
+// 请求带 dry_run=true 查询参数或 X-Dry-Run: true 请求头时，接口照常执行校验、权限检查和写操作，
+// 返回写入后的结果，结束后回滚事务，并跳过 webhook、变更同步、审计日志和搜索索引等副作用
+func DryRunMiddleware(runner DryRunner) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun, ok := dryRunRequested(c)
+		if !ok {
+			return
+		}
+		if !dryRun || isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		c.Header(DryRunHeader, "true")
+		ctx := c.Request.Context()
+		err := runner.DryRun(ctx, func(ctx context.Context) error {
+			c.Request = c.Request.WithContext(ctx)
+			c.Next()
+			return nil
+		})
+		c.Request = c.Request.WithContext(ctx)
+		if err != nil {
+			// 响应已由接口写出，这里只能记录日志
+			logs.CtxErrorf(ctx, "试运行回滚失败: method=%s, path=%s, error=%s", c.Request.Method, c.FullPath(), err.Error())
+			return
+		}
+		logs.CtxInfof(ctx, "试运行已回滚: method=%s, path=%s, status=%d", c.Request.Method, c.FullPath(), c.Writer.Status())
+	}
+}
+
+// RejectDryRunMiddleware 拒绝不支持试运行的写请求，避免客户端以为是试运行而实际写入了数据
+func RejectDryRunMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dryRun, ok := dryRunRequested(c)
+		if !ok {
+			return
+		}
+		if dryRun && !isSafeMethod(c.Request.Method) {
+			handle.HandleErrorWithContext(c, errorx.New(systemError.SystemErrDryRunUnsupported,
+				errorx.K("method", c.Request.Method), errorx.K("path", c.FullPath())), "试运行", &handle.ErrorConfig{
+				DefaultStatusCode: http.StatusBadRequest,
+				LogLevel:          "info",
+			})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// dryRunRequested 解析试运行参数，查询参数优先于请求头；参数无效时写出错误响应并返回 ok=false
+func dryRunRequested(c *gin.Context) (dryRun bool, ok bool) {
+	value, exists := c.GetQuery(DryRunQuery)
+	if !exists {
+		value = c.GetHeader(DryRunHeader)
+	}
+	if value == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		handle.HandleErrorWithContext(c, errorx.New(systemError.SystemErrInvalidDryRun, errorx.K("value", value)), "试运行", &handle.ErrorConfig{
+			DefaultStatusCode: http.StatusBadRequest,
+			LogLevel:          "info",
+		})
+		c.Abort()
+		return false, false
+	}
+	return dryRun, true
+}
+
+// isSafeMethod 是否为只读请求方法，只读请求不需要试运行
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
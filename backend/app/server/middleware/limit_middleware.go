@@ -1 +1,84 @@
 package middleware
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	systemError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+	"backend/utils/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 限流接口类别，对应 RATE_LIMIT_TIERS 中的类别
+const (
+	RateLimitClassGeneral = "general" // 普通接口
+	RateLimitClassSearch  = "search"  // 搜索
+	RateLimitClassExport  = "export"  // 导出
+	RateLimitClassEmail   = "email"   // 测试邮件
+)
+
+// 限流响应头
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimiter 按调用方和接口类别限流，未启用限流时返回 nil
+type RateLimiter interface {
+	Allow(ctx context.Context, class string) (*ratelimit.Result, error)
+}
+
+// RateLimitMiddleware 限流中间件，需要放在 AuthMiddleware 之后以便按用户计数
+// 响应头 X-RateLimit-* 返回调用方的剩余额度，同一请求经过多个类别时返回剩余最少的类别
+// 超出额度时返回 429 和 Retry-After；限流器出错时放行请求
+func RateLimitMiddleware(limiter RateLimiter, class string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		result, err := limiter.Allow(ctx, class)
+		if err != nil {
+			logs.CtxWarnf(ctx, "限流检查失败，放行请求: class=%s, error=%s", class, err.Error())
+			c.Next()
+			return
+		}
+		if result == nil {
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, result)
+		if result.Allowed {
+			c.Next()
+			return
+		}
+
+		retryAfter := int(math.Ceil(result.RetryAfter(time.Now()).Seconds()))
+		c.Header("Retry-After", strconv.Itoa(retryAfter))
+		err = errorx.New(systemError.SystemErrRateLimited, errorx.Kf("retry_after", "%d", retryAfter))
+		handle.HandleErrorWithContext(c, err, "请求限流", &handle.ErrorConfig{
+			DefaultStatusCode: http.StatusTooManyRequests,
+			LogLevel:          "info",
+		})
+		c.Abort()
+	}
+}
+
+// setRateLimitHeaders 写入限流响应头，已有响应头的剩余额度更少时保留原值
+func setRateLimitHeaders(c *gin.Context, result *ratelimit.Result) {
+	if existing := c.Writer.Header().Get(headerRateLimitRemaining); existing != "" {
+		if remaining, err := strconv.Atoi(existing); err == nil && remaining < result.Remaining {
+			return
+		}
+	}
+	c.Header(headerRateLimitLimit, strconv.Itoa(result.Limit))
+	c.Header(headerRateLimitRemaining, strconv.Itoa(result.Remaining))
+	c.Header(headerRateLimitReset, strconv.FormatInt(result.Reset.Unix(), 10))
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"context"
+
+	"backend/app/types/meta"
+	"backend/utils/errorx"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserLocaleResolver 查询用户的语言偏好，未设置时返回空字符串
+type UserLocaleResolver interface {
+	UserLocale(ctx context.Context, userID uint) (string, error)
+}
+
+// UserLocaleMiddleware 用户语言偏好中间件，需要放在 AuthMiddleware 之后
+// 用户设置了语言偏好时写入 context，错误消息、参数校验提示和成功提示优先使用该语言，未设置时按 Accept-Language 选择
+// 查询失败时忽略语言偏好
+func UserLocaleMiddleware(resolver UserLocaleResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		userID, _ := ctx.Value(meta.ContextKeyUserID).(uint)
+		if userID == 0 {
+			c.Next()
+			return
+		}
+
+		locale, err := resolver.UserLocale(ctx, userID)
+		if err != nil {
+			logs.CtxWarnf(ctx, "查询用户语言偏好失败，按 Accept-Language 选择: user_id=%d, error=%s", userID, err.Error())
+		}
+		if locale != "" {
+			c.Request = c.Request.WithContext(errorx.WithLocale(ctx, locale))
+		}
+		c.Next()
+	}
+}
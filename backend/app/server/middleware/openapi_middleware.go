@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"backend/app/types/consts"
+	systemError "backend/app/types/errorn"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+	"backend/utils/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPIMiddleware 按接口定义（/api/openapi.json）校验请求和响应，防止实现与接口定义不一致
+// 接口定义中没有的 /api 路由放行并记录一次警告（通常是修改接口注释后没有重新生成 app/docs）；
+// 请求不符合时返回 400，响应不符合时替换为 500 错误响应（需要缓存整个响应，只在 debug 模式下生效）
+func OpenAPIMiddleware(specJSON []byte) gin.HandlerFunc {
+	validateRequests := envx.GetBool(consts.OpenAPIValidateRequests, false)
+	validateResponses := envx.GetBool(consts.OpenAPIValidateResponses, false)
+	if validateResponses && gin.Mode() != gin.DebugMode {
+		logs.Warn("响应校验只在 debug 模式下生效，已忽略", "env", consts.OpenAPIValidateResponses, "mode", gin.Mode())
+		validateResponses = false
+	}
+	if !validateRequests && !validateResponses {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	spec, err := openapi.Parse(specJSON)
+	if err != nil {
+		panic(fmt.Sprintf("接口定义校验初始化失败: %v", err))
+	}
+	logs.Info("接口定义校验已启用", "operations", spec.Len(), "requests", validateRequests, "responses", validateResponses)
+
+	// undocumented 已记录警告的路由，每个路由只警告一次
+	var undocumented sync.Map
+
+	return func(c *gin.Context) {
+		op := spec.Operation(c.Request.Method, c.FullPath())
+		if op == nil {
+			if path := c.FullPath(); strings.HasPrefix(path, "/api/") {
+				route := c.Request.Method + " " + path
+				if _, warned := undocumented.LoadOrStore(route, struct{}{}); !warned {
+					logs.CtxWarnf(c.Request.Context(), "路由不在接口定义中，跳过校验: route=%s", route)
+				}
+			}
+			c.Next()
+			return
+		}
+
+		if validateRequests {
+			if violations := spec.ValidateRequest(op, openAPIRequest(c, op)); len(violations) > 0 {
+				handle.HandleErrorWithContext(c, contractError(systemError.SystemErrRequestContract, violations), "请求校验", &handle.ErrorConfig{
+					DefaultStatusCode: http.StatusBadRequest,
+					LogLevel:          "info",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		// 非 JSON 接口（如 SSE、文件下载）不缓存响应
+		if !validateResponses || !op.ProducesJSON() {
+			c.Next()
+			return
+		}
+
+		w := &bufferedWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if violations := spec.ValidateResponse(op, w.status, w.Header().Get("Content-Type"), w.body.Bytes()); len(violations) > 0 {
+			c.Writer.Header().Del("Content-Length")
+			handle.HandleErrorWithContext(c, contractError(systemError.SystemErrResponseContract, violations), "响应校验", &handle.ErrorConfig{
+				DefaultStatusCode: http.StatusInternalServerError,
+				LogLevel:          "error",
+			})
+			return
+		}
+		c.Writer.WriteHeader(w.status)
+		if w.body.Len() > 0 {
+			_, _ = c.Writer.Write(w.body.Bytes())
+		}
+	}
+}
+
+// openAPIRequest 收集待校验的请求参数，接口定义了请求体时读取请求体并放回
+func openAPIRequest(c *gin.Context, op *openapi.Operation) *openapi.Request {
+	req := &openapi.Request{
+		Query:       c.Request.URL.Query(),
+		Header:      c.Request.Header,
+		Path:        make(map[string]string, len(c.Params)),
+		ContentType: c.ContentType(),
+	}
+	for _, p := range c.Params {
+		req.Path[p.Key] = p.Value
+	}
+
+	for _, p := range op.Parameters {
+		if p.In != "body" || c.Request.Body == nil {
+			continue
+		}
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			logs.CtxWarnf(c.Request.Context(), "读取请求体失败，跳过请求体校验: %s", err.Error())
+		}
+		req.Body = body
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		break
+	}
+	return req
+}
+
+// contractError 将不符合项转换为聚合错误，响应的 errors 数组中每项对应一处不符合
+func contractError(code int32, violations []openapi.Violation) error {
+	errs := make([]error, 0, len(violations))
+	for _, v := range violations {
+		errs = append(errs, errorx.New(code, errorx.K("field", v.Field), errorx.K("reason", v.Reason)))
+	}
+	return errorx.Join(errs...)
+}
+
+// bufferedWriter 缓存响应，校验通过后再写出
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if w.body.Len() == 0 {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.body.Len() > 0
+}
+
+// Flush 缓存期间不写出
+func (w *bufferedWriter) Flush() {}
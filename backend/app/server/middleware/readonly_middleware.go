@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+
+	systemError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/readonly"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readOnlyAllowedPaths 只读模式下仍允许的非 GET 请求（不写入数据）
+var readOnlyAllowedPaths = map[string]bool{
+	"/api/user/login":         true,
+	"/api/user/refresh-token": true,
+	// 导出账户数据只读取数据库，导出文件写在 EXPORT_DIR
+	"/api/user/export": true,
+	// 导入预览只解析上传的文件
+	"/api/import/preview": true,
+	// GraphQL 查询使用 POST，变更操作由 GraphQL 处理器拒绝
+	"/api/graphql": true,
+}
+
+// ReadOnlyMiddleware 只读模式中间件
+// 只读模式下拒绝写请求（GET、HEAD、OPTIONS 以外的方法），返回 503
+func ReadOnlyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !readonly.Enabled() || !isMutatingMethod(c.Request.Method) || readOnlyAllowedPaths[c.FullPath()] {
+			c.Next()
+			return
+		}
+
+		err := errorx.New(systemError.SystemErrReadOnly, errorx.K("reason", readonly.Reason()))
+		handle.HandleErrorWithContext(c, err, "只读模式写请求", &handle.ErrorConfig{
+			DefaultStatusCode: http.StatusServiceUnavailable,
+			LogLevel:          "info",
+		})
+		c.Abort()
+	}
+}
+
+// isMutatingMethod 是否为写请求方法
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestRecorder 请求统计，由 stats logic 实现
+type RequestRecorder interface {
+	RecordRequest(statusCode int)
+}
+
+// RequestStatsMiddleware 请求统计中间件，在请求结束时按响应状态码记录 /api 下的请求
+// 需要放在 Recovery 中间件之前，panic 恢复后的 500 响应才会被统计
+func RequestStatsMiddleware(recorder RequestRecorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.Next()
+			return
+		}
+		c.Next()
+		recorder.RecordRequest(c.Writer.Status())
+	}
+}
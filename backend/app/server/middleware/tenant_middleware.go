@@ -0,0 +1,170 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"backend/app/types/consts"
+	tenantError "backend/app/types/errorn"
+	"backend/app/types/meta"
+	"backend/utils/envx"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// TenantResolveModeHeader 从请求头解析租户
+	TenantResolveModeHeader = "header"
+	// TenantResolveModeSubdomain 从子域名解析租户
+	TenantResolveModeSubdomain = "subdomain"
+
+	// defaultTenantHeader 默认租户请求头
+	defaultTenantHeader = "X-Tenant-ID"
+	// maxTenantIDLength 租户ID最大长度
+	maxTenantIDLength = 64
+)
+
+// TenantMiddleware 多租户解析中间件
+// 未启用多租户时直接放行；启用后从请求头或子域名解析租户ID并写入 context，
+// 解析不到时使用默认租户
+func TenantMiddleware() gin.HandlerFunc {
+	resolver := NewTenantResolver()
+	if resolver.Enabled() {
+		logs.Info("多租户已启用", "mode", resolver.mode, "header", resolver.header, "default_tenant", resolver.defaultTenant)
+	}
+
+	return func(c *gin.Context) {
+		if !resolver.Enabled() {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+
+		tenantID, err := resolver.Resolve(c.GetHeader, c.Request.Host)
+		if err != nil {
+			logs.CtxWarnf(ctx, "无效的租户: tenant_id=%s, path=%s", tenantID, c.Request.URL.Path)
+			handle.HandleErrorWithContext(c, err, "租户解析", &handle.ErrorConfig{
+				DefaultStatusCode: http.StatusBadRequest,
+			})
+			c.Abort()
+			return
+		}
+
+		ctx = context.WithValue(ctx, meta.ContextKeyTenantID, tenantID)
+		ctx = logs.WithFields(ctx, "tenant_id", tenantID)
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// TenantResolver 租户解析器，HTTP 中间件和 gRPC 拦截器共用同一套解析规则
+type TenantResolver struct {
+	enabled       bool
+	mode          string
+	header        string
+	defaultTenant string
+}
+
+// NewTenantResolver 根据多租户配置创建租户解析器
+func NewTenantResolver() *TenantResolver {
+	enabled := envx.GetBool(consts.TenantEnabled, false)
+
+	mode := strings.ToLower(strings.TrimSpace(envx.GetStringOptional(consts.TenantResolveMode)))
+	if mode == "" {
+		mode = TenantResolveModeHeader
+	}
+
+	header := tenantHeaderName()
+
+	defaultTenant := envx.GetStringOptional(consts.TenantDefault)
+
+	return &TenantResolver{
+		enabled:       enabled,
+		mode:          mode,
+		header:        header,
+		defaultTenant: defaultTenant,
+	}
+}
+
+// Enabled 是否启用多租户
+func (r *TenantResolver) Enabled() bool {
+	return r.enabled
+}
+
+// Header 返回请求头模式下读取的租户请求头名称
+func (r *TenantResolver) Header() string {
+	return r.header
+}
+
+// Resolve 解析租户ID
+// getHeader: 按名称读取请求头（gRPC 中读取元数据）
+// host: 请求的 Host（gRPC 中为 :authority），子域名模式下使用
+// 解析不到时使用默认租户，租户ID不合法时返回错误
+func (r *TenantResolver) Resolve(getHeader func(name string) string, host string) (string, error) {
+	var tenantID string
+	switch r.mode {
+	case TenantResolveModeSubdomain:
+		tenantID = resolveTenantFromHost(host)
+	default:
+		tenantID = strings.TrimSpace(getHeader(r.header))
+	}
+	if tenantID == "" {
+		tenantID = r.defaultTenant
+	}
+
+	if !isValidTenantID(tenantID) {
+		return tenantID, errorx.New(tenantError.TenantErrInvalid, errorx.K("tenant_id", tenantID))
+	}
+	return tenantID, nil
+}
+
+// tenantHeaderName 返回租户请求头名称
+func tenantHeaderName() string {
+	if header := envx.GetStringOptional(consts.TenantHeader); header != "" {
+		return header
+	}
+	return defaultTenantHeader
+}
+
+// TenantFromContext 从 context 中获取租户ID
+// 未启用多租户时返回 false
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(meta.ContextKeyTenantID).(string)
+	return tenantID, ok
+}
+
+// resolveTenantFromHost 从 Host 中解析子域名作为租户ID
+// 例如 acme.example.com -> acme；不足三级域名时返回空字符串
+func resolveTenantFromHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if net.ParseIP(host) != nil {
+		return ""
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) < 3 {
+		return ""
+	}
+	return strings.ToLower(labels[0])
+}
+
+// isValidTenantID 校验租户ID：允许为空（默认租户），否则只能包含字母、数字、下划线和中划线
+func isValidTenantID(tenantID string) bool {
+	if len(tenantID) > maxTenantIDLength {
+		return false
+	}
+	for _, r := range tenantID {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
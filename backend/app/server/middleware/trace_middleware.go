@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"backend/app/types/meta"
+	"backend/utils/logs"
+	"backend/utils/trace"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// TraceIDHeader 链路追踪 ID 请求/响应头
+	TraceIDHeader = trace.TraceIDHeader
+	// RequestIDHeader 请求 ID 请求/响应头
+	RequestIDHeader = trace.RequestIDHeader
+
+	// maxRequestIDLength 请求头中 request_id 的最大长度，超过时重新生成
+	maxRequestIDLength = 128
+)
+
+// TraceMiddleware 链路追踪中间件
+// 优先沿用请求头中的 traceparent（W3C Trace Context）、trace_id 和 request_id，否则生成新的 ID，并写回响应头
+// trace_id 过长或包含字母、数字、- 和 _ 以外的字符时重新生成
+// 每个请求创建一个 span，接入 OpenTelemetry 后导出，5xx 响应标记为失败
+// 同时注入客户端 IP，用于审计日志
+func TraceMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		if remote, ok := trace.ContinueTrace(ctx, c.GetHeader(trace.TraceparentHeader), c.GetHeader(trace.TracestateHeader)); ok {
+			// 沿用上游 W3C traceparent 的链路
+			ctx = remote
+		} else if traceID := c.GetHeader(TraceIDHeader); trace.ValidTraceID(traceID) {
+			ctx = context.WithValue(ctx, logs.TraceIDContextKey, traceID)
+		} else {
+			ctx = trace.InjectTraceID(ctx)
+		}
+		ctx, span := trace.StartSpan(ctx, c.Request.Method+" "+c.FullPath())
+		requestID := c.GetHeader(RequestIDHeader)
+		if len(requestID) > maxRequestIDLength {
+			requestID = ""
+		}
+		ctx = trace.InjectRequestID(ctx, requestID)
+		ctx = context.WithValue(ctx, meta.ContextKeyClientIP, c.ClientIP())
+
+		if traceID := trace.TraceID(ctx); traceID != "" {
+			c.Writer.Header().Set(TraceIDHeader, traceID)
+		}
+		c.Writer.Header().Set(RequestIDHeader, trace.RequestID(ctx))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(
+			"http.request.method", c.Request.Method,
+			"http.route", c.FullPath(),
+			"http.response.status_code", status,
+		)
+		// 只有服务端错误标记 span 失败，客户端错误是正常的业务结果
+		var err error
+		if status >= http.StatusInternalServerError {
+			err = errors.New(http.StatusText(status))
+			if last := c.Errors.Last(); last != nil {
+				err = last.Err
+			}
+		}
+		span.End(err)
+	}
+}
@@ -1,7 +1,10 @@
 package server
 
 import (
+	"backend/app/server/grpc"
 	"backend/app/server/http"
+	"backend/app/server/reload"
+	"backend/app/server/validate"
 
 	"go.uber.org/fx"
 )
@@ -9,7 +12,13 @@ import (
 // ServerModule fx 服务器模块
 var ServerModule = fx.Module("server",
 	fx.Invoke(
+		// 注册自定义验证规则
+		validate.RegisterValidators,
+		// 动态配置重新加载
+		reload.WatchConfig,
 		// 启动 HTTP 服务器
 		http.HTTPServer,
+		// 启动 gRPC 服务器（GRPC_ENABLED=true 时）
+		grpc.GRPCServer,
 	),
 )
@@ -0,0 +1,114 @@
+package reload
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"backend/app/server/middleware"
+	"backend/app/types/consts"
+	"backend/utils/envx"
+	"backend/utils/logs"
+	"backend/utils/readonly"
+	"backend/utils/safego"
+
+	"go.uber.org/fx"
+)
+
+// WatchConfigParams 定义动态配置重新加载的依赖
+type WatchConfigParams struct {
+	fx.In
+
+	Lifecycle fx.Lifecycle
+}
+
+// WatchConfig 注册动态配置的订阅者，在 .env/配置文件修改或收到 SIGHUP 信号时重新加载
+// 只有这里订阅的配置支持运行时修改，其他配置修改后需要重启
+func WatchConfig(params WatchConfigParams) error {
+	interval, err := envx.GetDurationWithDefault(consts.ConfigWatchInterval, 30*time.Second)
+	if err != nil {
+		return err
+	}
+
+	registerDynamicConfig()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+
+	params.Lifecycle.Append(fx.Hook{
+		OnStart: func(context.Context) error {
+			// 定期检查文件修改
+			if interval > 0 {
+				safego.Go(ctx, func() {
+					envx.WatchFiles(ctx, interval, logReload)
+				})
+			}
+
+			// 收到 SIGHUP 时重新加载
+			signal.Notify(signals, syscall.SIGHUP)
+			safego.Go(ctx, func() {
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-signals:
+						changed, err := envx.Reload()
+						logReload(changed, err)
+					}
+				}
+			})
+
+			logs.Info("动态配置重新加载已启用", "interval", interval.String())
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			signal.Stop(signals)
+			cancel()
+			return nil
+		},
+	})
+	return nil
+}
+
+// registerDynamicConfig 订阅支持运行时修改的配置
+func registerDynamicConfig() {
+	// 日志级别
+	envx.Watch(consts.EnvLogLevel, func(value string) {
+		logs.SetLevel(value)
+	})
+
+	// 允许跨域的源
+	envx.Watch(consts.CORSAllowedOrigins, func(string) {
+		middleware.SetCORSAllowedOrigins(envx.GetStringSlice(consts.CORSAllowedOrigins))
+	})
+
+	// 只读（维护）模式
+	applyReadOnly := func(string) {
+		if !envx.GetBool(consts.ReadOnly, false) {
+			readonly.Disable()
+			return
+		}
+		reason := envx.GetStringOptional(consts.ReadOnlyReason)
+		if reason == "" {
+			reason = "maintenance"
+		}
+		readonly.Enable(reason)
+	}
+	envx.Watch(consts.ReadOnly, applyReadOnly)
+	envx.Watch(consts.ReadOnlyReason, applyReadOnly)
+}
+
+// logReload 记录重新加载的结果
+func logReload(changed []string, err error) {
+	if err != nil {
+		logs.Error("重新加载配置失败", "error", err.Error())
+		return
+	}
+	if len(changed) == 0 {
+		logs.Info("配置已重新加载，动态配置无变化")
+		return
+	}
+	logs.Info("动态配置已更新", "keys", changed, "mode", readonly.Mode())
+}
@@ -1,10 +1,21 @@
 package router
 
 import (
+	"backend/app/internal/handler/audit"
+	"backend/app/internal/handler/bot"
+	"backend/app/internal/handler/change"
+	"backend/app/internal/handler/export"
+	"backend/app/internal/handler/feed"
 	"backend/app/internal/handler/file"
+	"backend/app/internal/handler/graph"
+	"backend/app/internal/handler/importer"
 	"backend/app/internal/handler/item"
+	"backend/app/internal/handler/notify"
+	"backend/app/internal/handler/stats"
+	"backend/app/internal/handler/system"
 	"backend/app/internal/handler/tag"
 	"backend/app/internal/handler/user"
+	"backend/app/internal/handler/webhook"
 	"backend/app/server/middleware"
 
 	"github.com/gin-gonic/gin"
@@ -15,19 +26,56 @@ import (
 // fileHandler: File 处理器
 // itemHandler: Item 处理器
 // tagHandler: Tag 处理器
-func SetupAPIRouter(r *gin.Engine, userHandler *user.UserHandler, fileHandler *file.FileHandler, itemHandler *item.ItemHandler, tagHandler *tag.TagHandler) {
+// systemHandler: System 处理器
+// webhookHandler: Webhook 处理器
+// notifyHandler: Notify 处理器
+// changeHandler: Change 处理器
+// exportHandler: Export 处理器
+// importHandler: Import 处理器
+// auditHandler: Audit 处理器
+// feedHandler: 订阅处理器
+// statsHandler: 统计处理器
+// botHandler: Telegram 机器人处理器
+// graphHandler: GraphQL 处理器
+// limiter: 限流器，作用于需要认证的路由
+// locales: 用户语言偏好，作用于需要认证的路由
+// dryRunner: 试运行，作用于项目、标签和导入接口
+func SetupAPIRouter(r *gin.Engine, limiter middleware.RateLimiter, locales middleware.UserLocaleResolver, dryRunner middleware.DryRunner, userHandler *user.UserHandler, fileHandler *file.FileHandler, itemHandler *item.ItemHandler, tagHandler *tag.TagHandler, systemHandler *system.SystemHandler, webhookHandler *webhook.WebhookHandler, notifyHandler *notify.NotifyHandler, changeHandler *change.ChangeHandler, exportHandler *export.ExportHandler, importHandler *importer.ImportHandler, auditHandler *audit.AuditHandler, feedHandler *feed.FeedHandler, statsHandler *stats.StatsHandler, botHandler *bot.BotHandler, graphHandler *graph.GraphHandler) {
 	api := r.Group("/api")
 
+	// 需要认证的路由使用的中间件：认证、用户语言偏好、普通接口限流、拒绝试运行
+	// 支持试运行的路由（项目、标签和导入）使用 dryRunnable，试运行时写操作执行后回滚
+	authed := []gin.HandlerFunc{
+		middleware.AuthMiddleware(),
+		middleware.UserLocaleMiddleware(locales),
+		middleware.RateLimitMiddleware(limiter, middleware.RateLimitClassGeneral),
+		middleware.RejectDryRunMiddleware(),
+	}
+	dryRunnable := append(authed[:len(authed)-1:len(authed)-1], middleware.DryRunMiddleware(dryRunner))
+
 	// 用户相关路由
 	{
 		userGroup := api.Group("/user")
 		userGroup.POST("/login", userHandler.Login)
 		userGroup.POST("/refresh-token", userHandler.RefreshToken)
+		userGroup.POST("/email/verify", userHandler.VerifyEmail)
+		userGroup.POST("/password/forgot", userHandler.ForgotPassword)
+		userGroup.POST("/password/reset", userHandler.ResetPassword)
+		userGroup.GET("/export/download", exportHandler.Download)
 		// 需要认证的路由
 		userGroupAuth := userGroup.Group("")
-		userGroupAuth.Use(middleware.AuthMiddleware())
+		userGroupAuth.Use(authed...)
 		userGroupAuth.GET("/info", userHandler.GetUserInfo)
 		userGroupAuth.PUT("/info", userHandler.UpateUserInfo)
+		userGroupAuth.PUT("/email", userHandler.UpdateEmail)
+		userGroupAuth.POST("/export", middleware.RateLimitMiddleware(limiter, middleware.RateLimitClassExport), exportHandler.Export)
+		userGroupAuth.POST("/export/pause", exportHandler.Pause)
+		userGroupAuth.POST("/export/resume", exportHandler.Resume)
+		userGroupAuth.GET("/feed", feedHandler.GetFeed)
+		userGroupAuth.POST("/feed/rotate", feedHandler.RotateFeed)
+		userGroupAuth.GET("/telegram", botHandler.GetTelegramStatus)
+		userGroupAuth.POST("/telegram/link", botHandler.CreateTelegramLink)
+		userGroupAuth.DELETE("/telegram", botHandler.UnlinkTelegram)
 	}
 
 	// 文件相关路由
@@ -39,9 +87,13 @@ func SetupAPIRouter(r *gin.Engine, userHandler *user.UserHandler, fileHandler *f
 	// 项目相关路由（需要认证）
 	{
 		itemGroup := api.Group("/item")
-		itemGroup.Use(middleware.AuthMiddleware())
+		// 日历订阅和订阅源使用订阅令牌认证
+		itemGroup.GET("/calendar.ics", feedHandler.Calendar)
+		itemGroup.GET("/feed.atom", feedHandler.Atom)
+		itemGroup.Use(dryRunnable...)
 		itemGroup.POST("", itemHandler.CreateItem)
 		itemGroup.GET("/list", itemHandler.GetItemList)
+		itemGroup.GET("/search", middleware.RateLimitMiddleware(limiter, middleware.RateLimitClassSearch), itemHandler.SearchItems)
 		itemGroup.GET("/daily-count", itemHandler.GetDailyItemCount)
 		itemGroup.GET("/:item_id", itemHandler.GetItem)
 		itemGroup.PUT("/:item_id", itemHandler.UpdateItem)
@@ -51,11 +103,91 @@ func SetupAPIRouter(r *gin.Engine, userHandler *user.UserHandler, fileHandler *f
 	// 标签相关路由（需要认证）
 	{
 		tagGroup := api.Group("/tag")
-		tagGroup.Use(middleware.AuthMiddleware())
+		tagGroup.Use(dryRunnable...)
 		tagGroup.POST("", tagHandler.CreateTag)
 		tagGroup.GET("/list", tagHandler.GetTagList)
 		tagGroup.GET("/:tag_id", tagHandler.GetTag)
 		tagGroup.PUT("/:tag_id", tagHandler.UpdateTag)
 		tagGroup.DELETE("/:tag_id", tagHandler.DeleteTag)
 	}
+
+	// 系统相关路由（需要认证，定时任务、后台任务队列、SSE 任务列表和测试邮件需要管理员权限）
+	{
+		systemGroup := api.Group("/system")
+		systemGroup.Use(authed...)
+		systemGroup.GET("/info", systemHandler.GetSystemInfo)
+
+		jobGroup := systemGroup.Group("", middleware.AdminMiddleware())
+		jobGroup.GET("/jobs", systemHandler.ListJobs)
+		jobGroup.POST("/jobs/:name/run", systemHandler.RunJob)
+		jobGroup.GET("/queue", systemHandler.GetQueueStats)
+		jobGroup.GET("/queue/jobs", systemHandler.ListQueueJobs)
+		jobGroup.POST("/queue/jobs", systemHandler.EnqueueQueueJob)
+		jobGroup.GET("/queue/jobs/:job_id", systemHandler.GetQueueJob)
+		jobGroup.POST("/queue/jobs/:job_id/retry", systemHandler.RetryQueueJob)
+		jobGroup.GET("/sse/tasks", systemHandler.ListSSETasks)
+		jobGroup.POST("/email/test", middleware.RateLimitMiddleware(limiter, middleware.RateLimitClassEmail), notifyHandler.SendTestEmail)
+	}
+
+	// Webhook 相关路由
+	{
+		webhookGroup := api.Group("/webhook")
+		webhookGroup.Use(authed...)
+		webhookGroup.POST("", webhookHandler.CreateWebhook)
+		webhookGroup.GET("/list", webhookHandler.GetWebhookList)
+		webhookGroup.GET("/:webhook_id", webhookHandler.GetWebhook)
+		webhookGroup.PUT("/:webhook_id", webhookHandler.UpdateWebhook)
+		webhookGroup.DELETE("/:webhook_id", webhookHandler.DeleteWebhook)
+		webhookGroup.GET("/:webhook_id/deliveries", webhookHandler.GetDeliveryList)
+	}
+
+	// 通知相关路由（需要认证）
+	{
+		notifyGroup := api.Group("/notify")
+		notifyGroup.Use(authed...)
+		notifyGroup.GET("/preferences", notifyHandler.GetPreference)
+		notifyGroup.PUT("/preferences", notifyHandler.UpdatePreference)
+	}
+
+	// 同步相关路由（需要认证）
+	{
+		syncGroup := api.Group("/sync")
+		syncGroup.Use(authed...)
+		syncGroup.GET("/changes", changeHandler.GetChanges)
+		syncGroup.GET("/stream", changeHandler.Stream)
+	}
+
+	// 数据导入路由
+	{
+		importGroup := api.Group("/import")
+		importGroup.Use(dryRunnable...)
+		importGroup.GET("/formats", importHandler.GetFormats)
+		importGroup.POST("/preview", importHandler.Preview)
+		importGroup.POST("", importHandler.Import)
+	}
+
+	// 管理相关路由（需要认证和管理员权限）
+	{
+		adminGroup := api.Group("/admin")
+		adminGroup.Use(authed...)
+		adminGroup.Use(middleware.AdminMiddleware())
+		adminGroup.GET("/audit", auditHandler.GetAuditLogList)
+		adminGroup.GET("/audit/export", middleware.RateLimitMiddleware(limiter, middleware.RateLimitClassExport), auditHandler.ExportAuditLogs)
+		adminGroup.GET("/stats", statsHandler.GetStats)
+		adminGroup.GET("/log-level", systemHandler.GetLogLevel)
+		adminGroup.PUT("/log-level", systemHandler.SetLogLevel)
+	}
+
+	// 元数据路由（需要认证）
+	{
+		metaGroup := api.Group("/meta")
+		metaGroup.Use(authed...)
+		metaGroup.GET("/errors", systemHandler.ListErrorCodes)
+	}
+
+	// Telegram 机器人 webhook（使用 webhook 密钥认证）
+	api.POST("/telegram/webhook", botHandler.Webhook)
+
+	// GraphQL 路由（需要认证）
+	api.Group("/graphql", authed...).POST("", graphHandler.Serve)
 }
@@ -0,0 +1,56 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	systemError "backend/app/types/errorn"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/health"
+	"backend/utils/readonly"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readyCheckTimeout 就绪检查的超时时间
+const readyCheckTimeout = 3 * time.Second
+
+// SetupHealthRouter 设置健康检查路由
+// /healthz: 存活检查，进程可以响应即返回成功
+// /readyz: 就绪检查，执行所有通过 health.Register 注册的依赖检查，任一失败返回 503
+// 两个端点都会返回当前运行模式（read_only、read_write），只读模式不影响就绪状态
+func SetupHealthRouter(r *gin.Engine) {
+	r.GET("/healthz", func(c *gin.Context) {
+		handle.Success(c, gin.H{"status": health.StatusUp, "mode": readonly.Mode()})
+	})
+
+	r.GET("/readyz", func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), readyCheckTimeout)
+		defer cancel()
+
+		results, ok := health.Check(ctx)
+		if ok {
+			handle.Success(c, gin.H{"status": health.StatusUp, "mode": readonly.Mode(), "checks": results})
+			return
+		}
+
+		// 汇总失败的检查项
+		failed := make([]string, 0, len(results))
+		for _, name := range health.Names() {
+			if result, exists := results[name]; exists && result.Status == health.StatusDown {
+				failed = append(failed, name)
+			}
+		}
+
+		err := errorx.New(systemError.SystemErrNotReady, errorx.K("reason", strings.Join(failed, ", ")))
+		statusErr := err.(errorx.StatusError)
+		c.JSON(http.StatusServiceUnavailable, handle.Response{
+			Code:    statusErr.Code(),
+			Message: errorx.LocalizedMsg(err, handle.Locale(c)),
+			Data:    gin.H{"status": health.StatusDown, "mode": readonly.Mode(), "checks": results},
+		})
+	})
+}
@@ -0,0 +1,28 @@
+package router
+
+import (
+	"net/http"
+
+	"backend/utils/handle"
+	"backend/utils/logs"
+	"backend/utils/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupMetricsRouter 设置指标路由
+// 输出所有通过 metrics.Register 注册的指标快照
+// format=prometheus 时以 Prometheus 文本格式输出计数器，供 Prometheus 抓取
+func SetupMetricsRouter(r *gin.Engine) {
+	r.GET("/metrics", func(c *gin.Context) {
+		if c.Query("format") == "prometheus" {
+			c.Header("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			c.Status(http.StatusOK)
+			if err := metrics.WritePrometheus(c.Writer); err != nil {
+				logs.Warn("输出 Prometheus 指标失败", "error", err.Error())
+			}
+			return
+		}
+		handle.Success(c, metrics.Snapshot())
+	})
+}
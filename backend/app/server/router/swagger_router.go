@@ -3,7 +3,7 @@ package router
 import (
 	"net/http"
 
-	_ "backend/app/docs" // swagger docs
+	"backend/app/docs"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -14,6 +14,11 @@ func SetupSwaggerRouter(r *gin.Engine) {
 	// Swagger文档路由
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// 接口定义（OpenAPI 2.0），与 OPENAPI_VALIDATE_* 校验使用的是同一份
+	r.GET("/api/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(docs.SwaggerInfo.ReadDoc()))
+	})
+
 	// 根路径重定向到swagger文档
 	r.GET("/", func(c *gin.Context) {
 		c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
@@ -0,0 +1,13 @@
+package router
+
+import (
+	"backend/app/server/ws"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetupWebSocketRouter 设置 WebSocket 路由
+// 连接建立后在消息中认证（浏览器不能设置 WebSocket 请求头），不经过认证中间件
+func SetupWebSocketRouter(r *gin.Engine, handler *ws.Handler) {
+	r.GET("/api/ws/tasks", handler.Serve)
+}
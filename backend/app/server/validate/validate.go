@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"backend/app/types/meta"
+	"backend/utils/bind"
+	"backend/utils/errorx"
+	"backend/utils/timex"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// hexColorPattern 十六进制颜色: #RGB、#RRGGBB、#RRGGBBAA
+var hexColorPattern = regexp.MustCompile(`^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+// RegisterValidators 注册业务自定义验证规则
+// 需要在处理请求前调用
+func RegisterValidators() error {
+	statuses := make([]string, 0, len(meta.ItemStatuses))
+	for _, status := range meta.ItemStatuses {
+		statuses = append(statuses, string(status))
+	}
+
+	validators := []struct {
+		tag       string
+		fn        validator.Func
+		message   string
+		messageEN string
+	}{
+		{"hexcolor", validateHexColor, "必须是十六进制颜色，如 #1E90FF", "must be a hex color, e.g. #1E90FF"},
+		{"dateformat", validateDateFormat, "日期格式不正确，支持 YYYY-MM-DD、YYYY-MM-DD HH:MM:SS、RFC3339 或 today、last-7-days 等相对日期", "invalid date, supported formats: YYYY-MM-DD, YYYY-MM-DD HH:MM:SS, RFC3339 or relative dates such as today, last-7-days"},
+		{"itemstatus", validateItemStatus, "必须是以下值之一: " + strings.Join(statuses, " "), "must be one of: " + strings.Join(statuses, " ")},
+	}
+
+	for _, v := range validators {
+		if err := bind.RegisterValidator(v.tag, v.fn, v.message, errorx.L(errorx.LocaleEN, v.messageEN)); err != nil {
+			return fmt.Errorf("注册验证规则 %s 失败: %w", v.tag, err)
+		}
+	}
+	return nil
+}
+
+// validateHexColor 验证十六进制颜色（标签颜色）
+func validateHexColor(fl validator.FieldLevel) bool {
+	return hexColorPattern.MatchString(fl.Field().String())
+}
+
+// validateDateFormat 验证日期字符串（查询参数中的日期），支持相对日期
+func validateDateFormat(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+	if timex.IsRelativeDate(value) {
+		return true
+	}
+	_, err := timex.ParseDateString(value)
+	return err == nil
+}
+
+// validateItemStatus 验证项目状态
+func validateItemStatus(fl validator.FieldLevel) bool {
+	return meta.ItemStatus(fl.Field().String()).Valid()
+}
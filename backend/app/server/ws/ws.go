@@ -0,0 +1,339 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"backend/app/server/middleware"
+	"backend/utils/errorx"
+	"backend/utils/handle"
+	"backend/utils/logs"
+	"backend/utils/rand"
+	"backend/utils/sse"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// authTimeout 握手时没有带 Authorization 请求头的连接，需要在该时长内发送 auth 消息
+	authTimeout = 10 * time.Second
+	// writeTimeout 写入一条消息的超时时间
+	writeTimeout = 10 * time.Second
+	// maxMessageSize 客户端消息的最大字节数
+	maxMessageSize = 4096
+	// DefaultPingInterval 默认的 ping 间隔，超过两个间隔没有收到 pong 时断开连接
+	DefaultPingInterval = 30 * time.Second
+)
+
+// 消息类型
+const (
+	TypeAuth        = "auth"        // 客户端：认证，token 为 access token
+	TypeSubscribe   = "subscribe"   // 客户端：按 resume_key 订阅任务，从 last_event_id 之后续传
+	TypeUnsubscribe = "unsubscribe" // 客户端：取消订阅
+	TypeReady       = "ready"       // 服务端：认证通过，可以订阅任务
+	TypeSubscribed  = "subscribed"  // 服务端：订阅成功
+	TypeEvent       = "event"       // 服务端：任务数据，与 SSE 的一个事件对应
+	TypeDone        = "done"        // 服务端：任务结束，订阅随之结束
+	TypeError       = "error"       // 服务端：认证、订阅失败或订阅被中止
+)
+
+// TaskSubscriber 订阅 SSE 任务的数据，由 SSE 管理器实现
+type TaskSubscriber interface {
+	Subscribe(ctx context.Context, resumeKey string, lastEventID uint64, subscriberID string, opts ...sse.TaskOption) (<-chan sse.Event, string, error)
+}
+
+// ClientMessage 客户端发送的消息
+type ClientMessage struct {
+	Type        string `json:"type"`
+	Token       string `json:"token,omitempty"`         // auth：access token，不带 Bearer 前缀
+	ResumeKey   string `json:"resume_key,omitempty"`    // subscribe、unsubscribe：任务的 resumeKey
+	LastEventID uint64 `json:"last_event_id,omitempty"` // subscribe：最后收到的事件 ID，0 时补发全部数据
+}
+
+// ServerMessage 服务端推送的消息
+type ServerMessage struct {
+	Type      string `json:"type"`
+	TaskID    string `json:"task_id,omitempty"`
+	ResumeKey string `json:"resume_key,omitempty"`
+	ID        uint64 `json:"id,omitempty"`      // event：事件 ID，重连后作为 last_event_id；保活、排队数据没有事件 ID
+	Event     string `json:"event,omitempty"`   // event、error：与 SSE 的 event 行相同（alive、queued、server_shutdown），普通数据为 message
+	Data      any    `json:"data,omitempty"`    // event：任务推送的数据
+	Dropped   uint64 `json:"dropped,omitempty"` // event：该事件之前没有送达的数据条数
+	Code      int32  `json:"code,omitempty"`    // error：错误码
+	Error     string `json:"error,omitempty"`   // error：错误消息
+}
+
+// Handler 通过 WebSocket 推送 SSE 任务的数据，供不能使用 EventSource 的客户端（如移动端 WebView）使用
+// 与 SSE 接口订阅同一个任务管理器，按 resumeKey 续传，一个连接可以同时订阅多个任务
+type Handler struct {
+	tasks        TaskSubscriber
+	pingInterval time.Duration
+	upgrader     websocket.Upgrader
+}
+
+// NewHandler 创建 WebSocket 处理器，pingInterval 为 0 时使用 DefaultPingInterval
+// 握手时按 CORS_ALLOWED_ORIGINS 校验 Origin
+func NewHandler(tasks TaskSubscriber, pingInterval time.Duration) *Handler {
+	if pingInterval <= 0 {
+		pingInterval = DefaultPingInterval
+	}
+	return &Handler{
+		tasks:        tasks,
+		pingInterval: pingInterval,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool {
+				origin := r.Header.Get("Origin")
+				return origin == "" || middleware.IsOriginAllowed(origin)
+			},
+		},
+	}
+}
+
+// Serve 升级为 WebSocket 连接并处理客户端消息
+// 握手时带 Authorization 请求头的连接直接认证，否则需要在 authTimeout 内发送 auth 消息（浏览器不能设置 WebSocket 请求头）
+func (h *Handler) Serve(c *gin.Context) {
+	ctx := c.Request.Context()
+	authed := false
+	if header := c.GetHeader("Authorization"); header != "" {
+		authCtx, err := middleware.Authenticate(ctx, header)
+		if err != nil {
+			handle.HandleErrorWithContext(c, err, "WebSocket 认证", &handle.ErrorConfig{
+				DefaultStatusCode: http.StatusUnauthorized,
+			})
+			return
+		}
+		ctx, authed = authCtx, true
+	}
+
+	ws, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 已经返回错误响应
+		logs.CtxWarnf(ctx, "WebSocket 握手失败: error=%s", err.Error())
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	conn := &connection{
+		ws:     ws,
+		tasks:  h.tasks,
+		locale: handle.Locale(c),
+		cancel: cancel,
+		subs:   make(map[string]context.CancelFunc),
+	}
+	defer conn.close()
+
+	ws.SetReadLimit(maxMessageSize)
+	if !authed {
+		if ctx, authed = conn.authenticate(ctx); !authed {
+			return
+		}
+	}
+	if !conn.send(ServerMessage{Type: TypeReady}) {
+		return
+	}
+
+	// 超过两个 ping 间隔没有收到任何消息（包括 pong）时读取失败，连接结束
+	pongWait := 2 * h.pingInterval
+	_ = ws.SetReadDeadline(time.Now().Add(pongWait))
+	ws.SetPongHandler(func(string) error {
+		return ws.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	conn.wg.Add(1)
+	go conn.ping(ctx, h.pingInterval)
+
+	for {
+		var msg ClientMessage
+		if err := ws.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) && ctx.Err() == nil {
+				logs.CtxDebugf(ctx, "WebSocket 连接断开: error=%s", err.Error())
+			}
+			return
+		}
+		_ = ws.SetReadDeadline(time.Now().Add(pongWait))
+
+		switch msg.Type {
+		case TypeSubscribe:
+			conn.subscribe(ctx, msg.ResumeKey, msg.LastEventID)
+		case TypeUnsubscribe:
+			conn.unsubscribe(msg.ResumeKey)
+		case TypeAuth:
+			// 已认证的连接忽略重复的 auth 消息
+		default:
+			conn.send(ServerMessage{Type: TypeError, Error: "unknown message type: " + msg.Type})
+		}
+	}
+}
+
+// connection 一个 WebSocket 连接
+type connection struct {
+	ws      *websocket.Conn
+	tasks   TaskSubscriber
+	locale  string
+	cancel  context.CancelFunc
+	writeMu sync.Mutex // gorilla/websocket 同一时间只允许一个写入者
+	wg      sync.WaitGroup
+
+	mu   sync.Mutex
+	subs map[string]context.CancelFunc // 进行中的订阅（key: resumeKey）
+}
+
+// authenticate 读取第一条消息进行认证，失败时发送错误消息并返回 false
+func (conn *connection) authenticate(ctx context.Context) (context.Context, bool) {
+	_ = conn.ws.SetReadDeadline(time.Now().Add(authTimeout))
+	var msg ClientMessage
+	if err := conn.ws.ReadJSON(&msg); err != nil {
+		logs.CtxDebugf(ctx, "等待 WebSocket 认证失败: error=%s", err.Error())
+		return ctx, false
+	}
+	if msg.Type != TypeAuth {
+		conn.sendError("", "", errors.New("first message must be auth"))
+		return ctx, false
+	}
+	authCtx, err := middleware.Authenticate(ctx, "Bearer "+msg.Token)
+	if err != nil {
+		logs.CtxWarnf(ctx, "WebSocket 认证失败: error=%s", errorx.ErrorWithoutStack(err))
+		conn.sendError("", "", err)
+		return ctx, false
+	}
+	return authCtx, true
+}
+
+// subscribe 订阅任务并转发数据，重复订阅同一个任务时先取消之前的订阅
+func (conn *connection) subscribe(ctx context.Context, resumeKey string, lastEventID uint64) {
+	conn.unsubscribe(resumeKey)
+
+	subCtx, cancel := context.WithCancel(ctx)
+	ch, taskID, err := conn.tasks.Subscribe(subCtx, resumeKey, lastEventID, rand.MustGenerateUIDWithPrefix("ws_"))
+	if err != nil {
+		cancel()
+		conn.sendError(resumeKey, "", err)
+		return
+	}
+	conn.mu.Lock()
+	conn.subs[resumeKey] = cancel
+	conn.mu.Unlock()
+	conn.send(ServerMessage{Type: TypeSubscribed, TaskID: taskID, ResumeKey: resumeKey})
+
+	conn.wg.Add(1)
+	go func() {
+		defer conn.wg.Done()
+		defer conn.remove(resumeKey, subCtx)
+
+		for event := range ch {
+			if event.Err != nil {
+				conn.sendError(resumeKey, taskID, event.Err)
+				// 服务关闭时 HTTP 服务器不会关闭已升级的连接，由这里结束
+				if errors.Is(event.Err, sse.ErrServerShutdown) {
+					conn.closeWith(websocket.CloseGoingAway, sse.ShutdownEventName)
+				}
+				return
+			}
+			name := event.SSEEventName()
+			if name == "" {
+				name = "message"
+			}
+			if !conn.send(ServerMessage{
+				Type:      TypeEvent,
+				TaskID:    taskID,
+				ResumeKey: resumeKey,
+				ID:        event.ID,
+				Event:     name,
+				Data:      event.SSEData(),
+				Dropped:   event.Dropped,
+			}) {
+				return
+			}
+		}
+		// 取消订阅或连接断开时通道也会关闭，只有任务结束时发送 done
+		if subCtx.Err() == nil {
+			conn.send(ServerMessage{Type: TypeDone, TaskID: taskID, ResumeKey: resumeKey})
+		}
+	}()
+}
+
+// unsubscribe 取消订阅，转发 goroutine 随通道关闭退出
+func (conn *connection) unsubscribe(resumeKey string) {
+	conn.mu.Lock()
+	cancel, ok := conn.subs[resumeKey]
+	delete(conn.subs, resumeKey)
+	conn.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
+
+// remove 任务结束后移除订阅
+// 被取消的订阅已经从 subs 中移除，重复订阅时 subs 中是新的订阅，都不能再移除
+func (conn *connection) remove(resumeKey string, subCtx context.Context) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if subCtx.Err() != nil {
+		return
+	}
+	conn.subs[resumeKey]()
+	delete(conn.subs, resumeKey)
+}
+
+// ping 定时发送 ping，客户端回复 pong 后延长读取超时
+func (conn *connection) ping(ctx context.Context, interval time.Duration) {
+	defer conn.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := conn.ws.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				conn.cancel()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// send 发送一条消息，写入失败时结束连接
+func (conn *connection) send(msg ServerMessage) bool {
+	conn.writeMu.Lock()
+	defer conn.writeMu.Unlock()
+	_ = conn.ws.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if err := conn.ws.WriteJSON(msg); err != nil {
+		conn.cancel()
+		_ = conn.ws.Close()
+		return false
+	}
+	return true
+}
+
+// sendError 发送错误消息，错误为 errorx 错误时带上错误码和翻译后的消息
+func (conn *connection) sendError(resumeKey, taskID string, err error) {
+	msg := ServerMessage{Type: TypeError, TaskID: taskID, ResumeKey: resumeKey, Error: err.Error()}
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		msg.Code = statusErr.Code()
+		msg.Error = errorx.LocalizedMsg(err, conn.locale)
+	}
+	if errors.Is(err, sse.ErrServerShutdown) {
+		msg.Event = sse.ShutdownEventName
+	}
+	conn.send(msg)
+}
+
+// closeWith 发送关闭帧后关闭连接，读取循环随之结束
+func (conn *connection) closeWith(code int, text string) {
+	conn.writeMu.Lock()
+	_ = conn.ws.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, text), time.Now().Add(writeTimeout))
+	conn.writeMu.Unlock()
+	conn.cancel()
+	_ = conn.ws.Close()
+}
+
+// close 连接结束时取消所有订阅，等待转发 goroutine 退出
+func (conn *connection) close() {
+	conn.cancel()
+	_ = conn.ws.Close()
+	conn.wg.Wait()
+}
@@ -1,5 +1,20 @@
 package consts
 
+// 配置文件环境变量名
+const (
+	// ConfigFile 配置文件路径（YAML/TOML），启动参数 -config 优先
+	// 配置文件中的值只填充未设置的环境变量，环境变量优先
+	// 默认值: 空（不加载配置文件）
+	ConfigFile = "CONFIG_FILE"
+
+	// ConfigWatchInterval 检查 .env 和配置文件是否修改的间隔，修改后重新加载动态配置
+	// 动态配置: LOG_LEVEL, CORS_ALLOWED_ORIGINS, READ_ONLY, READ_ONLY_REASON，其他配置修改后需要重启
+	// 收到 SIGHUP 信号时也会重新加载；设置为 0 时只在收到 SIGHUP 时重新加载
+	// 支持 30s、1m 等格式，纯数字视为秒
+	// 默认值: 30s
+	ConfigWatchInterval = "CONFIG_WATCH_INTERVAL"
+)
+
 // HTTP 配置环境变量名
 const (
 	// HTTPPort HTTP 端口
@@ -13,6 +28,42 @@ const (
 
 	// AdminPassword 管理员密码
 	AdminPassword = "ADMIN_PASSWORD"
+
+	// AdminUserIDs 拥有管理权限的用户ID（逗号分隔），可访问审计日志、后台任务等管理接口，支持运行时重新加载
+	// 默认值: 1（空数据库启动时由 ADMIN_USERNAME 创建的管理员账户）
+	AdminUserIDs = "ADMIN_USER_IDS"
+
+	// CORSAllowedOrigins 允许跨域的源（逗号分隔），支持运行时重新加载
+	// 例如: https://app.example.com,https://admin.example.com
+	// 默认值: 空（允许所有源）
+	CORSAllowedOrigins = "CORS_ALLOWED_ORIGINS"
+
+	// ErrorResponseFormat 错误响应格式
+	// legacy 为 {code, message}，problem 为 RFC 7807 application/problem+json
+	// 可选值: legacy, problem
+	// 默认值: legacy
+	ErrorResponseFormat = "ERROR_RESPONSE_FORMAT"
+
+	// ResponseFormats 允许按 Accept 请求头协商的响应格式（逗号分隔）
+	// JSON 始终可用且为默认格式，启用 xml、msgpack 后请求 Accept: application/xml 等可获得对应格式
+	// 可选值: json, xml, msgpack
+	// 默认值: json
+	ResponseFormats = "RESPONSE_FORMATS"
+)
+
+// gRPC 配置环境变量名
+const (
+	// GRPCEnabled 是否启动 gRPC 服务（同一端口同时提供 grpc-gateway 的 HTTP/JSON 接口）
+	// 默认值: false
+	GRPCEnabled = "GRPC_ENABLED"
+
+	// GRPCPort gRPC 端口，不能与 HTTP_PORT 相同
+	// 默认值: 9090
+	GRPCPort = "GRPC_PORT"
+
+	// GRPCReflection 是否启用 gRPC 服务反射（grpcurl 等工具使用）
+	// 默认值: false
+	GRPCReflection = "GRPC_REFLECTION"
 )
 
 // Storage 存储配置环境变量名
@@ -47,10 +98,18 @@ const (
 	EnvLogLevel = "LOG_LEVEL"
 
 	// EnvLogOutput 日志输出方式环境变量名
-	// 可选值: console, json
+	// 可选值: console, json, loki（stdout 输出 JSON，同时推送到 Loki）
 	// 默认值: console
 	EnvLogOutput = "LOG_OUTPUT"
 
+	// EnvLogSchema JSON 日志（stdout、日志文件、Loki）的字段命名方式
+	// zap: ts、level、msg、caller
+	// ecs: Elastic Common Schema（@timestamp、log.level、message、log.origin.file.name）
+	// otel: OpenTelemetry 日志数据模型（timestamp、severity_text、body、code.filepath）
+	// 可选值: zap, ecs, otel
+	// 默认值: zap
+	EnvLogSchema = "LOG_SCHEMA"
+
 	// EnvLogDevelopment 是否为开发模式环境变量名
 	// 可选值: true, false
 	// 默认值: false
@@ -80,6 +139,143 @@ const (
 	// 可选值: true, false
 	// 默认值: true
 	EnvLogCompress = "LOG_COMPRESS"
+
+	// EnvLogErrorStack 是否在创建错误时捕获调用堆栈
+	// 开启后 error 级别的错误日志会附带结构化的 stack 字段，生产环境可关闭以减少开销
+	// 可选值: true, false
+	// 默认值: true
+	EnvLogErrorStack = "LOG_ERROR_STACK"
+
+	// EnvLogErrorStackDepth 创建错误时最多捕获的堆栈帧数，热点路径上可调小以减少开销
+	// 默认值: 32
+	EnvLogErrorStackDepth = "LOG_ERROR_STACK_DEPTH"
+
+	// EnvLogSamplingDebug debug 日志的采样规则，格式为 initial/thereafter
+	// 每秒内相同消息的日志前 initial 条全部输出，之后每 thereafter 条输出 1 条
+	// 默认值: 空（不采样）
+	EnvLogSamplingDebug = "LOG_SAMPLING_DEBUG"
+
+	// EnvLogSamplingInfo info 日志的采样规则，格式同 LOG_SAMPLING_DEBUG
+	// 默认值: 空（不采样）
+	EnvLogSamplingInfo = "LOG_SAMPLING_INFO"
+
+	// EnvLogSamplingWarn warn 日志的采样规则，格式同 LOG_SAMPLING_DEBUG，error 及以上级别从不采样
+	// 默认值: 空（不采样）
+	EnvLogSamplingWarn = "LOG_SAMPLING_WARN"
+
+	// EnvLogLokiURL LOG_OUTPUT=loki 时的 Loki 地址，如 http://loki:3100，
+	// 只写地址时自动补全推送接口路径 /loki/api/v1/push，可以带 user:password 进行 Basic 认证
+	EnvLogLokiURL = "LOG_LOKI_URL"
+
+	// EnvLogLokiTenant Loki 多租户时的租户 ID（X-Scope-OrgID）
+	// 默认值: 空
+	EnvLogLokiTenant = "LOG_LOKI_TENANT"
+
+	// EnvLogLokiService 日志流的 service 标签
+	// 默认值: backend
+	EnvLogLokiService = "LOG_LOKI_SERVICE"
+
+	// EnvLogLokiEnv 日志流的 env 标签
+	// 默认值: production
+	EnvLogLokiEnv = "LOG_LOKI_ENV"
+
+	// EnvLogLokiBatchSize 每批最多推送的日志条数
+	// 默认值: 500
+	EnvLogLokiBatchSize = "LOG_LOKI_BATCH_SIZE"
+
+	// EnvLogLokiBatchWait 不满一批时最长等待时间
+	// 默认值: 1s
+	EnvLogLokiBatchWait = "LOG_LOKI_BATCH_WAIT"
+
+	// EnvLogAsync 是否异步写入 stdout 和日志文件，开启后记录日志时不等待 I/O
+	// 服务停止时写入剩余的日志，进程被强制结束（如 SIGKILL）时可能丢失最后的日志
+	// 可选值: true, false
+	// 默认值: false
+	EnvLogAsync = "LOG_ASYNC"
+
+	// EnvLogAsyncQueueSize 异步写入时队列可以容纳的日志条数，队列满时记录日志会等待
+	// 默认值: 8192
+	EnvLogAsyncQueueSize = "LOG_ASYNC_QUEUE_SIZE"
+
+	// EnvLogAsyncFlushInterval 异步写入时缓冲区写入 stdout 和日志文件的间隔
+	// 默认值: 1s
+	EnvLogAsyncFlushInterval = "LOG_ASYNC_FLUSH_INTERVAL"
+
+	// EnvLogRedactKeys 额外需要脱敏的字段名，逗号分隔，忽略大小写
+	// 默认脱敏 password、token、authorization、refresh_token、secret 及以 _ 连接的后缀相同的字段（如 access_token）
+	// 默认值: 空
+	EnvLogRedactKeys = "LOG_REDACT_KEYS"
+
+	// APILogSkipPaths 不记录请求日志的路径，逗号分隔，如 /health,/metrics；返回 5xx 时仍然记录
+	// 默认值: 空
+	APILogSkipPaths = "API_LOG_SKIP_PATHS"
+
+	// APILogSlowThreshold 慢请求阈值，耗时超过时请求日志使用 warn 级别并附带 slow 字段，为 0 时不区分
+	// 默认值: 1s
+	APILogSlowThreshold = "API_LOG_SLOW_THRESHOLD"
+)
+
+// OpenTelemetry 链路追踪环境变量名
+const (
+	// OTelEndpoint OTLP/HTTP 导出地址（如 http://localhost:4318），未配置时不创建 OpenTelemetry span
+	OTelEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	// OTelHeaders 导出请求附带的请求头，格式: key1=value1,key2=value2
+	OTelHeaders = "OTEL_EXPORTER_OTLP_HEADERS"
+	// OTelServiceName 服务名称，对应资源属性 service.name
+	// 默认值: backend
+	OTelServiceName = "OTEL_SERVICE_NAME"
+	// OTelSampleRatio 新链路的采样比例（0-1），上游已采样的链路始终采样
+	// 默认值: 1
+	OTelSampleRatio = "OTEL_TRACES_SAMPLER_ARG"
+)
+
+// Sentry 错误上报环境变量名
+const (
+	// SentryDSN 项目 DSN，未配置时不上报
+	SentryDSN = "SENTRY_DSN"
+	// SentryEnvironment 环境名称
+	// 默认值: production
+	SentryEnvironment = "SENTRY_ENVIRONMENT"
+	// SentryRelease 版本号
+	// 默认值: 构建时注入的版本号
+	SentryRelease = "SENTRY_RELEASE"
+	// SentryQueueSize 上报队列长度，队列满时丢弃
+	// 默认值: 100
+	SentryQueueSize = "SENTRY_QUEUE_SIZE"
+)
+
+// 启动配置环境变量名
+const (
+	// StartupTimeout 应用启动的最长时间，超过后启动失败退出
+	// 支持 30s、1m 等格式，纯数字视为秒
+	// 默认值: 60s
+	StartupTimeout = "STARTUP_TIMEOUT"
+
+	// StartupRetryMaxElapsed 启动时连接依赖服务（数据库、Redis、ElasticSearch）的最长重试时间
+	// 设置为 0 表示不重试
+	// 默认值: 30s
+	StartupRetryMaxElapsed = "STARTUP_RETRY_MAX_ELAPSED"
+
+	// StartupRetryInitialInterval 首次重试前的等待时间，之后每次翻倍
+	// 默认值: 500ms
+	StartupRetryInitialInterval = "STARTUP_RETRY_INITIAL_INTERVAL"
+
+	// StartupRetryMaxInterval 单次重试等待时间上限
+	// 默认值: 5s
+	StartupRetryMaxInterval = "STARTUP_RETRY_MAX_INTERVAL"
+)
+
+// 运行模式配置环境变量名
+const (
+	// ReadOnly 是否以只读模式运行
+	// 只读模式下数据层拒绝写入，写请求返回 503，适用于备份、迁移和副本切换期间
+	// 可选值: true, false
+	// 默认值: false
+	ReadOnly = "READ_ONLY"
+
+	// ReadOnlyReason 只读原因，会返回给被拒绝的写请求
+	// 默认值: maintenance
+	ReadOnlyReason = "READ_ONLY_REASON"
 )
 
 // SQLite 数据库配置环境变量名
@@ -112,4 +308,531 @@ const (
 	// SQLiteSlowQueryThreshold 慢查询阈值（毫秒）
 	// 默认值: 200
 	SQLiteSlowQueryThreshold = "SQLITE_SLOW_QUERY_THRESHOLD"
+
+	// SQLiteEnableQueryTrace 是否启用查询追踪
+	// 启用后每条 SQL 的语句、耗时和影响行数会带 trace_id 记录到日志
+	// 可选值: true, false
+	// 默认值: false
+	SQLiteEnableQueryTrace = "SQLITE_ENABLE_QUERY_TRACE"
+
+	// SQLiteExplainEnabled 是否对慢的列表/搜索查询输出执行计划
+	// 启用后被标记的查询超过阈值时，会以 warn 级别记录 EXPLAIN QUERY PLAN 结果，便于发现缺失的索引
+	// 可选值: true, false
+	// 默认值: false
+	SQLiteExplainEnabled = "SQLITE_EXPLAIN_ENABLED"
+
+	// SQLiteExplainThreshold 输出执行计划的耗时阈值（毫秒）
+	// 默认值: 100
+	SQLiteExplainThreshold = "SQLITE_EXPLAIN_THRESHOLD"
+
+	// SQLitePoolStatsInterval 连接池统计日志输出间隔（秒）
+	// 设置为 0 表示不输出
+	// 默认值: 60
+	SQLitePoolStatsInterval = "SQLITE_POOL_STATS_INTERVAL"
+
+	// SQLiteLitestreamEnabled 是否与 Litestream 协同
+	// 启用后数据库使用 WAL 模式并设置 busy_timeout，检查点只使用 PASSIVE 模式，避免截断 Litestream 尚未复制的 WAL
+	// 可选值: true, false
+	// 默认值: false
+	SQLiteLitestreamEnabled = "SQLITE_LITESTREAM_ENABLED"
+
+	// SQLiteWALCheckpointInterval WAL 检查点间隔（秒）
+	// 设置为 0 表示不主动执行检查点（由 SQLite 自动检查点或 Litestream 负责）
+	// 默认值: 0
+	SQLiteWALCheckpointInterval = "SQLITE_WAL_CHECKPOINT_INTERVAL"
+
+	// SQLiteRestoreCommand 数据库文件不存在或为空时执行的恢复命令（通过 sh -c 执行）
+	// {db} 会被替换为数据库文件路径
+	// 例如: litestream restore -if-replica-exists -o {db} s3://bucket/data.db
+	// 默认值: 空（不恢复）
+	SQLiteRestoreCommand = "SQLITE_RESTORE_COMMAND"
+
+	// DBBatchInsertSize 批量插入时每批的记录数
+	// 大批量导入会拆分为多条 INSERT 在同一事务中执行，避免超出 SQLite 参数个数上限
+	// 默认值: 100
+	DBBatchInsertSize = "DB_BATCH_INSERT_SIZE"
+
+	// ColumnEncryptKey 数据库字段加密密钥（AES-GCM）
+	// 使用 serializer:encrypt 标记的字段会以密文存储
+	// 取值为 32 字节密钥的 base64 或十六进制编码，可通过 openssl rand -base64 32 生成，无法解码为 32 字节时启动失败
+	// 轮换密钥时使用 id:key 逗号分隔的多个密钥，第一个用于加密，其余只用于解密历史数据
+	// 如: k2:<新密钥>,default:<旧密钥>（单个密钥不带 id 时 id 为 default）
+	// 默认值: 空（未配置时写入加密字段会报错）
+	ColumnEncryptKey = "COLUMN_ENCRYPT_KEY"
+)
+
+// 数据保留配置环境变量名
+const (
+	// RetentionEnabled 是否启用数据保留定时任务
+	// 可选值: true, false
+	// 默认值: false
+	RetentionEnabled = "RETENTION_ENABLED"
+
+	// RetentionInterval 数据保留任务执行间隔（支持 1h、24h 等格式，纯数字视为秒）
+	// 默认值: 24h
+	RetentionInterval = "RETENTION_INTERVAL"
+
+	// RetentionDryRun 是否只统计待清理的数据而不删除
+	// 可选值: true, false
+	// 默认值: false
+	RetentionDryRun = "RETENTION_DRY_RUN"
+
+	// RetentionSoftDeletedDays 软删除数据的保留天数，超过后彻底删除
+	// 设置为 0 表示不清理
+	// 默认值: 30
+	RetentionSoftDeletedDays = "RETENTION_SOFT_DELETED_DAYS"
+
+	// RetentionAuditLogDays 审计日志的保留天数
+	// 设置为 0 表示不清理
+	// 默认值: 90
+	RetentionAuditLogDays = "RETENTION_AUDIT_LOG_DAYS"
+
+	// RetentionQueueJobDays 已完成（成功或死信）的后台任务保留天数
+	// 设置为 0 表示不清理
+	// 默认值: 7
+	RetentionQueueJobDays = "RETENTION_QUEUE_JOB_DAYS"
+
+	// RetentionWebhookDeliveryDays webhook 投递记录的保留天数
+	// 设置为 0 表示不清理
+	// 默认值: 30
+	RetentionWebhookDeliveryDays = "RETENTION_WEBHOOK_DELIVERY_DAYS"
+
+	// RetentionSyncChangeDays 同步变更记录的保留天数，超过后客户端无法从断线处增量同步，需要重新全量加载
+	// 设置为 0 表示不清理
+	// 默认值: 7
+	RetentionSyncChangeDays = "RETENTION_SYNC_CHANGE_DAYS"
+)
+
+// 定时任务配置环境变量名
+const (
+	// SchedulerEnabled 是否按计划执行定时任务
+	// 多实例部署时只在一个实例上启用，其余实例仍可通过管理接口手动触发任务
+	// 可选值: true, false
+	// 默认值: true
+	SchedulerEnabled = "SCHEDULER_ENABLED"
+
+	// SchedulerSchedules 覆盖任务的默认调度表达式，格式为 任务名=表达式，多个用分号分隔
+	// 表达式支持 5 段 cron（分 时 日 月 周）、@daily 等预定义表达式、@every 1h 和 off（只能手动触发）
+	// 数据库 system_config 中的 scheduler.<任务名>.spec 优先级更高
+	// 示例: db_backup=0 3 * * *;file_gc=@daily
+	// 默认值: 空（使用任务默认值）
+	SchedulerSchedules = "SCHEDULER_SCHEDULES"
+
+	// SchedulerTimezone cron 表达式使用的时区，如 Asia/Shanghai
+	// 默认值: 空（使用系统时区）
+	SchedulerTimezone = "SCHEDULER_TIMEZONE"
+
+	// BackupDir 数据库备份目录（db_backup 任务，仅支持 SQLite）
+	// 默认值: ./backups
+	BackupDir = "BACKUP_DIR"
+
+	// BackupKeep 保留的备份文件数量，超出时删除最早的备份
+	// 默认值: 7
+	BackupKeep = "BACKUP_KEEP"
+
+	// FileGCGrace 文件清理（file_gc 任务）的宽限期，只删除早于该时长且没有数据库记录的存储文件
+	// 默认值: 24h
+	FileGCGrace = "FILE_GC_GRACE"
+)
+
+// 后台任务队列配置环境变量名
+const (
+	// JobQueueEnabled 是否在本实例启动任务队列的工作协程
+	// 关闭后仍可入队，任务由其他启用的实例执行
+	// 可选值: true, false
+	// 默认值: true
+	JobQueueEnabled = "JOB_QUEUE_ENABLED"
+
+	// JobQueueWorkers 同时执行的任务数
+	// 默认值: 4
+	JobQueueWorkers = "JOB_QUEUE_WORKERS"
+
+	// JobQueuePollInterval 查询待执行任务的间隔（本实例入队时会立即唤醒）
+	// 默认值: 1s
+	JobQueuePollInterval = "JOB_QUEUE_POLL_INTERVAL"
+
+	// JobQueueLease 任务租约时长，也是单次执行的超时时间
+	// 进程退出后，执行中的任务在租约过期后被重新领取
+	// 默认值: 10m
+	JobQueueLease = "JOB_QUEUE_LEASE"
+
+	// JobQueueMaxAttempts 任务最大执行次数（包括首次执行），超过后转为死信
+	// 默认值: 5
+	JobQueueMaxAttempts = "JOB_QUEUE_MAX_ATTEMPTS"
+
+	// JobQueueRetryInterval 首次重试的等待时间，之后每次翻倍，最长 1h
+	// 默认值: 10s
+	JobQueueRetryInterval = "JOB_QUEUE_RETRY_INTERVAL"
+)
+
+// Webhook 配置环境变量名
+const (
+	// WebhookTimeout 投递 webhook 的请求超时时间
+	// 默认值: 10s
+	WebhookTimeout = "WEBHOOK_TIMEOUT"
+
+	// WebhookMaxAttempts 每次投递的最大请求次数（包括首次请求），失败后通过后台任务队列按退避重试
+	// 默认值: 8
+	WebhookMaxAttempts = "WEBHOOK_MAX_ATTEMPTS"
+
+	// WebhookAllowPrivateNetwork 是否允许投递到回环、内网等非公网地址
+	// 关闭时在建立连接时检查解析后的 IP，防止通过回调地址访问内部服务
+	// 可选值: true, false
+	// 默认值: false
+	WebhookAllowPrivateNetwork = "WEBHOOK_ALLOW_PRIVATE_NETWORK"
+)
+
+// 邮件配置环境变量名
+const (
+	// SMTPHost SMTP 服务器地址
+	// 未配置时邮件只记录日志不发送
+	// 默认值: 空
+	SMTPHost = "SMTP_HOST"
+
+	// SMTPPort SMTP 服务器端口
+	// 默认值: 587
+	SMTPPort = "SMTP_PORT"
+
+	// SMTPUsername SMTP 认证用户名（可选，为空时不认证）
+	SMTPUsername = "SMTP_USERNAME"
+
+	// SMTPPassword SMTP 认证密码（可选）
+	SMTPPassword = "SMTP_PASSWORD"
+
+	// SMTPFrom 发件人，如 Peano <noreply@example.com>
+	// 配置 SMTP_HOST 时必填
+	SMTPFrom = "SMTP_FROM"
+
+	// SMTPTLS 连接加密方式
+	// 可选值: starttls（587 端口）, tls（465 端口）, none（不加密，只用于本机调试）
+	// 默认值: starttls
+	SMTPTLS = "SMTP_TLS"
+
+	// SMTPTimeout 单封邮件的发送超时时间
+	// 默认值: 10s
+	SMTPTimeout = "SMTP_TIMEOUT"
+
+	// AppPublicURL 前端访问地址，用于生成邮件中的验证、重置密码链接
+	// 默认值: http://localhost:5174
+	AppPublicURL = "APP_PUBLIC_URL"
+
+	// EmailVerifyExpire 邮箱验证链接有效期
+	// 默认值: 24h
+	EmailVerifyExpire = "EMAIL_VERIFY_EXPIRE"
+
+	// PasswordResetExpire 重置密码链接有效期
+	// 默认值: 1h
+	PasswordResetExpire = "PASSWORD_RESET_EXPIRE"
+)
+
+// SSE 配置环境变量名
+const (
+	// SSETaskTTL SSE 任务的过期时间，过期任务无法续传
+	// 变更订阅（/api/sync/stream）的广播任务运行该时长后结束，客户端收到 done 事件后重连
+	// 默认值: 1h
+	SSETaskTTL = "SSE_TASK_TTL"
+
+	// SSEStore SSE 任务的存储方式
+	// memory 只能在单实例内续传；redis 在多个实例间共享任务、缓存数据和订阅者，
+	// 负载均衡后重连请求落到其他实例时也能续传，需要配置 REDIS_HOST
+	// 可选值: memory, redis
+	// 默认值: memory
+	SSEStore = "SSE_STORE"
+
+	// SSEMaxEvents 每个任务最多保存的数据条数，用于断线重连时补发，超出后按 SSE_EVICTION 淘汰
+	// 默认值: 0（不限制）
+	SSEMaxEvents = "SSE_MAX_EVENTS"
+
+	// SSEMaxEventBytes 每个任务最多保存的数据字节数（按 JSON 大小计算）
+	// 默认值: 0（不限制）
+	SSEMaxEventBytes = "SSE_MAX_EVENT_BYTES"
+
+	// SSEEviction 保存的数据超出上限时的淘汰策略
+	// oldest 淘汰最早的数据，只保留最近的数据；newest 不再保存新数据
+	// 被淘汰的数据仍会实时推送给在线的订阅者，只是重连后无法补发
+	// 可选值: oldest, newest
+	// 默认值: oldest
+	SSEEviction = "SSE_EVICTION"
+
+	// SSEKeepalive 订阅者超过该时长没有收到数据时发送一条 alive 事件（任务状态和已运行时长），
+	// 避免长时间计算的任务被代理按空闲连接断开
+	// 默认值: 0（不发送）
+	SSEKeepalive = "SSE_KEEPALIVE"
+
+	// SSEMaxConcurrentTasks 本实例同时执行的 SSE 任务数上限（数据导出），超出后按请求顺序排队，
+	// 排队期间推送 queued 事件（排队位置）；变更订阅和导入进度推送不受限制
+	// 默认值: 0（不限制）
+	SSEMaxConcurrentTasks = "SSE_MAX_CONCURRENT_TASKS"
+
+	// WSPingInterval WebSocket 订阅（/api/ws/tasks）发送 ping 的间隔，超过两个间隔没有收到 pong 时断开连接
+	// 默认值: 30s
+	WSPingInterval = "WS_PING_INTERVAL"
+)
+
+// 数据导出配置环境变量名
+const (
+	// ExportDir 账户数据导出文件（zip）的保存目录
+	// 默认值: ./exports
+	ExportDir = "EXPORT_DIR"
+
+	// ExportLinkExpire 导出文件下载链接的有效期，过期的导出文件在下次导出时删除
+	// 默认值: 24h
+	ExportLinkExpire = "EXPORT_LINK_EXPIRE"
+
+	// ExportTimeout 单次导出任务的超时时间
+	// 默认值: 10m
+	ExportTimeout = "EXPORT_TIMEOUT"
+)
+
+// 数据导入配置环境变量名
+const (
+	// ImportMaxSize 导入文件的最大大小（MB）
+	// 默认值: 10
+	ImportMaxSize = "IMPORT_MAX_SIZE"
+
+	// ImportMaxItems 单次导入的最大项目数
+	// 默认值: 5000
+	ImportMaxItems = "IMPORT_MAX_ITEMS"
+
+	// ImportTimeout 等待导入任务完成并推送进度的最长时间，导入任务本身受后台任务租约（JOB_QUEUE_LEASE）限制
+	// 默认值: 10m
+	ImportTimeout = "IMPORT_TIMEOUT"
+)
+
+// 审计日志配置环境变量名
+const (
+	// AuditExportMaxRows 审计日志单次导出 CSV 的最大行数，超过时需要缩小查询范围
+	// 审计日志的保留天数见 RETENTION_AUDIT_LOG_DAYS
+	// 默认值: 100000
+	AuditExportMaxRows = "AUDIT_EXPORT_MAX_ROWS"
+
+	// AuditLogFile 审计日志文件路径，配置后审计日志在写入数据库的同时写入该文件（每行一条 JSON），
+	// 与应用日志分开存放，按 LOG_MAX_SIZE 等配置轮转
+	// 默认值: 空（只写入数据库）
+	AuditLogFile = "AUDIT_LOG_FILE"
+)
+
+// 订阅配置环境变量名
+const (
+	// FeedCalendarMaxItems 日历订阅最多包含的项目数，超出时只保留截止时间最晚的项目
+	// 默认值: 500
+	FeedCalendarMaxItems = "FEED_CALENDAR_MAX_ITEMS"
+
+	// FeedAtomEnabled 是否启用最近项目订阅源（Atom）
+	// 可选值: true, false
+	// 默认值: false
+	FeedAtomEnabled = "FEED_ATOM_ENABLED"
+
+	// FeedAtomDays 订阅源包含最近多少天内创建或完成的项目
+	// 默认值: 7
+	FeedAtomDays = "FEED_ATOM_DAYS"
+
+	// FeedAtomMaxItems 订阅源最多包含的项目数
+	// 默认值: 50
+	FeedAtomMaxItems = "FEED_ATOM_MAX_ITEMS"
+)
+
+// 限流配置环境变量名
+const (
+	// RateLimitEnabled 是否启用接口限流
+	// 限流按用户（未登录时按 IP）计数，计数保存在内存中，多实例部署时每个实例单独计数
+	// 可选值: true, false
+	// 默认值: false
+	RateLimitEnabled = "RATE_LIMIT_ENABLED"
+
+	// RateLimitTiers 限流档位配置，格式: 档位:类别=次数/窗口,...;档位:...
+	// 类别: general（普通接口）, search（搜索）, export（导出）, email（测试邮件）
+	// 用户档位保存在用户记录上，未设置或档位不存在时使用 default 档位，档位未配置的类别使用 default 档位的规则
+	// 默认值: default:general=600/1m,search=60/1m,export=5/1h,email=10/1h;api-heavy:general=6000/1m,search=600/1m,export=20/1h,email=10/1h
+	RateLimitTiers = "RATE_LIMIT_TIERS"
+)
+
+// 接口定义校验配置环境变量名
+const (
+	// OpenAPIValidateRequests 是否按接口定义（/api/openapi.json）校验请求，不符合时返回 400
+	// 只校验接口定义中有的路由，接口定义之外的参数和字段忽略
+	// 可选值: true, false
+	// 默认值: false
+	OpenAPIValidateRequests = "OPENAPI_VALIDATE_REQUESTS"
+
+	// OpenAPIValidateResponses 是否按接口定义校验响应，不符合时替换为 500 错误响应
+	// 需要缓存整个响应，只在 GIN_MODE=debug 时生效，用于开发时发现实现与接口定义不一致
+	// 可选值: true, false
+	// 默认值: false
+	OpenAPIValidateResponses = "OPENAPI_VALIDATE_RESPONSES"
 )
+
+// Telegram 机器人配置环境变量名
+const (
+	// TelegramEnabled 是否启用 Telegram 机器人
+	// 启用后已绑定的用户可以通过机器人快速记录项目、查看今天的项目和标记完成
+	// 可选值: true, false
+	// 默认值: false
+	TelegramEnabled = "TELEGRAM_ENABLED"
+
+	// TelegramBotToken 机器人令牌（从 @BotFather 获取），启用时必填
+	TelegramBotToken = "TELEGRAM_BOT_TOKEN"
+
+	// TelegramBotUsername 机器人用户名（不含 @），配置后生成绑定码时返回 t.me 绑定链接
+	TelegramBotUsername = "TELEGRAM_BOT_USERNAME"
+
+	// TelegramMode 接收消息的方式
+	// 可选值: polling（长轮询，不需要公网地址）, webhook（Telegram 推送到 /api/telegram/webhook）
+	// 多实例部署时使用 webhook，长轮询只能由一个实例执行
+	// 默认值: polling
+	TelegramMode = "TELEGRAM_MODE"
+
+	// TelegramWebhookURL webhook 的公网地址（如 https://example.com/api/telegram/webhook）
+	// TELEGRAM_MODE=webhook 时配置后启动时自动调用 setWebhook，未配置时需要手动设置
+	TelegramWebhookURL = "TELEGRAM_WEBHOOK_URL"
+
+	// TelegramWebhookSecret webhook 密钥，Telegram 推送时携带在 X-Telegram-Bot-Api-Secret-Token 请求头中
+	// TELEGRAM_MODE=webhook 时必填
+	TelegramWebhookSecret = "TELEGRAM_WEBHOOK_SECRET"
+
+	// TelegramAPIURL Bot API 地址，使用自建 Bot API 服务器或代理时配置
+	// 默认值: https://api.telegram.org
+	TelegramAPIURL = "TELEGRAM_API_URL"
+
+	// TelegramLinkCodeTTL 绑定码有效期（支持 10m、1h 等格式，纯数字视为秒）
+	// 默认值: 10m
+	TelegramLinkCodeTTL = "TELEGRAM_LINK_CODE_TTL"
+
+	// TelegramTimezone 计算“今天”使用的时区，如 Asia/Shanghai
+	// 默认值: 服务器本地时区
+	TelegramTimezone = "TELEGRAM_TIMEZONE"
+)
+
+// 多租户配置环境变量名
+const (
+	// TenantEnabled 是否启用多租户
+	// 启用后所有请求都会解析租户ID，数据库读写自动按 tenant_id 隔离
+	// 可选值: true, false
+	// 默认值: false
+	TenantEnabled = "MULTI_TENANT_ENABLED"
+
+	// TenantResolveMode 租户解析方式
+	// 可选值: header（从请求头读取）, subdomain（从子域名读取，如 acme.example.com -> acme）
+	// 默认值: header
+	TenantResolveMode = "TENANT_RESOLVE_MODE"
+
+	// TenantHeader 租户请求头名称（TENANT_RESOLVE_MODE=header 时生效）
+	// 默认值: X-Tenant-ID
+	TenantHeader = "TENANT_HEADER"
+
+	// TenantDefault 默认租户ID，请求中未解析到租户时使用
+	// 默认值: 空（默认租户）
+	TenantDefault = "TENANT_DEFAULT"
+)
+
+// Redis 配置环境变量名
+const (
+	// RedisHost Redis 主机地址
+	// 未配置时不启用 Redis
+	// 默认值: 空
+	RedisHost = "REDIS_HOST"
+
+	// RedisPort Redis 端口
+	// 默认值: 6379
+	RedisPort = "REDIS_PORT"
+
+	// RedisPassword Redis 密码
+	// 默认值: 空
+	RedisPassword = "REDIS_PASSWORD"
+
+	// RedisDB Redis 数据库编号
+	// 默认值: 0
+	RedisDB = "REDIS_DB"
+
+	// RedisPoolSize 连接池大小
+	// 默认值: 10
+	RedisPoolSize = "REDIS_POOL_SIZE"
+
+	// RedisMinIdleConns 最小空闲连接数
+	// 默认值: 0
+	RedisMinIdleConns = "REDIS_MIN_IDLE_CONNS"
+
+	// RedisMaxIdleConns 最大空闲连接数
+	// 默认值: 0（不限制）
+	RedisMaxIdleConns = "REDIS_MAX_IDLE_CONNS"
+
+	// RedisMaxRetries 最大重试次数
+	// 默认值: 3
+	RedisMaxRetries = "REDIS_MAX_RETRIES"
+
+	// RedisDialTimeout 连接超时时间（支持 5s、1m 等格式，纯数字视为秒）
+	// 默认值: 5s
+	RedisDialTimeout = "REDIS_DIAL_TIMEOUT"
+
+	// RedisReadTimeout 读取超时时间
+	// 默认值: 3s
+	RedisReadTimeout = "REDIS_READ_TIMEOUT"
+
+	// RedisWriteTimeout 写入超时时间
+	// 默认值: 3s
+	RedisWriteTimeout = "REDIS_WRITE_TIMEOUT"
+
+	// RedisPoolTimeout 从连接池获取连接的超时时间
+	// 默认值: 4s
+	RedisPoolTimeout = "REDIS_POOL_TIMEOUT"
+)
+
+// ElasticSearch 配置环境变量名
+const (
+	// ESAddresses ElasticSearch 服务器地址列表（逗号分隔）
+	// 未配置时不启用 ElasticSearch
+	// 默认值: 空
+	ESAddresses = "ES_ADDRESSES"
+
+	// ESUsername 用户名（可选）
+	ESUsername = "ES_USERNAME"
+
+	// ESPassword 密码（可选）
+	ESPassword = "ES_PASSWORD"
+
+	// ESCloudID Elastic Cloud ID（可选）
+	ESCloudID = "ES_CLOUD_ID"
+
+	// ESAPIKey API Key（可选，优先级高于用户名密码）
+	ESAPIKey = "ES_API_KEY"
+
+	// ESMaxRetries 最大重试次数
+	// 默认值: 3
+	ESMaxRetries = "ES_MAX_RETRIES"
+
+	// ESEnableRetryOnTimeout 是否在超时时重试
+	// 可选值: true, false
+	// 默认值: false
+	ESEnableRetryOnTimeout = "ES_ENABLE_RETRY_ON_TIMEOUT"
+
+	// ESEnableCompression 是否启用请求体压缩
+	// 可选值: true, false
+	// 默认值: false
+	ESEnableCompression = "ES_ENABLE_COMPRESSION"
+
+	// ESRequestTimeout 请求超时时间（支持 10s、1m 等格式，纯数字视为秒）
+	// 默认值: 10s
+	ESRequestTimeout = "ES_REQUEST_TIMEOUT"
+
+	// ESPingTimeout 连接检查超时时间
+	// 默认值: 5s
+	ESPingTimeout = "ES_PING_TIMEOUT"
+
+	// ESItemIndex 项目搜索索引名称（配置 ElasticSearch 后生效）
+	// 默认值: items
+	ESItemIndex = "ES_ITEM_INDEX"
+)
+
+// SecretFileKeys 支持通过 <KEY>_FILE 从文件读取的敏感配置（Docker/Kubernetes secret）
+// 例如 JWT_SECRET_FILE=/run/secrets/jwt_secret，同时设置 JWT_SECRET 时以 JWT_SECRET 为准
+var SecretFileKeys = []string{
+	JWTSecret,
+	AdminPassword,
+	ColumnEncryptKey,
+	SMTPPassword,
+	RedisPassword,
+	ESPassword,
+	ESAPIKey,
+	TelegramBotToken,
+	TelegramWebhookSecret,
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogDTO struct {
+	AuditID   uint            `json:"audit_id"`
+	UserID    uint            `json:"user_id"`                     // 操作人ID，未登录（如登录失败）时为 0
+	Entity    string          `json:"entity"`                      // user、item、tag、webhook、system
+	EntityID  uint            `json:"entity_id"`                   // 数据ID
+	Action    string          `json:"action"`                      // login、login_failed、token_refreshed、email_changed、password_reset、created、updated、deleted、config_changed
+	IP        string          `json:"ip"`                          // 客户端IP
+	Detail    json.RawMessage `json:"detail" swaggertype:"object"` // 操作详情，没有时为 null
+	CreatedAt time.Time       `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+type TelegramStatusDTO struct {
+	Enabled bool `json:"enabled"` // 是否启用了 Telegram 机器人
+	Linked  bool `json:"linked"`  // 当前用户是否已绑定
+}
+
+type TelegramLinkDTO struct {
+	Code      string    `json:"code"`               // 绑定码，发送 /link <绑定码> 给机器人完成绑定
+	ExpiresAt time.Time `json:"expires_at"`         // 绑定码过期时间
+	LinkURL   string    `json:"link_url,omitempty"` // 打开后自动发送绑定码的 t.me 链接，配置了机器人用户名时返回
+}
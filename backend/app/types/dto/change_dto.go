@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ChangeDTO struct {
+	Seq       uint            `json:"seq"`                       // 同步序号，只增不减，不同租户共用序号空间，因此不连续
+	Entity    string          `json:"entity"`                    // item、tag
+	EntityID  uint            `json:"entity_id"`                 // 数据ID
+	Action    string          `json:"action"`                    // created、updated、deleted
+	Data      json.RawMessage `json:"data" swaggertype:"object"` // 变更后的数据，删除时为 null
+	UserID    uint            `json:"user_id"`                   // 操作人ID
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type ChangeListDTO struct {
+	Changes []*ChangeDTO `json:"changes"`
+	Latest  uint         `json:"latest"`   // 本次返回的最后一个序号，下次请求作为 since
+	HasMore bool         `json:"has_more"` // 是否还有更多变更
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// ExportProgressDTO 账户数据导出进度
+type ExportProgressDTO struct {
+	TaskID      string     `json:"task_id"`                // 导出任务ID
+	Stage       string     `json:"stage"`                  // started、profile、items、tags、files、completed、failed
+	Items       int        `json:"items"`                  // 已导出的项目数
+	Tags        int        `json:"tags"`                   // 已导出的标签数
+	Files       int        `json:"files"`                  // 已导出的文件数
+	DownloadURL string     `json:"download_url,omitempty"` // 下载地址，completed 时返回
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`   // 下载地址过期时间，completed 时返回
+	Error       string     `json:"error,omitempty"`        // 失败原因，failed 时返回
+}
@@ -0,0 +1,8 @@
+package dto
+
+// FeedDTO 当前用户的订阅链接
+type FeedDTO struct {
+	Token       string `json:"token"`              // 订阅令牌，长期有效，重新生成后失效
+	CalendarURL string `json:"calendar_url"`       // 日历订阅地址（iCalendar）
+	AtomURL     string `json:"atom_url,omitempty"` // 最近项目订阅地址（Atom），未启用时为空
+}
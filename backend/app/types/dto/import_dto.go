@@ -0,0 +1,39 @@
+package dto
+
+// ImportPreviewDTO 导入预览，列出导入后将创建的项目和标签，不写入数据
+type ImportPreviewDTO struct {
+	Format       string          `json:"format"`        // 导入格式
+	Items        int             `json:"items"`         // 将创建的项目数
+	Done         int             `json:"done"`          // 其中已完成的项目数
+	Truncated    int             `json:"truncated"`     // 内容超长被截断的项目数
+	NewTags      []string        `json:"new_tags"`      // 将创建的标签（标签值）
+	ExistingTags []string        `json:"existing_tags"` // 已存在、直接使用的标签（标签值）
+	SkippedTotal int             `json:"skipped_total"` // 跳过的内容数
+	Skipped      []ImportSkipDTO `json:"skipped"`       // 跳过的内容及原因（最多返回 100 条）
+	Samples      []ImportItemDTO `json:"samples"`       // 前 20 个将创建的项目
+}
+
+// ImportItemDTO 将创建的项目
+type ImportItemDTO struct {
+	Source  string   `json:"source"`  // 来源位置，如 tasks.csv:3
+	Content string   `json:"content"` // 内容
+	Status  string   `json:"status"`  // 状态
+	Tags    []string `json:"tags"`    // 标签值
+}
+
+// ImportSkipDTO 跳过的内容
+type ImportSkipDTO struct {
+	Source string `json:"source"` // 来源位置
+	Reason string `json:"reason"` // 跳过原因
+}
+
+// ImportProgressDTO 导入进度
+type ImportProgressDTO struct {
+	TaskID string `json:"task_id"`         // 导入任务ID
+	JobID  uint   `json:"job_id"`          // 后台任务ID
+	Stage  string `json:"stage"`           // queued、tags、items、completed、failed
+	Total  int    `json:"total"`           // 项目总数
+	Items  int    `json:"items"`           // 已创建的项目数
+	Tags   int    `json:"tags"`            // 新创建的标签数
+	Error  string `json:"error,omitempty"` // 失败原因，failed 时返回
+}
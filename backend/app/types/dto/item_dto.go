@@ -3,12 +3,14 @@ package dto
 import "time"
 
 type ItemDTO struct {
-	ItemID    uint      `json:"item_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	Content   string    `json:"content"`
-	Status    string    `json:"status"`
-	Tags      []TagDTO  `json:"tags"`
+	ItemID    uint       `json:"item_id"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	Content   string     `json:"content"`
+	Status    string     `json:"status"`
+	DueAt     *time.Time `json:"due_at"`
+	Version   uint       `json:"version"`
+	Tags      []TagDTO   `json:"tags"`
 }
 
 type DailyItemCountDTO struct {
@@ -0,0 +1,12 @@
+package dto
+
+type NotificationPreferenceDTO struct {
+	EmailEnabled bool `json:"email_enabled"` // 是否接收通知邮件（不影响验证邮箱、重置密码等事务邮件）
+	DailyDigest  bool `json:"daily_digest"`  // 是否接收每日待办摘要
+}
+
+type DigestResultDTO struct {
+	Recipients int `json:"recipients"` // 开启摘要的用户数
+	Sent       int `json:"sent"`       // 加入发送队列的邮件数
+	Skipped    int `json:"skipped"`    // 没有待办项目而跳过的用户数
+}
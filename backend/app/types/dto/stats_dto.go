@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type AdminStatsDTO struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Users       int64           `json:"users"`
+	Items       ItemStatsDTO    `json:"items"`
+	Files       FileStatsDTO    `json:"files"`
+	SSETasks    SSETaskStatsDTO `json:"sse_tasks"`
+	Requests    RequestStatsDTO `json:"requests"`
+}
+
+type ItemStatsDTO struct {
+	Total    int64            `json:"total"`
+	ByStatus map[string]int64 `json:"by_status"` // key: 项目状态
+}
+
+type FileStatsDTO struct {
+	Total        int64 `json:"total"`
+	StorageBytes int64 `json:"storage_bytes"` // 文件记录的总字节数
+}
+
+type SSETaskStatsDTO struct {
+	Active int64            `json:"active"`  // 本实例正在运行的 SSE 任务数
+	ByKind map[string]int64 `json:"by_kind"` // key: 任务类型（export、import、sync）
+}
+
+type RequestStatsDTO struct {
+	Window            string  `json:"window"`              // 统计窗口，如 1h
+	WindowSeconds     int64   `json:"window_seconds"`      // 实际统计时长，进程启动不足一个窗口时为运行时长
+	Total             int64   `json:"total"`               // 请求数
+	ClientErrors      int64   `json:"client_errors"`       // 4xx 响应数
+	ServerErrors      int64   `json:"server_errors"`       // 5xx 响应数
+	RequestsPerMinute float64 `json:"requests_per_minute"` // 平均每分钟请求数
+	ErrorRate         float64 `json:"error_rate"`          // 5xx 响应占比（0-1）
+	ClientErrorRate   float64 `json:"client_error_rate"`   // 4xx 响应占比（0-1）
+}
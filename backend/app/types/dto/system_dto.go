@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type SystemInfoDTO struct {
+	Version       string    `json:"version"`
+	GitCommit     string    `json:"git_commit"`
+	BuildTime     string    `json:"build_time"`
+	GoVersion     string    `json:"go_version"`
+	SchemaVersion string    `json:"schema_version"`
+	DBDriver      string    `json:"db_driver"`
+	DBVersion     string    `json:"db_version"`
+	Mode          string    `json:"mode"`
+	StartedAt     time.Time `json:"started_at"`
+	UptimeSeconds int64     `json:"uptime_seconds"`
+}
+
+type JobStatusDTO struct {
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	Spec           string     `json:"spec"`    // 生效的调度表达式，为空表示只能手动触发
+	Running        bool       `json:"running"` // 是否正在执行
+	NextRunAt      *time.Time `json:"next_run_at"`
+	LastRunAt      *time.Time `json:"last_run_at"`
+	LastDurationMs int64      `json:"last_duration_ms"`
+	LastTrigger    string     `json:"last_trigger"` // schedule、manual
+	LastError      string     `json:"last_error"`
+	Runs           int64      `json:"runs"`
+	Failures       int64      `json:"failures"`
+	Skipped        int64      `json:"skipped"` // 因上一次执行未结束而跳过的次数
+}
+
+type BackupDTO struct {
+	Path      string    `json:"path"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type QueueJobDTO struct {
+	ID          uint            `json:"id"`
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload" swaggertype:"object"`
+	Status      string          `json:"status"`   // pending、running、succeeded、dead
+	Attempts    int             `json:"attempts"` // 已执行次数
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"` // 最早执行时间（等待重试时为下一次执行时间）
+	LastError   string          `json:"last_error"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  *time.Time      `json:"finished_at"`
+}
+
+// SSETaskDTO 本实例上的 SSE 任务（导出、导入、变更订阅），不包含断点续传标识和进度
+type SSETaskDTO struct {
+	TaskID        string    `json:"task_id"`
+	Kind          string    `json:"kind"`           // export、import、sync
+	Status        string    `json:"status"`         // running、completed、failed、cancelled
+	LastEventID   uint64    `json:"last_event_id"`  // 已推送的数据条数
+	DroppedEvents uint64    `json:"dropped_events"` // 超出保存上限、重连后无法补发的数据条数
+	Subscribers   int       `json:"subscribers"`    // 本实例上的订阅者数量
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type QueueStatsDTO struct {
+	Types     []string `json:"types"` // 已注册的任务类型
+	Pending   int64    `json:"pending"`
+	Running   int64    `json:"running"`
+	Succeeded int64    `json:"succeeded"`
+	Dead      int64    `json:"dead"`
+}
+
+// LogLevelDTO 当前的日志级别
+type LogLevelDTO struct {
+	Level string `json:"level" example:"info"` // debug、info、warn、error
+}
+
+// ErrorCodeDTO 错误码目录中的一项
+type ErrorCodeDTO struct {
+	Code       int32             `json:"code" example:"4000000"`              // 错误码
+	Messages   map[string]string `json:"messages"`                            // 各语言的消息模板，支持 {key} 占位符
+	HTTPStatus int               `json:"http_status,omitempty" example:"404"` // 默认 HTTP 状态码，未注册时不返回
+	Retryable  bool              `json:"retryable,omitempty"`                 // 是否可重试（如依赖服务暂时不可用），为 false 时不返回
+}
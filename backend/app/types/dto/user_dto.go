@@ -5,6 +5,11 @@ type UserDTO struct {
 	Username string
 	NickName string
 	Avatar   string
+
+	Email         string
+	EmailVerified bool
+
+	Locale string
 }
 
 type TokenDTO struct {
@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookDTO struct {
+	WebhookID   uint      `json:"webhook_id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret"` // 只在创建和更换密钥时返回完整密钥，其余情况为掩码
+	Events      []string  `json:"events"`
+	Description string    `json:"description"`
+	Enabled     bool      `json:"enabled"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WebhookDeliveryDTO struct {
+	DeliveryID     uint            `json:"delivery_id"`
+	WebhookID      uint            `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status"`   // pending、retrying、succeeded、failed
+	Attempts       int             `json:"attempts"` // 已请求次数
+	ResponseStatus int             `json:"response_status"`
+	ResponseBody   string          `json:"response_body"`
+	LastError      string          `json:"last_error"`
+	DurationMs     int64           `json:"duration_ms"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookEventDTO webhook 请求体
+type WebhookEventDTO struct {
+	Event     string    `json:"event"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
@@ -0,0 +1,38 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 审计日志错误码 (12000000-12000099)
+	AuditErrDatabaseError = int32(12000000) // 数据库错误
+	AuditErrInvalidParam  = int32(12000001) // 参数错误
+	AuditErrParamRequired = int32(12000002) // 缺少必填参数
+	AuditErrTooManyRows   = int32(12000003) // 导出行数超过上限
+)
+
+func init() {
+	// 注册审计日志错误码
+	errorx.RegisterBatch(map[int32]string{
+		AuditErrDatabaseError: "数据库错误: {reason}",
+		AuditErrInvalidParam:  "参数错误: {reason}",
+		AuditErrParamRequired: "缺少必填参数: {param}",
+		AuditErrTooManyRows:   "导出的审计日志共 {count} 条，超过上限 {max} 条，请缩小查询范围",
+	})
+
+	// 注册审计日志错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		AuditErrDatabaseError: "database error: {reason}",
+		AuditErrInvalidParam:  "invalid parameter: {reason}",
+		AuditErrParamRequired: "missing required parameter: {param}",
+		AuditErrTooManyRows:   "{count} audit log entries match, more than the export limit of {max}; narrow the filters",
+	})
+
+	// 注册审计日志错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		AuditErrDatabaseError: http.StatusInternalServerError,
+	})
+}
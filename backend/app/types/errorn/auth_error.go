@@ -1,6 +1,8 @@
 package errorn
 
 import (
+	"net/http"
+
 	"backend/utils/errorx"
 )
 
@@ -23,6 +25,13 @@ const (
 	AuthErrUserAlreadyExists = int32(2000014) // 用户已存在
 	AuthErrUserLocked        = int32(2000015) // 账号已被锁定
 	AuthErrUserUpdateFailed  = int32(2000016) // 更新用户信息失败
+	AuthErrInvalidParam      = int32(2000017) // 参数错误
+	AuthErrParamRequired     = int32(2000018) // 缺少必填参数
+	AuthErrPermissionDenied  = int32(2000019) // 没有权限
+	AuthErrEmailAlreadyUsed  = int32(2000020) // 邮箱已被使用
+	AuthErrLinkInvalid       = int32(2000021) // 邮件链接无效或已过期
+	AuthErrEmailSendFailed   = int32(2000022) // 发送邮件失败
+	AuthErrLocaleUnsupported = int32(2000023) // 不支持的语言
 )
 
 func init() {
@@ -45,5 +54,65 @@ func init() {
 		AuthErrUserAlreadyExists: "用户已存在: {username}",
 		AuthErrUserLocked:        "账号已被锁定，请30分钟后再试",
 		AuthErrUserUpdateFailed:  "更新用户信息失败: {reason}",
+		AuthErrInvalidParam:      "参数错误: {reason}",
+		AuthErrParamRequired:     "缺少必填参数: {param}",
+		AuthErrPermissionDenied:  "没有权限: {reason}",
+		AuthErrEmailAlreadyUsed:  "邮箱已被使用: {email}",
+		AuthErrLinkInvalid:       "链接无效或已过期",
+		AuthErrEmailSendFailed:   "发送邮件失败: {reason}",
+		AuthErrLocaleUnsupported: "不支持的语言: {locale}，可选值: {locales}",
+	})
+
+	// 注册认证错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		AuthErrTokenRequired:     "token is required",
+		AuthErrTokenInvalid:      "invalid token: {reason}",
+		AuthErrTokenExpired:      "token has expired",
+		AuthErrTokenMalformed:    "malformed token: {reason}",
+		AuthErrTokenSignature:    "token signature verification failed",
+		AuthErrUserNotFound:      "user not found: {user_uid}",
+		AuthErrJWTSecretMissing:  "JWT secret is not configured, please set JWT_SECRET",
+		AuthErrJWTSecretInvalid:  "invalid JWT secret",
+		AuthErrPasswordIncorrect: "incorrect password",
+		AuthErrCaptchaInvalid:    "invalid captcha",
+		AuthErrCaptchaExpired:    "captcha has expired",
+		AuthErrUserInactive:      "user is disabled",
+		AuthErrUserCreateFailed:  "failed to create user: {reason}",
+		AuthErrUserDeleteFailed:  "failed to delete user: {reason}",
+		AuthErrUserAlreadyExists: "user already exists: {username}",
+		AuthErrUserLocked:        "account is locked, please try again in 30 minutes",
+		AuthErrUserUpdateFailed:  "failed to update user: {reason}",
+		AuthErrInvalidParam:      "invalid parameter: {reason}",
+		AuthErrParamRequired:     "missing required parameter: {param}",
+		AuthErrPermissionDenied:  "permission denied: {reason}",
+		AuthErrEmailAlreadyUsed:  "email is already in use: {email}",
+		AuthErrLinkInvalid:       "the link is invalid or has expired",
+		AuthErrEmailSendFailed:   "failed to send email: {reason}",
+		AuthErrLocaleUnsupported: "unsupported language: {locale}, available: {locales}",
 	})
+
+	// 注册认证错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		AuthErrTokenRequired:     http.StatusUnauthorized,
+		AuthErrTokenInvalid:      http.StatusUnauthorized,
+		AuthErrTokenExpired:      http.StatusUnauthorized,
+		AuthErrTokenMalformed:    http.StatusUnauthorized,
+		AuthErrTokenSignature:    http.StatusUnauthorized,
+		AuthErrUserNotFound:      http.StatusNotFound,
+		AuthErrJWTSecretMissing:  http.StatusInternalServerError,
+		AuthErrJWTSecretInvalid:  http.StatusInternalServerError,
+		AuthErrPasswordIncorrect: http.StatusUnauthorized,
+		AuthErrUserInactive:      http.StatusForbidden,
+		AuthErrUserCreateFailed:  http.StatusInternalServerError,
+		AuthErrUserDeleteFailed:  http.StatusInternalServerError,
+		AuthErrUserAlreadyExists: http.StatusConflict,
+		AuthErrUserLocked:        http.StatusForbidden,
+		AuthErrUserUpdateFailed:  http.StatusInternalServerError,
+		AuthErrPermissionDenied:  http.StatusForbidden,
+		AuthErrEmailAlreadyUsed:  http.StatusConflict,
+		AuthErrEmailSendFailed:   http.StatusInternalServerError,
+	})
+
+	// 注册可重试的认证错误码
+	errorx.RegisterRetryable(AuthErrEmailSendFailed)
 }
@@ -0,0 +1,43 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 机器人错误码 (15000000-15000099)
+	BotErrDatabaseError    = int32(15000000) // 数据库错误
+	BotErrInvalidParam     = int32(15000001) // 参数错误
+	BotErrParamRequired    = int32(15000002) // 缺少必填参数
+	BotErrDisabled         = int32(15000003) // 机器人未启用
+	BotErrWebhookForbidden = int32(15000004) // webhook 密钥错误
+)
+
+func init() {
+	// 注册机器人错误码
+	errorx.RegisterBatch(map[int32]string{
+		BotErrDatabaseError:    "数据库错误: {reason}",
+		BotErrInvalidParam:     "参数错误: {reason}",
+		BotErrParamRequired:    "缺少必填参数: {param}",
+		BotErrDisabled:         "Telegram 机器人未启用",
+		BotErrWebhookForbidden: "webhook 密钥错误",
+	})
+
+	// 注册机器人错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		BotErrDatabaseError:    "database error: {reason}",
+		BotErrInvalidParam:     "invalid parameter: {reason}",
+		BotErrParamRequired:    "missing required parameter: {param}",
+		BotErrDisabled:         "the Telegram bot is not enabled",
+		BotErrWebhookForbidden: "invalid webhook secret",
+	})
+
+	// 注册机器人错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		BotErrDatabaseError:    http.StatusInternalServerError,
+		BotErrDisabled:         http.StatusNotFound,
+		BotErrWebhookForbidden: http.StatusForbidden,
+	})
+}
@@ -0,0 +1,54 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 数据导出错误码 (10000000-10000099)
+	ExportErrDatabaseError = int32(10000000) // 数据库错误
+	ExportErrInvalidParam  = int32(10000001) // 参数错误
+	ExportErrParamRequired = int32(10000002) // 缺少必填参数
+	ExportErrStartFailed   = int32(10000003) // 启动导出任务失败
+	ExportErrLinkInvalid   = int32(10000004) // 下载链接无效或已过期
+	ExportErrFileNotFound  = int32(10000005) // 导出文件不存在
+	ExportErrNotRunning    = int32(10000006) // 没有运行中的导出
+	ExportErrNotPaused     = int32(10000007) // 导出没有暂停
+)
+
+func init() {
+	// 注册数据导出错误码
+	errorx.RegisterBatch(map[int32]string{
+		ExportErrDatabaseError: "数据库错误: {reason}",
+		ExportErrInvalidParam:  "参数错误: {reason}",
+		ExportErrParamRequired: "缺少必填参数: {param}",
+		ExportErrStartFailed:   "启动导出任务失败: {reason}",
+		ExportErrLinkInvalid:   "下载链接无效或已过期",
+		ExportErrFileNotFound:  "导出文件不存在或已被清理，请重新导出",
+		ExportErrNotRunning:    "没有运行中的导出",
+		ExportErrNotPaused:     "导出没有暂停",
+	})
+
+	// 注册数据导出错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		ExportErrDatabaseError: "database error: {reason}",
+		ExportErrInvalidParam:  "invalid parameter: {reason}",
+		ExportErrParamRequired: "missing required parameter: {param}",
+		ExportErrStartFailed:   "failed to start export: {reason}",
+		ExportErrLinkInvalid:   "the download link is invalid or has expired",
+		ExportErrFileNotFound:  "the export file no longer exists, please export again",
+		ExportErrNotRunning:    "there is no running export",
+		ExportErrNotPaused:     "the export is not paused",
+	})
+
+	// 注册数据导出错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		ExportErrDatabaseError: http.StatusInternalServerError,
+		ExportErrStartFailed:   http.StatusServiceUnavailable,
+		ExportErrFileNotFound:  http.StatusGone,
+		ExportErrNotRunning:    http.StatusNotFound,
+		ExportErrNotPaused:     http.StatusConflict,
+	})
+}
@@ -0,0 +1,47 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 订阅错误码 (13000000-13000099)
+	FeedErrDatabaseError = int32(13000000) // 数据库错误
+	FeedErrInvalidParam  = int32(13000001) // 参数错误
+	FeedErrParamRequired = int32(13000002) // 缺少必填参数
+	FeedErrLinkInvalid   = int32(13000003) // 订阅链接无效或已失效
+	FeedErrDisabled      = int32(13000004) // 订阅源未启用
+	FeedErrTagNotFound   = int32(13000005) // 标签不存在
+)
+
+func init() {
+	// 注册订阅错误码
+	errorx.RegisterBatch(map[int32]string{
+		FeedErrDatabaseError: "数据库错误: {reason}",
+		FeedErrInvalidParam:  "参数错误: {reason}",
+		FeedErrParamRequired: "缺少必填参数: {param}",
+		FeedErrLinkInvalid:   "订阅链接无效或已失效，请重新获取订阅链接",
+		FeedErrDisabled:      "订阅源未启用",
+		FeedErrTagNotFound:   "标签不存在: {tag}",
+	})
+
+	// 注册订阅错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		FeedErrDatabaseError: "database error: {reason}",
+		FeedErrInvalidParam:  "invalid parameter: {reason}",
+		FeedErrParamRequired: "missing required parameter: {param}",
+		FeedErrLinkInvalid:   "the feed link is invalid or has been revoked, get a new feed link",
+		FeedErrDisabled:      "the feed is not enabled",
+		FeedErrTagNotFound:   "tag not found: {tag}",
+	})
+
+	// 注册订阅错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		FeedErrDatabaseError: http.StatusInternalServerError,
+		FeedErrLinkInvalid:   http.StatusForbidden,
+		FeedErrDisabled:      http.StatusNotFound,
+		FeedErrTagNotFound:   http.StatusNotFound,
+	})
+}
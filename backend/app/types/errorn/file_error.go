@@ -1,6 +1,8 @@
 package errorn
 
 import (
+	"net/http"
+
 	"backend/utils/errorx"
 )
 
@@ -30,4 +32,32 @@ func init() {
 		FileErrHashCalculateFailed: "计算文件哈希失败: {reason}",
 		FileErrDatabaseError:       "数据库错误: {reason}",
 	})
+
+	// 注册文件错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		FileErrUploadFailed:        "failed to upload file: {reason}",
+		FileErrInvalidFile:         "invalid file",
+		FileErrFileTooLarge:        "file is too large, max size: {max_size}",
+		FileErrUnsupportedType:     "unsupported file type: {file_type}",
+		FileErrStorageError:        "storage error: {reason}",
+		FileErrFileNotFound:        "file not found: {file_id}",
+		FileErrDeleteFailed:        "failed to delete file: {reason}",
+		FileErrHashCalculateFailed: "failed to calculate file hash: {reason}",
+		FileErrDatabaseError:       "database error: {reason}",
+	})
+
+	// 注册文件错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		FileErrUploadFailed:        http.StatusInternalServerError,
+		FileErrFileTooLarge:        http.StatusRequestEntityTooLarge,
+		FileErrUnsupportedType:     http.StatusUnsupportedMediaType,
+		FileErrStorageError:        http.StatusInternalServerError,
+		FileErrFileNotFound:        http.StatusNotFound,
+		FileErrDeleteFailed:        http.StatusInternalServerError,
+		FileErrHashCalculateFailed: http.StatusInternalServerError,
+		FileErrDatabaseError:       http.StatusInternalServerError,
+	})
+
+	// 注册可重试的文件错误码
+	errorx.RegisterRetryable(FileErrStorageError)
 }
@@ -0,0 +1,55 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 数据导入错误码 (11000000-11000099)
+	ImportErrDatabaseError     = int32(11000000) // 数据库错误
+	ImportErrInvalidParam      = int32(11000001) // 参数错误
+	ImportErrParamRequired     = int32(11000002) // 缺少必填参数
+	ImportErrUnsupportedFormat = int32(11000003) // 不支持的导入格式
+	ImportErrFileTooLarge      = int32(11000004) // 导入文件过大
+	ImportErrParseFailed       = int32(11000005) // 解析导入文件失败
+	ImportErrNoRecords         = int32(11000006) // 没有可导入的内容
+	ImportErrTooManyItems      = int32(11000007) // 导入的项目过多
+	ImportErrStartFailed       = int32(11000008) // 启动导入任务失败
+)
+
+func init() {
+	// 注册数据导入错误码
+	errorx.RegisterBatch(map[int32]string{
+		ImportErrDatabaseError:     "数据库错误: {reason}",
+		ImportErrInvalidParam:      "参数错误: {reason}",
+		ImportErrParamRequired:     "缺少必填参数: {param}",
+		ImportErrUnsupportedFormat: "不支持的导入格式: {format}，支持: {formats}",
+		ImportErrFileTooLarge:      "导入文件过大，最大允许: {max_size}",
+		ImportErrParseFailed:       "解析导入文件失败: {reason}",
+		ImportErrNoRecords:         "导入文件中没有可导入的内容",
+		ImportErrTooManyItems:      "导入的项目过多: {count}，单次最多导入 {max} 个",
+		ImportErrStartFailed:       "启动导入任务失败: {reason}",
+	})
+
+	// 注册数据导入错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		ImportErrDatabaseError:     "database error: {reason}",
+		ImportErrInvalidParam:      "invalid parameter: {reason}",
+		ImportErrParamRequired:     "missing required parameter: {param}",
+		ImportErrUnsupportedFormat: "unsupported import format: {format}, supported: {formats}",
+		ImportErrFileTooLarge:      "import file is too large, max size: {max_size}",
+		ImportErrParseFailed:       "failed to parse import file: {reason}",
+		ImportErrNoRecords:         "the import file contains nothing to import",
+		ImportErrTooManyItems:      "too many items to import: {count}, at most {max} per import",
+		ImportErrStartFailed:       "failed to start import: {reason}",
+	})
+
+	// 注册数据导入错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		ImportErrDatabaseError: http.StatusInternalServerError,
+		ImportErrFileTooLarge:  http.StatusRequestEntityTooLarge,
+		ImportErrStartFailed:   http.StatusServiceUnavailable,
+	})
+}
@@ -1,27 +1,65 @@
 package errorn
 
 import (
+	"net/http"
+
 	"backend/utils/errorx"
 )
 
 const (
 	// Item 错误码 (4000000-4000099)
-	ItemErrNotFound      = int32(4000000) // 项目不存在
-	ItemErrCreateFailed  = int32(4000001) // 创建项目失败
-	ItemErrUpdateFailed  = int32(4000002) // 更新项目失败
-	ItemErrDeleteFailed  = int32(4000003) // 删除项目失败
-	ItemErrInvalidStatus = int32(4000004) // 无效的状态
-	ItemErrDatabaseError = int32(4000005) // 数据库错误
+	ItemErrNotFound        = int32(4000000) // 项目不存在
+	ItemErrCreateFailed    = int32(4000001) // 创建项目失败
+	ItemErrUpdateFailed    = int32(4000002) // 更新项目失败
+	ItemErrDeleteFailed    = int32(4000003) // 删除项目失败
+	ItemErrInvalidStatus   = int32(4000004) // 无效的状态
+	ItemErrDatabaseError   = int32(4000005) // 数据库错误
+	ItemErrVersionConflict = int32(4000006) // 版本冲突
+	ItemErrSearchFailed    = int32(4000007) // 搜索失败
+	ItemErrInvalidParam    = int32(4000008) // 参数错误
+	ItemErrParamRequired   = int32(4000009) // 缺少必填参数
 )
 
 func init() {
 	// 注册 Item 错误码
 	errorx.RegisterBatch(map[int32]string{
-		ItemErrNotFound:      "项目不存在: {item_id}",
-		ItemErrCreateFailed:  "创建项目失败: {reason}",
-		ItemErrUpdateFailed:  "更新项目失败: {reason}",
-		ItemErrDeleteFailed:  "删除项目失败: {reason}",
-		ItemErrInvalidStatus: "无效的状态: {status}",
-		ItemErrDatabaseError: "数据库错误: {reason}",
+		ItemErrNotFound:        "项目不存在: {item_id}",
+		ItemErrCreateFailed:    "创建项目失败: {reason}",
+		ItemErrUpdateFailed:    "更新项目失败: {reason}",
+		ItemErrDeleteFailed:    "删除项目失败: {reason}",
+		ItemErrInvalidStatus:   "无效的状态: {status}",
+		ItemErrDatabaseError:   "数据库错误: {reason}",
+		ItemErrVersionConflict: "项目已被修改，请刷新后重试: {item_id}",
+		ItemErrSearchFailed:    "搜索项目失败: {reason}",
+		ItemErrInvalidParam:    "参数错误: {reason}",
+		ItemErrParamRequired:   "缺少必填参数: {param}",
+	})
+
+	// 注册 Item 错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		ItemErrNotFound:        "item not found: {item_id}",
+		ItemErrCreateFailed:    "failed to create item: {reason}",
+		ItemErrUpdateFailed:    "failed to update item: {reason}",
+		ItemErrDeleteFailed:    "failed to delete item: {reason}",
+		ItemErrInvalidStatus:   "invalid status: {status}",
+		ItemErrDatabaseError:   "database error: {reason}",
+		ItemErrVersionConflict: "item has been modified, please refresh and try again: {item_id}",
+		ItemErrSearchFailed:    "failed to search items: {reason}",
+		ItemErrInvalidParam:    "invalid parameter: {reason}",
+		ItemErrParamRequired:   "missing required parameter: {param}",
 	})
+
+	// 注册 Item 错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		ItemErrNotFound:        http.StatusNotFound,
+		ItemErrCreateFailed:    http.StatusInternalServerError,
+		ItemErrUpdateFailed:    http.StatusInternalServerError,
+		ItemErrDeleteFailed:    http.StatusInternalServerError,
+		ItemErrDatabaseError:   http.StatusInternalServerError,
+		ItemErrVersionConflict: http.StatusConflict,
+		ItemErrSearchFailed:    http.StatusInternalServerError,
+	})
+
+	// 注册可重试的 Item 错误码
+	errorx.RegisterRetryable(ItemErrSearchFailed)
 }
@@ -0,0 +1,46 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 通知错误码 (8000000-8000099)
+	NotifyErrDatabaseError  = int32(8000000) // 数据库错误
+	NotifyErrInvalidParam   = int32(8000001) // 参数错误
+	NotifyErrParamRequired  = int32(8000002) // 缺少必填参数
+	NotifyErrTemplateFailed = int32(8000003) // 渲染邮件模板失败
+	NotifyErrSendFailed     = int32(8000004) // 发送邮件失败
+)
+
+func init() {
+	// 注册通知错误码
+	errorx.RegisterBatch(map[int32]string{
+		NotifyErrDatabaseError:  "数据库错误: {reason}",
+		NotifyErrInvalidParam:   "参数错误: {reason}",
+		NotifyErrParamRequired:  "缺少必填参数: {param}",
+		NotifyErrTemplateFailed: "渲染邮件模板失败: {reason}",
+		NotifyErrSendFailed:     "发送邮件失败: {reason}",
+	})
+
+	// 注册通知错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		NotifyErrDatabaseError:  "database error: {reason}",
+		NotifyErrInvalidParam:   "invalid parameter: {reason}",
+		NotifyErrParamRequired:  "missing required parameter: {param}",
+		NotifyErrTemplateFailed: "failed to render email template: {reason}",
+		NotifyErrSendFailed:     "failed to send email: {reason}",
+	})
+
+	// 注册通知错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		NotifyErrDatabaseError:  http.StatusInternalServerError,
+		NotifyErrTemplateFailed: http.StatusInternalServerError,
+		NotifyErrSendFailed:     http.StatusBadGateway,
+	})
+
+	// 注册可重试的通知错误码
+	errorx.RegisterRetryable(NotifyErrSendFailed)
+}
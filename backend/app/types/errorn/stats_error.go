@@ -0,0 +1,35 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 统计错误码 (14000000-14000099)
+	StatsErrDatabaseError = int32(14000000) // 数据库错误
+	StatsErrInvalidParam  = int32(14000001) // 参数错误
+	StatsErrParamRequired = int32(14000002) // 缺少必填参数
+)
+
+func init() {
+	// 注册统计错误码
+	errorx.RegisterBatch(map[int32]string{
+		StatsErrDatabaseError: "数据库错误: {reason}",
+		StatsErrInvalidParam:  "参数错误: {reason}",
+		StatsErrParamRequired: "缺少必填参数: {param}",
+	})
+
+	// 注册统计错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		StatsErrDatabaseError: "database error: {reason}",
+		StatsErrInvalidParam:  "invalid parameter: {reason}",
+		StatsErrParamRequired: "missing required parameter: {param}",
+	})
+
+	// 注册统计错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		StatsErrDatabaseError: http.StatusInternalServerError,
+	})
+}
@@ -0,0 +1,39 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 同步错误码 (9000000-9000099)
+	SyncErrDatabaseError = int32(9000000) // 数据库错误
+	SyncErrInvalidParam  = int32(9000001) // 参数错误
+	SyncErrParamRequired = int32(9000002) // 缺少必填参数
+	SyncErrStreamFailed  = int32(9000003) // 订阅变更失败
+)
+
+func init() {
+	// 注册同步错误码
+	errorx.RegisterBatch(map[int32]string{
+		SyncErrDatabaseError: "数据库错误: {reason}",
+		SyncErrInvalidParam:  "参数错误: {reason}",
+		SyncErrParamRequired: "缺少必填参数: {param}",
+		SyncErrStreamFailed:  "订阅变更失败: {reason}",
+	})
+
+	// 注册同步错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		SyncErrDatabaseError: "database error: {reason}",
+		SyncErrInvalidParam:  "invalid parameter: {reason}",
+		SyncErrParamRequired: "missing required parameter: {param}",
+		SyncErrStreamFailed:  "failed to subscribe to changes: {reason}",
+	})
+
+	// 注册同步错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		SyncErrDatabaseError: http.StatusInternalServerError,
+		SyncErrStreamFailed:  http.StatusServiceUnavailable,
+	})
+}
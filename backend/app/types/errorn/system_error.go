@@ -0,0 +1,93 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 系统错误码 (1000000-1000099)
+	SystemErrNotReady          = int32(1000000) // 服务未就绪
+	SystemErrReadOnly          = int32(1000001) // 服务处于只读模式
+	SystemErrDatabase          = int32(1000002) // 数据库错误
+	SystemErrJobNotFound       = int32(1000003) // 定时任务不存在
+	SystemErrJobRunning        = int32(1000004) // 定时任务正在执行
+	SystemErrInvalidParam      = int32(1000005) // 参数错误
+	SystemErrParamRequired     = int32(1000006) // 缺少必填参数
+	SystemErrQueueJobNotFound  = int32(1000007) // 后台任务不存在
+	SystemErrQueueJobNotDead   = int32(1000008) // 后台任务不是死信状态
+	SystemErrQueueUnknownType  = int32(1000009) // 未注册的后台任务类型
+	SystemErrQueueTypeDenied   = int32(1000010) // 不允许手动添加的后台任务类型
+	SystemErrInternal          = int32(1000011) // 服务器内部错误
+	SystemErrRateLimited       = int32(1000012) // 请求过于频繁
+	SystemErrRequestContract   = int32(1000013) // 请求不符合接口定义
+	SystemErrResponseContract  = int32(1000014) // 响应不符合接口定义
+	SystemErrInvalidDryRun     = int32(1000015) // 试运行参数无效
+	SystemErrDryRunUnsupported = int32(1000016) // 接口不支持试运行
+	SystemErrHookRejected      = int32(1000017) // 操作被扩展钩子拒绝
+)
+
+func init() {
+	// 注册系统错误码
+	errorx.RegisterBatch(map[int32]string{
+		SystemErrNotReady:          "服务未就绪: {reason}",
+		SystemErrReadOnly:          "服务处于只读模式，暂不支持写操作: {reason}",
+		SystemErrDatabase:          "数据库错误: {reason}",
+		SystemErrJobNotFound:       "定时任务不存在: {name}",
+		SystemErrJobRunning:        "定时任务正在执行: {name}",
+		SystemErrInvalidParam:      "参数错误: {reason}",
+		SystemErrParamRequired:     "缺少必填参数: {param}",
+		SystemErrQueueJobNotFound:  "后台任务不存在: {job_id}",
+		SystemErrQueueJobNotDead:   "只能重试死信状态的后台任务: {job_id}",
+		SystemErrQueueUnknownType:  "未注册的后台任务类型: {type}",
+		SystemErrQueueTypeDenied:   "不允许手动添加的后台任务类型: {type}，可选值: {types}",
+		SystemErrInternal:          "服务器内部错误",
+		SystemErrRateLimited:       "请求过于频繁，请在 {retry_after} 秒后重试",
+		SystemErrRequestContract:   "请求不符合接口定义: {field} {reason}",
+		SystemErrResponseContract:  "响应不符合接口定义: {field} {reason}",
+		SystemErrInvalidDryRun:     "试运行参数无效: {value}，可选值: true, false",
+		SystemErrDryRunUnsupported: "该接口不支持试运行: {method} {path}",
+		SystemErrHookRejected:      "操作被拒绝: {reason}",
+	})
+
+	// 注册系统错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		SystemErrNotReady:          "service is not ready: {reason}",
+		SystemErrReadOnly:          "service is in read-only mode, writes are not allowed: {reason}",
+		SystemErrDatabase:          "database error: {reason}",
+		SystemErrJobNotFound:       "scheduled job not found: {name}",
+		SystemErrJobRunning:        "scheduled job is already running: {name}",
+		SystemErrInvalidParam:      "invalid parameter: {reason}",
+		SystemErrParamRequired:     "missing required parameter: {param}",
+		SystemErrQueueJobNotFound:  "background job not found: {job_id}",
+		SystemErrQueueJobNotDead:   "only dead background jobs can be retried: {job_id}",
+		SystemErrQueueUnknownType:  "unknown background job type: {type}",
+		SystemErrQueueTypeDenied:   "background job type cannot be added manually: {type}, available: {types}",
+		SystemErrInternal:          "internal server error",
+		SystemErrRateLimited:       "too many requests, retry after {retry_after} seconds",
+		SystemErrRequestContract:   "request does not match the API contract: {field} {reason}",
+		SystemErrResponseContract:  "response does not match the API contract: {field} {reason}",
+		SystemErrInvalidDryRun:     "invalid dry run value: {value}, available: true, false",
+		SystemErrDryRunUnsupported: "dry run is not supported by this endpoint: {method} {path}",
+		SystemErrHookRejected:      "operation rejected: {reason}",
+	})
+
+	// 注册系统错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		SystemErrNotReady:         http.StatusServiceUnavailable,
+		SystemErrReadOnly:         http.StatusServiceUnavailable,
+		SystemErrDatabase:         http.StatusInternalServerError,
+		SystemErrJobNotFound:      http.StatusNotFound,
+		SystemErrJobRunning:       http.StatusConflict,
+		SystemErrQueueJobNotFound: http.StatusNotFound,
+		SystemErrQueueJobNotDead:  http.StatusConflict,
+		SystemErrInternal:         http.StatusInternalServerError,
+		SystemErrRateLimited:      http.StatusTooManyRequests,
+		SystemErrResponseContract: http.StatusInternalServerError,
+		SystemErrHookRejected:     http.StatusUnprocessableEntity,
+	})
+
+	// 注册可重试的系统错误码
+	errorx.RegisterRetryable(SystemErrNotReady, SystemErrRateLimited)
+}
@@ -1,6 +1,8 @@
 package errorn
 
 import (
+	"net/http"
+
 	"backend/utils/errorx"
 )
 
@@ -12,6 +14,8 @@ const (
 	TagErrDeleteFailed  = int32(5000003) // 删除标签失败
 	TagErrAlreadyExists = int32(5000004) // 标签已存在
 	TagErrDatabaseError = int32(5000005) // 数据库错误
+	TagErrInvalidParam  = int32(5000006) // 参数错误
+	TagErrParamRequired = int32(5000007) // 缺少必填参数
 )
 
 func init() {
@@ -23,5 +27,29 @@ func init() {
 		TagErrDeleteFailed:  "删除标签失败: {reason}",
 		TagErrAlreadyExists: "标签已存在: {tag_value}",
 		TagErrDatabaseError: "数据库错误: {reason}",
+		TagErrInvalidParam:  "参数错误: {reason}",
+		TagErrParamRequired: "缺少必填参数: {param}",
+	})
+
+	// 注册 Tag 错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		TagErrNotFound:      "tag not found: {tag_id}",
+		TagErrCreateFailed:  "failed to create tag: {reason}",
+		TagErrUpdateFailed:  "failed to update tag: {reason}",
+		TagErrDeleteFailed:  "failed to delete tag: {reason}",
+		TagErrAlreadyExists: "tag already exists: {tag_value}",
+		TagErrDatabaseError: "database error: {reason}",
+		TagErrInvalidParam:  "invalid parameter: {reason}",
+		TagErrParamRequired: "missing required parameter: {param}",
+	})
+
+	// 注册 Tag 错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		TagErrNotFound:      http.StatusNotFound,
+		TagErrCreateFailed:  http.StatusInternalServerError,
+		TagErrUpdateFailed:  http.StatusInternalServerError,
+		TagErrDeleteFailed:  http.StatusInternalServerError,
+		TagErrAlreadyExists: http.StatusConflict,
+		TagErrDatabaseError: http.StatusInternalServerError,
 	})
 }
@@ -0,0 +1,33 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// 租户错误码 (6000000-6000099)
+	TenantErrInvalid  = int32(6000000) // 无效的租户
+	TenantErrMismatch = int32(6000001) // 租户不匹配
+)
+
+func init() {
+	// 注册租户错误码
+	errorx.RegisterBatch(map[int32]string{
+		TenantErrInvalid:  "无效的租户: {tenant_id}",
+		TenantErrMismatch: "Token 与当前租户不匹配",
+	})
+
+	// 注册租户错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		TenantErrInvalid:  "invalid tenant: {tenant_id}",
+		TenantErrMismatch: "token does not belong to the current tenant",
+	})
+
+	// 注册租户错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		TenantErrInvalid:  http.StatusBadRequest,
+		TenantErrMismatch: http.StatusUnauthorized,
+	})
+}
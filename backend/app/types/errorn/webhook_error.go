@@ -0,0 +1,53 @@
+package errorn
+
+import (
+	"net/http"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// Webhook 错误码 (7000000-7000099)
+	WebhookErrNotFound         = int32(7000000) // webhook 不存在
+	WebhookErrDatabaseError    = int32(7000001) // 数据库错误
+	WebhookErrInvalidParam     = int32(7000002) // 参数错误
+	WebhookErrParamRequired    = int32(7000003) // 缺少必填参数
+	WebhookErrInvalidURL       = int32(7000004) // 无效的回调地址
+	WebhookErrInvalidEvent     = int32(7000005) // 不支持的事件类型
+	WebhookErrSecretFailed     = int32(7000006) // 生成或保存签名密钥失败
+	WebhookErrDeliveryNotFound = int32(7000007) // 投递记录不存在
+)
+
+func init() {
+	// 注册 Webhook 错误码
+	errorx.RegisterBatch(map[int32]string{
+		WebhookErrNotFound:         "webhook 不存在: {webhook_id}",
+		WebhookErrDatabaseError:    "数据库错误: {reason}",
+		WebhookErrInvalidParam:     "参数错误: {reason}",
+		WebhookErrParamRequired:    "缺少必填参数: {param}",
+		WebhookErrInvalidURL:       "无效的回调地址: {url}",
+		WebhookErrInvalidEvent:     "不支持的事件类型: {event}",
+		WebhookErrSecretFailed:     "签名密钥处理失败: {reason}",
+		WebhookErrDeliveryNotFound: "投递记录不存在: {delivery_id}",
+	})
+
+	// 注册 Webhook 错误码英文消息
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		WebhookErrNotFound:         "webhook not found: {webhook_id}",
+		WebhookErrDatabaseError:    "database error: {reason}",
+		WebhookErrInvalidParam:     "invalid parameter: {reason}",
+		WebhookErrParamRequired:    "missing required parameter: {param}",
+		WebhookErrInvalidURL:       "invalid callback url: {url}",
+		WebhookErrInvalidEvent:     "unsupported event: {event}",
+		WebhookErrSecretFailed:     "failed to handle signing secret: {reason}",
+		WebhookErrDeliveryNotFound: "delivery not found: {delivery_id}",
+	})
+
+	// 注册 Webhook 错误码 HTTP 状态码（未列出的使用 400）
+	errorx.RegisterStatusBatch(map[int32]int{
+		WebhookErrNotFound:         http.StatusNotFound,
+		WebhookErrDatabaseError:    http.StatusInternalServerError,
+		WebhookErrSecretFailed:     http.StatusInternalServerError,
+		WebhookErrDeliveryNotFound: http.StatusNotFound,
+	})
+}
@@ -0,0 +1,22 @@
+// Package i18n 接口响应中按键翻译的消息（如成功提示），按请求语言选择
+// 错误消息按错误码注册，见 errorn；参数校验提示见 bind
+package i18n
+
+import "backend/utils/errorx"
+
+// 消息键
+const (
+	MsgJobTriggered = "system.job_triggered" // 定时任务已触发
+)
+
+func init() {
+	// 注册中文消息
+	errorx.RegisterMessages(errorx.DefaultLocale, map[string]string{
+		MsgJobTriggered: "任务已触发",
+	})
+
+	// 注册英文消息
+	errorx.RegisterMessages(errorx.LocaleEN, map[string]string{
+		MsgJobTriggered: "job triggered",
+	})
+}
@@ -1,11 +1,32 @@
 package meta
 
+import "context"
+
 // ContextKey 上下文键
 type ContextKey string
 
 const (
 	ContextKeyAccessToken ContextKey = "access_token"
 	ContextKeyUserID      ContextKey = "user_id"
+	ContextKeyTenantID    ContextKey = "tenant_id"
+	ContextKeyClientIP    ContextKey = "client_ip"
+	// ContextKeyDryRun 试运行请求：写操作在事务中执行后回滚，不产生副作用
+	ContextKeyDryRun ContextKey = "dry_run"
+)
+
+// IsDryRun 是否为试运行请求
+// 试运行时 webhook、变更同步、审计日志、搜索索引和业务指标等副作用都应跳过
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(ContextKeyDryRun).(bool)
+	return dryRun
+}
+
+// GORM 语句设置键
+const (
+	// GormKeyExplain 标记该查询在慢查询时输出执行计划
+	GormKeyExplain = "peano:explain"
+	// GormKeyCrossTenant 标记该原始 SQL 与租户无关，多租户模式下允许执行
+	GormKeyCrossTenant = "peano:cross_tenant"
 )
 
 // ItemStatus 项目状态
@@ -16,3 +37,38 @@ const (
 	ItemStatusDone   ItemStatus = "done"
 	ItemStatusMarked ItemStatus = "marked"
 )
+
+// ItemStatuses 所有项目状态
+var ItemStatuses = []ItemStatus{ItemStatusNormal, ItemStatusDone, ItemStatusMarked}
+
+// Valid 是否为有效的项目状态
+func (s ItemStatus) Valid() bool {
+	for _, status := range ItemStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent webhook 事件类型
+type WebhookEvent string
+
+const (
+	WebhookEventItemCreated   WebhookEvent = "item.created"
+	WebhookEventItemCompleted WebhookEvent = "item.completed"
+	WebhookEventTagDeleted    WebhookEvent = "tag.deleted"
+)
+
+// WebhookEvents 所有 webhook 事件类型
+var WebhookEvents = []WebhookEvent{WebhookEventItemCreated, WebhookEventItemCompleted, WebhookEventTagDeleted}
+
+// Valid 是否为有效的 webhook 事件类型
+func (e WebhookEvent) Valid() bool {
+	for _, event := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
@@ -3,11 +3,12 @@ package es
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
-	"strings"
 	"time"
 
+	"backend/utils/logs"
+	"backend/utils/trace"
+
 	"github.com/elastic/go-elasticsearch/v8"
 )
 
@@ -39,8 +40,12 @@ func NewElasticSearch(config *ElasticSearchConfig) (*elasticsearch.Client, error
 
 	// 构建 ES 客户端配置
 	esConfig := elasticsearch.Config{
-		Addresses:  config.Addresses,
-		MaxRetries: config.MaxRetries,
+		Addresses:           config.Addresses,
+		MaxRetries:          config.MaxRetries,
+		CompressRequestBody: config.EnableCompression,
+		DisableMetaHeader:   config.DisableMetaHeader,
+		// 出站请求携带 traceparent，与 ES 的链路关联
+		Transport: trace.Transport(nil),
 	}
 
 	// 如果需要超时重试，使用 RetryOnError 回调
@@ -54,16 +59,16 @@ func NewElasticSearch(config *ElasticSearchConfig) (*elasticsearch.Client, error
 	// 设置认证方式（优先级：APIKey > Username/Password > CloudID）
 	if config.APIKey != "" {
 		esConfig.APIKey = config.APIKey
-		log.Printf("🔑 使用 API Key 认证")
+		logs.Info("ElasticSearch 使用 API Key 认证")
 	} else if config.Username != "" && config.Password != "" {
 		esConfig.Username = config.Username
 		esConfig.Password = config.Password
-		log.Printf("🔑 使用用户名密码认证")
+		logs.Info("ElasticSearch 使用用户名密码认证", "username", config.Username)
 	} else if config.CloudID != "" {
 		esConfig.CloudID = config.CloudID
-		log.Printf("🔑 使用 Cloud ID 认证")
+		logs.Info("ElasticSearch 使用 Cloud ID 认证")
 	} else {
-		log.Printf("⚠️  未配置认证信息，使用匿名连接")
+		logs.Warn("ElasticSearch 未配置认证信息，使用匿名连接")
 	}
 
 	// 创建 ES 客户端
@@ -87,14 +92,14 @@ func NewElasticSearch(config *ElasticSearchConfig) (*elasticsearch.Client, error
 	}
 
 	// 输出连接信息
-	log.Printf("✅ ElasticSearch 连接成功")
-	log.Printf("📊 ElasticSearch 配置信息:")
-	log.Printf("   - 服务器地址: %s", strings.Join(config.Addresses, ", "))
-	log.Printf("   - 最大重试次数: %d", config.MaxRetries)
-	log.Printf("   - 请求超时时间: %v", config.RequestTimeout)
-	log.Printf("   - Ping 超时时间: %v", config.PingTimeout)
-	log.Printf("   - 启用压缩: %v", config.EnableCompression)
-	log.Printf("   - 超时重试: %v", config.EnableRetryOnTimeout)
+	logs.Info("ElasticSearch 连接成功",
+		"addresses", config.Addresses,
+		"max_retries", config.MaxRetries,
+		"request_timeout", config.RequestTimeout.String(),
+		"ping_timeout", config.PingTimeout.String(),
+		"compression", config.EnableCompression,
+		"retry_on_timeout", config.EnableRetryOnTimeout,
+	)
 
 	return esClient, nil
 }
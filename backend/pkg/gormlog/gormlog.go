@@ -0,0 +1,111 @@
+package gormlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"backend/utils/logs"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// GormLoggerConfig GORM 日志适配器配置
+type GormLoggerConfig struct {
+	LogLevel                  logger.LogLevel // 日志级别
+	SlowThreshold             time.Duration   // 慢查询阈值，<= 0 表示不记录慢查询
+	IgnoreRecordNotFoundError bool            // 是否忽略 ErrRecordNotFound 错误
+	ParameterizedQueries      bool            // 是否只记录带占位符的 SQL（不输出参数值）
+}
+
+// gormLogger 基于 utils/logs 的 GORM 日志适配器
+// 日志以结构化形式输出，并携带 context 中的 trace_id
+type gormLogger struct {
+	config GormLoggerConfig
+}
+
+// NewGormLogger 创建 GORM 日志适配器
+func NewGormLogger(config *GormLoggerConfig) logger.Interface {
+	return &gormLogger{
+		config: *config,
+	}
+}
+
+// LogMode 实现 logger.Interface 接口
+func (l *gormLogger) LogMode(level logger.LogLevel) logger.Interface {
+	newLogger := *l
+	newLogger.config.LogLevel = level
+	return &newLogger
+}
+
+// Info 实现 logger.Interface 接口
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= logger.Info {
+		logs.CtxInfo(ctx, fmt.Sprintf(msg, data...), "source", utils.FileWithLineNum())
+	}
+}
+
+// Warn 实现 logger.Interface 接口
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= logger.Warn {
+		logs.CtxWarn(ctx, fmt.Sprintf(msg, data...), "source", utils.FileWithLineNum())
+	}
+}
+
+// Error 实现 logger.Interface 接口
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.config.LogLevel >= logger.Error {
+		logs.CtxError(ctx, fmt.Sprintf(msg, data...), "source", utils.FileWithLineNum())
+	}
+}
+
+// Trace 实现 logger.Interface 接口，记录 SQL 执行情况
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.config.LogLevel <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	durationMs := float64(elapsed.Microseconds()) / 1000.0
+
+	switch {
+	case err != nil && l.config.LogLevel >= logger.Error && (!errors.Is(err, gorm.ErrRecordNotFound) || !l.config.IgnoreRecordNotFoundError):
+		sql, rows := fc()
+		logs.CtxError(ctx, "SQL 执行错误",
+			"error", err.Error(),
+			"sql", sql,
+			"rows", rows,
+			"duration_ms", durationMs,
+			"source", utils.FileWithLineNum(),
+		)
+	case l.config.SlowThreshold > 0 && elapsed > l.config.SlowThreshold && l.config.LogLevel >= logger.Warn:
+		sql, rows := fc()
+		logs.CtxWarn(ctx, "慢查询",
+			"sql", sql,
+			"rows", rows,
+			"duration_ms", durationMs,
+			"threshold_ms", l.config.SlowThreshold.Milliseconds(),
+			"source", utils.FileWithLineNum(),
+		)
+	case l.config.LogLevel == logger.Info:
+		sql, rows := fc()
+		logs.CtxInfo(ctx, "SQL 执行",
+			"sql", sql,
+			"rows", rows,
+			"duration_ms", durationMs,
+			"source", utils.FileWithLineNum(),
+		)
+	}
+}
+
+// ParamsFilter 实现 gorm.ParamsFilter 接口
+// 启用 ParameterizedQueries 时不输出参数值
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.config.ParameterizedQueries {
+		return sql, nil
+	}
+	return sql, params
+}
@@ -2,9 +2,11 @@ package mysql
 
 import (
 	"fmt"
-	"log"
 	"time"
 
+	"backend/pkg/gormlog"
+	"backend/utils/logs"
+
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -29,22 +31,20 @@ func NewMySQL(config *MySQLConfig) (*gorm.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
 		config.User, config.Password, config.Host, config.Port, config.DBName)
 
-	// 配置 GORM 日志
-	gormConfig := &gorm.Config{}
+	// 配置 GORM 日志，统一输出到 utils/logs（结构化并携带 trace_id）
+	gormLoggerConfig := &gormlog.GormLoggerConfig{
+		LogLevel:                  logger.Warn, // 日志级别
+		IgnoreRecordNotFoundError: true,        // 忽略 ErrRecordNotFound 错误
+	}
 
 	// 根据配置启用慢查询日志
 	if config.EnableSlowQueryLog {
-		slowThreshold := time.Duration(config.SlowQueryThreshold) * time.Millisecond
-		gormConfig.Logger = logger.New(
-			log.New(log.Writer(), "\r\n", log.LstdFlags), // io writer
-			logger.Config{
-				SlowThreshold:             slowThreshold, // 慢查询阈值
-				LogLevel:                  logger.Warn,   // 日志级别
-				IgnoreRecordNotFoundError: true,          // 忽略 ErrRecordNotFound 错误
-				Colorful:                  true,          // 启用彩色输出
-			},
-		)
-		log.Printf("✅ 慢查询日志已启用，阈值: %dms", config.SlowQueryThreshold)
+		gormLoggerConfig.SlowThreshold = time.Duration(config.SlowQueryThreshold) * time.Millisecond // 慢查询阈值
+		logs.Info("慢查询日志已启用", "threshold_ms", config.SlowQueryThreshold)
+	}
+
+	gormConfig := &gorm.Config{
+		Logger: gormlog.NewGormLogger(gormLoggerConfig),
 	}
 
 	// 打开数据库连接
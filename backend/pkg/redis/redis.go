@@ -23,8 +23,24 @@ type RedisConfig struct {
 	PoolTimeout  time.Duration
 }
 
+// NewRedis 创建 Redis 客户端并检查连接
 func NewRedis(config *RedisConfig) (*redis.Client, error) {
-	redisClient := redis.NewClient(&redis.Options{
+	redisClient := NewRedisClient(config)
+
+	// 检查Redis连接
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		return nil, err
+	}
+
+	return redisClient, nil
+}
+
+// NewRedisClient 创建 Redis 客户端，不检查连接
+func NewRedisClient(config *RedisConfig) *redis.Client {
+	return redis.NewClient(&redis.Options{
 		Addr:         fmt.Sprintf("%s:%s", config.Host, config.Port), // Redis服务器地址
 		Password:     config.Password,                                // Redis密码
 		DB:           config.DB,                                      // 使用指定数据库
@@ -37,14 +53,4 @@ func NewRedis(config *RedisConfig) (*redis.Client, error) {
 		WriteTimeout: config.WriteTimeout,                            // 写入超时时间
 		PoolTimeout:  config.PoolTimeout,                             // 连接池超时时间
 	})
-
-	// 检查Redis连接
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := redisClient.Ping(ctx).Err(); err != nil {
-		return nil, err
-	}
-
-	return redisClient, nil
 }
@@ -2,9 +2,12 @@ package sqlite
 
 import (
 	"fmt"
-	"log"
+	"strings"
 	"time"
 
+	"backend/pkg/gormlog"
+	"backend/utils/logs"
+
 	sqliteDriver "gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -18,29 +21,29 @@ type SQLiteConfig struct {
 	ConnMaxIdleTimeMin int    // 连接最大空闲时间（分钟）
 	EnableSlowQueryLog bool   // 是否启用慢查询日志
 	SlowQueryThreshold int    // 慢查询阈值（毫秒）
+	WALMode            bool   // 是否启用 WAL 日志模式
+	BusyTimeoutMs      int    // 数据库锁等待时间（毫秒），0 表示使用驱动默认值
 }
 
 func NewSQLite(config *SQLiteConfig) (*gorm.DB, error) {
-	// 配置 GORM 日志
-	gormConfig := &gorm.Config{}
+	// 配置 GORM 日志，统一输出到 utils/logs（结构化并携带 trace_id）
+	gormLoggerConfig := &gormlog.GormLoggerConfig{
+		LogLevel:                  logger.Warn, // 日志级别
+		IgnoreRecordNotFoundError: true,        // 忽略 ErrRecordNotFound 错误
+	}
 
 	// 根据配置启用慢查询日志
 	if config.EnableSlowQueryLog {
-		slowThreshold := time.Duration(config.SlowQueryThreshold) * time.Millisecond
-		gormConfig.Logger = logger.New(
-			log.New(log.Writer(), "\r\n", log.LstdFlags), // io writer
-			logger.Config{
-				SlowThreshold:             slowThreshold, // 慢查询阈值
-				LogLevel:                  logger.Warn,   // 日志级别
-				IgnoreRecordNotFoundError: true,          // 忽略 ErrRecordNotFound 错误
-				Colorful:                  true,          // 启用彩色输出
-			},
-		)
-		log.Printf("✅ 慢查询日志已启用，阈值: %dms", config.SlowQueryThreshold)
+		gormLoggerConfig.SlowThreshold = time.Duration(config.SlowQueryThreshold) * time.Millisecond // 慢查询阈值
+		logs.Info("慢查询日志已启用", "threshold_ms", config.SlowQueryThreshold)
+	}
+
+	gormConfig := &gorm.Config{
+		Logger: gormlog.NewGormLogger(gormLoggerConfig),
 	}
 
 	// 打开数据库连接
-	db, err := gorm.Open(sqliteDriver.Open(config.DBPath), gormConfig)
+	db, err := gorm.Open(sqliteDriver.Open(buildDSN(config)), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("打开数据库连接失败: %w", err)
 	}
@@ -71,3 +74,24 @@ func NewSQLite(config *SQLiteConfig) (*gorm.DB, error) {
 
 	return db, nil
 }
+
+// buildDSN 根据配置构建 SQLite DSN
+// 连接参数通过 DSN 设置，保证连接池中的每个连接都生效
+func buildDSN(config *SQLiteConfig) string {
+	params := make([]string, 0, 3)
+	if config.WALMode {
+		params = append(params, "_journal_mode=WAL", "_synchronous=NORMAL")
+	}
+	if config.BusyTimeoutMs > 0 {
+		params = append(params, fmt.Sprintf("_busy_timeout=%d", config.BusyTimeoutMs))
+	}
+	if len(params) == 0 {
+		return config.DBPath
+	}
+
+	sep := "?"
+	if strings.Contains(config.DBPath, "?") {
+		sep = "&"
+	}
+	return config.DBPath + sep + strings.Join(params, "&")
+}
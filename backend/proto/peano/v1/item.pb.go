@@ -0,0 +1,935 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: peano/v1/item.proto
+
+package peanov1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ItemStatus 项目状态
+type ItemStatus int32
+
+const (
+	// 未设置，创建时使用默认状态，更新和筛选时不生效
+	ItemStatus_ITEM_STATUS_UNSPECIFIED ItemStatus = 0
+	ItemStatus_ITEM_STATUS_NORMAL      ItemStatus = 1
+	ItemStatus_ITEM_STATUS_DONE        ItemStatus = 2
+	ItemStatus_ITEM_STATUS_MARKED      ItemStatus = 3
+)
+
+// Enum value maps for ItemStatus.
+var (
+	ItemStatus_name = map[int32]string{
+		0: "ITEM_STATUS_UNSPECIFIED",
+		1: "ITEM_STATUS_NORMAL",
+		2: "ITEM_STATUS_DONE",
+		3: "ITEM_STATUS_MARKED",
+	}
+	ItemStatus_value = map[string]int32{
+		"ITEM_STATUS_UNSPECIFIED": 0,
+		"ITEM_STATUS_NORMAL":      1,
+		"ITEM_STATUS_DONE":        2,
+		"ITEM_STATUS_MARKED":      3,
+	}
+)
+
+func (x ItemStatus) Enum() *ItemStatus {
+	p := new(ItemStatus)
+	*p = x
+	return p
+}
+
+func (x ItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_peano_v1_item_proto_enumTypes[0].Descriptor()
+}
+
+func (ItemStatus) Type() protoreflect.EnumType {
+	return &file_peano_v1_item_proto_enumTypes[0]
+}
+
+func (x ItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ItemStatus.Descriptor instead.
+func (ItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{0}
+}
+
+// Item 项目
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        uint64                 `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Status        ItemStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=peano.v1.ItemStatus" json:"status,omitempty"`
+	Version       uint64                 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Tags          []*Tag                 `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_peano_v1_item_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *Item) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Item) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *Item) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Item) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Item) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Item) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Status        ItemStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=peano.v1.ItemStatus" json:"status,omitempty"`
+	TagIds        []uint64               `protobuf:"varint,3,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateItemRequest) Reset() {
+	*x = CreateItemRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateItemRequest) ProtoMessage() {}
+
+func (x *CreateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateItemRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateItemRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *CreateItemRequest) GetTagIds() []uint64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+type GetItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        uint64                 `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetItemRequest) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+type UpdateItemRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ItemId  uint64                 `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	Content *string                `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	Status  ItemStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=peano.v1.ItemStatus" json:"status,omitempty"`
+	// 为空时不修改关联的标签
+	TagIds []uint64 `protobuf:"varint,4,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	// 乐观锁版本号，不设置时不做版本检查
+	Version       *uint64 `protobuf:"varint,5,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemRequest) Reset() {
+	*x = UpdateItemRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemRequest) ProtoMessage() {}
+
+func (x *UpdateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateItemRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateItemRequest) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateItemRequest) GetTagIds() []uint64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+func (x *UpdateItemRequest) GetVersion() uint64 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type DeleteItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemId        uint64                 `protobuf:"varint,1,opt,name=item_id,json=itemId,proto3" json:"item_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemRequest) Reset() {
+	*x = DeleteItemRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemRequest) ProtoMessage() {}
+
+func (x *DeleteItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteItemRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteItemRequest) GetItemId() uint64 {
+	if x != nil {
+		return x.ItemId
+	}
+	return 0
+}
+
+// 日期参数格式与 REST 接口相同：YYYY-MM-DD 或 today、last-7-days 等相对日期，按 x-timezone 元数据的时区计算
+type ListItemsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DateStart *string                `protobuf:"bytes,1,opt,name=date_start,json=dateStart,proto3,oneof" json:"date_start,omitempty"`
+	DateEnd   *string                `protobuf:"bytes,2,opt,name=date_end,json=dateEnd,proto3,oneof" json:"date_end,omitempty"`
+	Status    ItemStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=peano.v1.ItemStatus" json:"status,omitempty"`
+	// 页码，默认 1
+	Page int32 `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	// 每页条数，默认 20，最大 100
+	PageSize      int32 `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsRequest) Reset() {
+	*x = ListItemsRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsRequest) ProtoMessage() {}
+
+func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListItemsRequest) GetDateStart() string {
+	if x != nil && x.DateStart != nil {
+		return *x.DateStart
+	}
+	return ""
+}
+
+func (x *ListItemsRequest) GetDateEnd() string {
+	if x != nil && x.DateEnd != nil {
+		return *x.DateEnd
+	}
+	return ""
+}
+
+func (x *ListItemsRequest) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *ListItemsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Total         int64                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsResponse) Reset() {
+	*x = ListItemsResponse{}
+	mi := &file_peano_v1_item_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsResponse) ProtoMessage() {}
+
+func (x *ListItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListItemsResponse) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListItemsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListItemsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListItemsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchItemsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Keyword string                 `protobuf:"bytes,1,opt,name=keyword,proto3" json:"keyword,omitempty"`
+	Status  ItemStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=peano.v1.ItemStatus" json:"status,omitempty"`
+	// 页码，默认 1
+	Page int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	// 每页条数，默认 20，最大 100
+	PageSize      int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchItemsRequest) Reset() {
+	*x = SearchItemsRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchItemsRequest) ProtoMessage() {}
+
+func (x *SearchItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchItemsRequest.ProtoReflect.Descriptor instead.
+func (*SearchItemsRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchItemsRequest) GetKeyword() string {
+	if x != nil {
+		return x.Keyword
+	}
+	return ""
+}
+
+func (x *SearchItemsRequest) GetStatus() ItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ItemStatus_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *SearchItemsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetDailyItemCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DateStart     string                 `protobuf:"bytes,1,opt,name=date_start,json=dateStart,proto3" json:"date_start,omitempty"`
+	DateEnd       string                 `protobuf:"bytes,2,opt,name=date_end,json=dateEnd,proto3" json:"date_end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDailyItemCountRequest) Reset() {
+	*x = GetDailyItemCountRequest{}
+	mi := &file_peano_v1_item_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyItemCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyItemCountRequest) ProtoMessage() {}
+
+func (x *GetDailyItemCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyItemCountRequest.ProtoReflect.Descriptor instead.
+func (*GetDailyItemCountRequest) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDailyItemCountRequest) GetDateStart() string {
+	if x != nil {
+		return x.DateStart
+	}
+	return ""
+}
+
+func (x *GetDailyItemCountRequest) GetDateEnd() string {
+	if x != nil {
+		return x.DateEnd
+	}
+	return ""
+}
+
+// DailyItemCount 每日项目数量
+type DailyItemCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyItemCount) Reset() {
+	*x = DailyItemCount{}
+	mi := &file_peano_v1_item_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyItemCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyItemCount) ProtoMessage() {}
+
+func (x *DailyItemCount) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyItemCount.ProtoReflect.Descriptor instead.
+func (*DailyItemCount) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DailyItemCount) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *DailyItemCount) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetDailyItemCountResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DailyItemCounts []*DailyItemCount      `protobuf:"bytes,1,rep,name=daily_item_counts,json=dailyItemCounts,proto3" json:"daily_item_counts,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetDailyItemCountResponse) Reset() {
+	*x = GetDailyItemCountResponse{}
+	mi := &file_peano_v1_item_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDailyItemCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDailyItemCountResponse) ProtoMessage() {}
+
+func (x *GetDailyItemCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_peano_v1_item_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDailyItemCountResponse.ProtoReflect.Descriptor instead.
+func (*GetDailyItemCountResponse) Descriptor() ([]byte, []int) {
+	return file_peano_v1_item_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetDailyItemCountResponse) GetDailyItemCounts() []*DailyItemCount {
+	if x != nil {
+		return x.DailyItemCounts
+	}
+	return nil
+}
+
+var File_peano_v1_item_proto protoreflect.FileDescriptor
+
+const file_peano_v1_item_proto_rawDesc = "" +
+	"\n" +
+	"\x13peano/v1/item.proto\x12\bpeano.v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x12peano/v1/tag.proto\"\x9a\x02\n" +
+	"\x04Item\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x04R\x06itemId\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.peano.v1.ItemStatusR\x06status\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x04R\aversion\x12!\n" +
+	"\x04tags\x18\x05 \x03(\v2\r.peano.v1.TagR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"t\n" +
+	"\x11CreateItemRequest\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\x12,\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.peano.v1.ItemStatusR\x06status\x12\x17\n" +
+	"\atag_ids\x18\x03 \x03(\x04R\x06tagIds\")\n" +
+	"\x0eGetItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x04R\x06itemId\"\xc9\x01\n" +
+	"\x11UpdateItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x04R\x06itemId\x12\x1d\n" +
+	"\acontent\x18\x02 \x01(\tH\x00R\acontent\x88\x01\x01\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.peano.v1.ItemStatusR\x06status\x12\x17\n" +
+	"\atag_ids\x18\x04 \x03(\x04R\x06tagIds\x12\x1d\n" +
+	"\aversion\x18\x05 \x01(\x04H\x01R\aversion\x88\x01\x01B\n" +
+	"\n" +
+	"\b_contentB\n" +
+	"\n" +
+	"\b_version\",\n" +
+	"\x11DeleteItemRequest\x12\x17\n" +
+	"\aitem_id\x18\x01 \x01(\x04R\x06itemId\"\xd1\x01\n" +
+	"\x10ListItemsRequest\x12\"\n" +
+	"\n" +
+	"date_start\x18\x01 \x01(\tH\x00R\tdateStart\x88\x01\x01\x12\x1e\n" +
+	"\bdate_end\x18\x02 \x01(\tH\x01R\adateEnd\x88\x01\x01\x12,\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x14.peano.v1.ItemStatusR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSizeB\r\n" +
+	"\v_date_startB\v\n" +
+	"\t_date_end\"\x80\x01\n" +
+	"\x11ListItemsResponse\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.peano.v1.ItemR\x05items\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x8d\x01\n" +
+	"\x12SearchItemsRequest\x12\x18\n" +
+	"\akeyword\x18\x01 \x01(\tR\akeyword\x12,\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x14.peano.v1.ItemStatusR\x06status\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"T\n" +
+	"\x18GetDailyItemCountRequest\x12\x1d\n" +
+	"\n" +
+	"date_start\x18\x01 \x01(\tR\tdateStart\x12\x19\n" +
+	"\bdate_end\x18\x02 \x01(\tR\adateEnd\"V\n" +
+	"\x0eDailyItemCount\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"a\n" +
+	"\x19GetDailyItemCountResponse\x12D\n" +
+	"\x11daily_item_counts\x18\x01 \x03(\v2\x18.peano.v1.DailyItemCountR\x0fdailyItemCounts*o\n" +
+	"\n" +
+	"ItemStatus\x12\x1b\n" +
+	"\x17ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12ITEM_STATUS_NORMAL\x10\x01\x12\x14\n" +
+	"\x10ITEM_STATUS_DONE\x10\x02\x12\x16\n" +
+	"\x12ITEM_STATUS_MARKED\x10\x032\xa4\x05\n" +
+	"\vItemService\x12O\n" +
+	"\n" +
+	"CreateItem\x12\x1b.peano.v1.CreateItemRequest\x1a\x0e.peano.v1.Item\"\x14\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/items\x12P\n" +
+	"\aGetItem\x12\x18.peano.v1.GetItemRequest\x1a\x0e.peano.v1.Item\"\x1b\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/items/{item_id}\x12Y\n" +
+	"\n" +
+	"UpdateItem\x12\x1b.peano.v1.UpdateItemRequest\x1a\x0e.peano.v1.Item\"\x1e\x82\xd3\xe4\x93\x02\x18:\x01*2\x13/v1/items/{item_id}\x12^\n" +
+	"\n" +
+	"DeleteItem\x12\x1b.peano.v1.DeleteItemRequest\x1a\x16.google.protobuf.Empty\"\x1b\x82\xd3\xe4\x93\x02\x15*\x13/v1/items/{item_id}\x12W\n" +
+	"\tListItems\x12\x1a.peano.v1.ListItemsRequest\x1a\x1b.peano.v1.ListItemsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/v1/items\x12b\n" +
+	"\vSearchItems\x12\x1c.peano.v1.SearchItemsRequest\x1a\x1b.peano.v1.ListItemsResponse\"\x18\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/items:search\x12z\n" +
+	"\x11GetDailyItemCount\x12\".peano.v1.GetDailyItemCountRequest\x1a#.peano.v1.GetDailyItemCountResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/v1/items:dailyCountB Z\x1ebackend/proto/peano/v1;peanov1b\x06proto3"
+
+var (
+	file_peano_v1_item_proto_rawDescOnce sync.Once
+	file_peano_v1_item_proto_rawDescData []byte
+)
+
+func file_peano_v1_item_proto_rawDescGZIP() []byte {
+	file_peano_v1_item_proto_rawDescOnce.Do(func() {
+		file_peano_v1_item_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_peano_v1_item_proto_rawDesc), len(file_peano_v1_item_proto_rawDesc)))
+	})
+	return file_peano_v1_item_proto_rawDescData
+}
+
+var file_peano_v1_item_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_peano_v1_item_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_peano_v1_item_proto_goTypes = []any{
+	(ItemStatus)(0),                   // 0: peano.v1.ItemStatus
+	(*Item)(nil),                      // 1: peano.v1.Item
+	(*CreateItemRequest)(nil),         // 2: peano.v1.CreateItemRequest
+	(*GetItemRequest)(nil),            // 3: peano.v1.GetItemRequest
+	(*UpdateItemRequest)(nil),         // 4: peano.v1.UpdateItemRequest
+	(*DeleteItemRequest)(nil),         // 5: peano.v1.DeleteItemRequest
+	(*ListItemsRequest)(nil),          // 6: peano.v1.ListItemsRequest
+	(*ListItemsResponse)(nil),         // 7: peano.v1.ListItemsResponse
+	(*SearchItemsRequest)(nil),        // 8: peano.v1.SearchItemsRequest
+	(*GetDailyItemCountRequest)(nil),  // 9: peano.v1.GetDailyItemCountRequest
+	(*DailyItemCount)(nil),            // 10: peano.v1.DailyItemCount
+	(*GetDailyItemCountResponse)(nil), // 11: peano.v1.GetDailyItemCountResponse
+	(*Tag)(nil),                       // 12: peano.v1.Tag
+	(*timestamppb.Timestamp)(nil),     // 13: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),             // 14: google.protobuf.Empty
+}
+var file_peano_v1_item_proto_depIdxs = []int32{
+	0,  // 0: peano.v1.Item.status:type_name -> peano.v1.ItemStatus
+	12, // 1: peano.v1.Item.tags:type_name -> peano.v1.Tag
+	13, // 2: peano.v1.Item.created_at:type_name -> google.protobuf.Timestamp
+	13, // 3: peano.v1.Item.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: peano.v1.CreateItemRequest.status:type_name -> peano.v1.ItemStatus
+	0,  // 5: peano.v1.UpdateItemRequest.status:type_name -> peano.v1.ItemStatus
+	0,  // 6: peano.v1.ListItemsRequest.status:type_name -> peano.v1.ItemStatus
+	1,  // 7: peano.v1.ListItemsResponse.items:type_name -> peano.v1.Item
+	0,  // 8: peano.v1.SearchItemsRequest.status:type_name -> peano.v1.ItemStatus
+	13, // 9: peano.v1.DailyItemCount.date:type_name -> google.protobuf.Timestamp
+	10, // 10: peano.v1.GetDailyItemCountResponse.daily_item_counts:type_name -> peano.v1.DailyItemCount
+	2,  // 11: peano.v1.ItemService.CreateItem:input_type -> peano.v1.CreateItemRequest
+	3,  // 12: peano.v1.ItemService.GetItem:input_type -> peano.v1.GetItemRequest
+	4,  // 13: peano.v1.ItemService.UpdateItem:input_type -> peano.v1.UpdateItemRequest
+	5,  // 14: peano.v1.ItemService.DeleteItem:input_type -> peano.v1.DeleteItemRequest
+	6,  // 15: peano.v1.ItemService.ListItems:input_type -> peano.v1.ListItemsRequest
+	8,  // 16: peano.v1.ItemService.SearchItems:input_type -> peano.v1.SearchItemsRequest
+	9,  // 17: peano.v1.ItemService.GetDailyItemCount:input_type -> peano.v1.GetDailyItemCountRequest
+	1,  // 18: peano.v1.ItemService.CreateItem:output_type -> peano.v1.Item
+	1,  // 19: peano.v1.ItemService.GetItem:output_type -> peano.v1.Item
+	1,  // 20: peano.v1.ItemService.UpdateItem:output_type -> peano.v1.Item
+	14, // 21: peano.v1.ItemService.DeleteItem:output_type -> google.protobuf.Empty
+	7,  // 22: peano.v1.ItemService.ListItems:output_type -> peano.v1.ListItemsResponse
+	7,  // 23: peano.v1.ItemService.SearchItems:output_type -> peano.v1.ListItemsResponse
+	11, // 24: peano.v1.ItemService.GetDailyItemCount:output_type -> peano.v1.GetDailyItemCountResponse
+	18, // [18:25] is the sub-list for method output_type
+	11, // [11:18] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_peano_v1_item_proto_init() }
+func file_peano_v1_item_proto_init() {
+	if File_peano_v1_item_proto != nil {
+		return
+	}
+	file_peano_v1_tag_proto_init()
+	file_peano_v1_item_proto_msgTypes[3].OneofWrappers = []any{}
+	file_peano_v1_item_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_peano_v1_item_proto_rawDesc), len(file_peano_v1_item_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_peano_v1_item_proto_goTypes,
+		DependencyIndexes: file_peano_v1_item_proto_depIdxs,
+		EnumInfos:         file_peano_v1_item_proto_enumTypes,
+		MessageInfos:      file_peano_v1_item_proto_msgTypes,
+	}.Build()
+	File_peano_v1_item_proto = out.File
+	file_peano_v1_item_proto_goTypes = nil
+	file_peano_v1_item_proto_depIdxs = nil
+}
@@ -0,0 +1,103 @@
+// Package atom 生成 Atom（RFC 4287）格式的订阅源
+package atom
+
+import (
+	"encoding/xml"
+	"io"
+	"time"
+)
+
+// ContentType Atom 订阅源的 MIME 类型
+const ContentType = "application/atom+xml"
+
+// namespace Atom 命名空间
+const namespace = "http://www.w3.org/2005/Atom"
+
+// Feed 订阅源
+type Feed struct {
+	XMLName xml.Name `xml:"feed"`
+	NS      string   `xml:"xmlns,attr"`
+	ID      string   `xml:"id"`
+	Title   string   `xml:"title"`
+	Updated Time     `xml:"updated"`
+	Author  *Person  `xml:"author,omitempty"`
+	Links   []Link   `xml:"link"`
+	Entries []*Entry `xml:"entry"`
+}
+
+// Entry 订阅条目
+type Entry struct {
+	ID         string     `xml:"id"`
+	Title      string     `xml:"title"`
+	Updated    Time       `xml:"updated"`
+	Published  *Time      `xml:"published,omitempty"`
+	Links      []Link     `xml:"link,omitempty"`
+	Categories []Category `xml:"category,omitempty"`
+	Content    *Text      `xml:"content,omitempty"`
+}
+
+// Person 作者
+type Person struct {
+	Name string `xml:"name"`
+}
+
+// Link 链接
+type Link struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+// Category 分类
+type Category struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr,omitempty"`
+}
+
+// Text 文本内容，Type 为 text 或 html
+type Text struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+// Time Atom 时间，输出为 RFC 3339 格式的 UTC 时间
+type Time time.Time
+
+// MarshalXML 按 RFC 3339 输出时间
+func (t Time) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(time.Time(t).UTC().Format(time.RFC3339), start)
+}
+
+// NewTime 转换时间，用于 Published 等可选字段
+func NewTime(t time.Time) *Time {
+	at := Time(t)
+	return &at
+}
+
+// WriteTo 写出 XML 声明和订阅源
+func (f *Feed) WriteTo(w io.Writer) (int64, error) {
+	f.NS = namespace
+	cw := &countWriter{w: w}
+	if _, err := io.WriteString(cw, xml.Header); err != nil {
+		return cw.n, err
+	}
+	enc := xml.NewEncoder(cw)
+	enc.Indent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return cw.n, err
+	}
+	err := enc.Close()
+	return cw.n, err
+}
+
+// countWriter 统计写出的字节数
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,80 @@
+package atom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFeedWriteTo 测试订阅源输出
+func TestFeedWriteTo(t *testing.T) {
+	updated := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	feed := &Feed{
+		ID:      "urn:test:feed",
+		Title:   "项目",
+		Updated: Time(updated),
+		Author:  &Person{Name: "admin"},
+		Links:   []Link{{Rel: "self", Type: ContentType, Href: "/feed.atom"}},
+		Entries: []*Entry{{
+			ID:         "urn:test:item:1",
+			Title:      "买菜 & 做饭 <今天>",
+			Updated:    Time(updated),
+			Published:  NewTime(updated.Add(-time.Hour)),
+			Categories: []Category{{Term: "life", Label: "生活"}},
+			Content:    &Text{Type: "text", Body: "内容\n第二行"},
+		}},
+	}
+
+	var buf bytes.Buffer
+	n, err := feed.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("输出失败: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("字节数错误: %d != %d", n, buf.Len())
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		`<?xml version="1.0" encoding="UTF-8"?>`,
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		`<updated>2024-03-01T01:30:00Z</updated>`,
+		`<published>2024-03-01T00:30:00Z</published>`,
+		`<title>买菜 &amp; 做饭 &lt;今天&gt;</title>`,
+		`<link rel="self" type="application/atom+xml" href="/feed.atom"></link>`,
+		`<category term="life" label="生活"></category>`,
+		`<content type="text">内容&#xA;第二行</content>`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("输出中缺少 %s\n%s", want, out)
+		}
+	}
+
+	// 输出可以被重新解析
+	var parsed struct {
+		Entries []struct {
+			Title string `xml:"title"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("解析输出失败: %v", err)
+	}
+	if len(parsed.Entries) != 1 || parsed.Entries[0].Title != "买菜 & 做饭 <今天>" {
+		t.Fatalf("解析结果错误: %+v", parsed)
+	}
+}
+
+// TestFeedWithoutEntries 测试没有条目的订阅源
+func TestFeedWithoutEntries(t *testing.T) {
+	feed := &Feed{ID: "urn:test:feed", Title: "空", Updated: Time(time.Unix(0, 0))}
+
+	var buf bytes.Buffer
+	if _, err := feed.WriteTo(&buf); err != nil {
+		t.Fatalf("输出失败: %v", err)
+	}
+	if strings.Contains(buf.String(), "<entry>") || strings.Contains(buf.String(), "<author>") {
+		t.Fatalf("不应输出空的条目或作者: %s", buf.String())
+	}
+}
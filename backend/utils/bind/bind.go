@@ -3,11 +3,14 @@ package bind
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"backend/utils/errorx"
+	"backend/utils/handle"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -21,15 +24,92 @@ type FieldErrorConfig struct {
 	// FieldErrorCodes 字段名到错误码的映射
 	// key: 字段名, value: 该字段的格式错误码
 	FieldErrorCodes map[string]int32
-	// FieldLabels 字段名到中文标签的映射
-	// key: 字段名, value: 中文标签
+	// FieldLabels 字段名到中文标签的映射（可选）
+	// 结构体字段的 label 标签优先，未设置 label 标签时使用该映射
+	// key: 结构体字段名或 json/form/uri 参数名, value: 中文标签
 	FieldLabels map[string]string
 }
 
+// 验证消息键
+const (
+	msgValidationFailed = "bind.validation_failed"
+	msgRequired         = "bind.required"
+	msgFieldInvalid     = "bind.field_invalid"
+	msgRulePrefix       = "bind.rule."
+	msgRuleDefault      = "bind.rule_default"
+)
+
+func init() {
+	// 注册验证消息（{field}: 字段标签, {param}: 规则参数, {tag}: 规则名）
+	errorx.RegisterMessages(errorx.DefaultLocale, map[string]string{
+		msgValidationFailed:        "参数验证失败",
+		msgRequired:                "{field}不能为空",
+		msgFieldInvalid:            "{field}字段验证失败: {reason}",
+		msgRuleDefault:             "验证失败: {tag}",
+		msgRulePrefix + "required": "不能为空",
+		msgRulePrefix + "email":    "邮箱格式不正确",
+		msgRulePrefix + "len":      "长度必须为{param}",
+		msgRulePrefix + "min":      "长度不能少于{param}",
+		msgRulePrefix + "max":      "长度不能超过{param}",
+		msgRulePrefix + "gte":      "必须大于等于{param}",
+		msgRulePrefix + "lte":      "必须小于等于{param}",
+		msgRulePrefix + "gt":       "必须大于{param}",
+		msgRulePrefix + "lt":       "必须小于{param}",
+		msgRulePrefix + "oneof":    "必须是以下值之一: {param}",
+		msgRulePrefix + "regexp":   "格式不正确",
+	})
+	errorx.RegisterMessages(errorx.LocaleEN, map[string]string{
+		msgValidationFailed:        "parameter validation failed",
+		msgRequired:                "{field} is required",
+		msgFieldInvalid:            "{field} is invalid: {reason}",
+		msgRuleDefault:             "failed on rule {tag}",
+		msgRulePrefix + "required": "is required",
+		msgRulePrefix + "email":    "invalid email format",
+		msgRulePrefix + "len":      "length must be {param}",
+		msgRulePrefix + "min":      "length must be at least {param}",
+		msgRulePrefix + "max":      "length must be at most {param}",
+		msgRulePrefix + "gte":      "must be greater than or equal to {param}",
+		msgRulePrefix + "lte":      "must be less than or equal to {param}",
+		msgRulePrefix + "gt":       "must be greater than {param}",
+		msgRulePrefix + "lt":       "must be less than {param}",
+		msgRulePrefix + "oneof":    "must be one of: {param}",
+		msgRulePrefix + "regexp":   "invalid format",
+	})
+}
+
+// RegisterValidator 注册自定义验证规则到 gin 的验证器
+// tag: 验证标签名，在 binding 标签中使用（如 binding:"omitempty,hexcolor"），与内置规则同名时覆盖内置规则
+// fn: 验证函数
+// message: 验证失败时的友好提示（默认语言），支持 {field}（字段标签）和 {param}（规则参数）占位符
+// translations: 可选，其他语言的提示（errorx.L）
+func RegisterValidator(tag string, fn validator.Func, message string, translations ...errorx.LocaleMessage) error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return fmt.Errorf("不支持的验证引擎: %T", binding.Validator.Engine())
+	}
+	if err := v.RegisterValidation(tag, fn); err != nil {
+		return err
+	}
+
+	errorx.RegisterMessages(errorx.DefaultLocale, map[string]string{msgRulePrefix + tag: message})
+	for _, t := range translations {
+		errorx.RegisterMessages(t.Locale, map[string]string{msgRulePrefix + tag: t.Message})
+	}
+	return nil
+}
+
 // HandleBindingError 处理 gin binding 验证错误，转换为 errorx 错误
 // config: 错误码配置
 // err: gin binding 返回的错误
+// 字段标签取自 config.FieldLabels，消息使用默认语言；需要读取 label 标签和按请求语言输出时使用 ShouldBind* 系列函数
 func HandleBindingError(config FieldErrorConfig, err error) error {
+	return handleBindingError(config, nil, errorx.DefaultLocale, err)
+}
+
+// handleBindingError 处理 gin binding 验证错误
+// obj: 绑定的结构体，用于读取字段的 label 标签，可以为 nil
+// locale: 错误消息使用的语言
+func handleBindingError(config FieldErrorConfig, obj interface{}, locale string, err error) error {
 	if err == nil {
 		return nil
 	}
@@ -39,35 +119,37 @@ func HandleBindingError(config FieldErrorConfig, err error) error {
 	if !errors.As(err, &validationErrors) {
 		// 如果不是验证错误，返回通用参数错误
 		if config.InvalidParamCode > 0 {
-			return errorx.New(config.InvalidParamCode, errorx.K("reason", err.Error()))
+			return errorx.NewWithLocale(locale, config.InvalidParamCode, errorx.K("reason", err.Error()))
 		}
 		return errorx.New(0, err.Error())
 	}
 
 	// 处理第一个验证错误
 	if len(validationErrors) == 0 {
+		reason := errorx.Message(locale, msgValidationFailed)
 		if config.InvalidParamCode > 0 {
-			return errorx.New(config.InvalidParamCode, errorx.K("reason", "参数验证失败"))
+			return errorx.NewWithLocale(locale, config.InvalidParamCode, errorx.K("reason", reason))
 		}
-		return errorx.New(0, "参数验证失败")
+		return errorx.New(0, reason)
 	}
 
 	firstErr := validationErrors[0]
 	fieldName := firstErr.Field()
 	tag := firstErr.Tag()
 
-	// 获取字段的中文标签
-	fieldLabel := getFieldLabel(config, fieldName)
+	// 获取字段的标签
+	fieldLabel := getFieldLabel(config, obj, locale, firstErr)
 
 	// 如果是 required 错误
 	if tag == "required" {
 		if config.RequiredCode > 0 {
-			return errorx.New(config.RequiredCode, errorx.K("param", fieldLabel))
+			return errorx.NewWithLocale(locale, config.RequiredCode, errorx.K("param", fieldLabel))
 		}
+		reason := errorx.Message(locale, msgRequired, errorx.K("field", fieldLabel))
 		if config.InvalidParamCode > 0 {
-			return errorx.New(config.InvalidParamCode, errorx.K("reason", fmt.Sprintf("%s不能为空", fieldLabel)))
+			return errorx.NewWithLocale(locale, config.InvalidParamCode, errorx.K("reason", reason))
 		}
-		return errorx.New(0, fmt.Sprintf("%s不能为空", fieldLabel))
+		return errorx.New(0, reason)
 	}
 
 	// 查找字段对应的错误码
@@ -75,25 +157,99 @@ func HandleBindingError(config FieldErrorConfig, err error) error {
 		fieldValue := getFieldValue(firstErr)
 		// 将字段名转换为小写，以匹配错误消息模板中的占位符格式（如 {email}, {mobile}）
 		paramKey := strings.ToLower(fieldName)
-		return errorx.New(errorCode, errorx.K(paramKey, fieldValue))
+		return errorx.NewWithLocale(locale, errorCode, errorx.K(paramKey, fieldValue))
 	}
 
 	// 通用错误处理
-	reason := fmt.Sprintf("%s字段验证失败: %s", fieldLabel, getValidationErrorMessage(firstErr))
+	reason := errorx.Message(locale, msgFieldInvalid,
+		errorx.K("field", fieldLabel),
+		errorx.K("reason", getValidationErrorMessage(firstErr, fieldLabel, locale)),
+	)
 	if config.InvalidParamCode > 0 {
-		return errorx.New(config.InvalidParamCode, errorx.K("reason", reason))
+		return errorx.NewWithLocale(locale, config.InvalidParamCode, errorx.K("reason", reason))
 	}
 	return errorx.New(0, reason)
 }
 
-// getFieldLabel 获取字段的中文标签
-func getFieldLabel(config FieldErrorConfig, fieldName string) string {
-	if config.FieldLabels != nil {
-		if label, ok := config.FieldLabels[fieldName]; ok {
-			return label
+// getFieldLabel 获取字段的标签
+// 默认语言: 结构体 label 标签 -> config.FieldLabels -> 参数名
+// 其他语言: 参数名（json/form/uri 标签），label 标签只提供默认语言的名称
+func getFieldLabel(config FieldErrorConfig, obj interface{}, locale string, fe validator.FieldError) string {
+	field, found := lookupStructField(obj, fe.StructNamespace())
+	paramName := fe.Field()
+	if found {
+		paramName = getParamName(field)
+	}
+
+	if errorx.MatchLocale(locale) == errorx.DefaultLocale {
+		if found {
+			if label := field.Tag.Get("label"); label != "" {
+				return label
+			}
+		}
+		if config.FieldLabels != nil {
+			if label, ok := config.FieldLabels[fe.StructField()]; ok {
+				return label
+			}
+			if label, ok := config.FieldLabels[paramName]; ok {
+				return label
+			}
+		}
+	}
+	return paramName
+}
+
+// getParamName 获取字段在请求中的参数名，依次读取 json、form、uri 标签，都没有时使用字段名
+func getParamName(field reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
 		}
 	}
-	return fieldName
+	return field.Name
+}
+
+// lookupStructField 根据验证错误的命名空间（如 CreateItemReq.Items[0].Content）查找结构体字段
+func lookupStructField(obj interface{}, namespace string) (reflect.StructField, bool) {
+	if obj == nil {
+		return reflect.StructField{}, false
+	}
+
+	// 第一段是结构体类型名
+	parts := strings.Split(namespace, ".")
+	if len(parts) < 2 {
+		return reflect.StructField{}, false
+	}
+
+	t := reflect.TypeOf(obj)
+	var field reflect.StructField
+	for _, part := range parts[1:] {
+		// 去掉切片/数组/map 的下标
+		name, _, _ := strings.Cut(part, "[")
+		t = indirectType(t)
+		if t.Kind() != reflect.Struct {
+			return reflect.StructField{}, false
+		}
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return reflect.StructField{}, false
+		}
+		field = f
+		t = f.Type
+		if strings.Contains(part, "[") {
+			t = indirectType(t).Elem()
+		}
+	}
+	return field, true
+}
+
+// indirectType 返回指针指向的类型
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
 }
 
 // getFieldValue 获取字段的值（用于错误消息）
@@ -104,45 +260,22 @@ func getFieldValue(fe validator.FieldError) string {
 	return ""
 }
 
-// getValidationErrorMessage 获取验证错误消息
-func getValidationErrorMessage(fe validator.FieldError) string {
-	fieldName := fe.Field()
+// getValidationErrorMessage 获取验证规则的友好提示
+// 优先使用自定义验证规则注册的消息，内置规则使用 bind 注册的消息，都没有时输出规则名
+func getValidationErrorMessage(fe validator.FieldError, fieldLabel string, locale string) string {
 	tag := fe.Tag()
-
-	// 根据标签返回友好的错误消息
-	switch tag {
-	case "required":
-		return fmt.Sprintf("%s不能为空", fieldName)
-	case "email":
-		return "邮箱格式不正确"
-	case "len":
-		return fmt.Sprintf("%s长度必须为%s", fieldName, fe.Param())
-	case "min":
-		return fmt.Sprintf("%s长度不能少于%s", fieldName, fe.Param())
-	case "max":
-		return fmt.Sprintf("%s长度不能超过%s", fieldName, fe.Param())
-	case "gte":
-		return fmt.Sprintf("%s必须大于等于%s", fieldName, fe.Param())
-	case "lte":
-		return fmt.Sprintf("%s必须小于等于%s", fieldName, fe.Param())
-	case "gt":
-		return fmt.Sprintf("%s必须大于%s", fieldName, fe.Param())
-	case "lt":
-		return fmt.Sprintf("%s必须小于%s", fieldName, fe.Param())
-	case "oneof":
-		return fmt.Sprintf("%s必须是以下值之一: %s", fieldName, fe.Param())
-	case "regexp":
-		return fmt.Sprintf("%s格式不正确", fieldName)
-	default:
-		return fmt.Sprintf("%s验证失败: %s", fieldName, tag)
+	key := msgRulePrefix + tag
+	if message := errorx.Message(locale, key, errorx.K("field", fieldLabel), errorx.K("param", fe.Param())); message != key {
+		return message
 	}
+	return errorx.Message(locale, msgRuleDefault, errorx.K("tag", tag))
 }
 
 // ShouldBindJSON 绑定并验证 JSON 请求体
 // 如果验证失败，返回 errorx 错误
 func ShouldBindJSON(c *gin.Context, obj interface{}, config FieldErrorConfig) error {
 	if err := c.ShouldBindJSON(obj); err != nil {
-		return HandleBindingError(config, err)
+		return handleBindingError(config, obj, handle.Locale(c), err)
 	}
 	return nil
 }
@@ -151,7 +284,7 @@ func ShouldBindJSON(c *gin.Context, obj interface{}, config FieldErrorConfig) er
 // 如果验证失败，返回 errorx 错误
 func ShouldBindQuery(c *gin.Context, obj interface{}, config FieldErrorConfig) error {
 	if err := c.ShouldBindQuery(obj); err != nil {
-		return HandleBindingError(config, err)
+		return handleBindingError(config, obj, handle.Locale(c), err)
 	}
 	return nil
 }
@@ -160,7 +293,7 @@ func ShouldBindQuery(c *gin.Context, obj interface{}, config FieldErrorConfig) e
 // 如果验证失败，返回 errorx 错误
 func ShouldBindURI(c *gin.Context, obj interface{}, config FieldErrorConfig) error {
 	if err := c.ShouldBindUri(obj); err != nil {
-		return HandleBindingError(config, err)
+		return handleBindingError(config, obj, handle.Locale(c), err)
 	}
 	return nil
 }
@@ -169,7 +302,17 @@ func ShouldBindURI(c *gin.Context, obj interface{}, config FieldErrorConfig) err
 // 如果验证失败，返回 errorx 错误
 func ShouldBind(c *gin.Context, obj interface{}, config FieldErrorConfig) error {
 	if err := c.ShouldBind(obj); err != nil {
-		return HandleBindingError(config, err)
+		return handleBindingError(config, obj, handle.Locale(c), err)
+	}
+	return nil
+}
+
+// Validate 按 binding 标签验证已解析的结构体（如 GraphQL 输入），不读取请求
+// locale: 错误消息使用的语言
+// 如果验证失败，返回 errorx 错误
+func Validate(obj interface{}, locale string, config FieldErrorConfig) error {
+	if err := binding.Validator.ValidateStruct(obj); err != nil {
+		return handleBindingError(config, obj, locale, err)
 	}
 	return nil
 }
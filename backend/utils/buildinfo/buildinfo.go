@@ -0,0 +1,84 @@
+// Package buildinfo 提供构建信息（版本号、提交、构建时间）和进程启动时间
+//
+// 版本信息在构建时通过 ldflags 注入:
+//
+//	go build -ldflags "-X backend/utils/buildinfo.Version=v1.2.0 \
+//	  -X backend/utils/buildinfo.GitCommit=$(git rev-parse --short HEAD) \
+//	  -X backend/utils/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./app/cmd
+//
+// 未注入时 GitCommit 和 BuildTime 会尝试从 Go 嵌入的 VCS 信息中读取
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// 构建时通过 ldflags 注入
+var (
+	// Version 应用版本号
+	Version = "dev"
+	// GitCommit 构建时的 Git 提交
+	GitCommit = ""
+	// BuildTime 构建时间
+	BuildTime = ""
+)
+
+// startTime 进程启动时间
+var startTime = time.Now()
+
+// Info 构建信息
+type Info struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+var (
+	info     Info
+	infoOnce sync.Once
+)
+
+// Get 返回构建信息
+func Get() Info {
+	infoOnce.Do(func() {
+		info = Info{
+			Version:   Version,
+			GitCommit: GitCommit,
+			BuildTime: BuildTime,
+			GoVersion: runtime.Version(),
+		}
+
+		// 未通过 ldflags 注入时，从 VCS 信息补全
+		bi, ok := debug.ReadBuildInfo()
+		if !ok {
+			return
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.GitCommit == "" {
+					info.GitCommit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			}
+		}
+	})
+	return info
+}
+
+// StartTime 返回进程启动时间
+func StartTime() time.Time {
+	return startTime
+}
+
+// Uptime 返回进程运行时长
+func Uptime() time.Duration {
+	return time.Since(startTime)
+}
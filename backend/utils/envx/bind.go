@@ -0,0 +1,172 @@
+package envx
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/utils/errorx"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Bind 根据结构体标签从环境变量填充配置
+// 支持的标签：
+//   - env:"KEY": 环境变量名，未设置该标签的嵌套结构体会递归处理
+//   - default:"...": 环境变量不存在或为空时使用的默认值
+//   - required:"true": 环境变量不存在或为空且没有默认值时报错
+//   - min:"N": 数值（Duration 为秒数）的最小值
+//
+// 支持的字段类型：string、bool、int*、uint*、float*、time.Duration（30s, 1m 或秒数）、[]string（逗号分隔）
+// 所有字段都会被检查，错误会聚合后一起返回，便于启动时一次性发现所有配置问题
+//
+//	type RedisConfig struct {
+//		Host     string        `env:"REDIS_HOST" required:"true"`
+//		Port     int           `env:"REDIS_PORT" default:"6379"`
+//		Timeout  time.Duration `env:"REDIS_TIMEOUT" default:"3s"`
+//		PoolSize int           `env:"REDIS_POOL_SIZE" default:"10" min:"1"`
+//	}
+func Bind(cfg interface{}) error {
+	v := reflect.ValueOf(cfg)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envx.Bind 需要结构体指针，实际为 %T", cfg)
+	}
+
+	var agg errorx.Aggregate
+	bindStruct(v.Elem(), &agg)
+	return agg.Err()
+}
+
+// bindStruct 填充结构体的每个字段
+func bindStruct(v reflect.Value, agg *errorx.Aggregate) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("env")
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct && field.Type != durationType {
+				bindStruct(v.Field(i), agg)
+			}
+			continue
+		}
+
+		agg.Add(bindField(v.Field(i), field, key))
+	}
+}
+
+// bindField 读取环境变量并写入字段
+func bindField(fv reflect.Value, field reflect.StructField, key string) error {
+	value := os.Getenv(key)
+	if value == "" {
+		value = field.Tag.Get("default")
+	}
+	if value == "" {
+		if field.Tag.Get("required") == "true" {
+			return fmt.Errorf("环境变量 %s 未配置或为空", key)
+		}
+		return nil
+	}
+
+	if err := setValue(fv, key, value); err != nil {
+		return err
+	}
+	return checkMin(fv, field, key)
+}
+
+// setValue 按字段类型转换并写入值
+func setValue(fv reflect.Value, key, value string) error {
+	if fv.Type() == durationType {
+		duration, err := parseDuration(key, value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(duration))
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := parseBool(value)
+		if err != nil {
+			return fmt.Errorf("解析环境变量 %s 失败: %w", key, err)
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("解析环境变量 %s 失败: %w", key, err)
+		}
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(value), 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("解析环境变量 %s 失败: %w", key, err)
+		}
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(value), fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("解析环境变量 %s 失败: %w", key, err)
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		if fv.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("环境变量 %s 对应的字段类型 %s 不受支持", key, fv.Type())
+		}
+		fv.Set(reflect.ValueOf(splitList(value)))
+	default:
+		return fmt.Errorf("环境变量 %s 对应的字段类型 %s 不受支持", key, fv.Type())
+	}
+	return nil
+}
+
+// checkMin 检查数值是否小于 min 标签
+func checkMin(fv reflect.Value, field reflect.StructField, key string) error {
+	minStr := field.Tag.Get("min")
+	if minStr == "" {
+		return nil
+	}
+	minValue, err := strconv.ParseFloat(minStr, 64)
+	if err != nil {
+		return fmt.Errorf("字段 %s 的 min 标签无效: %s", field.Name, minStr)
+	}
+
+	var current float64
+	switch {
+	case fv.Type() == durationType:
+		current = time.Duration(fv.Int()).Seconds()
+	case fv.CanInt():
+		current = float64(fv.Int())
+	case fv.CanUint():
+		current = float64(fv.Uint())
+	case fv.CanFloat():
+		current = fv.Float()
+	default:
+		return nil
+	}
+
+	if current < minValue {
+		return fmt.Errorf("环境变量 %s 的值 %v 小于最小值 %s", key, fv.Interface(), minStr)
+	}
+	return nil
+}
+
+// parseBool 解析布尔值，支持 true/false、1/0、yes/no、on/off（不区分大小写）
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "1", "yes", "on":
+		return true, nil
+	case "false", "0", "no", "off":
+		return false, nil
+	}
+	return false, fmt.Errorf("无效的布尔值: %s", value)
+}
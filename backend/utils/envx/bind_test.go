@@ -0,0 +1,60 @@
+package envx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testConfig struct {
+	Host    string        `env:"ENVX_TEST_HOST" required:"true"`
+	Port    int           `env:"ENVX_TEST_PORT" default:"6379" min:"1"`
+	Debug   bool          `env:"ENVX_TEST_DEBUG"`
+	Timeout time.Duration `env:"ENVX_TEST_TIMEOUT" default:"3s"`
+	Origins []string      `env:"ENVX_TEST_ORIGINS"`
+	Nested  struct {
+		Ratio float64 `env:"ENVX_TEST_RATIO" default:"0.5"`
+	}
+}
+
+func TestBind(t *testing.T) {
+	t.Setenv("ENVX_TEST_HOST", "localhost")
+	t.Setenv("ENVX_TEST_DEBUG", "yes")
+	t.Setenv("ENVX_TEST_TIMEOUT", "10")
+	t.Setenv("ENVX_TEST_ORIGINS", "http://a.com, http://b.com")
+
+	var cfg testConfig
+	if err := Bind(&cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Host != "localhost" || cfg.Port != 6379 || !cfg.Debug || cfg.Timeout != 10*time.Second {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+	if len(cfg.Origins) != 2 || cfg.Origins[1] != "http://b.com" {
+		t.Errorf("unexpected origins: %v", cfg.Origins)
+	}
+	if cfg.Nested.Ratio != 0.5 {
+		t.Errorf("expected nested default 0.5, got %v", cfg.Nested.Ratio)
+	}
+}
+
+func TestBindAggregatesErrors(t *testing.T) {
+	t.Setenv("ENVX_TEST_HOST", "")
+	t.Setenv("ENVX_TEST_PORT", "0")
+	t.Setenv("ENVX_TEST_DEBUG", "maybe")
+
+	var cfg testConfig
+	err := Bind(&cfg)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, key := range []string{"ENVX_TEST_HOST", "ENVX_TEST_PORT", "ENVX_TEST_DEBUG"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("expected error to mention %s, got %q", key, err.Error())
+		}
+	}
+
+	if err := Bind(cfg); err == nil {
+		t.Error("expected error for non-pointer")
+	}
+}
@@ -93,19 +93,7 @@ func GetDuration(key string) (time.Duration, error) {
 		return 0, fmt.Errorf("环境变量 %s 未配置或为空", key)
 	}
 
-	// 先尝试作为 Duration 解析（支持 30s, 1m 等格式）
-	duration, err := time.ParseDuration(valueStr)
-	if err == nil {
-		return duration, nil
-	}
-
-	// 如果解析失败，尝试作为秒数解析
-	seconds, err := strconv.Atoi(valueStr)
-	if err != nil {
-		return 0, fmt.Errorf("解析环境变量 %s 失败，期望格式为 Duration（如 30s, 1m）或秒数: %w", key, err)
-	}
-
-	return time.Duration(seconds) * time.Second, nil
+	return parseDuration(key, valueStr)
 }
 
 // GetDurationWithDefault 从环境变量读取时间 Duration（可选，带默认值）
@@ -118,6 +106,11 @@ func GetDurationWithDefault(key string, defaultValue time.Duration) (time.Durati
 		return defaultValue, nil
 	}
 
+	return parseDuration(key, valueStr)
+}
+
+// parseDuration 解析 Duration 字符串，支持 30s, 1m 等格式和纯数字（作为秒数）
+func parseDuration(key, valueStr string) (time.Duration, error) {
 	// 先尝试作为 Duration 解析（支持 30s, 1m 等格式）
 	duration, err := time.ParseDuration(valueStr)
 	if err == nil {
@@ -169,16 +162,7 @@ func GetStringSlice(key string) []string {
 		return []string{}
 	}
 
-	parts := strings.Split(value, ",")
-	result := make([]string, 0, len(parts))
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part != "" {
-			result = append(result, part)
-		}
-	}
-
-	return result
+	return splitList(value)
 }
 
 // GetStringSliceRequired 从环境变量读取字符串切片（必需，逗号分隔）
@@ -195,6 +179,17 @@ func GetStringSliceRequired(key string) ([]string, error) {
 		return nil, fmt.Errorf("环境变量 %s 格式错误", key)
 	}
 
+	result := splitList(value)
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("环境变量 %s 解析后为空", key)
+	}
+
+	return result, nil
+}
+
+// splitList 按逗号分割并去除空元素
+func splitList(value string) []string {
 	parts := strings.Split(value, ",")
 	result := make([]string, 0, len(parts))
 	for _, part := range parts {
@@ -203,10 +198,5 @@ func GetStringSliceRequired(key string) ([]string, error) {
 			result = append(result, part)
 		}
 	}
-
-	if len(result) == 0 {
-		return nil, fmt.Errorf("环境变量 %s 解析后为空", key)
-	}
-
-	return result, nil
+	return result
 }
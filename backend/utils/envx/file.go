@@ -0,0 +1,149 @@
+package envx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFile 从配置文件加载配置，合并到环境变量中
+// 支持 YAML（.yaml, .yml）和 TOML（.toml）格式
+// 已设置的环境变量优先，配置文件中的值只填充未设置的变量
+// 返回从配置文件写入的变量名；文件会被记录，Reload 时重新读取
+//
+// 嵌套的键使用下划线连接并转为大写，以下两种写法等价：
+//
+//	sqlite:
+//	  db_path: data.db
+//
+//	SQLITE_DB_PATH: data.db
+//
+// 列表会使用逗号连接，如 cors_origins: [a, b] 对应 CORS_ORIGINS=a,b
+func LoadFile(path string) ([]string, error) {
+	snapshotProcessEnv()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件 %s 失败: %w", path, err)
+	}
+
+	values, err := parseFile(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var loaded []string
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return nil, fmt.Errorf("设置环境变量 %s 失败: %w", key, err)
+		}
+		loaded = append(loaded, key)
+	}
+	sort.Strings(loaded)
+
+	addSource(source{path: path})
+	return loaded, nil
+}
+
+// parseFile 按扩展名解析配置文件，返回展开后的键值
+func parseFile(path string, content []byte) (map[string]string, error) {
+	raw := make(map[string]interface{})
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(content, &raw); err != nil {
+			return nil, fmt.Errorf("解析 YAML 配置文件 %s 失败: %w", path, err)
+		}
+	case ".toml":
+		if err := toml.Unmarshal(content, &raw); err != nil {
+			return nil, fmt.Errorf("解析 TOML 配置文件 %s 失败: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("不支持的配置文件格式: %s，支持 .yaml, .yml, .toml", path)
+	}
+
+	values := make(map[string]string)
+	if err := flatten("", raw, values); err != nil {
+		return nil, fmt.Errorf("解析配置文件 %s 失败: %w", path, err)
+	}
+	return values, nil
+}
+
+// flatten 将嵌套的配置展开为环境变量形式的键值
+func flatten(prefix string, value interface{}, values map[string]string) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if err := flatten(joinKey(prefix, key), child, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	case map[interface{}]interface{}:
+		for key, child := range v {
+			if err := flatten(joinKey(prefix, fmt.Sprint(key)), child, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if prefix == "" {
+		return fmt.Errorf("配置文件顶层必须是键值对")
+	}
+	if _, ok := values[prefix]; ok {
+		return fmt.Errorf("配置项 %s 重复", prefix)
+	}
+
+	str, err := formatValue(prefix, value)
+	if err != nil {
+		return err
+	}
+	values[prefix] = str
+	return nil
+}
+
+// formatValue 将配置值转换为环境变量字符串
+func formatValue(key string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			str, err := formatValue(key, item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, str)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]interface{}, map[interface{}]interface{}:
+		return "", fmt.Errorf("配置项 %s 的列表元素不能是对象", key)
+	default:
+		// 数字、时间等类型使用默认格式
+		return fmt.Sprint(v), nil
+	}
+}
+
+// joinKey 连接嵌套的键并转为环境变量格式（大写，- 和 . 替换为 _）
+func joinKey(prefix, key string) string {
+	key = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(strings.TrimSpace(key)))
+	if prefix == "" {
+		return key
+	}
+	return prefix + "_" + key
+}
@@ -0,0 +1,78 @@
+package envx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlFile := filepath.Join(dir, "config.yaml")
+	yamlContent := `
+sqlite:
+  db_path: data.db
+  explain-enabled: true
+log:
+  level: debug
+cors_origins: [http://a.com, http://b.com]
+ENVX_TEST_FLAT: 30
+`
+	if err := os.WriteFile(yamlFile, []byte(yamlContent), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// 已设置的环境变量优先
+	t.Setenv("LOG_LEVEL", "warn")
+	for _, key := range []string{"SQLITE_DB_PATH", "SQLITE_EXPLAIN_ENABLED", "CORS_ORIGINS", "ENVX_TEST_FLAT"} {
+		t.Setenv(key, "")
+		os.Unsetenv(key)
+	}
+
+	loaded, err := LoadFile(yamlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sources = nil })
+	if len(loaded) != 4 {
+		t.Errorf("expected 4 loaded keys, got %v", loaded)
+	}
+
+	expected := map[string]string{
+		"SQLITE_DB_PATH":         "data.db",
+		"SQLITE_EXPLAIN_ENABLED": "true",
+		"LOG_LEVEL":              "warn",
+		"CORS_ORIGINS":           "http://a.com,http://b.com",
+		"ENVX_TEST_FLAT":         "30",
+	}
+	for key, want := range expected {
+		if got := os.Getenv(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestParseFileTOML(t *testing.T) {
+	values, err := parseFile("config.toml", []byte(`
+read_only = true
+
+[startup]
+timeout = "30s"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["READ_ONLY"] != "true" || values["STARTUP_TIMEOUT"] != "30s" {
+		t.Errorf("unexpected values: %v", values)
+	}
+}
+
+func TestParseFileErrors(t *testing.T) {
+	if _, err := parseFile("config.json", []byte(`{}`)); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+	if _, err := parseFile("config.yaml", []byte("log:\n  level: info\nLOG_LEVEL: debug\n")); err == nil {
+		t.Error("expected error for duplicated key")
+	}
+}
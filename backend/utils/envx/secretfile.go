@@ -0,0 +1,38 @@
+package envx
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileSuffix 从文件读取配置的变量名后缀，如 JWT_SECRET_FILE=/run/secrets/jwt_secret
+const FileSuffix = "_FILE"
+
+// ResolveFiles 按 Docker/Kubernetes secret 约定解析敏感配置
+// 对每个 key，未设置 key 但设置了 key_FILE 时，读取该文件内容写入 key（去掉末尾换行）
+// 只处理传入的 key，避免误读 LOG_FILE 等本身以 _FILE 结尾的普通配置
+func ResolveFiles(keys ...string) error {
+	for _, key := range keys {
+		if os.Getenv(key) != "" {
+			continue
+		}
+		path := os.Getenv(key + FileSuffix)
+		if path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("读取环境变量 %s%s 指定的文件失败: %w", key, FileSuffix, err)
+		}
+		value := strings.TrimRight(string(content), "\r\n")
+		if value == "" {
+			return fmt.Errorf("环境变量 %s%s 指定的文件 %s 为空", key, FileSuffix, path)
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("设置环境变量 %s 失败: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package envx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveFiles(t *testing.T) {
+	dir := t.TempDir()
+	secretPath := filepath.Join(dir, "jwt_secret")
+	if err := os.WriteFile(secretPath, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("ENVX_TEST_SECRET", "")
+	t.Setenv("ENVX_TEST_SECRET_FILE", secretPath)
+	t.Setenv("ENVX_TEST_SET", "plain")
+	t.Setenv("ENVX_TEST_SET_FILE", filepath.Join(dir, "missing"))
+
+	if err := ResolveFiles("ENVX_TEST_SECRET", "ENVX_TEST_SET"); err != nil {
+		t.Fatal(err)
+	}
+	if got := os.Getenv("ENVX_TEST_SECRET"); got != "s3cret" {
+		t.Errorf("expected secret from file, got %q", got)
+	}
+	if got := os.Getenv("ENVX_TEST_SET"); got != "plain" {
+		t.Errorf("expected plain variable to win, got %q", got)
+	}
+
+	t.Setenv("ENVX_TEST_MISSING_FILE", filepath.Join(dir, "missing"))
+	if err := ResolveFiles("ENVX_TEST_MISSING"); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
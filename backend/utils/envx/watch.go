@@ -0,0 +1,191 @@
+package envx
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// source 已加载的配置来源
+type source struct {
+	path   string
+	dotenv bool // true: .env 文件，false: YAML/TOML 配置文件
+}
+
+// read 重新读取配置来源
+func (s source) read() (map[string]string, error) {
+	if s.dotenv {
+		return godotenv.Read(s.path)
+	}
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return parseFile(s.path, content)
+}
+
+var (
+	sourcesMu sync.Mutex
+	// sources 按加载顺序记录的配置来源（先加载的优先）
+	sources []source
+	// processEnv 加载配置文件前进程已有的环境变量，重新加载时不会被覆盖
+	processEnv     map[string]bool
+	processEnvOnce sync.Once
+
+	watchMu sync.RWMutex
+	// watchers 动态配置的订阅者（key: 环境变量名）
+	watchers = make(map[string][]func(value string))
+)
+
+// LoadEnvFile 加载 .env 文件，已设置的环境变量不会被覆盖
+// 与 LoadFile 一样会记录来源，以便 Reload 时重新读取
+func LoadEnvFile(path string) error {
+	snapshotProcessEnv()
+	if err := godotenv.Load(path); err != nil {
+		return err
+	}
+	addSource(source{path: path, dotenv: true})
+	return nil
+}
+
+// snapshotProcessEnv 记录加载配置文件前进程已有的环境变量
+func snapshotProcessEnv() {
+	processEnvOnce.Do(func() {
+		processEnv = make(map[string]bool)
+		for _, kv := range os.Environ() {
+			key, _, _ := strings.Cut(kv, "=")
+			processEnv[key] = true
+		}
+	})
+}
+
+// addSource 记录配置来源
+func addSource(s source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources = append(sources, s)
+}
+
+// Watch 订阅动态配置的变化
+// 只有被订阅的环境变量会在 Reload 时更新（白名单），其他配置修改后需要重启生效
+// fn 在值变化时调用，参数为新值（变量被删除时为空字符串）
+func Watch(key string, fn func(value string)) {
+	watchMu.Lock()
+	defer watchMu.Unlock()
+	watchers[key] = append(watchers[key], fn)
+}
+
+// Reload 重新读取已加载的 .env 和配置文件，更新被订阅的环境变量并通知订阅者
+// 启动前已存在于进程环境中的变量不会被覆盖，与启动时的优先级一致
+// 返回值发生变化的环境变量名
+func Reload() ([]string, error) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	// 按加载顺序合并，先加载的来源优先
+	merged := make(map[string]string)
+	for _, s := range sources {
+		values, err := s.read()
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range values {
+			if _, ok := merged[key]; !ok {
+				merged[key] = value
+			}
+		}
+	}
+
+	type notification struct {
+		fns   []func(value string)
+		value string
+	}
+	var changed []string
+	var notifications []notification
+
+	watchMu.RLock()
+	for key, fns := range watchers {
+		if processEnv[key] {
+			continue
+		}
+		value := merged[key]
+		if os.Getenv(key) == value {
+			continue
+		}
+		if value == "" {
+			os.Unsetenv(key)
+		} else {
+			os.Setenv(key, value)
+		}
+		changed = append(changed, key)
+		notifications = append(notifications, notification{fns: fns, value: value})
+	}
+	watchMu.RUnlock()
+
+	for _, n := range notifications {
+		for _, fn := range n.fns {
+			fn(n.value)
+		}
+	}
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// WatchFiles 定期检查已加载的 .env 和配置文件，文件修改后调用 Reload
+// 阻塞直到 ctx 结束；每次重新加载后调用 onReload（可以为 nil）
+func WatchFiles(ctx context.Context, interval time.Duration, onReload func(changed []string, err error)) {
+	modTimes := sourceModTimes()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			current := sourceModTimes()
+			if sameModTimes(modTimes, current) {
+				continue
+			}
+			modTimes = current
+
+			changed, err := Reload()
+			if onReload != nil {
+				onReload(changed, err)
+			}
+		}
+	}
+}
+
+// sourceModTimes 返回每个配置来源的修改时间
+func sourceModTimes() map[string]time.Time {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	modTimes := make(map[string]time.Time, len(sources))
+	for _, s := range sources {
+		if info, err := os.Stat(s.path); err == nil {
+			modTimes[s.path] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+// sameModTimes 比较两次检查的修改时间是否一致
+func sameModTimes(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, t := range a {
+		if !b[path].Equal(t) {
+			return false
+		}
+	}
+	return true
+}
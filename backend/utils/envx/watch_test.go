@@ -0,0 +1,55 @@
+package envx
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("ENVX_TEST_DYNAMIC=info\nENVX_TEST_STATIC=a\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ENVX_TEST_DYNAMIC", "")
+	t.Setenv("ENVX_TEST_STATIC", "")
+	os.Unsetenv("ENVX_TEST_DYNAMIC")
+	os.Unsetenv("ENVX_TEST_STATIC")
+
+	sources = nil
+	if err := LoadEnvFile(path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sources = nil
+		delete(watchers, "ENVX_TEST_DYNAMIC")
+	})
+
+	var notified []string
+	Watch("ENVX_TEST_DYNAMIC", func(value string) {
+		notified = append(notified, value)
+	})
+
+	if err := os.WriteFile(path, []byte("ENVX_TEST_DYNAMIC=debug\nENVX_TEST_STATIC=b\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	changed, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changed) != 1 || changed[0] != "ENVX_TEST_DYNAMIC" {
+		t.Errorf("expected only the watched key to change, got %v", changed)
+	}
+	if len(notified) != 1 || notified[0] != "debug" {
+		t.Errorf("unexpected notifications: %v", notified)
+	}
+	if os.Getenv("ENVX_TEST_DYNAMIC") != "debug" || os.Getenv("ENVX_TEST_STATIC") != "a" {
+		t.Errorf("unexpected env: dynamic=%q static=%q", os.Getenv("ENVX_TEST_DYNAMIC"), os.Getenv("ENVX_TEST_STATIC"))
+	}
+
+	// 未变化时不通知
+	if changed, _ := Reload(); len(changed) != 0 || len(notified) != 1 {
+		t.Errorf("expected no changes, got %v", changed)
+	}
+}
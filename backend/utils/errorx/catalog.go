@@ -0,0 +1,51 @@
+package errorx
+
+import "sort"
+
+// CatalogEntry 错误码目录中的一项
+type CatalogEntry struct {
+	Code       int32             `json:"code"`                  // 错误码
+	Messages   map[string]string `json:"messages"`              // 各语言的消息模板（key: 注册时的语言标签），包含默认语言
+	HTTPStatus int               `json:"http_status,omitempty"` // 注册的默认 HTTP 状态码，未注册时为 0
+	Retryable  bool              `json:"retryable,omitempty"`   // 是否注册为可重试
+}
+
+// Catalog 返回所有已注册的错误码、各语言的消息模板、HTTP 状态码和是否可重试，按错误码升序排列
+// 用于导出给前端等外部系统，由同一份注册信息生成错误处理
+func Catalog() []CatalogEntry {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	entries := make(map[int32]*CatalogEntry, len(codeRegistry))
+	entry := func(code int32) *CatalogEntry {
+		e, ok := entries[code]
+		if !ok {
+			e = &CatalogEntry{Code: code, Messages: make(map[string]string)}
+			entries[code] = e
+		}
+		return e
+	}
+	for code, message := range codeRegistry {
+		entry(code).Messages[DefaultLocale] = message
+	}
+	for key, messages := range localeRegistry {
+		for code, message := range messages {
+			entry(code).Messages[localeNames[key]] = message
+		}
+	}
+	for code, status := range statusRegistry {
+		entry(code).HTTPStatus = status
+	}
+	for code := range retryableRegistry {
+		entry(code).Retryable = true
+	}
+
+	catalog := make([]CatalogEntry, 0, len(entries))
+	for _, e := range entries {
+		catalog = append(catalog, *e)
+	}
+	sort.Slice(catalog, func(i, j int) bool {
+		return catalog[i].Code < catalog[j].Code
+	})
+	return catalog
+}
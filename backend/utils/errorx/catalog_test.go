@@ -0,0 +1,43 @@
+package errorx_test
+
+import (
+	"net/http"
+	"sort"
+	"testing"
+
+	"backend/utils/errorx"
+)
+
+func TestCatalog(t *testing.T) {
+	const ErrCatalog = int32(1000300)
+	errorx.Register(ErrCatalog, "目录测试: {id}",
+		errorx.L("en", "catalog test: {id}"),
+		errorx.WithStatus(http.StatusConflict),
+	)
+
+	catalog := errorx.Catalog()
+	if !sort.SliceIsSorted(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code }) {
+		t.Error("expected catalog sorted by code")
+	}
+
+	var found *errorx.CatalogEntry
+	for i := range catalog {
+		switch catalog[i].Code {
+		case ErrCatalog:
+			found = &catalog[i]
+		case ErrInvalidParam:
+			if catalog[i].HTTPStatus != 0 {
+				t.Errorf("expected no status for ErrInvalidParam, got %d", catalog[i].HTTPStatus)
+			}
+		}
+	}
+	if found == nil {
+		t.Fatal("expected registered code in catalog")
+	}
+	if found.HTTPStatus != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", found.HTTPStatus)
+	}
+	if found.Messages[errorx.DefaultLocale] != "目录测试: {id}" || found.Messages["en"] != "catalog test: {id}" {
+		t.Errorf("unexpected messages: %v", found.Messages)
+	}
+}
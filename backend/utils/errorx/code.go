@@ -5,33 +5,84 @@ import "sync"
 var (
 	// codeRegistry 错误码注册表
 	codeRegistry = make(map[int32]string)
+	// statusRegistry 错误码对应的默认 HTTP 状态码
+	statusRegistry = make(map[int32]int)
 	// registryMu 保护注册表的互斥锁
 	registryMu sync.RWMutex
 )
 
+// RegisterOption 注册错误码时的附加选项
+type RegisterOption interface {
+	apply(code int32)
+}
+
+// apply 注册其他语言的消息，调用方需持有写锁
+func (lm LocaleMessage) apply(code int32) {
+	registerLocaleLocked(lm.Locale, code, lm.Message)
+}
+
+// statusOption 默认 HTTP 状态码选项
+type statusOption int
+
+// apply 注册 HTTP 状态码，调用方需持有写锁
+func (s statusOption) apply(code int32) {
+	statusRegistry[code] = int(s)
+}
+
+// WithStatus 指定错误码的默认 HTTP 状态码，用于 Register 的额外参数
+func WithStatus(status int) RegisterOption {
+	return statusOption(status)
+}
+
 // Register 注册错误码和对应的消息模板
 // code: 错误码
-// message: 错误消息模板，支持 {key} 占位符
-func Register(code int32, message string) {
+// message: 默认语言的错误消息模板，支持 {key} 占位符
+// opts: 可选，其他语言的消息模板（errorx.L）或默认 HTTP 状态码（errorx.WithStatus）
+//
+//	errorx.Register(ErrNotFound, "资源不存在: {id}",
+//		errorx.L("en", "resource not found: {id}"),
+//		errorx.WithStatus(http.StatusNotFound),
+//	)
+func Register(code int32, message string, opts ...RegisterOption) {
 	registryMu.Lock()
 	defer registryMu.Unlock()
 	codeRegistry[code] = message
+	for _, opt := range opts {
+		opt.apply(code)
+	}
 }
 
-// RegisterBatch 批量注册错误码
-func RegisterBatch(codes map[int32]string) {
+// RegisterStatus 注册错误码对应的默认 HTTP 状态码
+func RegisterStatus(code int32, status int) {
 	registryMu.Lock()
 	defer registryMu.Unlock()
-	for code, message := range codes {
-		codeRegistry[code] = message
+	statusRegistry[code] = status
+}
+
+// RegisterStatusBatch 批量注册错误码对应的默认 HTTP 状态码
+func RegisterStatusBatch(statuses map[int32]int) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for code, status := range statuses {
+		statusRegistry[code] = status
 	}
 }
 
-// getRegisteredMessage 获取注册的错误消息
-func getRegisteredMessage(code int32) string {
+// HTTPStatus 返回错误码注册的默认 HTTP 状态码，未注册时返回 false
+func HTTPStatus(code int32) (int, bool) {
 	registryMu.RLock()
 	defer registryMu.RUnlock()
-	return codeRegistry[code]
+	status, ok := statusRegistry[code]
+	return status, ok
+}
+
+// RegisterBatch 批量注册错误码
+func RegisterBatch(codes map[int32]string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for code, message := range codes {
+		codeRegistry[code] = message
+	}
 }
 
 // IsRegistered 检查错误码是否已注册
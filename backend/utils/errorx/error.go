@@ -5,26 +5,97 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // StatusError 表示带状态码的错误
 type StatusError interface {
 	error
-	Code() int32   // 错误码
-	Msg() string   // 错误消息
-	Unwrap() error // 返回被包装的原始错误
+	Code() int32    // 错误码
+	Msg() string    // 错误消息
+	Unwrap() error  // 返回被包装的原始错误
+	Stack() []Frame // 错误创建时的调用堆栈，未捕获时返回 nil
+}
+
+// Frame 调用堆栈中的一帧
+type Frame struct {
+	Function string `json:"function"` // 函数名
+	File     string `json:"file"`     // 文件路径
+	Line     int    `json:"line"`     // 行号
+}
+
+// String 返回 "file:line function" 格式的字符串
+func (f Frame) String() string {
+	return fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Function)
+}
+
+// DefaultStackDepth 默认最多捕获的堆栈帧数
+const DefaultStackDepth = 32
+
+var (
+	// stackEnabled 是否捕获堆栈，默认开启
+	stackEnabled atomic.Bool
+	// stackDepth 最多捕获的堆栈帧数
+	stackDepth atomic.Int32
+)
+
+func init() {
+	stackEnabled.Store(true)
+	stackDepth.Store(DefaultStackDepth)
+}
+
+// SetStackEnabled 设置是否在创建错误时捕获堆栈
+// 生产环境可关闭以减少开销，关闭后 Stack() 返回 nil
+func SetStackEnabled(enabled bool) {
+	stackEnabled.Store(enabled)
+}
+
+// StackEnabled 返回是否捕获堆栈
+func StackEnabled() bool {
+	return stackEnabled.Load()
+}
+
+// SetStackDepth 设置最多捕获的堆栈帧数，小于等于 0 时使用 DefaultStackDepth
+// 热点路径上可以调小以减少开销，只影响之后创建的错误
+func SetStackDepth(depth int) {
+	if depth <= 0 {
+		depth = DefaultStackDepth
+	}
+	stackDepth.Store(int32(depth))
+}
+
+// StackDepth 返回最多捕获的堆栈帧数
+func StackDepth() int {
+	return int(stackDepth.Load())
+}
+
+// Frames 返回错误链中第一个 StatusError 的调用堆栈，用于日志等输出结构化的堆栈
+// 不是 StatusError 或未捕获堆栈时返回 nil
+func Frames(err error) []Frame {
+	var statusErr StatusError
+	if err == nil || !errors.As(err, &statusErr) {
+		return nil
+	}
+	return statusErr.Stack()
 }
 
 // statusError 实现 StatusError 接口
 type statusError struct {
-	code    int32
-	msg     string
-	cause   error
-	stack   []uintptr
-	callers []string
+	code      int32
+	msg       string
+	kvs       map[string]string // 占位符参数，用于按其他语言重新生成消息
+	templated bool              // 消息是否由注册的模板生成
+	locale    string            // 生成消息使用的语言
+	cause     error
+	stack     []uintptr // 创建时只记录程序计数器，第一次调用 Stack() 时再解析为堆栈帧
+
+	framesOnce sync.Once
+	frames     []Frame
 }
 
 // Error 实现 error 接口
+// 堆栈不包含在消息中，通过 Stack() 获取
 func (e *statusError) Error() string {
 	var parts []string
 	parts = append(parts, fmt.Sprintf("code=%d", e.code))
@@ -34,10 +105,6 @@ func (e *statusError) Error() string {
 		parts = append(parts, fmt.Sprintf("cause=%s", e.cause.Error()))
 	}
 
-	if len(e.callers) > 0 {
-		parts = append(parts, fmt.Sprintf("stack=%s", strings.Join(e.callers, "\n")))
-	}
-
 	return strings.Join(parts, " ")
 }
 
@@ -56,6 +123,22 @@ func (e *statusError) Unwrap() error {
 	return e.cause
 }
 
+// Stack 返回错误创建时的调用堆栈，解析结果会被缓存
+// 包装已有堆栈的错误时不会重复捕获，此时返回原始错误的堆栈
+func (e *statusError) Stack() []Frame {
+	if len(e.stack) > 0 {
+		e.framesOnce.Do(func() {
+			e.frames = formatStack(e.stack)
+		})
+		return e.frames
+	}
+	var statusErr StatusError
+	if e.cause != nil && errors.As(e.cause, &statusErr) {
+		return statusErr.Stack()
+	}
+	return nil
+}
+
 // New 创建新的错误
 // code: 错误码
 // args: 可选参数，支持以下类型：
@@ -63,8 +146,21 @@ func (e *statusError) Unwrap() error {
 //   - KV: 键值对，用于替换消息模板中的占位符
 //   - error: 被包装的原始错误
 func New(code int32, args ...interface{}) error {
+	err := newStatusError(DefaultLocale, code, args)
+
+	// 捕获堆栈（如果错误还没有堆栈）
+	if !hasStack(err.cause) {
+		err.stack = captureStack(2)
+	}
+
+	return err
+}
+
+// newStatusError 解析参数并使用指定语言的消息模板创建错误（不捕获堆栈）
+func newStatusError(locale string, code int32, args []interface{}) *statusError {
 	err := &statusError{
-		code: code,
+		code:   code,
+		locale: locale,
 	}
 
 	// 解析参数
@@ -88,21 +184,17 @@ func New(code int32, args ...interface{}) error {
 
 	// 获取错误消息
 	if msg == "" {
-		msg = getMessage(code, kvs)
+		msg = getMessage(code, locale, kvs)
+		err.templated = true
 	} else if len(kvs) > 0 {
 		// 如果提供了消息和键值对，使用键值对替换消息中的占位符
 		msg = replacePlaceholders(msg, kvs)
 	}
 
 	err.msg = msg
+	err.kvs = kvs
 	err.cause = cause
 
-	// 捕获堆栈（如果错误还没有堆栈）
-	if !hasStack(cause) {
-		err.stack = captureStack(2)
-		err.callers = formatStack(err.stack)
-	}
-
 	return err
 }
 
@@ -110,6 +202,7 @@ func New(code int32, args ...interface{}) error {
 // err: 要包装的错误
 // code: 错误码
 // args: 可选参数，同 New 函数
+// err 已经是 StatusError 时原样返回，需要重新编码或追加参数时使用 WrapWithCode、AttachKV
 func Wrap(err error, code int32, args ...interface{}) error {
 	if err == nil {
 		return nil
@@ -126,6 +219,193 @@ func Wrap(err error, code int32, args ...interface{}) error {
 	return New(code, args...)
 }
 
+// WrapWithCode 使用新的错误码包装错误，err 已经是 StatusError 时也会重新编码
+// 原错误作为 cause 保留，errors.Is/errors.As 和堆栈不受影响；
+// 错误链中 StatusError 的占位符参数会合并到新错误中，args 中的同名参数优先
+//
+//	// 下层返回带 item_id 的 ErrNotFound，上层改为 ErrExportFailed 并沿用 item_id
+//	return errorx.WrapWithCode(err, ErrExportFailed, errorx.K("operation", "export"))
+func WrapWithCode(err error, code int32, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+
+	if kvs := KVs(err); len(kvs) > 0 {
+		inherited := make([]interface{}, 0, len(kvs)+len(args)+1)
+		for key, value := range kvs {
+			inherited = append(inherited, K(key, value))
+		}
+		args = append(inherited, args...)
+	}
+	// 原错误放在最后，作为 cause
+	args = append(args, err)
+
+	wrapped := newStatusError(DefaultLocale, code, args)
+	if !hasStack(wrapped.cause) {
+		wrapped.stack = captureStack(2)
+	}
+	return wrapped
+}
+
+// AttachKV 为错误链中的 StatusError 追加占位符参数（如 item_id、operation），错误码、原始错误和堆栈不变
+// 同名参数覆盖原有的值；消息由注册模板生成时按合并后的参数重新生成
+// err 为 nil 或错误链中没有 StatusError 时原样返回
+func AttachKV(err error, kvs ...KV) error {
+	if err == nil || len(kvs) == 0 {
+		return err
+	}
+	var se *statusError
+	if !errors.As(err, &se) {
+		return err
+	}
+
+	merged := make(map[string]string, len(se.kvs)+len(kvs))
+	for key, value := range se.kvs {
+		merged[key] = value
+	}
+	added := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		merged[kv.Key] = kv.Value
+		added[kv.Key] = kv.Value
+	}
+
+	attached := &statusError{
+		code:      se.code,
+		kvs:       merged,
+		templated: se.templated,
+		locale:    se.locale,
+		cause:     se.cause,
+		stack:     se.stack,
+	}
+	if se.templated {
+		attached.msg = getMessage(se.code, se.locale, merged)
+	} else {
+		attached.msg = replacePlaceholders(se.msg, added)
+	}
+
+	// StatusError 外层还有其他包装（如 fmt.Errorf）时，在新的 StatusError 外重建这些包装
+	if se != err {
+		return rewrap(err, se, attached)
+	}
+	return attached
+}
+
+// rewrap 将错误链中的 old 替换为 replacement，并重建 old 外层的包装，不包含 old 的错误原样返回
+// errorx 自身的包装按原类型重建，其他包装（如 fmt.Errorf 的 %w）重建为 rewrappedError
+func rewrap(err, old, replacement error) error {
+	if err == old {
+		return replacement
+	}
+
+	switch e := err.(type) {
+	case *statusError:
+		cause := rewrap(e.cause, old, replacement)
+		if cause == e.cause {
+			return err
+		}
+		return &statusError{
+			code:      e.code,
+			msg:       e.msg,
+			kvs:       e.kvs,
+			templated: e.templated,
+			locale:    e.locale,
+			cause:     cause,
+			stack:     e.stack,
+		}
+	case *retryableError:
+		if inner := rewrap(e.err, old, replacement); inner != e.err {
+			return &retryableError{err: inner, retryable: e.retryable}
+		}
+		return err
+	case *indexedError:
+		if inner := rewrap(e.err, old, replacement); inner != e.err {
+			return &indexedError{index: e.index, err: inner}
+		}
+		return err
+	case *multiError:
+		if errs, changed := rewrapAll(e.errs, old, replacement); changed {
+			return &multiError{errs: errs}
+		}
+		return err
+	case interface{ Unwrap() error }:
+		inner := e.Unwrap()
+		if inner == nil {
+			return err
+		}
+		rebuilt := rewrap(inner, old, replacement)
+		if rebuilt == inner {
+			return err
+		}
+		return &rewrappedError{
+			msg:  strings.Replace(err.Error(), inner.Error(), rebuilt.Error(), 1),
+			errs: []error{rebuilt},
+			orig: err,
+		}
+	case interface{ Unwrap() []error }:
+		members := e.Unwrap()
+		errs, changed := rewrapAll(members, old, replacement)
+		if !changed {
+			return err
+		}
+		msg := err.Error()
+		for i, member := range members {
+			if errs[i] != member {
+				msg = strings.Replace(msg, member.Error(), errs[i].Error(), 1)
+			}
+		}
+		return &rewrappedError{msg: msg, errs: errs, orig: err}
+	}
+	return err
+}
+
+// rewrapAll 对每个错误调用 rewrap，返回新的错误列表和是否有错误被替换
+func rewrapAll(errs []error, old, replacement error) ([]error, bool) {
+	rebuilt := make([]error, len(errs))
+	changed := false
+	for i, member := range errs {
+		rebuilt[i] = rewrap(member, old, replacement)
+		if rebuilt[i] != member {
+			changed = true
+		}
+	}
+	return rebuilt, changed
+}
+
+// rewrappedError AttachKV 重建的其他包装：消息中被替换的错误改为新错误的消息，errors.Is 仍能匹配原来的包装
+type rewrappedError struct {
+	msg  string
+	errs []error // 重建后的被包装错误
+	orig error   // 原来的包装
+}
+
+// Error 返回重建后的消息
+func (e *rewrappedError) Error() string {
+	return e.msg
+}
+
+// Unwrap 返回重建后的被包装错误
+func (e *rewrappedError) Unwrap() []error {
+	return e.errs
+}
+
+// Is 匹配原来的包装及其错误链
+func (e *rewrappedError) Is(target error) bool {
+	return errors.Is(e.orig, target)
+}
+
+// KVs 返回错误链中第一个 StatusError 的占位符参数（副本），没有时返回 nil
+func KVs(err error) map[string]string {
+	var se *statusError
+	if err == nil || !errors.As(err, &se) || len(se.kvs) == 0 {
+		return nil
+	}
+	kvs := make(map[string]string, len(se.kvs))
+	for key, value := range se.kvs {
+		kvs[key] = value
+	}
+	return kvs
+}
+
 // Wrapf 使用格式化消息包装错误
 func Wrapf(err error, format string, args ...interface{}) error {
 	if err == nil {
@@ -153,7 +433,17 @@ func Kf(key, format string, args ...interface{}) KV {
 }
 
 // ErrorWithoutStack 返回不包含堆栈信息的错误消息
+// MultiError 会输出每个成员的错误码和消息，带序号的错误会保留序号前缀
 func ErrorWithoutStack(err error) string {
+	var multi MultiError
+	if errors.As(err, &multi) {
+		return multi.Error()
+	}
+	var ie *indexedError
+	if errors.As(err, &ie) {
+		return fmt.Sprintf("[%d] %s", ie.index, ErrorWithoutStack(ie.err))
+	}
+
 	var statusErr StatusError
 	if errors.As(err, &statusErr) {
 		return fmt.Sprintf("code=%d message=%s", statusErr.Code(), statusErr.Msg())
@@ -184,9 +474,9 @@ func ExtractRPCDesc(err error) string {
 	return desc
 }
 
-// getMessage 获取错误消息
-func getMessage(code int32, kvs map[string]string) string {
-	msg := getRegisteredMessage(code)
+// getMessage 获取指定语言的错误消息
+func getMessage(code int32, locale string, kvs map[string]string) string {
+	msg := getLocalizedMessage(code, locale)
 	if msg == "" {
 		return fmt.Sprintf("unknown error code: %d", code)
 	}
@@ -220,30 +510,36 @@ func hasStack(err error) bool {
 	return errors.As(err, &statusErr) && len(statusErr.stack) > 0
 }
 
-// captureStack 捕获堆栈信息
+// captureStack 捕获堆栈信息（最多 StackDepth 帧），关闭堆栈捕获时返回 nil
 func captureStack(skip int) []uintptr {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(skip+1, pcs[:])
-	return pcs[0:n]
+	if !StackEnabled() {
+		return nil
+	}
+	pcs := make([]uintptr, StackDepth())
+	n := runtime.Callers(skip+1, pcs)
+	return pcs[:n:n]
 }
 
-// formatStack 格式化堆栈信息
-func formatStack(pcs []uintptr) []string {
+// formatStack 将程序计数器解析为堆栈帧
+func formatStack(pcs []uintptr) []Frame {
 	if len(pcs) == 0 {
 		return nil
 	}
 
 	frames := runtime.CallersFrames(pcs)
-	var callers []string
+	stack := make([]Frame, 0, len(pcs))
 
 	for {
 		frame, more := frames.Next()
+		stack = append(stack, Frame{
+			Function: frame.Function,
+			File:     frame.File,
+			Line:     frame.Line,
+		})
 		if !more {
 			break
 		}
-		callers = append(callers, fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function))
 	}
 
-	return callers
+	return stack
 }
@@ -3,6 +3,7 @@ package errorx_test
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"backend/utils/errorx"
@@ -118,6 +119,92 @@ func TestWrap(t *testing.T) {
 	}
 }
 
+func ExampleWrapWithCode() {
+	err := errorx.New(ErrNotFound, errorx.K("resource", "item"))
+	err = errorx.WrapWithCode(err, ErrInvalidParam, errorx.K("param", "item_id"))
+	fmt.Println(errorx.ErrorWithoutStack(err))
+	fmt.Println(errorx.KVs(err)["resource"])
+	// Output:
+	// code=1000001 message=invalid parameter: item_id
+	// item
+}
+
+func TestWrapWithCode(t *testing.T) {
+	inner := errorx.New(ErrNotFound, errorx.K("resource", "item"))
+	err := errorx.WrapWithCode(inner, ErrPermissionDenied, errorx.K("reason", "{resource} is locked"), errorx.K("resource", "tag"))
+
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code() != ErrPermissionDenied {
+		t.Fatalf("expected re-coded error, got %v", err)
+	}
+	if !errors.Is(err, inner) {
+		t.Error("expected cause chain to be preserved")
+	}
+	if kvs := errorx.KVs(err); kvs["resource"] != "tag" || kvs["reason"] == "" {
+		t.Errorf("expected merged kvs with new values taking priority, got %v", kvs)
+	}
+	if len(statusErr.Stack()) == 0 || &statusErr.Stack()[0] != &errorx.Frames(inner)[0] {
+		t.Error("expected original stack")
+	}
+
+	plain := errors.New("plain")
+	if err := errorx.WrapWithCode(plain, ErrNotFound); !errors.Is(err, plain) || errorx.Frames(err) == nil {
+		t.Error("expected plain error to be wrapped with stack")
+	}
+	if errorx.WrapWithCode(nil, ErrNotFound) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+func TestAttachKV(t *testing.T) {
+	inner := errorx.New(ErrNotFound, errorx.K("resource", "{id}"), errors.New("record not found"))
+	err := errorx.AttachKV(inner, errorx.K("operation", "delete"), errorx.K("resource", "item"))
+
+	if got := errorx.ErrorWithoutStack(err); got != "code=1000002 message=resource not found: item" {
+		t.Errorf("expected message regenerated from template, got %q", got)
+	}
+	if kvs := errorx.KVs(err); kvs["operation"] != "delete" {
+		t.Errorf("expected attached kv, got %v", kvs)
+	}
+	if errorx.KVs(inner)["operation"] != "" {
+		t.Error("expected original error to be unchanged")
+	}
+	if errors.Unwrap(err) != errors.Unwrap(inner) {
+		t.Error("expected same cause")
+	}
+
+	// 直接传入的消息只替换剩余的占位符
+	direct := errorx.AttachKV(errorx.New(ErrNotFound, "item {id} not found"), errorx.K("id", "42"))
+	if got := errorx.ErrorWithoutStack(direct); got != "code=1000002 message=item 42 not found" {
+		t.Errorf("unexpected message %q", got)
+	}
+
+	// 外层有其他包装时重建包装，消息中只有一份错误码和消息，errors.As 找到追加了参数的 StatusError
+	wrapped := fmt.Errorf("delete: %w", inner)
+	err = errorx.AttachKV(wrapped, errorx.K("item_id", "1"))
+	if !errors.Is(err, wrapped) || errorx.KVs(err)["item_id"] != "1" {
+		t.Errorf("expected outer chain to be kept, got %v", err)
+	}
+	if got := err.Error(); got != "delete: code=1000002 message=resource not found: {id} cause=record not found" {
+		t.Errorf("unexpected wrapped message %q", got)
+	}
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) || errorx.KVs(statusErr)["item_id"] != "1" {
+		t.Errorf("expected errors.As to find attached StatusError")
+	}
+
+	// errorx 自身的包装按原类型重建
+	marked := errorx.AttachKV(errorx.MarkRetryable(inner, true), errorx.K("item_id", "2"))
+	if !errorx.IsRetryable(marked) || errorx.KVs(marked)["item_id"] != "2" {
+		t.Errorf("expected retryable wrapper to be rebuilt, got %v", marked)
+	}
+
+	plain := errors.New("plain")
+	if errorx.AttachKV(plain, errorx.K("a", "b")) != plain {
+		t.Error("expected non-StatusError to be returned unchanged")
+	}
+}
+
 func TestErrorWithoutStack(t *testing.T) {
 	err := errorx.New(ErrPermissionDenied, errorx.K("reason", "test"))
 	msg := errorx.ErrorWithoutStack(err)
@@ -126,3 +213,76 @@ func TestErrorWithoutStack(t *testing.T) {
 		t.Errorf("expected %q, got %q", expected, msg)
 	}
 }
+
+func TestHTTPStatus(t *testing.T) {
+	const ErrConflict = int32(1000200)
+	errorx.Register(ErrConflict, "conflict: {id}", errorx.WithStatus(409))
+
+	if status, ok := errorx.HTTPStatus(ErrConflict); !ok || status != 409 {
+		t.Errorf("expected 409, got %d (registered=%v)", status, ok)
+	}
+	if _, ok := errorx.HTTPStatus(ErrInvalidParam); ok {
+		t.Error("expected no status for code without registration")
+	}
+
+	errorx.RegisterStatusBatch(map[int32]int{ErrNotFound: 404})
+	if status, _ := errorx.HTTPStatus(ErrNotFound); status != 404 {
+		t.Errorf("expected 404, got %d", status)
+	}
+}
+
+func TestStack(t *testing.T) {
+	err := errorx.New(ErrNotFound)
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatal("expected StatusError")
+	}
+
+	stack := statusErr.Stack()
+	if len(stack) == 0 {
+		t.Fatal("expected stack to be captured")
+	}
+	if !strings.HasSuffix(stack[0].Function, "TestStack") || stack[0].Line == 0 {
+		t.Errorf("expected first frame in TestStack, got %s", stack[0])
+	}
+	if strings.Contains(err.Error(), "stack=") {
+		t.Errorf("expected Error() without stack, got %q", err.Error())
+	}
+
+	// 包装已有堆栈的错误时返回原始堆栈
+	wrapped := errorx.New(ErrInvalidParam, err)
+	if !errors.As(wrapped, &statusErr) || len(statusErr.Stack()) != len(stack) {
+		t.Error("expected wrapped error to expose the original stack")
+	}
+
+	errorx.SetStackEnabled(false)
+	defer errorx.SetStackEnabled(true)
+	if errors.As(errorx.New(ErrNotFound), &statusErr) && statusErr.Stack() != nil {
+		t.Error("expected no stack when capture is disabled")
+	}
+}
+
+func TestStackDepthAndFrames(t *testing.T) {
+	errorx.SetStackDepth(2)
+	defer errorx.SetStackDepth(0)
+	if depth := errorx.StackDepth(); depth != 2 {
+		t.Fatalf("expected depth 2, got %d", depth)
+	}
+
+	err := fmt.Errorf("wrapped: %w", errorx.New(ErrNotFound))
+	frames := errorx.Frames(err)
+	if len(frames) != 2 || !strings.HasSuffix(frames[0].Function, "TestStackDepthAndFrames") {
+		t.Fatalf("expected 2 frames starting in TestStackDepthAndFrames, got %v", frames)
+	}
+	if again := errorx.Frames(err); &again[0] != &frames[0] {
+		t.Error("expected formatted frames to be cached")
+	}
+
+	errorx.SetStackDepth(0)
+	if depth := errorx.StackDepth(); depth != errorx.DefaultStackDepth {
+		t.Errorf("expected default depth, got %d", depth)
+	}
+	if errorx.Frames(errors.New("plain")) != nil || errorx.Frames(nil) != nil {
+		t.Error("expected nil frames for non-StatusError")
+	}
+}
@@ -0,0 +1,285 @@
+package errorx
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLocale 默认语言，Register/RegisterBatch 注册的消息属于该语言
+	DefaultLocale = "zh-CN"
+	// LocaleEN 英文
+	LocaleEN = "en"
+)
+
+// LocaleMessage 某个语言下的错误消息
+type LocaleMessage struct {
+	Locale  string
+	Message string
+}
+
+// L 创建某个语言下的错误消息，用于 Register 的额外参数
+func L(locale, message string) LocaleMessage {
+	return LocaleMessage{Locale: locale, Message: message}
+}
+
+// localeRegistry 多语言错误消息注册表（key: 规范化后的语言标签）
+// 默认语言的消息保存在 codeRegistry 中
+var localeRegistry = make(map[string]map[int32]string)
+
+// localeNames 语言标签注册时的原始写法（key: 规范化后的语言标签）
+var localeNames = make(map[string]string)
+
+// messageRegistry 按键注册的消息模板（key: 消息键 -> 规范化后的语言标签 -> 模板）
+// 用于不对应错误码的消息片段，如参数验证规则的提示
+var messageRegistry = make(map[string]map[string]string)
+
+// RegisterMessages 注册某个语言下按键索引的消息模板，支持 {key} 占位符
+func RegisterMessages(locale string, messages map[string]string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	key := normalizeLocale(locale)
+	if key == "" {
+		key = normalizeLocale(DefaultLocale)
+	}
+	for name, message := range messages {
+		templates, ok := messageRegistry[name]
+		if !ok {
+			templates = make(map[string]string)
+			messageRegistry[name] = templates
+		}
+		templates[key] = message
+	}
+}
+
+// Message 返回按键注册的消息在指定语言下的内容，并替换占位符
+// 查找顺序同错误码消息，均未注册时返回消息键本身
+func Message(locale, key string, kvs ...KV) string {
+	registryMu.RLock()
+	templates := messageRegistry[key]
+	locale = normalizeLocale(locale)
+	msg, ok := templates[locale]
+	if !ok {
+		if base, _, found := strings.Cut(locale, "-"); found {
+			msg, ok = templates[base]
+		}
+	}
+	if !ok {
+		msg, ok = templates[normalizeLocale(DefaultLocale)]
+	}
+	registryMu.RUnlock()
+	if !ok {
+		return key
+	}
+
+	if len(kvs) > 0 {
+		values := make(map[string]string, len(kvs))
+		for _, kv := range kvs {
+			values[kv.Key] = kv.Value
+		}
+		msg = replacePlaceholders(msg, values)
+	}
+	return msg
+}
+
+// RegisterLocale 注册错误码在某个语言下的消息模板
+func RegisterLocale(locale string, code int32, message string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registerLocaleLocked(locale, code, message)
+}
+
+// RegisterLocaleBatch 批量注册某个语言下的错误消息模板
+func RegisterLocaleBatch(locale string, codes map[int32]string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for code, message := range codes {
+		registerLocaleLocked(locale, code, message)
+	}
+}
+
+// registerLocaleLocked 注册多语言消息，调用方需持有写锁
+func registerLocaleLocked(locale string, code int32, message string) {
+	key := normalizeLocale(locale)
+	if key == "" || key == normalizeLocale(DefaultLocale) {
+		codeRegistry[code] = message
+		return
+	}
+	messages, ok := localeRegistry[key]
+	if !ok {
+		messages = make(map[int32]string)
+		localeRegistry[key] = messages
+		localeNames[key] = strings.TrimSpace(locale)
+	}
+	messages[code] = message
+}
+
+// Locales 返回已注册的语言（默认语言排在第一位）
+func Locales() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	locales := make([]string, 0, len(localeRegistry)+1)
+	for key := range localeRegistry {
+		locales = append(locales, localeNames[key])
+	}
+	sort.Strings(locales)
+	return append([]string{DefaultLocale}, locales...)
+}
+
+// getLocalizedMessage 获取某个语言下的错误消息模板
+// 查找顺序: 完整语言标签（en-us） -> 主语言（en） -> 默认语言
+func getLocalizedMessage(code int32, locale string) string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	locale = normalizeLocale(locale)
+	if messages, ok := localeRegistry[locale]; ok {
+		if msg, ok := messages[code]; ok {
+			return msg
+		}
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if messages, ok := localeRegistry[base]; ok {
+			if msg, ok := messages[code]; ok {
+				return msg
+			}
+		}
+	}
+	return codeRegistry[code]
+}
+
+// NewWithLocale 使用指定语言的消息模板创建错误
+// 参数同 New；未注册该语言时使用默认语言
+func NewWithLocale(locale string, code int32, args ...interface{}) error {
+	err := newStatusError(locale, code, args)
+
+	// 捕获堆栈（如果错误还没有堆栈）
+	if !hasStack(err.cause) {
+		err.stack = captureStack(2)
+	}
+
+	return err
+}
+
+// LocalizedMsg 返回错误在指定语言下的消息
+// 仅使用注册模板生成的消息会被翻译；直接传入消息的错误和普通错误返回原始消息
+func LocalizedMsg(err error, locale string) string {
+	if err == nil {
+		return ""
+	}
+
+	var se *statusError
+	if errors.As(err, &se) {
+		if !se.templated {
+			return se.msg
+		}
+		msg := getLocalizedMessage(se.code, locale)
+		if msg == "" {
+			return se.msg
+		}
+		return replacePlaceholders(msg, se.kvs)
+	}
+
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Msg()
+	}
+	return err.Error()
+}
+
+// MatchLocale 根据 Accept-Language 请求头选择已注册的语言
+// 按 q 值从高到低匹配，支持完整标签（en-US）和主语言（en）匹配，均不匹配时返回默认语言
+func MatchLocale(acceptLanguage string) string {
+	if acceptLanguage == "" {
+		return DefaultLocale
+	}
+
+	type candidate struct {
+		tag string
+		q   float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = normalizeLocale(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+				q = parsed
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: tag, q: q})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	locales := Locales()
+	for _, c := range candidates {
+		if locale, ok := matchRegisteredLocale(c.tag, locales); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// LookupLocale 查找已注册的语言，用于校验用户设置的语言偏好
+// 支持完整标签和主语言匹配（en-US 匹配 en），返回注册时的写法
+func LookupLocale(locale string) (string, bool) {
+	tag := normalizeLocale(locale)
+	if tag == "" {
+		return "", false
+	}
+	return matchRegisteredLocale(tag, Locales())
+}
+
+// matchRegisteredLocale 在已注册的语言中查找匹配项
+func matchRegisteredLocale(tag string, locales []string) (string, bool) {
+	// 完整标签匹配
+	for _, locale := range locales {
+		if normalizeLocale(locale) == tag {
+			return locale, true
+		}
+	}
+	// 主语言匹配（en-US 匹配 en，zh 匹配 zh-CN）
+	base, _, _ := strings.Cut(tag, "-")
+	for _, locale := range locales {
+		localeBase, _, _ := strings.Cut(normalizeLocale(locale), "-")
+		if localeBase == base {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// normalizeLocale 规范化语言标签: 小写，下划线替换为连字符
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// localeContextKey 语言在 context 中的键
+type localeContextKey struct{}
+
+// WithLocale 将语言写入 context（例如用户偏好设置），优先级高于 Accept-Language
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext 从 context 中读取语言，未设置时返回空字符串
+func LocaleFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	locale, _ := ctx.Value(localeContextKey{}).(string)
+	return locale
+}
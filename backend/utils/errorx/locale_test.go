@@ -0,0 +1,103 @@
+package errorx_test
+
+import (
+	"fmt"
+	"testing"
+
+	"backend/utils/errorx"
+)
+
+const ErrLocalized = int32(1000100)
+
+func init() {
+	errorx.Register(ErrLocalized, "资源不存在: {id}",
+		errorx.L("en", "resource not found: {id}"),
+		errorx.L("ja-JP", "リソースが見つかりません: {id}"),
+	)
+}
+
+func ExampleNewWithLocale() {
+	err := errorx.NewWithLocale("en", ErrLocalized, errorx.K("id", "42"))
+	fmt.Println(errorx.ErrorWithoutStack(err))
+	// Output: code=1000100 message=resource not found: 42
+}
+
+func ExampleLocalizedMsg() {
+	// 使用默认语言创建，按请求语言输出
+	err := errorx.New(ErrLocalized, errorx.K("id", "42"))
+	fmt.Println(errorx.LocalizedMsg(err, "en-US"))
+	fmt.Println(errorx.LocalizedMsg(err, "fr"))
+	// Output:
+	// resource not found: 42
+	// 资源不存在: 42
+}
+
+func TestMatchLocale(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", errorx.DefaultLocale},
+		{"en-US,en;q=0.9", "en"},
+		{"fr-FR,ja;q=0.8,en;q=0.5", "ja-JP"},
+		{"zh", errorx.DefaultLocale},
+		{"fr, *;q=0.1", errorx.DefaultLocale},
+		{"en;q=0, zh-CN;q=0.5", errorx.DefaultLocale},
+		{"de;q=0.2, en_GB;q=0.7", "en"},
+	}
+	for _, tt := range tests {
+		if got := errorx.MatchLocale(tt.header); got != tt.want {
+			t.Errorf("MatchLocale(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestLookupLocale(t *testing.T) {
+	tests := []struct {
+		locale string
+		want   string
+		ok     bool
+	}{
+		{"en", "en", true},
+		{"EN_us", "en", true},
+		{"zh", errorx.DefaultLocale, true},
+		{"ja-jp", "ja-JP", true},
+		{"fr", "", false},
+		{" ", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := errorx.LookupLocale(tt.locale)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LookupLocale(%q) = %q, %v, want %q, %v", tt.locale, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestLocalizedMsgDirectMessage(t *testing.T) {
+	// 直接传入的消息不翻译
+	err := errorx.New(ErrLocalized, "自定义消息")
+	if got := errorx.LocalizedMsg(err, "en"); got != "自定义消息" {
+		t.Errorf("expected direct message, got %q", got)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	errorx.RegisterMessages(errorx.DefaultLocale, map[string]string{"test.min": "长度不能少于{param}"})
+	errorx.RegisterMessages(errorx.LocaleEN, map[string]string{"test.min": "must be at least {param} characters"})
+
+	cases := []struct {
+		locale, want string
+	}{
+		{errorx.DefaultLocale, "长度不能少于3"},
+		{"en-US", "must be at least 3 characters"},
+		{"ja", "长度不能少于3"},
+	}
+	for _, c := range cases {
+		if got := errorx.Message(c.locale, "test.min", errorx.K("param", "3")); got != c.want {
+			t.Errorf("Message(%q) = %q, want %q", c.locale, got, c.want)
+		}
+	}
+	if got := errorx.Message(errorx.LocaleEN, "test.unknown"); got != "test.unknown" {
+		t.Errorf("expected key for unknown message, got %q", got)
+	}
+}
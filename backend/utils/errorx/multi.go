@@ -0,0 +1,167 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiError 聚合多个错误，每个错误保留各自的错误码和消息
+// 实现了 Unwrap() []error，errors.Is/errors.As 可以匹配其中任意一个错误
+type MultiError interface {
+	error
+	Errors() []error // 返回聚合的错误列表
+}
+
+// multiError 实现 MultiError 接口
+type multiError struct {
+	errs []error
+}
+
+// Error 实现 error 接口，每个错误以不含堆栈的形式输出
+func (e *multiError) Error() string {
+	parts := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		parts = append(parts, ErrorWithoutStack(err))
+	}
+	return fmt.Sprintf("%d errors occurred: %s", len(e.errs), strings.Join(parts, "; "))
+}
+
+// Errors 返回聚合的错误列表
+func (e *multiError) Errors() []error {
+	return e.errs
+}
+
+// Unwrap 返回聚合的错误列表，支持 errors.Is/errors.As
+func (e *multiError) Unwrap() []error {
+	return e.errs
+}
+
+// Join 聚合多个错误
+// nil 会被忽略，嵌套的 MultiError 会被展开；没有错误时返回 nil
+func Join(errs ...error) error {
+	var flat []error
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if multi, ok := err.(*multiError); ok {
+			flat = append(flat, multi.errs...)
+			continue
+		}
+		flat = append(flat, err)
+	}
+	if len(flat) == 0 {
+		return nil
+	}
+	return &multiError{errs: flat}
+}
+
+// Aggregate 批量操作的错误收集器
+//
+//	var agg errorx.Aggregate
+//	for i, row := range rows {
+//		if err := importRow(row); err != nil {
+//			agg.AddAt(i, err)
+//		}
+//	}
+//	return agg.Err()
+type Aggregate struct {
+	errs []error
+}
+
+// Add 添加错误，nil 会被忽略
+func (a *Aggregate) Add(err error) {
+	if err != nil {
+		a.errs = append(a.errs, err)
+	}
+}
+
+// AddAt 添加带序号（如行号）的错误，nil 会被忽略
+func (a *Aggregate) AddAt(index int, err error) {
+	if err != nil {
+		a.errs = append(a.errs, WithIndex(index, err))
+	}
+}
+
+// Len 返回已收集的错误数量
+func (a *Aggregate) Len() int {
+	return len(a.errs)
+}
+
+// Err 返回聚合后的错误，没有错误时返回 nil
+func (a *Aggregate) Err() error {
+	return Join(a.errs...)
+}
+
+// indexedError 带序号的错误，用于标识批量操作中失败的行
+type indexedError struct {
+	index int
+	err   error
+}
+
+// Error 实现 error 接口
+func (e *indexedError) Error() string {
+	return fmt.Sprintf("[%d] %s", e.index, e.err.Error())
+}
+
+// Unwrap 返回原始错误
+func (e *indexedError) Unwrap() error {
+	return e.err
+}
+
+// WithIndex 为错误附加序号
+func WithIndex(index int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &indexedError{index: index, err: err}
+}
+
+// IndexOf 返回错误附加的序号
+func IndexOf(err error) (int, bool) {
+	var ie *indexedError
+	if errors.As(err, &ie) {
+		return ie.index, true
+	}
+	return 0, false
+}
+
+// Detail 单个错误的详情，用于在响应中输出聚合错误
+type Detail struct {
+	Index   *int   `json:"index,omitempty" xml:"index,omitempty"` // 序号（如批量导入的行号）
+	Code    int32  `json:"code" xml:"code"`                       // 错误码，非 StatusError 时为 0
+	Message string `json:"message" xml:"message"`                 // 按语言翻译后的错误消息
+}
+
+// Details 将错误展开为详情列表
+// MultiError 展开为每个成员，其他错误返回单个元素
+func Details(err error, locale string) []Detail {
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	var multi MultiError
+	if errors.As(err, &multi) {
+		errs = multi.Errors()
+	}
+
+	details := make([]Detail, 0, len(errs))
+	for _, e := range errs {
+		var detail Detail
+		var ie *indexedError
+		if errors.As(e, &ie) {
+			index := ie.index
+			detail.Index = &index
+			e = ie.err
+		}
+		detail.Message = LocalizedMsg(e, locale)
+		var statusErr StatusError
+		if errors.As(e, &statusErr) {
+			detail.Code = statusErr.Code()
+		}
+		details = append(details, detail)
+	}
+	return details
+}
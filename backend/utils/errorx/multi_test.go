@@ -0,0 +1,75 @@
+package errorx_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/utils/errorx"
+)
+
+func ExampleJoin() {
+	err := errorx.Join(
+		errorx.New(ErrInvalidParam, errorx.K("param", "name")),
+		nil,
+		errorx.New(ErrNotFound, errorx.K("resource", "tag")),
+	)
+	fmt.Println(errorx.ErrorWithoutStack(err))
+	// Output: 2 errors occurred: code=1000001 message=invalid parameter: name; code=1000002 message=resource not found: tag
+}
+
+func ExampleAggregate() {
+	var agg errorx.Aggregate
+	for i, name := range []string{"ok", "", "ok", ""} {
+		if name == "" {
+			agg.AddAt(i, errorx.New(ErrInvalidParam, errorx.K("param", "name")))
+		}
+	}
+
+	for _, detail := range errorx.Details(agg.Err(), errorx.DefaultLocale) {
+		fmt.Printf("row=%d code=%d message=%s\n", *detail.Index, detail.Code, detail.Message)
+	}
+	// Output:
+	// row=1 code=1000001 message=invalid parameter: name
+	// row=3 code=1000001 message=invalid parameter: name
+}
+
+func TestJoin(t *testing.T) {
+	if errorx.Join(nil, nil) != nil {
+		t.Error("expected nil when all errors are nil")
+	}
+
+	plain := errors.New("plain")
+	inner := errorx.Join(errorx.New(ErrNotFound), plain)
+	err := errorx.Join(inner, errorx.New(ErrInvalidParam))
+
+	var multi errorx.MultiError
+	if !errors.As(err, &multi) {
+		t.Fatal("expected MultiError")
+	}
+	if len(multi.Errors()) != 3 {
+		t.Errorf("expected nested errors to be flattened, got %d", len(multi.Errors()))
+	}
+	if !errors.Is(err, plain) {
+		t.Error("errors.Is should match a member error")
+	}
+
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code() != ErrNotFound {
+		t.Error("errors.As should match the first StatusError member")
+	}
+}
+
+func TestDetails(t *testing.T) {
+	err := errorx.Join(errorx.WithIndex(2, errors.New("bad row")), errorx.New(ErrNotFound, errorx.K("resource", "x")))
+	details := errorx.Details(err, errorx.DefaultLocale)
+	if len(details) != 2 {
+		t.Fatalf("expected 2 details, got %d", len(details))
+	}
+	if details[0].Index == nil || *details[0].Index != 2 || details[0].Code != 0 || details[0].Message != "bad row" {
+		t.Errorf("unexpected first detail: %+v", details[0])
+	}
+	if details[1].Index != nil || details[1].Code != ErrNotFound {
+		t.Errorf("unexpected second detail: %+v", details[1])
+	}
+}
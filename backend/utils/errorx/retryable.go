@@ -0,0 +1,100 @@
+package errorx
+
+// retryableRegistry 可重试的错误码
+var retryableRegistry = make(map[int32]bool)
+
+// retryableOption 可重试选项
+type retryableOption struct{}
+
+// apply 将错误码标记为可重试，调用方需持有写锁
+func (retryableOption) apply(code int32) {
+	retryableRegistry[code] = true
+}
+
+// WithRetryable 将错误码标记为可重试（临时性错误，如依赖服务超时），用于 Register 的额外参数
+func WithRetryable() RegisterOption {
+	return retryableOption{}
+}
+
+// RegisterRetryable 将错误码标记为可重试
+func RegisterRetryable(codes ...int32) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, code := range codes {
+		retryableRegistry[code] = true
+	}
+}
+
+// isRetryableCode 检查错误码是否注册为可重试
+func isRetryableCode(code int32) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return retryableRegistry[code]
+}
+
+// retryableError 单个错误上指定的是否可重试，优先于错误码的注册
+type retryableError struct {
+	err       error
+	retryable bool
+}
+
+// Error 返回原始错误的消息
+func (e *retryableError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 返回原始错误
+func (e *retryableError) Unwrap() error {
+	return e.err
+}
+
+// MarkRetryable 指定单个错误是否可重试，优先于错误码的注册
+// 可用于普通错误（如依赖服务返回 503），也可将注册为可重试的错误码标记为不可重试
+func MarkRetryable(err error, retryable bool) error {
+	if err == nil {
+		return nil
+	}
+	return &retryableError{err: err, retryable: retryable}
+}
+
+// IsRetryable 判断错误是否可以重试
+// 沿错误链从外到内查找，以第一个能判断的错误为准：
+//   - MarkRetryable 指定的结果
+//   - 错误码注册为可重试（WithRetryable / RegisterRetryable）
+//   - 实现 Timeout() bool 并返回 true 的错误（如网络超时、context.DeadlineExceeded）
+//
+// 都不满足时返回 false；聚合错误的任意成员可重试即视为可重试
+func IsRetryable(err error) bool {
+	retryable, _ := retryableOf(err)
+	return retryable
+}
+
+// retryableOf 判断错误是否可以重试，第二个返回值表示是否找到了判断依据
+func retryableOf(err error) (bool, bool) {
+	for err != nil {
+		if marked, ok := err.(*retryableError); ok {
+			return marked.retryable, true
+		}
+		if se, ok := err.(*statusError); ok && isRetryableCode(se.code) {
+			return true, true
+		}
+		if t, ok := err.(interface{ Timeout() bool }); ok && t.Timeout() {
+			return true, true
+		}
+
+		switch x := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, member := range x.Unwrap() {
+				if retryable, ok := retryableOf(member); ok && retryable {
+					return true, true
+				}
+			}
+			return false, false
+		case interface{ Unwrap() error }:
+			err = x.Unwrap()
+		default:
+			return false, false
+		}
+	}
+	return false, false
+}
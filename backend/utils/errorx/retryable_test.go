@@ -0,0 +1,58 @@
+package errorx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"backend/utils/errorx"
+)
+
+func TestIsRetryable(t *testing.T) {
+	const (
+		ErrUnavailable = int32(1000400)
+		ErrTimeout     = int32(1000401)
+	)
+	errorx.Register(ErrUnavailable, "service unavailable: {reason}", errorx.WithRetryable())
+	errorx.Register(ErrTimeout, "timeout")
+	errorx.RegisterRetryable(ErrTimeout)
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"plain error", errors.New("boom"), false},
+		{"unregistered code", errorx.New(ErrNotFound), false},
+		{"registered with option", errorx.New(ErrUnavailable), true},
+		{"registered by RegisterRetryable", errorx.New(ErrTimeout), true},
+		{"wrapped by fmt", fmt.Errorf("index: %w", errorx.New(ErrUnavailable)), true},
+		{"cause of other code", errorx.New(ErrInvalidParam, errorx.New(ErrUnavailable)), true},
+		{"deadline exceeded", context.DeadlineExceeded, true},
+		{"canceled", context.Canceled, false},
+		{"marked retryable", errorx.MarkRetryable(errors.New("503"), true), true},
+		{"marked not retryable", errorx.MarkRetryable(errorx.New(ErrUnavailable), false), false},
+		{"joined", errorx.Join(errors.New("boom"), errorx.New(ErrTimeout)), true},
+		{"joined none", errorx.Join(errors.New("boom"), errorx.New(ErrNotFound)), false},
+	}
+	for _, tt := range tests {
+		if got := errorx.IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// 标记不影响错误码和消息
+	err := errorx.MarkRetryable(errorx.New(ErrUnavailable, errorx.K("reason", "es")), true)
+	var statusErr errorx.StatusError
+	if !errors.As(err, &statusErr) || statusErr.Code() != ErrUnavailable {
+		t.Fatal("expected marked error to keep StatusError")
+	}
+	if got := errorx.ErrorWithoutStack(err); got != "code=1000400 message=service unavailable: es" {
+		t.Errorf("unexpected message %q", got)
+	}
+	if errorx.MarkRetryable(nil, true) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
@@ -0,0 +1,120 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"backend/utils/errorx"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain errorx 错误码在 ErrorInfo 详情中的 domain
+const ErrorDomain = "errorx"
+
+// metadataCode ErrorInfo 元数据中错误码的键
+const metadataCode = "code"
+
+// CodeFromHTTPStatus 将 HTTP 状态码转换为 gRPC 状态码
+// 与 grpc-gateway 的 HTTPStatusFromCode 互逆，经过网关后 HTTP 状态码保持不变
+func CodeFromHTTPStatus(httpStatus int) codes.Code {
+	switch httpStatus {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.Aborted
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge, http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	case 499:
+		return codes.Canceled
+	}
+	switch {
+	case httpStatus >= 500:
+		return codes.Internal
+	case httpStatus >= 400:
+		return codes.InvalidArgument
+	default:
+		return codes.Unknown
+	}
+}
+
+// Status 将错误转换为 gRPC 状态
+// errorx 错误：状态码由错误码注册的 HTTP 状态码转换（未注册时为 InvalidArgument），消息按 locale 翻译，错误码写入 ErrorInfo 详情
+// gRPC 状态错误原样返回，ctx 取消和超时分别转换为 Canceled、DeadlineExceeded，其他错误转换为 Unknown
+func Status(err error, locale string) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var statusErr errorx.StatusError
+	if errors.As(err, &statusErr) {
+		httpStatus, ok := errorx.HTTPStatus(statusErr.Code())
+		if !ok {
+			httpStatus = http.StatusBadRequest
+		}
+
+		st := status.New(CodeFromHTTPStatus(httpStatus), errorx.LocalizedMsg(err, locale))
+		code := strconv.FormatInt(int64(statusErr.Code()), 10)
+		withDetails, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+			Reason:   code,
+			Domain:   ErrorDomain,
+			Metadata: map[string]string{metadataCode: code},
+		})
+		if detailErr != nil {
+			return st
+		}
+		return withDetails
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	default:
+		return status.New(codes.Unknown, err.Error())
+	}
+}
+
+// ErrorCode 从 gRPC 状态的 ErrorInfo 详情中读取 errorx 错误码
+func ErrorCode(st *status.Status) (int32, bool) {
+	if st == nil {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != ErrorDomain {
+			continue
+		}
+		code, err := strconv.ParseInt(info.GetMetadata()[metadataCode], 10, 32)
+		if err != nil {
+			return 0, false
+		}
+		return int32(code), true
+	}
+	return 0, false
+}
@@ -0,0 +1,106 @@
+package grpcx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"backend/utils/errorx"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testErrNotFound = int32(99000001)
+	testErrInvalid  = int32(99000002)
+)
+
+func init() {
+	errorx.RegisterBatch(map[int32]string{
+		testErrNotFound: "记录不存在: {id}",
+		testErrInvalid:  "参数错误",
+	})
+	errorx.RegisterLocaleBatch(errorx.LocaleEN, map[int32]string{
+		testErrNotFound: "record not found: {id}",
+	})
+	errorx.RegisterStatus(testErrNotFound, http.StatusNotFound)
+}
+
+// TestStatusFromErrorx 测试 errorx 错误按注册的 HTTP 状态码转换，消息按语言翻译，错误码可以读回
+func TestStatusFromErrorx(t *testing.T) {
+	err := fmt.Errorf("查询失败: %w", errorx.New(testErrNotFound, errorx.K("id", "7")))
+
+	st := Status(err, errorx.LocaleEN)
+	if st.Code() != codes.NotFound {
+		t.Fatalf("期望 NotFound，实际 %s", st.Code())
+	}
+	if st.Message() != "record not found: 7" {
+		t.Fatalf("消息未按语言翻译: %q", st.Message())
+	}
+	code, ok := ErrorCode(st)
+	if !ok || code != testErrNotFound {
+		t.Fatalf("期望读回错误码 %d，实际 %d, ok=%v", testErrNotFound, code, ok)
+	}
+
+	// 经过 gRPC 传输后仍能读回错误码
+	received, _ := status.FromError(st.Err())
+	if code, ok := ErrorCode(received); !ok || code != testErrNotFound {
+		t.Fatalf("传输后期望读回错误码 %d，实际 %d, ok=%v", testErrNotFound, code, ok)
+	}
+}
+
+// TestStatusUnregisteredHTTPStatus 测试未注册 HTTP 状态码的错误码转换为 InvalidArgument
+func TestStatusUnregisteredHTTPStatus(t *testing.T) {
+	st := Status(errorx.New(testErrInvalid), errorx.DefaultLocale)
+	if st.Code() != codes.InvalidArgument || st.Message() != "参数错误" {
+		t.Fatalf("期望 InvalidArgument/参数错误，实际 %s/%q", st.Code(), st.Message())
+	}
+}
+
+// TestStatusOtherErrors 测试非 errorx 错误的转换
+func TestStatusOtherErrors(t *testing.T) {
+	cases := []struct {
+		err  error
+		want codes.Code
+	}{
+		{status.Error(codes.PermissionDenied, "denied"), codes.PermissionDenied},
+		{fmt.Errorf("wrap: %w", context.Canceled), codes.Canceled},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{errors.New("boom"), codes.Unknown},
+	}
+	for _, c := range cases {
+		st := Status(c.err, errorx.DefaultLocale)
+		if st.Code() != c.want {
+			t.Errorf("%v: 期望 %s，实际 %s", c.err, c.want, st.Code())
+		}
+		if _, ok := ErrorCode(st); ok {
+			t.Errorf("%v: 不应包含错误码", c.err)
+		}
+	}
+	if Status(nil, errorx.DefaultLocale) != nil {
+		t.Error("nil 错误应返回 nil")
+	}
+}
+
+// TestCodeFromHTTPStatus 测试 HTTP 状态码转换
+func TestCodeFromHTTPStatus(t *testing.T) {
+	cases := map[int]codes.Code{
+		http.StatusBadRequest:          codes.InvalidArgument,
+		http.StatusUnauthorized:        codes.Unauthenticated,
+		http.StatusNotFound:            codes.NotFound,
+		http.StatusConflict:            codes.Aborted,
+		http.StatusTooManyRequests:     codes.ResourceExhausted,
+		http.StatusServiceUnavailable:  codes.Unavailable,
+		http.StatusInternalServerError: codes.Internal,
+		http.StatusBadGateway:          codes.Internal,
+		http.StatusTeapot:              codes.InvalidArgument,
+	}
+	for httpStatus, want := range cases {
+		if got := CodeFromHTTPStatus(httpStatus); got != want {
+			t.Errorf("%d: 期望 %s，实际 %s", httpStatus, want, got)
+		}
+	}
+}
@@ -5,18 +5,21 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"backend/utils/errorx"
 	"backend/utils/logs"
+	"backend/utils/trace"
 
 	"github.com/gin-gonic/gin"
 )
 
 // ErrorConfig 错误处理配置
 type ErrorConfig struct {
-	// DefaultStatusCode 默认 HTTP 状态码（当错误不是 StatusError 时使用）
+	// DefaultStatusCode 默认 HTTP 状态码（当错误不是 StatusError，或错误码未注册 HTTP 状态码时使用）
 	DefaultStatusCode int
 	// DefaultErrorCode 默认错误码（当错误不是 StatusError 时使用）
 	DefaultErrorCode int32
@@ -27,9 +30,12 @@ type ErrorConfig struct {
 
 // Response 统一响应结构体（用于 Swagger 文档）
 type Response struct {
-	Code    int32       `json:"code" example:"0"`                 // 响应码，0 表示成功
-	Message string      `json:"message,omitempty" example:"操作成功"` // 响应消息（可选）
-	Data    interface{} `json:"data,omitempty"`                   // 响应数据（可选）
+	Code      int32           `json:"code" xml:"code" example:"0"`                                                            // 响应码，0 表示成功
+	Message   string          `json:"message,omitempty" xml:"message,omitempty" example:"操作成功"`                               // 响应消息（可选）
+	Data      interface{}     `json:"data,omitempty" xml:"data,omitempty"`                                                    // 响应数据（可选）
+	Errors    []errorx.Detail `json:"errors,omitempty" xml:"-"`                                                               // 聚合错误详情（可选，批量操作部分失败时返回）
+	TraceID   string          `json:"trace_id,omitempty" xml:"trace_id,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"` // 链路追踪 ID（仅错误响应）
+	RequestID string          `json:"request_id,omitempty" xml:"request_id,omitempty" example:"req_P8oBUcz6utMujQ"`           // 请求 ID（仅错误响应）
 }
 
 // HandleError 统一处理错误并返回响应
@@ -51,30 +57,42 @@ func HandleError(c *gin.Context, err error, operation string, config *ErrorConfi
 		}
 	}
 
+	// 聚合错误：输出 errors 数组
+	var multi errorx.MultiError
+	if errors.As(err, &multi) {
+		logStructured(config.LogLevel, operation+"失败",
+			"error", errorx.ErrorWithoutStack(err),
+			"error_count", len(multi.Errors()),
+			"path", c.Request.URL.Path,
+			"method", c.Request.Method,
+			"ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+		writeMultiError(c, multi, config)
+		return
+	}
+
 	// 检查是否是 StatusError 类型
 	var statusErr errorx.StatusError
 	if errors.As(err, &statusErr) {
 		// 使用结构化日志记录
-		logStructured(config.LogLevel, operation+"失败",
+		keyvals := withStack(config.LogLevel, statusErr, []interface{}{
 			"error_code", statusErr.Code(),
 			"error_msg", statusErr.Msg(),
 			"path", c.Request.URL.Path,
 			"method", c.Request.Method,
 			"ip", c.ClientIP(),
 			"user_agent", c.Request.UserAgent(),
-		)
+		})
+		logStructured(config.LogLevel, operation+"失败", keyvals...)
 
-		// 使用配置的状态码，如果没有配置则使用默认的 BadRequest
-		statusCode := config.DefaultStatusCode
-		if statusCode == 0 {
-			statusCode = http.StatusBadRequest
-		}
+		// 优先使用错误码注册的状态码，其次使用配置的状态码，都没有时使用 BadRequest
+		statusCode := resolveStatusCode(statusErr.Code(), config)
 
-		// 返回 JSON 响应
-		c.JSON(statusCode, gin.H{
-			"code":    statusErr.Code(),
-			"message": statusErr.Msg(),
-		})
+		// 返回 JSON 响应（消息按请求语言翻译）
+		locale := Locale(c)
+		c.Header("Content-Language", locale)
+		writeError(c, statusCode, statusErr.Code(), errorx.LocalizedMsg(err, locale), nil)
 		return
 	}
 
@@ -92,14 +110,7 @@ func HandleError(c *gin.Context, err error, operation string, config *ErrorConfi
 		statusCode = http.StatusBadRequest
 	}
 
-	response := gin.H{
-		"message": err.Error(),
-	}
-	if config.DefaultErrorCode > 0 {
-		response["code"] = config.DefaultErrorCode
-	}
-
-	c.JSON(statusCode, response)
+	writeError(c, statusCode, config.DefaultErrorCode, err.Error(), nil)
 }
 
 // HandleErrorWithContext 带上下文的错误处理
@@ -120,30 +131,42 @@ func HandleErrorWithContext(c *gin.Context, err error, operation string, config
 
 	ctx := c.Request.Context()
 
+	// 聚合错误：输出 errors 数组
+	var multi errorx.MultiError
+	if errors.As(err, &multi) {
+		logStructuredWithContext(ctx, config.LogLevel, operation+"失败",
+			"error", errorx.ErrorWithoutStack(err),
+			"error_count", len(multi.Errors()),
+			"path", c.Request.URL.Path,
+			"method", c.Request.Method,
+			"ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+		writeMultiError(c, multi, config)
+		return
+	}
+
 	// 检查是否是 StatusError 类型
 	var statusErr errorx.StatusError
 	if errors.As(err, &statusErr) {
 		// 使用结构化日志记录（带上下文）
-		logStructuredWithContext(ctx, config.LogLevel, operation+"失败",
+		keyvals := withStack(config.LogLevel, statusErr, []interface{}{
 			"error_code", statusErr.Code(),
 			"error_msg", statusErr.Msg(),
 			"path", c.Request.URL.Path,
 			"method", c.Request.Method,
 			"ip", c.ClientIP(),
 			"user_agent", c.Request.UserAgent(),
-		)
+		})
+		logStructuredWithContext(ctx, config.LogLevel, operation+"失败", keyvals...)
 
-		// 使用配置的状态码，如果没有配置则使用默认的 BadRequest
-		statusCode := config.DefaultStatusCode
-		if statusCode == 0 {
-			statusCode = http.StatusBadRequest
-		}
+		// 优先使用错误码注册的状态码，其次使用配置的状态码，都没有时使用 BadRequest
+		statusCode := resolveStatusCode(statusErr.Code(), config)
 
-		// 返回 JSON 响应
-		c.JSON(statusCode, gin.H{
-			"code":    statusErr.Code(),
-			"message": statusErr.Msg(),
-		})
+		// 返回 JSON 响应（消息按请求语言翻译）
+		locale := Locale(c)
+		c.Header("Content-Language", locale)
+		writeError(c, statusCode, statusErr.Code(), errorx.LocalizedMsg(err, locale), nil)
 		return
 	}
 
@@ -161,26 +184,165 @@ func HandleErrorWithContext(c *gin.Context, err error, operation string, config
 		statusCode = http.StatusBadRequest
 	}
 
+	writeError(c, statusCode, config.DefaultErrorCode, err.Error(), nil)
+}
+
+// writeMultiError 返回聚合错误响应
+// code、message 和 HTTP 状态码取自第一个错误，errors 数组包含所有错误的错误码、消息和序号
+func writeMultiError(c *gin.Context, multi errorx.MultiError, config *ErrorConfig) {
+	locale := Locale(c)
+	details := errorx.Details(multi, locale)
+
+	statusCode := config.DefaultStatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusBadRequest
+	}
+	var code int32
+	var message string
+	if len(details) > 0 {
+		code = details[0].Code
+		message = details[0].Message
+		if status, ok := errorx.HTTPStatus(code); ok {
+			statusCode = status
+		}
+	}
+	if code == 0 {
+		code = config.DefaultErrorCode
+	}
+
+	c.Header("Content-Language", locale)
+	writeError(c, statusCode, code, message, details)
+}
+
+// writeError 按配置的格式输出错误响应
+// 默认为 {code, message}，启用 problem details 时输出 application/problem+json（XML 协商时为 application/problem+xml）
+// code 为 0 时不输出 code 字段，details 为空时不输出 errors 字段
+// context 中有 trace_id/request_id 时同时写入响应体和响应头，便于根据用户反馈的错误定位日志
+// 每次输出都会按错误码、路由和 HTTP 状态码计数（指标名称 http_errors_total）
+func writeError(c *gin.Context, statusCode int, code int32, message string, details []errorx.Detail) {
+	recordError(c, statusCode, code)
+
+	ctx := c.Request.Context()
+	traceID, requestID := trace.TraceID(ctx), trace.RequestID(ctx)
+	if traceID != "" {
+		c.Header(trace.TraceIDHeader, traceID)
+	}
+	if requestID != "" {
+		c.Header(trace.RequestIDHeader, requestID)
+	}
+
+	if ProblemDetailsEnabled() {
+		problem := ProblemDetails{
+			Type:      problemTypeBlank,
+			Title:     http.StatusText(statusCode),
+			Status:    statusCode,
+			Detail:    message,
+			Instance:  c.Request.URL.Path,
+			Code:      code,
+			TraceID:   traceID,
+			RequestID: requestID,
+			Errors:    details,
+		}
+		switch NegotiateFormat(c) {
+		case FormatJSON:
+			c.Header("Content-Type", ProblemContentType)
+		case FormatXML:
+			c.Header("Content-Type", ProblemXMLContentType)
+		}
+		respond(c, statusCode, problem, xmlProblem{ProblemDetails: problem, Errors: newXMLDetails(details)})
+		return
+	}
+
 	response := gin.H{
-		"message": err.Error(),
+		"message": message,
+	}
+	if code > 0 {
+		response["code"] = code
+	}
+	if len(details) > 0 {
+		response["errors"] = details
+	}
+	if traceID != "" {
+		response["trace_id"] = traceID
+	}
+	if requestID != "" {
+		response["request_id"] = requestID
+	}
+	respond(c, statusCode, response, xmlResponse{
+		Response: Response{
+			Code:      code,
+			Message:   message,
+			TraceID:   traceID,
+			RequestID: requestID,
+		},
+		Errors: newXMLDetails(details),
+	})
+}
+
+// withStack error 级别日志附带结构化的错误堆栈，其他级别不输出以减少噪音
+func withStack(level string, statusErr errorx.StatusError, keyvals []interface{}) []interface{} {
+	if level != "error" {
+		return keyvals
 	}
-	if config.DefaultErrorCode > 0 {
-		response["code"] = config.DefaultErrorCode
+	if stack := statusErr.Stack(); len(stack) > 0 {
+		keyvals = append(keyvals, "stack", stack)
 	}
+	return keyvals
+}
 
-	c.JSON(statusCode, response)
+// resolveStatusCode 根据错误码解析 HTTP 状态码
+func resolveStatusCode(code int32, config *ErrorConfig) int {
+	if status, ok := errorx.HTTPStatus(code); ok {
+		return status
+	}
+	if config.DefaultStatusCode != 0 {
+		return config.DefaultStatusCode
+	}
+	return http.StatusBadRequest
+}
+
+// Locale 返回当前请求使用的语言
+// 优先使用 context 中设置的语言（如用户偏好），其次根据 Accept-Language 匹配已注册的语言
+func Locale(c *gin.Context) string {
+	if locale := errorx.LocaleFromContext(c.Request.Context()); locale != "" {
+		return locale
+	}
+	return errorx.MatchLocale(c.GetHeader("Accept-Language"))
+}
+
+// TimezoneHeader 客户端时区请求头（IANA 时区名，如 Asia/Shanghai）
+const TimezoneHeader = "X-Timezone"
+
+// Location 返回当前请求使用的时区，用于解析 today、last-7-days 等相对日期
+// 优先使用 X-Timezone 请求头，其次使用 tz 查询参数，都没有或无法识别时使用 UTC
+func Location(c *gin.Context) *time.Location {
+	name := c.GetHeader(TimezoneHeader)
+	if name == "" {
+		name = c.Query("tz")
+	}
+	if name == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
 
 // Success 返回成功响应
+// 启用 XML、MessagePack 响应格式时按 Accept 请求头协商，默认为 JSON
 func Success(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusOK, gin.H{
+	respond(c, http.StatusOK, gin.H{
 		"code": 0,
 		"data": data,
-	})
+	}, xmlResponse{Response: Response{Data: data}})
 }
 
 // SuccessWithMessage 返回带消息的成功响应
+// message 为按键注册的消息（errorx.RegisterMessages）时按请求语言翻译，否则原样返回
 func SuccessWithMessage(c *gin.Context, message string, data interface{}) {
+	message = errorx.Message(Locale(c), message)
 	response := gin.H{
 		"code":    0,
 		"message": message,
@@ -188,7 +350,7 @@ func SuccessWithMessage(c *gin.Context, message string, data interface{}) {
 	if data != nil {
 		response["data"] = data
 	}
-	c.JSON(http.StatusOK, response)
+	respond(c, http.StatusOK, response, xmlResponse{Response: Response{Message: message, Data: data}})
 }
 
 // logStructured 根据日志级别记录结构化日志
@@ -254,6 +416,24 @@ func logStructuredWithContext(ctx context.Context, level string, msg string, key
 	}
 }
 
+// SSEEvent 自带事件 ID 的数据（如 sse.Event），StreamSSE 输出 SSEEventID() 作为 id 行，序列化 SSEData() 作为 data 行
+type SSEEvent interface {
+	SSEEventID() string
+	SSEData() any
+}
+
+// SSEErrorEvent 可能表示订阅被服务端中止的数据（如 sse.Event），SSEError() 不为 nil 时
+// StreamSSE 发送错误事件后结束，不再发送 done 事件，客户端可以带 Last-Event-ID 重连
+type SSEErrorEvent interface {
+	SSEError() error
+}
+
+// SSENamedEvent 自带事件名称的数据（如 sse.Event 的保活数据），SSEEventName() 不为空时代替 EventName 作为 event 行，
+// 订阅被中止的数据代替 ErrorEventName
+type SSENamedEvent interface {
+	SSEEventName() string
+}
+
 // SSEConfig SSE 配置选项
 type SSEConfig struct {
 	EventName     string        // 事件名称，默认为 "message"
@@ -263,15 +443,49 @@ type SSEConfig struct {
 	OnConnect     func()        // 连接建立时的回调
 	OnDisconnect  func()        // 连接断开时的回调
 	OnError       func(error)   // 发生错误时的回调
+
+	// EventID 生成事件 ID（SSE 规范：id: xxx），客户端重连时通过 Last-Event-ID 请求头带回最后收到的 ID
+	// 为 nil 时数据实现 SSEEvent 则使用其事件 ID；返回空字符串时不输出 id 行
+	EventID func(data any) string
+	// Serializer 将数据序列化为 data 行的内容，默认使用 json.Marshal
+	// 可用于输出预渲染的字符串或 protojson 等格式，内容包含换行时按规范拆分为多行 data
+	Serializer func(data any) (string, error)
+	// ErrorEventName 序列化失败时发送的错误事件名称，默认为 "error"
+	ErrorEventName string
+}
+
+// JSONSerializer 默认的 SSE 序列化函数
+func JSONSerializer(data any) (string, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// StringSerializer 直接输出预渲染的字符串（string、[]byte 或 fmt.Stringer），其他类型使用 JSON 序列化
+func StringSerializer(data any) (string, error) {
+	switch v := data.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		return JSONSerializer(data)
+	}
 }
 
 // DefaultSSEConfig 默认 SSE 配置
 func DefaultSSEConfig() SSEConfig {
 	return SSEConfig{
-		EventName:     "message",
-		EnablePing:    true,
-		PingInterval:  30 * time.Second,
-		RetryInterval: 3000,
+		EventName:      "message",
+		EnablePing:     true,
+		PingInterval:   30 * time.Second,
+		RetryInterval:  3000,
+		Serializer:     JSONSerializer,
+		ErrorEventName: "error",
 	}
 }
 
@@ -291,6 +505,12 @@ func StreamSSE[T any](c *gin.Context, dataChan <-chan T, config ...SSEConfig) {
 		if cfg.RetryInterval == 0 {
 			cfg.RetryInterval = 3000
 		}
+		if cfg.Serializer == nil {
+			cfg.Serializer = JSONSerializer
+		}
+		if cfg.ErrorEventName == "" {
+			cfg.ErrorEventName = "error"
+		}
 	}
 
 	// 设置 SSE 响应头（符合 SSE 规范）
@@ -322,9 +542,8 @@ func StreamSSE[T any](c *gin.Context, dataChan <-chan T, config ...SSEConfig) {
 	notify := c.Writer.CloseNotify()
 
 	// 发送 SSE 事件的辅助函数
-	sendEvent := func(eventName, data string) bool {
-		_, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", eventName, data)
-		if err != nil {
+	sendEvent := func(id, eventName, data string) bool {
+		if err := writeSSEEvent(c.Writer, id, eventName, data); err != nil {
 			if cfg.OnError != nil {
 				cfg.OnError(err)
 			}
@@ -360,22 +579,57 @@ func StreamSSE[T any](c *gin.Context, dataChan <-chan T, config ...SSEConfig) {
 		case data, ok := <-dataChan:
 			if !ok {
 				// 通道已关闭，发送 done 事件后结束
-				sendEvent("done", `{"status":"completed"}`)
+				sendEvent("", "done", `{"status":"completed"}`)
 				cleanup()
 				return
 			}
 
-			// 序列化数据
-			jsonData, err := json.Marshal(data)
+			// 事件名称
+			eventName := cfg.EventName
+			named := ""
+			if event, ok := any(data).(SSENamedEvent); ok && event.SSEEventName() != "" {
+				named = event.SSEEventName()
+				eventName = named
+			}
+
+			// 订阅被中止，数据自带事件名称时（如服务关闭）代替 ErrorEventName
+			if event, ok := any(data).(SSEErrorEvent); ok && event.SSEError() != nil {
+				if named == "" {
+					named = cfg.ErrorEventName
+				}
+				errData, _ := json.Marshal(gin.H{"error": event.SSEError().Error()})
+				sendEvent("", named, string(errData))
+				cleanup()
+				return
+			}
+
+			// 事件 ID
+			var id string
+			var value any = data
+			if event, ok := value.(SSEEvent); ok {
+				id = event.SSEEventID()
+				value = event.SSEData()
+			}
+			if cfg.EventID != nil {
+				id = cfg.EventID(value)
+			}
+
+			// 序列化数据，失败时发送错误事件，客户端可以区分错误帧和数据帧
+			payload, err := cfg.Serializer(value)
 			if err != nil {
 				if cfg.OnError != nil {
 					cfg.OnError(err)
 				}
+				errData, _ := json.Marshal(gin.H{"id": id, "error": err.Error()})
+				if !sendEvent("", cfg.ErrorEventName, string(errData)) {
+					cleanup()
+					return
+				}
 				continue
 			}
 
-			// 发送 SSE 事件（SSE 规范：event: name\ndata: data\n\n）
-			if !sendEvent(cfg.EventName, string(jsonData)) {
+			// 发送 SSE 事件（SSE 规范：id: id\nevent: name\ndata: data\n\n）
+			if !sendEvent(id, eventName, payload) {
 				cleanup()
 				return
 			}
@@ -400,6 +654,29 @@ func StreamSSE[T any](c *gin.Context, dataChan <-chan T, config ...SSEConfig) {
 	}
 }
 
+// writeSSEEvent 按 SSE 规范写入一个事件
+// id 为空时不输出 id 行，data 中的换行拆分为多行 data（客户端会以换行重新拼接）
+func writeSSEEvent(w io.Writer, id, eventName, data string) error {
+	var b strings.Builder
+	if id != "" {
+		// id 中不能包含换行
+		b.WriteString("id: ")
+		b.WriteString(strings.NewReplacer("\r", "", "\n", "").Replace(id))
+		b.WriteByte('\n')
+	}
+	b.WriteString("event: ")
+	b.WriteString(eventName)
+	b.WriteByte('\n')
+	for _, line := range strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n") {
+		b.WriteString("data: ")
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
 // SSE 简化版本，使用默认配置
 func SSE[T any](c *gin.Context, dataChan <-chan T, eventName string) {
 	cfg := DefaultSSEConfig()
@@ -0,0 +1,32 @@
+package handle
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/utils/metrics"
+)
+
+// ErrorMetricName 错误响应计数器在 metrics 端点中的指标名称
+const ErrorMetricName = "http_errors_total"
+
+// unmatchedRoute 未匹配路由时使用的 route 标签值，避免按原始路径产生过多标签组合
+const unmatchedRoute = "unmatched"
+
+// errorCounter 按错误码、路由和 HTTP 状态码统计返回给客户端的错误响应
+var errorCounter = metrics.NewCounterVec(ErrorMetricName, "返回给客户端的错误响应数", "code", "route", "status")
+
+// recordError 记录一次错误响应，code 为 0 表示非 StatusError 的普通错误
+func recordError(c *gin.Context, statusCode int, code int32) {
+	route := c.FullPath()
+	if route == "" {
+		route = unmatchedRoute
+	}
+	errorCounter.Inc(strconv.FormatInt(int64(code), 10), route, strconv.Itoa(statusCode))
+}
+
+// ErrorCount 返回指定错误码、路由和 HTTP 状态码的错误响应次数
+func ErrorCount(code int32, route string, statusCode int) int64 {
+	return errorCounter.Value(strconv.FormatInt(int64(code), 10), route, strconv.Itoa(statusCode))
+}
@@ -0,0 +1,29 @@
+package handle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"backend/utils/errorx"
+)
+
+func TestErrorMetrics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	errorx.Register(4049901, "测试资源不存在", errorx.WithStatus(http.StatusNotFound))
+
+	r := gin.New()
+	r.GET("/items/:id", func(c *gin.Context) {
+		HandleError(c, errorx.New(4049901), "查询", nil)
+	})
+
+	before := ErrorCount(4049901, "/items/:id", http.StatusNotFound)
+	for i := 0; i < 2; i++ {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/items/1", nil))
+	}
+	if got := ErrorCount(4049901, "/items/:id", http.StatusNotFound) - before; got != 2 {
+		t.Errorf("expected 2 errors, got %d", got)
+	}
+}
@@ -0,0 +1,105 @@
+package handle
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NDJSONContentType NDJSON 响应类型
+const NDJSONContentType = "application/x-ndjson"
+
+// NDJSONConfig NDJSON 配置选项
+type NDJSONConfig struct {
+	FlushInterval time.Duration // 刷新间隔，默认 1 秒
+	FlushLines    int           // 累计写入多少行后立即刷新，默认 100
+	OnError       func(error)   // 发生错误时的回调
+}
+
+// DefaultNDJSONConfig 默认 NDJSON 配置
+func DefaultNDJSONConfig() NDJSONConfig {
+	return NDJSONConfig{
+		FlushInterval: time.Second,
+		FlushLines:    100,
+	}
+}
+
+// StreamNDJSON 以 NDJSON（每行一个 JSON）格式输出 channel 中的数据
+// 按 FlushInterval 或 FlushLines 定期刷新，channel 关闭或客户端断开时结束
+// 序列化失败的数据输出为 {"error": "..."} 行，不中断输出
+func StreamNDJSON[T any](c *gin.Context, dataChan <-chan T, config ...NDJSONConfig) {
+	// 合并配置
+	cfg := DefaultNDJSONConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+		if cfg.FlushInterval == 0 {
+			cfg.FlushInterval = time.Second
+		}
+		if cfg.FlushLines == 0 {
+			cfg.FlushLines = 100
+		}
+	}
+
+	c.Header("Content-Type", NDJSONContentType)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // 禁用 nginx 缓冲
+	c.Status(http.StatusOK)
+
+	flushTicker := time.NewTicker(cfg.FlushInterval)
+	defer flushTicker.Stop()
+
+	ctx := c.Request.Context()
+	pending := 0
+
+	flush := func() {
+		if pending > 0 {
+			c.Writer.Flush()
+			pending = 0
+		}
+	}
+
+	// 写入一行的辅助函数
+	writeLine := func(line []byte) bool {
+		if _, err := c.Writer.Write(append(line, '\n')); err != nil {
+			if cfg.OnError != nil {
+				cfg.OnError(err)
+			}
+			return false
+		}
+		pending++
+		if pending >= cfg.FlushLines {
+			flush()
+		}
+		return true
+	}
+
+	for {
+		select {
+		case data, ok := <-dataChan:
+			if !ok {
+				flush()
+				return
+			}
+
+			line, err := json.Marshal(data)
+			if err != nil {
+				if cfg.OnError != nil {
+					cfg.OnError(err)
+				}
+				line, _ = json.Marshal(gin.H{"error": err.Error()})
+			}
+			if !writeLine(line) {
+				return
+			}
+
+		case <-flushTicker.C:
+			flush()
+
+		case <-ctx.Done():
+			// 客户端断开连接
+			return
+		}
+	}
+}
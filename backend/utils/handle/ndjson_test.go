@@ -0,0 +1,41 @@
+package handle
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestStreamNDJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/export", nil)
+
+	type row struct {
+		ID    int `json:"id"`
+		Value any `json:"value"`
+	}
+	dataChan := make(chan row, 3)
+	dataChan <- row{ID: 1, Value: "a"}
+	dataChan <- row{ID: 2, Value: func() {}}
+	dataChan <- row{ID: 3, Value: "c"}
+	close(dataChan)
+
+	var errs int
+	StreamNDJSON(c, dataChan, NDJSONConfig{FlushLines: 1, OnError: func(error) { errs++ }})
+
+	expected := `{"id":1,"value":"a"}` + "\n" +
+		`{"error":"json: unsupported type: func()"}` + "\n" +
+		`{"id":3,"value":"c"}` + "\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("unexpected body:\n%q\nwant:\n%q", got, expected)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != NDJSONContentType {
+		t.Errorf("expected content type %q, got %q", NDJSONContentType, ct)
+	}
+	if errs != 1 {
+		t.Errorf("expected 1 error callback, got %d", errs)
+	}
+}
@@ -0,0 +1,146 @@
+package handle
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+
+	"backend/utils/errorx"
+)
+
+// Format 响应格式
+type Format string
+
+const (
+	// FormatJSON JSON 格式（默认，始终可用）
+	FormatJSON Format = "json"
+	// FormatXML XML 格式（application/xml、text/xml）
+	FormatXML Format = "xml"
+	// FormatMsgPack MessagePack 格式（application/msgpack、application/x-msgpack）
+	FormatMsgPack Format = "msgpack"
+
+	// ProblemXMLContentType RFC 7807 问题详情的 XML 响应类型
+	ProblemXMLContentType = "application/problem+xml"
+)
+
+// formatMIMEs 各格式对应的 Accept 类型，第一个作为响应的 Content-Type
+var formatMIMEs = map[Format][]string{
+	FormatJSON:    {gin.MIMEJSON},
+	FormatXML:     {gin.MIMEXML, gin.MIMEXML2},
+	FormatMsgPack: {binding.MIMEMSGPACK2, binding.MIMEMSGPACK},
+}
+
+// responseFormats 启用的响应格式，JSON 始终排在第一位，作为 Accept 为空或 */* 时的默认格式
+var responseFormats atomic.Pointer[[]Format]
+
+// SetResponseFormats 设置除 JSON 外可以按 Accept 请求头协商的响应格式，默认只输出 JSON
+func SetResponseFormats(formats ...Format) {
+	enabled := []Format{FormatJSON}
+	for _, format := range formats {
+		if format != FormatJSON && !containsFormat(enabled, format) {
+			enabled = append(enabled, format)
+		}
+	}
+	responseFormats.Store(&enabled)
+}
+
+// ParseResponseFormats 解析逗号分隔的响应格式列表，如 "json,xml,msgpack"
+func ParseResponseFormats(value string) ([]Format, error) {
+	var formats []Format
+	for _, part := range strings.Split(value, ",") {
+		format := Format(strings.ToLower(strings.TrimSpace(part)))
+		if format == "" {
+			continue
+		}
+		if _, ok := formatMIMEs[format]; !ok {
+			return nil, fmt.Errorf("未知的响应格式: %s", format)
+		}
+		formats = append(formats, format)
+	}
+	return formats, nil
+}
+
+// NegotiateFormat 根据 Accept 请求头选择响应格式
+// 只启用 JSON、Accept 为空或没有匹配的格式时返回 FormatJSON
+func NegotiateFormat(c *gin.Context) Format {
+	enabled := responseFormats.Load()
+	if enabled == nil || len(*enabled) == 1 || c.GetHeader("Accept") == "" {
+		return FormatJSON
+	}
+
+	var offered []string
+	for _, format := range *enabled {
+		offered = append(offered, formatMIMEs[format]...)
+	}
+	accepted := c.NegotiateFormat(offered...)
+	for _, format := range *enabled {
+		for _, mime := range formatMIMEs[format] {
+			if mime == accepted {
+				return format
+			}
+		}
+	}
+	return FormatJSON
+}
+
+// respond 按协商的格式输出响应
+// body 用于 JSON 和 MessagePack，envelope 用于 XML（XML 不支持 map，需要使用带 xml 标签的结构体）
+// 需要自定义 Content-Type 时在调用前设置响应头
+func respond(c *gin.Context, statusCode int, body any, envelope any) {
+	if enabled := responseFormats.Load(); enabled != nil && len(*enabled) > 1 {
+		// 响应内容随 Accept 变化，避免缓存混用
+		c.Writer.Header().Add("Vary", "Accept")
+	}
+
+	switch NegotiateFormat(c) {
+	case FormatXML:
+		c.XML(statusCode, envelope)
+	case FormatMsgPack:
+		c.Render(statusCode, render.MsgPack{Data: body})
+	default:
+		c.JSON(statusCode, body)
+	}
+}
+
+// xmlResponse XML 格式的统一响应
+// encoding/xml 的 omitempty 对 a>b 形式的路径无效，errors 使用指针在为空时省略
+type xmlResponse struct {
+	Response
+	XMLName xml.Name    `xml:"response"`
+	Errors  *xmlDetails `xml:"errors,omitempty"`
+}
+
+// xmlProblem XML 格式的问题详情（RFC 7807 附录 A）
+type xmlProblem struct {
+	ProblemDetails
+	XMLName xml.Name    `xml:"urn:ietf:rfc:7807 problem"`
+	Errors  *xmlDetails `xml:"errors,omitempty"`
+}
+
+// xmlDetails XML 格式的聚合错误详情
+type xmlDetails struct {
+	Items []errorx.Detail `xml:"error"`
+}
+
+// newXMLDetails 创建 XML 格式的聚合错误详情，没有详情时返回 nil
+func newXMLDetails(details []errorx.Detail) *xmlDetails {
+	if len(details) == 0 {
+		return nil
+	}
+	return &xmlDetails{Items: details}
+}
+
+// containsFormat 判断格式是否在列表中
+func containsFormat(formats []Format, format Format) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package handle
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+
+	"backend/utils/errorx"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	defer SetResponseFormats()
+
+	newContext := func(accept string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/items", nil)
+		if accept != "" {
+			c.Request.Header.Set("Accept", accept)
+		}
+		return c, w
+	}
+
+	// 未启用其他格式时始终返回 JSON
+	SetResponseFormats()
+	if c, _ := newContext("application/xml"); NegotiateFormat(c) != FormatJSON {
+		t.Errorf("expected json when xml is disabled")
+	}
+
+	SetResponseFormats(FormatXML, FormatMsgPack)
+	cases := map[string]Format{
+		"":                                  FormatJSON,
+		"*/*":                               FormatJSON,
+		"application/xml":                   FormatXML,
+		"text/xml":                          FormatXML,
+		"application/msgpack":               FormatMsgPack,
+		"application/x-msgpack":             FormatMsgPack,
+		"text/html, application/xml;q=0.9":  FormatXML,
+		"application/yaml":                  FormatJSON,
+		"application/json, application/xml": FormatJSON,
+	}
+	for accept, want := range cases {
+		if c, _ := newContext(accept); NegotiateFormat(c) != want {
+			t.Errorf("Accept %q: expected %s, got %s", accept, want, NegotiateFormat(c))
+		}
+	}
+
+	// XML 成功响应
+	c, w := newContext("application/xml")
+	Success(c, Page[string]{Page: 1, PageSize: 20, Total: 1, TotalPages: 1, Items: []string{"a"}})
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/xml") {
+		t.Errorf("expected xml content type, got %q", ct)
+	}
+	expected := "<response><code>0</code><data><page>1</page><page_size>20</page_size><total>1</total><total_pages>1</total_pages><items><item>a</item></items></data></response>"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("unexpected body:\n%s\nwant:\n%s", got, expected)
+	}
+	if vary := w.Header().Get("Vary"); vary != "Accept" {
+		t.Errorf("expected Vary: Accept, got %q", vary)
+	}
+
+	// MessagePack 错误响应
+	c, w = newContext("application/msgpack")
+	writeError(c, 404, 4040001, "资源不存在", nil)
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/msgpack") {
+		t.Errorf("expected msgpack content type, got %q", ct)
+	}
+	var body map[string]interface{}
+	mh := &codec.MsgpackHandle{}
+	mh.RawToString = true
+	if err := codec.NewDecoderBytes(w.Body.Bytes(), mh).Decode(&body); err != nil {
+		t.Fatalf("decode msgpack: %v", err)
+	}
+	if body["message"] != "资源不存在" {
+		t.Errorf("unexpected message: %v", body["message"])
+	}
+
+	// problem details 使用 application/problem+xml
+	SetProblemDetails(true)
+	defer SetProblemDetails(false)
+	c, w = newContext("application/xml")
+	writeError(c, 404, 0, "资源不存在", []errorx.Detail{{Code: 1, Message: "a"}})
+	if ct := w.Header().Get("Content-Type"); ct != ProblemXMLContentType {
+		t.Errorf("expected %q, got %q", ProblemXMLContentType, ct)
+	}
+	if body := w.Body.String(); !strings.HasPrefix(body, `<problem xmlns="urn:ietf:rfc:7807">`) ||
+		!strings.Contains(body, "<errors><error><code>1</code><message>a</message></error></errors>") {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestParseResponseFormats(t *testing.T) {
+	formats, err := ParseResponseFormats(" json, XML ,msgpack,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(formats) != 3 || formats[1] != FormatXML || formats[2] != FormatMsgPack {
+		t.Errorf("unexpected formats: %v", formats)
+	}
+	if _, err := ParseResponseFormats("json,yaml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,103 @@
+package handle
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Page 分页响应结构体
+type Page[T any] struct {
+	Page       int   `json:"page" xml:"page" example:"1"`               // 当前页码
+	PageSize   int   `json:"page_size" xml:"page_size" example:"20"`    // 每页条数
+	Total      int64 `json:"total" xml:"total" example:"42"`            // 总条数
+	TotalPages int   `json:"total_pages" xml:"total_pages" example:"3"` // 总页数
+	Items      []T   `json:"items" xml:"items>item"`                    // 当前页数据
+}
+
+// PageConfig 分页响应配置选项
+type PageConfig struct {
+	LinkHeader    bool   // 是否输出 RFC 5988 Link 响应头，默认 true
+	PageParam     string // 页码参数名，默认 "page"
+	PageSizeParam string // 每页条数参数名，默认 "page_size"
+}
+
+// DefaultPageConfig 默认分页响应配置
+func DefaultPageConfig() PageConfig {
+	return PageConfig{
+		LinkHeader:    true,
+		PageParam:     "page",
+		PageSizeParam: "page_size",
+	}
+}
+
+// TotalPages 根据总条数和每页条数计算总页数
+func TotalPages(total int64, pageSize int) int {
+	if total <= 0 || pageSize <= 0 {
+		return 0
+	}
+	return int((total + int64(pageSize) - 1) / int64(pageSize))
+}
+
+// Paginated 返回分页成功响应
+// items 为 nil 时输出空数组，启用 Link 响应头时输出 first、prev、next、last 链接
+func Paginated[T any](c *gin.Context, items []T, page, pageSize int, total int64, config ...PageConfig) {
+	cfg := DefaultPageConfig()
+	if len(config) > 0 {
+		cfg = config[0]
+		if cfg.PageParam == "" {
+			cfg.PageParam = "page"
+		}
+		if cfg.PageSizeParam == "" {
+			cfg.PageSizeParam = "page_size"
+		}
+	}
+
+	if items == nil {
+		items = []T{}
+	}
+	totalPages := TotalPages(total, pageSize)
+
+	if cfg.LinkHeader {
+		if link := pageLinks(c.Request, cfg, page, pageSize, totalPages); link != "" {
+			c.Header("Link", link)
+		}
+	}
+
+	Success(c, Page[T]{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		Items:      items,
+	})
+}
+
+// pageLinks 生成 RFC 5988 Link 响应头，保留请求中的其他查询参数
+func pageLinks(r *http.Request, cfg PageConfig, page, pageSize, totalPages int) string {
+	if totalPages == 0 {
+		return ""
+	}
+
+	link := func(target int, rel string) string {
+		query := r.URL.Query()
+		query.Set(cfg.PageParam, strconv.Itoa(target))
+		query.Set(cfg.PageSizeParam, strconv.Itoa(pageSize))
+		u := url.URL{Path: r.URL.Path, RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, u.String(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if page > 1 {
+		links = append(links, link(min(page-1, totalPages), "prev"))
+	}
+	if page < totalPages {
+		links = append(links, link(page+1, "next"))
+	}
+	links = append(links, link(totalPages, "last"))
+	return strings.Join(links, ", ")
+}
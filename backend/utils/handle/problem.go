@@ -0,0 +1,41 @@
+package handle
+
+import (
+	"sync/atomic"
+
+	"backend/utils/errorx"
+)
+
+const (
+	// ProblemContentType RFC 7807 问题详情的响应类型
+	ProblemContentType = "application/problem+json"
+
+	// problemTypeBlank 未定义具体问题类型时使用的 type（RFC 7807 3.1）
+	problemTypeBlank = "about:blank"
+)
+
+// ProblemDetails RFC 7807 问题详情响应结构体（用于 Swagger 文档）
+type ProblemDetails struct {
+	Type      string          `json:"type" xml:"type" example:"about:blank"`                                                  // 问题类型
+	Title     string          `json:"title" xml:"title" example:"Not Found"`                                                  // HTTP 状态码对应的简短描述
+	Status    int             `json:"status" xml:"status" example:"404"`                                                      // HTTP 状态码
+	Detail    string          `json:"detail,omitempty" xml:"detail,omitempty" example:"资源不存在"`                                // 错误消息（按请求语言翻译）
+	Instance  string          `json:"instance,omitempty" xml:"instance,omitempty" example:"/api/v1/items/1"`                  // 请求路径
+	TraceID   string          `json:"trace_id,omitempty" xml:"trace_id,omitempty" example:"4bf92f3577b34da6a3ce929d0e0e4736"` // 链路追踪 ID
+	RequestID string          `json:"request_id,omitempty" xml:"request_id,omitempty" example:"req_P8oBUcz6utMujQ"`           // 请求 ID
+	Code      int32           `json:"code,omitempty" xml:"code,omitempty" example:"4000001"`                                  // 业务错误码
+	Errors    []errorx.Detail `json:"errors,omitempty" xml:"-"`                                                               // 聚合错误详情（可选）
+}
+
+// problemDetails 是否以 problem+json 格式输出错误响应
+var problemDetails atomic.Bool
+
+// SetProblemDetails 设置错误响应是否使用 RFC 7807 problem+json 格式，默认使用 {code, message} 格式
+func SetProblemDetails(enabled bool) {
+	problemDetails.Store(enabled)
+}
+
+// ProblemDetailsEnabled 返回错误响应是否使用 problem+json 格式
+func ProblemDetailsEnabled() bool {
+	return problemDetails.Load()
+}
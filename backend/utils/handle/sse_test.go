@@ -0,0 +1,138 @@
+package handle
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// closeNotifyRecorder 为 httptest.ResponseRecorder 补充 CloseNotify
+type closeNotifyRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (r closeNotifyRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+func TestStreamSSE(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(closeNotifyRecorder{w})
+	c.Request = httptest.NewRequest("GET", "/events", nil)
+
+	dataChan := make(chan int, 3)
+	dataChan <- 1
+	dataChan <- 2
+	dataChan <- 3
+	close(dataChan)
+
+	cfg := DefaultSSEConfig()
+	cfg.EventID = func(data any) string {
+		return strconv.Itoa(data.(int))
+	}
+	cfg.Serializer = func(data any) (string, error) {
+		if data.(int) == 2 {
+			return "", errors.New("bad payload")
+		}
+		return "line" + strconv.Itoa(data.(int)) + "\nnext", nil
+	}
+	StreamSSE(c, dataChan, cfg)
+
+	expected := "retry: 3000\n\n" +
+		"id: 1\nevent: message\ndata: line1\ndata: next\n\n" +
+		"event: error\ndata: {\"error\":\"bad payload\",\"id\":\"2\"}\n\n" +
+		"id: 3\nevent: message\ndata: line3\ndata: next\n\n" +
+		"event: done\ndata: {\"status\":\"completed\"}\n\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("unexpected stream:\n%q\nwant:\n%q", got, expected)
+	}
+}
+
+type idEvent struct {
+	id   string
+	data any
+}
+
+func (e idEvent) SSEEventID() string { return e.id }
+func (e idEvent) SSEData() any       { return e.data }
+
+// TestStreamSSEEventID 测试数据自带事件 ID 时输出 id 行，data 行只包含数据
+func TestStreamSSEEventID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(closeNotifyRecorder{w})
+	c.Request = httptest.NewRequest("GET", "/events", nil)
+
+	dataChan := make(chan idEvent, 1)
+	dataChan <- idEvent{id: "7", data: map[string]int{"step": 1}}
+	close(dataChan)
+	StreamSSE(c, dataChan)
+
+	expected := "retry: 3000\n\n" +
+		"id: 7\nevent: message\ndata: {\"step\":1}\n\n" +
+		"event: done\ndata: {\"status\":\"completed\"}\n\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("unexpected stream:\n%q\nwant:\n%q", got, expected)
+	}
+}
+
+type errEvent struct {
+	idEvent
+	err error
+}
+
+func (e errEvent) SSEError() error { return e.err }
+
+// TestStreamSSEErrorEvent 测试订阅被中止时发送错误事件后结束，不发送 done 事件
+func TestStreamSSEErrorEvent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(closeNotifyRecorder{w})
+	c.Request = httptest.NewRequest("GET", "/events", nil)
+
+	dataChan := make(chan errEvent, 2)
+	dataChan <- errEvent{idEvent: idEvent{id: "1", data: 1}}
+	dataChan <- errEvent{err: errors.New("subscriber too slow")}
+	close(dataChan)
+	StreamSSE(c, dataChan)
+
+	expected := "retry: 3000\n\n" +
+		"id: 1\nevent: message\ndata: 1\n\n" +
+		"event: error\ndata: {\"error\":\"subscriber too slow\"}\n\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("unexpected stream:\n%q\nwant:\n%q", got, expected)
+	}
+}
+
+type namedEvent struct {
+	name string
+	data any
+}
+
+func (e namedEvent) SSEEventName() string { return e.name }
+
+// TestStreamSSENamedEvent 测试数据自带事件名称时代替配置的事件名称
+func TestStreamSSENamedEvent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(closeNotifyRecorder{w})
+	c.Request = httptest.NewRequest("GET", "/events", nil)
+
+	dataChan := make(chan namedEvent, 2)
+	dataChan <- namedEvent{name: "alive"}
+	dataChan <- namedEvent{}
+	close(dataChan)
+	StreamSSE(c, dataChan)
+
+	expected := "retry: 3000\n\n" +
+		"event: alive\ndata: {}\n\n" +
+		"event: message\ndata: {}\n\n" +
+		"event: done\ndata: {\"status\":\"completed\"}\n\n"
+	if got := w.Body.String(); got != expected {
+		t.Errorf("unexpected stream:\n%q\nwant:\n%q", got, expected)
+	}
+}
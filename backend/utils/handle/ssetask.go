@@ -0,0 +1,139 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/utils/errorx"
+	"backend/utils/logs"
+	"backend/utils/rand"
+	"backend/utils/sse"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// LastEventIDHeader 客户端最后收到的事件 ID，EventSource 断线重连时自动带上
+	LastEventIDHeader = "Last-Event-ID"
+	// ResumeKeyHeader 续传任务的 resumeKey 请求头，来自 SSETask 发送的元数据事件
+	ResumeKeyHeader = "X-Resume-Key"
+	// ResumeKeyQuery 续传任务的 resumeKey 查询参数，用于不能设置请求头的 EventSource
+	ResumeKeyQuery = "resume_key"
+)
+
+// SSETaskManager 执行 SSE 任务的管理器，由 *sse.SSEManager 实现
+type SSETaskManager interface {
+	ExecuteWithSSE(ctx context.Context, resumeKey string, lastEventID uint64, subscriberID string, asyncFunc sse.AsyncTaskFunc, asyncTimeout time.Duration, opts ...sse.TaskOption) (<-chan sse.Event, string, error)
+	GetTaskInfo(taskID string) (*sse.TaskInfo, error)
+}
+
+// SSETaskConfig SSETask 配置选项
+type SSETaskConfig struct {
+	Operation    string           // 操作名称（用于日志记录），默认为 "执行 SSE 任务"
+	SubscriberID string           // 订阅者ID，默认每个连接生成一个
+	Timeout      time.Duration    // 异步任务超时时间，0 表示不限制
+	TaskOptions  []sse.TaskOption // 传给 ExecuteWithSSE 的任务选项
+	Stream       *SSEConfig       // StreamSSE 配置，为 nil 时使用 DefaultSSEConfig
+	// MetaEventName 连接建立后发送的元数据事件名称，默认为 "task"，数据为 {"task_id":"...","resume_key":"..."}
+	MetaEventName string
+	// InvalidParamCode Last-Event-ID 不是事件 ID 时返回的错误码，为 0 时返回普通错误（400）
+	InvalidParamCode int32
+}
+
+// SSETask 执行 SSE 任务并推送数据，处理断线重连、续传和元数据事件
+// resumeKeyFromHeader 为 true 时从 X-Resume-Key 请求头读取 resumeKey，否则从 resume_key 查询参数读取；
+// 带 resumeKey 时按 Last-Event-ID 请求头续传，任务已结束或过期时返回 410，管理器已停止时返回 503，
+// 找不到任务时创建新任务
+func SSETask(c *gin.Context, manager SSETaskManager, resumeKeyFromHeader bool, task sse.AsyncTaskFunc, config ...SSETaskConfig) {
+	var cfg SSETaskConfig
+	if len(config) > 0 {
+		cfg = config[0]
+	}
+	if cfg.Operation == "" {
+		cfg.Operation = "执行 SSE 任务"
+	}
+	if cfg.SubscriberID == "" {
+		cfg.SubscriberID = rand.MustGenerateUIDWithPrefix("sub_")
+	}
+	if cfg.MetaEventName == "" {
+		cfg.MetaEventName = "task"
+	}
+	streamCfg := DefaultSSEConfig()
+	if cfg.Stream != nil {
+		streamCfg = *cfg.Stream
+	}
+
+	ctx := errorx.WithLocale(c.Request.Context(), Locale(c))
+
+	var resumeKey string
+	if resumeKeyFromHeader {
+		resumeKey = c.GetHeader(ResumeKeyHeader)
+	} else {
+		resumeKey = c.Query(ResumeKeyQuery)
+	}
+
+	// 只有续传时 Last-Event-ID 才有意义，新任务从第一条数据开始推送
+	var lastEventID uint64
+	if header := c.GetHeader(LastEventIDHeader); header != "" && resumeKey != "" {
+		id, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			HandleErrorWithContext(c, invalidLastEventID(ctx, cfg.InvalidParamCode), cfg.Operation, nil)
+			return
+		}
+		lastEventID = id
+	}
+
+	dataChan, taskID, err := manager.ExecuteWithSSE(ctx, resumeKey, lastEventID, cfg.SubscriberID, task, cfg.Timeout, cfg.TaskOptions...)
+	if err != nil {
+		HandleErrorWithContext(c, err, cfg.Operation, &ErrorConfig{
+			DefaultStatusCode: sseTaskStatusCode(err),
+			LogLevel:          "warn",
+		})
+		return
+	}
+
+	// 续传其他实例上的任务时从任务存储中查询，查询失败时沿用请求中的 resumeKey
+	if info, err := manager.GetTaskInfo(taskID); err == nil {
+		resumeKey = info.ResumeKey
+	} else {
+		logs.CtxWarnf(ctx, "查询 SSE 任务信息失败: task_id=%s, error=%s", taskID, err.Error())
+	}
+	meta, _ := json.Marshal(gin.H{"task_id": taskID, "resume_key": resumeKey})
+
+	// retry 行之后、第一条数据之前发送元数据事件，不带 id 行，不影响 Last-Event-ID
+	onConnect := streamCfg.OnConnect
+	streamCfg.OnConnect = func() {
+		if err := writeSSEEvent(c.Writer, "", cfg.MetaEventName, string(meta)); err == nil {
+			c.Writer.Flush()
+		}
+		if onConnect != nil {
+			onConnect()
+		}
+	}
+	StreamSSE(c, dataChan, streamCfg)
+}
+
+// invalidLastEventID Last-Event-ID 不是事件 ID 时返回的错误
+func invalidLastEventID(ctx context.Context, code int32) error {
+	reason := LastEventIDHeader + " 必须是事件 ID"
+	if code > 0 {
+		return errorx.NewWithLocale(errorx.LocaleFromContext(ctx), code, errorx.K("reason", reason))
+	}
+	return errors.New(reason)
+}
+
+// sseTaskStatusCode ExecuteWithSSE 返回普通错误时的 HTTP 状态码
+func sseTaskStatusCode(err error) int {
+	switch {
+	case errors.Is(err, sse.ErrTaskNotRunning), errors.Is(err, sse.ErrTaskExpired):
+		return http.StatusGone
+	case errors.Is(err, sse.ErrManagerStopped):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
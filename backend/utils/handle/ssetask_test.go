@@ -0,0 +1,178 @@
+package handle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"backend/utils/sse"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TestSSETask 测试先发送元数据事件，再推送任务数据；带 resumeKey 和 Last-Event-ID 重连时只补发之后的数据
+func TestSSETask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := sse.NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	task := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		_ = updateProgress(2)
+		<-release
+		return nil
+	}
+
+	// 第一次连接收到两条数据后断开
+	w := newSyncRecorder()
+	c, _ := gin.CreateTestContext(w)
+	reqCtx, disconnect := context.WithCancel(context.Background())
+	c.Request = httptest.NewRequest("POST", "/tasks", nil).WithContext(reqCtx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		SSETask(c, m, true, task)
+	}()
+	waitBody(t, w, "id: 2\n")
+	disconnect()
+	<-done
+
+	body := w.BodyString()
+	if !strings.HasPrefix(body, "retry: 3000\n\nevent: task\ndata: ") {
+		t.Fatalf("期望先发送元数据事件，实际为 %q", body)
+	}
+	line := strings.SplitN(strings.TrimPrefix(body, "retry: 3000\n\nevent: task\ndata: "), "\n", 2)[0]
+	var meta struct {
+		TaskID    string `json:"task_id"`
+		ResumeKey string `json:"resume_key"`
+	}
+	if err := json.Unmarshal([]byte(line), &meta); err != nil || meta.TaskID == "" || meta.ResumeKey == "" {
+		t.Fatalf("元数据事件无效: %q, %v", line, err)
+	}
+
+	// 带 resumeKey 重连，只补发 Last-Event-ID 之后的数据，任务结束后发送 done 事件
+	w = newSyncRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/tasks", nil)
+	c.Request.Header.Set(ResumeKeyHeader, meta.ResumeKey)
+	c.Request.Header.Set(LastEventIDHeader, "1")
+	done = make(chan struct{})
+	go func() {
+		defer close(done)
+		SSETask(c, m, true, task)
+	}()
+	waitBody(t, w, "id: 2\n")
+	close(release)
+	<-done
+
+	body = w.BodyString()
+	if !strings.Contains(body, `"task_id":"`+meta.TaskID+`"`) {
+		t.Fatalf("续传时应发送同一个任务的元数据，实际为 %q", body)
+	}
+	if strings.Contains(body, "id: 1\n") || !strings.Contains(body, "id: 2\nevent: message\ndata: 2\n\n") {
+		t.Fatalf("续传时只应补发之后的数据，实际为 %q", body)
+	}
+	if !strings.HasSuffix(body, "event: done\ndata: {\"status\":\"completed\"}\n\n") {
+		t.Fatalf("期望任务结束后发送 done 事件，实际为 %q", body)
+	}
+}
+
+// TestSSETaskErrors 测试 Last-Event-ID 无效、任务已结束和管理器已停止时返回错误响应
+func TestSSETaskErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := sse.NewSSEManager(time.Hour)
+
+	task := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		return nil
+	}
+	ch, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", task, 0)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	for range ch {
+	}
+	info, _ := m.GetTaskInfo(taskID)
+
+	serve := func(resumeKey, lastEventID string) int {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(closeNotifyRecorder{w})
+		c.Request = httptest.NewRequest("GET", "/tasks?"+ResumeKeyQuery+"="+resumeKey, nil)
+		if lastEventID != "" {
+			c.Request.Header.Set(LastEventIDHeader, lastEventID)
+		}
+		SSETask(c, m, false, task)
+		return w.Code
+	}
+
+	if code := serve(info.ResumeKey, "abc"); code != http.StatusBadRequest {
+		t.Errorf("Last-Event-ID 无效时期望 400，实际为 %d", code)
+	}
+	if code := serve(info.ResumeKey, "1"); code != http.StatusGone {
+		t.Errorf("任务已结束时期望 410，实际为 %d", code)
+	}
+	_ = m.Stop(context.Background())
+	if code := serve("", ""); code != http.StatusServiceUnavailable {
+		t.Errorf("管理器已停止时期望 503，实际为 %d", code)
+	}
+}
+
+// syncRecorder 加锁的 httptest.ResponseRecorder，SSETask 在其他协程写入时测试可以安全读取响应内容
+type syncRecorder struct {
+	mu sync.Mutex
+	w  *httptest.ResponseRecorder
+}
+
+func newSyncRecorder() *syncRecorder {
+	return &syncRecorder{w: httptest.NewRecorder()}
+}
+
+func (r *syncRecorder) Header() http.Header {
+	return r.w.Header()
+}
+
+func (r *syncRecorder) Write(b []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.w.Write(b)
+}
+
+func (r *syncRecorder) WriteHeader(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.w.WriteHeader(code)
+}
+
+func (r *syncRecorder) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.w.Flush()
+}
+
+func (r *syncRecorder) CloseNotify() <-chan bool {
+	return make(chan bool)
+}
+
+// BodyString 返回当前已写入的响应内容
+func (r *syncRecorder) BodyString() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.w.Body.String()
+}
+
+// waitBody 等待响应中出现 substr
+func waitBody(t *testing.T, w *syncRecorder, substr string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(w.BodyString(), substr) {
+		if time.Now().After(deadline) {
+			t.Fatalf("等待数据推送超时: %q", w.BodyString())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,121 @@
+// Package health 提供了一个轻量的就绪检查注册表，各模块注册依赖检查函数，由 readyz 端点统一执行
+package health
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// StatusUp 检查通过
+	StatusUp = "up"
+	// StatusDown 检查失败
+	StatusDown = "down"
+)
+
+// CheckFunc 就绪检查函数，返回 nil 表示依赖可用
+type CheckFunc func(ctx context.Context) error
+
+// Result 单项检查结果
+type Result struct {
+	Status     string `json:"status"`          // 检查状态: up, down
+	Error      string `json:"error,omitempty"` // 失败原因
+	DurationMs int64  `json:"duration_ms"`     // 检查耗时（毫秒）
+}
+
+var (
+	// checks 检查函数注册表（key: 检查名称）
+	checks = make(map[string]CheckFunc)
+	// checksMu 保护注册表的互斥锁
+	checksMu sync.RWMutex
+)
+
+// Register 注册就绪检查函数
+// name: 检查名称，同名注册会覆盖之前的检查函数
+// fn: 检查函数
+func Register(name string, fn CheckFunc) {
+	if fn == nil {
+		return
+	}
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	checks[name] = fn
+}
+
+// Unregister 注销就绪检查函数
+func Unregister(name string) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	delete(checks, name)
+}
+
+// Names 返回已注册的检查名称（按字母序）
+func Names() []string {
+	checksMu.RLock()
+	defer checksMu.RUnlock()
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check 并发执行所有已注册的检查函数
+// 返回每项检查的结果，以及是否全部通过
+func Check(ctx context.Context) (map[string]Result, bool) {
+	checksMu.RLock()
+	fns := make(map[string]CheckFunc, len(checks))
+	for name, fn := range checks {
+		fns[name] = fn
+	}
+	checksMu.RUnlock()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]Result, len(fns))
+		healthy = true
+	)
+
+	for name, fn := range fns {
+		wg.Add(1)
+		go func(name string, fn CheckFunc) {
+			defer wg.Done()
+
+			start := time.Now()
+			err := runCheck(ctx, fn)
+			result := Result{
+				Status:     StatusUp,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[name] = result
+			if err != nil {
+				healthy = false
+			}
+		}(name, fn)
+	}
+	wg.Wait()
+
+	return results, healthy
+}
+
+// runCheck 执行单个检查函数，检查函数 panic 时视为失败
+func runCheck(ctx context.Context, fn CheckFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("检查异常: %v", r)
+		}
+	}()
+	return fn(ctx)
+}
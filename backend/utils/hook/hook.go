@@ -0,0 +1,92 @@
+// Package hook 提供类型化的钩子，用于在不修改业务代码的情况下扩展行为（补充数据、自定义校验、同步外部系统等）
+package hook
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"backend/utils/logs"
+)
+
+// Func 钩子函数，payload 为指针时可以修改（如补充字段）
+type Func[T any] func(ctx context.Context, payload T) error
+
+// Hook 同一时机的钩子，按注册顺序调用
+// 零值可用；注册一般在启动时完成，调用时可以并发
+type Hook[T any] struct {
+	mu      sync.RWMutex
+	entries []entry[T]
+}
+
+type entry[T any] struct {
+	name string
+	fn   Func[T]
+}
+
+// Error 钩子返回的错误，Unwrap 返回钩子函数的原始错误
+type Error struct {
+	Name string // 钩子名称
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("钩子 %s: %s", e.Name, e.Err.Error())
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Register 注册钩子，name 用于日志和错误信息
+func (h *Hook[T]) Register(name string, fn Func[T]) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = append(h.entries, entry[T]{name: name, fn: fn})
+}
+
+// Len 已注册的钩子数量
+func (h *Hook[T]) Len() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.entries)
+}
+
+// Run 依次调用钩子，遇到错误立即返回 *Error，后面的钩子不再调用
+// 用于操作前的钩子，返回错误表示拒绝本次操作；钩子 panic 时视为返回错误
+func (h *Hook[T]) Run(ctx context.Context, payload T) error {
+	for _, e := range h.snapshot() {
+		if err := call(ctx, e, payload); err != nil {
+			return &Error{Name: e.name, Err: err}
+		}
+	}
+	return nil
+}
+
+// Notify 调用所有钩子，错误只记录日志
+// 用于操作后的钩子，操作已经完成，钩子失败不影响调用方
+func (h *Hook[T]) Notify(ctx context.Context, payload T) {
+	for _, e := range h.snapshot() {
+		if err := call(ctx, e, payload); err != nil {
+			logs.CtxWarnf(ctx, "钩子执行失败: name=%s, error=%s", e.name, err.Error())
+		}
+	}
+}
+
+func (h *Hook[T]) snapshot() []entry[T] {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.entries
+}
+
+// call 调用钩子，panic 转换为错误
+func call[T any](ctx context.Context, e entry[T], payload T) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logs.CtxErrorf(ctx, "钩子 panic: name=%s, panic=%v\n%s", e.name, r, debug.Stack())
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return e.fn(ctx, payload)
+}
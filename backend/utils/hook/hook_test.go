@@ -0,0 +1,78 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type payload struct {
+	Content string
+	Calls   []string
+}
+
+// TestRunOrderAndModify 测试按注册顺序调用，钩子可以修改参数
+func TestRunOrderAndModify(t *testing.T) {
+	var h Hook[*payload]
+	h.Register("first", func(ctx context.Context, p *payload) error {
+		p.Calls = append(p.Calls, "first")
+		p.Content += "!"
+		return nil
+	})
+	h.Register("second", func(ctx context.Context, p *payload) error {
+		p.Calls = append(p.Calls, "second")
+		return nil
+	})
+
+	p := &payload{Content: "hi"}
+	if err := h.Run(context.Background(), p); err != nil {
+		t.Fatalf("期望成功，实际返回错误: %v", err)
+	}
+	if p.Content != "hi!" || len(p.Calls) != 2 || p.Calls[0] != "first" {
+		t.Fatalf("unexpected payload: %+v", p)
+	}
+	if h.Len() != 2 {
+		t.Fatalf("expected 2 hooks, got %d", h.Len())
+	}
+}
+
+// TestRunStopsOnError 测试钩子返回错误时中止，错误可以取出原始错误
+func TestRunStopsOnError(t *testing.T) {
+	errRejected := errors.New("rejected")
+	var h Hook[*payload]
+	h.Register("reject", func(ctx context.Context, p *payload) error {
+		return errRejected
+	})
+	h.Register("never", func(ctx context.Context, p *payload) error {
+		t.Fatal("错误之后的钩子不应执行")
+		return nil
+	})
+
+	err := h.Run(context.Background(), &payload{})
+	var hookErr *Error
+	if !errors.As(err, &hookErr) || hookErr.Name != "reject" || !errors.Is(err, errRejected) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestPanicRecovered 测试钩子 panic 时 Run 返回错误，Notify 继续调用后面的钩子
+func TestPanicRecovered(t *testing.T) {
+	var h Hook[*payload]
+	h.Register("panic", func(ctx context.Context, p *payload) error {
+		panic("boom")
+	})
+	h.Register("after", func(ctx context.Context, p *payload) error {
+		p.Calls = append(p.Calls, "after")
+		return nil
+	})
+
+	if err := h.Run(context.Background(), &payload{}); err == nil {
+		t.Fatal("expected error for panic")
+	}
+
+	p := &payload{}
+	h.Notify(context.Background(), p)
+	if len(p.Calls) != 1 {
+		t.Fatalf("Notify 应继续调用后面的钩子，got %v", p.Calls)
+	}
+}
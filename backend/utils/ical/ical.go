@@ -0,0 +1,152 @@
+// Package ical 生成 iCalendar（RFC 5545）格式的日历，用于日历订阅
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 组件类型
+const (
+	KindEvent = "VEVENT" // 日程
+	KindTodo  = "VTODO"  // 待办
+)
+
+// maxLineOctets 单行最大字节数（不含换行），超过时折行
+const maxLineOctets = 75
+
+// timeFormat UTC 时间格式
+const timeFormat = "20060102T150405Z"
+
+type property struct {
+	name  string
+	value string
+}
+
+// Component 日历组件（VEVENT、VTODO），属性按添加顺序输出
+type Component struct {
+	kind  string
+	props []property
+}
+
+// NewComponent 创建日历组件
+func NewComponent(kind string) *Component {
+	return &Component{kind: kind}
+}
+
+// Text 添加文本属性，值会被转义
+func (c *Component) Text(name, value string) *Component {
+	c.props = append(c.props, property{name: name, value: escapeText(value)})
+	return c
+}
+
+// Time 添加时间属性，统一输出为 UTC
+func (c *Component) Time(name string, t time.Time) *Component {
+	c.props = append(c.props, property{name: name, value: t.UTC().Format(timeFormat)})
+	return c
+}
+
+// List 添加多值文本属性（如 CATEGORIES），values 为空时不添加
+func (c *Component) List(name string, values []string) *Component {
+	if len(values) == 0 {
+		return c
+	}
+	escaped := make([]string, len(values))
+	for i, v := range values {
+		escaped[i] = escapeText(v)
+	}
+	c.props = append(c.props, property{name: name, value: strings.Join(escaped, ",")})
+	return c
+}
+
+// Raw 添加不转义的属性，用于 STATUS 等枚举值
+func (c *Component) Raw(name, value string) *Component {
+	c.props = append(c.props, property{name: name, value: value})
+	return c
+}
+
+// Calendar 日历
+type Calendar struct {
+	ProdID     string // 生成日历的产品标识
+	Name       string // 日历名称（X-WR-CALNAME），订阅时显示
+	Components []*Component
+}
+
+// Add 添加组件
+func (cal *Calendar) Add(components ...*Component) {
+	cal.Components = append(cal.Components, components...)
+}
+
+// WriteTo 以 iCalendar 格式写出日历，行以 CRLF 结尾，超长行按 75 字节折行
+func (cal *Calendar) WriteTo(w io.Writer) (int64, error) {
+	cw := &countWriter{w: w}
+	bw := bufio.NewWriter(cw)
+
+	writeLine(bw, "BEGIN", "VCALENDAR")
+	writeLine(bw, "VERSION", "2.0")
+	writeLine(bw, "PRODID", cal.ProdID)
+	writeLine(bw, "CALSCALE", "GREGORIAN")
+	writeLine(bw, "METHOD", "PUBLISH")
+	if cal.Name != "" {
+		writeLine(bw, "X-WR-CALNAME", escapeText(cal.Name))
+	}
+	for _, c := range cal.Components {
+		writeLine(bw, "BEGIN", c.kind)
+		for _, p := range c.props {
+			writeLine(bw, p.name, p.value)
+		}
+		writeLine(bw, "END", c.kind)
+	}
+	writeLine(bw, "END", "VCALENDAR")
+
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// writeLine 写出一行属性，超长时折行（续行以空格开头），不拆分 UTF-8 字符
+func writeLine(w *bufio.Writer, name, value string) {
+	line := name + ":" + value
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.WriteString(line[:cut])
+		w.WriteString("\r\n ")
+		line = line[cut:]
+		// 续行开头的空格占一个字节
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(line)
+	w.WriteString("\r\n")
+}
+
+// escapeText 转义文本值中的反斜杠、分号、逗号和换行
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+// countWriter 统计写出的字节数
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
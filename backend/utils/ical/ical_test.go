@@ -0,0 +1,90 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// TestCalendarWriteTo 测试日历输出格式
+func TestCalendarWriteTo(t *testing.T) {
+	due := time.Date(2024, 3, 1, 9, 30, 0, 0, time.FixedZone("CST", 8*3600))
+	cal := &Calendar{ProdID: "-//test//EN", Name: "项目"}
+	cal.Add(NewComponent(KindEvent).
+		Text("UID", "item-1@test").
+		Time("DTSTART", due).
+		Text("SUMMARY", "买菜; 做饭, 洗碗").
+		List("CATEGORIES", []string{"生活", "a,b"}).
+		List("EMPTY", nil).
+		Raw("STATUS", "CONFIRMED"))
+
+	var buf bytes.Buffer
+	n, err := cal.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("输出失败: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("字节数错误: %d != %d", n, buf.Len())
+	}
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//test//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:项目",
+		"BEGIN:VEVENT",
+		"UID:item-1@test",
+		"DTSTART:20240301T013000Z",
+		`SUMMARY:买菜\; 做饭\, 洗碗`,
+		`CATEGORIES:生活,a\,b`,
+		"STATUS:CONFIRMED",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+	if buf.String() != want {
+		t.Fatalf("输出错误:\n%q\n期望:\n%q", buf.String(), want)
+	}
+}
+
+// TestEscapeText 测试文本转义
+func TestEscapeText(t *testing.T) {
+	got := escapeText("a\\b;c,d\r\ne\nf")
+	want := `a\\b\;c\,d\ne\nf`
+	if got != want {
+		t.Fatalf("转义错误: %q，期望 %q", got, want)
+	}
+}
+
+// TestWriteLineFolding 测试超长行折行且不拆分 UTF-8 字符
+func TestWriteLineFolding(t *testing.T) {
+	cal := &Calendar{ProdID: "p"}
+	cal.Add(NewComponent(KindTodo).Text("DESCRIPTION", strings.Repeat("项目内容", 30)))
+
+	var buf bytes.Buffer
+	if _, err := cal.WriteTo(&buf); err != nil {
+		t.Fatalf("输出失败: %v", err)
+	}
+
+	var unfolded strings.Builder
+	for i, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Fatalf("第 %d 行超过 %d 字节: %d", i, maxLineOctets, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Fatalf("第 %d 行拆分了 UTF-8 字符: %q", i, line)
+		}
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+	if !strings.Contains(unfolded.String(), "\nDESCRIPTION:"+strings.Repeat("项目内容", 30)+"\n") {
+		t.Fatalf("折行还原后内容不一致: %q", unfolded.String())
+	}
+}
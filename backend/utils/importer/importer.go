@@ -0,0 +1,271 @@
+// Package importer 提供了导入第三方导出文件的解析器框架
+//
+// 每种导出格式对应一个 Parser，通过 Register 注册后按格式名查找。解析器只负责把文件内容转换为
+// 与存储无关的 Record（内容、完成状态、标签名），标签去重、长度限制和写入由调用方处理。
+// 内置 todoist（CSV）、notion（zip 中的 Markdown 页面和 CSV 数据库）和 markdown 三种格式
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxUncompressedSize zip 解压后的总大小上限，防止压缩炸弹
+	maxUncompressedSize = 64 << 20
+	// maxZipEntries zip 中最多处理的文件数
+	maxZipEntries = 10000
+)
+
+var (
+	// ErrUnknownFormat 格式没有注册解析器
+	ErrUnknownFormat = errors.New("不支持的导入格式")
+	// ErrTooLarge zip 解压后超过大小上限
+	ErrTooLarge = errors.New("压缩包解压后过大")
+)
+
+// Record 解析出的一条记录，对应一个项目
+type Record struct {
+	Source  string   `json:"source"`         // 来源位置，如 tasks.csv:3
+	Content string   `json:"content"`        // 内容
+	Done    bool     `json:"done"`           // 是否已完成
+	Marked  bool     `json:"marked"`         // 是否标记为重要
+	Tags    []string `json:"tags,omitempty"` // 标签名（未去重，保持原始大小写）
+}
+
+// Skip 跳过的内容及原因
+type Skip struct {
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+}
+
+// Result 解析结果
+type Result struct {
+	Records []Record
+	Skipped []Skip
+}
+
+func (r *Result) add(record Record) {
+	record.Content = strings.TrimSpace(record.Content)
+	if record.Content == "" {
+		r.skip(record.Source, "内容为空")
+		return
+	}
+	r.Records = append(r.Records, record)
+}
+
+func (r *Result) skip(source, reason string) {
+	r.Skipped = append(r.Skipped, Skip{Source: source, Reason: reason})
+}
+
+// Parser 导出文件解析器，name 为上传的文件名，用于判断文件类型和生成来源位置
+type Parser interface {
+	Parse(name string, data []byte) (*Result, error)
+}
+
+// ParserFunc 函数形式的解析器
+type ParserFunc func(name string, data []byte) (*Result, error)
+
+// Parse 实现 Parser
+func (f ParserFunc) Parse(name string, data []byte) (*Result, error) {
+	return f(name, data)
+}
+
+var (
+	mu      sync.RWMutex
+	parsers = make(map[string]Parser)
+)
+
+// Register 注册格式的解析器，格式名重复时 panic
+func Register(format string, parser Parser) {
+	if format == "" || parser == nil {
+		panic("importer: 格式名和解析器不能为空")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := parsers[format]; ok {
+		panic("importer: 格式已注册: " + format)
+	}
+	parsers[format] = parser
+}
+
+// Lookup 查找格式的解析器
+func Lookup(format string) (Parser, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	parser, ok := parsers[format]
+	return parser, ok
+}
+
+// Formats 返回已注册的格式（按名称排序）
+func Formats() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	formats := make([]string, 0, len(parsers))
+	for format := range parsers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Parse 使用格式的解析器解析文件
+func Parse(format string, name string, data []byte) (*Result, error) {
+	parser, ok := Lookup(format)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+	return parser.Parse(name, data)
+}
+
+func init() {
+	Register("todoist", ParserFunc(parseTodoist))
+	Register("notion", ParserFunc(parseNotion))
+	Register("markdown", ParserFunc(parseMarkdown))
+}
+
+// entry 导出文件中的一个文件（单个文件或 zip 中的文件）
+type entry struct {
+	name string
+	data []byte
+}
+
+// isZip 是否为 zip 文件
+func isZip(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("PK\x03\x04"))
+}
+
+// readEntries 读取文件内容，zip 文件展开为其中扩展名在 exts 中的文件，按路径排序
+// 单个文件直接返回，不检查扩展名
+func readEntries(name string, data []byte, exts ...string) ([]entry, error) {
+	if !isZip(data) {
+		return []entry{{name: name, data: data}}, nil
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("读取压缩包失败: %w", err)
+	}
+
+	var entries []entry
+	var total int64
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !hasExt(f.Name, exts) || isHidden(f.Name) {
+			continue
+		}
+		if len(entries) >= maxZipEntries {
+			return nil, fmt.Errorf("压缩包中的文件超过 %d 个", maxZipEntries)
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("读取压缩包中的文件失败: %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, maxUncompressedSize-total+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("读取压缩包中的文件失败: %s: %w", f.Name, err)
+		}
+		total += int64(len(content))
+		if total > maxUncompressedSize {
+			return nil, ErrTooLarge
+		}
+		entries = append(entries, entry{name: f.Name, data: content})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	return entries, nil
+}
+
+func hasExt(name string, exts []string) bool {
+	ext := strings.ToLower(path.Ext(name))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+// isHidden 是否为隐藏文件或 macOS 压缩时附带的元数据文件
+func isHidden(name string) bool {
+	for _, part := range strings.Split(name, "/") {
+		if strings.HasPrefix(part, ".") || part == "__MACOSX" {
+			return true
+		}
+	}
+	return false
+}
+
+// baseName 去掉目录和扩展名的文件名
+func baseName(name string) string {
+	base := path.Base(name)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// source 生成来源位置
+func source(name string, line int) string {
+	return fmt.Sprintf("%s:%d", path.Base(name), line)
+}
+
+// stripBOM 去掉 UTF-8 BOM
+func stripBOM(data []byte) []byte {
+	return bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF"))
+}
+
+// extractTags 提取文本中 re 匹配的标签（如 #work、@home），返回去掉标签后的文本和标签名
+// 标签前需要是行首或空白字符并以字母开头，邮箱地址、Markdown 标题和 #1 这样的编号不会被识别为标签
+func extractTags(text string, re *regexp.Regexp) (string, []string) {
+	var tags []string
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		matches := re.FindAllStringSubmatch(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		for _, m := range matches {
+			tags = append(tags, m[2])
+		}
+		// 只整理包含标签的行，其他行保持原有缩进
+		lines[i] = strings.Join(strings.Fields(re.ReplaceAllString(line, "$1")), " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n")), tags
+}
+
+var (
+	hashTagPattern = regexp.MustCompile(`(^|\s)#([\p{L}_][\p{L}\p{N}_/-]*)`)
+	atTagPattern   = regexp.MustCompile(`(^|\s)@([\p{L}_][\p{L}\p{N}_/-]*)`)
+)
+
+// splitList 拆分逗号分隔的列表，去掉空白和空项
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
+
+// isTruthy 是否为表示"是/已完成"的值
+func isTruthy(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "yes", "true", "done", "completed", "complete", "finished", "checked", "x", "1", "是", "完成", "已完成":
+		return true
+	default:
+		return false
+	}
+}
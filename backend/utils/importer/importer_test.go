@@ -0,0 +1,167 @@
+package importer
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// zipData 生成测试用的 zip 文件
+func zipData(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// TestRegistry 测试内置格式和未注册的格式
+func TestRegistry(t *testing.T) {
+	if got := Formats(); !reflect.DeepEqual(got, []string{"markdown", "notion", "todoist"}) {
+		t.Errorf("unexpected formats: %v", got)
+	}
+	if _, err := Parse("evernote", "a.enex", nil); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+// TestParseTodoist 测试 Todoist CSV 的任务、分组、标签、优先级和评论
+func TestParseTodoist(t *testing.T) {
+	csv := "\xEF\xBB\xBFTYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"meta,view_style=list,,,,,,,,\n" +
+		"task,Buy milk @errand @home,,4,1,,,,,\n" +
+		"note,Whole milk only,,,,,,,,\n" +
+		"\n" +
+		"section,Work,,,,,,,,\n" +
+		"task,Send report,Quarterly numbers,1,1,,,,,\n" +
+		"task,,,4,1,,,,,\n"
+
+	result, err := Parse("todoist", "Inbox.csv", []byte(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{
+		{Source: "Inbox.csv:3", Content: "Buy milk\n\nWhole milk only", Tags: []string{"errand", "home"}},
+		{Source: "Inbox.csv:7", Content: "Send report\nQuarterly numbers", Marked: true, Tags: []string{"Work"}},
+	}
+	if !reflect.DeepEqual(result.Records, want) {
+		t.Errorf("unexpected records:\n%+v\nwant:\n%+v", result.Records, want)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Source != "Inbox.csv:8" {
+		t.Errorf("unexpected skipped: %+v", result.Skipped)
+	}
+
+	// 备份文件中有多个项目时项目名作为标签
+	data := zipData(t, map[string]string{
+		"Personal.csv": "TYPE,CONTENT\ntask,Call mom\n",
+		"Work.csv":     "TYPE,CONTENT\ntask,Review PR @urgent\n",
+	})
+	result, err = Parse("todoist", "backup.zip", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Record{
+		{Source: "Personal.csv:2", Content: "Call mom", Tags: []string{"Personal"}},
+		{Source: "Work.csv:2", Content: "Review PR", Tags: []string{"Work", "urgent"}},
+	}
+	if !reflect.DeepEqual(result.Records, want) {
+		t.Errorf("unexpected records:\n%+v\nwant:\n%+v", result.Records, want)
+	}
+
+	if _, err := Parse("todoist", "a.csv", []byte("name,value\na,b\n")); err == nil {
+		t.Error("csv without TYPE column should fail")
+	}
+}
+
+// TestParseMarkdown 测试列表项、任务状态、子列表、标签和 front matter
+func TestParseMarkdown(t *testing.T) {
+	md := "---\ntitle: Week\ntags:\n  - plan\n---\n" +
+		"# Todo\n\n" +
+		"- [x] Write spec #work\n" +
+		"- [ ] Book flights\n" +
+		"  - compare prices\n" +
+		"  details on #travel site\n" +
+		"\n" +
+		"Some paragraph ends the list.\n" +
+		"1. Numbered item #1 ignored\n" +
+		"   ```\n   code\n   ```\n"
+
+	result, err := Parse("markdown", "week.md", []byte(md))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{
+		{Source: "week.md:8", Content: "Write spec", Done: true, Tags: []string{"plan", "work"}},
+		{Source: "week.md:9", Content: "Book flights\n- compare prices\ndetails on site", Tags: []string{"plan", "travel"}},
+		{Source: "week.md:14", Content: "Numbered item #1 ignored\n```\ncode\n```", Tags: []string{"plan"}},
+	}
+	if !reflect.DeepEqual(result.Records, want) {
+		t.Errorf("unexpected records:\n%#v\nwant:\n%#v", result.Records, want)
+	}
+
+	// 没有列表项的文件作为一条笔记
+	data := zipData(t, map[string]string{
+		"notes/idea.md":          "Plain note about #ideas\n\nSecond paragraph.",
+		"notes/.obsidian/app.md": "- hidden",
+		"notes/image.png":        "binary",
+	})
+	result, err = Parse("markdown", "vault.zip", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Record{
+		{Source: "idea.md:1", Content: "Plain note about\n\nSecond paragraph.", Tags: []string{"ideas"}},
+	}
+	if !reflect.DeepEqual(result.Records, want) {
+		t.Errorf("unexpected records:\n%#v\nwant:\n%#v", result.Records, want)
+	}
+}
+
+// TestParseNotion 测试页面属性、数据库 CSV 以及数据库同时导出为页面时不重复导入
+func TestParseNotion(t *testing.T) {
+	data := zipData(t, map[string]string{
+		"Export/Plan 0123456789abcdef0123456789abcdef.md":             "# Plan\n\nTags: work, q3\nStatus: Done\nPriority: High\n\nBody text\nNote: not a property",
+		"Export/Tasks 11111111111111111111111111111111.csv":           "\xEF\xBB\xBFName,Tags,Done\nFix bug,dev,Yes\nWrite docs,,No\n",
+		"Export/Tasks 11111111111111111111111111111111_all.csv":       "Name,Tags,Done\nFix bug,dev,Yes\nWrite docs,,No\n",
+		"Export/Db 22222222222222222222222222222222.csv":              "Name,Status\nRow page,Done\n",
+		"Export/Db 22222222222222222222222222222222/Row page 3333.md": "# Row page\n\nStatus: Done\n\nRow body",
+	})
+
+	result, err := Parse("notion", "export.zip", data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Record{
+		{Source: "Row page 3333.md:1", Content: "Row page\n\nRow body", Done: true},
+		{Source: "Plan 0123456789abcdef0123456789abcdef.md:1", Content: "Plan\n\nBody text\nNote: not a property", Done: true, Marked: true, Tags: []string{"work", "q3"}},
+		{Source: "Tasks 11111111111111111111111111111111.csv:2", Content: "Fix bug", Done: true, Tags: []string{"dev"}},
+		{Source: "Tasks 11111111111111111111111111111111.csv:3", Content: "Write docs"},
+	}
+	if !reflect.DeepEqual(result.Records, want) {
+		t.Errorf("unexpected records:\n%#v\nwant:\n%#v", result.Records, want)
+	}
+}
+
+// TestReadEntriesTooLarge 测试解压后超过大小上限的压缩包
+func TestReadEntriesTooLarge(t *testing.T) {
+	data := zipData(t, map[string]string{
+		"a.md": string(bytes.Repeat([]byte("a"), maxUncompressedSize/2+1)),
+		"b.md": string(bytes.Repeat([]byte("b"), maxUncompressedSize/2+1)),
+	})
+	if _, err := Parse("markdown", "big.zip", data); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package importer
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// listItemPattern 列表项: 缩进、列表标记、任务状态、内容
+	listItemPattern = regexp.MustCompile(`^(\s*)((?:[-*+]|\d+[.)])\s+)(?:\[([ xX])\]\s*)?(.*)$`)
+	// codeFencePattern 代码块开始或结束
+	codeFencePattern = regexp.MustCompile("^\\s*(```|~~~)")
+)
+
+// parseMarkdown 解析 Markdown 文件，或包含多个 Markdown 文件的 zip（如 Obsidian 库）
+//
+//   - 顶层列表项（- * + 或 1.）为项目，子列表和缩进的行追加到上一项的内容中
+//   - 任务列表中 [x] 为已完成，内容中的 #tag 为标签
+//   - 文件中没有列表项时整个文件作为一个项目（笔记）
+//   - front matter 中的 tags 作为文件中所有项目的标签
+func parseMarkdown(name string, data []byte) (*Result, error) {
+	entries, err := readEntries(name, data, ".md", ".markdown", ".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, e := range entries {
+		parseMarkdownFile(result, e.name, string(stripBOM(e.data)))
+	}
+	return result, nil
+}
+
+func parseMarkdownFile(result *Result, name string, text string) {
+	body, fileTags, offset := splitFrontMatter(strings.ReplaceAll(text, "\r\n", "\n"))
+
+	type item struct {
+		line   int
+		indent int // 内容的缩进，续行去掉这部分缩进
+		lines  []string
+		done   bool
+	}
+	var items []*item
+	var current *item
+	base := -1 // 顶层列表项的缩进
+	inCode := false
+
+	for i, line := range strings.Split(body, "\n") {
+		fence := codeFencePattern.MatchString(line)
+		if fence {
+			inCode = !inCode
+		}
+		if fence || inCode {
+			if current != nil {
+				current.lines = append(current.lines, trimIndent(line, current.indent))
+			}
+			continue
+		}
+
+		indent := indentWidth(line)
+		if m := listItemPattern.FindStringSubmatch(line); m != nil {
+			if base < 0 || indent <= base {
+				base = indent
+				current = &item{
+					line:   offset + i + 1,
+					indent: indent + len(m[2]),
+					lines:  []string{m[4]},
+					done:   m[3] == "x" || m[3] == "X",
+				}
+				items = append(items, current)
+				continue
+			}
+		}
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			// 空行不结束当前项，之后缩进的行仍属于当前项
+		case current != nil && indent > base:
+			current.lines = append(current.lines, strings.TrimRight(trimIndent(line, current.indent), " \t"))
+		default:
+			// 标题和正文结束当前项
+			current = nil
+		}
+	}
+
+	// 没有列表项时整个文件是一条笔记
+	if len(items) == 0 {
+		content, tags := extractTags(body, hashTagPattern)
+		result.add(Record{
+			Source:  source(name, offset+1),
+			Content: content,
+			Tags:    append(append([]string{}, fileTags...), tags...),
+		})
+		return
+	}
+
+	for _, it := range items {
+		content, tags := extractTags(strings.Join(it.lines, "\n"), hashTagPattern)
+		result.add(Record{
+			Source:  source(name, it.line),
+			Content: content,
+			Done:    it.done,
+			Tags:    append(append([]string{}, fileTags...), tags...),
+		})
+	}
+}
+
+// splitFrontMatter 拆分 YAML front matter，返回正文、其中的 tags 和正文开始前的行数
+// 支持 tags: a, b、tags: [a, b] 和多行列表三种写法
+func splitFrontMatter(text string) (string, []string, int) {
+	if !strings.HasPrefix(text, "---\n") {
+		return text, nil, 0
+	}
+	lines := strings.Split(text, "\n")
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		return text, nil, 0
+	}
+
+	var tags []string
+	inTags := false
+	for _, line := range lines[1:end] {
+		trimmed := strings.TrimSpace(line)
+		if inTags && strings.HasPrefix(trimmed, "- ") {
+			tags = append(tags, unquote(strings.TrimPrefix(trimmed, "- ")))
+			continue
+		}
+		inTags = false
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(strings.ToLower(key)) != "tags" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			inTags = true
+			continue
+		}
+		for _, tag := range splitList(strings.Trim(value, "[]")) {
+			tags = append(tags, unquote(tag))
+		}
+	}
+	return strings.Join(lines[end+1:], "\n"), tags, end + 1
+}
+
+// indentWidth 行首缩进宽度，制表符按 4 个空格计算
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// trimIndent 去掉行首最多 width 宽度的缩进
+func trimIndent(line string, width int) string {
+	for i, r := range line {
+		switch {
+		case width <= 0:
+			return line[i:]
+		case r == ' ':
+			width--
+		case r == '\t':
+			width -= 4
+		default:
+			return line[i:]
+		}
+	}
+	return ""
+}
+
+func unquote(value string) string {
+	return strings.Trim(strings.TrimSpace(value), `"'`)
+}
@@ -0,0 +1,186 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// notionPropertyPattern 页面标题下方的属性行，如 "Tags: work, home"
+var notionPropertyPattern = regexp.MustCompile(`^([^:]{1,40}):\s*(.*)$`)
+
+// Notion 属性名（小写），分别对应标签、完成状态和优先级
+var (
+	notionTagKeys      = map[string]bool{"tags": true, "tag": true, "labels": true, "label": true, "标签": true}
+	notionStatusKeys   = map[string]bool{"status": true, "state": true, "done": true, "completed": true, "complete": true, "状态": true, "完成": true}
+	notionPriorityKeys = map[string]bool{"priority": true, "优先级": true}
+)
+
+// parseNotion 解析 Notion 导出的 zip（Markdown & CSV 格式），也可以是单个页面（.md）或数据库（.csv）
+//
+//   - 页面以标题为第一行，标题下方的 "属性: 值" 行为页面属性，标题和正文作为项目内容
+//   - 数据库 CSV 的每一行为一个项目，第一列（Name）为内容
+//   - Tags 属性为标签，Status/Done 属性为 Done、Yes 等值时为已完成，Priority 为 High 时标记为重要
+//
+// 导出数据库时每一行也会导出为数据库同名目录下的页面，页面包含正文，这时只导入页面，不再导入 CSV
+func parseNotion(name string, data []byte) (*Result, error) {
+	entries, err := readEntries(name, data, ".md", ".csv")
+	if err != nil {
+		return nil, err
+	}
+
+	pageDirs := make(map[string]bool)
+	csvNames := make(map[string]bool)
+	for _, e := range entries {
+		switch strings.ToLower(path.Ext(e.name)) {
+		case ".md":
+			pageDirs[path.Dir(e.name)] = true
+		case ".csv":
+			csvNames[trimExt(e.name)] = true
+		}
+	}
+
+	result := &Result{}
+	for _, e := range entries {
+		if strings.ToLower(path.Ext(e.name)) != ".csv" {
+			parseNotionPage(result, e)
+			continue
+		}
+
+		// 新版导出同时包含 <数据库名>.csv 和 <数据库名>_all.csv，内容相同
+		database := trimExt(e.name)
+		if strings.HasSuffix(database, "_all") && csvNames[strings.TrimSuffix(database, "_all")] {
+			continue
+		}
+		database = strings.TrimSuffix(database, "_all")
+		if pageDirs[database] {
+			continue
+		}
+		if err := parseNotionCSV(result, e); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func parseNotionPage(result *Result, e entry) {
+	lines := strings.Split(strings.ReplaceAll(string(stripBOM(e.data)), "\r\n", "\n"), "\n")
+
+	// 标题
+	i := 0
+	for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+		i++
+	}
+	title := ""
+	if i < len(lines) && strings.HasPrefix(lines[i], "# ") {
+		title = strings.TrimSpace(strings.TrimPrefix(lines[i], "# "))
+		i++
+	}
+
+	// 属性（紧跟在标题后的连续属性行）
+	record := Record{Source: source(e.name, 1)}
+	if title != "" {
+		for i < len(lines) && strings.TrimSpace(lines[i]) == "" {
+			i++
+		}
+		start := i
+		var props []property
+		for i < len(lines) {
+			m := notionPropertyPattern.FindStringSubmatch(lines[i])
+			if m == nil {
+				break
+			}
+			props = append(props, property{key: m[1], value: m[2]})
+			i++
+		}
+		// 属性后面必须是空行或文件结尾，否则是正文
+		if i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+			i = start
+		} else {
+			applyNotionProperties(&record, props)
+		}
+	}
+
+	body := strings.TrimSpace(strings.Join(lines[i:], "\n"))
+	switch {
+	case title == "":
+		record.Content = body
+	case body == "":
+		record.Content = title
+	default:
+		record.Content = title + "\n\n" + body
+	}
+	result.add(record)
+}
+
+func parseNotionCSV(result *Result, e entry) error {
+	r := csv.NewReader(bytes.NewReader(stripBOM(e.data)))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("读取 %s 失败: %w", e.name, err)
+	}
+
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return fmt.Errorf("读取 %s 失败: %w", e.name, err)
+			}
+			result.skip(source(e.name, parseErr.StartLine), "CSV 格式错误: "+parseErr.Err.Error())
+			continue
+		}
+		line, _ := r.FieldPos(0)
+
+		props := make([]property, 0, len(header))
+		for j := 1; j < len(header) && j < len(row); j++ {
+			props = append(props, property{key: header[j], value: row[j]})
+		}
+		record := Record{Source: source(e.name, line), Content: row[0]}
+		applyNotionProperties(&record, props)
+		result.add(record)
+	}
+}
+
+// property 页面属性或数据库的一列
+type property struct {
+	key   string
+	value string
+}
+
+// applyNotionProperties 根据页面属性设置标签、完成状态和是否重要
+func applyNotionProperties(record *Record, props []property) {
+	for _, prop := range props {
+		key, value := strings.ToLower(strings.TrimSpace(prop.key)), prop.value
+		switch {
+		case notionTagKeys[key]:
+			record.Tags = append(record.Tags, splitList(value)...)
+		case notionStatusKeys[key]:
+			record.Done = record.Done || isTruthy(value)
+		case notionPriorityKeys[key]:
+			switch strings.ToLower(strings.TrimSpace(value)) {
+			case "high", "urgent", "p1", "高", "紧急":
+				record.Marked = true
+			}
+		}
+	}
+}
+
+// trimExt 去掉扩展名，保留目录
+func trimExt(name string) string {
+	return strings.TrimSuffix(name, path.Ext(name))
+}
@@ -0,0 +1,123 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// parseTodoist 解析 Todoist 导出的项目 CSV，或包含多个项目 CSV 的 zip（备份文件）
+//
+//   - task 行为项目，内容中的 @label 为标签，DESCRIPTION 追加在内容后，PRIORITY 为 1（最高优先级）时标记为重要
+//   - section 行的名称作为之后任务的标签；zip 中有多个 CSV 时项目名（文件名）也作为标签
+//   - note 行（评论）追加到上一个任务的内容中
+//
+// Todoist 只导出未完成的任务，导入的项目状态都是未完成
+func parseTodoist(name string, data []byte) (*Result, error) {
+	entries, err := readEntries(name, data, ".csv")
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for _, e := range entries {
+		project := ""
+		if len(entries) > 1 {
+			project = baseName(e.name)
+		}
+		if err := parseTodoistCSV(result, e, project); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func parseTodoistCSV(result *Result, e entry, project string) error {
+	r := csv.NewReader(bytes.NewReader(stripBOM(e.data)))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("%s 不是 Todoist 导出的 CSV: %w", e.name, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, col := range header {
+		columns[strings.ToUpper(strings.TrimSpace(col))] = i
+	}
+	if _, ok := columns["TYPE"]; !ok {
+		return fmt.Errorf("%s 不是 Todoist 导出的 CSV: 缺少 TYPE 列", e.name)
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return fmt.Errorf("%s 不是 Todoist 导出的 CSV: 缺少 CONTENT 列", e.name)
+	}
+	field := func(row []string, col string) string {
+		i, ok := columns[col]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	section := ""
+	last := -1 // 上一个任务在 result.Records 中的位置
+	for {
+		row, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return fmt.Errorf("读取 %s 失败: %w", e.name, err)
+			}
+			result.skip(source(e.name, parseErr.StartLine), "CSV 格式错误: "+parseErr.Err.Error())
+			continue
+		}
+		line, _ := r.FieldPos(0)
+
+		switch strings.ToLower(field(row, "TYPE")) {
+		case "task":
+			content, tags := extractTags(field(row, "CONTENT"), atTagPattern)
+			if description := field(row, "DESCRIPTION"); description != "" {
+				content += "\n" + description
+			}
+			if section != "" {
+				tags = append([]string{section}, tags...)
+			}
+			if project != "" {
+				tags = append([]string{project}, tags...)
+			}
+
+			before := len(result.Records)
+			result.add(Record{
+				Source:  source(e.name, line),
+				Content: content,
+				Marked:  field(row, "PRIORITY") == "1",
+				Tags:    tags,
+			})
+			if len(result.Records) > before {
+				last = before
+			} else {
+				last = -1
+			}
+		case "section":
+			section = field(row, "CONTENT")
+			last = -1
+		case "note":
+			note := field(row, "CONTENT")
+			if last < 0 || note == "" {
+				result.skip(source(e.name, line), "评论没有对应的任务")
+				continue
+			}
+			result.Records[last].Content += "\n\n" + note
+		case "":
+			// 空行分隔不同的分组
+		default:
+			// meta 等行不对应项目
+		}
+	}
+}
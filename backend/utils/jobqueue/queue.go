@@ -0,0 +1,468 @@
+// Package jobqueue 提供了一个持久化的后台任务队列，支持工作协程池、失败重试、死信和进程重启后继续执行
+//
+// 任务保存在 Store 中（通常是数据库表），工作协程按类型领取任务并加租约，
+// 进程崩溃后租约过期的任务会被重新领取，因此任务处理函数需要保证幂等
+package jobqueue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/retry"
+	"backend/utils/safego"
+)
+
+// Status 任务状态
+type Status string
+
+const (
+	// StatusPending 等待执行（包括等待重试）
+	StatusPending Status = "pending"
+	// StatusRunning 执行中
+	StatusRunning Status = "running"
+	// StatusSucceeded 执行成功
+	StatusSucceeded Status = "succeeded"
+	// StatusDead 超过最大重试次数或不可重试的失败，需要人工处理
+	StatusDead Status = "dead"
+)
+
+const (
+	// RunsMetricName 任务执行次数计数器名称（标签: type、result）
+	RunsMetricName = "job_queue_runs_total"
+
+	// ResultSucceeded 执行成功
+	ResultSucceeded = "succeeded"
+	// ResultRetried 执行失败，等待重试
+	ResultRetried = "retried"
+	// ResultDead 执行失败，转为死信
+	ResultDead = "dead"
+
+	// storeTimeout 更新任务状态的超时时间
+	storeTimeout = 5 * time.Second
+)
+
+var (
+	// ErrUnknownType 任务类型没有注册处理函数
+	ErrUnknownType = errors.New("未注册的任务类型")
+	// ErrStopped 队列已停止
+	ErrStopped = errors.New("任务队列已停止")
+)
+
+// runsCounter 任务执行次数（result: succeeded、retried、dead）
+var runsCounter = metrics.NewCounterVec(RunsMetricName, "后台任务执行次数", "type", "result")
+
+// Job 队列中的任务
+type Job struct {
+	ID          uint            `json:"id"`
+	TenantID    string          `json:"tenant_id,omitempty"`  // 由存储在创建时填充，执行时用于恢复上下文
+	CreatedBy   uint            `json:"created_by,omitempty"` // 入队的用户ID，由存储在创建时填充，系统入队时为 0
+	Type        string          `json:"type"`
+	Payload     json.RawMessage `json:"payload,omitempty"`
+	Status      Status          `json:"status"`
+	Attempts    int             `json:"attempts"` // 已领取次数（包括正在执行的一次）
+	MaxAttempts int             `json:"max_attempts"`
+	RunAt       time.Time       `json:"run_at"` // 最早执行时间
+	LastError   string          `json:"last_error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+}
+
+// Decode 将任务参数解析到 v
+func (j *Job) Decode(v any) error {
+	if len(j.Payload) == 0 {
+		return nil
+	}
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Store 任务存储
+type Store interface {
+	// Enqueue 保存新任务，需要回填 ID 和 CreatedAt
+	Enqueue(ctx context.Context, job *Job) error
+	// Claim 领取最多 limit 个可执行的任务：类型在 types 中，且为 pending 状态并到达执行时间，
+	// 或为 running 状态但租约已过期（执行中的进程已退出）
+	// 领取的任务状态改为 running，Attempts 加 1，租约到期时间为 leaseUntil
+	Claim(ctx context.Context, types []string, now time.Time, leaseUntil time.Time, limit int) ([]*Job, error)
+	// Complete 标记任务执行成功
+	Complete(ctx context.Context, id uint, finishedAt time.Time) error
+	// Retry 标记任务执行失败，在 runAt 之后重试
+	Retry(ctx context.Context, id uint, runAt time.Time, lastError string) error
+	// Dead 标记任务为死信
+	Dead(ctx context.Context, id uint, finishedAt time.Time, lastError string) error
+}
+
+// Handler 任务处理函数，返回 retry.Permanent 包装的错误时不再重试
+type Handler func(ctx context.Context, job *Job) error
+
+// Option 队列配置选项
+type Option func(*Queue)
+
+// WithWorkers 设置工作协程数量，默认 4
+func WithWorkers(n int) Option {
+	return func(q *Queue) {
+		if n > 0 {
+			q.workers = n
+		}
+	}
+}
+
+// WithPollInterval 设置没有新任务通知时的轮询间隔，默认 1s
+func WithPollInterval(d time.Duration) Option {
+	return func(q *Queue) {
+		if d > 0 {
+			q.pollInterval = d
+		}
+	}
+}
+
+// WithLease 设置任务租约时长，默认 10m
+// 处理函数的 ctx 在租约到期时取消，避免任务被其他进程重复领取时仍在执行
+func WithLease(d time.Duration) Option {
+	return func(q *Queue) {
+		if d > 0 {
+			q.lease = d
+		}
+	}
+}
+
+// WithMaxAttempts 设置默认最大执行次数（包括首次执行），默认 5
+func WithMaxAttempts(n int) Option {
+	return func(q *Queue) {
+		if n > 0 {
+			q.maxAttempts = n
+		}
+	}
+}
+
+// WithBackoff 设置重试的退避策略（只使用 InitialInterval、MaxInterval 和 Multiplier）
+// 默认首次 10s，每次翻倍，最长 1h
+func WithBackoff(b retry.Backoff) Option {
+	return func(q *Queue) {
+		q.backoff = b
+	}
+}
+
+// WithContext 设置执行任务前对 ctx 的处理，如根据 Job.TenantID 恢复租户信息
+func WithContext(fn func(ctx context.Context, job *Job) context.Context) Option {
+	return func(q *Queue) {
+		q.prepareContext = fn
+	}
+}
+
+// EnqueueOption 入队选项
+type EnqueueOption func(*Job)
+
+// RunAt 设置最早执行时间
+func RunAt(t time.Time) EnqueueOption {
+	return func(j *Job) {
+		j.RunAt = t
+	}
+}
+
+// MaxAttempts 设置最大执行次数
+func MaxAttempts(n int) EnqueueOption {
+	return func(j *Job) {
+		if n > 0 {
+			j.MaxAttempts = n
+		}
+	}
+}
+
+// Queue 后台任务队列
+type Queue struct {
+	store          Store
+	workers        int
+	pollInterval   time.Duration
+	lease          time.Duration
+	maxAttempts    int
+	backoff        retry.Backoff
+	prepareContext func(ctx context.Context, job *Job) context.Context
+	now            func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	started  bool
+
+	// wake 有新任务入队时唤醒轮询
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New 创建任务队列
+func New(store Store, opts ...Option) *Queue {
+	q := &Queue{
+		store:        store,
+		workers:      4,
+		pollInterval: time.Second,
+		lease:        10 * time.Minute,
+		maxAttempts:  5,
+		backoff: retry.Backoff{
+			InitialInterval: 10 * time.Second,
+			MaxInterval:     time.Hour,
+			Multiplier:      2,
+		},
+		now:      time.Now,
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(q)
+	}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	return q
+}
+
+// Handle 注册任务类型的处理函数，需要在 Start 之前调用
+func (q *Queue) Handle(jobType string, handler Handler) error {
+	if jobType == "" || handler == nil {
+		return errors.New("任务类型和处理函数不能为空")
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.started {
+		return errors.New("任务队列已启动，不能再注册处理函数")
+	}
+	if _, ok := q.handlers[jobType]; ok {
+		return fmt.Errorf("任务类型已注册: %s", jobType)
+	}
+	q.handlers[jobType] = handler
+	return nil
+}
+
+// Types 返回已注册的任务类型（按名称排序）
+func (q *Queue) Types() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	types := make([]string, 0, len(q.handlers))
+	for jobType := range q.handlers {
+		types = append(types, jobType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Enqueue 添加任务，payload 序列化为 JSON 保存
+// 任务类型必须已注册；未启动工作协程（如多实例中只入队的实例）时任务由其他实例执行
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload any, opts ...EnqueueOption) (*Job, error) {
+	q.mu.RLock()
+	_, ok := q.handlers[jobType]
+	q.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, jobType)
+	}
+
+	var raw json.RawMessage
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("序列化任务参数失败: %w", err)
+		}
+		raw = data
+	}
+
+	job := &Job{
+		Type:        jobType,
+		Payload:     raw,
+		Status:      StatusPending,
+		MaxAttempts: q.maxAttempts,
+		RunAt:       q.now(),
+	}
+	for _, opt := range opts {
+		opt(job)
+	}
+	if err := q.store.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	q.notify()
+	return job, nil
+}
+
+// Start 启动工作协程
+func (q *Queue) Start() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.started {
+		return nil
+	}
+	if q.ctx.Err() != nil {
+		return ErrStopped
+	}
+	q.started = true
+
+	q.wg.Add(1)
+	safego.Go(q.ctx, func() {
+		defer q.wg.Done()
+		q.poll()
+	})
+	logs.Info("后台任务队列已启动", "workers", q.workers, "types", len(q.handlers))
+	return nil
+}
+
+// Stop 停止领取新任务，取消正在执行的任务并等待其结束（最多等待到 ctx 结束）
+// 被取消的任务会按失败处理并等待重试
+func (q *Queue) Stop(ctx context.Context) error {
+	q.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// notify 唤醒轮询协程
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// poll 按空闲的工作协程数量领取任务
+func (q *Queue) poll() {
+	slots := make(chan struct{}, q.workers)
+	ticker := time.NewTicker(q.pollInterval)
+	defer ticker.Stop()
+
+	types := q.Types()
+	for {
+		// 领取到的任务数等于空闲数时可能还有更多任务，立即继续领取
+		for q.claim(types, slots) {
+		}
+
+		select {
+		case <-q.ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim 领取任务并交给工作协程执行，返回是否占满了所有空闲的工作协程
+func (q *Queue) claim(types []string, slots chan struct{}) bool {
+	free := cap(slots) - len(slots)
+	if free == 0 || q.ctx.Err() != nil {
+		return false
+	}
+
+	now := q.now()
+	ctx, cancel := context.WithTimeout(q.ctx, storeTimeout)
+	jobs, err := q.store.Claim(ctx, types, now, now.Add(q.lease), free)
+	cancel()
+	if err != nil {
+		if q.ctx.Err() == nil {
+			logs.Warn("领取后台任务失败", "error", err.Error())
+		}
+		return false
+	}
+
+	for _, job := range jobs {
+		slots <- struct{}{}
+		q.wg.Add(1)
+		safego.Go(q.ctx, func() {
+			defer func() {
+				<-slots
+				q.wg.Done()
+				q.notify()
+			}()
+			q.execute(job)
+		})
+	}
+	return len(jobs) == free
+}
+
+// execute 执行任务并更新状态
+func (q *Queue) execute(job *Job) {
+	q.mu.RLock()
+	handler := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	// 进程在执行过程中多次退出会导致领取次数超过上限
+	if job.Attempts > job.MaxAttempts {
+		q.finish(job, fmt.Errorf("超过最大执行次数 %d", job.MaxAttempts), 0)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(q.ctx, q.lease)
+	defer cancel()
+	if q.prepareContext != nil {
+		ctx = q.prepareContext(ctx, job)
+	}
+
+	logs.Info("开始执行后台任务", "id", job.ID, "type", job.Type, "attempt", job.Attempts)
+	start := q.now()
+	err := runHandler(ctx, handler, job)
+	q.finish(job, err, q.now().Sub(start))
+}
+
+// finish 根据执行结果更新任务状态
+func (q *Queue) finish(job *Job, runErr error, elapsed time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	now := q.now()
+	var result string
+	var err error
+	switch {
+	case runErr == nil:
+		result = ResultSucceeded
+		err = q.store.Complete(ctx, job.ID, now)
+		logs.Info("后台任务执行成功", "id", job.ID, "type", job.Type, "duration_ms", elapsed.Milliseconds())
+	case retry.IsPermanent(runErr) || job.Attempts >= job.MaxAttempts:
+		result = ResultDead
+		err = q.store.Dead(ctx, job.ID, now, runErr.Error())
+		logs.Error("后台任务执行失败，已转为死信", "id", job.ID, "type", job.Type, "attempt", job.Attempts, "error", runErr.Error())
+	default:
+		result = ResultRetried
+		runAt := now.Add(q.retryDelay(job.Attempts))
+		err = q.store.Retry(ctx, job.ID, runAt, runErr.Error())
+		logs.Warn("后台任务执行失败，等待重试", "id", job.ID, "type", job.Type, "attempt", job.Attempts, "run_at", runAt, "error", runErr.Error())
+	}
+	runsCounter.Inc(job.Type, result)
+
+	// 状态更新失败时任务保持 running，租约过期后会被重新领取
+	if err != nil {
+		logs.Error("更新后台任务状态失败", "id", job.ID, "type", job.Type, "error", err.Error())
+	}
+}
+
+// retryDelay 第 attempt 次执行失败后的重试等待时间
+func (q *Queue) retryDelay(attempt int) time.Duration {
+	var delay time.Duration
+	for i := 0; i < attempt; i++ {
+		delay = q.backoff.Next(delay)
+	}
+	return delay
+}
+
+// runHandler 执行处理函数，将 panic 转换为错误
+func runHandler(ctx context.Context, handler Handler, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logs.Error("后台任务 panic", "id", job.ID, "type", job.Type, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
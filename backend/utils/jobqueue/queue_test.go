@@ -0,0 +1,318 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/utils/retry"
+)
+
+// memoryStore 内存实现的 Store
+type memoryStore struct {
+	mu     sync.Mutex
+	nextID uint
+	jobs   map[uint]*Job
+	leases map[uint]time.Time
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{jobs: make(map[uint]*Job), leases: make(map[uint]time.Time)}
+}
+
+func (m *memoryStore) Enqueue(ctx context.Context, job *Job) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	job.ID = m.nextID
+	job.CreatedAt = time.Now()
+	stored := *job
+	m.jobs[job.ID] = &stored
+	return nil
+}
+
+func (m *memoryStore) Claim(ctx context.Context, types []string, now time.Time, leaseUntil time.Time, limit int) ([]*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]uint, 0, len(m.jobs))
+	for id := range m.jobs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var claimed []*Job
+	for _, id := range ids {
+		job := m.jobs[id]
+		if len(claimed) >= limit || !contains(types, job.Type) {
+			continue
+		}
+		ready := job.Status == StatusPending && !job.RunAt.After(now)
+		expired := job.Status == StatusRunning && m.leases[id].Before(now)
+		if !ready && !expired {
+			continue
+		}
+		job.Status = StatusRunning
+		job.Attempts++
+		m.leases[id] = leaseUntil
+		copied := *job
+		claimed = append(claimed, &copied)
+	}
+	return claimed, nil
+}
+
+func (m *memoryStore) Complete(ctx context.Context, id uint, finishedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[id].Status = StatusSucceeded
+	m.jobs[id].FinishedAt = &finishedAt
+	return nil
+}
+
+func (m *memoryStore) Retry(ctx context.Context, id uint, runAt time.Time, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[id].Status = StatusPending
+	m.jobs[id].RunAt = runAt
+	m.jobs[id].LastError = lastError
+	return nil
+}
+
+func (m *memoryStore) Dead(ctx context.Context, id uint, finishedAt time.Time, lastError string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.jobs[id].Status = StatusDead
+	m.jobs[id].FinishedAt = &finishedAt
+	m.jobs[id].LastError = lastError
+	return nil
+}
+
+func (m *memoryStore) get(id uint) Job {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return *m.jobs[id]
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// fastBackoff 测试使用的重试退避
+var fastBackoff = retry.Backoff{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2}
+
+// TestEnqueueAndRun 测试任务入队后执行并解析参数
+func TestEnqueueAndRun(t *testing.T) {
+	store := newMemoryStore()
+	q := New(store, WithPollInterval(10*time.Millisecond))
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+	got := make(chan string, 1)
+	if err := q.Handle("greet", func(ctx context.Context, job *Job) error {
+		var p payload
+		if err := job.Decode(&p); err != nil {
+			return err
+		}
+		got <- p.Name
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer q.Stop(context.Background())
+
+	job, err := q.Enqueue(context.Background(), "greet", payload{Name: "peano"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case name := <-got:
+		if name != "peano" {
+			t.Errorf("expected payload peano, got %s", name)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("job was not executed")
+	}
+	waitFor(t, func() bool { return store.get(job.ID).Status == StatusSucceeded })
+	if store.get(job.ID).Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", store.get(job.ID).Attempts)
+	}
+}
+
+// TestEnqueueUnknownType 测试未注册的任务类型不能入队
+func TestEnqueueUnknownType(t *testing.T) {
+	q := New(newMemoryStore())
+	if _, err := q.Enqueue(context.Background(), "missing", nil); !errors.Is(err, ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+}
+
+// TestRetryThenSucceed 测试失败后按退避重试直到成功
+func TestRetryThenSucceed(t *testing.T) {
+	store := newMemoryStore()
+	q := New(store, WithPollInterval(5*time.Millisecond), WithBackoff(fastBackoff))
+
+	var calls atomic.Int32
+	q.Handle("flaky", func(ctx context.Context, job *Job) error {
+		if calls.Add(1) < 3 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+	q.Start()
+	defer q.Stop(context.Background())
+
+	job, _ := q.Enqueue(context.Background(), "flaky", nil)
+	waitFor(t, func() bool { return store.get(job.ID).Status == StatusSucceeded })
+	if got := store.get(job.ID).Attempts; got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+// TestDeadLetter 测试超过最大执行次数和不可重试错误转为死信
+func TestDeadLetter(t *testing.T) {
+	store := newMemoryStore()
+	q := New(store, WithPollInterval(5*time.Millisecond), WithBackoff(fastBackoff), WithMaxAttempts(2))
+
+	q.Handle("broken", func(ctx context.Context, job *Job) error {
+		return errors.New("always fails")
+	})
+	q.Handle("invalid", func(ctx context.Context, job *Job) error {
+		return retry.Permanent(errors.New("invalid payload"))
+	})
+	q.Handle("panics", func(ctx context.Context, job *Job) error {
+		panic("boom")
+	})
+	q.Start()
+	defer q.Stop(context.Background())
+
+	broken, _ := q.Enqueue(context.Background(), "broken", nil)
+	invalid, _ := q.Enqueue(context.Background(), "invalid", nil)
+	panics, _ := q.Enqueue(context.Background(), "panics", nil, MaxAttempts(1))
+
+	waitFor(t, func() bool {
+		return store.get(broken.ID).Status == StatusDead &&
+			store.get(invalid.ID).Status == StatusDead &&
+			store.get(panics.ID).Status == StatusDead
+	})
+	if got := store.get(broken.ID).Attempts; got != 2 {
+		t.Errorf("expected 2 attempts before dead letter, got %d", got)
+	}
+	if got := store.get(invalid.ID).Attempts; got != 1 {
+		t.Errorf("permanent error should not be retried, got %d attempts", got)
+	}
+	if got := store.get(panics.ID).LastError; got != "panic: boom" {
+		t.Errorf("unexpected last error: %s", got)
+	}
+}
+
+// TestExpiredLeaseReclaimed 测试进程退出后租约过期的任务被重新领取
+func TestExpiredLeaseReclaimed(t *testing.T) {
+	store := newMemoryStore()
+	job := &Job{Type: "resume", Status: StatusPending, MaxAttempts: 3, RunAt: time.Now()}
+	store.Enqueue(context.Background(), job)
+
+	// 模拟上一个进程领取后退出
+	if claimed, _ := store.Claim(context.Background(), []string{"resume"}, time.Now(), time.Now().Add(-time.Second), 1); len(claimed) != 1 {
+		t.Fatal("expected job to be claimed")
+	}
+
+	q := New(store, WithPollInterval(5*time.Millisecond))
+	q.Handle("resume", func(ctx context.Context, job *Job) error { return nil })
+	q.Start()
+	defer q.Stop(context.Background())
+
+	waitFor(t, func() bool { return store.get(job.ID).Status == StatusSucceeded })
+	if got := store.get(job.ID).Attempts; got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+// TestWorkerLimit 测试同时执行的任务数不超过工作协程数量
+func TestWorkerLimit(t *testing.T) {
+	store := newMemoryStore()
+	q := New(store, WithWorkers(2), WithPollInterval(5*time.Millisecond))
+
+	var running, peak atomic.Int32
+	q.Handle("slow", func(ctx context.Context, job *Job) error {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		running.Add(-1)
+		return nil
+	})
+	q.Start()
+	defer q.Stop(context.Background())
+
+	var ids []uint
+	for i := 0; i < 6; i++ {
+		job, _ := q.Enqueue(context.Background(), "slow", nil)
+		ids = append(ids, job.ID)
+	}
+	waitFor(t, func() bool {
+		for _, id := range ids {
+			if store.get(id).Status != StatusSucceeded {
+				return false
+			}
+		}
+		return true
+	})
+	if peak.Load() > 2 {
+		t.Errorf("expected at most 2 concurrent jobs, got %d", peak.Load())
+	}
+}
+
+// TestStopCancelsRunningJob 测试停止队列时取消正在执行的任务并等待重试
+func TestStopCancelsRunningJob(t *testing.T) {
+	store := newMemoryStore()
+	q := New(store, WithPollInterval(5*time.Millisecond))
+
+	started := make(chan struct{})
+	q.Handle("long", func(ctx context.Context, job *Job) error {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	q.Start()
+
+	job, _ := q.Enqueue(context.Background(), "long", nil)
+	<-started
+	if err := q.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := store.get(job.ID).Status; got != StatusPending {
+		t.Errorf("expected canceled job to be pending for retry, got %s", got)
+	}
+	if _, err := q.Enqueue(context.Background(), "long", nil); err != nil {
+		t.Errorf("enqueue should still work after stop: %v", err)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"backend/utils/logs"
@@ -103,6 +105,48 @@ func (s *LocalStorage) GetURL(ctx context.Context, path string) (string, error)
 	return fmt.Sprintf("%s/%s", baseURL, path), nil
 }
 
+// Open 打开文件用于读取，调用方负责关闭
+// path 参数应该是 URL 格式的路径（正斜杠），会转换为系统路径格式
+func (s *LocalStorage) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	systemPath := filepath.FromSlash(path)
+	fullPath := filepath.Join(s.basePath, systemPath)
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("打开文件失败: %w", err)
+	}
+	return file, nil
+}
+
+// PathsInText 查找文本（如富文本内容、头像地址）中引用的本地存储文件，返回去重后的存储路径
+// 只识别 GetURL 生成的地址，未配置 baseURL 时返回 nil
+func (s *LocalStorage) PathsInText(text string) []string {
+	baseURL := strings.TrimSuffix(s.baseURL, "/")
+	if baseURL == "" {
+		return nil
+	}
+	prefix := baseURL + "/"
+
+	var paths []string
+	seen := make(map[string]bool)
+	for {
+		i := strings.Index(text, prefix)
+		if i < 0 {
+			return paths
+		}
+		text = text[i+len(prefix):]
+
+		path := text
+		if end := strings.IndexAny(text, " \t\r\n\"'<>()?#"); end >= 0 {
+			path = text[:end]
+		}
+		if path != "" && !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+}
+
 // Delete 删除文件
 // path 参数应该是 URL 格式的路径（正斜杠），会转换为系统路径格式
 func (s *LocalStorage) Delete(ctx context.Context, path string) error {
@@ -120,7 +164,50 @@ func (s *LocalStorage) Delete(ctx context.Context, path string) error {
 	return nil
 }
 
+// Walk 遍历存储目录下的所有文件
+// fn 的 path 参数为 URL 格式的相对路径（与 Upload 返回值一致），返回错误时停止遍历；ctx 取消时停止遍历
+func (s *LocalStorage) Walk(ctx context.Context, fn func(path string, modTime time.Time) error) error {
+	return filepath.WalkDir(s.basePath, func(fullPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil // 遍历过程中被删除
+			}
+			return err
+		}
+		relativePath, err := filepath.Rel(s.basePath, fullPath)
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(relativePath), info.ModTime())
+	})
+}
+
 // GetType 获取存储类型
 func (s *LocalStorage) GetType() string {
 	return "local"
 }
+
+// CheckWritable 检查目录是否可写，目录不存在时创建
+func CheckWritable(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("创建目录 %s 失败: %w", dir, err)
+	}
+	f, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		return fmt.Errorf("目录 %s 不可写: %w", dir, err)
+	}
+	name := f.Name()
+	f.Close()
+	return os.Remove(name)
+}
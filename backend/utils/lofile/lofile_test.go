@@ -0,0 +1,72 @@
+package lofile
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPathsInText(t *testing.T) {
+	s := &LocalStorage{basePath: t.TempDir(), baseURL: "http://localhost:8080/uploads/"}
+
+	text := `<p>a<img src="http://localhost:8080/uploads/2025/03/13/a_1_20250313.png"></p>` +
+		`<img src='http://localhost:8080/uploads/2025/03/13/b_2_20250313.jpg?w=100'>` +
+		`http://localhost:8080/uploads/2025/03/13/a_1_20250313.png ` +
+		`http://example.com/uploads/2025/03/13/c.png`
+	got := s.PathsInText(text)
+	want := []string{"2025/03/13/a_1_20250313.png", "2025/03/13/b_2_20250313.jpg"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("PathsInText() = %v, want %v", got, want)
+	}
+
+	if paths := (&LocalStorage{}).PathsInText(text); paths != nil {
+		t.Fatalf("PathsInText() without baseURL = %v, want nil", paths)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+	s := NewLocalStorage(t.TempDir(), "")
+
+	path, err := s.Upload(ctx, strings.NewReader("hello"), "a.txt", "text/plain")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	r, err := s.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil || string(content) != "hello" {
+		t.Fatalf("Open() content = %q, %v", content, err)
+	}
+
+	if _, err := s.Open(ctx, "missing.txt"); err == nil {
+		t.Fatal("Open() missing file: want error")
+	}
+}
+
+func TestCheckWritable(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "a", "b")
+	if err := CheckWritable(dir); err != nil {
+		t.Fatalf("CheckWritable() error = %v", err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Fatalf("CheckWritable() left files: %v", entries)
+	}
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckWritable(filepath.Join(file, "sub")); err == nil {
+		t.Fatal("CheckWritable() under a file should fail")
+	}
+}
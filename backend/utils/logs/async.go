@@ -0,0 +1,91 @@
+package logs
+
+import (
+	"bufio"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// asyncBufferSize 后台写入时的缓冲区大小
+const asyncBufferSize = 256 * 1024
+
+// asyncWriter 异步写入的 WriteSyncer
+// Write 只把日志放入有界队列，由后台 goroutine 写入缓冲区，缓冲区满、每隔 flushInterval 或 Sync 时写入底层 writer；
+// 队列满时 Write 等待，不丢弃日志
+type asyncWriter struct {
+	w             zapcore.WriteSyncer
+	queue         chan []byte
+	flush         chan chan error
+	flushInterval time.Duration
+}
+
+func newAsyncWriter(w zapcore.WriteSyncer, queueSize int, flushInterval time.Duration) *asyncWriter {
+	a := &asyncWriter{
+		w:             w,
+		queue:         make(chan []byte, queueSize),
+		flush:         make(chan chan error),
+		flushInterval: flushInterval,
+	}
+	go a.run()
+	return a
+}
+
+// Write 放入写入队列，p 在返回后会被 zap 复用，需要复制
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	b := make([]byte, len(p))
+	copy(b, p)
+	a.queue <- b
+	return len(p), nil
+}
+
+// Sync 写入队列和缓冲区中的全部日志，zap 记录 panic、fatal 日志后和 logs.Sync 时调用
+func (a *asyncWriter) Sync() error {
+	done := make(chan error, 1)
+	a.flush <- done
+	return <-done
+}
+
+func (a *asyncWriter) run() {
+	buf := bufio.NewWriterSize(a.w, asyncBufferSize)
+	ticker := time.NewTicker(a.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case b := <-a.queue:
+			_, _ = buf.Write(b)
+		case <-ticker.C:
+			_ = buf.Flush()
+		case done := <-a.flush:
+			// 写入 Sync 之前放入队列的日志
+			for n := len(a.queue); n > 0; n-- {
+				_, _ = buf.Write(<-a.queue)
+			}
+			if err := buf.Flush(); err != nil {
+				done <- err
+				continue
+			}
+			done <- a.w.Sync()
+		}
+	}
+}
+
+// wrapAsync 配置 LOG_ASYNC=true 时把 writer 包装为异步写入
+func wrapAsync(w zapcore.WriteSyncer) zapcore.WriteSyncer {
+	if !envx.GetBool(consts.EnvLogAsync, false) {
+		return w
+	}
+	queueSize, err := envx.GetIntWithDefaultAndMin(consts.EnvLogAsyncQueueSize, 8192, 1)
+	if err != nil {
+		queueSize = 8192
+	}
+	flushInterval, err := envx.GetDurationWithDefault(consts.EnvLogAsyncFlushInterval, time.Second)
+	if err != nil || flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	return newAsyncWriter(w, queueSize, flushInterval)
+}
@@ -0,0 +1,73 @@
+package logs
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// syncBuffer 并发安全的 WriteSyncer
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) Sync() error { return nil }
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// TestAsyncWriter 测试 Sync 时按顺序写入全部日志，队列满时等待不丢弃
+func TestAsyncWriter(t *testing.T) {
+	out := &syncBuffer{}
+	writer := newAsyncWriter(out, 4, time.Hour)
+	enc := zapcore.NewConsoleEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	logger := zap.New(zapcore.NewCore(enc, writer, zapcore.DebugLevel))
+
+	for i := 0; i < 100; i++ {
+		logger.Info(strconv.Itoa(i))
+	}
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("Sync 失败: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("期望 100 条日志，实际为 %d", len(lines))
+	}
+	for i, line := range lines {
+		if line != strconv.Itoa(i) {
+			t.Fatalf("第 %d 条日志为 %q，顺序不正确", i, line)
+		}
+	}
+}
+
+// TestAsyncWriterFlushInterval 测试不调用 Sync 时按间隔写入
+func TestAsyncWriterFlushInterval(t *testing.T) {
+	out := &syncBuffer{}
+	writer := newAsyncWriter(out, 16, 10*time.Millisecond)
+	_, _ = writer.Write([]byte("hello\n"))
+
+	deadline := time.Now().Add(2 * time.Second)
+	for out.String() != "hello\n" {
+		if time.Now().After(deadline) {
+			t.Fatalf("等待写入超时: %q", out.String())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
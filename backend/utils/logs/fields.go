@@ -0,0 +1,73 @@
+package logs
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// fieldsContextKey WithFields 写入 context 的字段
+const fieldsContextKey contextKey = "log_fields"
+
+// contextField WithFields 添加的字段
+type contextField struct {
+	key   string
+	value interface{}
+}
+
+// WithFields 返回带有日志字段的新 context，之后使用这个 context 的 Ctx* 日志自动附带这些字段
+// keyvals 为成对的 key-value，如 "user_id", 1, "item_id", 2；key 不是字符串的键值对和多余的值会被忽略，
+// 与已有字段同名时覆盖已有的值，不影响父 context
+func WithFields(ctx context.Context, keyvals ...interface{}) context.Context {
+	if len(keyvals) < 2 {
+		return ctx
+	}
+	parent := contextFields(ctx)
+	fields := make([]contextField, len(parent), len(parent)+len(keyvals)/2)
+	copy(fields, parent)
+
+next:
+	for i := 0; i < len(keyvals)-1; i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			continue
+		}
+		for j := range fields {
+			if fields[j].key == key {
+				fields[j].value = keyvals[i+1]
+				continue next
+			}
+		}
+		fields = append(fields, contextField{key: key, value: keyvals[i+1]})
+	}
+	return context.WithValue(ctx, fieldsContextKey, fields)
+}
+
+// Fields 返回 WithFields 写入 context 的字段，格式为成对的 key-value
+func Fields(ctx context.Context) []interface{} {
+	fields := contextFields(ctx)
+	if len(fields) == 0 {
+		return nil
+	}
+	keyvals := make([]interface{}, 0, len(fields)*2)
+	for _, field := range fields {
+		keyvals = append(keyvals, field.key, field.value)
+	}
+	return keyvals
+}
+
+func contextFields(ctx context.Context) []contextField {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsContextKey).([]contextField)
+	return fields
+}
+
+// appendContextFields 在追踪字段之后添加 WithFields 写入的字段
+func appendContextFields(ctx context.Context, fields []zap.Field) []zap.Field {
+	for _, field := range contextFields(ctx) {
+		fields = append(fields, zap.Any(field.key, redactValue(field.key, field.value)))
+	}
+	return fields
+}
@@ -0,0 +1,43 @@
+package logs
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestWithFields 测试 WithFields 写入的字段自动附带到 Ctx* 日志，同名字段覆盖，不影响父 context
+func TestWithFields(t *testing.T) {
+	core, observed := observer.New(zapcore.DebugLevel)
+	logger := &zapLogger{logger: zap.New(core), level: zap.NewAtomicLevel()}
+	logger.sugar = logger.logger.Sugar()
+
+	parent := WithFields(context.Background(), "user_id", 1, "item_id", 2)
+	child := WithFields(parent, "item_id", 3, 4)
+
+	logger.CtxInfo(parent, "父 context")
+	logger.CtxError(child, "子 context", "action", "delete")
+	logger.WithTraceFields(child).Infof("格式化日志")
+
+	entries := observed.All()
+	if len(entries) != 3 {
+		t.Fatalf("期望 3 条日志，实际为 %d", len(entries))
+	}
+	if fields := entries[0].ContextMap(); fields["user_id"] != int64(1) || fields["item_id"] != int64(2) {
+		t.Errorf("父 context 的字段不正确: %v", fields)
+	}
+	for _, entry := range entries[1:] {
+		if fields := entry.ContextMap(); fields["user_id"] != int64(1) || fields["item_id"] != int64(3) {
+			t.Errorf("子 context 的字段不正确: %v", fields)
+		}
+	}
+	if action := entries[1].ContextMap()["action"]; action != "delete" {
+		t.Errorf("调用处的字段不应丢失: %v", action)
+	}
+	if got := Fields(child); len(got) != 4 {
+		t.Errorf("期望 2 个字段，实际为 %v", got)
+	}
+}
@@ -0,0 +1,170 @@
+package logs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// HookEntry 交给 Hook 的 error 及以上级别的日志
+type HookEntry struct {
+	Level   string                 // 日志级别：error、dpanic、panic、fatal
+	Time    time.Time              // 记录时间
+	Message string                 // 日志消息
+	Caller  string                 // 调用位置，如 handle/handle.go:120
+	TraceID string                 // 链路追踪 ID，来自 trace_id 字段
+	SpanID  string                 // 当前 span ID，来自 span_id 字段
+	Stack   string                 // 调用堆栈，来自 stack 字段或 zap 的 stacktrace，每行一个调用
+	Fields  map[string]interface{} // 全部结构化字段（包含 trace_id、span_id 和 stack）
+}
+
+// Hook 接收 error 及以上级别日志的钩子，用于上报到 Sentry 等错误聚合服务
+// Fire 在记录日志的 goroutine 中同步调用，不能阻塞，耗时的上报应放入队列异步处理
+type Hook interface {
+	Fire(entry *HookEntry)
+}
+
+var (
+	hooksMu sync.Mutex
+	// hooks 已注册的钩子，写时复制，记录日志时无锁读取
+	hooks atomic.Pointer[[]Hook]
+)
+
+// AddHook 注册接收 error 及以上级别日志的钩子，返回取消注册的函数
+func AddHook(hook Hook) (remove func()) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	var list []Hook
+	if current := hooks.Load(); current != nil {
+		list = append(list, *current...)
+	}
+	list = append(list, hook)
+	hooks.Store(&list)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeHook(hook) })
+	}
+}
+
+// removeHook 取消注册钩子
+func removeHook(hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	current := hooks.Load()
+	if current == nil {
+		return
+	}
+	list := make([]Hook, 0, len(*current))
+	for _, h := range *current {
+		if h != hook {
+			list = append(list, h)
+		}
+	}
+	hooks.Store(&list)
+}
+
+// loadHooks 返回已注册的钩子
+func loadHooks() []Hook {
+	if current := hooks.Load(); current != nil {
+		return *current
+	}
+	return nil
+}
+
+// hookCore 把 error 及以上级别的日志交给已注册的钩子，不输出日志
+type hookCore struct {
+	level  zapcore.LevelEnabler
+	fields []zapcore.Field
+}
+
+// newHookCore 创建钩子 core，日志级别高于 error 时（如 fatal）只处理启用的级别
+func newHookCore(level zapcore.LevelEnabler) zapcore.Core {
+	return &hookCore{level: level}
+}
+
+func (c *hookCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.ErrorLevel && c.level.Enabled(level)
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	return &hookCore{
+		level:  c.level,
+		fields: append(c.fields[:len(c.fields):len(c.fields)], fields...),
+	}
+}
+
+func (c *hookCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) && len(loadHooks()) > 0 {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *hookCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	list := loadHooks()
+	if len(list) == 0 {
+		return nil
+	}
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	hookEntry := &HookEntry{
+		Level:   entry.Level.String(),
+		Time:    entry.Time,
+		Message: entry.Message,
+		Stack:   formatStack(enc.Fields["stack"]),
+		Fields:  enc.Fields,
+	}
+	if entry.Caller.Defined {
+		hookEntry.Caller = entry.Caller.TrimmedPath()
+	}
+	hookEntry.TraceID, _ = enc.Fields["trace_id"].(string)
+	hookEntry.SpanID, _ = enc.Fields["span_id"].(string)
+	if hookEntry.Stack == "" {
+		hookEntry.Stack = entry.Stack
+	}
+
+	for _, hook := range list {
+		hook.Fire(hookEntry)
+	}
+	return nil
+}
+
+func (c *hookCore) Sync() error {
+	return nil
+}
+
+// formatStack 把 stack 字段转换为字符串，支持字符串和 errorx.Frame 等实现了 String 的调用帧切片
+func formatStack(stack interface{}) string {
+	switch v := stack.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	rv := reflect.ValueOf(stack)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Sprint(stack)
+	}
+	lines := make([]string, rv.Len())
+	for i := range lines {
+		lines[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,47 @@
+package logs
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type frame struct{ name string }
+
+func (f frame) String() string { return f.name }
+
+type recordHook struct{ entries []*HookEntry }
+
+func (h *recordHook) Fire(entry *HookEntry) { h.entries = append(h.entries, entry) }
+
+// TestHook 测试钩子只接收 error 及以上级别的日志，并带上字段、trace_id 和堆栈
+func TestHook(t *testing.T) {
+	hook := &recordHook{}
+	remove := AddHook(hook)
+
+	logger := zap.New(newHookCore(zapcore.DebugLevel), zap.AddCaller()).With(zap.String("trace_id", "trace_1"))
+	logger.Info("一般信息")
+	logger.Error("保存失败", zap.String("span_id", "span_1"), zap.Any("stack", []frame{{"a.go:1 a"}, {"b.go:2 b"}}))
+
+	if len(hook.entries) != 1 {
+		t.Fatalf("期望收到 1 条日志，实际为 %d", len(hook.entries))
+	}
+	entry := hook.entries[0]
+	if entry.Level != "error" || entry.Message != "保存失败" || entry.Caller == "" {
+		t.Errorf("日志信息不正确: %+v", entry)
+	}
+	if entry.TraceID != "trace_1" || entry.SpanID != "span_1" {
+		t.Errorf("期望 trace_id=trace_1 span_id=span_1，实际为 %s %s", entry.TraceID, entry.SpanID)
+	}
+	if entry.Stack != "a.go:1 a\nb.go:2 b" {
+		t.Errorf("堆栈不正确: %q", entry.Stack)
+	}
+
+	// 取消注册后不再接收
+	remove()
+	logger.Error("保存失败")
+	if len(hook.entries) != 1 {
+		t.Errorf("取消注册后不应再收到日志，实际为 %d 条", len(hook.entries))
+	}
+}
@@ -31,6 +31,26 @@ type CtxStructuredLogger interface {
 	CtxDebug(ctx context.Context, msg string, keyvals ...interface{})
 }
 
+// LevelSetter 支持运行时修改日志级别的 logger
+type LevelSetter interface {
+	SetLevel(level string)
+}
+
+// LevelGetter 支持查询当前日志级别的 logger
+type LevelGetter interface {
+	Level() string
+}
+
+// ConsoleChecker 可以查询输出格式的 logger
+type ConsoleChecker interface {
+	IsConsole() bool
+}
+
+// Syncer 支持写入缓冲日志的 logger
+type Syncer interface {
+	Sync() error
+}
+
 var (
 	defaultLogger Logger
 )
@@ -53,6 +73,43 @@ func GetDefaultLogger() Logger {
 	return defaultLogger
 }
 
+// SetLevel 修改默认 logger 的日志级别
+// level: debug, info, warn, error 等，无法识别时使用 info
+// 返回 false 表示默认 logger 不支持运行时修改
+func SetLevel(level string) bool {
+	setter, ok := GetDefaultLogger().(LevelSetter)
+	if !ok {
+		return false
+	}
+	setter.SetLevel(level)
+	return true
+}
+
+// GetLevel 返回默认 logger 当前的日志级别，默认 logger 不支持查询时返回空字符串
+func GetLevel() string {
+	getter, ok := GetDefaultLogger().(LevelGetter)
+	if !ok {
+		return ""
+	}
+	return getter.Level()
+}
+
+// IsConsole 默认 logger 是否输出带颜色的控制台格式，为 JSON 格式或无法查询时返回 false
+// 用于只在控制台格式下需要的渲染，如请求日志的彩色状态码
+func IsConsole() bool {
+	checker, ok := GetDefaultLogger().(ConsoleChecker)
+	return ok && checker.IsConsole()
+}
+
+// Sync 写入默认 logger 缓冲的日志，进程退出前调用，避免丢失最后的日志
+func Sync() error {
+	syncer, ok := GetDefaultLogger().(Syncer)
+	if !ok {
+		return nil
+	}
+	return syncer.Sync()
+}
+
 // 包级别的日志方法（兼容性接口）
 
 // Error 记录错误级别日志
@@ -0,0 +1,285 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// lokiPushPath Loki 推送接口路径，LOG_LOKI_URL 只写地址时自动补全
+	lokiPushPath = "/loki/api/v1/push"
+	// lokiSyncTimeout Sync 等待推送完成的最长时间
+	lokiSyncTimeout = 5 * time.Second
+)
+
+// lokiConfig Loki 推送配置
+type lokiConfig struct {
+	url       string            // 推送接口地址，可以带 user:password 进行 Basic 认证
+	tenant    string            // 多租户时的租户 ID（X-Scope-OrgID）
+	labels    map[string]string // 日志流标签，推送时再加上 level
+	batchSize int               // 每批最多推送的日志条数
+	batchWait time.Duration     // 不满一批时最长等待时间
+	client    *http.Client
+}
+
+// getLokiConfig 从环境变量读取 Loki 推送配置
+func getLokiConfig() (lokiConfig, error) {
+	cfg := lokiConfig{
+		url:    strings.TrimSpace(envx.GetStringOptional(consts.EnvLogLokiURL)),
+		tenant: envx.GetStringOptional(consts.EnvLogLokiTenant),
+		labels: map[string]string{
+			"service": envOrDefault(consts.EnvLogLokiService, "backend"),
+			"env":     envOrDefault(consts.EnvLogLokiEnv, "production"),
+		},
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+	if cfg.url == "" {
+		return cfg, fmt.Errorf("环境变量 %s=loki 时必须配置 %s", consts.EnvLogOutput, consts.EnvLogLokiURL)
+	}
+	u, err := url.Parse(cfg.url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return cfg, fmt.Errorf("环境变量 %s 无效: %s", consts.EnvLogLokiURL, cfg.url)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = lokiPushPath
+		cfg.url = u.String()
+	}
+
+	batchSize, err := envx.GetIntWithDefaultAndMin(consts.EnvLogLokiBatchSize, 500, 1)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.batchSize = batchSize
+	batchWait, err := envx.GetDurationWithDefault(consts.EnvLogLokiBatchWait, time.Second)
+	if err != nil {
+		return cfg, err
+	}
+	if batchWait <= 0 {
+		return cfg, fmt.Errorf("环境变量 %s 必须大于 0", consts.EnvLogLokiBatchWait)
+	}
+	cfg.batchWait = batchWait
+	return cfg, nil
+}
+
+// CheckLokiConfig 校验 LOG_OUTPUT=loki 时的推送配置，未使用 Loki 时返回 nil
+func CheckLokiConfig() error {
+	if !isLokiOutput(envx.GetStringOptional(consts.EnvLogOutput)) {
+		return nil
+	}
+	_, err := getLokiConfig()
+	return err
+}
+
+// isLokiOutput 是否推送到 Loki
+func isLokiOutput(output string) bool {
+	return strings.EqualFold(strings.TrimSpace(output), "loki")
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if value := strings.TrimSpace(envx.GetStringOptional(key)); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// lokiLine 一条待推送的日志
+type lokiLine struct {
+	level string
+	time  time.Time
+	line  string
+}
+
+// lokiSink 批量推送日志到 Loki
+// 日志先放入队列，满一批或等待 batchWait 后推送，队列满时丢弃，推送失败时输出到 stderr
+type lokiSink struct {
+	cfg     lokiConfig
+	lines   chan lokiLine
+	flush   chan chan struct{}
+	dropped atomic.Int64
+}
+
+func newLokiSink(cfg lokiConfig) *lokiSink {
+	s := &lokiSink{
+		cfg:   cfg,
+		lines: make(chan lokiLine, cfg.batchSize*10),
+		flush: make(chan chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// add 放入推送队列，不阻塞记录日志
+func (s *lokiSink) add(line lokiLine) {
+	select {
+	case s.lines <- line:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+// sync 推送队列中的全部日志，最多等待 lokiSyncTimeout
+func (s *lokiSink) sync() error {
+	done := make(chan struct{})
+	timer := time.NewTimer(lokiSyncTimeout)
+	defer timer.Stop()
+	select {
+	case s.flush <- done:
+	case <-timer.C:
+		return fmt.Errorf("推送日志到 Loki 超时")
+	}
+	select {
+	case <-done:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("推送日志到 Loki 超时")
+	}
+}
+
+func (s *lokiSink) run() {
+	ticker := time.NewTicker(s.cfg.batchWait)
+	defer ticker.Stop()
+
+	batch := make([]lokiLine, 0, s.cfg.batchSize)
+	push := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.push(batch); err != nil {
+			fmt.Fprintf(os.Stderr, "推送 %d 条日志到 Loki 失败: %v\n", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case line := <-s.lines:
+			batch = append(batch, line)
+			if len(batch) >= s.cfg.batchSize {
+				push()
+			}
+		case <-ticker.C:
+			push()
+		case done := <-s.flush:
+			// 推送 sync 之前放入队列的日志
+			for n := len(s.lines); n > 0; n-- {
+				batch = append(batch, <-s.lines)
+				if len(batch) >= s.cfg.batchSize {
+					push()
+				}
+			}
+			push()
+			close(done)
+		}
+	}
+}
+
+// lokiStream Loki 推送接口的日志流
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// push 按日志级别分为多个日志流推送
+func (s *lokiSink) push(batch []lokiLine) error {
+	streams := make(map[string]*lokiStream)
+	var order []*lokiStream
+	for _, line := range batch {
+		stream, ok := streams[line.level]
+		if !ok {
+			labels := make(map[string]string, len(s.cfg.labels)+1)
+			for k, v := range s.cfg.labels {
+				labels[k] = v
+			}
+			labels["level"] = line.level
+			stream = &lokiStream{Stream: labels}
+			streams[line.level] = stream
+			order = append(order, stream)
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(line.time.UnixNano(), 10), line.line})
+	}
+	if dropped := s.dropped.Swap(0); dropped > 0 {
+		fmt.Fprintf(os.Stderr, "Loki 推送队列已满，丢弃了 %d 条日志\n", dropped)
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"streams": order})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, s.cfg.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.cfg.tenant != "" {
+		req.Header.Set("X-Scope-OrgID", s.cfg.tenant)
+	}
+	resp, err := s.cfg.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// lokiCore 把日志编码为 JSON 后交给 lokiSink 推送
+type lokiCore struct {
+	zapcore.LevelEnabler
+	enc  zapcore.Encoder
+	sink *lokiSink
+}
+
+func newLokiCore(enc zapcore.Encoder, level zapcore.LevelEnabler, sink *lokiSink) zapcore.Core {
+	return &lokiCore{LevelEnabler: level, enc: enc, sink: sink}
+}
+
+func (c *lokiCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	return &lokiCore{LevelEnabler: c.LevelEnabler, enc: enc, sink: c.sink}
+}
+
+func (c *lokiCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *lokiCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	c.sink.add(lokiLine{
+		level: entry.Level.String(),
+		time:  entry.Time,
+		line:  strings.TrimSuffix(buf.String(), "\n"),
+	})
+	buf.Free()
+	return nil
+}
+
+func (c *lokiCore) Sync() error {
+	return c.sink.sync()
+}
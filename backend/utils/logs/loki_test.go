@@ -0,0 +1,95 @@
+package logs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestLokiCore 测试日志按级别分为多个日志流推送，带上 service、env 和 level 标签
+func TestLokiCore(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		streams []lokiStream
+		tenant  string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Streams []lokiStream `json:"streams"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		streams = append(streams, body.Streams...)
+		tenant = r.Header.Get("X-Scope-OrgID")
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sink := newLokiSink(lokiConfig{
+		url:       server.URL + lokiPushPath,
+		tenant:    "team_a",
+		labels:    map[string]string{"service": "backend", "env": "test"},
+		batchSize: 100,
+		batchWait: time.Hour,
+		client:    server.Client(),
+	})
+	enc := zapcore.NewJSONEncoder(getEncoderConfig(false, false))
+	logger := zap.New(newLokiCore(enc, zapcore.InfoLevel, sink)).With(zap.String("module", "item"))
+
+	logger.Debug("不推送")
+	logger.Info("创建成功", zap.Int("id", 1))
+	logger.Info("创建成功", zap.Int("id", 2))
+	logger.Error("保存失败")
+	if err := logger.Sync(); err != nil {
+		t.Fatalf("推送失败: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if tenant != "team_a" {
+		t.Errorf("期望租户 team_a，实际为 %q", tenant)
+	}
+	count := make(map[string]int)
+	for _, stream := range streams {
+		if stream.Stream["service"] != "backend" || stream.Stream["env"] != "test" {
+			t.Errorf("日志流标签不正确: %v", stream.Stream)
+		}
+		count[stream.Stream["level"]] += len(stream.Values)
+		for _, value := range stream.Values {
+			var line map[string]interface{}
+			if err := json.Unmarshal([]byte(value[1]), &line); err != nil || line["module"] != "item" {
+				t.Errorf("日志内容不正确: %q", value[1])
+			}
+		}
+	}
+	if count["info"] != 2 || count["error"] != 1 || count["debug"] != 0 {
+		t.Errorf("期望 2 条 info、1 条 error，实际为 %v", count)
+	}
+}
+
+// TestGetLokiConfig 测试只写 Loki 地址时补全推送接口路径
+func TestGetLokiConfig(t *testing.T) {
+	cases := map[string]string{
+		"http://loki:3100":                  "http://loki:3100/loki/api/v1/push",
+		"http://loki:3100/":                 "http://loki:3100/loki/api/v1/push",
+		"https://u:p@loki/loki/api/v1/push": "https://u:p@loki/loki/api/v1/push",
+	}
+	for value, want := range cases {
+		t.Setenv("LOG_LOKI_URL", value)
+		cfg, err := getLokiConfig()
+		if err != nil || cfg.url != want {
+			t.Errorf("LOG_LOKI_URL=%s 期望 %s，实际为 %s, %v", value, want, cfg.url, err)
+		}
+	}
+	t.Setenv("LOG_LOKI_URL", "loki:3100")
+	if _, err := getLokiConfig(); err == nil {
+		t.Error("地址无效时应返回错误")
+	}
+}
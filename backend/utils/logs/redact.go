@@ -0,0 +1,85 @@
+package logs
+
+import (
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+)
+
+const (
+	// redactedValue 敏感字段替换后的值
+	redactedValue = "***"
+	// redactedJWT JWT 替换后的值
+	redactedJWT = "[JWT]"
+)
+
+// defaultRedactKeys 默认脱敏的字段名
+var defaultRedactKeys = []string{"password", "token", "authorization", "refresh_token", "secret"}
+
+// redactKeys 脱敏的字段名（小写），由 LOG_REDACT_KEYS 追加
+var redactKeys atomic.Pointer[map[string]struct{}]
+
+// jwtPattern JWT 格式的字符串：三段 base64url，header 以 {" 开头，编码后为 eyJ
+var jwtPattern = regexp.MustCompile(`eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+`)
+
+// loadRedactKeys 返回脱敏的字段名，首次调用时读取 LOG_REDACT_KEYS
+func loadRedactKeys() map[string]struct{} {
+	if keys := redactKeys.Load(); keys != nil {
+		return *keys
+	}
+	keys := make(map[string]struct{})
+	for _, key := range append(defaultRedactKeys, envx.GetStringSlice(consts.EnvLogRedactKeys)...) {
+		keys[strings.ToLower(strings.TrimSpace(key))] = struct{}{}
+	}
+	redactKeys.Store(&keys)
+	return keys
+}
+
+// isSensitiveKey 字段名是否需要脱敏，忽略大小写
+// 与脱敏字段名相同或以 _ 或 - 连接的后缀相同时脱敏，如 password、user_password、access_token
+func isSensitiveKey(key string) bool {
+	keys := loadRedactKeys()
+	key = strings.ToLower(key)
+	if _, ok := keys[key]; ok {
+		return true
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] != '_' && key[i] != '-' {
+			continue
+		}
+		if _, ok := keys[key[i+1:]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redactValue 敏感字段的值替换为 ***，其他字段中 JWT 格式的字符串替换为 [JWT]
+func redactValue(key string, value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if isSensitiveKey(key) {
+		return redactedValue
+	}
+	switch v := value.(type) {
+	case string:
+		return redactString(v)
+	case error:
+		if msg := v.Error(); strings.Contains(msg, "eyJ") {
+			return redactString(msg)
+		}
+	}
+	return value
+}
+
+// redactString 把字符串中 JWT 格式的部分替换为 [JWT]
+func redactString(s string) string {
+	if !strings.Contains(s, "eyJ") {
+		return s
+	}
+	return jwtPattern.ReplaceAllString(s, redactedJWT)
+}
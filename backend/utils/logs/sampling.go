@@ -0,0 +1,95 @@
+package logs
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingRule 单个日志级别的采样规则
+// 每秒内相同级别、相同消息的日志前 Initial 条全部输出，之后每 Thereafter 条输出 1 条
+type SamplingRule struct {
+	Initial    int
+	Thereafter int
+}
+
+// samplingCore 按日志级别采样的 zapcore.Core
+// 配置了采样规则的级别交给对应的采样 core，其他级别直接输出；error 及以上级别从不采样
+type samplingCore struct {
+	zapcore.Core
+	samplers map[zapcore.Level]zapcore.Core
+}
+
+// newSamplingCore 按采样规则包装 core，没有有效规则时原样返回
+func newSamplingCore(core zapcore.Core, rules map[zapcore.Level]SamplingRule) zapcore.Core {
+	samplers := make(map[zapcore.Level]zapcore.Core, len(rules))
+	for level, rule := range rules {
+		if level >= zapcore.ErrorLevel || rule.Initial <= 0 {
+			continue
+		}
+		samplers[level] = zapcore.NewSamplerWithOptions(core, time.Second, rule.Initial, rule.Thereafter)
+	}
+	if len(samplers) == 0 {
+		return core
+	}
+	return &samplingCore{Core: core, samplers: samplers}
+}
+
+// With 为底层 core 和所有采样 core 添加字段
+func (c *samplingCore) With(fields []zapcore.Field) zapcore.Core {
+	samplers := make(map[zapcore.Level]zapcore.Core, len(c.samplers))
+	for level, sampler := range c.samplers {
+		samplers[level] = sampler.With(fields)
+	}
+	return &samplingCore{Core: c.Core.With(fields), samplers: samplers}
+}
+
+// Check 按日志级别选择采样 core
+func (c *samplingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if sampler, ok := c.samplers[entry.Level]; ok {
+		return sampler.Check(entry, checked)
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// getSamplingRules 从环境变量读取各级别的采样规则
+func getSamplingRules() map[zapcore.Level]SamplingRule {
+	rules := make(map[zapcore.Level]SamplingRule)
+	for level, key := range map[zapcore.Level]string{
+		zapcore.DebugLevel: consts.EnvLogSamplingDebug,
+		zapcore.InfoLevel:  consts.EnvLogSamplingInfo,
+		zapcore.WarnLevel:  consts.EnvLogSamplingWarn,
+	} {
+		if rule, ok := parseSamplingRule(envx.GetStringOptional(key)); ok {
+			rules[level] = rule
+		}
+	}
+	return rules
+}
+
+// parseSamplingRule 解析 "initial/thereafter" 格式的采样规则，如 "100/100"
+// 只写 initial 时超出部分全部丢弃
+func parseSamplingRule(value string) (SamplingRule, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return SamplingRule{}, false
+	}
+	initialStr, thereafterStr, _ := strings.Cut(value, "/")
+	initial, err := strconv.Atoi(strings.TrimSpace(initialStr))
+	if err != nil || initial <= 0 {
+		return SamplingRule{}, false
+	}
+	var thereafter int
+	if thereafterStr != "" {
+		thereafter, err = strconv.Atoi(strings.TrimSpace(thereafterStr))
+		if err != nil || thereafter < 0 {
+			return SamplingRule{}, false
+		}
+	}
+	return SamplingRule{Initial: initial, Thereafter: thereafter}, true
+}
@@ -0,0 +1,60 @@
+package logs
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestSamplingCore 测试配置了规则的级别按规则采样，error 级别和未配置的级别不采样
+func TestSamplingCore(t *testing.T) {
+	observed, logs := observer.New(zapcore.DebugLevel)
+	core := newSamplingCore(observed, map[zapcore.Level]SamplingRule{
+		zapcore.InfoLevel:  {Initial: 2, Thereafter: 3},
+		zapcore.ErrorLevel: {Initial: 1},
+	})
+	logger := zap.New(core).With(zap.String("module", "test"))
+
+	for i := 0; i < 8; i++ {
+		logger.Info("访问日志")
+		logger.Debug("调试日志")
+		logger.Error("错误日志")
+	}
+
+	// info 前 2 条全部输出，之后第 3、6 条各输出 1 条
+	if n := logs.FilterMessage("访问日志").Len(); n != 4 {
+		t.Errorf("期望输出 4 条 info 日志，实际为 %d", n)
+	}
+	if n := logs.FilterMessage("调试日志").Len(); n != 8 {
+		t.Errorf("未配置规则的级别不应采样，实际输出 %d 条", n)
+	}
+	if n := logs.FilterMessage("错误日志").Len(); n != 8 {
+		t.Errorf("error 级别不应采样，实际输出 %d 条", n)
+	}
+	if entry := logs.All()[0]; entry.ContextMap()["module"] != "test" {
+		t.Errorf("采样后应保留 With 添加的字段，实际为 %v", entry.ContextMap())
+	}
+}
+
+// TestParseSamplingRule 测试解析采样规则
+func TestParseSamplingRule(t *testing.T) {
+	cases := map[string]struct {
+		rule SamplingRule
+		ok   bool
+	}{
+		"100/100": {SamplingRule{Initial: 100, Thereafter: 100}, true},
+		" 10 ":    {SamplingRule{Initial: 10}, true},
+		"":        {SamplingRule{}, false},
+		"0/10":    {SamplingRule{}, false},
+		"10/-1":   {SamplingRule{}, false},
+		"abc":     {SamplingRule{}, false},
+	}
+	for value, want := range cases {
+		rule, ok := parseSamplingRule(value)
+		if rule != want.rule || ok != want.ok {
+			t.Errorf("parseSamplingRule(%q) = %+v, %v，期望 %+v, %v", value, rule, ok, want.rule, want.ok)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package logs
+
+import (
+	"strings"
+
+	"backend/app/types/consts"
+	"backend/utils/envx"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// JSON 日志的字段命名方式（LOG_SCHEMA）
+const (
+	// SchemaZap zap 默认的字段名：ts、level、msg、caller
+	SchemaZap = "zap"
+	// SchemaECS Elastic Common Schema：@timestamp、log.level、message、log.origin.file.name
+	SchemaECS = "ecs"
+	// SchemaOTel OpenTelemetry 日志数据模型：timestamp、severity_text、body、code.filepath
+	SchemaOTel = "otel"
+)
+
+// ecsVersion 输出的 ecs.version 字段
+const ecsVersion = "8.11.0"
+
+// getLogSchema 读取 LOG_SCHEMA，无法识别时使用 zap
+func getLogSchema() string {
+	switch schema := strings.ToLower(strings.TrimSpace(envx.GetStringOptional(consts.EnvLogSchema))); schema {
+	case SchemaECS, SchemaOTel:
+		return schema
+	default:
+		return SchemaZap
+	}
+}
+
+// newJSONEncoder 创建 stdout、日志文件和 Loki 使用的 JSON 编码器，字段名按 LOG_SCHEMA 设置
+func newJSONEncoder(development bool) zapcore.Encoder {
+	schema := getLogSchema()
+	encoder := zapcore.NewJSONEncoder(applyLogSchema(getEncoderConfig(development, false), schema))
+	if schema == SchemaECS {
+		// Elasticsearch 的 ECS 集成按 ecs.version 识别日志格式
+		encoder.AddString("ecs.version", ecsVersion)
+	}
+	return encoder
+}
+
+// applyLogSchema 按字段命名方式修改编码器配置，只修改字段名和时间、级别的格式，其他字段不变
+// 点号分隔的字段名（如 log.level）在 Elasticsearch/OpenSearch 中按嵌套对象处理
+func applyLogSchema(cfg zapcore.EncoderConfig, schema string) zapcore.EncoderConfig {
+	switch schema {
+	case SchemaECS:
+		cfg.TimeKey = "@timestamp"
+		cfg.LevelKey = "log.level"
+		cfg.NameKey = "log.logger"
+		cfg.CallerKey = "log.origin.file.name"
+		cfg.MessageKey = "message"
+		cfg.StacktraceKey = "error.stack_trace"
+		cfg.EncodeLevel = zapcore.LowercaseLevelEncoder
+		cfg.EncodeTime = zapcore.TimeEncoderOfLayout("2006-01-02T15:04:05.000Z07:00")
+	case SchemaOTel:
+		cfg.TimeKey = "timestamp"
+		cfg.LevelKey = "severity_text"
+		cfg.NameKey = "scope.name"
+		cfg.CallerKey = "code.filepath"
+		cfg.MessageKey = "body"
+		cfg.StacktraceKey = "exception.stacktrace"
+		cfg.EncodeLevel = zapcore.CapitalLevelEncoder
+		cfg.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	}
+	return cfg
+}
@@ -0,0 +1,51 @@
+package logs
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// TestLogSchema 测试按 LOG_SCHEMA 设置 JSON 日志的字段名
+func TestLogSchema(t *testing.T) {
+	cases := map[string][]string{
+		"":     {"ts", "level", "msg", "caller"},
+		"ecs":  {"@timestamp", "log.level", "message", "log.origin.file.name", "ecs.version"},
+		"otel": {"timestamp", "severity_text", "body", "code.filepath"},
+	}
+	entry := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Message: "磁盘空间不足",
+		Caller:  zapcore.NewEntryCaller(0, "/src/backend/utils/logs/zap.go", 12, true),
+	}
+	for schema, keys := range cases {
+		t.Setenv("LOG_SCHEMA", schema)
+		buf, err := newJSONEncoder(false).EncodeEntry(entry, nil)
+		if err != nil {
+			t.Fatalf("编码失败: %v", err)
+		}
+		var line map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+			t.Fatalf("JSON 格式不正确: %s", buf.String())
+		}
+		for _, key := range keys {
+			if _, ok := line[key]; !ok {
+				t.Errorf("LOG_SCHEMA=%s 时缺少字段 %s: %s", schema, key, buf.String())
+			}
+		}
+		if len(line) != len(keys) {
+			t.Errorf("LOG_SCHEMA=%s 时字段数不正确: %s", schema, buf.String())
+		}
+	}
+
+	t.Setenv("LOG_SCHEMA", "otel")
+	buf, _ := newJSONEncoder(false).EncodeEntry(entry, nil)
+	var line map[string]interface{}
+	_ = json.Unmarshal(buf.Bytes(), &line)
+	if line["severity_text"] != "WARN" || line["timestamp"] != "2025-01-02T03:04:05Z" {
+		t.Errorf("OpenTelemetry 格式的级别或时间不正确: %s", buf.String())
+	}
+}
@@ -3,10 +3,12 @@ package logs
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"backend/app/types/consts"
@@ -37,6 +39,29 @@ const (
 type zapLogger struct {
 	logger *zap.Logger
 	sugar  *zap.SugaredLogger
+	level  zap.AtomicLevel // 日志级别，支持运行时修改
+	// console stdout 是否为控制台格式（带颜色），为 JSON 格式时为 false
+	console bool
+}
+
+// SetLevel 实现 LevelSetter 接口，运行时修改日志级别
+func (z *zapLogger) SetLevel(level string) {
+	z.level.SetLevel(zapcore.Level(parseLogLevel(level)))
+}
+
+// IsConsole 实现 ConsoleChecker 接口
+func (z *zapLogger) IsConsole() bool {
+	return z.console
+}
+
+// Level 实现 LevelGetter 接口，返回当前的日志级别，如 debug、info
+func (z *zapLogger) Level() string {
+	return z.level.Level().String()
+}
+
+// Sync 实现 Syncer 接口，写入缓冲的日志，推送到 Loki 时等待队列中的日志推送完成
+func (z *zapLogger) Sync() error {
+	return z.logger.Sync()
 }
 
 // GetLogger 返回底层 zap logger
@@ -46,46 +71,46 @@ func (z *zapLogger) GetLogger() interface{} {
 
 // Error 记录错误级别日志
 func (z *zapLogger) Error(msg string, keyvals ...interface{}) {
-	z.logger.Error(msg, z.parseKeyvals(keyvals...)...)
+	z.logger.Error(redactString(msg), z.parseKeyvals(keyvals...)...)
 }
 
 // Warn 记录警告级别日志
 func (z *zapLogger) Warn(msg string, keyvals ...interface{}) {
-	z.logger.Warn(msg, z.parseKeyvals(keyvals...)...)
+	z.logger.Warn(redactString(msg), z.parseKeyvals(keyvals...)...)
 }
 
 // Info 记录信息级别日志
 func (z *zapLogger) Info(msg string, keyvals ...interface{}) {
-	z.logger.Info(msg, z.parseKeyvals(keyvals...)...)
+	z.logger.Info(redactString(msg), z.parseKeyvals(keyvals...)...)
 }
 
 // Debug 记录调试级别日志
 func (z *zapLogger) Debug(msg string, keyvals ...interface{}) {
-	z.logger.Debug(msg, z.parseKeyvals(keyvals...)...)
+	z.logger.Debug(redactString(msg), z.parseKeyvals(keyvals...)...)
 }
 
 // CtxError 记录带上下文的错误级别日志
 func (z *zapLogger) CtxError(ctx context.Context, msg string, keyvals ...interface{}) {
 	fields := append(extractTraceFields(ctx), z.parseKeyvals(keyvals...)...)
-	z.logger.Error(msg, fields...)
+	z.logger.Error(redactString(msg), fields...)
 }
 
 // CtxWarn 记录带上下文的警告级别日志
 func (z *zapLogger) CtxWarn(ctx context.Context, msg string, keyvals ...interface{}) {
 	fields := append(extractTraceFields(ctx), z.parseKeyvals(keyvals...)...)
-	z.logger.Warn(msg, fields...)
+	z.logger.Warn(redactString(msg), fields...)
 }
 
 // CtxInfo 记录带上下文的信息级别日志
 func (z *zapLogger) CtxInfo(ctx context.Context, msg string, keyvals ...interface{}) {
 	fields := append(extractTraceFields(ctx), z.parseKeyvals(keyvals...)...)
-	z.logger.Info(msg, fields...)
+	z.logger.Info(redactString(msg), fields...)
 }
 
 // CtxDebug 记录带上下文的调试级别日志
 func (z *zapLogger) CtxDebug(ctx context.Context, msg string, keyvals ...interface{}) {
 	fields := append(extractTraceFields(ctx), z.parseKeyvals(keyvals...)...)
-	z.logger.Debug(msg, fields...)
+	z.logger.Debug(redactString(msg), fields...)
 }
 
 // WithTraceFields 为 logger 添加追踪字段（用于降级处理）
@@ -115,13 +140,14 @@ func (z *zapLogger) parseKeyvals(keyvals ...interface{}) []zap.Field {
 			// 如果不是字符串 key，跳过这个键值对
 			continue
 		}
-		value := keyvals[i+1]
+		// 脱敏密码、token 等敏感字段和 JWT 格式的字符串
+		value := redactValue(key, keyvals[i+1])
 		fields = append(fields, zap.Any(key, value))
 	}
 
 	// 如果 keyvals 是奇数个，最后一个值作为通用字段
 	if len(keyvals)%2 == 1 {
-		fields = append(fields, zap.Any("extra", keyvals[len(keyvals)-1]))
+		fields = append(fields, zap.Any("extra", redactValue("extra", keyvals[len(keyvals)-1])))
 	}
 
 	return fields
@@ -134,17 +160,48 @@ const (
 	TraceIDContextKey      contextKey = "trace_id"
 	SpanIDContextKey       contextKey = "span_id"
 	ParentSpanIDContextKey contextKey = "parent_span_id"
+	RequestIDContextKey    contextKey = "request_id"
 )
 
+// SpanContextFunc 读取 context 中当前 span 的 trace_id 和 span_id，没有 span 时返回 ok=false
+type SpanContextFunc func(ctx context.Context) (traceID, spanID string, ok bool)
+
+// spanContextFunc 由 SetSpanContextFunc 设置
+var spanContextFunc atomic.Pointer[SpanContextFunc]
+
+// SetSpanContextFunc 设置读取当前 span 的函数，Ctx* 日志优先使用它返回的 trace_id 和 span_id，
+// 没有 span 时仍从 context 的追踪字段中读取；接入 OpenTelemetry 时由 telemetry.Setup 设置，为 nil 时取消
+func SetSpanContextFunc(fn SpanContextFunc) {
+	if fn == nil {
+		spanContextFunc.Store(nil)
+		return
+	}
+	spanContextFunc.Store(&fn)
+}
+
 // extractTraceFields 从 context 中提取追踪字段
-// 支持从 context 中提取 trace_id、span_id 和 parent_span_id
+// 支持从 context 中提取 trace_id、span_id、parent_span_id、request_id 和 WithFields 写入的字段
 // 同时支持类型化的 key 和字符串 key（向后兼容）
 func extractTraceFields(ctx context.Context) []zap.Field {
 	if ctx == nil {
 		return nil
 	}
 
-	fields := make([]zap.Field, 0, 3)
+	fields := make([]zap.Field, 0, 4)
+
+	// 接入 OpenTelemetry 等链路追踪后，优先使用 context 中当前 span 的 trace_id 和 span_id
+	if fn := spanContextFunc.Load(); fn != nil {
+		if traceID, spanID, ok := (*fn)(ctx); ok {
+			fields = append(fields, zap.String("trace_id", traceID), zap.String("span_id", spanID))
+			if parentSpanID, ok := ctx.Value(ParentSpanIDContextKey).(string); ok && parentSpanID != "" && parentSpanID != spanID {
+				fields = append(fields, zap.String("parent_span_id", parentSpanID))
+			}
+			if requestID, ok := ctx.Value(RequestIDContextKey).(string); ok && requestID != "" {
+				fields = append(fields, zap.String("request_id", requestID))
+			}
+			return appendContextFields(ctx, fields)
+		}
+	}
 
 	// 尝试从 context 中提取 trace_id（优先使用类型化的 key）
 	var traceID interface{}
@@ -182,7 +239,13 @@ func extractTraceFields(ctx context.Context) []zap.Field {
 		}
 	}
 
-	return fields
+	// 尝试从 context 中提取 request_id
+	if requestID, ok := ctx.Value(RequestIDContextKey).(string); ok && requestID != "" {
+		fields = append(fields, zap.String("request_id", requestID))
+	}
+
+	// WithFields 写入的字段
+	return appendContextFields(ctx, fields)
 }
 
 // newZapLogger 创建新的 zap logger
@@ -197,51 +260,57 @@ func newZapLogger() *zapLogger {
 
 	// 设置日志级别
 	level := parseLogLevel(logLevel)
-	zapLevel := zapcore.Level(level)
+	zapLevel := zap.NewAtomicLevelAt(zapcore.Level(level))
 
 	// 如果设置了日志文件，使用 lumberjack 进行日志轮转
 	var fileWriter zapcore.WriteSyncer
 	if logFile != "" {
-		// 确保日志目录存在
-		if mkdirErr := os.MkdirAll(filepath.Dir(logFile), 0o755); mkdirErr == nil {
-			// 配置日志轮转
-			lumberjackLogger := &lumberjack.Logger{
-				Filename:   logFile,
-				MaxSize:    getLogMaxSize(),    // 单个文件最大大小（MB）
-				MaxBackups: getLogMaxBackups(), // 保留的旧文件数量
-				MaxAge:     getLogMaxAge(),     // 保留天数
-				Compress:   getLogCompress(),   // 是否压缩旧文件
-			}
-			fileWriter = zapcore.AddSync(lumberjackLogger)
+		if rotatingFile, err := OpenRotatingFile(logFile); err == nil {
+			fileWriter = wrapAsync(zapcore.AddSync(rotatingFile))
 		}
 	}
 
 	// 创建 logger
 	var cores []zapcore.Core
 
-	// 根据输出格式选择编码器
+	// 推送到 Loki 时 stdout 使用 JSON 格式，Loki 不可用时仍可从容器日志中查看
+	lokiOutput := isLokiOutput(logOutput)
+	if lokiOutput {
+		logOutput = "json"
+	}
+
+	// 根据输出格式选择编码器，JSON 格式的字段名按 LOG_SCHEMA 设置
 	var encoder zapcore.Encoder
-	encoderConfig := getEncoderConfig(logDevelopment, getEncoding(logOutput) == "console")
 	if getEncoding(logOutput) == "json" {
-		encoder = zapcore.NewJSONEncoder(encoderConfig)
+		encoder = newJSONEncoder(logDevelopment)
 	} else {
-		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+		encoder = zapcore.NewConsoleEncoder(getEncoderConfig(logDevelopment, true))
 	}
 
 	// 创建 stdout core
-	stdoutCore := zapcore.NewCore(encoder, zapcore.AddSync(os.Stdout), zapLevel)
+	stdoutCore := zapcore.NewCore(encoder, wrapAsync(zapcore.AddSync(os.Stdout)), zapLevel)
 	cores = append(cores, stdoutCore)
 
 	// 如果配置了文件输出，创建文件 core（文件输出使用 JSON 格式，不使用颜色）
 	if fileWriter != nil {
-		fileEncoderConfig := getEncoderConfig(logDevelopment, false)
-		fileEncoder := zapcore.NewJSONEncoder(fileEncoderConfig)
-		fileCore := zapcore.NewCore(fileEncoder, fileWriter, zapLevel)
+		fileCore := zapcore.NewCore(newJSONEncoder(logDevelopment), fileWriter, zapLevel)
 		cores = append(cores, fileCore)
 	}
 
-	// 合并所有 cores
-	core := zapcore.NewTee(cores...)
+	// LOG_OUTPUT=loki 时直接推送到 Loki，不需要 Promtail 采集
+	if lokiOutput {
+		if lokiConfig, err := getLokiConfig(); err != nil {
+			fmt.Fprintf(os.Stderr, "日志不推送到 Loki: %v\n", err)
+		} else {
+			cores = append(cores, newLokiCore(newJSONEncoder(logDevelopment), zapLevel, newLokiSink(lokiConfig)))
+		}
+	}
+
+	// 合并所有 cores，按级别采样高频日志；error 及以上级别从不采样，同时交给已注册的钩子
+	core := zapcore.NewTee(
+		newSamplingCore(zapcore.NewTee(cores...), getSamplingRules()),
+		newHookCore(zapLevel),
+	)
 
 	// 构建 logger
 	var options []zap.Option
@@ -253,8 +322,10 @@ func newZapLogger() *zapLogger {
 	logger = zap.New(core, options...)
 
 	return &zapLogger{
-		logger: logger,
-		sugar:  logger.Sugar(),
+		logger:  logger,
+		sugar:   logger.Sugar(),
+		level:   zapLevel,
+		console: getEncoding(logOutput) == "console",
 	}
 }
 
@@ -312,6 +383,21 @@ func isRunningInContainer() bool {
 	return false
 }
 
+// OpenRotatingFile 打开按 LOG_MAX_SIZE、LOG_MAX_BACKUPS、LOG_MAX_AGE、LOG_COMPRESS 轮转的文件，目录不存在时创建
+// 用于应用日志之外单独存放的日志，如审计日志
+func OpenRotatingFile(path string) (io.WriteCloser, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return &lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    getLogMaxSize(),    // 单个文件最大大小（MB）
+		MaxBackups: getLogMaxBackups(), // 保留的旧文件数量
+		MaxAge:     getLogMaxAge(),     // 保留天数
+		Compress:   getLogCompress(),   // 是否压缩旧文件
+	}, nil
+}
+
 // getLogMaxSize 获取日志文件最大大小（MB）
 func getLogMaxSize() int {
 	maxSize := envx.GetStringOptional(consts.EnvLogMaxSize)
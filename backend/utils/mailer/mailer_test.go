@@ -0,0 +1,253 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// TestBuildPlainText 测试构建纯文本邮件
+func TestBuildPlainText(t *testing.T) {
+	data, err := Build("Peano <noreply@example.com>", &Message{
+		To:      []string{"alice@example.com"},
+		Subject: "验证邮箱",
+		Text:    "你好",
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if subject != "验证邮箱" {
+		t.Errorf("unexpected subject: %s", subject)
+	}
+	if got := msg.Header.Get("To"); got != "<alice@example.com>" {
+		t.Errorf("unexpected to: %s", got)
+	}
+	if !strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>") {
+		t.Errorf("unexpected message id: %s", msg.Header.Get("Message-ID"))
+	}
+	if !strings.HasPrefix(msg.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("unexpected content type: %s", msg.Header.Get("Content-Type"))
+	}
+}
+
+// TestBuildAlternative 测试同时有纯文本和 HTML 时构建 multipart/alternative 邮件
+func TestBuildAlternative(t *testing.T) {
+	data, err := Build("noreply@example.com", &Message{
+		To:      []string{"alice@example.com", "Bob <bob@example.com>"},
+		Subject: "digest",
+		Text:    "plain",
+		HTML:    "<p>html</p>",
+	}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type: %s", msg.Header.Get("Content-Type"))
+	}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	var parts []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		body, _ := io.ReadAll(part)
+		parts = append(parts, part.Header.Get("Content-Type")+"|"+string(body))
+	}
+	if len(parts) != 2 || !strings.HasSuffix(parts[0], "|plain") || !strings.HasSuffix(parts[1], "|<p>html</p>") {
+		t.Errorf("unexpected parts: %v", parts)
+	}
+}
+
+// TestBuildInvalid 测试无效地址和缺少收件人
+func TestBuildInvalid(t *testing.T) {
+	if _, err := Build("noreply@example.com", &Message{Subject: "x"}, time.Now()); !errors.Is(err, ErrNoRecipient) {
+		t.Errorf("expected ErrNoRecipient, got %v", err)
+	}
+	if _, err := Build("noreply@example.com", &Message{To: []string{"not an address"}}, time.Now()); err == nil {
+		t.Error("invalid recipient should fail")
+	}
+}
+
+// TestIsPermanent 测试 SMTP 5xx 错误不可重试
+func TestIsPermanent(t *testing.T) {
+	if !IsPermanent(&textproto.Error{Code: 550, Msg: "no such user"}) {
+		t.Error("550 should be permanent")
+	}
+	if IsPermanent(&textproto.Error{Code: 451, Msg: "try again"}) {
+		t.Error("451 should be retryable")
+	}
+	if IsPermanent(errors.New("connection refused")) {
+		t.Error("network error should be retryable")
+	}
+}
+
+// TestRender 测试渲染模板，HTML 自动转义而纯文本不转义
+func TestRender(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/greet.tmpl": {Data: []byte(`{{define "subject"}}Hi {{.Name}}{{end}}
+{{define "text"}}Hello {{.Name}}{{end}}
+{{define "html"}}<p>Hello {{.Name}}</p>{{end}}`)},
+		"templates/plain.tmpl": {Data: []byte(`{{define "subject"}}Plain{{end}}{{define "text"}}Only text{{end}}`)},
+	}
+	templates, err := ParseTemplates(fsys, "templates/*.tmpl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := templates.Names(); len(names) != 2 || names[0] != "greet" || names[1] != "plain" {
+		t.Errorf("unexpected names: %v", names)
+	}
+
+	msg, err := templates.Render("greet", map[string]string{"Name": "<b>Tom</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Subject != "Hi <b>Tom</b>" || msg.Text != "Hello <b>Tom</b>\n" {
+		t.Errorf("unexpected text rendering: %q %q", msg.Subject, msg.Text)
+	}
+	if msg.HTML != "<p>Hello &lt;b&gt;Tom&lt;/b&gt;</p>" {
+		t.Errorf("unexpected html rendering: %q", msg.HTML)
+	}
+
+	msg, err = templates.Render("plain", nil)
+	if err != nil || msg.HTML != "" {
+		t.Errorf("plain template should have no html: %v %q", err, msg.HTML)
+	}
+	if _, err := templates.Render("missing", nil); err == nil {
+		t.Error("missing template should fail")
+	}
+}
+
+// TestParseTemplatesMissingBlock 测试缺少必需块的模板解析失败
+func TestParseTemplatesMissingBlock(t *testing.T) {
+	fsys := fstest.MapFS{"bad.tmpl": {Data: []byte(`{{define "subject"}}x{{end}}`)}}
+	if _, err := ParseTemplates(fsys, "*.tmpl"); err == nil {
+		t.Error("template without text block should fail")
+	}
+}
+
+// fakeSMTPServer 只实现发送邮件所需命令的 SMTP 服务器，返回收到的邮件内容
+func fakeSMTPServer(t *testing.T, rcptCode int) (string, <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	received := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+			switch cmd {
+			case "EHLO", "HELO":
+				tp.PrintfLine("250 localhost")
+			case "MAIL":
+				tp.PrintfLine("250 OK")
+			case "RCPT":
+				tp.PrintfLine("%d recipient", rcptCode)
+			case "DATA":
+				tp.PrintfLine("354 go ahead")
+				data, _ := io.ReadAll(tp.DotReader())
+				received <- string(data)
+				tp.PrintfLine("250 queued")
+			case "QUIT":
+				tp.PrintfLine("221 bye")
+				return
+			default:
+				tp.PrintfLine("250 OK")
+			}
+		}
+	}()
+	return ln.Addr().String(), received
+}
+
+// TestSMTPSenderSend 测试通过 SMTP 发送邮件
+func TestSMTPSenderSend(t *testing.T) {
+	addr, received := fakeSMTPServer(t, 250)
+	host, port, _ := net.SplitHostPort(addr)
+	portNum, _ := strconv.Atoi(port)
+	sender, err := NewSMTPSender(SMTPConfig{Host: host, Port: portNum, From: "noreply@example.com", TLS: TLSNone, Timeout: 2 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sender.Send(context.Background(), &Message{To: []string{"alice@example.com"}, Subject: "hi", Text: "body"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case data := <-received:
+		msg, err := mail.ReadMessage(bufio.NewReader(strings.NewReader(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg.Header.Get("Subject") != "hi" {
+			t.Errorf("unexpected subject: %s", msg.Header.Get("Subject"))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message not received")
+	}
+}
+
+// TestSMTPSenderRejected 测试收件人被拒绝时返回不可重试错误
+func TestSMTPSenderRejected(t *testing.T) {
+	addr, _ := fakeSMTPServer(t, 550)
+	host, port, _ := net.SplitHostPort(addr)
+	portNum, _ := strconv.Atoi(port)
+	sender, _ := NewSMTPSender(SMTPConfig{Host: host, Port: portNum, From: "noreply@example.com", TLS: TLSNone, Timeout: 2 * time.Second})
+
+	err := sender.Send(context.Background(), &Message{To: []string{"nobody@example.com"}, Subject: "hi", Text: "body"})
+	if !IsPermanent(err) {
+		t.Errorf("expected permanent error, got %v", err)
+	}
+}
+
+// TestNewSMTPSenderInvalid 测试无效配置
+func TestNewSMTPSenderInvalid(t *testing.T) {
+	if _, err := NewSMTPSender(SMTPConfig{From: "noreply@example.com"}); err == nil {
+		t.Error("empty host should fail")
+	}
+	if _, err := NewSMTPSender(SMTPConfig{Host: "smtp.example.com", From: "bad"}); err == nil {
+		t.Error("invalid from should fail")
+	}
+	if _, err := NewSMTPSender(SMTPConfig{Host: "smtp.example.com", From: "a@example.com", TLS: "ssl"}); err == nil {
+		t.Error("invalid tls mode should fail")
+	}
+}
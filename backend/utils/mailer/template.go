@@ -0,0 +1,103 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+// 模板文件中定义的块名
+const (
+	blockSubject = "subject"
+	blockText    = "text"
+	blockHTML    = "html"
+)
+
+// Templates 邮件模板集合
+//
+// 每个模板一个文件，文件名（去掉扩展名）为模板名，文件中通过 {{define}} 定义 subject、text 和可选的 html 三个块：
+// subject 和 text 按纯文本渲染，html 按 HTML 渲染并自动转义
+type Templates struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// ParseTemplates 解析 fsys 中匹配 pattern 的模板文件
+func ParseTemplates(fsys fs.FS, pattern string) (*Templates, error) {
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("没有匹配的邮件模板: %s", pattern)
+	}
+
+	t := &Templates{
+		text: make(map[string]*texttemplate.Template, len(files)),
+		html: make(map[string]*htmltemplate.Template, len(files)),
+	}
+	for _, file := range files {
+		name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		textTmpl, err := texttemplate.New(name).ParseFS(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("解析邮件模板 %s 失败: %w", file, err)
+		}
+		if textTmpl.Lookup(blockSubject) == nil || textTmpl.Lookup(blockText) == nil {
+			return nil, fmt.Errorf("邮件模板 %s 缺少 subject 或 text 块", file)
+		}
+		t.text[name] = textTmpl
+
+		if textTmpl.Lookup(blockHTML) != nil {
+			htmlTmpl, err := htmltemplate.New(name).ParseFS(fsys, file)
+			if err != nil {
+				return nil, fmt.Errorf("解析邮件模板 %s 失败: %w", file, err)
+			}
+			t.html[name] = htmlTmpl
+		}
+	}
+	return t, nil
+}
+
+// Render 渲染模板，返回的邮件未设置收件人
+func (t *Templates) Render(name string, data any) (*Message, error) {
+	textTmpl, ok := t.text[name]
+	if !ok {
+		return nil, fmt.Errorf("邮件模板不存在: %s", name)
+	}
+
+	var subject, text bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&subject, blockSubject, data); err != nil {
+		return nil, fmt.Errorf("渲染邮件模板 %s 失败: %w", name, err)
+	}
+	if err := textTmpl.ExecuteTemplate(&text, blockText, data); err != nil {
+		return nil, fmt.Errorf("渲染邮件模板 %s 失败: %w", name, err)
+	}
+	msg := &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}
+
+	if htmlTmpl, ok := t.html[name]; ok {
+		var html bytes.Buffer
+		if err := htmlTmpl.ExecuteTemplate(&html, blockHTML, data); err != nil {
+			return nil, fmt.Errorf("渲染邮件模板 %s 失败: %w", name, err)
+		}
+		msg.HTML = html.String()
+	}
+	return msg, nil
+}
+
+// Names 所有模板名（按名称排序）
+func (t *Templates) Names() []string {
+	names := make([]string, 0, len(t.text))
+	for name := range t.text {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
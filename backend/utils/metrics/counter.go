@@ -0,0 +1,41 @@
+package metrics
+
+// CounterVec 带标签的计数器，输出格式兼容 Prometheus counter
+type CounterVec struct {
+	v *vec
+}
+
+// NewCounterVec 创建并注册带标签的计数器
+// name: 指标名称，建议使用 Prometheus 命名规范（如 http_errors_total）
+// help: 指标说明
+// labels: 标签名称，Inc/Add 时按相同顺序传入标签值
+//
+// 计数器同时注册到 Snapshot（JSON）和 WritePrometheus（文本格式）
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{v: newVec(name, help, typeCounter, labels)}
+}
+
+// Inc 计数加 1
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add 计数增加 delta，标签值数量与标签名称不一致时 panic
+func (c *CounterVec) Add(delta int64, labelValues ...string) {
+	c.v.add(delta, labelValues)
+}
+
+// Value 返回指定标签值的当前计数
+func (c *CounterVec) Value(labelValues ...string) int64 {
+	return c.v.value(labelValues)
+}
+
+// Collect 返回所有标签组合的当前计数（按标签值排序）
+func (c *CounterVec) Collect() []Sample {
+	return c.v.collect()
+}
+
+// Reset 清空所有计数
+func (c *CounterVec) Reset() {
+	c.v.reset()
+}
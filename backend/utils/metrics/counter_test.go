@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCounterVec(t *testing.T) {
+	c := NewCounterVec("test_requests_total", "测试计数器", "code", "route")
+	defer Unregister("test_requests_total")
+
+	c.Inc("1", "/a")
+	c.Inc("1", "/a")
+	c.Add(3, "2", `/b"c`)
+
+	if got := c.Value("1", "/a"); got != 2 {
+		t.Errorf("expected 2, got %d", got)
+	}
+	samples := c.Collect()
+	if len(samples) != 2 || samples[0].Labels["route"] != "/a" || samples[1].Value != 3 {
+		t.Errorf("unexpected samples: %+v", samples)
+	}
+	if _, ok := Snapshot()["test_requests_total"]; !ok {
+		t.Error("expected counter in snapshot")
+	}
+
+	var b strings.Builder
+	if err := WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"# TYPE test_requests_total counter",
+		`test_requests_total{code="1",route="/a"} 2`,
+		`test_requests_total{code="2",route="/b\"c"} 3`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %q in output:\n%s", line, b.String())
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for wrong label count")
+		}
+	}()
+	c.Inc("1")
+}
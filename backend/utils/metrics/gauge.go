@@ -0,0 +1,51 @@
+package metrics
+
+// GaugeVec 带标签的仪表盘，值可增可减，输出格式兼容 Prometheus gauge
+type GaugeVec struct {
+	v *vec
+}
+
+// NewGaugeVec 创建并注册带标签的仪表盘
+// name: 指标名称（如 sse_tasks_active）
+// help: 指标说明
+// labels: 标签名称，Set/Add 时按相同顺序传入标签值
+//
+// 仪表盘同时注册到 Snapshot（JSON）和 WritePrometheus（文本格式）
+func NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{v: newVec(name, help, typeGauge, labels)}
+}
+
+// Set 设置当前值，标签值数量与标签名称不一致时 panic
+func (g *GaugeVec) Set(value int64, labelValues ...string) {
+	g.v.set(value, labelValues)
+}
+
+// Add 当前值增加 delta，delta 可以为负数
+func (g *GaugeVec) Add(delta int64, labelValues ...string) {
+	g.v.add(delta, labelValues)
+}
+
+// Inc 当前值加 1
+func (g *GaugeVec) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec 当前值减 1
+func (g *GaugeVec) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// Value 返回指定标签值的当前值
+func (g *GaugeVec) Value(labelValues ...string) int64 {
+	return g.v.value(labelValues)
+}
+
+// Collect 返回所有标签组合的当前值（按标签值排序）
+func (g *GaugeVec) Collect() []Sample {
+	return g.v.collect()
+}
+
+// Reset 清空所有标签组合，用于采集前按当前状态重新设置
+func (g *GaugeVec) Reset() {
+	g.v.reset()
+}
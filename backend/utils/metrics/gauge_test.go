@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGaugeVec(t *testing.T) {
+	g := NewGaugeVec("test_tasks_active", "测试仪表盘", "kind")
+	defer Unregister("test_tasks_active")
+
+	g.Inc("export")
+	g.Inc("export")
+	g.Dec("export")
+	g.Set(5, "import")
+	g.Add(-2, "import")
+
+	if got := g.Value("export"); got != 1 {
+		t.Errorf("expected 1, got %d", got)
+	}
+	if got := g.Value("import"); got != 3 {
+		t.Errorf("expected 3, got %d", got)
+	}
+
+	var b strings.Builder
+	if err := WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"# TYPE test_tasks_active gauge",
+		`test_tasks_active{kind="export"} 1`,
+		`test_tasks_active{kind="import"} 3`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %q in output:\n%s", line, b.String())
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// typeHistogram Prometheus histogram 指标类型
+const typeHistogram = "histogram"
+
+// DefBuckets 默认的分桶上限（秒），适用于请求、任务耗时
+var DefBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// HistogramSample 直方图的一个采样值
+type HistogramSample struct {
+	Labels  map[string]string `json:"labels"`
+	Buckets map[string]uint64 `json:"buckets"` // 各分桶的累计次数（key: 分桶上限，含 +Inf）
+	Count   uint64            `json:"count"`
+	Sum     float64           `json:"sum"`
+}
+
+// histogram 一个标签组合的观测值
+type histogram struct {
+	counts []uint64 // 各分桶的次数（不累计），最后一个为 +Inf
+	count  uint64
+	sum    float64
+}
+
+// HistogramVec 带标签的直方图，输出格式兼容 Prometheus histogram
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.RWMutex
+	values map[string]*histogram
+}
+
+var (
+	// histograms 已注册的直方图（key: 指标名称）
+	histograms = make(map[string]*HistogramVec)
+	// histogramsMu 保护 histograms 的互斥锁
+	histogramsMu sync.RWMutex
+)
+
+// NewHistogramVec 创建并注册带标签的直方图
+// name: 指标名称（如 sse_task_duration_seconds）
+// help: 指标说明
+// buckets: 分桶上限（升序），为空时使用 DefBuckets
+// labels: 标签名称，Observe 时按相同顺序传入标签值
+//
+// 直方图同时注册到 Snapshot（JSON）和 WritePrometheus（文本格式）
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	h := &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: buckets,
+		values:  make(map[string]*histogram),
+	}
+
+	histogramsMu.Lock()
+	histograms[name] = h
+	histogramsMu.Unlock()
+	Register(name, func() interface{} {
+		return h.Collect()
+	})
+	return h
+}
+
+// Observe 记录一个观测值，标签值数量与标签名称不一致时 panic
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	if len(labelValues) != len(h.labels) {
+		panic(fmt.Sprintf("metrics: %s 需要 %d 个标签值，实际为 %d 个", h.name, len(h.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, labelSeparator)
+	i := sort.SearchFloat64s(h.buckets, value)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogram{counts: make([]uint64, len(h.buckets)+1)}
+		h.values[key] = v
+	}
+	v.counts[i]++
+	v.count++
+	v.sum += value
+}
+
+// Collect 返回所有标签组合的当前值（按标签值排序）
+func (h *HistogramVec) Collect() []HistogramSample {
+	h.mu.RLock()
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	samples := make([]HistogramSample, 0, len(keys))
+	for _, key := range keys {
+		v := h.values[key]
+		labels := make(map[string]string, len(h.labels))
+		if len(h.labels) > 0 {
+			for i, value := range strings.Split(key, labelSeparator) {
+				labels[h.labels[i]] = value
+			}
+		}
+		buckets := make(map[string]uint64, len(v.counts))
+		var cumulative uint64
+		for i, count := range v.counts {
+			cumulative += count
+			buckets[h.bucketLabel(i)] = cumulative
+		}
+		samples = append(samples, HistogramSample{Labels: labels, Buckets: buckets, Count: v.count, Sum: v.sum})
+	}
+	h.mu.RUnlock()
+	return samples
+}
+
+// bucketLabel 第 i 个分桶的 le 标签值
+func (h *HistogramVec) bucketLabel(i int) string {
+	if i >= len(h.buckets) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(h.buckets[i], 'g', -1, 64)
+}
+
+// writePrometheus 以 Prometheus 文本格式输出 _bucket、_sum、_count
+func (h *HistogramVec) writePrometheus(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n", h.name, escapeHelp(h.help))
+	fmt.Fprintf(b, "# TYPE %s %s\n", h.name, typeHistogram)
+	for _, sample := range h.Collect() {
+		var labels strings.Builder
+		for _, label := range h.labels {
+			fmt.Fprintf(&labels, "%s=\"%s\",", label, escapeLabelValue(sample.Labels[label]))
+		}
+		for i := 0; i <= len(h.buckets); i++ {
+			le := h.bucketLabel(i)
+			fmt.Fprintf(b, "%s_bucket{%sle=\"%s\"} %d\n", h.name, labels.String(), le, sample.Buckets[le])
+		}
+		suffix := ""
+		if labels.Len() > 0 {
+			suffix = "{" + strings.TrimSuffix(labels.String(), ",") + "}"
+		}
+		fmt.Fprintf(b, "%s_sum%s %s\n", h.name, suffix, strconv.FormatFloat(sample.Sum, 'g', -1, 64))
+		fmt.Fprintf(b, "%s_count%s %d\n", h.name, suffix, sample.Count)
+	}
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHistogramVec(t *testing.T) {
+	h := NewHistogramVec("test_task_duration_seconds", "测试直方图", []float64{1, 0.1}, "kind")
+	defer func() {
+		histogramsMu.Lock()
+		delete(histograms, "test_task_duration_seconds")
+		histogramsMu.Unlock()
+		Unregister("test_task_duration_seconds")
+	}()
+
+	h.Observe(0.05, "export")
+	h.Observe(0.1, "export")
+	h.Observe(0.5, "export")
+	h.Observe(3, "export")
+
+	samples := h.Collect()
+	if len(samples) != 1 {
+		t.Fatalf("expected 1 sample, got %d", len(samples))
+	}
+	if s := samples[0]; s.Count != 4 || s.Sum != 3.65 || s.Buckets["0.1"] != 2 || s.Buckets["1"] != 3 || s.Buckets["+Inf"] != 4 {
+		t.Errorf("unexpected sample: %+v", s)
+	}
+
+	var b strings.Builder
+	if err := WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"# TYPE test_task_duration_seconds histogram",
+		`test_task_duration_seconds_bucket{kind="export",le="0.1"} 2`,
+		`test_task_duration_seconds_bucket{kind="export",le="1"} 3`,
+		`test_task_duration_seconds_bucket{kind="export",le="+Inf"} 4`,
+		`test_task_duration_seconds_sum{kind="export"} 3.65`,
+		`test_task_duration_seconds_count{kind="export"} 4`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("expected line %q in output:\n%s", line, b.String())
+		}
+	}
+
+	defer Unregister("test_hook")
+	called := 0
+	OnCollect("test_hook", func() { called++ })
+	Snapshot()
+	if called != 1 {
+		t.Errorf("expected hook to run before snapshot, got %d calls", called)
+	}
+}
@@ -0,0 +1,101 @@
+// Package metrics 提供了一个轻量的指标收集注册表，各模块注册采集函数，由 metrics 端点统一输出
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// CollectorFunc 指标采集函数，返回当前时刻的指标快照
+type CollectorFunc func() interface{}
+
+var (
+	// collectors 采集函数注册表（key: 指标名称）
+	collectors = make(map[string]CollectorFunc)
+	// collectorsMu 保护注册表的互斥锁
+	collectorsMu sync.RWMutex
+
+	// hooks 采集前执行的函数（key: 名称）
+	hooks = make(map[string]func())
+	// hooksMu 保护 hooks 的互斥锁
+	hooksMu sync.RWMutex
+)
+
+// Register 注册指标采集函数
+// name: 指标名称，同名注册会覆盖之前的采集函数
+// fn: 采集函数
+func Register(name string, fn CollectorFunc) {
+	if fn == nil {
+		return
+	}
+	collectorsMu.Lock()
+	defer collectorsMu.Unlock()
+	collectors[name] = fn
+}
+
+// Unregister 注销指标采集函数和同名的采集前钩子
+func Unregister(name string) {
+	collectorsMu.Lock()
+	delete(collectors, name)
+	collectorsMu.Unlock()
+
+	hooksMu.Lock()
+	delete(hooks, name)
+	hooksMu.Unlock()
+}
+
+// OnCollect 注册采集前执行的函数，Snapshot 和 WritePrometheus 输出之前调用
+// 用于按其他模块的当前状态刷新仪表盘（如任务数、订阅者数），同名注册会覆盖
+func OnCollect(name string, fn func()) {
+	if fn == nil {
+		return
+	}
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks[name] = fn
+}
+
+// runHooks 执行所有采集前钩子
+func runHooks() {
+	hooksMu.RLock()
+	fns := make([]func(), 0, len(hooks))
+	for _, fn := range hooks {
+		fns = append(fns, fn)
+	}
+	hooksMu.RUnlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// Names 返回已注册的指标名称（按字母序）
+func Names() []string {
+	collectorsMu.RLock()
+	defer collectorsMu.RUnlock()
+	names := make([]string, 0, len(collectors))
+	for name := range collectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Snapshot 采集所有已注册指标的当前快照
+func Snapshot() map[string]interface{} {
+	runHooks()
+
+	collectorsMu.RLock()
+	fns := make(map[string]CollectorFunc, len(collectors))
+	for name, fn := range collectors {
+		fns[name] = fn
+	}
+	collectorsMu.RUnlock()
+
+	// 在锁外执行采集函数，避免采集函数内部注册指标时死锁
+	result := make(map[string]interface{}, len(fns))
+	for name, fn := range fns {
+		result[name] = fn()
+	}
+	return result
+}
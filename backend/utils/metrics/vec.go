@@ -0,0 +1,181 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// labelSeparator 拼接标签值作为 map key 的分隔符
+const labelSeparator = "\xff"
+
+// Prometheus 指标类型
+const (
+	typeCounter = "counter"
+	typeGauge   = "gauge"
+)
+
+// Sample 指标的一个采样值
+type Sample struct {
+	Labels map[string]string `json:"labels"`
+	Value  int64             `json:"value"`
+}
+
+// vec 按标签值保存指标值，CounterVec 和 GaugeVec 共用
+type vec struct {
+	name   string
+	help   string
+	typ    string
+	labels []string
+
+	mu     sync.RWMutex
+	values map[string]int64
+}
+
+var (
+	// families 已注册的计数器和仪表盘（key: 指标名称）
+	families = make(map[string]*vec)
+	// familiesMu 保护 families 的互斥锁
+	familiesMu sync.RWMutex
+)
+
+// newVec 创建并注册指标，同时注册到 Snapshot（JSON）和 WritePrometheus（文本格式）
+func newVec(name, help, typ string, labels []string) *vec {
+	v := &vec{
+		name:   name,
+		help:   help,
+		typ:    typ,
+		labels: labels,
+		values: make(map[string]int64),
+	}
+
+	familiesMu.Lock()
+	families[name] = v
+	familiesMu.Unlock()
+	Register(name, func() interface{} {
+		return v.collect()
+	})
+	return v
+}
+
+// key 拼接标签值，标签值数量与标签名称不一致时 panic
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s 需要 %d 个标签值，实际为 %d 个", v.name, len(v.labels), len(labelValues)))
+	}
+	return strings.Join(labelValues, labelSeparator)
+}
+
+func (v *vec) add(delta int64, labelValues []string) {
+	key := v.key(labelValues)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[key] += delta
+}
+
+func (v *vec) set(value int64, labelValues []string) {
+	key := v.key(labelValues)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[key] = value
+}
+
+func (v *vec) value(labelValues []string) int64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.values[strings.Join(labelValues, labelSeparator)]
+}
+
+// collect 返回所有标签组合的当前值（按标签值排序）
+func (v *vec) collect() []Sample {
+	v.mu.RLock()
+	keys := make([]string, 0, len(v.values))
+	values := make(map[string]int64, len(v.values))
+	for key, value := range v.values {
+		keys = append(keys, key)
+		values[key] = value
+	}
+	v.mu.RUnlock()
+
+	sort.Strings(keys)
+	samples := make([]Sample, 0, len(keys))
+	for _, key := range keys {
+		labels := make(map[string]string, len(v.labels))
+		if len(v.labels) > 0 {
+			for i, value := range strings.Split(key, labelSeparator) {
+				labels[v.labels[i]] = value
+			}
+		}
+		samples = append(samples, Sample{Labels: labels, Value: values[key]})
+	}
+	return samples
+}
+
+func (v *vec) reset() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values = make(map[string]int64)
+}
+
+// WritePrometheus 以 Prometheus 文本格式输出所有通过 NewCounterVec、NewGaugeVec、NewHistogramVec 创建的指标
+func WritePrometheus(w io.Writer) error {
+	runHooks()
+
+	familiesMu.RLock()
+	names := make([]string, 0, len(families))
+	list := make(map[string]*vec, len(families))
+	for name, v := range families {
+		names = append(names, name)
+		list[name] = v
+	}
+	familiesMu.RUnlock()
+	histogramsMu.RLock()
+	hists := make(map[string]*HistogramVec, len(histograms))
+	for name, h := range histograms {
+		names = append(names, name)
+		hists[name] = h
+	}
+	histogramsMu.RUnlock()
+
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		if h, ok := hists[name]; ok {
+			h.writePrometheus(&b)
+			continue
+		}
+		v := list[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n", name, escapeHelp(v.help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, v.typ)
+		for _, sample := range v.collect() {
+			b.WriteString(name)
+			if len(v.labels) > 0 {
+				b.WriteByte('{')
+				for i, label := range v.labels {
+					if i > 0 {
+						b.WriteByte(',')
+					}
+					fmt.Fprintf(&b, "%s=\"%s\"", label, escapeLabelValue(sample.Labels[label]))
+				}
+				b.WriteByte('}')
+			}
+			fmt.Fprintf(&b, " %d\n", sample.Value)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// escapeHelp 转义 HELP 文本中的反斜杠和换行
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// escapeLabelValue 转义标签值中的反斜杠、双引号和换行
+func escapeLabelValue(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// Window 滑动窗口计数器，按 resolution 分桶，可统计最近 span 内任意时长的次数
+// 与 CounterVec 不同，Window 不注册到 metrics 端点，用于按时间窗口计算请求速率等
+type Window struct {
+	resolution time.Duration
+
+	mu      sync.Mutex
+	slots   []int64 // 每个桶对应的时间片序号，与当前时间片不一致时视为过期
+	buckets []int64
+}
+
+// NewWindow 创建滑动窗口计数器
+// span: 可统计的最长时长
+// resolution: 分桶粒度，统计时长按粒度向上取整
+func NewWindow(span, resolution time.Duration) *Window {
+	if resolution <= 0 {
+		resolution = time.Second
+	}
+	size := int((span + resolution - 1) / resolution)
+	if size < 1 {
+		size = 1
+	}
+	return &Window{
+		resolution: resolution,
+		slots:      make([]int64, size),
+		buckets:    make([]int64, size),
+	}
+}
+
+// Add 在 now 所在的时间片计数增加 delta
+func (w *Window) Add(now time.Time, delta int64) {
+	slot := w.slot(now)
+	i := w.index(slot)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.slots[i] != slot {
+		w.slots[i] = slot
+		w.buckets[i] = 0
+	}
+	w.buckets[i] += delta
+}
+
+// Inc 在 now 所在的时间片计数加 1
+func (w *Window) Inc(now time.Time) {
+	w.Add(now, 1)
+}
+
+// Sum 返回截至 now 最近 d 内的次数（包含 now 所在的时间片），d 超过 span 时按 span 统计
+func (w *Window) Sum(now time.Time, d time.Duration) int64 {
+	n := int64((d + w.resolution - 1) / w.resolution)
+	if n > int64(len(w.buckets)) {
+		n = int64(len(w.buckets))
+	}
+	current := w.slot(now)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var total int64
+	for slot := current - n + 1; slot <= current; slot++ {
+		i := w.index(slot)
+		if w.slots[i] == slot {
+			total += w.buckets[i]
+		}
+	}
+	return total
+}
+
+// Span 返回可统计的最长时长
+func (w *Window) Span() time.Duration {
+	return time.Duration(len(w.buckets)) * w.resolution
+}
+
+func (w *Window) slot(now time.Time) int64 {
+	return now.UnixNano() / int64(w.resolution)
+}
+
+func (w *Window) index(slot int64) int {
+	i := int(slot % int64(len(w.buckets)))
+	if i < 0 {
+		i += len(w.buckets)
+	}
+	return i
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindow(t *testing.T) {
+	w := NewWindow(time.Hour, time.Minute)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	w.Inc(start)
+	w.Add(start.Add(30*time.Second), 2)
+	w.Inc(start.Add(10 * time.Minute))
+	w.Add(start.Add(30*time.Minute), 4)
+
+	now := start.Add(30 * time.Minute)
+	if got := w.Sum(now, time.Minute); got != 4 {
+		t.Errorf("最近 1 分钟: expected 4, got %d", got)
+	}
+	if got := w.Sum(now, 25*time.Minute); got != 5 {
+		t.Errorf("最近 25 分钟: expected 5, got %d", got)
+	}
+	if got := w.Sum(now, 31*time.Minute); got != 8 {
+		t.Errorf("最近 31 分钟: expected 8, got %d", got)
+	}
+	// 超过 span 时按 span 统计
+	if got := w.Sum(now, 24*time.Hour); got != 8 {
+		t.Errorf("超过 span: expected 8, got %d", got)
+	}
+	if got := w.Span(); got != time.Hour {
+		t.Errorf("span: expected 1h, got %s", got)
+	}
+}
+
+func TestWindowExpire(t *testing.T) {
+	w := NewWindow(time.Hour, time.Minute)
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	w.Add(start, 3)
+	// 超过 span 后旧的桶不再计入
+	later := start.Add(time.Hour)
+	if got := w.Sum(later, time.Hour); got != 0 {
+		t.Errorf("expected 0, got %d", got)
+	}
+	// 复用同一个桶时先清零
+	w.Inc(later)
+	if got := w.Sum(later, time.Hour); got != 1 {
+		t.Errorf("expected 1, got %d", got)
+	}
+}
@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testSpec = `{
+  "swagger": "2.0",
+  "paths": {
+    "/api/item": {
+      "post": {
+        "consumes": ["application/json"],
+        "produces": ["application/json"],
+        "parameters": [
+          {"name": "request", "in": "body", "required": true, "schema": {"$ref": "#/definitions/CreateItemReq"}}
+        ],
+        "responses": {
+          "200": {"schema": {"allOf": [
+            {"$ref": "#/definitions/Response"},
+            {"type": "object", "properties": {"data": {"$ref": "#/definitions/ItemDTO"}}}
+          ]}},
+          "400": {"schema": {"$ref": "#/definitions/Response"}}
+        }
+      }
+    },
+    "/api/item/list": {
+      "get": {
+        "parameters": [
+          {"name": "page", "in": "query", "type": "integer", "minimum": 1},
+          {"name": "status", "in": "query", "type": "string", "enum": ["normal", "done"]},
+          {"name": "ids", "in": "query", "type": "array", "items": {"type": "integer"}},
+          {"name": "date_start", "in": "query", "type": "string", "required": true}
+        ],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/Response"}}}
+      }
+    },
+    "/api/item/{item_id}": {
+      "get": {
+        "parameters": [{"name": "item_id", "in": "path", "type": "integer", "required": true}],
+        "responses": {"200": {"schema": {"$ref": "#/definitions/Response"}}}
+      }
+    }
+  },
+  "definitions": {
+    "CreateItemReq": {
+      "type": "object",
+      "required": ["content"],
+      "properties": {
+        "content": {"type": "string", "minLength": 3, "maxLength": 10},
+        "status": {"enum": ["normal", "done"], "allOf": [{"$ref": "#/definitions/ItemStatus"}]},
+        "tags": {"type": "array", "maxItems": 2, "items": {"type": "integer"}}
+      }
+    },
+    "ItemStatus": {"type": "string"},
+    "ItemDTO": {
+      "type": "object",
+      "properties": {
+        "item_id": {"type": "integer"},
+        "content": {"type": "string"},
+        "tags": {"type": "array", "items": {"type": "integer"}}
+      }
+    },
+    "Response": {
+      "type": "object",
+      "properties": {"code": {"type": "integer"}, "message": {"type": "string"}, "data": {}}
+    }
+  }
+}`
+
+func mustParse(t *testing.T) *Spec {
+	t.Helper()
+	spec, err := Parse([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	return spec
+}
+
+// fields 返回不符合项的位置
+func fields(violations []Violation) string {
+	parts := make([]string, 0, len(violations))
+	for _, v := range violations {
+		parts = append(parts, v.Field)
+	}
+	return strings.Join(parts, ",")
+}
+
+// TestParseOperation 测试按 gin 路由路径查找接口
+func TestParseOperation(t *testing.T) {
+	spec := mustParse(t)
+	if spec.Len() != 3 {
+		t.Fatalf("expected 3 operations, got %d", spec.Len())
+	}
+	if spec.Operation("GET", "/api/item/:item_id") == nil {
+		t.Fatal("未找到路径参数接口")
+	}
+	if spec.Operation("DELETE", "/api/item/:item_id") != nil {
+		t.Fatal("未定义的方法不应找到接口")
+	}
+	if _, err := Parse([]byte(`{"openapi": "3.0.0"}`)); err == nil {
+		t.Fatal("expected error for OpenAPI 3")
+	}
+}
+
+// TestValidateRequestParams 测试 query 和 path 参数校验
+func TestValidateRequestParams(t *testing.T) {
+	spec := mustParse(t)
+	list := spec.Operation("GET", "/api/item/list")
+
+	ok := spec.ValidateRequest(list, &Request{Query: url.Values{
+		"date_start": {"2025-01-01"}, "page": {"2"}, "status": {"done"}, "ids": {"1,2"},
+	}})
+	if len(ok) != 0 {
+		t.Fatalf("expected no violations, got %v", ok)
+	}
+
+	got := spec.ValidateRequest(list, &Request{Query: url.Values{
+		"page": {"0"}, "status": {"deleted"}, "ids": {"1,x"},
+	}})
+	if fields(got) != "query.page,query.status,query.ids[1],query.date_start" {
+		t.Fatalf("unexpected violations: %v", got)
+	}
+
+	get := spec.Operation("GET", "/api/item/:item_id")
+	got = spec.ValidateRequest(get, &Request{Path: map[string]string{"item_id": "abc"}})
+	if len(got) != 1 || got[0].Reason != "必须是整数" {
+		t.Fatalf("unexpected violations: %v", got)
+	}
+}
+
+// TestValidateRequestBody 测试 JSON 请求体校验
+func TestValidateRequestBody(t *testing.T) {
+	spec := mustParse(t)
+	op := spec.Operation("POST", "/api/item")
+
+	valid := &Request{ContentType: "application/json; charset=utf-8", Body: []byte(`{"content":"买菜啊","status":"done","tags":[1],"due_at":null}`)}
+	if got := spec.ValidateRequest(op, valid); len(got) != 0 {
+		t.Fatalf("expected no violations, got %v", got)
+	}
+
+	tests := []struct {
+		name   string
+		req    *Request
+		fields string
+	}{
+		{"缺少请求体", &Request{ContentType: "application/json"}, "body"},
+		{"非 JSON", &Request{ContentType: "text/plain", Body: []byte("x")}, "body"},
+		{"无效 JSON", &Request{ContentType: "application/json", Body: []byte("{")}, "body"},
+		{"缺少必填字段", &Request{ContentType: "application/json", Body: []byte(`{"content":null}`)}, "body.content"},
+		{"字段不符合", &Request{ContentType: "application/json", Body: []byte(`{"content":"ab","status":"x","tags":[1,2.5,3]}`)}, ""},
+	}
+	for _, tt := range tests {
+		got := spec.ValidateRequest(op, tt.req)
+		if tt.fields == "" {
+			// 属性顺序不固定，只检查数量
+			if len(got) != 4 {
+				t.Errorf("%s: expected 4 violations, got %v", tt.name, got)
+			}
+			continue
+		}
+		if fields(got) != tt.fields {
+			t.Errorf("%s: expected %s, got %v", tt.name, tt.fields, got)
+		}
+	}
+}
+
+// TestValidateResponse 测试响应状态码和响应体校验
+func TestValidateResponse(t *testing.T) {
+	spec := mustParse(t)
+	op := spec.Operation("POST", "/api/item")
+
+	if got := spec.ValidateResponse(op, 200, "application/json", []byte(`{"code":0,"data":{"item_id":1,"content":"x","tags":null}}`)); len(got) != 0 {
+		t.Fatalf("expected no violations, got %v", got)
+	}
+	if got := spec.ValidateResponse(op, 200, "application/json", []byte(`{"code":0,"data":{"item_id":"1"}}`)); fields(got) != "response.data.item_id" {
+		t.Fatalf("unexpected violations: %v", got)
+	}
+	if got := spec.ValidateResponse(op, 201, "application/json", nil); fields(got) != "response" {
+		t.Fatalf("expected undefined 2xx status violation, got %v", got)
+	}
+	if got := spec.ValidateResponse(op, 429, "application/json", []byte(`{"code":1}`)); len(got) != 0 {
+		t.Fatalf("undefined error status should be ignored, got %v", got)
+	}
+	if got := spec.ValidateResponse(op, 400, "text/html", []byte("<html>")); fields(got) != "response" {
+		t.Fatalf("expected content type violation, got %v", got)
+	}
+}
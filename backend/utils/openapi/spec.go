@@ -0,0 +1,190 @@
+// Package openapi 按 swag 生成的 OpenAPI 2.0（Swagger 2.0）接口定义校验请求和响应
+// 只支持 swag 生成文档用到的子集：query/path/header/body 参数、$ref、allOf、type、required、enum 和长度/数值/数量范围
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Spec 解析后的接口定义
+type Spec struct {
+	operations  map[string]*Operation
+	definitions map[string]*Schema
+}
+
+// Operation 一个接口（方法 + 路径）
+type Operation struct {
+	Method     string
+	Path       string // gin 路由格式，如 /api/item/:item_id
+	Consumes   []string
+	Produces   []string
+	Parameters []*Parameter
+	Responses  map[string]*Response // key 为状态码或 default
+}
+
+// Parameter 接口参数
+type Parameter struct {
+	Name             string   `json:"name"`
+	In               string   `json:"in"` // query, path, header, body, formData
+	Required         bool     `json:"required"`
+	Type             string   `json:"type"`
+	Items            *Schema  `json:"items"`
+	CollectionFormat string   `json:"collectionFormat"` // csv（默认）, multi
+	Enum             []any    `json:"enum"`
+	Minimum          *float64 `json:"minimum"`
+	Maximum          *float64 `json:"maximum"`
+	MinLength        *int     `json:"minLength"`
+	MaxLength        *int     `json:"maxLength"`
+	Schema           *Schema  `json:"schema"` // in=body 时的请求体结构
+}
+
+// Response 接口响应
+type Response struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema 数据结构
+type Schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Required   []string           `json:"required"`
+	Properties map[string]*Schema `json:"properties"`
+	Items      *Schema            `json:"items"`
+	AllOf      []*Schema          `json:"allOf"`
+	Enum       []any              `json:"enum"`
+	Minimum    *float64           `json:"minimum"`
+	Maximum    *float64           `json:"maximum"`
+	MinLength  *int               `json:"minLength"`
+	MaxLength  *int               `json:"maxLength"`
+	MinItems   *int               `json:"minItems"`
+	MaxItems   *int               `json:"maxItems"`
+}
+
+// document Swagger 2.0 文档中用到的字段
+type document struct {
+	Swagger     string                                `json:"swagger"`
+	BasePath    string                                `json:"basePath"`
+	Consumes    []string                              `json:"consumes"`
+	Produces    []string                              `json:"produces"`
+	Paths       map[string]map[string]json.RawMessage `json:"paths"`
+	Definitions map[string]*Schema                    `json:"definitions"`
+}
+
+// operation 文档中的接口
+type operation struct {
+	Consumes   []string             `json:"consumes"`
+	Produces   []string             `json:"produces"`
+	Parameters []*Parameter         `json:"parameters"`
+	Responses  map[string]*Response `json:"responses"`
+}
+
+// httpMethods 路径下表示接口的字段，其他字段（如 parameters）忽略
+var httpMethods = map[string]bool{
+	"get": true, "put": true, "post": true, "delete": true, "options": true, "head": true, "patch": true,
+}
+
+// Parse 解析 Swagger 2.0 JSON 文档
+func Parse(data []byte) (*Spec, error) {
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("解析接口定义失败: %w", err)
+	}
+	if !strings.HasPrefix(doc.Swagger, "2.") {
+		return nil, fmt.Errorf("不支持的接口定义版本: %q", doc.Swagger)
+	}
+
+	basePath := strings.TrimRight(doc.BasePath, "/")
+	spec := &Spec{
+		operations:  make(map[string]*Operation),
+		definitions: doc.Definitions,
+	}
+	for path, item := range doc.Paths {
+		for method, raw := range item {
+			if !httpMethods[method] {
+				continue
+			}
+			var op operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("解析接口 %s %s 失败: %w", strings.ToUpper(method), path, err)
+			}
+			o := &Operation{
+				Method:     strings.ToUpper(method),
+				Path:       ginPath(basePath + path),
+				Consumes:   op.Consumes,
+				Produces:   op.Produces,
+				Parameters: op.Parameters,
+				Responses:  op.Responses,
+			}
+			if o.Consumes == nil {
+				o.Consumes = doc.Consumes
+			}
+			if o.Produces == nil {
+				o.Produces = doc.Produces
+			}
+			spec.operations[o.Method+" "+o.Path] = o
+		}
+	}
+	return spec, nil
+}
+
+// ginPath 将路径参数 {item_id} 转换为 gin 的 :item_id
+func ginPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			segments[i] = ":" + s[1:len(s)-1]
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Operation 按方法和 gin 路由路径（gin.Context.FullPath）查找接口，未定义时返回 nil
+func (s *Spec) Operation(method, path string) *Operation {
+	return s.operations[method+" "+path]
+}
+
+// Len 接口数量
+func (s *Spec) Len() int {
+	return len(s.operations)
+}
+
+// ProducesJSON 接口是否返回 JSON，未声明时视为 JSON
+func (o *Operation) ProducesJSON() bool {
+	if len(o.Produces) == 0 {
+		return true
+	}
+	for _, p := range o.Produces {
+		if IsJSON(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsJSON 是否为 JSON 内容类型（application/json、application/problem+json 等）
+func IsJSON(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.TrimSpace(strings.ToLower(mediaType))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// resolve 解析 $ref，只支持本文档内的 #/definitions/xxx
+func (s *Spec) resolve(schema *Schema) (*Schema, error) {
+	for depth := 0; schema != nil && schema.Ref != ""; depth++ {
+		if depth > 32 {
+			return nil, fmt.Errorf("$ref 嵌套过深: %s", schema.Ref)
+		}
+		name, ok := strings.CutPrefix(schema.Ref, "#/definitions/")
+		if !ok {
+			return nil, fmt.Errorf("不支持的 $ref: %s", schema.Ref)
+		}
+		def, ok := s.definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("未定义的 $ref: %s", schema.Ref)
+		}
+		schema = def
+	}
+	return schema, nil
+}
@@ -0,0 +1,347 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxViolations 单次校验最多返回的不符合项，避免请求体很大时返回过多
+const maxViolations = 20
+
+// Violation 一处不符合接口定义的地方
+type Violation struct {
+	Field  string // 位置，如 query.page、body.tags[0]、response.data.item_id
+	Reason string // 原因
+}
+
+func (v Violation) String() string {
+	return v.Field + " " + v.Reason
+}
+
+// Request 待校验的请求
+type Request struct {
+	Query       url.Values
+	Header      http.Header
+	Path        map[string]string // 路径参数
+	ContentType string
+	Body        []byte // 请求体，没有时为空
+}
+
+// ValidateRequest 按接口定义校验请求参数和 JSON 请求体，返回不符合的地方
+// formData 参数（文件上传）不校验；接口定义之外的参数和字段忽略
+func (s *Spec) ValidateRequest(op *Operation, req *Request) []Violation {
+	v := &validator{spec: s}
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "query":
+			values, ok := req.Query[p.Name]
+			v.param(p, "query."+p.Name, values, ok && len(values) > 0)
+		case "header":
+			value := req.Header.Get(p.Name)
+			v.param(p, "header."+p.Name, []string{value}, value != "")
+		case "path":
+			value, ok := req.Path[p.Name]
+			v.param(p, "path."+p.Name, []string{value}, ok && value != "")
+		case "body":
+			v.body(p, req)
+		}
+	}
+	return v.violations
+}
+
+// ValidateResponse 按接口定义校验响应状态码和 JSON 响应体，返回不符合的地方
+// 未定义的 2xx 状态码视为不符合，未定义的其他状态码（如限流、鉴权中间件返回的错误）不校验
+func (s *Spec) ValidateResponse(op *Operation, status int, contentType string, body []byte) []Violation {
+	v := &validator{spec: s}
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		resp, ok = op.Responses["default"]
+	}
+	if !ok {
+		if status >= 200 && status < 300 {
+			v.add("response", fmt.Sprintf("未定义的状态码 %d", status))
+		}
+		return v.violations
+	}
+	if resp == nil || resp.Schema == nil || len(bytes.TrimSpace(body)) == 0 {
+		return v.violations
+	}
+	if !IsJSON(contentType) {
+		v.add("response", fmt.Sprintf("内容类型必须是 JSON，实际为 %q", contentType))
+		return v.violations
+	}
+	value, err := decodeJSON(body)
+	if err != nil {
+		v.add("response", "不是有效的 JSON: "+err.Error())
+		return v.violations
+	}
+	v.value(resp.Schema, value, "response")
+	return v.violations
+}
+
+// validator 收集一次校验中不符合的地方
+type validator struct {
+	spec       *Spec
+	violations []Violation
+}
+
+func (v *validator) add(field, reason string) {
+	if len(v.violations) < maxViolations {
+		v.violations = append(v.violations, Violation{Field: field, Reason: reason})
+	}
+}
+
+func (v *validator) full() bool {
+	return len(v.violations) >= maxViolations
+}
+
+// param 校验 query/path/header 参数，values 为参数的原始值
+func (v *validator) param(p *Parameter, field string, values []string, present bool) {
+	if !present {
+		if p.Required {
+			v.add(field, "缺少必填参数")
+		}
+		return
+	}
+
+	schema := &Schema{
+		Type:      p.Type,
+		Items:     p.Items,
+		Enum:      p.Enum,
+		Minimum:   p.Minimum,
+		Maximum:   p.Maximum,
+		MinLength: p.MinLength,
+		MaxLength: p.MaxLength,
+	}
+	if p.Type != "array" {
+		if value, ok := v.parseParam(p.Type, values[0], field); ok {
+			v.value(schema, value, field)
+		}
+		return
+	}
+
+	// 数组参数：csv 格式为 a,b,c，multi 格式为 ?a=1&a=2
+	raw := values
+	if p.CollectionFormat != "multi" {
+		raw = strings.Split(values[0], ",")
+	}
+	itemType := ""
+	if p.Items != nil {
+		itemType = p.Items.Type
+	}
+	items := make([]any, 0, len(raw))
+	for i, s := range raw {
+		value, ok := v.parseParam(itemType, s, fmt.Sprintf("%s[%d]", field, i))
+		if !ok {
+			return
+		}
+		items = append(items, value)
+	}
+	v.value(schema, items, field)
+}
+
+// parseParam 按参数类型转换原始值，转换失败时记录不符合并返回 false
+func (v *validator) parseParam(typ, s, field string) (any, bool) {
+	switch typ {
+	case "integer":
+		if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+			v.add(field, "必须是整数")
+			return nil, false
+		}
+		return json.Number(s), true
+	case "number":
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			v.add(field, "必须是数字")
+			return nil, false
+		}
+		return json.Number(s), true
+	case "boolean":
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			v.add(field, "必须是布尔值")
+			return nil, false
+		}
+		return b, true
+	default:
+		return s, true
+	}
+}
+
+// body 校验 JSON 请求体
+func (v *validator) body(p *Parameter, req *Request) {
+	if len(bytes.TrimSpace(req.Body)) == 0 {
+		if p.Required {
+			v.add("body", "缺少请求体")
+		}
+		return
+	}
+	if !IsJSON(req.ContentType) {
+		v.add("body", fmt.Sprintf("内容类型必须是 JSON，实际为 %q", req.ContentType))
+		return
+	}
+	value, err := decodeJSON(req.Body)
+	if err != nil {
+		v.add("body", "不是有效的 JSON: "+err.Error())
+		return
+	}
+	v.value(p.Schema, value, "body")
+}
+
+// decodeJSON 解析 JSON，数字保留为 json.Number 以区分整数
+func decodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// value 按结构校验值，null 视为未设置（Go 的指针和切片字段可能序列化为 null），必填字段的 null 由上层对象校验
+func (v *validator) value(schema *Schema, value any, field string) {
+	if schema == nil || value == nil || v.full() {
+		return
+	}
+	schema, err := v.spec.resolve(schema)
+	if err != nil {
+		v.add(field, err.Error())
+		return
+	}
+	for _, sub := range schema.AllOf {
+		v.value(sub, value, field)
+	}
+	if !v.checkType(schema.Type, value, field) {
+		return
+	}
+	if len(schema.Enum) > 0 && !enumContains(schema.Enum, value) {
+		v.add(field, "必须是以下值之一: "+formatEnum(schema.Enum))
+		return
+	}
+
+	switch val := value.(type) {
+	case string:
+		n := utf8.RuneCountInString(val)
+		if schema.MinLength != nil && n < *schema.MinLength {
+			v.add(field, fmt.Sprintf("长度不能小于 %d", *schema.MinLength))
+		}
+		if schema.MaxLength != nil && n > *schema.MaxLength {
+			v.add(field, fmt.Sprintf("长度不能大于 %d", *schema.MaxLength))
+		}
+	case json.Number:
+		f, _ := val.Float64()
+		if schema.Minimum != nil && f < *schema.Minimum {
+			v.add(field, "不能小于 "+formatNumber(*schema.Minimum))
+		}
+		if schema.Maximum != nil && f > *schema.Maximum {
+			v.add(field, "不能大于 "+formatNumber(*schema.Maximum))
+		}
+	case []any:
+		if schema.MinItems != nil && len(val) < *schema.MinItems {
+			v.add(field, fmt.Sprintf("元素个数不能少于 %d", *schema.MinItems))
+		}
+		if schema.MaxItems != nil && len(val) > *schema.MaxItems {
+			v.add(field, fmt.Sprintf("元素个数不能多于 %d", *schema.MaxItems))
+		}
+		for i, item := range val {
+			v.value(schema.Items, item, fmt.Sprintf("%s[%d]", field, i))
+		}
+	case map[string]any:
+		for _, name := range schema.Required {
+			if val[name] == nil {
+				v.add(field+"."+name, "缺少必填字段")
+			}
+		}
+		for name, prop := range schema.Properties {
+			if item, ok := val[name]; ok {
+				v.value(prop, item, field+"."+name)
+			}
+		}
+	}
+}
+
+// typeNames 类型的中文名称
+var typeNames = map[string]string{
+	"object":  "对象",
+	"array":   "数组",
+	"string":  "字符串",
+	"integer": "整数",
+	"number":  "数字",
+	"boolean": "布尔值",
+}
+
+// checkType 校验值的类型，未声明类型时不校验
+func (v *validator) checkType(typ string, value any, field string) bool {
+	var ok bool
+	switch typ {
+	case "":
+		return true
+	case "object":
+		_, ok = value.(map[string]any)
+	case "array":
+		_, ok = value.([]any)
+	case "string":
+		_, ok = value.(string)
+	case "boolean":
+		_, ok = value.(bool)
+	case "number":
+		_, ok = value.(json.Number)
+	case "integer":
+		if n, isNumber := value.(json.Number); isNumber {
+			f, err := n.Float64()
+			ok = err == nil && f == math.Trunc(f)
+		}
+	default:
+		return true
+	}
+	if !ok {
+		v.add(field, "必须是"+typeNames[typ])
+	}
+	return ok
+}
+
+// enumContains 值是否在枚举中，数字按数值比较
+func enumContains(enum []any, value any) bool {
+	for _, e := range enum {
+		switch ev := e.(type) {
+		case string:
+			if s, ok := value.(string); ok && s == ev {
+				return true
+			}
+		case float64:
+			if n, ok := value.(json.Number); ok {
+				if f, err := n.Float64(); err == nil && f == ev {
+					return true
+				}
+			}
+		case bool:
+			if b, ok := value.(bool); ok && b == ev {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []any) string {
+	parts := make([]string, 0, len(enum))
+	for _, e := range enum {
+		if f, ok := e.(float64); ok {
+			parts = append(parts, formatNumber(f))
+			continue
+		}
+		parts = append(parts, fmt.Sprint(e))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatNumber(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
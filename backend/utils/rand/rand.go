@@ -3,6 +3,7 @@ package rand
 import (
 	"crypto/rand"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"time"
 )
@@ -51,11 +52,49 @@ func MustGenerateUIDWithPrefix(prefix string) string {
 }
 
 // GenTraceID 生成一个唯一的 trace_id
+// 格式与 W3C Trace Context 一致：32 位小写十六进制
 func GenTraceID() string {
-	return MustGenerateUIDWithPrefix("trace_")
+	return mustGenerateHex(16)
+}
+
+// GenRequestID 生成一个唯一的 request_id
+func GenRequestID() string {
+	return MustGenerateUIDWithPrefix("req_")
 }
 
 // GenSpanID 生成一个唯一的 span_id
+// 格式与 W3C Trace Context 一致：16 位小写十六进制
 func GenSpanID() string {
-	return MustGenerateUIDWithPrefix("span_")
+	return mustGenerateHex(8)
+}
+
+// mustGenerateHex 生成 n 字节的随机数并编码为十六进制，全零时重新生成（W3C 规定全零 ID 无效）
+func mustGenerateHex(n int) string {
+	b := make([]byte, n)
+	for {
+		if _, err := rand.Read(b); err != nil {
+			panic(fmt.Sprintf("生成随机数失败: %v", err))
+		}
+		for _, v := range b {
+			if v != 0 {
+				return hex.EncodeToString(b)
+			}
+		}
+	}
+}
+
+// codeAlphabet 随机码使用的字符，去掉了容易混淆的 0、O、1、I
+const codeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// GenerateCode 生成 n 位易于手动输入的随机码（大写字母和数字），用于绑定码等一次性验证码
+func GenerateCode(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	// 字符集长度为 32，取模不会产生偏差
+	for i := range b {
+		b[i] = codeAlphabet[int(b[i])%len(codeAlphabet)]
+	}
+	return string(b), nil
 }
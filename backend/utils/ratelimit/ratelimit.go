@@ -0,0 +1,188 @@
+// Package ratelimit 提供按档位配置的固定窗口限流器
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTier 默认档位，用户未设置档位或档位不存在时使用
+const DefaultTier = "default"
+
+// Rule 限流规则：每个窗口内最多 Limit 次请求
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+// String 返回规则的配置格式，如 "600/1m"
+func (r Rule) String() string {
+	return fmt.Sprintf("%d/%s", r.Limit, r.Window)
+}
+
+// ParseRule 解析 "次数/窗口" 格式的规则，如 "600/1m"、"5/1h"
+func ParseRule(s string) (Rule, error) {
+	limitStr, windowStr, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("规则格式错误: %q，应为 次数/窗口", s)
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+	if err != nil || limit <= 0 {
+		return Rule{}, fmt.Errorf("规则次数错误: %q", s)
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(windowStr))
+	if err != nil || window <= 0 {
+		return Rule{}, fmt.Errorf("规则窗口错误: %q", s)
+	}
+	return Rule{Limit: limit, Window: window}, nil
+}
+
+// Tiers 档位配置：档位 -> 接口类别 -> 规则
+type Tiers map[string]map[string]Rule
+
+// ParseTiers 解析档位配置
+// 格式: "档位:类别=规则,类别=规则;档位:..."，如
+// "default:general=600/1m,search=60/1m;api-heavy:general=6000/1m,search=600/1m"
+// 必须包含 default 档位
+func ParseTiers(spec string) (Tiers, error) {
+	tiers := make(Tiers)
+	for _, tierSpec := range strings.Split(spec, ";") {
+		tierSpec = strings.TrimSpace(tierSpec)
+		if tierSpec == "" {
+			continue
+		}
+		name, rulesSpec, ok := strings.Cut(tierSpec, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("档位格式错误: %q，应为 档位:类别=规则", tierSpec)
+		}
+		if _, exists := tiers[name]; exists {
+			return nil, fmt.Errorf("档位重复: %s", name)
+		}
+
+		rules := make(map[string]Rule)
+		for _, ruleSpec := range strings.Split(rulesSpec, ",") {
+			ruleSpec = strings.TrimSpace(ruleSpec)
+			if ruleSpec == "" {
+				continue
+			}
+			class, ruleStr, ok := strings.Cut(ruleSpec, "=")
+			class = strings.TrimSpace(class)
+			if !ok || class == "" {
+				return nil, fmt.Errorf("档位 %s 规则格式错误: %q，应为 类别=规则", name, ruleSpec)
+			}
+			rule, err := ParseRule(ruleStr)
+			if err != nil {
+				return nil, fmt.Errorf("档位 %s 类别 %s: %w", name, class, err)
+			}
+			rules[class] = rule
+		}
+		tiers[name] = rules
+	}
+
+	if _, ok := tiers[DefaultTier]; !ok {
+		return nil, fmt.Errorf("缺少 %s 档位", DefaultTier)
+	}
+	return tiers, nil
+}
+
+// Has 判断档位是否存在
+func (t Tiers) Has(tier string) bool {
+	_, ok := t[tier]
+	return ok
+}
+
+// Rule 返回档位下某类接口的规则
+// 档位不存在时使用默认档位，档位未配置该类别时使用默认档位的同类规则
+func (t Tiers) Rule(tier, class string) (Rule, bool) {
+	if rules, ok := t[tier]; ok {
+		if rule, ok := rules[class]; ok {
+			return rule, true
+		}
+	}
+	rule, ok := t[DefaultTier][class]
+	return rule, ok
+}
+
+// Result 单次限流判断结果
+type Result struct {
+	Allowed   bool      // 是否放行
+	Limit     int       // 窗口内允许的请求数
+	Remaining int       // 窗口内剩余的请求数
+	Reset     time.Time // 当前窗口结束时间
+}
+
+// RetryAfter 距离窗口重置的时间
+func (r Result) RetryAfter(now time.Time) time.Duration {
+	if d := r.Reset.Sub(now); d > 0 {
+		return d
+	}
+	return 0
+}
+
+type window struct {
+	start time.Time
+	end   time.Time
+	count int
+}
+
+// Limiter 内存固定窗口限流器，按 key 计数，仅在单实例内生效
+type Limiter struct {
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+// sweepInterval 清理过期窗口的间隔
+const sweepInterval = time.Minute
+
+// NewLimiter 创建限流器
+func NewLimiter() *Limiter {
+	return &Limiter{windows: make(map[string]*window)}
+}
+
+// Allow 在 key 对应的窗口内计数一次并判断是否放行，被拒绝的请求不计数
+func (l *Limiter) Allow(key string, rule Rule, now time.Time) Result {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	w, ok := l.windows[key]
+	if !ok || !now.Before(w.end) {
+		start := now.Truncate(rule.Window)
+		w = &window{start: start, end: start.Add(rule.Window)}
+		l.windows[key] = w
+	}
+
+	result := Result{Limit: rule.Limit, Reset: w.end}
+	if w.count >= rule.Limit {
+		return result
+	}
+	w.count++
+	result.Allowed = true
+	result.Remaining = rule.Limit - w.count
+	return result
+}
+
+// sweep 清理已过期的窗口，调用方需持有锁
+func (l *Limiter) sweep(now time.Time) {
+	for key, w := range l.windows {
+		if !now.Before(w.end) {
+			delete(l.windows, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Len 当前跟踪的窗口数量
+func (l *Limiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.windows)
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseRule 测试规则解析
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule(" 600/1m ")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if rule.Limit != 600 || rule.Window != time.Minute {
+		t.Fatalf("解析结果错误: %+v", rule)
+	}
+
+	for _, s := range []string{"", "600", "0/1m", "-1/1m", "abc/1m", "10/", "10/0s", "10/abc"} {
+		if _, err := ParseRule(s); err == nil {
+			t.Errorf("期望解析失败: %q", s)
+		}
+	}
+}
+
+// TestParseTiers 测试档位解析和规则回退
+func TestParseTiers(t *testing.T) {
+	tiers, err := ParseTiers("default:general=600/1m,search=60/1m,export=5/1h; api-heavy:general=6000/1m")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if !tiers.Has("api-heavy") || tiers.Has("unknown") {
+		t.Fatalf("档位判断错误: %+v", tiers)
+	}
+
+	cases := []struct {
+		tier, class string
+		want        Rule
+		ok          bool
+	}{
+		{"default", "search", Rule{60, time.Minute}, true},
+		{"api-heavy", "general", Rule{6000, time.Minute}, true},
+		{"api-heavy", "export", Rule{5, time.Hour}, true}, // 未配置的类别回退到默认档位
+		{"unknown", "general", Rule{600, time.Minute}, true},
+		{"", "export", Rule{5, time.Hour}, true},
+		{"default", "missing", Rule{}, false},
+	}
+	for _, c := range cases {
+		got, ok := tiers.Rule(c.tier, c.class)
+		if ok != c.ok || got != c.want {
+			t.Errorf("Rule(%q, %q) = %+v, %v，期望 %+v, %v", c.tier, c.class, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+// TestParseTiersInvalid 测试错误的档位配置
+func TestParseTiersInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"api-heavy:general=10/1m",
+		"default",
+		"default:general",
+		"default:general=10",
+		"default:general=10/1m;default:search=1/1m",
+		":general=10/1m",
+	} {
+		if _, err := ParseTiers(spec); err == nil {
+			t.Errorf("期望解析失败: %q", spec)
+		}
+	}
+}
+
+// TestLimiterAllow 测试窗口内计数、拒绝和窗口重置
+func TestLimiterAllow(t *testing.T) {
+	l := NewLimiter()
+	rule := Rule{Limit: 2, Window: time.Minute}
+	now := time.Date(2024, 1, 1, 10, 0, 30, 0, time.UTC)
+
+	r := l.Allow("u1", rule, now)
+	if !r.Allowed || r.Remaining != 1 || r.Limit != 2 {
+		t.Fatalf("第一次请求结果错误: %+v", r)
+	}
+	if want := time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC); !r.Reset.Equal(want) {
+		t.Fatalf("重置时间错误: %v，期望 %v", r.Reset, want)
+	}
+
+	r = l.Allow("u1", rule, now.Add(time.Second))
+	if !r.Allowed || r.Remaining != 0 {
+		t.Fatalf("第二次请求结果错误: %+v", r)
+	}
+
+	r = l.Allow("u1", rule, now.Add(2*time.Second))
+	if r.Allowed || r.Remaining != 0 {
+		t.Fatalf("超出限额应被拒绝: %+v", r)
+	}
+	if d := r.RetryAfter(now.Add(2 * time.Second)); d != 28*time.Second {
+		t.Fatalf("RetryAfter 错误: %v", d)
+	}
+
+	// 不同 key 互不影响
+	if r := l.Allow("u2", rule, now); !r.Allowed {
+		t.Fatalf("其他 key 不应被限流: %+v", r)
+	}
+
+	// 窗口结束后重新计数
+	r = l.Allow("u1", rule, now.Add(30*time.Second))
+	if !r.Allowed || r.Remaining != 1 {
+		t.Fatalf("新窗口应重新计数: %+v", r)
+	}
+}
+
+// TestLimiterSweep 测试过期窗口清理
+func TestLimiterSweep(t *testing.T) {
+	l := NewLimiter()
+	rule := Rule{Limit: 1, Window: time.Second}
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	l.Allow("a", rule, now)
+	l.Allow("b", rule, now)
+	if l.Len() != 2 {
+		t.Fatalf("窗口数量错误: %d", l.Len())
+	}
+
+	l.Allow("c", rule, now.Add(2*time.Minute))
+	if l.Len() != 1 {
+		t.Fatalf("过期窗口未清理: %d", l.Len())
+	}
+}
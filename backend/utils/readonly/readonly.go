@@ -0,0 +1,65 @@
+// Package readonly 维护进程级的只读模式开关，供数据层、中间件和健康检查共同读取
+package readonly
+
+import (
+	"errors"
+	"sync"
+)
+
+const (
+	// ModeReadOnly 只读模式
+	ModeReadOnly = "read_only"
+	// ModeReadWrite 读写模式
+	ModeReadWrite = "read_write"
+)
+
+// ErrReadOnly 只读模式下拒绝写操作
+var ErrReadOnly = errors.New("service is in read-only mode")
+
+var (
+	// enabled 是否处于只读模式
+	enabled bool
+	// reason 进入只读模式的原因
+	reason string
+	// mu 保护开关状态的读写锁
+	mu sync.RWMutex
+)
+
+// Enable 进入只读模式
+// r: 原因，例如 "backup"、"migration"，会在拒绝写操作时返回给调用方
+func Enable(r string) {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = true
+	reason = r
+}
+
+// Disable 退出只读模式
+func Disable() {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = false
+	reason = ""
+}
+
+// Enabled 是否处于只读模式
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return enabled
+}
+
+// Reason 返回进入只读模式的原因
+func Reason() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return reason
+}
+
+// Mode 返回当前模式: read_only, read_write
+func Mode() string {
+	if Enabled() {
+		return ModeReadOnly
+	}
+	return ModeReadWrite
+}
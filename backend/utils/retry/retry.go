@@ -0,0 +1,98 @@
+// Package retry 提供基于指数退避的重试工具
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Backoff 指数退避配置
+type Backoff struct {
+	InitialInterval time.Duration // 首次重试前的等待时间
+	MaxInterval     time.Duration // 单次等待时间上限
+	Multiplier      float64       // 每次重试后等待时间的增长倍数
+	MaxElapsed      time.Duration // 最长重试时间（从首次执行开始计算），0 表示不重试
+}
+
+// DefaultBackoff 返回默认的指数退避配置
+// 首次等待 500ms，每次翻倍，单次最多等待 5s，最长重试 30s
+func DefaultBackoff() Backoff {
+	return Backoff{
+		InitialInterval: 500 * time.Millisecond,
+		MaxInterval:     5 * time.Second,
+		Multiplier:      2,
+		MaxElapsed:      30 * time.Second,
+	}
+}
+
+// Next 根据当前等待时间计算下一次等待时间
+func (b Backoff) Next(current time.Duration) time.Duration {
+	if current <= 0 {
+		return b.InitialInterval
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	next := time.Duration(float64(current) * multiplier)
+	if b.MaxInterval > 0 && next > b.MaxInterval {
+		next = b.MaxInterval
+	}
+	return next
+}
+
+// permanentError 不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent 将错误标记为不可重试，Do 遇到该错误时立即返回原始错误
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent 判断错误（或其包装链中的错误）是否被标记为不可重试
+func IsPermanent(err error) bool {
+	var perm *permanentError
+	return errors.As(err, &perm)
+}
+
+// Do 执行 fn，失败时按指数退避重试
+// 直到 fn 成功、返回 Permanent 错误、超过 MaxElapsed 或 ctx 被取消时停止，返回最后一次的错误
+// attempt 从 1 开始计数
+func Do(ctx context.Context, b Backoff, fn func(ctx context.Context, attempt int) error) error {
+	start := time.Now()
+	var wait time.Duration
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx, attempt)
+		if err == nil {
+			return nil
+		}
+
+		var permanent *permanentError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		wait = b.Next(wait)
+		if b.MaxElapsed <= 0 || time.Since(start)+wait > b.MaxElapsed {
+			return err
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
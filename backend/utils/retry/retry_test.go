@@ -0,0 +1,99 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestDoSuccessAfterRetry 测试失败若干次后成功
+func TestDoSuccessAfterRetry(t *testing.T) {
+	b := Backoff{InitialInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond, Multiplier: 2, MaxElapsed: time.Second}
+
+	calls := 0
+	err := Do(context.Background(), b, func(ctx context.Context, attempt int) error {
+		calls++
+		if attempt < 3 {
+			return errors.New("not ready")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("期望成功，实际返回错误: %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("期望执行 3 次，实际执行 %d 次", calls)
+	}
+}
+
+// TestDoNoRetry 测试 MaxElapsed 为 0 时不重试
+func TestDoNoRetry(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Backoff{}, func(ctx context.Context, attempt int) error {
+		calls++
+		return errors.New("failed")
+	})
+	if err == nil || calls != 1 {
+		t.Fatalf("期望执行 1 次并返回错误，实际执行 %d 次, err=%v", calls, err)
+	}
+}
+
+// TestDoPermanent 测试不可重试错误立即返回原始错误
+func TestDoPermanent(t *testing.T) {
+	b := Backoff{InitialInterval: time.Millisecond, MaxElapsed: time.Second}
+	origin := errors.New("bad config")
+
+	calls := 0
+	err := Do(context.Background(), b, func(ctx context.Context, attempt int) error {
+		calls++
+		return Permanent(origin)
+	})
+	if !errors.Is(err, origin) || calls != 1 {
+		t.Fatalf("期望执行 1 次并返回原始错误，实际执行 %d 次, err=%v", calls, err)
+	}
+}
+
+// TestDoContextCanceled 测试 ctx 取消时停止重试
+func TestDoContextCanceled(t *testing.T) {
+	b := Backoff{InitialInterval: 50 * time.Millisecond, MaxElapsed: time.Minute}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Do(ctx, b, func(ctx context.Context, attempt int) error {
+		return errors.New("not ready")
+	})
+	if err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if time.Since(start) > 40*time.Millisecond {
+		t.Fatalf("ctx 取消后应立即返回，实际耗时 %v", time.Since(start))
+	}
+}
+
+// TestBackoffNext 测试等待时间的增长和上限
+func TestBackoffNext(t *testing.T) {
+	b := Backoff{InitialInterval: time.Second, MaxInterval: 3 * time.Second, Multiplier: 2}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	var wait time.Duration
+	for i, want := range expected {
+		wait = b.Next(wait)
+		if wait != want {
+			t.Fatalf("第 %d 次等待时间期望 %v，实际 %v", i+1, want, wait)
+		}
+	}
+}
+
+// TestIsPermanent 测试识别包装后的不可重试错误
+func TestIsPermanent(t *testing.T) {
+	base := errors.New("bad request")
+	if IsPermanent(base) {
+		t.Error("plain error should not be permanent")
+	}
+	if !IsPermanent(fmt.Errorf("wrapped: %w", Permanent(base))) {
+		t.Error("wrapped permanent error should be permanent")
+	}
+}
@@ -4,10 +4,56 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"runtime/pprof"
+	"sync/atomic"
 
 	"backend/utils/logs"
+	"backend/utils/metrics"
 )
 
+// MetricName safego 在 metrics 端点中的指标名称
+const MetricName = "safego"
+
+// PanicInfo panic 信息
+type PanicInfo struct {
+	Recovered interface{}       // recover() 的返回值
+	Err       error             // 转换后的错误
+	Stack     []byte            // panic 时的调用堆栈
+	Labels    map[string]string // ctx 中的 goroutine 标签（pprof.WithLabels / pprof.Do 设置）
+	Fields    map[string]string // ctx 中的追踪字段（trace_id、span_id、request_id）
+}
+
+// PanicHook panic 回调函数，可用于上报 Sentry 等错误追踪服务
+type PanicHook func(ctx context.Context, info PanicInfo)
+
+var (
+	// onPanic 全局 panic 回调函数
+	onPanic atomic.Pointer[PanicHook]
+	// panicCount 累计捕获的 panic 次数
+	panicCount atomic.Int64
+)
+
+func init() {
+	metrics.Register(MetricName, func() interface{} {
+		return map[string]int64{"panics": panicCount.Load()}
+	})
+}
+
+// OnPanic 设置全局 panic 回调函数，传入 nil 时取消回调
+// 回调函数在发生 panic 的 goroutine 中同步执行，耗时操作需要自行异步处理
+func OnPanic(hook PanicHook) {
+	if hook == nil {
+		onPanic.Store(nil)
+		return
+	}
+	onPanic.Store(&hook)
+}
+
+// PanicCount 返回累计捕获的 panic 次数
+func PanicCount() int64 {
+	return panicCount.Load()
+}
+
 func Recovery(ctx context.Context) {
 	e := recover()
 	if e == nil {
@@ -19,7 +65,19 @@ func Recovery(ctx context.Context) {
 	}
 
 	err := fmt.Errorf("%v", e)
-	logs.CtxErrorf(ctx, "[catch panic] err = %v \n stacktrace:\n%s", err, debug.Stack())
+	stack := debug.Stack()
+	panicCount.Add(1)
+	logs.CtxErrorf(ctx, "[catch panic] err = %v \n stacktrace:\n%s", err, stack)
+
+	if hook := onPanic.Load(); hook != nil {
+		callHook(ctx, *hook, PanicInfo{
+			Recovered: e,
+			Err:       err,
+			Stack:     stack,
+			Labels:    labels(ctx),
+			Fields:    traceFields(ctx),
+		})
+	}
 }
 
 func Go(ctx context.Context, fn func()) {
@@ -28,3 +86,38 @@ func Go(ctx context.Context, fn func()) {
 		fn()
 	}()
 }
+
+// callHook 执行 panic 回调函数，回调函数自身的 panic 只记录日志
+func callHook(ctx context.Context, hook PanicHook, info PanicInfo) {
+	defer func() {
+		if e := recover(); e != nil {
+			logs.CtxErrorf(ctx, "[catch panic] panic hook panic: %v", e)
+		}
+	}()
+	hook(ctx, info)
+}
+
+// labels 读取 ctx 中的 goroutine 标签
+func labels(ctx context.Context) map[string]string {
+	result := make(map[string]string)
+	pprof.ForLabels(ctx, func(key, value string) bool {
+		result[key] = value
+		return true
+	})
+	return result
+}
+
+// traceFields 读取 ctx 中的追踪字段
+func traceFields(ctx context.Context) map[string]string {
+	result := make(map[string]string)
+	for name, key := range map[string]interface{}{
+		"trace_id":   logs.TraceIDContextKey,
+		"span_id":    logs.SpanIDContextKey,
+		"request_id": logs.RequestIDContextKey,
+	} {
+		if value, ok := ctx.Value(key).(string); ok && value != "" {
+			result[name] = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+package safego
+
+import (
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"backend/utils/logs"
+)
+
+func TestOnPanic(t *testing.T) {
+	infos := make(chan PanicInfo, 1)
+	OnPanic(func(ctx context.Context, info PanicInfo) {
+		infos <- info
+	})
+	defer OnPanic(nil)
+
+	before := PanicCount()
+	ctx := context.WithValue(context.Background(), logs.TraceIDContextKey, "trace_1")
+	ctx = pprof.WithLabels(ctx, pprof.Labels("job", "reindex"))
+	Go(ctx, func() {
+		panic("boom")
+	})
+
+	info := <-infos
+	if info.Recovered != "boom" || info.Err == nil || len(info.Stack) == 0 {
+		t.Errorf("unexpected panic info: %+v", info)
+	}
+	if info.Labels["job"] != "reindex" {
+		t.Errorf("expected goroutine label job=reindex, got %v", info.Labels)
+	}
+	if info.Fields["trace_id"] != "trace_1" {
+		t.Errorf("expected trace_id field, got %v", info.Fields)
+	}
+	if PanicCount()-before != 1 {
+		t.Errorf("expected panic count to increase by 1, got %d", PanicCount()-before)
+	}
+}
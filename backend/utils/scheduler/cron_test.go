@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	loc := time.UTC
+	base := time.Date(2026, 10, 15, 10, 30, 20, 0, loc) // 周四
+
+	cases := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 15, 10, 31, 0, 0, loc)},
+		{"30 3 * * *", time.Date(2026, 10, 16, 3, 30, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2026, 10, 15, 10, 45, 0, 0, loc)},
+		{"0 9-17/4 * * *", time.Date(2026, 10, 15, 13, 0, 0, 0, loc)},
+		{"0 0 1 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, loc)},
+		{"0 0 * * 0", time.Date(2026, 10, 18, 0, 0, 0, 0, loc)},
+		{"0 0 * * 7", time.Date(2026, 10, 18, 0, 0, 0, 0, loc)},
+		{"0 0 * * 1,5", time.Date(2026, 10, 16, 0, 0, 0, 0, loc)},
+		// 日期和星期都指定时满足任意一个即可
+		{"0 0 20 * 6", time.Date(2026, 10, 17, 0, 0, 0, 0, loc)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, loc)},
+		{"@daily", time.Date(2026, 10, 16, 0, 0, 0, 0, loc)},
+		{"@hourly", time.Date(2026, 10, 15, 11, 0, 0, 0, loc)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, loc)},
+		{"@every 90m", time.Date(2026, 10, 15, 12, 0, 20, 0, loc)},
+	}
+	for _, tc := range cases {
+		schedule, err := Parse(tc.spec, loc)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tc.spec, err)
+			continue
+		}
+		if got := schedule.Next(base); !got.Equal(tc.want) {
+			t.Errorf("Parse(%q).Next = %s, want %s", tc.spec, got, tc.want)
+		}
+	}
+
+	// 永远不会匹配的表达式返回零值
+	schedule, err := Parse("0 0 30 2 *", loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := schedule.Next(base); !got.IsZero() {
+		t.Errorf("expected zero time, got %s", got)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 10ms",
+		"@every abc",
+	} {
+		if _, err := Parse(spec, time.UTC); err == nil {
+			t.Errorf("Parse(%q): expected error", spec)
+		}
+	}
+}
+
+func TestParseSpecs(t *testing.T) {
+	specs, err := ParseSpecs(" db_backup = 0 3 * * 1,5 ; file_gc=@daily;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 2 || specs["db_backup"] != "0 3 * * 1,5" || specs["file_gc"] != "@daily" {
+		t.Errorf("unexpected specs: %v", specs)
+	}
+	if _, err := ParseSpecs("db_backup"); err == nil {
+		t.Error("expected error for missing spec")
+	}
+}
@@ -0,0 +1,478 @@
+// Package scheduler 提供了一个轻量的定时任务调度器，支持 cron 表达式、防重叠执行、手动触发和执行状态持久化
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/safego"
+)
+
+const (
+	// MetricName 任务状态在 metrics 端点中的指标名称
+	MetricName = "scheduler"
+	// RunsMetricName 任务执行次数计数器名称（标签: job、result）
+	RunsMetricName = "scheduler_job_runs_total"
+
+	// TriggerSchedule 按调度计划触发
+	TriggerSchedule = "schedule"
+	// TriggerManual 手动触发
+	TriggerManual = "manual"
+
+	// SpecOff 关闭定时执行的调度表达式，任务仍可手动触发
+	SpecOff = "off"
+
+	// saveTimeout 保存执行状态的超时时间
+	saveTimeout = 5 * time.Second
+)
+
+var (
+	// ErrJobNotFound 任务不存在
+	ErrJobNotFound = errors.New("任务不存在")
+	// ErrJobRunning 任务正在执行
+	ErrJobRunning = errors.New("任务正在执行")
+	// ErrJobExists 任务名称重复
+	ErrJobExists = errors.New("任务已注册")
+	// ErrStopped 调度器已停止
+	ErrStopped = errors.New("调度器已停止")
+)
+
+// runsCounter 任务执行次数（result: success、failure、skipped）
+var runsCounter = metrics.NewCounterVec(RunsMetricName, "定时任务执行次数", "job", "result")
+
+// Job 定时任务
+type Job struct {
+	// Name 任务名称，唯一
+	Name string
+	// Description 任务说明
+	Description string
+	// Spec 默认调度表达式（格式见 Parse），可被配置或数据库覆盖；为空或 off 时只能手动触发
+	Spec string
+	// Timeout 单次执行超时时间，0 表示不限制
+	Timeout time.Duration
+	// RunOnStart 调度器启动时是否立即执行一次
+	RunOnStart bool
+	// Run 任务函数，ctx 在调度器停止或超时时取消
+	Run func(ctx context.Context) error
+}
+
+// JobStatus 任务执行状态
+type JobStatus struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	Spec           string    `json:"spec"`
+	Running        bool      `json:"running"`
+	NextRunAt      time.Time `json:"next_run_at,omitzero"`
+	LastRunAt      time.Time `json:"last_run_at,omitzero"`
+	LastDurationMs int64     `json:"last_duration_ms"`
+	LastTrigger    string    `json:"last_trigger,omitempty"`
+	LastError      string    `json:"last_error,omitempty"`
+	Runs           int64     `json:"runs"`
+	Failures       int64     `json:"failures"`
+	Skipped        int64     `json:"skipped"` // 因上一次执行未结束而跳过的次数
+}
+
+// Store 任务状态存储，用于持久化最近一次执行结果和读取数据库中配置的调度表达式
+type Store interface {
+	// LoadStatus 读取任务状态，不存在时返回 nil, nil
+	LoadStatus(ctx context.Context, name string) (*JobStatus, error)
+	// SaveStatus 保存任务状态
+	SaveStatus(ctx context.Context, status JobStatus) error
+	// LoadSpec 读取数据库中配置的调度表达式，未配置时返回空字符串
+	LoadSpec(ctx context.Context, name string) (string, error)
+}
+
+// Option 调度器配置选项
+type Option func(*Scheduler)
+
+// WithStore 设置任务状态存储
+func WithStore(store Store) Option {
+	return func(s *Scheduler) {
+		s.store = store
+	}
+}
+
+// WithSpecs 设置配置中的调度表达式（key: 任务名称），优先级高于任务默认值，低于 Store 中的配置
+func WithSpecs(specs map[string]string) Option {
+	return func(s *Scheduler) {
+		for name, spec := range specs {
+			s.specs[name] = spec
+		}
+	}
+}
+
+// WithLocation 设置 cron 表达式使用的时区，默认为 time.Local
+func WithLocation(loc *time.Location) Option {
+	return func(s *Scheduler) {
+		s.loc = loc
+	}
+}
+
+// entry 已注册的任务
+type entry struct {
+	job      Job
+	spec     string
+	schedule Schedule // 为 nil 时只能手动触发
+
+	mu      sync.Mutex
+	running bool
+	status  JobStatus
+}
+
+// Scheduler 定时任务调度器
+type Scheduler struct {
+	store Store
+	specs map[string]string
+	loc   *time.Location
+
+	mu      sync.RWMutex
+	entries map[string]*entry
+	started bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New 创建调度器
+func New(opts ...Option) *Scheduler {
+	s := &Scheduler{
+		specs:   make(map[string]string),
+		loc:     time.Local,
+		entries: make(map[string]*entry),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	return s
+}
+
+// Register 注册任务，调度器已启动时立即开始调度
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("任务名称和任务函数不能为空")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[job.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrJobExists, job.Name)
+	}
+
+	e := &entry{job: job, status: JobStatus{Name: job.Name, Description: job.Description}}
+	if err := s.resolveSchedule(e, s.started); err != nil {
+		return err
+	}
+	s.entries[job.Name] = e
+
+	if s.started {
+		s.startEntry(e)
+	}
+	return nil
+}
+
+// Start 启动调度器，从 Store 恢复任务状态并开始调度
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return nil
+	}
+
+	for _, e := range s.entries {
+		// 数据库中的调度表达式在启动时读取
+		if err := s.resolveSchedule(e, true); err != nil {
+			return err
+		}
+	}
+	for _, e := range s.entries {
+		s.startEntry(e)
+	}
+	s.started = true
+
+	metrics.Register(MetricName, func() interface{} {
+		return s.Statuses()
+	})
+	logs.Info("定时任务调度器已启动", "jobs", len(s.entries))
+	return nil
+}
+
+// Stop 停止调度器，取消正在执行的任务并等待其结束（最多等待到 ctx 结束）
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.cancel()
+	metrics.Unregister(MetricName)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Trigger 手动触发任务，任务在后台执行
+// 任务不存在时返回 ErrJobNotFound，任务正在执行时返回 ErrJobRunning
+func (s *Scheduler) Trigger(name string) error {
+	if s.ctx.Err() != nil {
+		return ErrStopped
+	}
+	s.mu.RLock()
+	e, ok := s.entries[name]
+	s.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	if !e.tryStart() {
+		return fmt.Errorf("%w: %s", ErrJobRunning, name)
+	}
+
+	s.wg.Add(1)
+	safego.Go(s.ctx, func() {
+		defer s.wg.Done()
+		s.execute(e, TriggerManual)
+	})
+	return nil
+}
+
+// Status 返回任务状态
+func (s *Scheduler) Status(name string) (JobStatus, bool) {
+	s.mu.RLock()
+	e, ok := s.entries[name]
+	s.mu.RUnlock()
+	if !ok {
+		return JobStatus{}, false
+	}
+	return e.snapshot(), true
+}
+
+// Statuses 返回所有任务的状态（按名称排序）
+func (s *Scheduler) Statuses() []JobStatus {
+	s.mu.RLock()
+	list := make([]JobStatus, 0, len(s.entries))
+	for _, e := range s.entries {
+		list = append(list, e.snapshot())
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// resolveSchedule 按 Store > 配置 > 任务默认值的优先级确定调度表达式
+// useStore 为 false 时不读取 Store（调度器启动前数据库可能尚未就绪）
+func (s *Scheduler) resolveSchedule(e *entry, useStore bool) error {
+	spec := e.job.Spec
+	if configured, ok := s.specs[e.job.Name]; ok {
+		spec = configured
+	}
+	if s.store != nil && useStore {
+		ctx, cancel := context.WithTimeout(s.ctx, saveTimeout)
+		stored, err := s.store.LoadSpec(ctx, e.job.Name)
+		cancel()
+		if err != nil {
+			logs.Warn("读取任务调度配置失败，使用默认配置", "job", e.job.Name, "error", err.Error())
+		} else if stored != "" {
+			spec = stored
+		}
+	}
+	spec = strings.TrimSpace(spec)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spec = spec
+	e.schedule = nil
+	if spec != "" && spec != SpecOff {
+		schedule, err := Parse(spec, s.loc)
+		if err != nil {
+			return fmt.Errorf("任务 %s 的调度表达式无效: %w", e.job.Name, err)
+		}
+		e.schedule = schedule
+	}
+	e.status.Spec = spec
+	return nil
+}
+
+// startEntry 恢复任务状态并启动调度循环，调用方需持有 s.mu
+func (s *Scheduler) startEntry(e *entry) {
+	s.restoreStatus(e)
+
+	if e.job.RunOnStart && e.tryStart() {
+		s.wg.Add(1)
+		safego.Go(s.ctx, func() {
+			defer s.wg.Done()
+			s.execute(e, TriggerSchedule)
+		})
+	}
+	if e.schedule == nil {
+		return
+	}
+
+	s.wg.Add(1)
+	safego.Go(s.ctx, func() {
+		defer s.wg.Done()
+		s.loop(e)
+	})
+}
+
+// loop 按调度计划循环执行任务
+func (s *Scheduler) loop(e *entry) {
+	for {
+		next := e.schedule.Next(time.Now())
+		if next.IsZero() {
+			logs.Warn("任务没有下一次执行时间，停止调度", "job", e.job.Name, "spec", e.spec)
+			return
+		}
+		e.setNextRun(next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// 上一次执行未结束时跳过本次，避免重叠执行
+		if !e.tryStart() {
+			e.mu.Lock()
+			e.status.Skipped++
+			e.mu.Unlock()
+			runsCounter.Inc(e.job.Name, "skipped")
+			logs.Warn("任务上一次执行未结束，跳过本次执行", "job", e.job.Name)
+			continue
+		}
+		s.execute(e, TriggerSchedule)
+	}
+}
+
+// execute 执行任务并记录状态，调用前需要通过 tryStart 获取执行权
+func (s *Scheduler) execute(e *entry, trigger string) {
+	ctx := s.ctx
+	if e.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.job.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	logs.Info("开始执行任务", "job", e.job.Name, "trigger", trigger)
+	err := runJob(ctx, e.job)
+	duration := time.Since(start)
+
+	e.mu.Lock()
+	e.running = false
+	e.status.Running = false
+	e.status.Runs++
+	e.status.LastRunAt = start
+	e.status.LastDurationMs = duration.Milliseconds()
+	e.status.LastTrigger = trigger
+	e.status.LastError = ""
+	if err != nil {
+		e.status.Failures++
+		e.status.LastError = err.Error()
+	}
+	status := e.status
+	e.mu.Unlock()
+
+	if err != nil {
+		runsCounter.Inc(e.job.Name, "failure")
+		logs.Error("任务执行失败", "job", e.job.Name, "trigger", trigger, "duration_ms", duration.Milliseconds(), "error", err.Error())
+	} else {
+		runsCounter.Inc(e.job.Name, "success")
+		logs.Info("任务执行完成", "job", e.job.Name, "trigger", trigger, "duration_ms", duration.Milliseconds())
+	}
+	s.saveStatus(status)
+}
+
+// runJob 执行任务函数，panic 转换为错误
+func runJob(ctx context.Context, job Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logs.CtxErrorf(ctx, "[Scheduler] job panic recover: job=%s, panic=%v\nstacktrace:\n%s", job.Name, r, debug.Stack())
+			err = fmt.Errorf("任务 panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// restoreStatus 从 Store 恢复任务的历史执行状态
+func (s *Scheduler) restoreStatus(e *entry) {
+	if s.store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+
+	saved, err := s.store.LoadStatus(ctx, e.job.Name)
+	if err != nil {
+		logs.Warn("读取任务状态失败", "job", e.job.Name, "error", err.Error())
+		return
+	}
+	if saved == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status.LastRunAt = saved.LastRunAt
+	e.status.LastDurationMs = saved.LastDurationMs
+	e.status.LastTrigger = saved.LastTrigger
+	e.status.LastError = saved.LastError
+	e.status.Runs = saved.Runs
+	e.status.Failures = saved.Failures
+	e.status.Skipped = saved.Skipped
+}
+
+// saveStatus 持久化任务状态，失败时只记录日志
+func (s *Scheduler) saveStatus(status JobStatus) {
+	if s.store == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+	if err := s.store.SaveStatus(ctx, status); err != nil {
+		logs.Warn("保存任务状态失败", "job", status.Name, "error", err.Error())
+	}
+}
+
+// tryStart 获取任务执行权，任务正在执行时返回 false
+func (e *entry) tryStart() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.running {
+		return false
+	}
+	e.running = true
+	e.status.Running = true
+	return true
+}
+
+// setNextRun 记录下一次执行时间
+func (e *entry) setNextRun(next time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.status.NextRunAt = next
+}
+
+// snapshot 返回任务状态快照
+func (e *entry) snapshot() JobStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.status
+}
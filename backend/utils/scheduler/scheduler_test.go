@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore 内存实现的 Store
+type memoryStore struct {
+	mu       sync.Mutex
+	statuses map[string]JobStatus
+	specs    map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{statuses: make(map[string]JobStatus), specs: make(map[string]string)}
+}
+
+func (m *memoryStore) LoadStatus(ctx context.Context, name string) (*JobStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	status, ok := m.statuses[name]
+	if !ok {
+		return nil, nil
+	}
+	return &status, nil
+}
+
+func (m *memoryStore) SaveStatus(ctx context.Context, status JobStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[status.Name] = status
+	return nil
+}
+
+func (m *memoryStore) LoadSpec(ctx context.Context, name string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.specs[name], nil
+}
+
+// waitFor 等待条件成立
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	store := newMemoryStore()
+	store.statuses["cleanup"] = JobStatus{Name: "cleanup", Runs: 5}
+	s := New(WithStore(store))
+
+	release := make(chan struct{})
+	calls := 0
+	if err := s.Register(Job{Name: "cleanup", Run: func(ctx context.Context) error {
+		calls++
+		<-release
+		return errors.New("boom")
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Job{Name: "cleanup", Run: func(context.Context) error { return nil }}); !errors.Is(err, ErrJobExists) {
+		t.Errorf("expected ErrJobExists, got %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop(context.Background())
+
+	if err := s.Trigger("missing"); !errors.Is(err, ErrJobNotFound) {
+		t.Errorf("expected ErrJobNotFound, got %v", err)
+	}
+	if err := s.Trigger("cleanup"); err != nil {
+		t.Fatal(err)
+	}
+	// 正在执行时不能重复触发
+	if err := s.Trigger("cleanup"); !errors.Is(err, ErrJobRunning) {
+		t.Errorf("expected ErrJobRunning, got %v", err)
+	}
+	close(release)
+
+	waitFor(t, func() bool {
+		status, _ := s.Status("cleanup")
+		return !status.Running && status.Runs == 6
+	})
+	status, _ := s.Status("cleanup")
+	if status.Failures != 1 || status.LastError != "boom" || status.LastTrigger != TriggerManual {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+	waitFor(t, func() bool {
+		saved, _ := store.LoadStatus(context.Background(), "cleanup")
+		return saved != nil && saved.Runs == 6
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	store := newMemoryStore()
+	// 数据库中的配置优先于配置和默认值
+	store.specs["tick"] = "@every 1s"
+	s := New(WithStore(store), WithSpecs(map[string]string{"tick": "@daily", "manual": SpecOff}))
+
+	var mu sync.Mutex
+	runs := 0
+	if err := s.Register(Job{Name: "tick", Spec: "@hourly", Run: func(ctx context.Context) error {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		return nil
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Register(Job{Name: "manual", Spec: "@hourly", RunOnStart: true, Run: func(ctx context.Context) error {
+		panic("boom")
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return runs >= 1
+	})
+	// panic 转换为任务错误
+	waitFor(t, func() bool {
+		status, _ := s.Status("manual")
+		return status.Runs == 1 && status.LastError != ""
+	})
+
+	statuses := s.Statuses()
+	if len(statuses) != 2 || statuses[0].Name != "manual" || statuses[0].Spec != SpecOff || statuses[1].Spec != "@every 1s" {
+		t.Errorf("unexpected statuses: %+v", statuses)
+	}
+	if statuses[1].NextRunAt.IsZero() {
+		t.Error("expected next run time")
+	}
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Trigger("tick"); !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped, got %v", err)
+	}
+}
+
+func TestRegisterInvalidSpec(t *testing.T) {
+	s := New()
+	err := s.Register(Job{Name: "bad", Spec: "61 * * * *", Run: func(context.Context) error { return nil }})
+	if err == nil {
+		t.Error("expected error for invalid spec")
+	}
+}
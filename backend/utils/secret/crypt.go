@@ -0,0 +1,231 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// tokenVersion 密文格式版本
+	// 密文格式: s1:<key_id>:<base64url(nonce + ciphertext)>
+	tokenVersion = "s1"
+
+	// minKeyLength 密钥材料的最小长度
+	minKeyLength = 16
+
+	// keyDerivationInfo HKDF 派生 AES 密钥时使用的 info，修改后已有密文无法解密
+	keyDerivationInfo = "peano/secret/aes-256-gcm"
+
+	// DefaultKeyID 只配置一个密钥（不带 ID）时使用的密钥 ID
+	DefaultKeyID = "default"
+)
+
+var (
+	// ErrInvalidCiphertext 密文格式错误或被篡改
+	ErrInvalidCiphertext = errors.New("密文格式错误或已被篡改")
+	// ErrUnknownKey 密文使用的密钥不在密钥环中
+	ErrUnknownKey = errors.New("密文使用的密钥不存在")
+	// ErrEmptyPurpose 未指定加密用途
+	ErrEmptyPurpose = errors.New("加密用途不能为空")
+
+	// keyIDPattern 密钥 ID 只允许字母、数字、下划线和短横线
+	keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+)
+
+// Keyring 对称加密密钥环（AES-256-GCM）
+// 使用当前密钥加密，按密文中的密钥 ID 选择密钥解密，轮换密钥时保留旧密钥即可解密历史数据
+//
+// 每次加密都需要指定用途（如 "db.column"、"share_link"），用途作为附加数据参与认证，
+// 一种用途的密文不能被当作另一种用途解密
+type Keyring struct {
+	currentID string
+	ciphers   map[string]cipher.AEAD
+}
+
+// NewKeyring 创建密钥环
+// currentID: 加密使用的密钥 ID，必须在 keys 中
+// keys: 密钥 ID 到密钥材料的映射，密钥材料至少 16 字节，通过 HKDF-SHA256 派生 AES-256 密钥
+func NewKeyring(currentID string, keys map[string][]byte) (*Keyring, error) {
+	if _, ok := keys[currentID]; !ok {
+		return nil, fmt.Errorf("当前密钥 %q 不存在", currentID)
+	}
+
+	k := &Keyring{currentID: currentID, ciphers: make(map[string]cipher.AEAD, len(keys))}
+	for id, material := range keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("密钥 ID %q 无效，只允许字母、数字、下划线和短横线", id)
+		}
+		if len(material) < minKeyLength {
+			return nil, fmt.Errorf("密钥 %q 长度不足 %d 字节", id, minKeyLength)
+		}
+		aead, err := newAEAD(material)
+		if err != nil {
+			return nil, fmt.Errorf("创建密钥 %q 的加密器失败: %w", id, err)
+		}
+		k.ciphers[id] = aead
+	}
+	return k, nil
+}
+
+// ParseKeyring 从配置字符串创建密钥环，格式见 ParseKeys
+func ParseKeyring(value string) (*Keyring, error) {
+	currentID, keys, err := ParseKeys(value)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeyring(currentID, keys)
+}
+
+// ParseKeys 解析密钥配置字符串，返回当前密钥 ID 和全部密钥
+// 支持两种格式：
+//   - 单个密钥: base64 编码的密钥（密钥 ID 为 default）
+//   - 多个密钥: id1:base64key1,id2:base64key2，第一个为当前密钥，其余用于解密历史数据
+//
+// 无法按 base64 解码时按原始字符串处理
+func ParseKeys(value string) (string, map[string][]byte, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil, errors.New("密钥不能为空")
+	}
+
+	keys := make(map[string][]byte)
+	var currentID string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		id, material, found := strings.Cut(entry, ":")
+		if !found {
+			id, material = DefaultKeyID, entry
+		}
+		if _, exists := keys[id]; exists {
+			return "", nil, fmt.Errorf("密钥 ID %q 重复", id)
+		}
+		keys[id] = DecodeKey(material)
+		if currentID == "" {
+			currentID = id
+		}
+	}
+	return currentID, keys, nil
+}
+
+// DecodeKey 解码密钥，优先按 base64 解码，解码失败时按原始字符串处理
+func DecodeKey(value string) []byte {
+	if key, err := base64.StdEncoding.DecodeString(value); err == nil {
+		return key
+	}
+	return []byte(value)
+}
+
+// CurrentKeyID 返回加密使用的密钥 ID
+func (k *Keyring) CurrentKeyID() string {
+	return k.currentID
+}
+
+// Encrypt 使用当前密钥加密
+// purpose 为加密用途，解密时必须使用相同的用途
+func (k *Keyring) Encrypt(plaintext []byte, purpose string) (string, error) {
+	if purpose == "" {
+		return "", ErrEmptyPurpose
+	}
+	aead := k.ciphers[k.currentID]
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData(k.currentID, purpose))
+	return tokenVersion + ":" + k.currentID + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt 解密 Encrypt 生成的密文
+func (k *Keyring) Decrypt(token string, purpose string) ([]byte, error) {
+	if purpose == "" {
+		return nil, ErrEmptyPurpose
+	}
+
+	keyID, data, ok := parseToken(token)
+	if !ok {
+		return nil, ErrInvalidCiphertext
+	}
+	aead, ok := k.ciphers[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(data)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+
+	nonceSize := aead.NonceSize()
+	plaintext, err := aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], additionalData(keyID, purpose))
+	if err != nil {
+		return nil, ErrInvalidCiphertext
+	}
+	return plaintext, nil
+}
+
+// EncryptString 加密字符串
+func (k *Keyring) EncryptString(plaintext string, purpose string) (string, error) {
+	return k.Encrypt([]byte(plaintext), purpose)
+}
+
+// DecryptString 解密为字符串
+func (k *Keyring) DecryptString(token string, purpose string) (string, error) {
+	plaintext, err := k.Decrypt(token, purpose)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation 判断密文是否使用了非当前密钥，可用于后台任务重新加密历史数据
+func (k *Keyring) NeedsRotation(token string) bool {
+	keyID, _, ok := parseToken(token)
+	return ok && keyID != k.currentID
+}
+
+// IsCiphertext 判断字符串是否是 Encrypt 生成的密文格式（不校验内容）
+func IsCiphertext(token string) bool {
+	_, _, ok := parseToken(token)
+	return ok
+}
+
+// parseToken 解析密文，返回密钥 ID 和 base64 数据
+func parseToken(token string) (keyID, data string, ok bool) {
+	version, rest, found := strings.Cut(token, ":")
+	if !found || version != tokenVersion {
+		return "", "", false
+	}
+	keyID, data, found = strings.Cut(rest, ":")
+	if !found || !keyIDPattern.MatchString(keyID) || data == "" {
+		return "", "", false
+	}
+	return keyID, data, true
+}
+
+// additionalData 附加认证数据，绑定密文格式版本、密钥 ID 和用途
+func additionalData(keyID, purpose string) []byte {
+	return []byte(tokenVersion + ":" + keyID + ":" + purpose)
+}
+
+// newAEAD 通过 HKDF 从密钥材料派生 AES-256 密钥并创建 AES-GCM 加密器
+func newAEAD(material []byte) (cipher.AEAD, error) {
+	key, err := hkdf.Key(sha256.New, material, nil, keyDerivationInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
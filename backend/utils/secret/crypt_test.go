@@ -0,0 +1,122 @@
+package secret_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"backend/utils/secret"
+)
+
+func TestKeyring(t *testing.T) {
+	oldKey := []byte("0123456789abcdef-old")
+	newKey := []byte("0123456789abcdef-new")
+
+	t.Run("加密后可以解密", func(t *testing.T) {
+		k, err := secret.NewKeyring("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+
+		token, err := k.EncryptString("hello", "db.column")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(token, "s1:k1:"))
+		assert.True(t, secret.IsCiphertext(token))
+
+		plaintext, err := k.DecryptString(token, "db.column")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", plaintext)
+
+		// 空字符串也可以加密
+		token, err = k.EncryptString("", "db.column")
+		require.NoError(t, err)
+		plaintext, err = k.DecryptString(token, "db.column")
+		require.NoError(t, err)
+		assert.Equal(t, "", plaintext)
+	})
+
+	t.Run("相同明文每次生成不同密文", func(t *testing.T) {
+		k, err := secret.NewKeyring("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+		token1, _ := k.EncryptString("hello", "db.column")
+		token2, _ := k.EncryptString("hello", "db.column")
+		assert.NotEqual(t, token1, token2)
+	})
+
+	t.Run("用途不同无法解密", func(t *testing.T) {
+		k, err := secret.NewKeyring("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+		token, err := k.EncryptString("hello", "share_link")
+		require.NoError(t, err)
+
+		_, err = k.DecryptString(token, "db.column")
+		assert.ErrorIs(t, err, secret.ErrInvalidCiphertext)
+
+		_, err = k.EncryptString("hello", "")
+		assert.ErrorIs(t, err, secret.ErrEmptyPurpose)
+	})
+
+	t.Run("篡改密文无法解密", func(t *testing.T) {
+		k, err := secret.NewKeyring("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+		token, err := k.EncryptString("hello", "db.column")
+		require.NoError(t, err)
+
+		tampered := token[:len(token)-2] + "AA"
+		if tampered == token {
+			tampered = token[:len(token)-2] + "BB"
+		}
+		_, err = k.DecryptString(tampered, "db.column")
+		assert.ErrorIs(t, err, secret.ErrInvalidCiphertext)
+
+		_, err = k.DecryptString("not-a-token", "db.column")
+		assert.ErrorIs(t, err, secret.ErrInvalidCiphertext)
+	})
+
+	t.Run("轮换密钥后可以解密历史密文", func(t *testing.T) {
+		before, err := secret.NewKeyring("k1", map[string][]byte{"k1": oldKey})
+		require.NoError(t, err)
+		oldToken, err := before.EncryptString("hello", "db.column")
+		require.NoError(t, err)
+
+		after, err := secret.NewKeyring("k2", map[string][]byte{"k1": oldKey, "k2": newKey})
+		require.NoError(t, err)
+		plaintext, err := after.DecryptString(oldToken, "db.column")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", plaintext)
+		assert.True(t, after.NeedsRotation(oldToken))
+
+		newToken, err := after.EncryptString("hello", "db.column")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(newToken, "s1:k2:"))
+		assert.False(t, after.NeedsRotation(newToken))
+
+		// 移除旧密钥后无法解密
+		_, err = before.DecryptString(newToken, "db.column")
+		assert.ErrorIs(t, err, secret.ErrUnknownKey)
+	})
+
+	t.Run("密钥校验", func(t *testing.T) {
+		_, err := secret.NewKeyring("k1", map[string][]byte{"k1": []byte("short")})
+		assert.Error(t, err)
+		_, err = secret.NewKeyring("k2", map[string][]byte{"k1": oldKey})
+		assert.Error(t, err)
+		_, err = secret.NewKeyring("bad:id", map[string][]byte{"bad:id": oldKey})
+		assert.Error(t, err)
+	})
+}
+
+func TestParseKeyring(t *testing.T) {
+	k, err := secret.ParseKeyring("MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	require.NoError(t, err)
+	assert.Equal(t, secret.DefaultKeyID, k.CurrentKeyID())
+
+	k, err = secret.ParseKeyring("k2:0123456789abcdef-new, k1:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	require.NoError(t, err)
+	assert.Equal(t, "k2", k.CurrentKeyID())
+
+	_, err = secret.ParseKeyring("k1:0123456789abcdef,k1:0123456789abcdef")
+	assert.Error(t, err)
+	_, err = secret.ParseKeyring("")
+	assert.Error(t, err)
+}
@@ -16,7 +16,12 @@ type TokenConfig struct {
 
 // Claims JWT声明
 type Claims struct {
-	UserID uint `json:"user_id"`
+	UserID   uint   `json:"user_id"`
+	TenantID string `json:"tenant_id,omitempty"` // 租户ID，未启用多租户时为空
+	// Purpose 一次性操作令牌的用途（如邮箱验证、重置密码），访问令牌和刷新令牌为空
+	Purpose string `json:"purpose,omitempty"`
+	// Fingerprint 操作令牌绑定的数据指纹，数据变化后令牌失效（如重置密码后旧的重置令牌不能再用）
+	Fingerprint string `json:"fp,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -32,11 +37,31 @@ func NewJWT(tokenConfig TokenConfig) *JWT {
 
 // GenerateAccessToken 生成访问令牌
 func (j *JWT) GenerateAccessToken(userID uint) (string, int64, error) {
-	expireTime := time.Now().Add(j.tokenConfig.AccessTokenExpire)
-	expireUnix := expireTime.Unix()
+	return j.GenerateAccessTokenForTenant(userID, "")
+}
+
+// GenerateAccessTokenForTenant 生成绑定租户的访问令牌
+func (j *JWT) GenerateAccessTokenForTenant(userID uint, tenantID string) (string, int64, error) {
+	return j.generateToken(userID, tenantID, j.tokenConfig.AccessTokenExpire)
+}
+
+// GenerateRefreshToken 生成刷新令牌
+func (j *JWT) GenerateRefreshToken(userID uint) (string, int64, error) {
+	return j.GenerateRefreshTokenForTenant(userID, "")
+}
 
+// GenerateRefreshTokenForTenant 生成绑定租户的刷新令牌
+func (j *JWT) GenerateRefreshTokenForTenant(userID uint, tenantID string) (string, int64, error) {
+	return j.generateToken(userID, tenantID, j.tokenConfig.RefreshTokenExpire)
+}
+
+// generateToken 生成令牌
+func (j *JWT) generateToken(userID uint, tenantID string, expire time.Duration) (string, int64, error) {
+	expireTime := time.Now().Add(expire)
+	expireUnix := expireTime.Unix()
 	claims := Claims{
-		UserID: userID,
+		UserID:   userID,
+		TenantID: tenantID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expireTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -53,30 +78,53 @@ func (j *JWT) GenerateAccessToken(userID uint) (string, int64, error) {
 	return tokenString, expireUnix, nil
 }
 
-// GenerateRefreshToken 生成刷新令牌
-func (j *JWT) GenerateRefreshToken(userID uint) (string, int64, error) {
-	expireTime := time.Now().Add(j.tokenConfig.RefreshTokenExpire)
-	expireUnix := expireTime.Unix()
+// GenerateActionToken 生成一次性操作令牌（如邮箱验证、重置密码）
+// 操作令牌不能作为访问令牌使用，fingerprint 由调用方根据需要绑定的数据计算，解析后自行比对
+// expire 为 0 时令牌不过期（如订阅链接），需要通过 fingerprint 使其失效
+func (j *JWT) GenerateActionToken(userID uint, tenantID string, purpose string, fingerprint string, expire time.Duration) (string, error) {
+	now := time.Now()
 	claims := Claims{
-		UserID: userID,
+		UserID:      userID,
+		TenantID:    tenantID,
+		Purpose:     purpose,
+		Fingerprint: fingerprint,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expireTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
+	if expire != 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(now.Add(expire))
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(j.tokenConfig.Secret))
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(j.tokenConfig.Secret))
+// ParseActionToken 解析操作令牌，用途不一致时返回错误
+func (j *JWT) ParseActionToken(tokenString string, purpose string) (*Claims, error) {
+	claims, err := j.parse(tokenString)
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
-
-	return tokenString, expireUnix, nil
+	if purpose == "" || claims.Purpose != purpose {
+		return nil, fmt.Errorf("invalid token purpose")
+	}
+	return claims, nil
 }
 
-// ParseToken 解析令牌
+// ParseToken 解析访问令牌或刷新令牌，操作令牌会被拒绝
 func (j *JWT) ParseToken(tokenString string) (*Claims, error) {
+	claims, err := j.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != "" {
+		return nil, fmt.Errorf("invalid token purpose")
+	}
+	return claims, nil
+}
+
+// parse 校验签名和有效期并解析声明
+func (j *JWT) parse(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		return []byte(j.tokenConfig.Secret), nil
 	})
@@ -228,3 +228,63 @@ func TestJWT_Integration(t *testing.T) {
 		assert.Greater(t, refreshExpire, expireUnix)
 	})
 }
+
+func TestActionToken(t *testing.T) {
+	jwtInstance := secret.NewJWT(secret.TokenConfig{
+		AccessTokenExpire:  time.Hour,
+		RefreshTokenExpire: 24 * time.Hour,
+		Secret:             "action-test-secret",
+	})
+
+	t.Run("生成并按用途解析", func(t *testing.T) {
+		token, err := jwtInstance.GenerateActionToken(7, "t1", "verify_email", "fp", time.Hour)
+		require.NoError(t, err)
+
+		claims, err := jwtInstance.ParseActionToken(token, "verify_email")
+		require.NoError(t, err)
+		assert.Equal(t, uint(7), claims.UserID)
+		assert.Equal(t, "t1", claims.TenantID)
+		assert.Equal(t, "fp", claims.Fingerprint)
+	})
+
+	t.Run("用途不一致时拒绝", func(t *testing.T) {
+		token, err := jwtInstance.GenerateActionToken(7, "", "verify_email", "fp", time.Hour)
+		require.NoError(t, err)
+
+		_, err = jwtInstance.ParseActionToken(token, "reset_password")
+		assert.Error(t, err)
+	})
+
+	t.Run("操作令牌不能作为访问令牌", func(t *testing.T) {
+		token, err := jwtInstance.GenerateActionToken(7, "", "verify_email", "fp", time.Hour)
+		require.NoError(t, err)
+
+		_, err = jwtInstance.ParseToken(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("访问令牌不能作为操作令牌", func(t *testing.T) {
+		token, _, err := jwtInstance.GenerateAccessToken(7)
+		require.NoError(t, err)
+
+		_, err = jwtInstance.ParseActionToken(token, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("不过期的操作令牌", func(t *testing.T) {
+		token, err := jwtInstance.GenerateActionToken(7, "", "item_feed", "fp", 0)
+		require.NoError(t, err)
+
+		claims, err := jwtInstance.ParseActionToken(token, "item_feed")
+		require.NoError(t, err)
+		assert.Nil(t, claims.ExpiresAt)
+	})
+
+	t.Run("过期的操作令牌", func(t *testing.T) {
+		token, err := jwtInstance.GenerateActionToken(7, "", "verify_email", "fp", -time.Minute)
+		require.NoError(t, err)
+
+		_, err = jwtInstance.ParseActionToken(token, "verify_email")
+		assert.Error(t, err)
+	})
+}
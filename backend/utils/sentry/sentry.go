@@ -0,0 +1,289 @@
+// Package sentry 把 error 及以上级别的日志上报到 Sentry 的最小客户端，实现 logs.Hook
+// 使用 Sentry 的 envelope 接口，不依赖官方 SDK
+package sentry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"backend/utils/logs"
+)
+
+const (
+	// defaultQueueSize 默认的上报队列长度
+	defaultQueueSize = 100
+	// defaultTimeout 默认的单次上报超时时间
+	defaultTimeout = 5 * time.Second
+	// clientName 上报时的客户端名称
+	clientName = "backend-sentry/1.0"
+)
+
+// Config Sentry 配置
+type Config struct {
+	DSN         string       // 项目 DSN，如 https://public_key@o0.ingest.sentry.io/123
+	Environment string       // 环境名称，如 production
+	Release     string       // 版本号
+	ServerName  string       // 实例名称，如主机名
+	QueueSize   int          // 上报队列长度，队列满时丢弃，默认 100
+	HTTPClient  *http.Client // 为空时使用超时 5 秒的客户端
+}
+
+// Hook 把日志异步上报到 Sentry，实现 logs.Hook
+type Hook struct {
+	cfg       Config
+	endpoint  string // envelope 接口地址
+	auth      string // X-Sentry-Auth 请求头
+	http      *http.Client
+	queue     chan *event
+	done      chan struct{}
+	mu        sync.RWMutex
+	closed    bool
+	dropped   atomic.Int64
+	closeOnce sync.Once
+}
+
+// NewHook 解析 DSN 并启动上报 goroutine，通过 logs.AddHook 注册后生效
+func NewHook(cfg Config) (*Hook, error) {
+	endpoint, key, err := parseDSN(cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+
+	h := &Hook{
+		cfg:      cfg,
+		endpoint: endpoint,
+		auth:     fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", clientName, key),
+		http:     httpClient,
+		queue:    make(chan *event, cfg.QueueSize),
+		done:     make(chan struct{}),
+	}
+	go h.run()
+	return h, nil
+}
+
+// ValidateDSN 校验 DSN 格式
+func ValidateDSN(dsn string) error {
+	_, _, err := parseDSN(dsn)
+	return err
+}
+
+// parseDSN 解析 DSN，返回 envelope 接口地址和公钥
+func parseDSN(dsn string) (endpoint, key string, err error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", "", fmt.Errorf("sentry: invalid dsn: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return "", "", errors.New("sentry: invalid dsn: scheme must be http or https")
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", errors.New("sentry: invalid dsn: missing public key")
+	}
+	path := strings.TrimRight(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	projectID := path[i+1:]
+	if projectID == "" {
+		return "", "", errors.New("sentry: invalid dsn: missing project id")
+	}
+	endpoint = fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, path[:i], projectID)
+	return endpoint, u.User.Username(), nil
+}
+
+// Fire 实现 logs.Hook，转换为 Sentry 事件放入上报队列，队列满或已关闭时丢弃
+func (h *Hook) Fire(entry *logs.HookEntry) {
+	e := h.newEvent(entry)
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return
+	}
+	select {
+	case h.queue <- e:
+	default:
+		h.dropped.Add(1)
+	}
+}
+
+// Dropped 返回因队列满丢弃的事件数
+func (h *Hook) Dropped() int64 {
+	return h.dropped.Load()
+}
+
+// Close 停止接收新事件，等待队列中的事件上报完成或 ctx 结束
+func (h *Hook) Close(ctx context.Context) error {
+	h.closeOnce.Do(func() {
+		h.mu.Lock()
+		h.closed = true
+		close(h.queue)
+		h.mu.Unlock()
+	})
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run 逐个上报队列中的事件
+func (h *Hook) run() {
+	defer close(h.done)
+	for e := range h.queue {
+		if err := h.send(e); err != nil {
+			// 使用 warn 级别，避免上报失败的日志再次触发上报
+			logs.Warn("上报 Sentry 失败", "event_id", e.EventID, "error", err.Error())
+		}
+	}
+}
+
+// send 通过 envelope 接口上报单个事件
+func (h *Hook) send(e *event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	var body bytes.Buffer
+	header, _ := json.Marshal(map[string]string{
+		"event_id": e.EventID,
+		"sent_at":  time.Now().UTC().Format(time.RFC3339Nano),
+	})
+	item, _ := json.Marshal(map[string]interface{}{"type": "event", "length": len(payload)})
+	body.Write(header)
+	body.WriteByte('\n')
+	body.Write(item)
+	body.WriteByte('\n')
+	body.Write(payload)
+	body.WriteByte('\n')
+
+	req, err := http.NewRequest(http.MethodPost, h.endpoint, &body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", h.auth)
+
+	resp, err := h.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("sentry: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// event Sentry 事件，只包含用到的字段
+type event struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Platform    string                 `json:"platform"`
+	Logger      string                 `json:"logger"`
+	Message     eventMessage           `json:"message"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Contexts    map[string]interface{} `json:"contexts,omitempty"`
+}
+
+type eventMessage struct {
+	Formatted string `json:"formatted"`
+}
+
+// newEvent 把日志转换为 Sentry 事件
+// 相同消息的日志聚合为同一个问题，字段放入 extra，trace_id 和调用位置作为标签便于搜索
+func (h *Hook) newEvent(entry *logs.HookEntry) *event {
+	e := &event{
+		EventID:     newEventID(),
+		Timestamp:   entry.Time.UTC().Format(time.RFC3339Nano),
+		Level:       eventLevel(entry.Level),
+		Platform:    "go",
+		Logger:      "backend",
+		Message:     eventMessage{Formatted: entry.Message},
+		Environment: h.cfg.Environment,
+		Release:     h.cfg.Release,
+		ServerName:  h.cfg.ServerName,
+		Tags:        make(map[string]string),
+		Extra:       make(map[string]interface{}, len(entry.Fields)),
+	}
+	if entry.Caller != "" {
+		e.Tags["caller"] = entry.Caller
+	}
+	if entry.TraceID != "" {
+		e.Tags["trace_id"] = entry.TraceID
+	}
+	for key, value := range entry.Fields {
+		if key == "stack" {
+			continue
+		}
+		// 不能序列化为 JSON 的字段转换为字符串，避免整个事件上报失败
+		if _, err := json.Marshal(value); err != nil {
+			value = fmt.Sprint(value)
+		}
+		e.Extra[key] = value
+	}
+	if entry.Stack != "" {
+		e.Extra["stack"] = entry.Stack
+	}
+	// Sentry 只识别 W3C 格式的 trace_id 和 span_id，用于关联性能监控中的链路
+	if isHex(entry.TraceID, 32) && isHex(entry.SpanID, 16) {
+		e.Contexts = map[string]interface{}{
+			"trace": map[string]string{"trace_id": entry.TraceID, "span_id": entry.SpanID},
+		}
+	}
+	return e
+}
+
+// eventLevel 把日志级别转换为 Sentry 的事件级别
+func eventLevel(level string) string {
+	switch level {
+	case "error":
+		return "error"
+	case "warn":
+		return "warning"
+	case "debug", "info":
+		return level
+	default:
+		return "fatal" // dpanic、panic、fatal
+	}
+}
+
+// newEventID 生成 32 位十六进制的事件 ID
+func newEventID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// isHex 判断 s 是否为 n 位十六进制字符串
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
@@ -0,0 +1,95 @@
+package sentry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/utils/logs"
+)
+
+// TestHook 测试日志转换为 Sentry 事件并通过 envelope 接口上报
+func TestHook(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	events := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scanner := bufio.NewScanner(r.Body)
+		var lines []string
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		var e map[string]interface{}
+		if len(lines) == 3 {
+			_ = json.Unmarshal([]byte(lines[2]), &e)
+		}
+		received <- r
+		events <- e
+	}))
+	defer server.Close()
+
+	hook, err := NewHook(Config{
+		DSN:         strings.Replace(server.URL, "://", "://public_key@", 1) + "/42",
+		Environment: "production",
+		Release:     "v1.2.0",
+	})
+	if err != nil {
+		t.Fatalf("创建钩子失败: %v", err)
+	}
+
+	hook.Fire(&logs.HookEntry{
+		Level:   "error",
+		Time:    time.Now(),
+		Message: "保存失败",
+		Caller:  "item/item_logic.go:42",
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+		Stack:   "a.go:1 a",
+		Fields:  map[string]interface{}{"item_id": 7, "ch": make(chan int)},
+	})
+	if err := hook.Close(context.Background()); err != nil {
+		t.Fatalf("关闭钩子失败: %v", err)
+	}
+
+	r := <-received
+	if r.URL.Path != "/api/42/envelope/" || !strings.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=public_key") {
+		t.Errorf("上报地址或认证信息不正确: %s %s", r.URL.Path, r.Header.Get("X-Sentry-Auth"))
+	}
+	e := <-events
+	if e["level"] != "error" || e["release"] != "v1.2.0" || e["environment"] != "production" {
+		t.Errorf("事件信息不正确: %v", e)
+	}
+	if msg, _ := e["message"].(map[string]interface{}); msg["formatted"] != "保存失败" {
+		t.Errorf("事件消息不正确: %v", e["message"])
+	}
+	extra, _ := e["extra"].(map[string]interface{})
+	if extra["item_id"] != float64(7) || extra["stack"] != "a.go:1 a" || extra["ch"] == nil {
+		t.Errorf("extra 不正确: %v", extra)
+	}
+	if tags, _ := e["tags"].(map[string]interface{}); tags["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("标签不正确: %v", e["tags"])
+	}
+	if e["contexts"] == nil {
+		t.Error("W3C 格式的 trace_id 应关联链路")
+	}
+
+	// 关闭后不再上报
+	hook.Fire(&logs.HookEntry{Level: "error", Message: "关闭后"})
+}
+
+// TestParseDSN 测试解析 DSN
+func TestParseDSN(t *testing.T) {
+	endpoint, key, err := parseDSN("https://abc@o1.ingest.sentry.io/sub/123")
+	if err != nil || endpoint != "https://o1.ingest.sentry.io/sub/api/123/envelope/" || key != "abc" {
+		t.Errorf("解析结果不正确: %s %s %v", endpoint, key, err)
+	}
+	for _, dsn := range []string{"", "ftp://abc@host/1", "https://host/1", "https://abc@host/"} {
+		if _, _, err := parseDSN(dsn); err == nil {
+			t.Errorf("DSN %q 应解析失败", dsn)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EvictionPolicy 保存的数据超出限制时的淘汰策略
+type EvictionPolicy string
+
+const (
+	EvictOldest EvictionPolicy = "oldest" // 淘汰最早的数据，只保留最近的数据（环形缓冲）
+	EvictNewest EvictionPolicy = "newest" // 不再保存新数据，保留最早的数据
+)
+
+// CacheLimit 每个任务保存的数据上限，用于断线重连时补发
+// 超出上限的数据不再补发，但仍会实时推送给在线的订阅者
+type CacheLimit struct {
+	MaxEvents int            // 最多保存的数据条数，0 表示不限制
+	MaxBytes  int            // 最多保存的数据字节数（按 JSON 序列化后的大小计算），0 表示不限制
+	Policy    EvictionPolicy // 超出上限时的淘汰策略，默认 EvictOldest
+}
+
+// Validate 校验上限配置
+func (l CacheLimit) Validate() error {
+	if l.MaxEvents < 0 || l.MaxBytes < 0 {
+		return fmt.Errorf("sse: cache limit must not be negative: max_events=%d, max_bytes=%d", l.MaxEvents, l.MaxBytes)
+	}
+	switch l.Policy {
+	case "", EvictOldest, EvictNewest:
+		return nil
+	default:
+		return fmt.Errorf("sse: unknown eviction policy: %s", l.Policy)
+	}
+}
+
+// WithCacheLimit 设置每个任务保存的数据上限，默认不限制
+func WithCacheLimit(limit CacheLimit) Option {
+	return func(m *SSEManager) {
+		m.cacheLimit = limit
+	}
+}
+
+// TaskOption ExecuteWithSSE 的配置选项
+type TaskOption func(*taskOptions)
+
+type taskOptions struct {
+	cacheLimit CacheLimit
+	delivery   DeliveryPolicy
+	kind       string
+	keepalive  time.Duration
+	unlimited  bool
+	ttl        time.Duration
+	sliding    bool
+	coalesce   *Coalesce
+
+	idempotencyKey string
+}
+
+// WithTaskCacheLimit 设置该任务保存的数据上限，覆盖管理器的 WithCacheLimit，只在创建任务时生效
+func WithTaskCacheLimit(limit CacheLimit) TaskOption {
+	return func(o *taskOptions) {
+		o.cacheLimit = limit
+	}
+}
+
+// eventCache 任务已保存数据的大小，用于按上限淘汰，只在执行任务的实例上维护
+type eventCache struct {
+	limit CacheLimit
+	sizes []int // 已保存数据的大小，按事件ID递增
+	bytes int   // 已保存数据的总大小
+}
+
+// size 数据的大小，没有字节数上限时不序列化
+func (c *eventCache) size(data interface{}) int {
+	if c.limit.MaxBytes <= 0 {
+		return 0
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return 0
+	}
+	return len(b)
+}
+
+func (c *eventCache) full(size int) bool {
+	return (c.limit.MaxEvents > 0 && len(c.sizes) > c.limit.MaxEvents) ||
+		(c.limit.MaxBytes > 0 && c.bytes+size > c.limit.MaxBytes)
+}
+
+// admit 登记一条新数据，返回是否保存该数据、需要从最早开始淘汰的条数
+func (c *eventCache) admit(data interface{}) (save bool, evict int) {
+	size := c.size(data)
+	if c.limit.Policy == EvictNewest {
+		if (c.limit.MaxEvents > 0 && len(c.sizes) >= c.limit.MaxEvents) || c.full(size) {
+			return false, 0
+		}
+		c.sizes = append(c.sizes, size)
+		c.bytes += size
+		return true, 0
+	}
+
+	c.sizes = append(c.sizes, size)
+	c.bytes += size
+	for len(c.sizes) > 0 && c.full(0) {
+		c.bytes -= c.sizes[0]
+		c.sizes = c.sizes[1:]
+		evict++
+	}
+	return true, evict
+}
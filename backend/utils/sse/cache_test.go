@@ -0,0 +1,125 @@
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestCacheLimitEvictOldest 测试超出上限时淘汰最早的数据，重连后第一条数据记录缺失的条数
+func TestCacheLimitEvictOldest(t *testing.T) {
+	m := NewSSEManager(time.Hour, WithCacheLimit(CacheLimit{MaxEvents: 2}))
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 5; i++ {
+			_ = updateProgress(i)
+		}
+		<-release
+		return nil
+	}
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	disconnect()
+	_, taskID, err := m.ExecuteWithSSE(ctx, "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	var info *TaskInfo
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		info, _ = m.GetTaskInfo(taskID)
+		if info.LastEventID == 5 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("等待数据保存超时")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if info.DroppedEvents != 3 {
+		t.Fatalf("期望淘汰 3 条数据，实际为 %d", info.DroppedEvents)
+	}
+
+	dataChan, _, err := m.ExecuteWithSSE(context.Background(), info.ResumeKey, 0, "client_2", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("续传失败: %v", err)
+	}
+	close(release)
+
+	var received []Event
+	for event := range dataChan {
+		received = append(received, event)
+	}
+	if len(received) != 2 || received[0].ID != 4 || received[1].ID != 5 {
+		t.Fatalf("期望补发事件 4、5，实际为 %v", received)
+	}
+	if received[0].Dropped != 3 || received[1].Dropped != 0 {
+		t.Fatalf("期望第一条数据前缺失 3 条，实际为 %v", received)
+	}
+}
+
+// TestTaskCacheLimit 测试任务的上限覆盖管理器的上限
+func TestTaskCacheLimit(t *testing.T) {
+	store := NewMemoryStore()
+	m := NewSSEManager(time.Hour, WithStore(store), WithCacheLimit(CacheLimit{MaxEvents: 100}))
+	defer m.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 3; i++ {
+			_ = updateProgress(i)
+		}
+		return nil
+	}
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second,
+		WithTaskCacheLimit(CacheLimit{MaxEvents: 1, Policy: EvictNewest}))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	for range dataChan {
+	}
+
+	events, _ := store.Events(context.Background(), taskID, 0)
+	if len(events) != 1 || events[0].ID != 1 {
+		t.Fatalf("期望只保存事件 1，实际为 %v", events)
+	}
+	if info, _ := m.GetTaskInfo(taskID); info.DroppedEvents != 2 {
+		t.Fatalf("期望 2 条数据没有保存，实际为 %d", info.DroppedEvents)
+	}
+
+	_, _, err = m.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, 10*time.Second,
+		WithTaskCacheLimit(CacheLimit{Policy: "lru"}))
+	if err == nil {
+		t.Fatal("未知的淘汰策略应返回错误")
+	}
+}
+
+// TestEventCacheBytes 测试按字节数淘汰
+func TestEventCacheBytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy EvictionPolicy
+		save   []bool
+		evict  []int
+	}{
+		// 每条数据 JSON 为 3 字节，上限 7 字节最多保存 2 条
+		{name: "oldest", policy: EvictOldest, save: []bool{true, true, true, true}, evict: []int{0, 0, 1, 1}},
+		{name: "newest", policy: EvictNewest, save: []bool{true, true, false, false}, evict: []int{0, 0, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := eventCache{limit: CacheLimit{MaxBytes: 7, Policy: tt.policy}}
+			for i := range tt.save {
+				save, evict := c.admit(100 + i)
+				if save != tt.save[i] || evict != tt.evict[i] {
+					t.Fatalf("第 %d 条数据: save=%v, evict=%d，期望 save=%v, evict=%d", i+1, save, evict, tt.save[i], tt.evict[i])
+				}
+			}
+			if c.bytes > 7 {
+				t.Fatalf("保存的数据超出上限: %d", c.bytes)
+			}
+		})
+	}
+}
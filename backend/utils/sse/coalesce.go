@@ -0,0 +1,137 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Coalesce 高频进度的合并配置
+// 合并期间 updateProgress 只把数据放入缓冲区，每隔 Interval 或收到 MaxEvents 条数据时推送缓冲区中保留的数据，
+// 被覆盖的数据不分配事件ID、不保存，按 DropReasonCoalesced 计入丢弃指标
+type Coalesce struct {
+	Interval  time.Duration // 最多间隔多久推送一次，0 表示不按时间推送
+	MaxEvents int           // 缓冲区收到多少条数据后立即推送，0 表示不按条数推送
+	// Key 数据的合并键，同一个键只保留最新的数据，按键第一次出现的顺序推送；
+	// 为 nil 时只保留最新的一条数据
+	Key func(data interface{}) string
+}
+
+// Validate 校验合并配置
+func (c Coalesce) Validate() error {
+	if c.Interval < 0 || c.MaxEvents < 0 {
+		return fmt.Errorf("sse: coalesce must not be negative: interval=%s, max_events=%d", c.Interval, c.MaxEvents)
+	}
+	if c.Interval == 0 && c.MaxEvents == 0 {
+		return fmt.Errorf("sse: coalesce requires interval or max events")
+	}
+	return nil
+}
+
+// WithCoalesce 合并该任务的高频进度，只在创建任务时生效
+// 异步任务返回或调用 CompleteTask 时先推送缓冲区中剩余的数据，最后一次进度不会丢失
+func WithCoalesce(coalesce Coalesce) TaskOption {
+	return func(o *taskOptions) {
+		o.coalesce = &coalesce
+	}
+}
+
+// coalescer 任务进度的合并缓冲区，只在执行任务的实例上维护
+type coalescer struct {
+	config    Coalesce
+	publish   func(ctx context.Context, data interface{}) error // 推送一条数据，即不合并时的 UpdateProgress
+	coalesced func(n int)                                       // 记录被覆盖的数据条数
+	mu        sync.Mutex                                        // 保护缓冲区，推送期间持有，保证推送顺序与调用顺序一致
+	pending   []interface{}                                     // 缓冲区中保留的数据
+	keys      map[string]int                                    // 合并键在 pending 中的位置
+	received  int                                               // 缓冲区收到的数据条数
+	timer     *time.Timer                                       // 按 Interval 推送的定时器，缓冲区为空时为 nil
+	ctx       context.Context                                   // 最后一次 add 的 ctx，定时推送时使用
+	err       error                                             // 定时推送返回的错误，下次 add 时返回
+}
+
+// add 把数据放入缓冲区，收到 MaxEvents 条数据时在当前 goroutine 推送
+func (c *coalescer) add(ctx context.Context, data interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.err; err != nil {
+		c.err = nil
+		return err
+	}
+	c.ctx = ctx
+	if c.config.Key == nil {
+		c.pending = append(c.pending[:0], data)
+	} else {
+		key := c.config.Key(data)
+		if i, ok := c.keys[key]; ok {
+			c.pending[i] = data
+		} else {
+			if c.keys == nil {
+				c.keys = make(map[string]int)
+			}
+			c.keys[key] = len(c.pending)
+			c.pending = append(c.pending, data)
+		}
+	}
+	c.received++
+
+	if c.config.MaxEvents > 0 && c.received >= c.config.MaxEvents {
+		return c.flushLocked(ctx)
+	}
+	if c.timer == nil && c.config.Interval > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(c.config.Interval, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			// 定时器触发前缓冲区已被推送
+			if c.timer != timer {
+				return
+			}
+			if err := c.flushLocked(c.ctx); err != nil {
+				c.err = err
+			}
+		})
+		c.timer = timer
+	}
+	return nil
+}
+
+// flush 推送缓冲区中剩余的数据
+func (c *coalescer) flush(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.flushLocked(ctx)
+}
+
+// flushLocked 按顺序推送缓冲区中保留的数据并清空缓冲区，调用方持有 c.mu
+func (c *coalescer) flushLocked(ctx context.Context) error {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	pending := c.pending
+	c.coalesced(c.received - len(pending))
+	c.pending, c.received = nil, 0
+	clear(c.keys)
+
+	for _, data := range pending {
+		if err := c.publish(ctx, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flushCoalesced 推送任务合并缓冲区中剩余的数据，没有启用合并时忽略
+func (m *SSEManager) flushCoalesced(ctx context.Context, taskID string) error {
+	m.mu.RLock()
+	task, exists := m.tasks[taskID]
+	m.mu.RUnlock()
+
+	if !exists || task.coalescer == nil {
+		return nil
+	}
+	return task.coalescer.flush(ctx)
+}
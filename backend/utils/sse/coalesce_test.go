@@ -0,0 +1,96 @@
+package sse
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestCoalesce 测试按条数合并时只推送最新的数据，异步任务返回时推送剩余的数据
+func TestCoalesce(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 25; i++ {
+			if err := updateProgress(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	dataChan, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0, WithCoalesce(Coalesce{MaxEvents: 10}))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	var got []interface{}
+	for event := range dataChan {
+		got = append(got, event.Data)
+	}
+	if fmt.Sprint(got) != "[10 20 25]" {
+		t.Fatalf("期望推送 [10 20 25]，实际为 %v", got)
+	}
+}
+
+// TestCoalesceByKey 测试按键合并时每个键保留最新的数据，按键第一次出现的顺序推送
+func TestCoalesceByKey(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	type progress struct {
+		File    string
+		Percent int
+	}
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 3; i++ {
+			_ = updateProgress(progress{File: "a", Percent: i * 10})
+			_ = updateProgress(progress{File: "b", Percent: i * 20})
+		}
+		return nil
+	}
+	dataChan, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0, WithCoalesce(Coalesce{
+		Interval: time.Hour,
+		Key:      func(data interface{}) string { return data.(progress).File },
+	}))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	var got []Event
+	for event := range dataChan {
+		got = append(got, event)
+	}
+	if len(got) != 2 || got[0].Data != (progress{"a", 30}) || got[1].Data != (progress{"b", 60}) || got[1].ID != 2 {
+		t.Fatalf("期望推送每个键最新的数据，实际为 %+v", got)
+	}
+}
+
+// TestCoalesceInterval 测试按时间合并时定时推送缓冲区中的数据，配置无效时返回错误
+func TestCoalesceInterval(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		_ = updateProgress(2)
+		<-release
+		return nil
+	}
+	dataChan, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0, WithCoalesce(Coalesce{Interval: 20 * time.Millisecond}))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	if event := nextEvent(t, dataChan); event.ID != 1 || event.Data != 2 {
+		t.Fatalf("期望定时推送最新的数据，实际为 %+v", event)
+	}
+	close(release)
+	for range dataChan {
+	}
+
+	if _, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, 0, WithCoalesce(Coalesce{})); err == nil {
+		t.Fatal("没有设置 Interval 和 MaxEvents 时应返回错误")
+	}
+}
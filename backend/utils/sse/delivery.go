@@ -0,0 +1,108 @@
+package sse
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSlowSubscriber 订阅者接收太慢，按 DeliverDisconnect 策略被断开
+var ErrSlowSubscriber = errors.New("subscriber too slow")
+
+// DeliveryMode 订阅者通道已满时的处理方式
+type DeliveryMode string
+
+const (
+	DeliverSkip       DeliveryMode = "skip"        // 跳过新数据（默认）
+	DeliverDropOldest DeliveryMode = "drop_oldest" // 丢弃通道中最早的数据，保证订阅者收到最新的数据
+	DeliverBlock      DeliveryMode = "block"       // 等待订阅者接收，超过 Timeout 后跳过
+	DeliverDisconnect DeliveryMode = "disconnect"  // 断开订阅者，通道中最后一条数据的 Err 为 ErrSlowSubscriber
+)
+
+// defaultBlockTimeout DeliverBlock 未设置 Timeout 时的等待时间
+const defaultBlockTimeout = time.Second
+
+// DeliveryPolicy 订阅者的投递策略
+// 没有送达的数据已保存在任务存储中（CacheLimit 以内），重连时可以补发
+type DeliveryPolicy struct {
+	Mode    DeliveryMode
+	Timeout time.Duration // DeliverBlock 的最长等待时间，默认 1s；等待期间会阻塞该任务其他订阅者的投递
+}
+
+// Validate 校验投递策略
+func (p DeliveryPolicy) Validate() error {
+	switch p.Mode {
+	case "", DeliverSkip, DeliverDropOldest, DeliverBlock, DeliverDisconnect:
+	default:
+		return fmt.Errorf("sse: unknown delivery mode: %s", p.Mode)
+	}
+	if p.Timeout < 0 {
+		return fmt.Errorf("sse: delivery timeout must not be negative: %s", p.Timeout)
+	}
+	return nil
+}
+
+// WithDelivery 设置订阅者默认的投递策略，默认为 DeliverSkip
+func WithDelivery(policy DeliveryPolicy) Option {
+	return func(m *SSEManager) {
+		m.delivery = policy
+	}
+}
+
+// WithSubscriberDelivery 设置本次调用的订阅者的投递策略，覆盖管理器的 WithDelivery，恢复已有任务时同样生效
+func WithSubscriberDelivery(policy DeliveryPolicy) TaskOption {
+	return func(o *taskOptions) {
+		o.delivery = policy
+	}
+}
+
+// subscriber 本实例上的订阅者
+type subscriber struct {
+	ch     chan Event
+	policy DeliveryPolicy
+	err    error // 被断开的原因，由持有 task.mu（远程订阅为转发 goroutine）的一方写入
+}
+
+// deliver 按投递策略发送数据，返回 false 表示应断开订阅者，dropped 为没有送达的数据条数
+func (s *subscriber) deliver(event Event) (connected bool, dropped int) {
+	select {
+	case s.ch <- event:
+		return true, 0
+	default:
+	}
+
+	switch s.policy.Mode {
+	case DeliverDropOldest:
+		for {
+			select {
+			case <-s.ch:
+				dropped++
+			default:
+			}
+			select {
+			case s.ch <- event:
+				return true, dropped
+			default:
+			}
+		}
+	case DeliverBlock:
+		timeout := s.policy.Timeout
+		if timeout <= 0 {
+			timeout = defaultBlockTimeout
+		}
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		select {
+		case s.ch <- event:
+			return true, 0
+		case <-timer.C:
+			// 等待超时，跳过
+			return true, 1
+		}
+	case DeliverDisconnect:
+		return false, 1
+	default:
+		// 订阅者通道已满，跳过
+		return true, 1
+	}
+}
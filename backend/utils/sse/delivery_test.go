@@ -0,0 +1,119 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestSubscriberDeliver 测试订阅者通道已满时各投递策略的行为
+func TestSubscriberDeliver(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    DeliveryPolicy
+		connected bool
+		ids       []uint64 // 通道中剩余的事件ID
+	}{
+		{name: "skip", policy: DeliveryPolicy{Mode: DeliverSkip}, connected: true, ids: []uint64{1, 2}},
+		{name: "drop_oldest", policy: DeliveryPolicy{Mode: DeliverDropOldest}, connected: true, ids: []uint64{2, 3}},
+		{name: "block", policy: DeliveryPolicy{Mode: DeliverBlock, Timeout: 10 * time.Millisecond}, connected: true, ids: []uint64{1, 2}},
+		{name: "disconnect", policy: DeliveryPolicy{Mode: DeliverDisconnect}, connected: false, ids: []uint64{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &subscriber{ch: make(chan Event, 2), policy: tt.policy}
+			sub.deliver(Event{ID: 1})
+			sub.deliver(Event{ID: 2})
+			if connected, dropped := sub.deliver(Event{ID: 3}); connected != tt.connected || dropped != 1 {
+				t.Fatalf("期望 connected=%v、丢弃 1 条，实际为 %v、%d", tt.connected, connected, dropped)
+			}
+			close(sub.ch)
+			var ids []uint64
+			for event := range sub.ch {
+				ids = append(ids, event.ID)
+			}
+			if len(ids) != len(tt.ids) || ids[0] != tt.ids[0] || ids[1] != tt.ids[1] {
+				t.Fatalf("期望通道中为 %v，实际为 %v", tt.ids, ids)
+			}
+		})
+	}
+
+	// 等待期间订阅者接收后送达
+	sub := &subscriber{ch: make(chan Event, 1), policy: DeliveryPolicy{Mode: DeliverBlock, Timeout: time.Second}}
+	sub.deliver(Event{ID: 1})
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		<-sub.ch
+	}()
+	sub.deliver(Event{ID: 2})
+	if event := <-sub.ch; event.ID != 2 {
+		t.Fatalf("期望等待后送达事件 2，实际为 %d", event.ID)
+	}
+}
+
+// TestDisconnectSlowSubscriber 测试不接收数据的订阅者被断开，通道中最后一条数据为 ErrSlowSubscriber
+func TestDisconnectSlowSubscriber(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		// 超过输出通道和订阅者通道的容量
+		for i := 1; i <= 400; i++ {
+			_ = updateProgress(i)
+			time.Sleep(100 * time.Microsecond)
+		}
+		<-release
+		return nil
+	}
+
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second,
+		WithSubscriberDelivery(DeliveryPolicy{Mode: DeliverDisconnect}))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	// 推送完全部数据之前不接收，让通道填满
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, _ := m.GetTaskInfo(taskID)
+		if info.LastEventID == 400 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("等待数据推送超时")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	var last Event
+	count := 0
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case event, ok := <-dataChan:
+			if !ok {
+				done = true
+				break
+			}
+			last = event
+			count++
+		case <-timeout:
+			t.Fatalf("等待订阅者断开超时，已接收 %d 条", count)
+		}
+	}
+	close(release)
+
+	if !errors.Is(last.Err, ErrSlowSubscriber) {
+		t.Fatalf("期望最后一条数据为 ErrSlowSubscriber，实际为 %+v", last)
+	}
+	if count >= 400 {
+		t.Fatalf("订阅者应在接收全部数据前被断开，实际接收 %d 条", count)
+	}
+
+	if _, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, time.Second,
+		WithSubscriberDelivery(DeliveryPolicy{Mode: "fifo"})); err == nil {
+		t.Fatal("未知的投递策略应返回错误")
+	}
+}
@@ -0,0 +1,91 @@
+package sse
+
+import (
+	"context"
+
+	"backend/utils/safego"
+)
+
+// TaskHook 任务生命周期回调，record 为回调时的任务元数据快照
+type TaskHook func(ctx context.Context, record *TaskRecord)
+
+// ProgressHook 任务推送数据后的回调，event 为已分配事件ID的数据
+type ProgressHook func(ctx context.Context, record *TaskRecord, event Event)
+
+// ErrorHook 异步任务返回错误后的回调
+type ErrorHook func(ctx context.Context, record *TaskRecord, err error)
+
+// taskHooks 管理器上注册的任务生命周期回调，按注册顺序执行
+type taskHooks struct {
+	onStart    []TaskHook
+	onProgress []ProgressHook
+	onComplete []TaskHook
+	onError    []ErrorHook
+}
+
+// OnTaskStart 注册任务开始的回调，在异步任务执行之前调用，可以多次注册
+// 回调在执行任务的 goroutine 中同步执行，ctx 不随 HTTP 请求断开取消
+func OnTaskStart(fn TaskHook) Option {
+	return func(m *SSEManager) {
+		if fn != nil {
+			m.hooks.onStart = append(m.hooks.onStart, fn)
+		}
+	}
+}
+
+// OnProgress 注册推送数据的回调，在 UpdateProgress 保存数据之后调用，可用于把进度持久化到数据库
+// 回调在调用 UpdateProgress 的 goroutine 中同步执行，耗时操作会拖慢异步任务
+func OnProgress(fn ProgressHook) Option {
+	return func(m *SSEManager) {
+		if fn != nil {
+			m.hooks.onProgress = append(m.hooks.onProgress, fn)
+		}
+	}
+}
+
+// OnComplete 注册任务结束的回调，任务完成、失败、取消时都会调用一次，record.Status 为最终状态
+func OnComplete(fn TaskHook) Option {
+	return func(m *SSEManager) {
+		if fn != nil {
+			m.hooks.onComplete = append(m.hooks.onComplete, fn)
+		}
+	}
+}
+
+// OnError 注册异步任务返回错误的回调，在 OnComplete 之前调用
+func OnError(fn ErrorHook) Option {
+	return func(m *SSEManager) {
+		if fn != nil {
+			m.hooks.onError = append(m.hooks.onError, fn)
+		}
+	}
+}
+
+// runHook 执行回调，回调 panic 时记录日志，不影响任务
+func runHook(ctx context.Context, fn func()) {
+	defer safego.Recovery(ctx)
+	fn()
+}
+
+func (m *SSEManager) taskStarted(ctx context.Context, record *TaskRecord) {
+	for _, fn := range m.hooks.onStart {
+		runHook(ctx, func() { fn(ctx, record) })
+	}
+}
+
+func (m *SSEManager) progressed(ctx context.Context, record *TaskRecord, event Event) {
+	for _, fn := range m.hooks.onProgress {
+		runHook(ctx, func() { fn(ctx, record, event) })
+	}
+}
+
+func (m *SSEManager) taskFinished(ctx context.Context, record *TaskRecord, err error) {
+	if err != nil {
+		for _, fn := range m.hooks.onError {
+			runHook(ctx, func() { fn(ctx, record, err) })
+		}
+	}
+	for _, fn := range m.hooks.onComplete {
+		runHook(ctx, func() { fn(ctx, record) })
+	}
+}
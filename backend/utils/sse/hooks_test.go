@@ -0,0 +1,70 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestTaskHooks 测试生命周期回调的调用顺序，回调 panic 不影响任务
+func TestTaskHooks(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, fmt.Sprintf(format, args...))
+	}
+	done := make(chan struct{})
+
+	var m *SSEManager
+	m = NewSSEManager(time.Hour,
+		OnTaskStart(func(ctx context.Context, r *TaskRecord) {
+			record("start:%s", r.Status)
+		}),
+		OnProgress(func(ctx context.Context, r *TaskRecord, event Event) {
+			// 回调中查询任务信息不会死锁
+			info, _ := m.GetTaskInfo(r.TaskID)
+			record("progress:%d:%v:%d", event.ID, event.Data, info.LastEventID)
+		}),
+		OnProgress(func(ctx context.Context, r *TaskRecord, event Event) {
+			panic("hook panic")
+		}),
+		OnError(func(ctx context.Context, r *TaskRecord, err error) {
+			record("error:%s", err)
+		}),
+		OnComplete(func(ctx context.Context, r *TaskRecord) {
+			record("complete:%s:%s", r.Status, r.Error)
+			close(done)
+		}),
+	)
+	defer m.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		_ = updateProgress(2)
+		return errors.New("boom")
+	}
+	dataChan, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	for range dataChan {
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待 OnComplete 超时")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"start:running", "progress:1:1:1", "progress:2:2:2", "error:boom", "complete:failed:boom"}
+	if fmt.Sprint(calls) != fmt.Sprint(want) {
+		t.Fatalf("期望回调为 %v，实际为 %v", want, calls)
+	}
+}
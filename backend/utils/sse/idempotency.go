@@ -0,0 +1,46 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WithIdempotencyKey 设置任务的幂等键，只在创建任务时生效
+// 同一个幂等键已经有未过期的任务（运行中、排队中或已结束）时，订阅者加入该任务，不再创建新任务重复执行；
+// 已结束的任务补发全部数据后通道关闭。幂等键由调用方生成（如客户端的 Idempotency-Key 请求头），
+// 需要按用户等维度隔离时由调用方拼接前缀
+func WithIdempotencyKey(key string) TaskOption {
+	return func(o *taskOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// findIdempotent 按幂等键查找未过期的任务，本实例的任务返回 task，其他实例的任务返回 record，都没有时返回 nil
+func (m *SSEManager) findIdempotent(ctx context.Context, key string) (*TaskInfo, *TaskRecord, error) {
+	now := time.Now()
+
+	m.mu.RLock()
+	for _, task := range m.tasks {
+		task.mu.RLock()
+		found := task.IdempotencyKey == key && task.ExpiresAt.After(now)
+		task.mu.RUnlock()
+		if found {
+			m.mu.RUnlock()
+			return task, nil, nil
+		}
+	}
+	m.mu.RUnlock()
+
+	record, err := m.store.FindByIdempotencyKey(ctx, key)
+	if errors.Is(err, ErrTaskNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if record.ExpiresAt.Before(now) {
+		return nil, nil, nil
+	}
+	return nil, record, nil
+}
@@ -0,0 +1,107 @@
+package sse
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestIdempotencyKey 测试同一个幂等键的请求加入已有任务，任务结束后再请求补发全部数据，不重复执行
+func TestIdempotencyKey(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		runs.Add(1)
+		_ = updateProgress(1)
+		<-release
+		_ = updateProgress(2)
+		return nil
+	}
+
+	first, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0, WithIdempotencyKey("user_1:export"))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	nextEvent(t, first)
+	if info, err := m.GetTaskInfo(taskID); err != nil || info.IdempotencyKey != "user_1:export" {
+		t.Fatalf("期望任务信息包含幂等键，实际为 %+v, %v", info, err)
+	}
+
+	// 重试的请求加入运行中的任务
+	retry, retryID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, 0, WithIdempotencyKey("user_1:export"))
+	if err != nil || retryID != taskID {
+		t.Fatalf("期望加入任务 %s，实际为 %s, %v", taskID, retryID, err)
+	}
+	if event := nextEvent(t, retry); event.ID != 1 {
+		t.Fatalf("期望先补发事件 1，实际为 %+v", event)
+	}
+
+	// 其他幂等键创建新任务
+	other, otherID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_3", asyncTask, 0, WithIdempotencyKey("user_2:export"))
+	if otherID == taskID {
+		t.Fatal("不同的幂等键不应加入同一个任务")
+	}
+
+	close(release)
+	for _, ch := range []<-chan Event{first, retry, other} {
+		for range ch {
+		}
+	}
+
+	// 任务结束后再请求，补发全部数据后通道关闭
+	done, doneID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_4", asyncTask, 0, WithIdempotencyKey("user_1:export"))
+	if err != nil || doneID != taskID {
+		t.Fatalf("期望加入已结束的任务 %s，实际为 %s, %v", taskID, doneID, err)
+	}
+	var ids []uint64
+	for event := range done {
+		ids = append(ids, event.ID)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("期望补发事件 [1 2]，实际为 %v", ids)
+	}
+	if n := runs.Load(); n != 2 {
+		t.Fatalf("期望执行 2 个任务，实际为 %d", n)
+	}
+}
+
+// TestIdempotencyKeyOnAnotherInstance 测试共享任务存储时，重试请求落到另一个实例也加入已有任务
+func TestIdempotencyKeyOnAnotherInstance(t *testing.T) {
+	store := NewMemoryStore()
+	owner := NewSSEManager(time.Hour, WithStore(store))
+	defer owner.Stop(context.Background())
+	other := NewSSEManager(time.Hour, WithStore(store))
+	defer other.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		<-release
+		return nil
+	}
+
+	first, taskID, err := owner.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0, WithIdempotencyKey("retry"))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	nextEvent(t, first)
+
+	retry, retryID, err := other.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, 0, WithIdempotencyKey("retry"))
+	if err != nil || retryID != taskID {
+		t.Fatalf("期望加入另一个实例上的任务 %s，实际为 %s, %v", taskID, retryID, err)
+	}
+	if event := nextEvent(t, retry); event.ID != 1 {
+		t.Fatalf("期望先补发事件 1，实际为 %+v", event)
+	}
+	if list := other.ListTasks(TaskFilter{}); len(list.Tasks) != 0 {
+		t.Fatalf("另一个实例不应创建任务，实际有 %d 个", len(list.Tasks))
+	}
+
+	close(release)
+	for range retry {
+	}
+}
@@ -0,0 +1,70 @@
+package sse
+
+import (
+	"time"
+)
+
+// KeepaliveEventName 保活数据的 SSE 事件名称（event 行）
+const KeepaliveEventName = "alive"
+
+// Keepalive 任务长时间没有推送数据时，管理器发送给订阅者的保活数据
+// 保活数据不分配事件ID、不保存到任务存储，客户端可以据此展示任务仍在运行以及已运行的时长
+type Keepalive struct {
+	TaskID      string     `json:"task_id"`
+	Status      TaskStatus `json:"status"`
+	ElapsedMs   int64      `json:"elapsed_ms"`    // 任务已运行的毫秒数
+	LastEventID uint64     `json:"last_event_id"` // 任务最后一条数据的事件ID
+}
+
+// WithKeepalive 设置保活间隔，订阅者超过 interval 没有收到数据时发送一条保活数据，默认为 0 不发送
+// 长时间计算不调用 updateProgress 的任务可以避免代理因连接空闲断开
+func WithKeepalive(interval time.Duration) Option {
+	return func(m *SSEManager) {
+		m.keepalive = interval
+	}
+}
+
+// WithSubscriberKeepalive 设置本次调用的订阅者的保活间隔，覆盖管理器的 WithKeepalive，0 表示不发送
+func WithSubscriberKeepalive(interval time.Duration) TaskOption {
+	return func(o *taskOptions) {
+		o.keepalive = interval
+	}
+}
+
+// keepalive 订阅者的保活配置
+type keepalive struct {
+	interval time.Duration     // 保活间隔，0 表示不发送
+	status   func() *Keepalive // 读取任务当前状态
+}
+
+// keepaliveStatus 本实例上任务的保活数据
+func keepaliveStatus(task *TaskInfo) *Keepalive {
+	task.mu.RLock()
+	defer task.mu.RUnlock()
+	return &Keepalive{
+		TaskID:      task.TaskID,
+		Status:      task.Status,
+		ElapsedMs:   time.Since(task.CreatedAt).Milliseconds(),
+		LastEventID: task.LastEventID,
+	}
+}
+
+// remoteKeepalive 其他实例上任务的保活数据，每次从任务存储读取最新状态，读取失败时使用上一次的状态
+func (m *SSEManager) remoteKeepalive(taskID string, record *TaskRecord) func() *Keepalive {
+	return func() *Keepalive {
+		ctx, cancel := storeContext()
+		defer cancel()
+		if latest, err := m.store.Get(ctx, taskID); err == nil {
+			record = latest
+		}
+		if record == nil {
+			return &Keepalive{TaskID: taskID, Status: TaskStatusRunning}
+		}
+		return &Keepalive{
+			TaskID:      record.TaskID,
+			Status:      record.Status,
+			ElapsedMs:   time.Since(record.CreatedAt).Milliseconds(),
+			LastEventID: record.LastEventID,
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestKeepalive 测试任务长时间没有推送数据时发送保活数据，保活数据不影响事件ID
+func TestKeepalive(t *testing.T) {
+	m := NewSSEManager(time.Hour, WithKeepalive(20*time.Millisecond))
+	defer m.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		time.Sleep(100 * time.Millisecond)
+		_ = updateProgress(2)
+		return nil
+	}
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	var ids []uint64
+	alive := 0
+	for event := range dataChan {
+		if event.Keepalive != nil {
+			alive++
+			// 保活数据反映任务的当前状态，可能领先于已经收到的数据（第二条数据还在转发中）
+			var lastID uint64
+			if len(ids) > 0 {
+				lastID = ids[len(ids)-1]
+			}
+			if event.Keepalive.TaskID != taskID || event.Keepalive.LastEventID < lastID || event.Keepalive.LastEventID == 0 {
+				t.Errorf("保活数据不正确: %+v", event.Keepalive)
+			}
+			if event.SSEEventID() != "" || event.SSEEventName() != KeepaliveEventName {
+				t.Errorf("保活数据不应输出事件ID: %q, %q", event.SSEEventID(), event.SSEEventName())
+			}
+			continue
+		}
+		ids = append(ids, event.ID)
+	}
+	if alive == 0 {
+		t.Fatal("期望收到保活数据")
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("期望事件ID为 [1 2]，实际为 %v", ids)
+	}
+
+	// 本次调用关闭保活
+	dataChan, _, _ = m.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, 10*time.Second, WithSubscriberKeepalive(0))
+	for event := range dataChan {
+		if event.Keepalive != nil {
+			t.Fatal("关闭保活后不应收到保活数据")
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package sse
+
+import (
+	"sort"
+	"time"
+)
+
+// WithTaskKind 设置任务类型（如 export），用于 ListTasks 按类型过滤，只在创建任务时生效
+func WithTaskKind(kind string) TaskOption {
+	return func(o *taskOptions) {
+		o.kind = kind
+	}
+}
+
+// TaskFilter ListTasks 的过滤条件，零值字段不过滤
+type TaskFilter struct {
+	Status        TaskStatus // 任务状态
+	Kind          string     // 任务类型
+	SubscriberID  string     // 本实例上的订阅者ID
+	CreatedAfter  time.Time  // 创建时间晚于
+	CreatedBefore time.Time  // 创建时间早于
+	Offset        int        // 跳过的条数
+	Limit         int        // 返回的条数，0 表示不限制
+}
+
+// TaskSummary 任务摘要，不包含断点续传标识和进度，可以用于运维页面展示
+type TaskSummary struct {
+	TaskID        string     `json:"task_id"`
+	Kind          string     `json:"kind"`
+	Status        TaskStatus `json:"status"`
+	LastEventID   uint64     `json:"last_event_id"`
+	DroppedEvents uint64     `json:"dropped_events"`
+	Subscribers   int        `json:"subscribers"` // 本实例上的订阅者数量
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+}
+
+// TaskList ListTasks 的查询结果
+type TaskList struct {
+	Tasks  []TaskSummary      `json:"tasks"`
+	Total  int                `json:"total"`  // 符合过滤条件的任务数量（分页前）
+	Counts map[TaskStatus]int `json:"counts"` // 本实例各状态的任务数量，不受过滤条件影响
+}
+
+// ListTasks 查询本实例上执行的任务，按创建时间倒序
+// 结束的任务保留到过期，可以按 Status 过滤
+func (m *SSEManager) ListTasks(filter TaskFilter) TaskList {
+	m.mu.RLock()
+	tasks := make([]*TaskInfo, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		tasks = append(tasks, task)
+	}
+	m.mu.RUnlock()
+
+	result := TaskList{Tasks: []TaskSummary{}, Counts: make(map[TaskStatus]int)}
+	matched := make([]TaskSummary, 0, len(tasks))
+	for _, task := range tasks {
+		task.mu.RLock()
+		summary := TaskSummary{
+			TaskID:        task.TaskID,
+			Kind:          task.Kind,
+			Status:        task.Status,
+			LastEventID:   task.LastEventID,
+			DroppedEvents: task.DroppedEvents,
+			Subscribers:   len(task.Subscribers),
+			CreatedAt:     task.CreatedAt,
+			UpdatedAt:     task.UpdatedAt,
+			ExpiresAt:     task.ExpiresAt,
+		}
+		_, subscribed := task.Subscribers[filter.SubscriberID]
+		task.mu.RUnlock()
+
+		result.Counts[summary.Status]++
+		if filter.Status != "" && summary.Status != filter.Status {
+			continue
+		}
+		if filter.Kind != "" && summary.Kind != filter.Kind {
+			continue
+		}
+		if filter.SubscriberID != "" && !subscribed {
+			continue
+		}
+		if !filter.CreatedAfter.IsZero() && !summary.CreatedAt.After(filter.CreatedAfter) {
+			continue
+		}
+		if !filter.CreatedBefore.IsZero() && !summary.CreatedAt.Before(filter.CreatedBefore) {
+			continue
+		}
+		matched = append(matched, summary)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].TaskID > matched[j].TaskID
+		}
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	result.Total = len(matched)
+	if filter.Offset > 0 {
+		if filter.Offset >= len(matched) {
+			return result
+		}
+		matched = matched[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(matched) {
+		matched = matched[:filter.Limit]
+	}
+	result.Tasks = matched
+	return result
+}
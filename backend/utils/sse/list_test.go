@@ -0,0 +1,55 @@
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestListTasks 测试按状态、类型、订阅者过滤任务和分页
+func TestListTasks(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	defer close(release)
+	running := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		<-release
+		return nil
+	}
+	finished := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		return nil
+	}
+
+	start := time.Now()
+	_, export1, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", running, 10*time.Second, WithTaskKind("export"))
+	_, export2, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", running, 10*time.Second, WithTaskKind("export"))
+	dataChan, _, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_3", finished, 10*time.Second, WithTaskKind("import"))
+	for range dataChan {
+	}
+
+	list := m.ListTasks(TaskFilter{Status: TaskStatusRunning, Kind: "export"})
+	if list.Total != 2 || len(list.Tasks) != 2 {
+		t.Fatalf("期望 2 个运行中的导出任务，实际为 %+v", list)
+	}
+	if list.Counts[TaskStatusRunning] != 2 || list.Counts[TaskStatusCompleted] != 1 {
+		t.Fatalf("各状态的任务数量不正确: %v", list.Counts)
+	}
+	// 按创建时间倒序
+	if list.Tasks[0].TaskID != export2 {
+		t.Fatalf("期望最新的任务在前: %+v", list.Tasks)
+	}
+
+	if list := m.ListTasks(TaskFilter{SubscriberID: "client_1"}); list.Total != 1 || list.Tasks[0].TaskID != export1 {
+		t.Fatalf("期望按订阅者查到任务 %s，实际为 %+v", export1, list)
+	}
+	if list := m.ListTasks(TaskFilter{Kind: "export", Offset: 1, Limit: 1}); list.Total != 2 || len(list.Tasks) != 1 {
+		t.Fatalf("分页结果不正确: %+v", list)
+	}
+	if list := m.ListTasks(TaskFilter{Offset: 10}); list.Total != 3 || len(list.Tasks) != 0 {
+		t.Fatalf("超出范围的分页应返回空列表: %+v", list)
+	}
+	if list := m.ListTasks(TaskFilter{CreatedBefore: start}); list.Total != 0 {
+		t.Fatalf("期望没有更早创建的任务: %+v", list)
+	}
+}
@@ -0,0 +1,111 @@
+package sse
+
+import (
+	"sync"
+	"time"
+
+	"backend/utils/metrics"
+)
+
+// 指标名称
+const (
+	CollectorName                 = "sse_manager"
+	TasksMetricName               = "sse_manager_tasks"
+	SubscribersMetricName         = "sse_manager_subscribers"
+	CachedEventsMetricName        = "sse_manager_cached_events"
+	DroppedEventsMetricName       = "sse_manager_dropped_events_total"
+	TaskDurationSecondsMetricName = "sse_manager_task_duration_seconds"
+)
+
+// 数据没有送达的原因
+const (
+	DropReasonEvicted        = "evicted"         // 超出 CacheLimit 没有保存或被淘汰，重连时无法补发
+	DropReasonSlowSubscriber = "slow_subscriber" // 订阅者通道已满，按投递策略跳过或丢弃
+	DropReasonCoalesced      = "coalesced"       // 按 WithCoalesce 合并时被更新的数据覆盖
+)
+
+// taskDurationBuckets 任务耗时的分桶上限（秒），导出、导入任务通常在秒到分钟级
+var taskDurationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800, 3600}
+
+// Collector SSEManager 的指标采集器
+// 任务数、订阅者数、已保存数据条数在采集前按本实例的任务刷新；丢弃数据条数和任务耗时在发生时记录
+type Collector struct {
+	m            *SSEManager
+	tasks        *metrics.GaugeVec
+	subscribers  *metrics.GaugeVec
+	cachedEvents *metrics.GaugeVec
+	dropped      *metrics.CounterVec
+	duration     *metrics.HistogramVec
+	mu           sync.Mutex // 串行刷新，避免并发采集时交错重置
+}
+
+// NewCollector 创建 SSEManager 的指标采集器并注册到 metrics，通过 /metrics 输出
+// 指标只统计本实例执行的任务，同一进程只应为一个管理器创建采集器（同名指标会覆盖）
+func NewCollector(m *SSEManager) *Collector {
+	c := &Collector{
+		m:            m,
+		tasks:        metrics.NewGaugeVec(TasksMetricName, "本实例保留的 SSE 任务数（结束的任务保留到过期）", "kind", "status"),
+		subscribers:  metrics.NewGaugeVec(SubscribersMetricName, "本实例上的 SSE 订阅者数", "kind"),
+		cachedEvents: metrics.NewGaugeVec(CachedEventsMetricName, "本实例任务保存的数据条数，用于断线重连补发", "kind"),
+		dropped:      metrics.NewCounterVec(DroppedEventsMetricName, "没有送达订阅者或没有保存的 SSE 数据条数", "kind", "reason"),
+		duration:     metrics.NewHistogramVec(TaskDurationSecondsMetricName, "SSE 任务从创建到结束的耗时（秒）", taskDurationBuckets, "kind", "status"),
+	}
+	m.collector.Store(c)
+	metrics.OnCollect(CollectorName, c.Collect)
+	return c
+}
+
+// Collect 按本实例当前的任务刷新仪表盘，由 metrics 在输出前调用
+func (c *Collector) Collect() {
+	type key struct {
+		kind   string
+		status TaskStatus
+	}
+	tasks := make(map[key]int64)
+	subscribers := make(map[string]int64)
+	cached := make(map[string]int64)
+
+	c.m.mu.RLock()
+	list := make([]*TaskInfo, 0, len(c.m.tasks))
+	for _, task := range c.m.tasks {
+		list = append(list, task)
+	}
+	c.m.mu.RUnlock()
+
+	for _, task := range list {
+		task.mu.RLock()
+		tasks[key{task.Kind, task.Status}]++
+		subscribers[task.Kind] += int64(len(task.Subscribers))
+		cached[task.Kind] += int64(len(task.cache.sizes))
+		task.mu.RUnlock()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tasks.Reset()
+	for k, v := range tasks {
+		c.tasks.Set(v, k.kind, string(k.status))
+	}
+	c.subscribers.Reset()
+	for kind, v := range subscribers {
+		c.subscribers.Set(v, kind)
+	}
+	c.cachedEvents.Reset()
+	for kind, v := range cached {
+		c.cachedEvents.Set(v, kind)
+	}
+}
+
+// observeDropped 记录没有送达或没有保存的数据条数，没有创建采集器时忽略
+func (m *SSEManager) observeDropped(kind string, reason string, n int) {
+	if c := m.collector.Load(); c != nil && n > 0 {
+		c.dropped.Add(int64(n), kind, reason)
+	}
+}
+
+// observeFinished 记录任务耗时，没有创建采集器时忽略
+func (m *SSEManager) observeFinished(kind string, status TaskStatus, d time.Duration) {
+	if c := m.collector.Load(); c != nil {
+		c.duration.Observe(d.Seconds(), kind, string(status))
+	}
+}
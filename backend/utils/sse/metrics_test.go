@@ -0,0 +1,77 @@
+package sse
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"backend/utils/metrics"
+)
+
+// TestCollector 测试采集器输出任务数、订阅者数、保存的数据条数、淘汰的数据条数和任务耗时
+func TestCollector(t *testing.T) {
+	m := NewSSEManager(time.Hour, WithCacheLimit(CacheLimit{MaxEvents: 2}))
+	defer m.Stop(context.Background())
+	c := NewCollector(m)
+	defer metrics.Unregister(CollectorName)
+
+	release := make(chan struct{})
+	defer close(release)
+	running := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 3; i++ {
+			_ = updateProgress(i)
+		}
+		<-release
+		return nil
+	}
+	finished := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		return nil
+	}
+
+	_, taskID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", running, 10*time.Second, WithTaskKind("export"))
+	dataChan, _, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", finished, 10*time.Second, WithTaskKind("import"))
+	for range dataChan {
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info, _ := m.GetTaskInfo(taskID)
+		if info.LastEventID == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("等待数据推送超时")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	c.Collect()
+	if got := c.tasks.Value("export", string(TaskStatusRunning)); got != 1 {
+		t.Errorf("期望 1 个运行中的导出任务，实际为 %d", got)
+	}
+	if got := c.tasks.Value("import", string(TaskStatusCompleted)); got != 1 {
+		t.Errorf("期望 1 个已完成的导入任务，实际为 %d", got)
+	}
+	if got := c.subscribers.Value("export"); got != 1 {
+		t.Errorf("期望 1 个订阅者，实际为 %d", got)
+	}
+	if got := c.cachedEvents.Value("export"); got != 2 {
+		t.Errorf("期望保存 2 条数据，实际为 %d", got)
+	}
+	if got := c.dropped.Value("export", DropReasonEvicted); got != 1 {
+		t.Errorf("期望淘汰 1 条数据，实际为 %d", got)
+	}
+
+	var b strings.Builder
+	if err := metrics.WritePrometheus(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		`sse_manager_tasks{kind="export",status="running"} 1`,
+		`sse_manager_task_duration_seconds_count{kind="import",status="completed"} 1`,
+	} {
+		if !strings.Contains(b.String(), line+"\n") {
+			t.Errorf("期望输出包含 %q:\n%s", line, b.String())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package sse
+
+import (
+	"context"
+	"errors"
+
+	"backend/utils/logs"
+)
+
+// errTaskPaused 任务已暂停，UpdateProgress 等待恢复后重试
+var errTaskPaused = errors.New("task is paused")
+
+// PauseTask 暂停运行中的任务，异步任务下一次调用 updateProgress 时阻塞，直到 ResumeTask 恢复
+// 任务的状态和已推送的数据保持不变，订阅者仍然连接，可以正常断线重连
+// 只能暂停本实例上执行的任务，其他实例上的任务返回 ErrTaskNotFound；不在运行状态时返回 ErrTaskNotRunning
+// 暂停期间 asyncTimeout 仍然计时，超时后 updateProgress 返回 context 错误
+func (m *SSEManager) PauseTask(ctx context.Context, taskID string) error {
+	return m.setPaused(ctx, taskID, true)
+}
+
+// ResumeTask 恢复已暂停的任务，阻塞中的 updateProgress 继续执行；不在暂停状态时返回 ErrTaskNotPaused
+func (m *SSEManager) ResumeTask(ctx context.Context, taskID string) error {
+	return m.setPaused(ctx, taskID, false)
+}
+
+func (m *SSEManager) setPaused(ctx context.Context, taskID string, paused bool) error {
+	m.mu.RLock()
+	task, exists := m.tasks[taskID]
+	m.mu.RUnlock()
+	if !exists {
+		return ErrTaskNotFound
+	}
+
+	task.mu.Lock()
+	if paused {
+		if task.Status != TaskStatusRunning {
+			task.mu.Unlock()
+			return ErrTaskNotRunning
+		}
+		task.Status = TaskStatusPaused
+		task.resumed = make(chan struct{})
+	} else {
+		if task.Status != TaskStatusPaused {
+			task.mu.Unlock()
+			return ErrTaskNotPaused
+		}
+		task.Status = TaskStatusRunning
+		close(task.resumed)
+		task.resumed = nil
+	}
+	task.touch()
+	record := task.record()
+	task.mu.Unlock()
+
+	// 同步状态到任务存储，供其他实例查询
+	storeCtx, cancel := storeContext()
+	defer cancel()
+	if err := m.store.Save(storeCtx, record); err != nil {
+		logs.CtxWarnf(ctx, "保存 SSE 任务状态失败: task_id=%s, status=%s, error=%s", taskID, record.Status, err.Error())
+	}
+	return nil
+}
+
+// waitResumed 等待任务恢复或结束，任务不在暂停状态时直接返回
+func (m *SSEManager) waitResumed(ctx context.Context, taskID string) error {
+	m.mu.RLock()
+	task, exists := m.tasks[taskID]
+	m.mu.RUnlock()
+	if !exists {
+		return ErrTaskNotFound
+	}
+
+	task.mu.RLock()
+	resumed := task.resumed
+	task.mu.RUnlock()
+	if resumed == nil {
+		return nil
+	}
+
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// PauseTask 使用默认管理器暂停任务
+func PauseTask(ctx context.Context, taskID string) error {
+	return getDefaultManager().PauseTask(ctx, taskID)
+}
+
+// ResumeTask 使用默认管理器恢复任务
+func ResumeTask(ctx context.Context, taskID string) error {
+	return getDefaultManager().ResumeTask(ctx, taskID)
+}
@@ -0,0 +1,106 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestPauseResume 测试暂停后 updateProgress 阻塞，恢复后继续推送
+func TestPauseResume(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	step := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 3; i++ {
+			<-step
+			if err := updateProgress(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	step <- struct{}{}
+	if event := <-dataChan; event.ID != 1 {
+		t.Fatalf("期望事件 1，实际为 %+v", event)
+	}
+
+	if err := m.PauseTask(context.Background(), taskID); err != nil {
+		t.Fatalf("暂停任务失败: %v", err)
+	}
+	if err := m.PauseTask(context.Background(), taskID); !errors.Is(err, ErrTaskNotRunning) {
+		t.Fatalf("重复暂停应返回 ErrTaskNotRunning，实际为 %v", err)
+	}
+	step <- struct{}{}
+	select {
+	case event := <-dataChan:
+		t.Fatalf("暂停期间不应推送数据: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if info, _ := m.GetTaskInfo(taskID); info.Status != TaskStatusPaused || info.LastEventID != 1 {
+		t.Fatalf("期望任务已暂停且进度不变，实际为 %s, %d", info.Status, info.LastEventID)
+	}
+	if _, err := m.GetTaskResult(taskID); !errors.Is(err, ErrTaskNotFinished) {
+		t.Fatalf("暂停的任务没有最终结果，实际为 %v", err)
+	}
+
+	if err := m.ResumeTask(context.Background(), taskID); err != nil {
+		t.Fatalf("恢复任务失败: %v", err)
+	}
+	if err := m.ResumeTask(context.Background(), taskID); !errors.Is(err, ErrTaskNotPaused) {
+		t.Fatalf("重复恢复应返回 ErrTaskNotPaused，实际为 %v", err)
+	}
+	if event := <-dataChan; event.ID != 2 {
+		t.Fatalf("期望恢复后推送事件 2，实际为 %+v", event)
+	}
+	step <- struct{}{}
+	if event := <-dataChan; event.ID != 3 {
+		t.Fatalf("期望事件 3，实际为 %+v", event)
+	}
+	for range dataChan {
+	}
+	if info, _ := m.GetTaskInfo(taskID); info.Status != TaskStatusCompleted {
+		t.Fatalf("期望任务完成，实际为 %s", info.Status)
+	}
+}
+
+// TestCancelPausedTask 测试取消暂停中的任务时 updateProgress 返回 ErrTaskNotRunning
+func TestCancelPausedTask(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	result := make(chan error, 1)
+	started := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		<-started
+		err := updateProgress(1)
+		result <- err
+		return err
+	}
+	dataChan, taskID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+
+	if err := m.PauseTask(context.Background(), taskID); err != nil {
+		t.Fatalf("暂停任务失败: %v", err)
+	}
+	close(started)
+	time.Sleep(20 * time.Millisecond)
+	m.CompleteTask(context.Background(), taskID, TaskStatusCancelled)
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrTaskNotRunning) {
+			t.Fatalf("期望 ErrTaskNotRunning，实际为 %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("取消后 updateProgress 应返回")
+	}
+	for range dataChan {
+	}
+}
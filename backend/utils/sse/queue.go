@@ -0,0 +1,147 @@
+package sse
+
+import (
+	"context"
+
+	"backend/utils/logs"
+)
+
+// QueuedEventName 排队数据的 SSE 事件名称（event 行）
+const QueuedEventName = "queued"
+
+// QueuePosition 任务排队期间推送给订阅者的排队位置，位置变化时推送，任务开始执行后不再推送
+// 与保活数据一样不分配事件ID、不保存到任务存储
+type QueuePosition struct {
+	TaskID   string `json:"task_id"`
+	Position int    `json:"position"` // 排队位置，1 表示下一个执行
+	Queued   int    `json:"queued"`   // 排队中的任务总数
+}
+
+// WithMaxConcurrentTasks 设置本实例同时执行的任务数上限，默认为 0 不限制
+// 超出上限的新任务状态为 TaskStatusQueued，按创建顺序排队，有任务结束时开始执行
+func WithMaxConcurrentTasks(n int) Option {
+	return func(m *SSEManager) {
+		m.maxRunning = n
+	}
+}
+
+// WithoutConcurrencyLimit 该任务不受 WithMaxConcurrentTasks 限制，也不占用名额，只在创建任务时生效
+// 用于常驻的广播任务或只转发其他队列进度的任务
+func WithoutConcurrencyLimit() TaskOption {
+	return func(o *taskOptions) {
+		o.unlimited = true
+	}
+}
+
+// queuedTask 排队中的任务
+type queuedTask struct {
+	task   *TaskInfo
+	launch func() // 开始执行异步任务
+}
+
+// reserve 为新任务占用一个名额，没有空闲名额时返回 false，任务需要排队
+func (m *SSEManager) reserve() bool {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+	if m.active < m.maxRunning && len(m.queue) == 0 {
+		m.active++
+		return true
+	}
+	return false
+}
+
+// enqueue 任务排队，排队期间已有任务结束空出名额时直接执行
+func (m *SSEManager) enqueue(ctx context.Context, task *TaskInfo, launch func()) {
+	m.queueMu.Lock()
+	if m.active < m.maxRunning && len(m.queue) == 0 {
+		m.active++
+		m.queueMu.Unlock()
+		m.dequeued(task, launch)
+		return
+	}
+	m.queue = append(m.queue, queuedTask{task: task, launch: launch})
+	positions := m.positions()
+	m.queueMu.Unlock()
+
+	logs.CtxInfof(ctx, "SSE 任务排队: task_id=%s, position=%d", task.TaskID, len(positions))
+	m.notifyQueued(positions)
+}
+
+// release 任务结束后释放名额，按创建顺序执行排队的任务
+func (m *SSEManager) release() {
+	var next []queuedTask
+	m.queueMu.Lock()
+	m.active--
+	// 管理器停止后排队的任务不再执行，由 Stop 取消
+	for !m.isStopped() && m.active < m.maxRunning && len(m.queue) > 0 {
+		next = append(next, m.queue[0])
+		m.queue = m.queue[1:]
+		m.active++
+	}
+	positions := m.positions()
+	m.queueMu.Unlock()
+
+	for _, q := range next {
+		m.dequeued(q.task, q.launch)
+	}
+	m.notifyQueued(positions)
+}
+
+// remove 排队中的任务被结束（如 CompleteTask 取消），从队列中移除
+func (m *SSEManager) remove(task *TaskInfo) {
+	m.queueMu.Lock()
+	for i, q := range m.queue {
+		if q.task == task {
+			m.queue = append(m.queue[:i:i], m.queue[i+1:]...)
+			break
+		}
+	}
+	positions := m.positions()
+	m.queueMu.Unlock()
+
+	m.notifyQueued(positions)
+}
+
+// dequeued 排队的任务开始执行，排队期间已被结束的任务释放名额
+func (m *SSEManager) dequeued(task *TaskInfo, launch func()) {
+	task.mu.Lock()
+	if task.Status != TaskStatusQueued {
+		task.mu.Unlock()
+		m.release()
+		return
+	}
+	task.Status = TaskStatusRunning
+	task.touch()
+	record := task.record()
+	task.mu.Unlock()
+
+	ctx, cancel := storeContext()
+	if err := m.store.Save(ctx, record); err != nil {
+		logs.CtxWarnf(ctx, "保存 SSE 任务状态失败: task_id=%s, status=%s, error=%s", task.TaskID, record.Status, err.Error())
+	}
+	cancel()
+	launch()
+}
+
+// positions 当前排队的任务及其位置，调用方持有 queueMu
+func (m *SSEManager) positions() map[*TaskInfo]*QueuePosition {
+	positions := make(map[*TaskInfo]*QueuePosition, len(m.queue))
+	for i, q := range m.queue {
+		positions[q.task] = &QueuePosition{TaskID: q.task.TaskID, Position: i + 1, Queued: len(m.queue)}
+	}
+	return positions
+}
+
+// position 任务的排队位置，任务不在排队时返回 nil
+func (m *SSEManager) position(task *TaskInfo) *QueuePosition {
+	m.queueMu.Lock()
+	defer m.queueMu.Unlock()
+	return m.positions()[task]
+}
+
+// notifyQueued 把排队位置推送给排队任务的订阅者
+func (m *SSEManager) notifyQueued(positions map[*TaskInfo]*QueuePosition) {
+	for task, position := range positions {
+		m.dispatch(task, Event{Queued: position})
+	}
+}
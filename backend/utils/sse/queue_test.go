@@ -0,0 +1,98 @@
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// nextEvent 读取下一条数据，超时时测试失败
+func nextEvent(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case event, ok := <-ch:
+		if !ok {
+			t.Fatal("通道已关闭")
+		}
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("等待数据超时")
+	}
+	return Event{}
+}
+
+// TestMaxConcurrentTasks 测试超出并发上限的任务按创建顺序排队，排队期间推送排队位置
+func TestMaxConcurrentTasks(t *testing.T) {
+	m := NewSSEManager(time.Hour, WithMaxConcurrentTasks(1))
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(taskID)
+		<-release
+		return nil
+	}
+
+	chA, taskA, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_a", asyncTask, 10*time.Second)
+	if event := nextEvent(t, chA); event.Data != taskA {
+		t.Fatalf("期望任务 A 开始执行，实际为 %+v", event)
+	}
+
+	chB, taskB, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_b", asyncTask, 10*time.Second)
+	if event := nextEvent(t, chB); event.Queued == nil || event.Queued.Position != 1 {
+		t.Fatalf("期望任务 B 排在第 1 位，实际为 %+v", event)
+	}
+	chC, taskC, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_c", asyncTask, 10*time.Second)
+	if event := nextEvent(t, chC); event.Queued == nil || event.Queued.Position != 2 || event.Queued.Queued != 2 {
+		t.Fatalf("期望任务 C 排在第 2 位，实际为 %+v", event)
+	}
+	if info, _ := m.GetTaskInfo(taskC); info.Status != TaskStatusQueued {
+		t.Fatalf("期望任务 C 排队中，实际为 %s", info.Status)
+	}
+
+	// 不受并发上限限制的任务直接执行
+	chD, taskD, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_d", asyncTask, 10*time.Second, WithoutConcurrencyLimit())
+	if event := nextEvent(t, chD); event.Data != taskD {
+		t.Fatalf("期望任务 D 直接执行，实际为 %+v", event)
+	}
+
+	// 重连到排队中的任务时先收到当前的排队位置
+	info, _ := m.GetTaskInfo(taskB)
+	chB2, _, _ := m.ExecuteWithSSE(context.Background(), info.ResumeKey, 0, "client_b2", asyncTask, 10*time.Second)
+	if event := nextEvent(t, chB2); event.Queued == nil || event.Queued.Position != 1 {
+		t.Fatalf("期望重连后收到排队位置，实际为 %+v", event)
+	}
+
+	// 任务 A 结束后任务 B 开始执行，任务 C 排到第 1 位
+	m.CompleteTask(context.Background(), taskA, TaskStatusCompleted)
+	for range chA {
+	}
+	for {
+		event := nextEvent(t, chB)
+		if event.Queued != nil {
+			continue
+		}
+		if event.Data != taskB {
+			t.Fatalf("期望任务 B 开始执行，实际为 %+v", event)
+		}
+		break
+	}
+	for {
+		event := nextEvent(t, chC)
+		if event.Queued != nil && event.Queued.Position == 1 {
+			break
+		}
+	}
+
+	// 取消排队中的任务 C
+	m.CompleteTask(context.Background(), taskC, TaskStatusCancelled)
+	for range chC {
+	}
+	m.queueMu.Lock()
+	queued := len(m.queue)
+	m.queueMu.Unlock()
+	if queued != 0 {
+		t.Fatalf("期望队列为空，实际为 %d", queued)
+	}
+	close(release)
+}
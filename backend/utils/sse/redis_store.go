@@ -0,0 +1,283 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/safego"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisPrefix RedisStore 默认的键前缀
+const DefaultRedisPrefix = "sse:"
+
+// RedisStore 基于 Redis 的任务存储，多个实例共享任务元数据、推送过的数据和订阅者，实时数据通过 Pub/Sub 在实例间转发
+//
+// 键（均在任务过期时间后自动删除）:
+//   - {prefix}task:{taskID}        任务元数据（JSON）
+//   - {prefix}resume:{resumeKey}   断点续传标识对应的任务ID
+//   - {prefix}events:{taskID}      推送过的数据（List，JSON）
+//   - {prefix}subs:{taskID}        订阅者ID（Set）
+//   - {prefix}live:{taskID}        实时数据频道（Pub/Sub）
+//
+// 数据以 JSON 保存，从 Redis 读出的数据和进度为 json.RawMessage，可以使用 Decode 转换
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// redisEvent Redis 中保存的数据和 Pub/Sub 消息，Done 为 true 表示任务结束
+type redisEvent struct {
+	ID   uint64          `json:"id,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+	Done bool            `json:"done,omitempty"`
+}
+
+func encodeEvent(event Event) ([]byte, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(redisEvent{ID: event.ID, Data: data})
+}
+
+// NewRedisStore 创建 Redis 任务存储，prefix 为空时使用 DefaultRedisPrefix
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+func (s *RedisStore) taskKey(taskID string) string      { return s.prefix + "task:" + taskID }
+func (s *RedisStore) resumeKey(resumeKey string) string { return s.prefix + "resume:" + resumeKey }
+func (s *RedisStore) idempotencyKey(key string) string  { return s.prefix + "idem:" + key }
+func (s *RedisStore) eventsKey(taskID string) string    { return s.prefix + "events:" + taskID }
+func (s *RedisStore) subsKey(taskID string) string      { return s.prefix + "subs:" + taskID }
+func (s *RedisStore) channel(taskID string) string      { return s.prefix + "live:" + taskID }
+
+// ttl 任务剩余的有效期，已过期的任务保留一分钟，便于返回 ErrTaskExpired
+func ttl(expiresAt time.Time) time.Duration {
+	if d := time.Until(expiresAt); d > time.Minute {
+		return d
+	}
+	return time.Minute
+}
+
+func (s *RedisStore) Save(ctx context.Context, record *TaskRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	expiration := ttl(record.ExpiresAt)
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.taskKey(record.TaskID), b, expiration)
+		pipe.Set(ctx, s.resumeKey(record.ResumeKey), record.TaskID, expiration)
+		if record.IdempotencyKey != "" {
+			pipe.Set(ctx, s.idempotencyKey(record.IdempotencyKey), record.TaskID, expiration)
+		}
+		// 滑动过期的任务延长过期时间时，已保存的数据和订阅者一起延长
+		pipe.Expire(ctx, s.eventsKey(record.TaskID), expiration)
+		pipe.Expire(ctx, s.subsKey(record.TaskID), expiration)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Get(ctx context.Context, taskID string) (*TaskRecord, error) {
+	b, err := s.client.Get(ctx, s.taskKey(taskID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		TaskRecord
+		Progress json.RawMessage `json:"progress,omitempty"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	record := raw.TaskRecord
+	if len(raw.Progress) > 0 {
+		record.Progress = raw.Progress
+	}
+	return &record, nil
+}
+
+func (s *RedisStore) FindByResumeKey(ctx context.Context, resumeKey string) (*TaskRecord, error) {
+	taskID, err := s.client.Get(ctx, s.resumeKey(resumeKey)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, taskID)
+}
+
+func (s *RedisStore) FindByIdempotencyKey(ctx context.Context, key string) (*TaskRecord, error) {
+	taskID, err := s.client.Get(ctx, s.idempotencyKey(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, taskID)
+}
+
+func (s *RedisStore) Delete(ctx context.Context, taskID string) error {
+	record, err := s.Get(ctx, taskID)
+	if err != nil && !errors.Is(err, ErrTaskNotFound) {
+		return err
+	}
+	keys := []string{s.taskKey(taskID), s.eventsKey(taskID), s.subsKey(taskID)}
+	if record != nil {
+		keys = append(keys, s.resumeKey(record.ResumeKey))
+		// 同一个幂等键后来创建的任务不受影响
+		if record.IdempotencyKey != "" {
+			if owner, _ := s.client.Get(ctx, s.idempotencyKey(record.IdempotencyKey)).Result(); owner == taskID {
+				keys = append(keys, s.idempotencyKey(record.IdempotencyKey))
+			}
+		}
+	}
+	return s.client.Del(ctx, keys...).Err()
+}
+
+func (s *RedisStore) AppendEvent(ctx context.Context, taskID string, event Event) error {
+	b, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+	expiration, err := s.expiration(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.RPush(ctx, s.eventsKey(taskID), b)
+		pipe.Expire(ctx, s.eventsKey(taskID), expiration)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) Events(ctx context.Context, taskID string, afterID uint64) ([]Event, error) {
+	values, err := s.client.LRange(ctx, s.eventsKey(taskID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(values))
+	for _, v := range values {
+		var stored redisEvent
+		if err := json.Unmarshal([]byte(v), &stored); err != nil {
+			return nil, err
+		}
+		if stored.ID > afterID {
+			events = append(events, Event{ID: stored.ID, Data: stored.Data})
+		}
+	}
+	return events, nil
+}
+
+func (s *RedisStore) TrimEvents(ctx context.Context, taskID string, count int) error {
+	return s.client.LTrim(ctx, s.eventsKey(taskID), int64(count), -1).Err()
+}
+
+func (s *RedisStore) AddSubscriber(ctx context.Context, taskID string, subscriberID string) error {
+	expiration, err := s.expiration(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SAdd(ctx, s.subsKey(taskID), subscriberID)
+		pipe.Expire(ctx, s.subsKey(taskID), expiration)
+		return nil
+	})
+	return err
+}
+
+func (s *RedisStore) RemoveSubscriber(ctx context.Context, taskID string, subscriberID string) error {
+	return s.client.SRem(ctx, s.subsKey(taskID), subscriberID).Err()
+}
+
+func (s *RedisStore) CountSubscribers(ctx context.Context, taskID string) (int, error) {
+	n, err := s.client.SCard(ctx, s.subsKey(taskID)).Result()
+	return int(n), err
+}
+
+func (s *RedisStore) Publish(ctx context.Context, taskID string, event Event) error {
+	b, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+	return s.client.Publish(ctx, s.channel(taskID), b).Err()
+}
+
+func (s *RedisStore) Subscribe(ctx context.Context, taskID string) (<-chan Event, error) {
+	pubsub := s.client.Subscribe(ctx, s.channel(taskID))
+	// 等待订阅确认，确保之后 Publish 的数据不会丢失
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	out := make(chan Event, 100)
+	safego.Go(ctx, func() {
+		defer close(out)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case msg, ok := <-messages:
+				if !ok {
+					return
+				}
+				var event redisEvent
+				if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+					logs.CtxWarnf(ctx, "解析 SSE 实时数据失败: task_id=%s, error=%s", taskID, err.Error())
+					continue
+				}
+				if event.Done {
+					return
+				}
+				select {
+				case out <- Event{ID: event.ID, Data: event.Data}:
+				default:
+					// 接收方通道已满，跳过
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+	return out, nil
+}
+
+func (s *RedisStore) Close(ctx context.Context, taskID string) error {
+	b, err := json.Marshal(redisEvent{Done: true})
+	if err != nil {
+		return err
+	}
+	return s.client.Publish(ctx, s.channel(taskID), b).Err()
+}
+
+// expiration 数据和订阅者的过期时间与任务元数据一致
+func (s *RedisStore) expiration(ctx context.Context, taskID string) (time.Duration, error) {
+	d, err := s.client.PTTL(ctx, s.taskKey(taskID)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, ErrTaskNotFound
+	}
+	return d, nil
+}
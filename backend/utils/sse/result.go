@@ -0,0 +1,46 @@
+package sse
+
+import "time"
+
+// TaskResult 已结束任务的最终结果
+type TaskResult struct {
+	TaskID      string      `json:"task_id"`
+	Kind        string      `json:"kind"`
+	Status      TaskStatus  `json:"status"`
+	Result      interface{} `json:"result"`          // 最后一次推送的数据；共享存储中读出的为 json.RawMessage，可以使用 Decode 转换
+	Error       string      `json:"error,omitempty"` // 异步任务返回的错误
+	LastEventID uint64      `json:"last_event_id"`   // 最后一条数据的事件ID
+	CompletedAt time.Time   `json:"completed_at"`
+	ExpiresAt   time.Time   `json:"expires_at"` // 结果保留到任务过期
+}
+
+// GetTaskResult 获取已结束任务的最终结果
+// 任务结束后保留到过期，客户端在任务结束后重连（ExecuteWithSSE 返回 ErrTaskNotRunning）时可以通过此方法获取结果
+// 任务还在运行时返回 ErrTaskNotFinished，已过期时返回 ErrTaskExpired
+func (m *SSEManager) GetTaskResult(taskID string) (*TaskResult, error) {
+	info, err := m.GetTaskInfo(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Status.Finished() {
+		return nil, ErrTaskNotFinished
+	}
+	if info.ExpiresAt.Before(time.Now()) {
+		return nil, ErrTaskExpired
+	}
+	return &TaskResult{
+		TaskID:      info.TaskID,
+		Kind:        info.Kind,
+		Status:      info.Status,
+		Result:      info.Progress,
+		Error:       info.Error,
+		LastEventID: info.LastEventID,
+		CompletedAt: info.UpdatedAt,
+		ExpiresAt:   info.ExpiresAt,
+	}, nil
+}
+
+// GetTaskResult 使用默认管理器获取已结束任务的最终结果
+func GetTaskResult(taskID string) (*TaskResult, error) {
+	return getDefaultManager().GetTaskResult(taskID)
+}
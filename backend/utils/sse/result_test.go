@@ -0,0 +1,73 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestGetTaskResult 测试任务结束后仍可以获取最终结果，重连返回 ErrTaskNotRunning
+func TestGetTaskResult(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress("half")
+		<-release
+		_ = updateProgress("done")
+		return nil
+	}
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second, WithTaskKind("export"))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	<-dataChan
+	if _, err := m.GetTaskResult(taskID); !errors.Is(err, ErrTaskNotFinished) {
+		t.Fatalf("运行中的任务期望返回 ErrTaskNotFinished，实际为 %v", err)
+	}
+	close(release)
+	for range dataChan {
+	}
+
+	info, _ := m.GetTaskInfo(taskID)
+	if _, _, err := m.ExecuteWithSSE(context.Background(), info.ResumeKey, 0, "client_2", asyncTask, 10*time.Second); !errors.Is(err, ErrTaskNotRunning) {
+		t.Fatalf("期望返回 ErrTaskNotRunning，实际为 %v", err)
+	}
+	result, err := m.GetTaskResult(taskID)
+	if err != nil {
+		t.Fatalf("获取任务结果失败: %v", err)
+	}
+	if result.Status != TaskStatusCompleted || result.Result != "done" || result.LastEventID != 2 || result.Kind != "export" {
+		t.Fatalf("任务结果不正确: %+v", result)
+	}
+}
+
+// TestGetTaskResultFailed 测试失败任务的结果包含错误信息，其他实例也可以查询
+func TestGetTaskResultFailed(t *testing.T) {
+	store := NewMemoryStore()
+	owner := NewSSEManager(time.Hour, WithStore(store))
+	defer owner.Stop(context.Background())
+	other := NewSSEManager(time.Hour, WithStore(store))
+	defer other.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		return errors.New("disk full")
+	}
+	dataChan, taskID, err := owner.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	for range dataChan {
+	}
+
+	result, err := other.GetTaskResult(taskID)
+	if err != nil {
+		t.Fatalf("另一个实例获取任务结果失败: %v", err)
+	}
+	if result.Status != TaskStatusFailed || result.Error != "disk full" || result.Result != 1 {
+		t.Fatalf("任务结果不正确: %+v", result)
+	}
+}
@@ -0,0 +1,74 @@
+package sse
+
+import (
+	"context"
+	"time"
+
+	"backend/utils/logs"
+)
+
+// WithFailedTaskTTL 设置失败任务的保留时间，任务失败后从失败时起保留 ttl，默认与其他任务一样保留到过期
+// 失败的任务通常只需要保留到客户端获取错误或调用 RetryTask，缩短保留时间可以尽早释放保存的数据；
+// 过期任务每 5 分钟清理一次，实际删除时间最多晚 5 分钟
+func WithFailedTaskTTL(ttl time.Duration) Option {
+	return func(m *SSEManager) {
+		m.failedTTL = ttl
+	}
+}
+
+// RetryTask 重新执行失败的任务，返回新任务的ID
+// 新任务沿用失败任务的异步任务、超时时间、配置选项和断点续传标识，客户端带原来的 resumeKey 重连即可订阅新任务；
+// 失败的任务及其保存的数据随之删除，失败原因在重试前可以通过 GetTaskInfo 或 GetTaskResult 获取
+// 只有创建任务的实例保存了异步任务，其他实例上的任务返回 ErrTaskNotFound；任务没有失败时返回 ErrTaskNotFailed
+func (m *SSEManager) RetryTask(ctx context.Context, taskID string) (string, error) {
+	if !m.track() {
+		return "", ErrManagerStopped
+	}
+	defer m.wg.Done()
+
+	// 先从本实例移除失败的任务，并发重试同一个任务时只有一次生效
+	m.mu.Lock()
+	task, exists := m.tasks[taskID]
+	if !exists {
+		m.mu.Unlock()
+		return "", ErrTaskNotFound
+	}
+	task.mu.RLock()
+	status := task.Status
+	expired := task.ExpiresAt.Before(time.Now())
+	task.mu.RUnlock()
+	if expired {
+		m.mu.Unlock()
+		return "", ErrTaskExpired
+	}
+	if status != TaskStatusFailed {
+		m.mu.Unlock()
+		return "", ErrTaskNotFailed
+	}
+	delete(m.tasks, taskID)
+	m.mu.Unlock()
+
+	// 删除失败任务的断点续传标识后，新任务才能沿用
+	storeCtx, cancel := storeContext()
+	if err := m.store.Delete(storeCtx, taskID); err != nil {
+		logs.CtxWarnf(ctx, "删除失败的 SSE 任务失败: task_id=%s, error=%s", taskID, err.Error())
+	}
+	cancel()
+
+	retried, err := m.newTask(ctx, task.ResumeKey, task.asyncFunc, task.asyncTimeout, task.options)
+	if err != nil {
+		return "", err
+	}
+	retried.mu.RLock()
+	queued := retried.Status == TaskStatusQueued
+	retried.mu.RUnlock()
+	m.start(ctx, retried, queued)
+
+	logs.CtxInfof(ctx, "重试失败的 SSE 任务: task_id=%s, retry_task_id=%s", taskID, retried.TaskID)
+	return retried.TaskID, nil
+}
+
+// RetryTask 使用默认管理器重新执行失败的任务
+func RetryTask(ctx context.Context, taskID string) (string, error) {
+	return getDefaultManager().RetryTask(ctx, taskID)
+}
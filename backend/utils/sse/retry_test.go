@@ -0,0 +1,85 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestRetryTask 测试失败的任务保留错误信息，重试后沿用 resumeKey，续传到新任务
+func TestRetryTask(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	var runs atomic.Int32
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		n := runs.Add(1)
+		_ = updateProgress(n)
+		if n == 1 {
+			return errors.New("下游服务不可用")
+		}
+		<-release
+		return nil
+	}
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0, WithTaskKind("export"))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	for range dataChan {
+	}
+	failed, _ := m.GetTaskInfo(taskID)
+	if failed.Status != TaskStatusFailed || failed.Error != "下游服务不可用" {
+		t.Fatalf("期望任务失败并保留错误信息，实际为 %s, %q", failed.Status, failed.Error)
+	}
+
+	retryID, err := m.RetryTask(context.Background(), taskID)
+	if err != nil || retryID == taskID {
+		t.Fatalf("重试失败: %s, %v", retryID, err)
+	}
+	if _, err := m.GetTaskInfo(taskID); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("重试后应删除失败的任务，实际为 %v", err)
+	}
+
+	// 带原来的 resumeKey 续传到新任务
+	resumed, resumedID, err := m.ExecuteWithSSE(context.Background(), failed.ResumeKey, 0, "client_2", asyncTask, 0)
+	if err != nil || resumedID != retryID {
+		t.Fatalf("期望续传到任务 %s，实际为 %s, %v", retryID, resumedID, err)
+	}
+	if event := nextEvent(t, resumed); event.ID != 1 || event.Data != int32(2) {
+		t.Fatalf("期望收到新任务的数据，实际为 %+v", event)
+	}
+	close(release)
+	for range resumed {
+	}
+	retried, _ := m.GetTaskInfo(retryID)
+	if retried.ResumeKey != failed.ResumeKey || retried.Kind != "export" || retried.Status != TaskStatusCompleted {
+		t.Fatalf("新任务应沿用断点续传标识和配置选项: %+v", retried)
+	}
+
+	if _, err := m.RetryTask(context.Background(), retryID); !errors.Is(err, ErrTaskNotFailed) {
+		t.Fatalf("成功的任务期望返回 ErrTaskNotFailed，实际为 %v", err)
+	}
+	if _, err := m.RetryTask(context.Background(), taskID); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("已重试的任务期望返回 ErrTaskNotFound，实际为 %v", err)
+	}
+}
+
+// TestFailedTaskTTL 测试失败的任务按 WithFailedTaskTTL 缩短保留时间
+func TestFailedTaskTTL(t *testing.T) {
+	m := NewSSEManager(time.Hour, WithFailedTaskTTL(time.Minute))
+	defer m.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		return errors.New("失败")
+	}
+	dataChan, taskID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0)
+	for range dataChan {
+	}
+	info, _ := m.GetTaskInfo(taskID)
+	if d := info.ExpiresAt.Sub(info.UpdatedAt); d != time.Minute {
+		t.Fatalf("期望失败任务保留 1m，实际为 %s", d)
+	}
+}
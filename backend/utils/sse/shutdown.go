@@ -0,0 +1,90 @@
+package sse
+
+import (
+	"context"
+
+	"backend/utils/logs"
+)
+
+// ShutdownEventName 服务关闭时订阅者收到的最后一条数据的 SSE 事件名称（event 行），数据为 ErrServerShutdown
+const ShutdownEventName = "server_shutdown"
+
+// Stop 停止管理器：不再创建或续传任务，取消本实例上未结束的任务（状态为 TaskStatusCancelled，
+// 错误为 ErrServerShutdown），向订阅者发送 ShutdownEventName 事件后关闭通道，
+// 等待数据转发 goroutine 退出，ctx 结束时不再等待并返回 ctx.Err()
+// 异步任务的 context 被取消，忽略 context 的任务在 Stop 返回后继续执行，推送的数据不再保存
+func (m *SSEManager) Stop(ctx context.Context) error {
+	var tasks []*TaskInfo
+	m.stopOnce.Do(func() {
+		if m.cleanupTick != nil {
+			m.cleanupTick.Stop()
+		}
+		m.mu.Lock()
+		close(m.stopped)
+		for _, task := range m.tasks {
+			tasks = append(tasks, task)
+		}
+		m.mu.Unlock()
+	})
+
+	cancelled := 0
+	for _, task := range tasks {
+		task.mu.Lock()
+		if task.Status.Finished() {
+			task.mu.Unlock()
+			continue
+		}
+		// 订阅者通道中剩余的数据转发完后发送 ErrServerShutdown
+		for _, sub := range task.subscribers {
+			if sub.err == nil {
+				sub.err = ErrServerShutdown
+			}
+		}
+		cancel := task.cancel
+		task.mu.Unlock()
+
+		if cancel != nil {
+			cancel()
+		}
+		m.complete(ctx, task.TaskID, TaskStatusCancelled, ErrServerShutdown)
+		cancelled++
+	}
+	if cancelled > 0 {
+		logs.CtxInfof(ctx, "SSE 管理器停止，取消未结束的任务: count=%d", cancelled)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		logs.CtxWarnf(ctx, "等待 SSE 数据转发结束超时: error=%s", ctx.Err().Error())
+		return ctx.Err()
+	}
+}
+
+// track 管理器未停止时把本次调用计入 wg，调用方结束时调用 m.wg.Done()
+// 持有 mu 判断，保证 Stop 关闭 stopped 之后不再有新的计数
+func (m *SSEManager) track() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.isStopped() {
+		return false
+	}
+	m.wg.Add(1)
+	return true
+}
+
+// isStopped 管理器是否已停止
+func (m *SSEManager) isStopped() bool {
+	select {
+	case <-m.stopped:
+		return true
+	default:
+		return false
+	}
+}
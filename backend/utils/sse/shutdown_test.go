@@ -0,0 +1,93 @@
+package sse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestStop 测试停止时取消运行中和排队中的任务，订阅者收到 ErrServerShutdown 后通道关闭
+func TestStop(t *testing.T) {
+	m := NewSSEManager(time.Hour, WithMaxConcurrentTasks(1))
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(taskID)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	running, runningID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0)
+	if event := nextEvent(t, running); event.Data != runningID {
+		t.Fatalf("期望任务开始执行，实际为 %+v", event)
+	}
+	queued, queuedID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", asyncTask, 0)
+	if event := nextEvent(t, queued); event.Queued == nil {
+		t.Fatalf("期望任务排队，实际为 %+v", event)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.Stop(ctx); err != nil {
+		t.Fatalf("停止管理器失败: %v", err)
+	}
+
+	for _, ch := range []<-chan Event{running, queued} {
+		event := nextEvent(t, ch)
+		// 取消运行中的任务时排队位置可能再推送一次
+		for event.Queued != nil {
+			event = nextEvent(t, ch)
+		}
+		if !errors.Is(event.Err, ErrServerShutdown) || event.SSEEventName() != ShutdownEventName {
+			t.Fatalf("期望收到 ErrServerShutdown，实际为 %+v", event)
+		}
+		if _, ok := <-ch; ok {
+			t.Fatal("期望通道已关闭")
+		}
+	}
+	for _, taskID := range []string{runningID, queuedID} {
+		// 异步任务返回的 context 错误不会把状态改为失败
+		info, _ := m.GetTaskInfo(taskID)
+		if info.Status != TaskStatusCancelled || info.Error != ErrServerShutdown.Error() {
+			t.Fatalf("期望任务 %s 因服务关闭取消，实际为 %s, %s", taskID, info.Status, info.Error)
+		}
+	}
+
+	if _, _, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_3", asyncTask, 0); !errors.Is(err, ErrManagerStopped) {
+		t.Fatalf("停止后应返回 ErrManagerStopped，实际为 %v", err)
+	}
+	if err := m.Stop(ctx); err != nil {
+		t.Fatalf("重复停止失败: %v", err)
+	}
+}
+
+// TestStopTimeout 测试订阅者不读取数据时，等待转发 goroutine 退出受 ctx 限制
+func TestStopTimeout(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		// 填满输出通道，转发 goroutine 阻塞在发送上
+		for i := 0; i < 300; i++ {
+			_ = updateProgress(i)
+		}
+		<-ctx.Done()
+		return nil
+	}
+	_, taskID, _ := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 0)
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		info, _ := m.GetTaskInfo(taskID)
+		if info.LastEventID == 300 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("等待数据推送超时: last_event_id=%d", info.LastEventID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := m.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望等待超时，实际为 %v", err)
+	}
+}
@@ -4,13 +4,19 @@ package sse
 import (
 	"context"
 	"errors"
-	"fmt"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"backend/utils/logs"
+	"backend/utils/rand"
 	"backend/utils/safego"
 )
 
+// storeTimeout 后台 goroutine 访问任务存储的超时时间
+const storeTimeout = 3 * time.Second
+
 var (
 	// ErrTaskNotFound 任务不存在
 	ErrTaskNotFound = errors.New("task not found")
@@ -18,6 +24,16 @@ var (
 	ErrTaskNotRunning = errors.New("task is not running")
 	// ErrTaskExpired 任务已过期
 	ErrTaskExpired = errors.New("task expired")
+	// ErrTaskNotFinished 任务还在运行，没有最终结果
+	ErrTaskNotFinished = errors.New("task is not finished")
+	// ErrTaskNotPaused 任务不在暂停状态
+	ErrTaskNotPaused = errors.New("task is not paused")
+	// ErrTaskNotFailed 任务没有失败，不能重试
+	ErrTaskNotFailed = errors.New("task is not failed")
+	// ErrManagerStopped 管理器已停止，不再创建或续传任务
+	ErrManagerStopped = errors.New("sse manager stopped")
+	// ErrServerShutdown 服务关闭，任务被取消、订阅被中止，客户端可以在服务重启后重新发起任务
+	ErrServerShutdown = errors.New("server shutdown")
 
 	// defaultManager 默认的 SSE 管理器，使用包级别函数时会自动初始化
 	defaultManager     *SSEManager
@@ -28,26 +44,127 @@ var (
 type TaskStatus string
 
 const (
+	TaskStatusQueued    TaskStatus = "queued"    // 排队中，见 WithMaxConcurrentTasks
 	TaskStatusRunning   TaskStatus = "running"   // 运行中
+	TaskStatusPaused    TaskStatus = "paused"    // 已暂停，见 PauseTask
 	TaskStatusCompleted TaskStatus = "completed" // 已完成
 	TaskStatusFailed    TaskStatus = "failed"    // 失败
 	TaskStatusCancelled TaskStatus = "cancelled" // 已取消
 )
 
+// Finished 任务是否已结束（完成、失败或取消），排队中、运行中和已暂停的任务可以续传
+func (s TaskStatus) Finished() bool {
+	return s != TaskStatusQueued && s != TaskStatusRunning && s != TaskStatusPaused
+}
+
+// LiveOnly 作为 lastEventID 传给 ExecuteWithSSE 时不补发历史数据，只接收实时数据
+const LiveOnly = ^uint64(0)
+
+// Event 任务推送的数据
+// ID 在任务内从 1 开始单调递增，客户端重连时带回最后收到的 ID，从下一条开始补发
+// Dropped 为该事件之前没有送达订阅者的数据条数（超出 CacheLimit 被淘汰或订阅者通道已满），重连后的第一条数据上可以据此提示数据不完整
+type Event struct {
+	ID      uint64      `json:"id"`
+	Data    interface{} `json:"data"` // 共享存储中读出的数据为 json.RawMessage
+	Dropped uint64      `json:"dropped,omitempty"`
+	Err     error       `json:"-"` // 订阅被中止的原因（如 ErrSlowSubscriber），为通道中最后一条数据，Data 为空
+
+	// Keepalive 不为 nil 时为管理器发送的保活数据（见 WithKeepalive），ID 为 0，Data 为空
+	Keepalive *Keepalive `json:"keepalive,omitempty"`
+	// Queued 不为 nil 时为任务排队期间的排队位置（见 WithMaxConcurrentTasks），ID 为 0，Data 为空
+	Queued *QueuePosition `json:"queued,omitempty"`
+}
+
+// SSEEventID SSE 事件 ID（id 行），供 handle.StreamSSE 使用；保活、排队数据不输出 id 行，不影响客户端的 Last-Event-ID
+func (e Event) SSEEventID() string {
+	if e.ID == 0 {
+		return ""
+	}
+	return strconv.FormatUint(e.ID, 10)
+}
+
+// SSEData SSE 事件数据（data 行）
+func (e Event) SSEData() any {
+	switch {
+	case e.Keepalive != nil:
+		return e.Keepalive
+	case e.Queued != nil:
+		return e.Queued
+	default:
+		return e.Data
+	}
+}
+
+// SSEEventName SSE 事件名称（event 行），保活数据为 KeepaliveEventName，排队数据为 QueuedEventName，
+// 服务关闭中止的订阅为 ShutdownEventName，其他数据使用 StreamSSE 配置的名称
+func (e Event) SSEEventName() string {
+	switch {
+	case errors.Is(e.Err, ErrServerShutdown):
+		return ShutdownEventName
+	case e.Keepalive != nil:
+		return KeepaliveEventName
+	case e.Queued != nil:
+		return QueuedEventName
+	default:
+		return ""
+	}
+}
+
+// SSEError 订阅被中止的原因，handle.StreamSSE 发送错误事件后结束
+func (e Event) SSEError() error {
+	return e.Err
+}
+
 // TaskInfo 任务信息
+// 推送过的数据和所有实例上的订阅者保存在 TaskStore 中，Subscribers 只包含本实例的订阅者
 type TaskInfo struct {
-	TaskID          string                      // 任务ID
-	ResumeKey       string                      // 断点续传标识
-	Status          TaskStatus                  // 任务状态
-	Progress        interface{}                 // 当前进度
-	CachedData      []interface{}               // 缓存的数据（断线期间）
-	CreatedAt       time.Time                   // 创建时间
-	UpdatedAt       time.Time                   // 更新时间
-	ExpiresAt       time.Time                   // 过期时间
-	DataChannel     chan interface{}            // 实时数据通道
-	Subscribers     map[string]chan interface{} // 订阅者列表（key: 订阅者ID）
-	mu              sync.RWMutex                // 保护并发访问
-	listenerStarted bool                        // 数据监听器是否已启动
+	TaskID          string                 // 任务ID
+	ResumeKey       string                 // 断点续传标识
+	IdempotencyKey  string                 // 幂等键，由 WithIdempotencyKey 设置
+	Kind            string                 // 任务类型，由 WithTaskKind 设置
+	Status          TaskStatus             // 任务状态
+	Progress        interface{}            // 当前进度
+	LastEventID     uint64                 // 最后一条数据的事件ID，没有数据时为 0
+	Error           string                 // 异步任务返回的错误，任务失败时设置
+	DroppedEvents   uint64                 // 超出 CacheLimit 没有保存或被淘汰的数据条数
+	CreatedAt       time.Time              // 创建时间
+	UpdatedAt       time.Time              // 更新时间
+	ExpiresAt       time.Time              // 过期时间
+	DataChannel     chan Event             // 实时数据通道
+	Subscribers     map[string]chan Event  // 订阅者列表（key: 订阅者ID）
+	mu              sync.RWMutex           // 保护并发访问
+	publishMu       sync.Mutex             // 串行化数据的保存和转发，保证与事件ID的顺序一致，访问任务存储时不持有 mu
+	listenerStarted bool                   // 数据监听器是否已启动
+	cache           eventCache             // 已保存数据的大小，用于按上限淘汰
+	subscribers     map[string]*subscriber // 订阅者的投递策略（key: 订阅者ID），与 Subscribers 同步
+	resumed         chan struct{}          // 暂停期间不为 nil，恢复或结束时关闭
+	limited         bool                   // 是否占用 WithMaxConcurrentTasks 的名额
+	cancel          context.CancelFunc     // 取消异步任务的 context，开始执行后设置
+	closed          bool                   // 订阅者通道是否已在任务结束时关闭，之后加入的订阅者补发完数据后直接关闭
+	ttl             time.Duration          // 任务的过期时长，由 WithTaskTTL 设置，默认为管理器的 defaultTTL
+	sliding         bool                   // 是否滑动过期，由 WithSlidingTTL 设置
+	coalescer       *coalescer             // 进度的合并缓冲区，由 WithCoalesce 设置，创建后不变
+	asyncFunc       AsyncTaskFunc          // 异步任务执行函数，RetryTask 重新执行
+	asyncTimeout    time.Duration          // 异步任务超时时间
+	options         taskOptions            // 创建任务时的配置选项，RetryTask 创建新任务时沿用
+}
+
+// record 任务元数据，调用方持有 task.mu
+func (task *TaskInfo) record() *TaskRecord {
+	return &TaskRecord{
+		TaskID:         task.TaskID,
+		ResumeKey:      task.ResumeKey,
+		IdempotencyKey: task.IdempotencyKey,
+		Kind:           task.Kind,
+		Status:         task.Status,
+		Progress:       task.Progress,
+		LastEventID:    task.LastEventID,
+		Error:          task.Error,
+		DroppedEvents:  task.DroppedEvents,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		ExpiresAt:      task.ExpiresAt,
+	}
 }
 
 // AsyncTaskFunc 异步任务执行函数
@@ -57,25 +174,51 @@ type TaskInfo struct {
 type AsyncTaskFunc func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error
 
 // SSEManager SSE 管理器
+// 异步任务在创建它的实例上执行，tasks 只保存本实例的任务；
+// 任务元数据、推送过的数据和订阅者同时写入 store，重连请求落到其他实例时从 store 续传
 type SSEManager struct {
-	tasks       map[string]*TaskInfo // 内存任务缓存
-	mu          sync.RWMutex         // 保护 tasks map
-	defaultTTL  time.Duration        // 默认任务过期时间
-	cleanupTick *time.Ticker         // 清理过期任务的定时器
-	stopCleanup chan struct{}        // 停止清理的信号
+	tasks       map[string]*TaskInfo      // 本实例的任务
+	mu          sync.RWMutex              // 保护 tasks map
+	store       TaskStore                 // 任务存储
+	cacheLimit  CacheLimit                // 每个任务保存的数据上限
+	delivery    DeliveryPolicy            // 订阅者默认的投递策略
+	keepalive   time.Duration             // 订阅者默认的保活间隔
+	maxRunning  int                       // 同时执行的任务数上限，0 表示不限制
+	queueMu     sync.Mutex                // 保护 active 和 queue
+	idemMu      sync.Mutex                // 带幂等键时保护任务的查找和创建，同一实例内不会重复创建
+	active      int                       // 占用名额的任务数（运行中和已暂停）
+	queue       []queuedTask              // 排队中的任务，按创建顺序
+	hooks       taskHooks                 // 任务生命周期回调
+	instance    string                    // 实例标识，区分不同实例上相同的订阅者ID
+	defaultTTL  time.Duration             // 默认任务过期时间
+	failedTTL   time.Duration             // 失败任务的保留时间，由 WithFailedTaskTTL 设置，0 表示与其他任务相同
+	cleanupTick *time.Ticker              // 清理过期任务的定时器
+	stopped     chan struct{}             // 管理器停止的信号，持有 mu 关闭
+	stopOnce    sync.Once                 // 保证只停止一次
+	wg          sync.WaitGroup            // 数据转发 goroutine，Stop 时等待退出
+	collector   atomic.Pointer[Collector] // 指标采集器，由 NewCollector 设置
 }
 
 // NewSSEManager 创建 SSE 管理器
 // defaultTTL: 默认任务过期时间，过期任务无法续传
-func NewSSEManager(defaultTTL time.Duration) *SSEManager {
+// opts: 配置选项，如 WithStore 设置共享的任务存储，WithCacheLimit 设置每个任务保存的数据上限，WithDelivery 设置订阅者的投递策略，
+// OnTaskStart、OnProgress、OnComplete、OnError 注册任务生命周期回调
+func NewSSEManager(defaultTTL time.Duration, opts ...Option) *SSEManager {
 	if defaultTTL <= 0 {
 		defaultTTL = 1 * time.Hour // 默认1小时
 	}
 
 	m := &SSEManager{
-		tasks:       make(map[string]*TaskInfo),
-		defaultTTL:  defaultTTL,
-		stopCleanup: make(chan struct{}),
+		tasks:      make(map[string]*TaskInfo),
+		instance:   rand.MustGenerateUID(),
+		defaultTTL: defaultTTL,
+		stopped:    make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.store == nil {
+		m.store = NewMemoryStore()
 	}
 
 	// 启动清理过期任务的 goroutine
@@ -87,6 +230,16 @@ func NewSSEManager(defaultTTL time.Duration) *SSEManager {
 	return m
 }
 
+// storeContext 后台 goroutine 访问任务存储使用的 context，不受 HTTP 请求断开影响
+func storeContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), storeTimeout)
+}
+
+// member 订阅者在任务存储中的标识
+func (m *SSEManager) member(subscriberID string) string {
+	return m.instance + ":" + subscriberID
+}
+
 // cleanupExpiredTasks 定期清理过期任务
 func (m *SSEManager) cleanupExpiredTasks() {
 	for {
@@ -94,14 +247,16 @@ func (m *SSEManager) cleanupExpiredTasks() {
 		case <-m.cleanupTick.C:
 			m.mu.Lock()
 			now := time.Now()
+			removed := make([]string, 0)
 			for taskID, task := range m.tasks {
 				task.mu.RLock()
 				expired := task.ExpiresAt.Before(now)
-				status := task.Status
 				task.mu.RUnlock()
 
-				if expired || (status != TaskStatusRunning) {
+				// 结束的任务保留到过期，供 GetTaskResult 查询最终结果
+				if expired {
 					delete(m.tasks, taskID)
+					removed = append(removed, taskID)
 					// 关闭通道
 					task.mu.Lock()
 					// 安全关闭数据通道
@@ -113,6 +268,7 @@ func (m *SSEManager) cleanupExpiredTasks() {
 						}()
 						if task.DataChannel != nil {
 							close(task.DataChannel)
+							task.DataChannel = nil
 						}
 					}()
 					// 安全关闭所有订阅者通道
@@ -130,40 +286,59 @@ func (m *SSEManager) cleanupExpiredTasks() {
 				}
 			}
 			m.mu.Unlock()
-		case <-m.stopCleanup:
+
+			// 任务由本实例创建，同时删除任务存储中的记录
+			for _, taskID := range removed {
+				ctx, cancel := storeContext()
+				if err := m.store.Delete(ctx, taskID); err != nil {
+					logs.CtxWarnf(ctx, "删除 SSE 任务记录失败: task_id=%s, error=%s", taskID, err.Error())
+				}
+				cancel()
+			}
+		case <-m.stopped:
 			return
 		}
 	}
 }
 
-// Stop 停止管理器，清理资源
-func (m *SSEManager) Stop() {
-	if m.cleanupTick != nil {
-		m.cleanupTick.Stop()
-	}
-	close(m.stopCleanup)
-}
-
 // ExecuteWithSSE 执行带有 SSE 的任务，自动处理断线重连、任务创建、数据缓存等
 //
 // 参数:
 //   - ctx: HTTP 请求的 context（用于检测客户端断线）
-//   - resumeKey: 断点续传标识，如果提供则尝试恢复已有任务，为空则创建新任务
+//   - resumeKey: 断点续传标识，如果提供则尝试恢复已有任务（包括其他实例上的任务），为空则创建新任务
+//   - lastEventID: 恢复任务时客户端最后收到的事件ID，先补发之后的数据再推送实时数据；
+//     为 0 时补发全部数据，为 LiveOnly 时只推送实时数据；创建新任务时忽略
 //   - subscriberID: 订阅者ID，用于标识不同的客户端连接
-//   - asyncFunc: 异步任务执行函数，会在独立的 context 中执行
+//   - asyncFunc: 异步任务执行函数，会在独立的 context 中执行；为 nil 时只订阅已有任务（见 Subscribe）
 //   - asyncTimeout: 异步任务超时时间
+//   - opts: 任务配置选项，WithTaskCacheLimit 只在创建任务时生效，WithSubscriberDelivery 作用于本次调用的订阅者
 //
 // 返回:
-//   - dataChan: 数据通道，用于接收任务进度数据
+//   - dataChan: 数据通道，用于接收任务推送的数据，按事件ID递增且不重复
 //   - taskID: 任务ID，可用于后续的断点续传
 //   - error: 错误信息
 func (m *SSEManager) ExecuteWithSSE(
 	ctx context.Context,
 	resumeKey string,
+	lastEventID uint64,
 	subscriberID string,
 	asyncFunc AsyncTaskFunc,
 	asyncTimeout time.Duration,
-) (<-chan interface{}, string, error) {
+	opts ...TaskOption,
+) (<-chan Event, string, error) {
+	options := taskOptions{cacheLimit: m.cacheLimit, delivery: m.delivery, keepalive: m.keepalive}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := options.delivery.Validate(); err != nil {
+		return nil, "", err
+	}
+	// 调用期间计入 wg，Stop 等待本次调用启动的 goroutine
+	if !m.track() {
+		return nil, "", ErrManagerStopped
+	}
+	defer m.wg.Done()
+
 	// 1. 检查是否需要恢复任务
 	var task *TaskInfo
 	var taskID string
@@ -193,140 +368,119 @@ func (m *SSEManager) ExecuteWithSSE(
 			if expired {
 				return nil, "", ErrTaskExpired
 			}
-			if status != TaskStatusRunning {
+			if status.Finished() {
 				return nil, "", ErrTaskNotRunning
 			}
+		} else {
+			// 本实例没有该任务，可能由其他实例创建
+			record, err := m.store.FindByResumeKey(ctx, resumeKey)
+			if err == nil {
+				if record.ExpiresAt.Before(time.Now()) {
+					return nil, "", ErrTaskExpired
+				}
+				if record.Status.Finished() {
+					return nil, "", ErrTaskNotRunning
+				}
+				return m.subscribeRemote(ctx, record.TaskID, lastEventID, subscriberID, options)
+			}
+			if !errors.Is(err, ErrTaskNotFound) {
+				return nil, "", err
+			}
 		}
 	}
 
-	// 2. 创建新任务（如果不存在）
-	if task == nil {
-		isNewTask = true
-		taskID = fmt.Sprintf("task_%d", time.Now().UnixNano())
-		resumeKey = fmt.Sprintf("resume_%d", time.Now().UnixNano())
-
-		task = &TaskInfo{
-			TaskID:      taskID,
-			ResumeKey:   resumeKey,
-			Status:      TaskStatusRunning,
-			CachedData:  make([]interface{}, 0),
-			CreatedAt:   time.Now(),
-			UpdatedAt:   time.Now(),
-			ExpiresAt:   time.Now().Add(m.defaultTTL),
-			DataChannel: make(chan interface{}, 100),
-			Subscribers: make(map[string]chan interface{}),
+	// 2. 带幂等键时加入同一个键创建的任务（包括已结束的任务），不重复执行
+	// 查找到创建完成之间持有 idemMu，同一实例内并发的重复请求只创建一个任务
+	unlockIdem := func() {}
+	defer func() { unlockIdem() }()
+	if task == nil && asyncFunc != nil && options.idempotencyKey != "" {
+		m.idemMu.Lock()
+		unlockIdem = sync.OnceFunc(m.idemMu.Unlock)
+		existing, record, err := m.findIdempotent(ctx, options.idempotencyKey)
+		if err != nil {
+			return nil, "", err
+		}
+		if record != nil {
+			unlockIdem()
+			return m.subscribeRemote(ctx, record.TaskID, lastEventID, subscriberID, options)
 		}
+		if existing != nil {
+			task = existing
+			taskID = existing.TaskID
+		}
+	}
 
-		m.mu.Lock()
-		m.tasks[taskID] = task
-		m.mu.Unlock()
+	// 3. 创建新任务（如果不存在），Subscribe 只订阅已有任务
+	if task == nil && asyncFunc == nil {
+		return nil, "", ErrTaskNotFound
+	}
+	if task == nil {
+		created, err := m.newTask(ctx, "", asyncFunc, asyncTimeout, options)
+		if err != nil {
+			return nil, "", err
+		}
+		isNewTask = true
+		lastEventID = 0
+		task = created
+		taskID = created.TaskID
 	}
+	unlockIdem()
 
-	// 3. 创建订阅者通道
-	subChan := make(chan interface{}, 100)
+	// 4. 创建订阅者通道，先登记订阅者再读取历史数据，两者之间的数据在转发时按事件ID去重
+	// 按幂等键加入已结束的任务时订阅者通道已经关闭过，补发完历史数据后直接结束
+	subChan := make(chan Event, 100)
+	sub := &subscriber{ch: subChan, policy: options.delivery}
 	task.mu.Lock()
-	task.Subscribers[subscriberID] = subChan
-
-	// 4. 如果是重连，发送缓存的历史数据
-	outputChan := make(chan interface{}, 100)
-	if len(task.CachedData) > 0 {
-		// 先发送缓存的数据
-		for _, cached := range task.CachedData {
-			select {
-			case subChan <- cached:
-			case <-ctx.Done():
-				task.mu.Unlock()
-				return nil, "", ctx.Err()
-			default:
-			}
-		}
-		// 清空缓存
-		task.CachedData = make([]interface{}, 0)
+	closed := task.closed
+	if closed {
+		close(subChan)
+	} else {
+		task.Subscribers[subscriberID] = subChan
+		task.subscribers[subscriberID] = sub
+	}
+	if lastEventID > task.LastEventID {
+		lastEventID = task.LastEventID
 	}
+	currentEventID := task.LastEventID
+	queued := task.Status == TaskStatusQueued
 	task.mu.Unlock()
 
-	// 5. 如果是新任务，启动异步任务执行和数据监听器
-	if isNewTask {
-		// 创建独立的 context（不受 HTTP 请求断开影响）
-		asyncCtx, cancel := context.WithTimeout(context.Background(), asyncTimeout)
-		if asyncTimeout <= 0 {
-			asyncCtx, cancel = context.WithCancel(context.Background())
+	// 5. 释放任务锁后访问任务存储：登记订阅者，重连时读取客户端最后收到的事件之后的数据
+	// 登记之后分配的数据在保存后才转发，不会同时缺失于历史数据和实时数据
+	if !closed {
+		if err := m.store.AddSubscriber(ctx, taskID, m.member(subscriberID)); err != nil {
+			logs.CtxWarnf(ctx, "登记 SSE 订阅者失败: task_id=%s, subscriber_id=%s, error=%s", taskID, subscriberID, err.Error())
 		}
+	}
+	history := m.history(ctx, taskID, lastEventID, currentEventID)
 
-		// 定义更新进度的函数
-		updateProgress := func(data interface{}) error {
-			return m.UpdateProgress(ctx, taskID, data)
+	// 重连到排队中的任务时先推送当前的排队位置
+	if queued && !isNewTask {
+		if position := m.position(task); position != nil {
+			sub.deliver(Event{Queued: position})
 		}
+	}
 
-		// 使用 safego 安全执行异步任务
-		safego.Go(ctx, func() {
-			defer cancel()
-			err := asyncFunc(asyncCtx, taskID, updateProgress)
-			if err != nil {
-				m.CompleteTask(ctx, taskID, TaskStatusFailed)
-			} else {
-				m.CompleteTask(ctx, taskID, TaskStatusCompleted)
-			}
-		})
-
-		// 启动数据监听 goroutine（从任务数据通道转发到订阅者）
-		// 这个监听器只在任务创建时启动一次
-		task.mu.Lock()
-		if !task.listenerStarted {
-			task.listenerStarted = true
-			task.mu.Unlock()
-
-			safego.Go(ctx, func() {
-				for {
-					select {
-					case data, ok := <-task.DataChannel:
-						if !ok {
-							return
-						}
-
-						task.mu.RLock()
-						hasSubscribers := len(task.Subscribers) > 0
-						subscribers := make(map[string]chan interface{})
-						for k, v := range task.Subscribers {
-							subscribers[k] = v
-						}
-						task.mu.RUnlock()
-
-						if hasSubscribers {
-							// 有订阅者，直接发送
-							for _, subChan := range subscribers {
-								select {
-								case subChan <- data:
-								default:
-									// 订阅者通道已满，跳过
-								}
-							}
-						} else {
-							// 无订阅者，缓存数据（断线期间）
-							task.mu.Lock()
-							task.CachedData = append(task.CachedData, data)
-							task.mu.Unlock()
-						}
-					case <-asyncCtx.Done():
-						return
-					}
-				}
-			})
-		} else {
-			task.mu.Unlock()
-		}
+	// 6. 如果是新任务，启动异步任务执行和数据监听器
+	if isNewTask {
+		m.start(ctx, task, queued)
 	}
 
-	// 6. 启动数据转发 goroutine（从订阅者通道转发到输出通道）
+	// 7. 启动数据转发 goroutine（先发送历史数据，再从订阅者通道转发到输出通道）
+	outputChan := make(chan Event, 100)
+	m.wg.Add(1)
 	safego.Go(ctx, func() {
+		defer m.wg.Done()
 		defer close(outputChan)
 		defer func() {
 			// 清理订阅者，使用 recover 防止重复关闭 channel
 			task.mu.Lock()
 			defer task.mu.Unlock()
+			m.removeSubscriber(taskID, subscriberID)
 			// 检查订阅者是否还存在（可能已被 CompleteTask 清理）
 			if _, exists := task.Subscribers[subscriberID]; exists {
 				delete(task.Subscribers, subscriberID)
+				delete(task.subscribers, subscriberID)
 				// 安全关闭 channel，防止重复关闭
 				func() {
 					defer func() {
@@ -339,42 +493,395 @@ func (m *SSEManager) ExecuteWithSSE(
 			}
 		}()
 
-		// 先发送缓存的数据（如果有）
+		forward(ctx, outputChan, history, subChan, lastEventID, keepalive{
+			interval: options.keepalive,
+			status:   func() *Keepalive { return keepaliveStatus(task) },
+		})
+
 		task.mu.RLock()
-		cachedData := make([]interface{}, len(task.CachedData))
-		copy(cachedData, task.CachedData)
+		err := sub.err
 		task.mu.RUnlock()
+		sendErr(ctx, outputChan, err)
+	})
 
-		for _, data := range cachedData {
-			select {
-			case outputChan <- data:
-			case <-ctx.Done():
-				return
+	return outputChan, taskID, nil
+}
+
+// Subscribe 按 resumeKey 订阅已有任务（包括其他实例上的任务），用于 WebSocket 等只消费任务数据的连接
+// 与带 resumeKey 的 ExecuteWithSSE 相同，但找不到任务时返回 ErrTaskNotFound，不创建新任务
+func (m *SSEManager) Subscribe(ctx context.Context, resumeKey string, lastEventID uint64, subscriberID string, opts ...TaskOption) (<-chan Event, string, error) {
+	if resumeKey == "" {
+		return nil, "", ErrTaskNotFound
+	}
+	return m.ExecuteWithSSE(ctx, resumeKey, lastEventID, subscriberID, nil, 0, opts...)
+}
+
+// sendErr 订阅被中止时发送最后一条带 Err 的数据
+func sendErr(ctx context.Context, out chan<- Event, err error) {
+	if err == nil {
+		return
+	}
+	select {
+	case out <- Event{Err: err}:
+	case <-ctx.Done():
+	}
+}
+
+// history 读取事件ID在 (afterID, lastID] 之间的数据，没有需要补发的数据时不访问任务存储
+func (m *SSEManager) history(ctx context.Context, taskID string, afterID uint64, lastID uint64) []Event {
+	if afterID >= lastID {
+		return nil
+	}
+	events, err := m.store.Events(ctx, taskID, afterID)
+	if err != nil {
+		logs.CtxWarnf(ctx, "读取 SSE 历史数据失败: task_id=%s, after_id=%d, error=%s", taskID, afterID, err.Error())
+		return nil
+	}
+	return events
+}
+
+// forward 先发送历史数据，再转发实时数据，跳过事件ID不大于 lastEventID 的数据，live 关闭或 ctx 结束时返回
+// 事件ID不连续时，在 Dropped 中记录中间缺失的条数；设置了保活间隔时，空闲超过间隔发送保活数据
+func forward(ctx context.Context, out chan<- Event, history []Event, live <-chan Event, lastEventID uint64, alive keepalive) {
+	send := func(event Event) bool {
+		// 排队位置等没有事件ID的数据直接转发
+		if event.ID != 0 {
+			if event.ID <= lastEventID {
+				return true
 			}
+			event.Dropped = event.ID - lastEventID - 1
 		}
+		select {
+		case out <- event:
+			if event.ID != 0 {
+				lastEventID = event.ID
+			}
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
 
-		// 然后转发实时数据
-		for {
+	for _, event := range history {
+		if !send(event) {
+			return
+		}
+	}
+
+	// 超过保活间隔没有转发数据时发送保活数据，没有设置间隔时 idle 为 nil 不会触发
+	var idle <-chan time.Time
+	var timer *time.Timer
+	if alive.interval > 0 {
+		timer = time.NewTimer(alive.interval)
+		defer timer.Stop()
+		idle = timer.C
+	}
+	for {
+		select {
+		case event, ok := <-live:
+			if !ok {
+				return
+			}
+			if !send(event) {
+				return
+			}
+		case <-idle:
 			select {
-			case data, ok := <-subChan:
-				if !ok {
-					return
-				}
-				select {
-				case outputChan <- data:
-				case <-ctx.Done():
-					return
-				}
+			case out <- Event{Keepalive: alive.status()}:
 			case <-ctx.Done():
 				return
 			}
+		case <-ctx.Done():
+			return
+		}
+		if timer != nil {
+			timer.Reset(alive.interval)
+		}
+	}
+}
+
+// dispatch 把任务数据发送给订阅者
+// 本实例的订阅者直接发送，其他实例有订阅者时通过任务存储推送
+func (m *SSEManager) dispatch(task *TaskInfo, event Event) {
+	ctx, cancel := storeContext()
+	defer cancel()
+
+	task.mu.RLock()
+	subscribers := make(map[string]*subscriber)
+	for k, v := range task.subscribers {
+		subscribers[k] = v
+	}
+	task.mu.RUnlock()
+
+	for subscriberID, sub := range subscribers {
+		connected, dropped := sub.deliver(event)
+		m.observeDropped(task.Kind, DropReasonSlowSubscriber, dropped)
+		if !connected {
+			m.disconnect(ctx, task, subscriberID, sub)
+		}
+	}
+
+	total, err := m.store.CountSubscribers(ctx, task.TaskID)
+	if err != nil {
+		logs.CtxWarnf(ctx, "查询 SSE 订阅者失败: task_id=%s, error=%s", task.TaskID, err.Error())
+		return
+	}
+	if total > len(subscribers) {
+		if err := m.store.Publish(ctx, task.TaskID, event); err != nil {
+			logs.CtxWarnf(ctx, "推送 SSE 数据失败: task_id=%s, error=%s", task.TaskID, err.Error())
+		}
+	}
+}
+
+// disconnect 断开接收太慢的订阅者，订阅者通道中剩余的数据转发完后发送 ErrSlowSubscriber
+func (m *SSEManager) disconnect(ctx context.Context, task *TaskInfo, subscriberID string, sub *subscriber) {
+	task.mu.Lock()
+	defer task.mu.Unlock()
+	if task.subscribers[subscriberID] != sub {
+		return
+	}
+	logs.CtxInfof(ctx, "断开接收太慢的 SSE 订阅者: task_id=%s, subscriber_id=%s", task.TaskID, subscriberID)
+	sub.err = ErrSlowSubscriber
+	delete(task.Subscribers, subscriberID)
+	delete(task.subscribers, subscriberID)
+	close(sub.ch)
+}
+
+// subscribeRemote 订阅其他实例上运行的任务：先补发历史数据，再转发该实例推送的实时数据，任务结束时通道关闭
+func (m *SSEManager) subscribeRemote(ctx context.Context, taskID string, lastEventID uint64, subscriberID string, options taskOptions) (<-chan Event, string, error) {
+	// 先订阅再读取历史数据，避免两者之间的数据丢失
+	live, err := m.store.Subscribe(ctx, taskID)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := m.store.AddSubscriber(ctx, taskID, m.member(subscriberID)); err != nil {
+		logs.CtxWarnf(ctx, "登记 SSE 订阅者失败: task_id=%s, subscriber_id=%s, error=%s", taskID, subscriberID, err.Error())
+	}
+
+	// 订阅之前任务可能已经结束，此时收不到结束消息，补发完历史数据后直接关闭
+	var history []Event
+	var kind string
+	ended := true
+	record, err := m.store.Get(ctx, taskID)
+	if err == nil {
+		kind = record.Kind
+		if lastEventID > record.LastEventID {
+			lastEventID = record.LastEventID
+		}
+		history = m.history(ctx, taskID, lastEventID, record.LastEventID)
+		ended = record.Status.Finished()
+	}
+
+	outputChan := make(chan Event, 100)
+	m.wg.Add(1)
+	safego.Go(ctx, func() {
+		defer m.wg.Done()
+		defer close(outputChan)
+		defer m.removeSubscriber(taskID, subscriberID)
+
+		// 实时数据先按投递策略转发到订阅者通道，与本实例的订阅者一致
+		sub := &subscriber{ch: make(chan Event, 100), policy: options.delivery}
+		if ended {
+			close(sub.ch)
+		} else {
+			m.wg.Add(1)
+			safego.Go(ctx, func() {
+				defer m.wg.Done()
+				defer close(sub.ch)
+				for {
+					var event Event
+					var ok bool
+					select {
+					case event, ok = <-live:
+						if !ok {
+							return
+						}
+					case <-m.stopped:
+						// 本实例关闭，任务仍在其他实例上运行，客户端重连后继续接收
+						sub.err = ErrServerShutdown
+						return
+					}
+					connected, dropped := sub.deliver(event)
+					m.observeDropped(kind, DropReasonSlowSubscriber, dropped)
+					if !connected {
+						logs.CtxInfof(ctx, "断开接收太慢的 SSE 订阅者: task_id=%s, subscriber_id=%s", taskID, subscriberID)
+						sub.err = ErrSlowSubscriber
+						return
+					}
+				}
+			})
+		}
+		forward(ctx, outputChan, history, sub.ch, lastEventID, keepalive{
+			interval: options.keepalive,
+			status:   m.remoteKeepalive(taskID, record),
+		})
+		// forward 因通道关闭返回时 sub.err 已写入；ctx 结束时不再发送
+		if ctx.Err() == nil {
+			sendErr(ctx, outputChan, sub.err)
 		}
 	})
 
 	return outputChan, taskID, nil
 }
 
-// UpdateProgress 更新任务进度，自动处理数据缓存和转发
+// newTask 创建任务并保存到任务存储和本实例，没有空闲名额时为排队状态
+// resumeKey 为空时生成新的断点续传标识，RetryTask 沿用失败任务的断点续传标识
+func (m *SSEManager) newTask(ctx context.Context, resumeKey string, asyncFunc AsyncTaskFunc, asyncTimeout time.Duration, options taskOptions) (*TaskInfo, error) {
+	taskID := rand.MustGenerateUIDWithPrefix("task_")
+	if resumeKey == "" {
+		resumeKey = rand.MustGenerateUIDWithPrefix("resume_")
+	}
+
+	if err := options.cacheLimit.Validate(); err != nil {
+		return nil, err
+	}
+	if options.coalesce != nil {
+		if err := options.coalesce.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	ttl := m.defaultTTL
+	if options.ttl > 0 {
+		ttl = options.ttl
+	}
+	now := time.Now()
+	task := &TaskInfo{
+		TaskID:         taskID,
+		ResumeKey:      resumeKey,
+		IdempotencyKey: options.idempotencyKey,
+		Kind:           options.kind,
+		Status:         TaskStatusRunning,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		ExpiresAt:      now.Add(ttl),
+		DataChannel:    make(chan Event, 100),
+		Subscribers:    make(map[string]chan Event),
+		cache:          eventCache{limit: options.cacheLimit},
+		subscribers:    make(map[string]*subscriber),
+		limited:        !options.unlimited && m.maxRunning > 0,
+		ttl:            ttl,
+		sliding:        options.sliding,
+		asyncFunc:      asyncFunc,
+		asyncTimeout:   asyncTimeout,
+		options:        options,
+	}
+	if options.coalesce != nil {
+		kind := task.Kind
+		task.coalescer = &coalescer{
+			config: *options.coalesce,
+			publish: func(ctx context.Context, data interface{}) error {
+				return m.publish(ctx, taskID, data)
+			},
+			coalesced: func(n int) {
+				m.observeDropped(kind, DropReasonCoalesced, n)
+			},
+		}
+	}
+	// 没有空闲名额时排队，订阅者先收到排队位置，开始执行后收到数据
+	reserved := !task.limited || m.reserve()
+	if !reserved {
+		task.Status = TaskStatusQueued
+	}
+
+	if err := m.store.Save(ctx, task.record()); err != nil {
+		if task.limited && reserved {
+			m.release()
+		}
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if m.isStopped() {
+		m.mu.Unlock()
+		if task.limited && reserved {
+			m.release()
+		}
+		return nil, ErrManagerStopped
+	}
+	m.tasks[taskID] = task
+	m.mu.Unlock()
+	return task, nil
+}
+
+// start 执行新任务的异步任务（排队的任务在空出名额时执行），并启动数据监听器
+func (m *SSEManager) start(ctx context.Context, task *TaskInfo, queued bool) {
+	// 开始执行异步任务，排队的任务在空出名额时调用
+	launch := func() {
+		// 创建独立的 context（不受 HTTP 请求断开影响），排队期间不计入超时
+		var asyncCtx context.Context
+		var cancel context.CancelFunc
+		if task.asyncTimeout > 0 {
+			asyncCtx, cancel = context.WithTimeout(context.Background(), task.asyncTimeout)
+		} else {
+			asyncCtx, cancel = context.WithCancel(context.Background())
+		}
+
+		// 定义更新进度的函数，使用任务的 context，客户端断开后数据仍然保存
+		updateProgress := func(data interface{}) error {
+			return m.UpdateProgress(asyncCtx, task.TaskID, data)
+		}
+
+		// 使用 safego 安全执行异步任务
+		task.mu.Lock()
+		task.cancel = cancel
+		record := task.record()
+		task.mu.Unlock()
+		safego.Go(ctx, func() {
+			defer cancel()
+			m.taskStarted(context.WithoutCancel(ctx), record)
+			err := task.asyncFunc(asyncCtx, task.TaskID, updateProgress)
+			// 结束前推送合并缓冲区中剩余的数据
+			if err := m.flushCoalesced(asyncCtx, task.TaskID); err != nil {
+				logs.CtxWarnf(ctx, "推送合并的 SSE 数据失败: task_id=%s, error=%s", task.TaskID, err.Error())
+			}
+			if err != nil {
+				m.complete(ctx, task.TaskID, TaskStatusFailed, err)
+			} else {
+				m.CompleteTask(ctx, task.TaskID, TaskStatusCompleted)
+			}
+		})
+	}
+	if queued {
+		m.enqueue(ctx, task, launch)
+	} else {
+		launch()
+	}
+
+	// 启动数据监听 goroutine（从任务数据通道转发到订阅者）
+	// 这个监听器只在任务创建时启动一次，任务结束（数据通道关闭）时先转发完剩余数据，再关闭订阅者通道
+	task.mu.Lock()
+	if !task.listenerStarted {
+		task.listenerStarted = true
+		dataChannel := task.DataChannel
+		task.mu.Unlock()
+
+		m.wg.Add(1)
+		safego.Go(ctx, func() {
+			defer m.wg.Done()
+			defer m.finish(task)
+
+			for event := range dataChannel {
+				m.dispatch(task, event)
+			}
+		})
+	} else {
+		task.mu.Unlock()
+	}
+}
+
+// removeSubscriber 从任务存储中移除订阅者
+func (m *SSEManager) removeSubscriber(taskID string, subscriberID string) {
+	ctx, cancel := storeContext()
+	defer cancel()
+	if err := m.store.RemoveSubscriber(ctx, taskID, m.member(subscriberID)); err != nil {
+		logs.CtxWarnf(ctx, "移除 SSE 订阅者失败: task_id=%s, subscriber_id=%s, error=%s", taskID, subscriberID, err.Error())
+	}
+}
+
+// UpdateProgress 更新任务进度，分配事件ID，保存到任务存储后转发给订阅者
+// 任务已暂停时阻塞，直到恢复（继续保存）、结束（返回 ErrTaskNotRunning）或 ctx 结束；
+// 启用 WithCoalesce 的任务先放入合并缓冲区，按合并配置推送
 //
 // 参数:
 //   - ctx: 上下文
@@ -387,43 +894,120 @@ func (m *SSEManager) UpdateProgress(ctx context.Context, taskID string, data int
 	task, exists := m.tasks[taskID]
 	m.mu.RUnlock()
 
-	if !exists {
-		return ErrTaskNotFound
+	if exists && task.coalescer != nil {
+		return task.coalescer.add(ctx, data)
 	}
+	return m.publish(ctx, taskID, data)
+}
 
-	task.mu.RLock()
-	status := task.Status
-	task.mu.RUnlock()
+// publish 推送一条进度数据，任务已暂停时等待恢复
+func (m *SSEManager) publish(ctx context.Context, taskID string, data interface{}) error {
+	record, event, err := m.update(ctx, taskID, data)
+	for errors.Is(err, errTaskPaused) {
+		// 任务已暂停，等待恢复后再保存数据
+		if err := m.waitResumed(ctx, taskID); err != nil {
+			return err
+		}
+		record, event, err = m.update(ctx, taskID, data)
+	}
+	if err != nil {
+		return err
+	}
+	// 释放任务锁后调用回调，回调中可以查询任务信息
+	m.progressed(ctx, record, event)
+	return nil
+}
 
-	if status != TaskStatusRunning {
-		return ErrTaskNotRunning
+// update 分配事件ID、保存数据并发送到任务通道，返回更新后的任务元数据
+func (m *SSEManager) update(ctx context.Context, taskID string, data interface{}) (*TaskRecord, Event, error) {
+	m.mu.RLock()
+	task, exists := m.tasks[taskID]
+	m.mu.RUnlock()
+
+	if !exists {
+		return nil, Event{}, ErrTaskNotFound
 	}
 
-	// 更新任务信息
+	// 持有 publishMu 分配事件ID、保存数据并发送到任务通道，保证事件ID与保存、转发的顺序一致
+	// 只在分配事件ID和发送时持有 mu，访问任务存储期间不阻塞订阅和查询
+	task.publishMu.Lock()
+	defer task.publishMu.Unlock()
+
 	task.mu.Lock()
+	if task.Status == TaskStatusPaused {
+		task.mu.Unlock()
+		return nil, Event{}, errTaskPaused
+	}
+	if task.Status != TaskStatusRunning {
+		task.mu.Unlock()
+		return nil, Event{}, ErrTaskNotRunning
+	}
+
+	task.LastEventID++
 	task.Progress = data
-	task.UpdatedAt = time.Now()
+	task.touch()
+	event := Event{ID: task.LastEventID, Data: data}
+	save, evict := task.cache.admit(data)
+	if !save {
+		task.DroppedEvents++
+		m.observeDropped(task.Kind, DropReasonEvicted, 1)
+	}
+	task.DroppedEvents += uint64(evict)
+	m.observeDropped(task.Kind, DropReasonEvicted, evict)
+
+	record := task.record()
 	task.mu.Unlock()
 
-	// 发送数据到任务通道
+	// 同步进度到任务存储，供重连和其他实例查询
+	storeCtx, cancel := storeContext()
+	if err := m.store.Save(storeCtx, record); err != nil {
+		logs.CtxWarnf(ctx, "保存 SSE 任务进度失败: task_id=%s, error=%s", taskID, err.Error())
+	}
+	if save {
+		if err := m.store.AppendEvent(storeCtx, taskID, event); err != nil {
+			logs.CtxWarnf(ctx, "保存 SSE 数据失败: task_id=%s, event_id=%d, error=%s", taskID, event.ID, err.Error())
+		}
+	}
+	if evict > 0 {
+		if err := m.store.TrimEvents(storeCtx, taskID, evict); err != nil {
+			logs.CtxWarnf(ctx, "淘汰 SSE 数据失败: task_id=%s, count=%d, error=%s", taskID, evict, err.Error())
+		}
+	}
+	cancel()
+
+	// 发送数据到任务通道，保存期间任务已结束时通道已关闭，不再发送
+	task.mu.Lock()
+	defer task.mu.Unlock()
+	if task.DataChannel == nil {
+		return record, event, nil
+	}
 	select {
-	case task.DataChannel <- data:
+	case task.DataChannel <- event:
 	case <-ctx.Done():
-		return ctx.Err()
+		return nil, Event{}, ctx.Err()
 	default:
-		// 通道已满，跳过
+		// 通道已满，跳过（数据已保存时重连可以补发）
 	}
 
-	return nil
+	return record, event, nil
 }
 
-// CompleteTask 标记任务完成
+// CompleteTask 标记任务完成，启用 WithCoalesce 的任务先推送合并缓冲区中剩余的数据
 //
 // 参数:
 //   - ctx: 上下文
 //   - taskID: 任务ID
 //   - status: 最终状态（completed 或 failed）
 func (m *SSEManager) CompleteTask(ctx context.Context, taskID string, status TaskStatus) {
+	if err := m.flushCoalesced(ctx, taskID); err != nil {
+		logs.CtxWarnf(ctx, "推送合并的 SSE 数据失败: task_id=%s, error=%s", taskID, err.Error())
+	}
+	m.complete(ctx, taskID, status, nil)
+}
+
+// complete 标记任务结束，taskErr 为异步任务返回的错误
+// 只有第一次结束生效，并调用 OnError、OnComplete 回调
+func (m *SSEManager) complete(ctx context.Context, taskID string, status TaskStatus, taskErr error) {
 	m.mu.RLock()
 	task, exists := m.tasks[taskID]
 	m.mu.RUnlock()
@@ -433,10 +1017,28 @@ func (m *SSEManager) CompleteTask(ctx context.Context, taskID string, status Tas
 	}
 
 	task.mu.Lock()
+	// 已结束的任务保持第一次结束时的状态，如取消后异步任务返回的 context 错误不会改为失败
+	if task.Status.Finished() {
+		task.mu.Unlock()
+		return
+	}
+	wasQueued := task.Status == TaskStatusQueued
+	// 唤醒暂停中等待恢复的 updateProgress，之后返回 ErrTaskNotRunning
+	if task.resumed != nil {
+		close(task.resumed)
+		task.resumed = nil
+	}
+	m.observeFinished(task.Kind, status, time.Since(task.CreatedAt))
 	task.Status = status
-	task.UpdatedAt = time.Now()
-	// 清空缓存
-	task.CachedData = make([]interface{}, 0)
+	task.Error = ""
+	if taskErr != nil {
+		task.Error = taskErr.Error()
+	}
+	task.touch()
+	if status == TaskStatusFailed && m.failedTTL > 0 {
+		task.ExpiresAt = task.UpdatedAt.Add(m.failedTTL)
+	}
+	record := task.record()
 	// 安全关闭数据通道
 	if task.DataChannel != nil {
 		func() {
@@ -449,16 +1051,53 @@ func (m *SSEManager) CompleteTask(ctx context.Context, taskID string, status Tas
 		}()
 		task.DataChannel = nil
 	}
+	listenerStarted := task.listenerStarted
 	task.mu.Unlock()
 
-	// 关闭所有订阅者通道
+	// 保存最终状态
+	storeCtx, cancel := storeContext()
+	if err := m.store.Save(storeCtx, record); err != nil {
+		logs.CtxWarnf(ctx, "保存 SSE 任务状态失败: task_id=%s, error=%s", taskID, err.Error())
+	}
+	cancel()
+
+	// 数据监听器转发完通道中剩余的数据后关闭订阅者通道，没有监听器时直接关闭
+	if !listenerStarted {
+		m.finish(task)
+	}
+
+	// 排队中的任务从队列中移除，执行过的任务释放名额
+	if wasQueued {
+		m.remove(task)
+	} else if task.limited {
+		m.release()
+	}
+
+	m.taskFinished(context.WithoutCancel(ctx), record, taskErr)
+}
+
+// finish 任务结束后关闭本实例的订阅者通道，并通知其他实例上的订阅者
+func (m *SSEManager) finish(task *TaskInfo) {
+	closeSubscribers(task)
+
+	ctx, cancel := storeContext()
+	defer cancel()
+	if err := m.store.Close(ctx, task.TaskID); err != nil {
+		logs.CtxWarnf(ctx, "通知 SSE 任务结束失败: task_id=%s, error=%s", task.TaskID, err.Error())
+	}
+}
+
+// closeSubscribers 关闭任务的所有订阅者通道
+func closeSubscribers(task *TaskInfo) {
 	task.mu.Lock()
-	subscribers := make(map[string]chan interface{})
+	subscribers := make(map[string]chan Event)
 	for k, v := range task.Subscribers {
 		subscribers[k] = v
 	}
 	// 清空订阅者列表，防止数据转发 goroutine 的 defer 重复关闭
-	task.Subscribers = make(map[string]chan interface{})
+	task.Subscribers = make(map[string]chan Event)
+	task.subscribers = make(map[string]*subscriber)
+	task.closed = true
 	task.mu.Unlock()
 
 	// 安全关闭所有订阅者通道
@@ -475,13 +1114,33 @@ func (m *SSEManager) CompleteTask(ctx context.Context, taskID string, status Tas
 }
 
 // GetTaskInfo 获取任务信息（用于查询任务状态）
+// 本实例没有该任务时从任务存储中查询，此时返回的进度为 json.RawMessage
 func (m *SSEManager) GetTaskInfo(taskID string) (*TaskInfo, error) {
 	m.mu.RLock()
 	task, exists := m.tasks[taskID]
 	m.mu.RUnlock()
 
 	if !exists {
-		return nil, ErrTaskNotFound
+		ctx, cancel := storeContext()
+		defer cancel()
+		record, err := m.store.Get(ctx, taskID)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskInfo{
+			TaskID:         record.TaskID,
+			ResumeKey:      record.ResumeKey,
+			IdempotencyKey: record.IdempotencyKey,
+			Kind:           record.Kind,
+			Status:         record.Status,
+			Progress:       record.Progress,
+			LastEventID:    record.LastEventID,
+			Error:          record.Error,
+			DroppedEvents:  record.DroppedEvents,
+			CreatedAt:      record.CreatedAt,
+			UpdatedAt:      record.UpdatedAt,
+			ExpiresAt:      record.ExpiresAt,
+		}, nil
 	}
 
 	task.mu.RLock()
@@ -489,13 +1148,18 @@ func (m *SSEManager) GetTaskInfo(taskID string) (*TaskInfo, error) {
 
 	// 返回副本，避免并发修改
 	info := &TaskInfo{
-		TaskID:    task.TaskID,
-		ResumeKey: task.ResumeKey,
-		Status:    task.Status,
-		Progress:  task.Progress,
-		CreatedAt: task.CreatedAt,
-		UpdatedAt: task.UpdatedAt,
-		ExpiresAt: task.ExpiresAt,
+		TaskID:         task.TaskID,
+		ResumeKey:      task.ResumeKey,
+		IdempotencyKey: task.IdempotencyKey,
+		Kind:           task.Kind,
+		Status:         task.Status,
+		Progress:       task.Progress,
+		LastEventID:    task.LastEventID,
+		Error:          task.Error,
+		DroppedEvents:  task.DroppedEvents,
+		CreatedAt:      task.CreatedAt,
+		UpdatedAt:      task.UpdatedAt,
+		ExpiresAt:      task.ExpiresAt,
 	}
 
 	return info, nil
@@ -526,22 +1190,26 @@ func Init(defaultTTL time.Duration) {
 // 参数:
 //   - ctx: HTTP 请求的 context（用于检测客户端断线）
 //   - resumeKey: 断点续传标识，如果提供则尝试恢复已有任务，为空则创建新任务
+//   - lastEventID: 恢复任务时客户端最后收到的事件ID，为 0 时补发全部数据，为 LiveOnly 时只推送实时数据
 //   - subscriberID: 订阅者ID，用于标识不同的客户端连接
-//   - asyncFunc: 异步任务执行函数，会在独立的 context 中执行
+//   - asyncFunc: 异步任务执行函数，会在独立的 context 中执行；为 nil 时只订阅已有任务（见 Subscribe）
 //   - asyncTimeout: 异步任务超时时间
+//   - opts: 任务配置选项，WithTaskCacheLimit 只在创建任务时生效，WithSubscriberDelivery 作用于本次调用的订阅者
 //
 // 返回:
-//   - dataChan: 数据通道，用于接收任务进度数据
+//   - dataChan: 数据通道，用于接收任务推送的数据
 //   - taskID: 任务ID，可用于后续的断点续传
 //   - error: 错误信息
 func ExecuteWithSSE(
 	ctx context.Context,
 	resumeKey string,
+	lastEventID uint64,
 	subscriberID string,
 	asyncFunc AsyncTaskFunc,
 	asyncTimeout time.Duration,
-) (<-chan interface{}, string, error) {
-	return getDefaultManager().ExecuteWithSSE(ctx, resumeKey, subscriberID, asyncFunc, asyncTimeout)
+	opts ...TaskOption,
+) (<-chan Event, string, error) {
+	return getDefaultManager().ExecuteWithSSE(ctx, resumeKey, lastEventID, subscriberID, asyncFunc, asyncTimeout, opts...)
 }
 
 // UpdateProgress 使用默认管理器更新任务进度
@@ -11,7 +11,7 @@ import (
 // TestBasicTaskExecution 测试基本任务执行功能
 func TestBasicTaskExecution(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 	subscriberID := "client_001"
@@ -44,6 +44,7 @@ func TestBasicTaskExecution(t *testing.T) {
 	dataChan, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"", // 空 resumeKey 表示创建新任务
+		0,
 		subscriberID,
 		asyncTask,
 		10*time.Second,
@@ -76,7 +77,7 @@ func TestBasicTaskExecution(t *testing.T) {
 // TestResumeTaskWithCachedData 测试断点续传功能：验证缓存数据被正确发送
 func TestResumeTaskWithCachedData(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	subscriberID1 := "client_001"
 	subscriberID2 := "client_002"
@@ -118,6 +119,7 @@ func TestResumeTaskWithCachedData(t *testing.T) {
 	dataChan1, taskID, err := manager.ExecuteWithSSE(
 		ctx1,
 		"",
+		0,
 		subscriberID1,
 		asyncTask,
 		10*time.Second,
@@ -176,6 +178,7 @@ func TestResumeTaskWithCachedData(t *testing.T) {
 	dataChan2, _, err := manager.ExecuteWithSSE(
 		ctx2,
 		resumeKey,
+		0,
 		subscriberID2,
 		asyncTask, // 这个函数不会再次执行（因为任务已在运行）
 		10*time.Second,
@@ -221,7 +224,7 @@ done:
 // TestMultipleSubscribers 测试多个订阅者同时订阅同一个任务
 func TestMultipleSubscribers(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -251,6 +254,7 @@ func TestMultipleSubscribers(t *testing.T) {
 	dataChan1, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -273,6 +277,7 @@ func TestMultipleSubscribers(t *testing.T) {
 	dataChan2, _, err := manager.ExecuteWithSSE(
 		ctx,
 		resumeKey,
+		0,
 		"client_002",
 		asyncTask,
 		10*time.Second,
@@ -321,7 +326,7 @@ func TestMultipleSubscribers(t *testing.T) {
 // TestTaskStatus 测试任务状态管理
 func TestTaskStatus(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -335,6 +340,7 @@ func TestTaskStatus(t *testing.T) {
 	dataChan, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -369,7 +375,7 @@ func TestTaskStatus(t *testing.T) {
 // TestTaskExpired 测试任务过期功能
 func TestTaskExpired(t *testing.T) {
 	manager := NewSSEManager(100 * time.Millisecond) // 很短的过期时间
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -392,6 +398,7 @@ func TestTaskExpired(t *testing.T) {
 	_, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -414,6 +421,7 @@ func TestTaskExpired(t *testing.T) {
 	_, _, err = manager.ExecuteWithSSE(
 		ctx,
 		resumeKey,
+		0,
 		"client_002",
 		asyncTask,
 		10*time.Second,
@@ -423,10 +431,86 @@ func TestTaskExpired(t *testing.T) {
 	}
 }
 
+// TestResumeFromLastEventID 测试按最后收到的事件ID续传：事件ID连续递增，不重复不遗漏
+func TestResumeFromLastEventID(t *testing.T) {
+	manager := NewSSEManager(1 * time.Hour)
+	defer manager.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 1; i <= 3; i++ {
+			updateProgress(i)
+		}
+		<-release
+		for i := 4; i <= 5; i++ {
+			updateProgress(i)
+		}
+		return nil
+	}
+
+	ctx1, disconnect := context.WithCancel(context.Background())
+	dataChan1, taskID, err := manager.ExecuteWithSSE(ctx1, "", 0, "client_001", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	first := <-dataChan1
+	if first.ID != 1 || first.Data != 1 {
+		t.Fatalf("第一条事件应为 1，实际为 %+v", first)
+	}
+	disconnect()
+
+	// 等待前三条数据推送完成
+	var info *TaskInfo
+	for i := 0; i < 100; i++ {
+		info, _ = manager.GetTaskInfo(taskID)
+		if info.LastEventID == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if info.LastEventID != 3 {
+		t.Fatalf("期望 LastEventID 为 3，实际为 %d", info.LastEventID)
+	}
+
+	// 只接收实时数据的订阅者不补发历史数据
+	liveChan, _, err := manager.ExecuteWithSSE(context.Background(), info.ResumeKey, LiveOnly, "client_003", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("订阅实时数据失败: %v", err)
+	}
+	// 从第一条之后续传
+	dataChan2, _, err := manager.ExecuteWithSSE(context.Background(), info.ResumeKey, first.ID, "client_002", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("恢复任务失败: %v", err)
+	}
+	close(release)
+
+	collect := func(ch <-chan Event) []uint64 {
+		var ids []uint64
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case event, ok := <-ch:
+				if !ok {
+					return ids
+				}
+				ids = append(ids, event.ID)
+			case <-timeout:
+				t.Fatalf("等待任务结束超时，已接收: %v", ids)
+			}
+		}
+	}
+	if ids := collect(dataChan2); fmt.Sprint(ids) != "[2 3 4 5]" {
+		t.Errorf("期望续传事件 [2 3 4 5]，实际为 %v", ids)
+	}
+	if ids := collect(liveChan); fmt.Sprint(ids) != "[4 5]" {
+		t.Errorf("期望实时事件 [4 5]，实际为 %v", ids)
+	}
+}
+
 // TestTaskNotFound 测试任务不存在的情况
 func TestTaskNotFound(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -443,10 +527,51 @@ func TestTaskNotFound(t *testing.T) {
 	}
 }
 
+// TestSubscribe 测试 Subscribe 只订阅已有任务，找不到任务时不创建新任务
+func TestSubscribe(t *testing.T) {
+	manager := NewSSEManager(1 * time.Hour)
+	defer manager.Stop(context.Background())
+
+	ctx := context.Background()
+
+	if _, _, err := manager.Subscribe(ctx, "resume_unknown", 0, "ws_1"); err != ErrTaskNotFound {
+		t.Fatalf("期望错误为 ErrTaskNotFound，实际为 %v", err)
+	}
+	if list := manager.ListTasks(TaskFilter{}); len(list.Tasks) != 0 {
+		t.Fatalf("找不到任务时不应创建新任务，实际有 %d 个任务", len(list.Tasks))
+	}
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(1)
+		_ = updateProgress(2)
+		<-release
+		return nil
+	}
+	first, taskID, err := manager.ExecuteWithSSE(ctx, "", 0, "client_1", asyncTask, 0)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	nextEvent(t, first)
+	nextEvent(t, first)
+	info, _ := manager.GetTaskInfo(taskID)
+
+	ch, subscribedID, err := manager.Subscribe(ctx, info.ResumeKey, 1, "ws_1")
+	if err != nil || subscribedID != taskID {
+		t.Fatalf("订阅任务失败: task_id=%s, error=%v", subscribedID, err)
+	}
+	if event := nextEvent(t, ch); event.ID != 2 {
+		t.Fatalf("期望从事件 2 开始补发，实际为 %+v", event)
+	}
+	close(release)
+	for range ch {
+	}
+}
+
 // TestResumeNonRunningTask 测试恢复非运行状态的任务
 func TestResumeNonRunningTask(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -460,6 +585,7 @@ func TestResumeNonRunningTask(t *testing.T) {
 	_, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -482,6 +608,7 @@ func TestResumeNonRunningTask(t *testing.T) {
 	_, _, err = manager.ExecuteWithSSE(
 		ctx,
 		resumeKey,
+		0,
 		"client_002",
 		asyncTask,
 		10*time.Second,
@@ -494,7 +621,7 @@ func TestResumeNonRunningTask(t *testing.T) {
 // TestDataCachingDuringDisconnect 测试断线期间数据缓存功能
 func TestDataCachingDuringDisconnect(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -526,6 +653,7 @@ func TestDataCachingDuringDisconnect(t *testing.T) {
 	dataChan1, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -576,6 +704,7 @@ func TestDataCachingDuringDisconnect(t *testing.T) {
 	dataChan2, _, err := manager.ExecuteWithSSE(
 		ctx,
 		resumeKey,
+		0,
 		"client_002",
 		asyncTask,
 		10*time.Second,
@@ -610,7 +739,7 @@ done:
 
 	// 验证数据连续性：第二个客户端应该接收到从第3条开始的数据
 	if len(secondClientData) > 0 {
-		firstData := secondClientData[0].(map[string]interface{})
+		firstData := secondClientData[0].(Event).Data.(map[string]interface{})
 		firstStep := firstData["step"].(int)
 		if firstStep < 3 {
 			t.Logf("注意：第二个客户端接收到的第一条数据的step为%d，可能包含了一些缓存数据", firstStep)
@@ -621,7 +750,7 @@ done:
 // TestConcurrentUpdates 测试并发更新进度
 func TestConcurrentUpdates(t *testing.T) {
 	manager := NewSSEManager(1 * time.Hour)
-	defer manager.Stop()
+	defer manager.Stop(context.Background())
 
 	ctx := context.Background()
 
@@ -651,6 +780,7 @@ func TestConcurrentUpdates(t *testing.T) {
 	dataChan, taskID, err := manager.ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -712,6 +842,7 @@ func TestPackageLevelFunctions(t *testing.T) {
 	dataChan, taskID, err := ExecuteWithSSE(
 		ctx,
 		"",
+		0,
 		"client_001",
 		asyncTask,
 		10*time.Second,
@@ -0,0 +1,276 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"backend/utils/safego"
+)
+
+// TaskRecord 任务存储中的任务元数据
+type TaskRecord struct {
+	TaskID         string      `json:"task_id"`
+	ResumeKey      string      `json:"resume_key"`
+	IdempotencyKey string      `json:"idempotency_key,omitempty"`
+	Kind           string      `json:"kind,omitempty"`
+	Status         TaskStatus  `json:"status"`
+	Progress       interface{} `json:"progress,omitempty"` // 共享存储中读出的进度为 json.RawMessage
+	LastEventID    uint64      `json:"last_event_id"`
+	DroppedEvents  uint64      `json:"dropped_events,omitempty"`
+	Error          string      `json:"error,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+	ExpiresAt      time.Time   `json:"expires_at"`
+}
+
+// TaskStore 任务存储，保存任务元数据、推送过的数据和订阅者
+// 默认使用进程内存储；多实例部署时使用共享存储（如 RedisStore），重连请求落到其他实例时也能通过 resumeKey 续传
+// 异步任务只在创建它的实例上执行，其他实例上的订阅者通过 Publish/Subscribe 接收实时数据
+type TaskStore interface {
+	// Save 保存任务元数据，在 ExpiresAt 之后过期
+	Save(ctx context.Context, record *TaskRecord) error
+	// Get 按任务ID读取，不存在时返回 ErrTaskNotFound
+	Get(ctx context.Context, taskID string) (*TaskRecord, error)
+	// FindByResumeKey 按断点续传标识读取，不存在时返回 ErrTaskNotFound
+	FindByResumeKey(ctx context.Context, resumeKey string) (*TaskRecord, error)
+	// FindByIdempotencyKey 按幂等键读取，不存在时返回 ErrTaskNotFound
+	FindByIdempotencyKey(ctx context.Context, key string) (*TaskRecord, error)
+	// Delete 删除任务及其数据和订阅者
+	Delete(ctx context.Context, taskID string) error
+
+	// AppendEvent 保存推送的数据，事件ID按递增顺序追加
+	AppendEvent(ctx context.Context, taskID string, event Event) error
+	// Events 读取事件ID大于 afterID 的数据，按事件ID递增
+	Events(ctx context.Context, taskID string, afterID uint64) ([]Event, error)
+	// TrimEvents 删除最早保存的 count 条数据
+	TrimEvents(ctx context.Context, taskID string, count int) error
+
+	// AddSubscriber 登记订阅者
+	AddSubscriber(ctx context.Context, taskID string, subscriberID string) error
+	// RemoveSubscriber 移除订阅者
+	RemoveSubscriber(ctx context.Context, taskID string, subscriberID string) error
+	// CountSubscribers 所有实例上的订阅者数量
+	CountSubscribers(ctx context.Context, taskID string) (int, error)
+
+	// Publish 把数据推送给其他实例上的订阅者
+	Publish(ctx context.Context, taskID string, event Event) error
+	// Subscribe 接收 Publish 推送的数据，调用 Close 或 ctx 结束时通道关闭
+	Subscribe(ctx context.Context, taskID string) (<-chan Event, error)
+	// Close 任务结束，关闭所有 Subscribe 返回的通道
+	Close(ctx context.Context, taskID string) error
+}
+
+// Option SSE 管理器配置选项
+type Option func(*SSEManager)
+
+// WithStore 设置任务存储，默认使用进程内存储
+func WithStore(store TaskStore) Option {
+	return func(m *SSEManager) {
+		m.store = store
+	}
+}
+
+// Decode 将订阅通道中的数据转换为 *T
+// 本实例产生的数据原样返回；从共享存储读出的数据为 json.RawMessage，反序列化为 *T
+func Decode[T any](data interface{}) (*T, bool) {
+	switch v := data.(type) {
+	case *T:
+		return v, true
+	case T:
+		return &v, true
+	case json.RawMessage:
+		out := new(T)
+		if err := json.Unmarshal(v, out); err != nil {
+			return nil, false
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+// MemoryStore 进程内任务存储，只能在单实例内续传
+type MemoryStore struct {
+	mu          sync.Mutex
+	records     map[string]*TaskRecord
+	resumeKeys  map[string]string // resumeKey -> taskID
+	idempotent  map[string]string // idempotencyKey -> taskID
+	events      map[string][]Event
+	subscribers map[string]map[string]struct{}
+	listeners   map[string][]chan Event
+}
+
+// NewMemoryStore 创建进程内任务存储
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records:     make(map[string]*TaskRecord),
+		resumeKeys:  make(map[string]string),
+		idempotent:  make(map[string]string),
+		events:      make(map[string][]Event),
+		subscribers: make(map[string]map[string]struct{}),
+		listeners:   make(map[string][]chan Event),
+	}
+}
+
+func (s *MemoryStore) Save(ctx context.Context, record *TaskRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *record
+	s.records[record.TaskID] = &copied
+	s.resumeKeys[record.ResumeKey] = record.TaskID
+	if record.IdempotencyKey != "" {
+		s.idempotent[record.IdempotencyKey] = record.TaskID
+	}
+	return nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, taskID string) (*TaskRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[taskID]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (s *MemoryStore) FindByResumeKey(ctx context.Context, resumeKey string) (*TaskRecord, error) {
+	s.mu.Lock()
+	taskID, ok := s.resumeKeys[resumeKey]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	return s.Get(ctx, taskID)
+}
+
+func (s *MemoryStore) FindByIdempotencyKey(ctx context.Context, key string) (*TaskRecord, error) {
+	s.mu.Lock()
+	taskID, ok := s.idempotent[key]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	return s.Get(ctx, taskID)
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if record, ok := s.records[taskID]; ok {
+		delete(s.resumeKeys, record.ResumeKey)
+		// 同一个幂等键后来创建的任务不受影响
+		if s.idempotent[record.IdempotencyKey] == taskID {
+			delete(s.idempotent, record.IdempotencyKey)
+		}
+	}
+	delete(s.records, taskID)
+	delete(s.events, taskID)
+	delete(s.subscribers, taskID)
+	s.closeListeners(taskID)
+	return nil
+}
+
+func (s *MemoryStore) AppendEvent(ctx context.Context, taskID string, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[taskID] = append(s.events[taskID], event)
+	return nil
+}
+
+func (s *MemoryStore) Events(ctx context.Context, taskID string, afterID uint64) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.events[taskID]
+	i := sort.Search(len(events), func(i int) bool { return events[i].ID > afterID })
+	return append([]Event(nil), events[i:]...), nil
+}
+
+func (s *MemoryStore) TrimEvents(ctx context.Context, taskID string, count int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	events := s.events[taskID]
+	if count >= len(events) {
+		delete(s.events, taskID)
+		return nil
+	}
+	s.events[taskID] = append([]Event(nil), events[count:]...)
+	return nil
+}
+
+func (s *MemoryStore) AddSubscriber(ctx context.Context, taskID string, subscriberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscribers[taskID] == nil {
+		s.subscribers[taskID] = make(map[string]struct{})
+	}
+	s.subscribers[taskID][subscriberID] = struct{}{}
+	return nil
+}
+
+func (s *MemoryStore) RemoveSubscriber(ctx context.Context, taskID string, subscriberID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers[taskID], subscriberID)
+	return nil
+}
+
+func (s *MemoryStore) CountSubscribers(ctx context.Context, taskID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[taskID]), nil
+}
+
+func (s *MemoryStore) Publish(ctx context.Context, taskID string, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.listeners[taskID] {
+		select {
+		case ch <- event:
+		default:
+			// 接收方通道已满，跳过
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) Subscribe(ctx context.Context, taskID string) (<-chan Event, error) {
+	ch := make(chan Event, 100)
+	s.mu.Lock()
+	s.listeners[taskID] = append(s.listeners[taskID], ch)
+	s.mu.Unlock()
+
+	safego.Go(ctx, func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		listeners := s.listeners[taskID]
+		for i, l := range listeners {
+			if l == ch {
+				s.listeners[taskID] = append(listeners[:i:i], listeners[i+1:]...)
+				close(ch)
+				break
+			}
+		}
+	})
+	return ch, nil
+}
+
+func (s *MemoryStore) Close(ctx context.Context, taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeListeners(taskID)
+	return nil
+}
+
+// closeListeners 关闭任务的所有 Subscribe 通道，调用方持有锁
+func (s *MemoryStore) closeListeners(taskID string) {
+	for _, ch := range s.listeners[taskID] {
+		close(ch)
+	}
+	delete(s.listeners, taskID)
+}
@@ -0,0 +1,130 @@
+package sse
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestResumeOnAnotherInstance 测试两个管理器共享任务存储时，重连请求落到另一个实例也能从最后收到的事件之后续传
+func TestResumeOnAnotherInstance(t *testing.T) {
+	store := NewMemoryStore()
+	owner := NewSSEManager(time.Hour, WithStore(store))
+	defer owner.Stop(context.Background())
+	other := NewSSEManager(time.Hour, WithStore(store))
+	defer other.Stop(context.Background())
+
+	release := make(chan struct{})
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		// 第一个客户端断开前后推送的数据
+		_ = updateProgress(1)
+		_ = updateProgress(2)
+		<-release
+		// 另一个实例订阅后推送的实时数据
+		_ = updateProgress(3)
+		return nil
+	}
+
+	ctx, disconnect := context.WithCancel(context.Background())
+	disconnect()
+	_, taskID, err := owner.ExecuteWithSSE(ctx, "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	info, err := owner.GetTaskInfo(taskID)
+	if err != nil {
+		t.Fatalf("获取任务信息失败: %v", err)
+	}
+
+	// 等待断开的订阅者移除、前两条数据保存
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		n, _ := store.CountSubscribers(context.Background(), taskID)
+		info, _ = owner.GetTaskInfo(taskID)
+		if n == 0 && info.LastEventID == 2 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("等待数据保存超时: subscribers=%d, last_event_id=%d", n, info.LastEventID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// 客户端已收到第一条数据，从第二条开始续传
+	dataChan, resumedID, err := other.ExecuteWithSSE(context.Background(), info.ResumeKey, 1, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("另一个实例续传失败: %v", err)
+	}
+	if resumedID != taskID {
+		t.Fatalf("期望续传任务 %s，实际为 %s", taskID, resumedID)
+	}
+	if info, err := other.GetTaskInfo(taskID); err != nil || info.LastEventID != 2 {
+		t.Fatalf("另一个实例查询任务失败: info=%+v, err=%v", info, err)
+	}
+	close(release)
+
+	var received []Event
+	timeout := time.After(2 * time.Second)
+	for done := false; !done; {
+		select {
+		case event, ok := <-dataChan:
+			if !ok {
+				done = true
+				break
+			}
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("等待任务结束超时，已接收: %v", received)
+		}
+	}
+	if len(received) != 2 || received[0].ID != 2 || received[1].ID != 3 {
+		t.Fatalf("期望依次收到事件 2、3，实际为 %v", received)
+	}
+	// 使用共享存储时数据可能为 json.RawMessage，通过 Decode 取出
+	if got, ok := Decode[int](received[1].Data); !ok || *got != 3 {
+		t.Fatalf("期望数据为 3，实际为 %v", received[1].Data)
+	}
+}
+
+// TestResumeFinishedTaskOnAnotherInstance 测试续传其他实例上已结束的任务
+func TestResumeFinishedTaskOnAnotherInstance(t *testing.T) {
+	store := NewMemoryStore()
+	owner := NewSSEManager(time.Hour, WithStore(store))
+	defer owner.Stop(context.Background())
+	other := NewSSEManager(time.Hour, WithStore(store))
+	defer other.Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		return nil
+	}
+	dataChan, taskID, err := owner.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	for range dataChan {
+	}
+	info, _ := owner.GetTaskInfo(taskID)
+
+	if _, _, err := other.ExecuteWithSSE(context.Background(), info.ResumeKey, 0, "client_2", asyncTask, 10*time.Second); err != ErrTaskNotRunning {
+		t.Fatalf("期望返回 ErrTaskNotRunning，实际为 %v", err)
+	}
+}
+
+// TestDecode 测试订阅数据的类型转换
+func TestDecode(t *testing.T) {
+	type progress struct {
+		Step int `json:"step"`
+	}
+
+	local := &progress{Step: 1}
+	if got, ok := Decode[progress](local); !ok || got != local {
+		t.Fatalf("本实例的数据应原样返回: %v, %v", got, ok)
+	}
+	if got, ok := Decode[progress](json.RawMessage(`{"step":2}`)); !ok || got.Step != 2 {
+		t.Fatalf("共享存储的数据应反序列化: %v, %v", got, ok)
+	}
+	if _, ok := Decode[progress]("other"); ok {
+		t.Fatal("其他类型应返回 false")
+	}
+}
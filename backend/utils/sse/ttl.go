@@ -0,0 +1,29 @@
+package sse
+
+import (
+	"time"
+)
+
+// WithTaskTTL 设置该任务的过期时间，覆盖管理器的 defaultTTL，只在创建任务时生效，0 表示使用 defaultTTL
+func WithTaskTTL(ttl time.Duration) TaskOption {
+	return func(o *taskOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithSlidingTTL 启用滑动过期，只在创建任务时生效
+// 每次 updateProgress、暂停恢复和任务结束时把过期时间延长为当前时间加上任务的 TTL，
+// 持续推送数据的长任务不会在运行中途过期，结束的任务从结束时起保留一个 TTL
+func WithSlidingTTL() TaskOption {
+	return func(o *taskOptions) {
+		o.sliding = true
+	}
+}
+
+// touch 更新任务的更新时间，滑动过期的任务同时延长过期时间，调用方持有 task.mu
+func (task *TaskInfo) touch() {
+	task.UpdatedAt = time.Now()
+	if task.sliding {
+		task.ExpiresAt = task.UpdatedAt.Add(task.ttl)
+	}
+}
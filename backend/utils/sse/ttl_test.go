@@ -0,0 +1,64 @@
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestTaskTTL 测试 WithTaskTTL 覆盖管理器的过期时间，WithSlidingTTL 在每次更新进度时延长过期时间
+func TestTaskTTL(t *testing.T) {
+	m := NewSSEManager(time.Hour)
+	defer m.Stop(context.Background())
+
+	release := make(chan struct{})
+	fixedTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		<-release
+		return updateProgress("done")
+	}
+	step := make(chan struct{})
+	slidingTask := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		for i := 0; i < 2; i++ {
+			<-step
+			_ = updateProgress(i)
+		}
+		return nil
+	}
+
+	fixedChan, fixedID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", fixedTask, 0, WithTaskTTL(time.Minute))
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	fixed, _ := m.GetTaskInfo(fixedID)
+	if d := fixed.ExpiresAt.Sub(fixed.CreatedAt); d != time.Minute {
+		t.Fatalf("期望过期时长为 1m，实际为 %s", d)
+	}
+
+	slidingChan, slidingID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_2", slidingTask, 0, WithTaskTTL(time.Minute), WithSlidingTTL())
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	created, _ := m.GetTaskInfo(slidingID)
+
+	time.Sleep(10 * time.Millisecond)
+	step <- struct{}{}
+	step <- struct{}{}
+	nextEvent(t, slidingChan)
+	updated, _ := m.GetTaskInfo(slidingID)
+	if !updated.ExpiresAt.After(created.ExpiresAt) {
+		t.Fatalf("滑动过期的任务更新进度后应延长过期时间: %s -> %s", created.ExpiresAt, updated.ExpiresAt)
+	}
+	if d := updated.ExpiresAt.Sub(updated.UpdatedAt); d != time.Minute {
+		t.Fatalf("期望过期时间为更新时间加 1m，实际相差 %s", d)
+	}
+
+	close(step)
+	close(release)
+	for range slidingChan {
+	}
+	for range fixedChan {
+	}
+	if info, _ := m.GetTaskInfo(fixedID); !info.ExpiresAt.Equal(fixed.ExpiresAt) {
+		t.Fatalf("固定过期的任务不应延长过期时间: %s -> %s", fixed.ExpiresAt, info.ExpiresAt)
+	}
+}
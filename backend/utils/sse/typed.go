@@ -0,0 +1,181 @@
+package sse
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"backend/utils/logs"
+	"backend/utils/safego"
+)
+
+// TypedEvent 强类型的任务数据
+type TypedEvent[T any] struct {
+	ID      uint64 `json:"id"`
+	Data    T      `json:"data"`
+	Dropped uint64 `json:"dropped,omitempty"` // 含义同 Event.Dropped
+	Err     error  `json:"-"`                 // 含义同 Event.Err
+
+	Keepalive *Keepalive     `json:"keepalive,omitempty"` // 含义同 Event.Keepalive
+	Queued    *QueuePosition `json:"queued,omitempty"`    // 含义同 Event.Queued
+}
+
+// SSEEventID SSE 事件 ID（id 行），供 handle.StreamSSE 使用
+func (e TypedEvent[T]) SSEEventID() string {
+	if e.ID == 0 {
+		return ""
+	}
+	return strconv.FormatUint(e.ID, 10)
+}
+
+// SSEData SSE 事件数据（data 行）
+func (e TypedEvent[T]) SSEData() any {
+	switch {
+	case e.Keepalive != nil:
+		return e.Keepalive
+	case e.Queued != nil:
+		return e.Queued
+	default:
+		return e.Data
+	}
+}
+
+// SSEEventName SSE 事件名称（event 行）
+func (e TypedEvent[T]) SSEEventName() string {
+	switch {
+	case e.Keepalive != nil:
+		return KeepaliveEventName
+	case e.Queued != nil:
+		return QueuedEventName
+	default:
+		return ""
+	}
+}
+
+// SSEError 订阅被中止的原因
+func (e TypedEvent[T]) SSEError() error {
+	return e.Err
+}
+
+// TypedAsyncTaskFunc 强类型的异步任务执行函数，参数含义同 AsyncTaskFunc
+type TypedAsyncTaskFunc[T any] func(ctx context.Context, taskID string, updateProgress func(data T) error) error
+
+// Manager 强类型的 SSE 管理器，包装 SSEManager，推送和接收的数据均为 T
+// 与包装的 SSEManager 共享任务，同一个 SSEManager 可以包装出多个不同类型的 Manager
+type Manager[T any] struct {
+	m *SSEManager
+}
+
+// NewManager 包装 SSE 管理器，m 为 nil 时使用默认管理器
+func NewManager[T any](m *SSEManager) *Manager[T] {
+	if m == nil {
+		m = getDefaultManager()
+	}
+	return &Manager[T]{m: m}
+}
+
+// Untyped 返回包装的 SSE 管理器
+func (tm *Manager[T]) Untyped() *SSEManager {
+	return tm.m
+}
+
+// ExecuteWithSSE 执行带有 SSE 的任务，参数和返回值含义同 SSEManager.ExecuteWithSSE
+// 共享存储中读出的数据自动反序列化为 T，无法转换的数据记录日志后跳过
+func (tm *Manager[T]) ExecuteWithSSE(
+	ctx context.Context,
+	resumeKey string,
+	lastEventID uint64,
+	subscriberID string,
+	asyncFunc TypedAsyncTaskFunc[T],
+	asyncTimeout time.Duration,
+	opts ...TaskOption,
+) (<-chan TypedEvent[T], string, error) {
+	untyped := func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		return asyncFunc(ctx, taskID, func(data T) error {
+			return updateProgress(data)
+		})
+	}
+
+	dataChan, taskID, err := tm.m.ExecuteWithSSE(ctx, resumeKey, lastEventID, subscriberID, untyped, asyncTimeout, opts...)
+	if err != nil {
+		return nil, "", err
+	}
+
+	out := make(chan TypedEvent[T], cap(dataChan))
+	safego.Go(ctx, func() {
+		defer close(out)
+		for event := range dataChan {
+			if event.Err != nil {
+				select {
+				case out <- TypedEvent[T]{Err: event.Err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			if event.ID == 0 {
+				select {
+				case out <- TypedEvent[T]{Keepalive: event.Keepalive, Queued: event.Queued}:
+				case <-ctx.Done():
+					return
+				}
+				continue
+			}
+			data, ok := Decode[T](event.Data)
+			if !ok {
+				logs.CtxWarnf(ctx, "SSE 数据类型不匹配: task_id=%s, event_id=%d, type=%T", taskID, event.ID, event.Data)
+				continue
+			}
+			select {
+			case out <- TypedEvent[T]{ID: event.ID, Data: *data, Dropped: event.Dropped}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	return out, taskID, nil
+}
+
+// UpdateProgress 更新任务进度
+func (tm *Manager[T]) UpdateProgress(ctx context.Context, taskID string, data T) error {
+	return tm.m.UpdateProgress(ctx, taskID, data)
+}
+
+// CompleteTask 标记任务完成
+func (tm *Manager[T]) CompleteTask(ctx context.Context, taskID string, status TaskStatus) {
+	tm.m.CompleteTask(ctx, taskID, status)
+}
+
+// GetTaskInfo 获取任务信息
+func (tm *Manager[T]) GetTaskInfo(taskID string) (*TaskInfo, error) {
+	return tm.m.GetTaskInfo(taskID)
+}
+
+// PauseTask 暂停任务
+func (tm *Manager[T]) PauseTask(ctx context.Context, taskID string) error {
+	return tm.m.PauseTask(ctx, taskID)
+}
+
+// ResumeTask 恢复已暂停的任务
+func (tm *Manager[T]) ResumeTask(ctx context.Context, taskID string) error {
+	return tm.m.ResumeTask(ctx, taskID)
+}
+
+// GetTaskResult 获取已结束任务的最终结果，TaskResult.Result 可以使用 Decode 转换为 T
+func (tm *Manager[T]) GetTaskResult(taskID string) (*TaskResult, error) {
+	return tm.m.GetTaskResult(taskID)
+}
+
+// Progress 获取任务的当前进度，任务还没有进度时返回 false
+func (tm *Manager[T]) Progress(taskID string) (T, bool, error) {
+	var zero T
+	info, err := tm.m.GetTaskInfo(taskID)
+	if err != nil {
+		return zero, false, err
+	}
+	data, ok := Decode[T](info.Progress)
+	if !ok {
+		return zero, false, nil
+	}
+	return *data, true, nil
+}
@@ -0,0 +1,92 @@
+package sse
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type typedProgress struct {
+	Step int `json:"step"`
+}
+
+// TestTypedManager 测试强类型管理器推送和接收的数据均为 T，无需类型断言
+func TestTypedManager(t *testing.T) {
+	m := NewManager[typedProgress](NewSSEManager(time.Hour))
+	defer m.Untyped().Stop(context.Background())
+
+	asyncTask := func(ctx context.Context, taskID string, updateProgress func(data typedProgress) error) error {
+		for i := 1; i <= 3; i++ {
+			if err := updateProgress(typedProgress{Step: i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	dataChan, taskID, err := m.ExecuteWithSSE(context.Background(), "", 0, "client_1", asyncTask, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+
+	var steps []int
+	for event := range dataChan {
+		if event.ID != uint64(event.Data.Step) {
+			t.Fatalf("事件ID与数据不一致: %+v", event)
+		}
+		steps = append(steps, event.Data.Step)
+	}
+	if len(steps) != 3 || steps[0] != 1 || steps[2] != 3 {
+		t.Fatalf("期望收到 [1 2 3]，实际为 %v", steps)
+	}
+
+	progress, ok, err := m.Progress(taskID)
+	if err != nil || !ok || progress.Step != 3 {
+		t.Fatalf("期望当前进度为 3，实际为 %+v, %v, %v", progress, ok, err)
+	}
+}
+
+// TestTypedManagerOnAnotherInstance 测试强类型管理器续传其他实例上的任务
+func TestTypedManagerOnAnotherInstance(t *testing.T) {
+	store := NewMemoryStore()
+	owner := NewSSEManager(time.Hour, WithStore(store))
+	defer owner.Stop(context.Background())
+	other := NewManager[*typedProgress](NewSSEManager(time.Hour, WithStore(store)))
+	defer other.Untyped().Stop(context.Background())
+
+	// 第一个客户端立即断开，任务在 owner 上继续执行
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	release := make(chan struct{})
+	_, taskID, err := owner.ExecuteWithSSE(ctx, "", 0, "client_1", func(ctx context.Context, taskID string, updateProgress func(data interface{}) error) error {
+		_ = updateProgress(&typedProgress{Step: 1})
+		<-release
+		return nil
+	}, 10*time.Second)
+	if err != nil {
+		t.Fatalf("创建任务失败: %v", err)
+	}
+	var info *TaskInfo
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		info, _ = owner.GetTaskInfo(taskID)
+		if info.LastEventID == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("等待数据保存超时")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	dataChan, _, err := other.ExecuteWithSSE(context.Background(), info.ResumeKey, 0, "client_2", nil, 10*time.Second)
+	if err != nil {
+		t.Fatalf("另一个实例续传失败: %v", err)
+	}
+	close(release)
+
+	event, ok := <-dataChan
+	if !ok || event.ID != 1 || event.Data == nil || event.Data.Step != 1 {
+		t.Fatalf("期望收到事件 1，实际为 %+v, %v", event, ok)
+	}
+}
@@ -0,0 +1,129 @@
+package taskgroup
+
+import (
+	"sync"
+	"time"
+
+	"backend/utils/logs"
+)
+
+// AdaptiveConfig 自适应并发配置
+// 每完成 Window 个任务评估一次：错误率或平均耗时超过阈值时并发数减半（不低于 MinLimit），
+// 否则并发数加一（不超过 MaxLimit）
+type AdaptiveConfig struct {
+	MinLimit           int           // 最小并发数，默认 1
+	MaxLimit           int           // 最大并发数，默认为创建任务组时的并发数
+	Window             int           // 每次评估统计的任务数，默认 20
+	ErrorRateThreshold float64       // 错误率阈值（0-1），0 表示不按错误率调整
+	LatencyThreshold   time.Duration // 平均耗时阈值，0 表示不按耗时调整
+}
+
+// WithAdaptiveLimit 启用自适应并发，根据任务错误率和耗时自动调整并发数
+func WithAdaptiveLimit(config AdaptiveConfig) Option {
+	return func(t *taskGroup) {
+		t.adaptive = &config
+	}
+}
+
+// limiter 支持运行时调整上限的并发限制器
+type limiter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	limit  int // 并发上限，小于等于 0 表示不限制
+	active int // 正在执行的任务数
+
+	adaptive *AdaptiveConfig
+	finished int           // 当前窗口内完成的任务数
+	failed   int           // 当前窗口内失败的任务数
+	elapsed  time.Duration // 当前窗口内任务的总耗时
+}
+
+func newLimiter(limit int, adaptive *AdaptiveConfig) *limiter {
+	l := &limiter{limit: limit}
+	l.cond = sync.NewCond(&l.mu)
+	if adaptive != nil {
+		cfg := *adaptive
+		if cfg.MinLimit <= 0 {
+			cfg.MinLimit = 1
+		}
+		if cfg.MaxLimit <= 0 {
+			cfg.MaxLimit = limit
+		}
+		if cfg.MaxLimit < cfg.MinLimit {
+			cfg.MaxLimit = cfg.MinLimit
+		}
+		if cfg.Window <= 0 {
+			cfg.Window = 20
+		}
+		l.adaptive = &cfg
+	}
+	return l
+}
+
+// acquire 获取一个执行名额，达到上限时阻塞
+func (l *limiter) acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.limit > 0 && l.active >= l.limit {
+		l.cond.Wait()
+	}
+	l.active++
+}
+
+// release 释放执行名额，并记录任务结果用于自适应调整
+func (l *limiter) release(err error, elapsed time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	if l.adaptive != nil {
+		l.observe(err, elapsed)
+	}
+	l.cond.Broadcast()
+}
+
+// setLimit 修改并发上限，已经在执行的任务不受影响
+func (l *limiter) setLimit(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = n
+	l.cond.Broadcast()
+}
+
+// getLimit 返回当前并发上限
+func (l *limiter) getLimit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+// observe 统计任务结果，窗口满时调整并发上限（调用方持有锁）
+func (l *limiter) observe(err error, elapsed time.Duration) {
+	cfg := l.adaptive
+	l.finished++
+	l.elapsed += elapsed
+	if err != nil {
+		l.failed++
+	}
+	if l.finished < cfg.Window {
+		return
+	}
+
+	errorRate := float64(l.failed) / float64(l.finished)
+	avgLatency := l.elapsed / time.Duration(l.finished)
+	l.finished, l.failed, l.elapsed = 0, 0, 0
+
+	overloaded := (cfg.ErrorRateThreshold > 0 && errorRate > cfg.ErrorRateThreshold) ||
+		(cfg.LatencyThreshold > 0 && avgLatency > cfg.LatencyThreshold)
+
+	next := l.limit
+	if overloaded {
+		next = max(cfg.MinLimit, l.limit/2)
+	} else {
+		next = min(cfg.MaxLimit, l.limit+1)
+	}
+	if next != l.limit {
+		logs.Info("[TaskGroup] 调整并发数", "from", l.limit, "to", next,
+			"error_rate", errorRate, "avg_latency", avgLatency.String())
+		l.limit = next
+	}
+}
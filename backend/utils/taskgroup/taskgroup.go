@@ -4,18 +4,89 @@ package taskgroup
 import (
 	"context"
 	"fmt"
+	"runtime/debug"
 	"sync/atomic"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
 	"backend/utils/logs"
+	"backend/utils/metrics"
+	"backend/utils/retry"
 )
 
+// MetricName 任务组在 metrics 端点中的指标名称
+const MetricName = "taskgroup"
+
+// PanicPolicy 任务 panic 时的处理策略
+type PanicPolicy int
+
+const (
+	// PanicFailGroup 将 panic 转换为任务错误（默认），可中断的任务组会因此取消其他任务
+	PanicFailGroup PanicPolicy = iota
+	// PanicContinue 只记录 panic，任务视为成功结束，不影响任务组的结果
+	PanicContinue
+)
+
+// PanicHandler panic 回调函数，可用于上报 Sentry 等错误追踪服务
+// recovered 为 recover() 的返回值，stack 为 panic 时的调用堆栈
+type PanicHandler func(ctx context.Context, recovered interface{}, stack []byte)
+
+// Option 任务组配置选项
+type Option func(*taskGroup)
+
+// WithPanicPolicy 设置任务 panic 时的处理策略，默认为 PanicFailGroup
+func WithPanicPolicy(policy PanicPolicy) Option {
+	return func(t *taskGroup) {
+		t.panicPolicy = policy
+	}
+}
+
+// WithPanicHandler 设置任务 panic 时的回调函数
+func WithPanicHandler(handler PanicHandler) Option {
+	return func(t *taskGroup) {
+		t.panicHandler = handler
+	}
+}
+
+// panicCount 所有任务组累计捕获的 panic 次数
+var panicCount atomic.Int64
+
+func init() {
+	metrics.Register(MetricName, func() interface{} {
+		return map[string]int64{"panics": panicCount.Load()}
+	})
+}
+
+// PanicCount 返回所有任务组累计捕获的 panic 次数
+func PanicCount() int64 {
+	return panicCount.Load()
+}
+
+// RetryPolicy 任务重试策略
+type RetryPolicy struct {
+	// MaxAttempts 最多执行次数（包含首次执行），小于等于 0 时只受 Backoff.MaxElapsed 限制
+	MaxAttempts int
+	// Backoff 重试间隔，零值时使用 retry.DefaultBackoff()
+	Backoff retry.Backoff
+	// RetryIf 判断错误是否可以重试（如 errorx.IsRetryable），为 nil 时所有错误都重试
+	// 返回 retry.Permanent 包装的错误时也不会重试
+	RetryIf func(err error) bool
+}
+
 // TaskGroup 定义了任务组的接口，用于管理并发任务的执行
 type TaskGroup interface {
 	// Go 添加一个任务到任务组中异步执行
 	// f 是要执行的任务函数，返回 error 表示任务执行结果
 	Go(f func() error)
+	// GoWithRetry 添加一个失败时按策略重试的任务
+	// 只有最后一次执行的错误会返回给任务组
+	GoWithRetry(f func(ctx context.Context, attempt int) error, policy RetryPolicy)
+	// SetLimit 在运行时修改最大并发数，小于等于 0 表示不限制
+	// 已经在执行的任务不受影响，等待中的任务按新的并发数调度
+	SetLimit(n int)
+	// Limit 返回当前的最大并发数（启用自适应并发时会自动变化）
+	Limit() int
 	// Wait 等待所有任务执行完成，并返回第一个遇到的错误（如果有）
 	Wait() error
 }
@@ -25,6 +96,11 @@ type taskGroup struct {
 	errGroup    *errgroup.Group // 底层的 errgroup，用于管理并发任务
 	ctx         context.Context // 上下文，用于任务取消和超时控制
 	execAllTask atomic.Bool     // 是否执行所有任务的标志（即使有任务失败）
+	limiter     *limiter        // 并发限制器，支持运行时调整
+
+	panicPolicy  PanicPolicy     // 任务 panic 时的处理策略
+	panicHandler PanicHandler    // 任务 panic 时的回调函数
+	adaptive     *AdaptiveConfig // 自适应并发配置
 }
 
 // NewTaskGroup 创建一个可中断的任务组
@@ -32,13 +108,17 @@ type taskGroup struct {
 //
 // 参数:
 //   - ctx: 上下文，用于控制任务的生命周期
-//   - concurrentCount: 最大并发数，限制同时执行的任务数量
+//   - concurrentCount: 最大并发数，限制同时执行的任务数量，小于等于 0 表示不限制
+//   - opts: 可选配置，如 WithPanicPolicy、WithPanicHandler、WithAdaptiveLimit
 //
 // 返回: TaskGroup 实例
-func NewTaskGroup(ctx context.Context, concurrentCount int) TaskGroup {
+func NewTaskGroup(ctx context.Context, concurrentCount int, opts ...Option) TaskGroup {
 	t := &taskGroup{}
+	for _, opt := range opts {
+		opt(t)
+	}
 	t.errGroup, t.ctx = errgroup.WithContext(ctx)
-	t.errGroup.SetLimit(concurrentCount)
+	t.limiter = newLimiter(concurrentCount, t.adaptive)
 	t.execAllTask.Store(false) // 设置为 false，表示遇到错误时中断其他任务
 
 	return t
@@ -49,27 +129,45 @@ func NewTaskGroup(ctx context.Context, concurrentCount int) TaskGroup {
 //
 // 参数:
 //   - ctx: 上下文，用于控制任务的生命周期
-//   - concurrentCount: 最大并发数，限制同时执行的任务数量
+//   - concurrentCount: 最大并发数，限制同时执行的任务数量，小于等于 0 表示不限制
+//   - opts: 可选配置，如 WithPanicPolicy、WithPanicHandler、WithAdaptiveLimit
 //
 // 返回: TaskGroup 实例
-func NewUninterruptibleTaskGroup(ctx context.Context, concurrentCount int) TaskGroup {
+func NewUninterruptibleTaskGroup(ctx context.Context, concurrentCount int, opts ...Option) TaskGroup {
 	t := &taskGroup{}
+	for _, opt := range opts {
+		opt(t)
+	}
 	t.errGroup, t.ctx = errgroup.WithContext(ctx)
-	t.errGroup.SetLimit(concurrentCount)
+	t.limiter = newLimiter(concurrentCount, t.adaptive)
 	t.execAllTask.Store(true) // 设置为 true，表示即使有任务失败也继续执行其他任务
 
 	return t
 }
 
 // Go 将任务添加到任务组中异步执行
-// 任务执行时会自动捕获 panic，并在日志中记录
+// 任务执行时会自动捕获 panic，记录日志和 panic 次数，并按 panic 策略处理
 // 对于可中断的任务组，如果 context 已被取消，任务会立即返回错误
+// 达到最大并发数时阻塞，直到有任务完成
 func (t *taskGroup) Go(f func() error) {
+	t.limiter.acquire()
+	start := time.Now()
 	t.errGroup.Go(func() (err error) {
+		defer func() {
+			t.limiter.release(err, time.Since(start))
+		}()
+
 		// 捕获 panic，防止单个任务崩溃影响整个程序
 		defer func() {
 			if r := recover(); r != nil {
-				logs.CtxErrorf(t.ctx, "[TaskGroup] exec panic recover:%+v", r)
+				stack := debug.Stack()
+				panicCount.Add(1)
+				logs.CtxErrorf(t.ctx, "[TaskGroup] exec panic recover:%+v\nstacktrace:\n%s", r, stack)
+				t.handlePanic(r, stack)
+				if t.panicPolicy == PanicContinue {
+					err = nil
+					return
+				}
 				// 将 panic 转换为 error，确保 errgroup 能够正确处理
 				err = fmt.Errorf("task panic: %v", r)
 			}
@@ -90,8 +188,57 @@ func (t *taskGroup) Go(f func() error) {
 	})
 }
 
+// SetLimit 在运行时修改最大并发数
+func (t *taskGroup) SetLimit(n int) {
+	t.limiter.setLimit(n)
+}
+
+// Limit 返回当前的最大并发数
+func (t *taskGroup) Limit() int {
+	return t.limiter.getLimit()
+}
+
+// handlePanic 调用 panic 回调函数，回调函数自身的 panic 只记录日志
+func (t *taskGroup) handlePanic(recovered interface{}, stack []byte) {
+	if t.panicHandler == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logs.CtxErrorf(t.ctx, "[TaskGroup] panic handler panic recover:%+v", r)
+		}
+	}()
+	t.panicHandler(t.ctx, recovered, stack)
+}
+
 // Wait 等待所有任务执行完成
 // 返回第一个遇到的错误（如果有），如果所有任务都成功完成则返回 nil
 func (t *taskGroup) Wait() error {
 	return t.errGroup.Wait()
 }
+
+// GoWithRetry 将失败时按策略重试的任务添加到任务组中异步执行
+// 重试等待期间任务仍占用一个并发名额，任务组的 context 被取消时停止重试
+func (t *taskGroup) GoWithRetry(f func(ctx context.Context, attempt int) error, policy RetryPolicy) {
+	backoff := policy.Backoff
+	if backoff == (retry.Backoff{}) {
+		backoff = retry.DefaultBackoff()
+	}
+
+	t.Go(func() error {
+		return retry.Do(t.ctx, backoff, func(ctx context.Context, attempt int) error {
+			err := f(ctx, attempt)
+			if err == nil {
+				return nil
+			}
+			if policy.RetryIf != nil && !policy.RetryIf(err) {
+				return retry.Permanent(err)
+			}
+			if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+				return retry.Permanent(err)
+			}
+			logs.CtxWarn(ctx, "[TaskGroup] 任务执行失败，准备重试", "attempt", attempt, "error", err.Error())
+			return err
+		})
+	})
+}
@@ -0,0 +1,161 @@
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"backend/utils/retry"
+)
+
+func TestGoWithRetry(t *testing.T) {
+	backoff := retry.Backoff{InitialInterval: time.Millisecond, Multiplier: 1, MaxElapsed: time.Second}
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+
+	t.Run("succeeds after retries", func(t *testing.T) {
+		var calls atomic.Int32
+		g := NewTaskGroup(context.Background(), 2)
+		g.GoWithRetry(func(ctx context.Context, attempt int) error {
+			calls.Add(1)
+			if attempt < 3 {
+				return errTransient
+			}
+			return nil
+		}, RetryPolicy{MaxAttempts: 5, Backoff: backoff})
+		if err := g.Wait(); err != nil {
+			t.Fatalf("expected success, got %v", err)
+		}
+		if calls.Load() != 3 {
+			t.Errorf("expected 3 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("stops at max attempts", func(t *testing.T) {
+		var calls atomic.Int32
+		g := NewTaskGroup(context.Background(), 2)
+		g.GoWithRetry(func(ctx context.Context, attempt int) error {
+			calls.Add(1)
+			return errTransient
+		}, RetryPolicy{MaxAttempts: 2, Backoff: backoff})
+		if err := g.Wait(); !errors.Is(err, errTransient) {
+			t.Fatalf("expected transient error, got %v", err)
+		}
+		if calls.Load() != 2 {
+			t.Errorf("expected 2 attempts, got %d", calls.Load())
+		}
+	})
+
+	t.Run("retry if rejects error", func(t *testing.T) {
+		var calls atomic.Int32
+		g := NewUninterruptibleTaskGroup(context.Background(), 2)
+		g.GoWithRetry(func(ctx context.Context, attempt int) error {
+			calls.Add(1)
+			return errFatal
+		}, RetryPolicy{MaxAttempts: 5, Backoff: backoff, RetryIf: func(err error) bool {
+			return !errors.Is(err, errFatal)
+		}})
+		if err := g.Wait(); !errors.Is(err, errFatal) {
+			t.Fatalf("expected fatal error, got %v", err)
+		}
+		if calls.Load() != 1 {
+			t.Errorf("expected 1 attempt, got %d", calls.Load())
+		}
+	})
+}
+
+func TestPanicPolicy(t *testing.T) {
+	t.Run("fail group", func(t *testing.T) {
+		before := PanicCount()
+		var handled atomic.Int32
+		g := NewTaskGroup(context.Background(), 2, WithPanicHandler(func(ctx context.Context, recovered interface{}, stack []byte) {
+			if recovered != "boom" || len(stack) == 0 {
+				t.Errorf("unexpected panic info: %v", recovered)
+			}
+			handled.Add(1)
+		}))
+		g.Go(func() error { panic("boom") })
+		if err := g.Wait(); err == nil {
+			t.Fatal("expected panic to fail the group")
+		}
+		if handled.Load() != 1 {
+			t.Errorf("expected panic handler to be called once, got %d", handled.Load())
+		}
+		if PanicCount()-before != 1 {
+			t.Errorf("expected panic count to increase by 1, got %d", PanicCount()-before)
+		}
+	})
+
+	t.Run("continue", func(t *testing.T) {
+		var done atomic.Int32
+		g := NewTaskGroup(context.Background(), 1, WithPanicPolicy(PanicContinue))
+		g.Go(func() error { panic("boom") })
+		g.Go(func() error {
+			done.Add(1)
+			return nil
+		})
+		if err := g.Wait(); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if done.Load() != 1 {
+			t.Error("expected remaining task to run")
+		}
+	})
+}
+
+func TestSetLimit(t *testing.T) {
+	g := NewTaskGroup(context.Background(), 1)
+	if g.Limit() != 1 {
+		t.Fatalf("expected limit 1, got %d", g.Limit())
+	}
+
+	// 运行中扩大并发数，两个任务需要同时执行才能完成
+	var running atomic.Int32
+	release := make(chan struct{})
+	task := func() error {
+		if running.Add(1) == 2 {
+			close(release)
+		}
+		<-release
+		return nil
+	}
+	g.Go(task)
+	g.SetLimit(2)
+	g.Go(task)
+
+	if err := g.Wait(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if g.Limit() != 2 {
+		t.Errorf("expected limit 2, got %d", g.Limit())
+	}
+}
+
+func TestAdaptiveLimit(t *testing.T) {
+	errTransient := errors.New("transient")
+	g := NewUninterruptibleTaskGroup(context.Background(), 8, WithAdaptiveLimit(AdaptiveConfig{
+		MinLimit:           2,
+		Window:             4,
+		ErrorRateThreshold: 0.5,
+	}))
+
+	// 全部失败：每个窗口并发数减半，直到最小并发数
+	for i := 0; i < 12; i++ {
+		g.Go(func() error { return errTransient })
+	}
+	_ = g.Wait()
+	if g.Limit() != 2 {
+		t.Fatalf("expected limit to shrink to 2, got %d", g.Limit())
+	}
+
+	// 全部成功：每个窗口并发数加一
+	for i := 0; i < 8; i++ {
+		g.Go(func() error { return nil })
+	}
+	_ = g.Wait()
+	if g.Limit() != 4 {
+		t.Errorf("expected limit to grow to 4, got %d", g.Limit())
+	}
+}
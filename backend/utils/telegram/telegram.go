@@ -0,0 +1,171 @@
+// Package telegram Telegram Bot API 的最小客户端，支持长轮询获取消息、发送消息和设置 webhook
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAPIURL Telegram Bot API 地址
+	DefaultAPIURL = "https://api.telegram.org"
+	// SecretTokenHeader webhook 请求中携带 setWebhook 时设置的 secret_token 的请求头
+	SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+)
+
+// Update 收到的更新，只解析消息
+type Update struct {
+	UpdateID int64    `json:"update_id"`
+	Message  *Message `json:"message,omitempty"`
+}
+
+// Message 消息
+type Message struct {
+	MessageID int64  `json:"message_id"`
+	From      *User  `json:"from,omitempty"`
+	Chat      Chat   `json:"chat"`
+	Date      int64  `json:"date"`
+	Text      string `json:"text,omitempty"`
+}
+
+// Chat 会话
+type Chat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"` // private、group、supergroup、channel
+}
+
+// User Telegram 用户
+type User struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username,omitempty"`
+	FirstName string `json:"first_name,omitempty"`
+}
+
+// APIError Bot API 返回的错误
+type APIError struct {
+	Method      string
+	Code        int
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("telegram %s 失败: %d %s", e.Method, e.Code, e.Description)
+}
+
+// Client Bot API 客户端
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// NewClient 创建客户端
+// token: 机器人令牌
+// apiURL: Bot API 地址，为空时使用 DefaultAPIURL
+// httpClient: 为空时使用 http.DefaultClient，长轮询时超时需要大于轮询等待时间
+func NewClient(token, apiURL string, httpClient *http.Client) *Client {
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL: strings.TrimRight(apiURL, "/") + "/bot" + token + "/",
+		http:    httpClient,
+	}
+}
+
+// GetUpdates 长轮询获取 offset 之后的消息，没有新消息时最多等待 timeout
+// 处理完成后以最后一个 UpdateID+1 作为下一次的 offset，Telegram 会丢弃之前的消息
+func (c *Client) GetUpdates(ctx context.Context, offset int64, timeout time.Duration) ([]Update, error) {
+	var updates []Update
+	err := c.call(ctx, "getUpdates", map[string]any{
+		"offset":          offset,
+		"timeout":         int(timeout / time.Second),
+		"allowed_updates": []string{"message"},
+	}, &updates)
+	return updates, err
+}
+
+// SendMessage 发送纯文本消息
+func (c *Client) SendMessage(ctx context.Context, chatID int64, text string) error {
+	return c.call(ctx, "sendMessage", map[string]any{
+		"chat_id": chatID,
+		"text":    text,
+	}, nil)
+}
+
+// SetWebhook 设置 webhook 地址，secretToken 不为空时 Telegram 会在 SecretTokenHeader 请求头中携带
+func (c *Client) SetWebhook(ctx context.Context, webhookURL, secretToken string) error {
+	params := map[string]any{
+		"url":             webhookURL,
+		"allowed_updates": []string{"message"},
+	}
+	if secretToken != "" {
+		params["secret_token"] = secretToken
+	}
+	return c.call(ctx, "setWebhook", params, nil)
+}
+
+// DeleteWebhook 删除 webhook，设置了 webhook 时 getUpdates 会返回错误
+func (c *Client) DeleteWebhook(ctx context.Context) error {
+	return c.call(ctx, "deleteWebhook", map[string]any{}, nil)
+}
+
+// response Bot API 响应
+type response struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+}
+
+// call 调用 Bot API 方法，result 为 nil 时忽略返回结果
+func (c *Client) call(ctx context.Context, method string, params any, result any) error {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+method, bytes.NewReader(body))
+	if err != nil {
+		return redact(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return redact(err)
+	}
+	defer resp.Body.Close()
+
+	var r response
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return fmt.Errorf("telegram %s 响应解析失败: status=%d, error=%w", method, resp.StatusCode, err)
+	}
+	if !r.OK {
+		return &APIError{Method: method, Code: r.ErrorCode, Description: r.Description}
+	}
+	if result != nil {
+		return json.Unmarshal(r.Result, result)
+	}
+	return nil
+}
+
+// redact 去掉错误中请求地址里的机器人令牌，避免写入日志
+func redact(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if i := strings.Index(urlErr.URL, "/bot"); i >= 0 {
+			method := urlErr.URL[strings.LastIndex(urlErr.URL, "/")+1:]
+			urlErr.URL = urlErr.URL[:i] + "/bot<token>/" + method
+		}
+	}
+	return err
+}
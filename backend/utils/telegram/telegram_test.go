@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClientGetUpdatesAndSendMessage 测试获取消息和发送消息
+func TestClientGetUpdatesAndSendMessage(t *testing.T) {
+	var sent map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch r.URL.Path {
+		case "/botTOKEN/getUpdates":
+			var params map[string]any
+			_ = json.Unmarshal(body, &params)
+			if params["offset"] != float64(5) || params["timeout"] != float64(30) {
+				t.Errorf("getUpdates 参数错误: %s", body)
+			}
+			io.WriteString(w, `{"ok":true,"result":[{"update_id":5,"message":{"message_id":1,"chat":{"id":42,"type":"private"},"date":1,"text":"买菜"}}]}`)
+		case "/botTOKEN/sendMessage":
+			_ = json.Unmarshal(body, &sent)
+			io.WriteString(w, `{"ok":true,"result":{}}`)
+		default:
+			t.Errorf("未知路径: %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("TOKEN", srv.URL+"/", nil)
+	updates, err := c.GetUpdates(context.Background(), 5, 30*time.Second)
+	if err != nil {
+		t.Fatalf("获取消息失败: %v", err)
+	}
+	if len(updates) != 1 || updates[0].Message == nil || updates[0].Message.Chat.ID != 42 || updates[0].Message.Text != "买菜" {
+		t.Fatalf("消息解析错误: %+v", updates)
+	}
+
+	if err := c.SendMessage(context.Background(), 42, "好的"); err != nil {
+		t.Fatalf("发送消息失败: %v", err)
+	}
+	if sent["chat_id"] != float64(42) || sent["text"] != "好的" {
+		t.Fatalf("发送参数错误: %v", sent)
+	}
+}
+
+// TestClientAPIError 测试 Bot API 返回错误
+func TestClientAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		io.WriteString(w, `{"ok":false,"error_code":409,"description":"Conflict: can't use getUpdates method while webhook is active"}`)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient("TOKEN", srv.URL, nil).GetUpdates(context.Background(), 0, 0)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != 409 || apiErr.Method != "getUpdates" {
+		t.Fatalf("expected APIError 409, got %v", err)
+	}
+}
+
+// TestClientRedactToken 测试网络错误中不包含机器人令牌
+func TestClientRedactToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	srv.Close()
+
+	err := NewClient("SECRET", srv.URL, nil).SendMessage(context.Background(), 1, "x")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), "SECRET") {
+		t.Fatalf("错误中包含令牌: %v", err)
+	}
+	if !strings.Contains(err.Error(), "/bot<token>/sendMessage") {
+		t.Fatalf("错误中缺少方法名: %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"backend/utils/logs"
+	"backend/utils/trace"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName 本服务创建 span 使用的 instrumentation scope
+const instrumentationName = "backend"
+
+// Config OpenTelemetry 配置
+type Config struct {
+	Endpoint    string            // OTLP/HTTP 地址，如 http://localhost:4318（Tempo、Jaeger、OpenTelemetry Collector）
+	Headers     map[string]string // 导出请求附带的请求头，如认证信息
+	ServiceName string            // 服务名称，对应资源属性 service.name
+	SampleRatio float64           // 新链路的采样比例（0-1），上游已采样的链路始终采样
+}
+
+// Setup 创建 TracerProvider 并接入 trace 和 logs
+// trace.StartSpan 创建真实的 span 并通过 OTLP 导出，Ctx* 日志自动附带当前 span 的 trace_id 和 span_id，
+// 同时设置全局的 TracerProvider 和 W3C 传播器，第三方的 OpenTelemetry 插桩与本服务的 span 处于同一条链路
+// 返回的 shutdown 在服务停止时调用，导出剩余的 span 并恢复默认实现
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("telemetry: endpoint is required")
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("telemetry: sample ratio must be between 0 and 1: %v", cfg.SampleRatio)
+	}
+
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(cfg.Endpoint),
+		otlptracehttp.WithHeaders(cfg.Headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: create otlp exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: create resource: %w", err)
+	}
+
+	// 本服务生成 trace_id 的链路作为未采样的远端父 span 传入，按比例决定是否采样
+	ratio := sdktrace.TraceIDRatioBased(cfg.SampleRatio)
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(ratio, sdktrace.WithRemoteParentNotSampled(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	trace.SetTracer(&tracer{tracer: provider.Tracer(instrumentationName)})
+	logs.SetSpanContextFunc(spanContext)
+
+	return func(ctx context.Context) error {
+		trace.SetTracer(nil)
+		logs.SetSpanContextFunc(nil)
+		return provider.Shutdown(ctx)
+	}, nil
+}
+
+// tracer 使用 OpenTelemetry 创建 span 的 trace.Tracer
+type tracer struct {
+	tracer oteltrace.Tracer
+}
+
+// Start 创建子 span，并把新 span 的 trace_id、span_id 写入 context，TraceID、Inject 和日志读取到的链路与 span 一致
+// context 中还没有 OpenTelemetry 的 span 时，以上游传入或本服务生成的 trace_id 作为远端父 span
+func (t *tracer) Start(ctx context.Context, name string) (context.Context, trace.Span) {
+	if !oteltrace.SpanContextFromContext(ctx).IsValid() {
+		if parent, ok := remoteParent(ctx); ok {
+			ctx = oteltrace.ContextWithRemoteSpanContext(ctx, parent)
+		}
+	}
+	parentSpanID, _ := ctx.Value(logs.SpanIDContextKey).(string)
+
+	ctx, span := t.tracer.Start(ctx, name)
+	sc := span.SpanContext()
+	ctx = context.WithValue(ctx, logs.TraceIDContextKey, sc.TraceID().String())
+	ctx = context.WithValue(ctx, logs.SpanIDContextKey, sc.SpanID().String())
+	if parentSpanID != "" {
+		ctx = context.WithValue(ctx, logs.ParentSpanIDContextKey, parentSpanID)
+	}
+	return ctx, otelSpan{span: span}
+}
+
+// remoteParent 将 context 中的链路转换为 OpenTelemetry 的远端 span
+func remoteParent(ctx context.Context) (oteltrace.SpanContext, bool) {
+	traceID, spanID, flags, ok := trace.SpanContext(ctx)
+	if !ok {
+		return oteltrace.SpanContext{}, false
+	}
+	tid, err := oteltrace.TraceIDFromHex(traceID)
+	if err != nil {
+		return oteltrace.SpanContext{}, false
+	}
+	sid, err := oteltrace.SpanIDFromHex(spanID)
+	if err != nil {
+		return oteltrace.SpanContext{}, false
+	}
+	var traceFlags oteltrace.TraceFlags
+	if b, err := hex.DecodeString(flags); err == nil && len(b) == 1 {
+		traceFlags = oteltrace.TraceFlags(b[0])
+	}
+	sc := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: traceFlags,
+		Remote:     true,
+	})
+	return sc, sc.IsValid()
+}
+
+// spanContext 读取 context 中 OpenTelemetry span 的 trace_id 和 span_id，用于日志字段
+func spanContext(ctx context.Context) (traceID, spanID string, ok bool) {
+	sc := oteltrace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return "", "", false
+	}
+	return sc.TraceID().String(), sc.SpanID().String(), true
+}
+
+// otelSpan OpenTelemetry 的 span
+type otelSpan struct {
+	span oteltrace.Span
+}
+
+func (s otelSpan) SetAttributes(keyvals ...interface{}) {
+	s.span.SetAttributes(attributes(keyvals)...)
+}
+
+func (s otelSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+	s.span.End()
+}
+
+// attributes 将成对的 key-value 转换为 span 属性，key 不是字符串的键值对跳过
+func attributes(keyvals []interface{}) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(keyvals)/2)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			continue
+		}
+		switch value := keyvals[i+1].(type) {
+		case string:
+			attrs = append(attrs, attribute.String(key, value))
+		case bool:
+			attrs = append(attrs, attribute.Bool(key, value))
+		case int:
+			attrs = append(attrs, attribute.Int(key, value))
+		case int64:
+			attrs = append(attrs, attribute.Int64(key, value))
+		case float64:
+			attrs = append(attrs, attribute.Float64(key, value))
+		default:
+			attrs = append(attrs, attribute.String(key, fmt.Sprint(value)))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,111 @@
+package timex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRelativeDays last-N-days 中 N 的最大值
+const maxRelativeDays = 3660
+
+// nowFunc 当前时间，测试时可替换
+var nowFunc = time.Now
+
+// RelativeRange 解析相对日期 token，返回 now 所在时区下范围内第一天和最后一天的零点
+// 支持的 token：
+// - today、yesterday
+// - this-week、last-week（周一为一周的第一天）
+// - this-month、last-month、this-year、last-year
+// - last-N-days（包含今天在内的最近 N 天，如 last-7-days）
+func RelativeRange(token string, now time.Time) (start, end time.Time, ok bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch token {
+	case "today":
+		return today, today, true
+	case "yesterday":
+		yesterday := today.AddDate(0, 0, -1)
+		return yesterday, yesterday, true
+	case "this-week", "last-week":
+		// time.Sunday 为 0，转换为周一为 0
+		monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		if token == "last-week" {
+			monday = monday.AddDate(0, 0, -7)
+		}
+		return monday, monday.AddDate(0, 0, 6), true
+	case "this-month", "last-month":
+		first := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		if token == "last-month" {
+			first = first.AddDate(0, -1, 0)
+		}
+		return first, first.AddDate(0, 1, -1), true
+	case "this-year", "last-year":
+		first := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		if token == "last-year" {
+			first = first.AddDate(-1, 0, 0)
+		}
+		return first, first.AddDate(1, 0, -1), true
+	}
+
+	// last-N-days
+	if n, found := strings.CutPrefix(token, "last-"); found {
+		if n, found = strings.CutSuffix(n, "-days"); found {
+			days, err := strconv.Atoi(n)
+			if err == nil && days >= 1 && days <= maxRelativeDays {
+				return today.AddDate(0, 0, -(days - 1)), today, true
+			}
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// IsRelativeDate 判断是否是支持的相对日期 token
+func IsRelativeDate(token string) bool {
+	_, _, ok := RelativeRange(token, time.Now())
+	return ok
+}
+
+// ParseDateInLocation 按指定时区解析日期字符串，格式与 ParseDateString 相同
+// RFC3339 格式自带时区，不受 loc 影响
+func ParseDateInLocation(dateStr string, loc *time.Location) (time.Time, error) {
+	if dateStr == "" {
+		return time.Time{}, fmt.Errorf("日期字符串不能为空")
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	for _, format := range dateFormats {
+		if t, err := time.ParseInLocation(format, dateStr, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("无法解析日期字符串: %s，支持的格式: YYYY-MM-DD, YYYY-MM-DD HH:MM:SS, RFC3339 或 today、last-7-days 等相对日期", dateStr)
+}
+
+// ParseDateStart 解析开始日期，相对日期取范围内第一天的零点
+func ParseDateStart(dateStr string, loc *time.Location) (time.Time, error) {
+	return parseRelativeOrAbsolute(dateStr, loc, false)
+}
+
+// ParseDateEnd 解析结束日期，相对日期取范围内最后一天的零点（与 YYYY-MM-DD 格式的含义一致）
+func ParseDateEnd(dateStr string, loc *time.Location) (time.Time, error) {
+	return parseRelativeOrAbsolute(dateStr, loc, true)
+}
+
+// parseRelativeOrAbsolute 优先按相对日期解析，否则按绝对日期解析
+func parseRelativeOrAbsolute(dateStr string, loc *time.Location, end bool) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if start, last, ok := RelativeRange(dateStr, nowFunc().In(loc)); ok {
+		if end {
+			return last, nil
+		}
+		return start, nil
+	}
+	return ParseDateInLocation(dateStr, loc)
+}
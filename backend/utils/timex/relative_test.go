@@ -0,0 +1,72 @@
+package timex
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeRange(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	// 2025-03-13 为周四
+	now := time.Date(2025, 3, 13, 1, 30, 0, 0, loc)
+
+	tests := []struct {
+		token string
+		start string
+		end   string
+	}{
+		{"today", "2025-03-13", "2025-03-13"},
+		{"yesterday", "2025-03-12", "2025-03-12"},
+		{"this-week", "2025-03-10", "2025-03-16"},
+		{"last-week", "2025-03-03", "2025-03-09"},
+		{"this-month", "2025-03-01", "2025-03-31"},
+		{"last-month", "2025-02-01", "2025-02-28"},
+		{"this-year", "2025-01-01", "2025-12-31"},
+		{"last-year", "2024-01-01", "2024-12-31"},
+		{"last-7-days", "2025-03-07", "2025-03-13"},
+		{"last-1-days", "2025-03-13", "2025-03-13"},
+	}
+	for _, tt := range tests {
+		start, end, ok := RelativeRange(tt.token, now)
+		if !ok {
+			t.Errorf("%s: expected token to be supported", tt.token)
+			continue
+		}
+		if FormatDateString(start) != tt.start || FormatDateString(end) != tt.end {
+			t.Errorf("%s: expected %s ~ %s, got %s ~ %s", tt.token, tt.start, tt.end, FormatDateString(start), FormatDateString(end))
+		}
+		if start.Location() != loc || start.Hour() != 0 {
+			t.Errorf("%s: expected midnight in %s, got %s", tt.token, loc, start)
+		}
+	}
+
+	for _, token := range []string{"", "tomorrow", "last-0-days", "last-x-days", "last-99999-days"} {
+		if _, _, ok := RelativeRange(token, now); ok {
+			t.Errorf("%q: expected token to be rejected", token)
+		}
+	}
+}
+
+func TestParseDateStartEnd(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	nowFunc = func() time.Time { return time.Date(2025, 3, 12, 20, 0, 0, 0, time.UTC) }
+	defer func() { nowFunc = time.Now }()
+
+	// UTC 20:00 在 UTC+8 已经是 3 月 13 日
+	start, err := ParseDateStart("last-7-days", loc)
+	if err != nil || FormatDateString(start) != "2025-03-07" {
+		t.Errorf("expected 2025-03-07, got %s (%v)", start, err)
+	}
+	end, err := ParseDateEnd("last-7-days", loc)
+	if err != nil || FormatDateString(end) != "2025-03-13" {
+		t.Errorf("expected 2025-03-13, got %s (%v)", end, err)
+	}
+
+	abs, err := ParseDateStart("2025-01-02", loc)
+	if err != nil || !abs.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, loc)) {
+		t.Errorf("expected absolute date in location, got %s (%v)", abs, err)
+	}
+	if _, err := ParseDateEnd("next-week", loc); err == nil {
+		t.Error("expected error for unsupported token")
+	}
+}
@@ -5,6 +5,14 @@ import (
 	"time"
 )
 
+// dateFormats 支持的日期格式
+var dateFormats = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	time.RFC3339Nano,
+}
+
 // ParseDateString 解析日期字符串为 time.Time
 // 支持的格式：
 // - "2006-01-02" (YYYY-MM-DD)
@@ -17,14 +25,7 @@ func ParseDateString(dateStr string) (time.Time, error) {
 	}
 
 	// 尝试多种日期格式
-	formats := []string{
-		"2006-01-02",
-		"2006-01-02 15:04:05",
-		time.RFC3339,
-		time.RFC3339Nano,
-	}
-
-	for _, format := range formats {
+	for _, format := range dateFormats {
 		if t, err := time.Parse(format, dateStr); err == nil {
 			return t, nil
 		}
@@ -0,0 +1,66 @@
+package trace
+
+import (
+	"context"
+	"sync/atomic"
+
+	"backend/utils/logs"
+)
+
+// Span 一次操作的 span，由 StartSpan 创建
+type Span interface {
+	// SetAttributes 设置 span 的属性，keyvals 为成对的 key-value
+	SetAttributes(keyvals ...interface{})
+	// End 结束 span，err 不为 nil 时标记为失败
+	End(err error)
+}
+
+// Tracer 创建 span 的实现
+// 返回的 context 需要同时写入 trace_id、span_id 和 parent_span_id（logs 的 context key），
+// 保证 TraceID、Inject 和日志读取到的链路与 span 一致
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// tracerHolder atomic.Value 要求每次存入的类型相同
+type tracerHolder struct {
+	tracer Tracer
+}
+
+var tracer atomic.Value
+
+// SetTracer 设置创建 span 的实现，接入 OpenTelemetry 时由 telemetry.Setup 设置，为 nil 时恢复默认实现
+func SetTracer(t Tracer) {
+	tracer.Store(tracerHolder{tracer: t})
+}
+
+// StartSpan 创建当前 span 的子 span，返回的 context 中 span_id 为新 span，parent_span_id 为当前 span
+// 默认只生成 span_id 写入 context（与 InjectSpan 相同），通过 SetTracer 接入 OpenTelemetry 后创建真实的 span 并导出
+func StartSpan(ctx context.Context, name string) (context.Context, Span) {
+	if holder, ok := tracer.Load().(tracerHolder); ok && holder.tracer != nil {
+		return holder.tracer.Start(ctx, name)
+	}
+	return InjectSpan(ctx), noopSpan{}
+}
+
+// SpanContext 返回 context 中符合 W3C 格式的 trace_id、span_id 和 trace-flags，没有 trace_id 时返回 ok=false
+// 用于把上游传入或本服务生成的链路转换为 OpenTelemetry 的远端父 span
+func SpanContext(ctx context.Context) (traceID, spanID, flags string, ok bool) {
+	id := TraceID(ctx)
+	if id == "" {
+		return "", "", "", false
+	}
+	span, _ := ctx.Value(logs.SpanIDContextKey).(string)
+	flags, _ = ctx.Value(traceFlagsKey).(string)
+	if flags == "" {
+		flags = defaultTraceFlags
+	}
+	return w3cID(id, 32), w3cID(span, 16), flags, true
+}
+
+// noopSpan 默认实现的 span，不记录任何数据
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(keyvals ...interface{}) {}
+
+func (noopSpan) End(err error) {}
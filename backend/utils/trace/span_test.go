@@ -0,0 +1,67 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/utils/logs"
+)
+
+// recordTracer 记录创建和结束的 span
+type recordTracer struct {
+	started []string
+	ended   []error
+}
+
+func (t *recordTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.started = append(t.started, name)
+	return InjectSpan(ctx), recordSpan{t}
+}
+
+type recordSpan struct{ t *recordTracer }
+
+func (s recordSpan) SetAttributes(keyvals ...interface{}) {}
+
+func (s recordSpan) End(err error) { s.t.ended = append(s.t.ended, err) }
+
+func TestStartSpan(t *testing.T) {
+	ctx := context.WithValue(InjectTraceID(context.Background()), logs.SpanIDContextKey, "00f067aa0ba902b7")
+
+	// 默认只生成 span_id，当前 span 成为 parent_span_id
+	child, span := StartSpan(ctx, "GET /api/items")
+	span.End(nil)
+	spanID, _ := child.Value(logs.SpanIDContextKey).(string)
+	if parent, _ := child.Value(logs.ParentSpanIDContextKey).(string); parent != "00f067aa0ba902b7" || !isHex(spanID, 16) {
+		t.Fatalf("unexpected span: span_id=%s, parent_span_id=%s", spanID, parent)
+	}
+
+	// 设置 Tracer 后由 Tracer 创建 span
+	recorder := &recordTracer{}
+	SetTracer(recorder)
+	defer SetTracer(nil)
+	_, span = StartSpan(ctx, "query")
+	span.End(errors.New("timeout"))
+	if len(recorder.started) != 1 || recorder.started[0] != "query" || len(recorder.ended) != 1 || recorder.ended[0] == nil {
+		t.Fatalf("unexpected tracer calls: %+v", recorder)
+	}
+}
+
+func TestSpanContext(t *testing.T) {
+	if _, _, _, ok := SpanContext(context.Background()); ok {
+		t.Fatal("expected no span context without trace_id")
+	}
+
+	ctx, _ := ContinueTrace(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", "")
+	traceID, spanID, flags, ok := SpanContext(ctx)
+	if !ok || traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || spanID != "00f067aa0ba902b7" || flags != "00" {
+		t.Fatalf("unexpected span context: %s %s %s %v", traceID, spanID, flags, ok)
+	}
+
+	// 非 W3C 格式的 trace_id 转换为哈希值
+	ctx = context.WithValue(context.Background(), logs.TraceIDContextKey, "legacy-trace")
+	traceID, _, flags, _ = SpanContext(ctx)
+	if !isHex(traceID, 32) || flags != defaultTraceFlags {
+		t.Fatalf("unexpected span context: %s %s", traceID, flags)
+	}
+}
@@ -7,6 +7,16 @@ import (
 	"backend/utils/rand"
 )
 
+const (
+	// TraceIDHeader 链路追踪 ID 请求/响应头
+	TraceIDHeader = "X-Trace-ID"
+	// RequestIDHeader 请求 ID 请求/响应头
+	RequestIDHeader = "X-Request-ID"
+
+	// MaxTraceIDLength 上游传入的 trace_id 的最大长度
+	MaxTraceIDLength = 128
+)
+
 func InjectTraceID(ctx context.Context) context.Context {
 	tace_id := rand.GenTraceID()
 	return context.WithValue(ctx, logs.TraceIDContextKey, tace_id)
@@ -31,3 +41,39 @@ func InjectSpan(ctx context.Context) context.Context {
 
 	return ctx
 }
+
+// InjectRequestID 设置请求 ID，requestID 为空时生成新的请求 ID
+func InjectRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		requestID = rand.GenRequestID()
+	}
+	return context.WithValue(ctx, logs.RequestIDContextKey, requestID)
+}
+
+// ValidTraceID 上游通过 X-Trace-ID 传入的 trace_id 是否可以沿用
+// trace_id 会写入日志和响应头，只接受不超过 MaxTraceIDLength 个字符的字母、数字、- 和 _
+func ValidTraceID(traceID string) bool {
+	if traceID == "" || len(traceID) > MaxTraceIDLength {
+		return false
+	}
+	for _, r := range traceID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// TraceID 返回 context 中的 trace_id，不存在时返回空字符串
+func TraceID(ctx context.Context) string {
+	traceID, _ := ctx.Value(logs.TraceIDContextKey).(string)
+	return traceID
+}
+
+// RequestID 返回 context 中的 request_id，不存在时返回空字符串
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(logs.RequestIDContextKey).(string)
+	return requestID
+}
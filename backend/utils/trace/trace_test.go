@@ -0,0 +1,33 @@
+package trace
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidTraceID(t *testing.T) {
+	valid := []string{
+		"4bf92f3577b34da6a3ce929d0e0e4736",
+		"req-abc_DEF-123",
+		strings.Repeat("a", MaxTraceIDLength),
+	}
+	for _, value := range valid {
+		if !ValidTraceID(value) {
+			t.Errorf("%q: expected valid trace_id", value)
+		}
+	}
+
+	invalid := []string{
+		"",
+		strings.Repeat("a", MaxTraceIDLength+1),
+		"abc def",
+		"abc\nforged=1",
+		"abc\"",
+		"链路",
+	}
+	for _, value := range invalid {
+		if ValidTraceID(value) {
+			t.Errorf("%q: expected invalid trace_id", value)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package trace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"backend/utils/logs"
+	"backend/utils/rand"
+)
+
+// W3C Trace Context 请求头（https://www.w3.org/TR/trace-context/）
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+
+	// traceparentVersion 支持的 traceparent 版本
+	traceparentVersion = "00"
+	// defaultTraceFlags 本服务发起的链路默认采样
+	defaultTraceFlags = "01"
+	// maxTracestateLength tracestate 的最大长度（规范要求至少支持 512 字符）
+	maxTracestateLength = 512
+)
+
+// w3cKey W3C Trace Context 在 context 中的 key
+type w3cKey string
+
+const (
+	traceFlagsKey w3cKey = "trace_flags"
+	traceStateKey w3cKey = "trace_state"
+)
+
+// ParseTraceparent 解析 traceparent 请求头，格式: {version}-{trace-id}-{parent-id}-{trace-flags}
+// 不支持的版本、格式错误或全零 ID 时返回 ok=false
+func ParseTraceparent(value string) (traceID, parentID, flags string, ok bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return "", "", "", false
+	}
+	// 00 版本只能有 4 段，更高版本允许在末尾追加字段
+	if parts[0] == traceparentVersion && len(parts) != 4 {
+		return "", "", "", false
+	}
+	if !isHex(parts[0], 2) || parts[0] == "ff" {
+		return "", "", "", false
+	}
+	if !isHex(parts[1], 32) || !isHex(parts[2], 16) || !isHex(parts[3], 2) {
+		return "", "", "", false
+	}
+	if isZero(parts[1]) || isZero(parts[2]) {
+		return "", "", "", false
+	}
+	return parts[1], parts[2], parts[3], true
+}
+
+// ContinueTrace 沿用上游的 traceparent/tracestate，继续远端的链路
+// 上游的 parent-id 作为当前 span，调用 InjectSpan 后成为新 span 的 parent_span_id
+func ContinueTrace(ctx context.Context, traceparent, tracestate string) (context.Context, bool) {
+	traceID, parentID, flags, ok := ParseTraceparent(traceparent)
+	if !ok {
+		return ctx, false
+	}
+	ctx = context.WithValue(ctx, logs.TraceIDContextKey, traceID)
+	ctx = context.WithValue(ctx, logs.SpanIDContextKey, parentID)
+	ctx = context.WithValue(ctx, traceFlagsKey, flags)
+	if tracestate != "" && len(tracestate) <= maxTracestateLength {
+		ctx = context.WithValue(ctx, traceStateKey, tracestate)
+	}
+	return ctx, true
+}
+
+// Inject 将 context 中的链路信息写入出站请求头（traceparent、tracestate）
+// context 中没有 trace_id 时不写入；trace_id/span_id 不符合 W3C 格式时（如上游通过 X-Trace-ID 传入）使用其哈希值
+func Inject(ctx context.Context, header http.Header) {
+	traceID, spanID, flags, ok := SpanContext(ctx)
+	if !ok {
+		return
+	}
+
+	header.Set(TraceparentHeader, traceparentVersion+"-"+traceID+"-"+spanID+"-"+flags)
+	if state, ok := ctx.Value(traceStateKey).(string); ok && state != "" {
+		header.Set(TracestateHeader, state)
+	}
+}
+
+// Transport 返回在出站请求中注入 traceparent/tracestate 的 http.RoundTripper
+// base 为 nil 时使用 http.DefaultTransport
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base}
+}
+
+// transport 注入链路信息的 http.RoundTripper
+type transport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip 复制请求后注入链路信息（RoundTripper 不能修改原始请求）
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if TraceID(req.Context()) == "" {
+		return t.base.RoundTrip(req)
+	}
+	clone := req.Clone(req.Context())
+	Inject(req.Context(), clone.Header)
+	return t.base.RoundTrip(clone)
+}
+
+// w3cID 返回符合 W3C 格式的 ID，不符合时取 sha256 的前 size 位十六进制
+func w3cID(id string, size int) string {
+	if isHex(id, size) && !isZero(id) {
+		return id
+	}
+	if id == "" && size == 16 {
+		return rand.GenSpanID()
+	}
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])[:size]
+}
+
+// isHex 判断是否是指定长度的小写十六进制字符串
+func isHex(s string, size int) bool {
+	if len(s) != size {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isZero 判断 ID 是否全为 0
+func isZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,91 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"backend/utils/logs"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	traceID, parentID, flags, ok := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok || traceID != "4bf92f3577b34da6a3ce929d0e0e4736" || parentID != "00f067aa0ba902b7" || flags != "01" {
+		t.Errorf("unexpected result: %s %s %s %v", traceID, parentID, flags, ok)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+	}
+	for _, value := range invalid {
+		if _, _, _, ok := ParseTraceparent(value); ok {
+			t.Errorf("%q: expected invalid traceparent", value)
+		}
+	}
+
+	// 更高版本允许追加字段
+	if _, _, _, ok := ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"); !ok {
+		t.Error("expected future version with extra fields to be accepted")
+	}
+}
+
+func TestContinueTraceAndInject(t *testing.T) {
+	ctx, ok := ContinueTrace(context.Background(),
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", "vendor=abc")
+	if !ok {
+		t.Fatal("expected trace to be continued")
+	}
+	ctx = InjectSpan(ctx)
+	if parent, _ := ctx.Value(logs.ParentSpanIDContextKey).(string); parent != "00f067aa0ba902b7" {
+		t.Errorf("expected remote parent span, got %q", parent)
+	}
+	spanID, _ := ctx.Value(logs.SpanIDContextKey).(string)
+
+	header := http.Header{}
+	Inject(ctx, header)
+	expected := "00-4bf92f3577b34da6a3ce929d0e0e4736-" + spanID + "-00"
+	if got := header.Get(TraceparentHeader); got != expected {
+		t.Errorf("expected traceparent %q, got %q", expected, got)
+	}
+	if got := header.Get(TracestateHeader); got != "vendor=abc" {
+		t.Errorf("expected tracestate to be propagated, got %q", got)
+	}
+
+	// 非 W3C 格式的 trace_id（如 X-Trace-ID 传入）使用哈希值
+	header = http.Header{}
+	Inject(context.WithValue(context.Background(), logs.TraceIDContextKey, "legacy-trace"), header)
+	if _, _, _, ok := ParseTraceparent(header.Get(TraceparentHeader)); !ok {
+		t.Errorf("expected valid traceparent, got %q", header.Get(TraceparentHeader))
+	}
+}
+
+func TestTransport(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(TraceparentHeader)
+	}))
+	defer server.Close()
+
+	ctx := InjectSpan(InjectTraceID(context.Background()))
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	resp, err := (&http.Client{Transport: Transport(nil)}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if !strings.HasPrefix(received, "00-"+TraceID(ctx)+"-") {
+		t.Errorf("expected traceparent with trace id %s, got %q", TraceID(ctx), received)
+	}
+	if req.Header.Get(TraceparentHeader) != "" {
+		t.Error("expected original request to be left untouched")
+	}
+}
@@ -0,0 +1,109 @@
+// Package webhook 提供 webhook 请求签名、验签和只允许访问公网地址的 HTTP 客户端
+//
+// 签名算法: HMAC-SHA256(secret, "<timestamp>.<body>")，十六进制编码后以 "sha256=" 为前缀放在 X-Peano-Signature 请求头中，
+// timestamp 为 Unix 秒，放在 X-Peano-Timestamp 请求头中，接收方应拒绝时间偏差过大的请求以防重放
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+const (
+	// SignatureHeader 签名请求头
+	SignatureHeader = "X-Peano-Signature"
+	// TimestampHeader 签名时间戳请求头（Unix 秒）
+	TimestampHeader = "X-Peano-Timestamp"
+	// EventHeader 事件类型请求头
+	EventHeader = "X-Peano-Event"
+	// DeliveryHeader 投递ID请求头，同一事件重试时保持不变，可用于去重
+	DeliveryHeader = "X-Peano-Delivery"
+
+	// signaturePrefix 签名值前缀，标识签名算法
+	signaturePrefix = "sha256="
+	// secretPrefix 生成的签名密钥前缀
+	secretPrefix = "whsec_"
+)
+
+// ErrPrivateAddress 目标地址不是公网地址
+var ErrPrivateAddress = errors.New("不允许访问内网地址")
+
+// Sign 计算请求签名
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify 校验请求签名，timestamp 与当前时间的偏差超过 tolerance 时校验失败（tolerance 为 0 时不校验时间）
+func Verify(secret string, signature string, timestamp int64, body []byte, tolerance time.Duration) bool {
+	if tolerance > 0 {
+		skew := time.Since(time.Unix(timestamp, 0))
+		if skew < -tolerance || skew > tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(secret, timestamp, body)))
+}
+
+// GenerateSecret 生成随机签名密钥
+func GenerateSecret() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// NewClient 创建投递 webhook 使用的 HTTP 客户端
+// allowPrivate 为 false 时拒绝连接回环、内网、链路本地等非公网地址（在建立连接时检查解析后的 IP，DNS 重绑定也无法绕过），
+// 且不跟随重定向
+func NewClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// IsPublicIP 判断是否为公网地址
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSignAndVerify 测试签名和验签
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"event":"item.created"}`)
+	now := time.Now().Unix()
+
+	signature := Sign("secret", now, body)
+	if !strings.HasPrefix(signature, "sha256=") || len(signature) != len("sha256=")+64 {
+		t.Fatalf("unexpected signature format: %s", signature)
+	}
+	if !Verify("secret", signature, now, body, time.Minute) {
+		t.Error("valid signature should verify")
+	}
+	if Verify("other", signature, now, body, time.Minute) {
+		t.Error("signature with wrong secret should not verify")
+	}
+	if Verify("secret", signature, now, []byte(`{"event":"tag.deleted"}`), time.Minute) {
+		t.Error("signature of different body should not verify")
+	}
+	old := now - 3600
+	if Verify("secret", Sign("secret", old, body), old, body, 5*time.Minute) {
+		t.Error("expired timestamp should not verify")
+	}
+	if !Verify("secret", Sign("secret", old, body), old, body, 0) {
+		t.Error("zero tolerance should skip timestamp check")
+	}
+}
+
+// TestGenerateSecret 测试生成的密钥带前缀且不重复
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := GenerateSecret()
+	if !strings.HasPrefix(a, "whsec_") || a == b {
+		t.Errorf("unexpected secrets: %s, %s", a, b)
+	}
+}
+
+// TestIsPublicIP 测试公网地址判断
+func TestIsPublicIP(t *testing.T) {
+	cases := map[string]bool{
+		"8.8.8.8":         true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"10.1.2.3":        false,
+		"172.16.0.1":      false,
+		"192.168.1.1":     false,
+		"169.254.169.254": false,
+		"::1":             false,
+		"fd00::1":         false,
+		"0.0.0.0":         false,
+	}
+	for addr, want := range cases {
+		if got := IsPublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+// TestClientRejectsPrivateAddress 测试默认客户端拒绝连接内网地址
+func TestClientRejectsPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(time.Second, false).Get(server.URL)
+	if !errors.Is(err, ErrPrivateAddress) {
+		t.Errorf("expected ErrPrivateAddress, got %v", err)
+	}
+
+	resp, err := NewClient(time.Second, true).Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+}
@@ -320,7 +320,7 @@ export default function Archive() {
         ]);
 
         if (tagsRes) {
-          setTags(tagsRes.tags || []);
+          setTags(tagsRes.items || []);
         }
 
         if (reset) {
@@ -946,7 +946,7 @@ export default function Home() {
       ]);
 
       setItems(itemsRes.items || []);
-      setTags(tagsRes.tags || []);
+      setTags(tagsRes.items || []);
     } catch (error) {
       toast.error("加载数据失败");
     } finally {
@@ -39,5 +39,5 @@ export interface GetTagListResponse {
   page_size: number
   total: number
   total_pages: number
-  tags: TagDTO[]
+  items: TagDTO[]
 }
@@ -73,6 +73,11 @@ http.interceptors.request.use(
     if (token && config.headers) {
       config.headers.Authorization = `Bearer ${token}`;
     }
+    // 发送客户端时区，服务端按该时区解析 today、last-7-days 等相对日期
+    const timezone = Intl.DateTimeFormat().resolvedOptions().timeZone;
+    if (timezone && config.headers) {
+      config.headers["X-Timezone"] = timezone;
+    }
     return config;
   },
   (error: AxiosError) => {